@@ -22,11 +22,17 @@ const (
 )
 
 type CallbackMetadataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Engine        *string                `protobuf:"bytes,1,opt,name=engine,proto3,oneof" json:"engine,omitempty"`
-	Url           *string                `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Engine          *string                `protobuf:"bytes,1,opt,name=engine,proto3,oneof" json:"engine,omitempty"`
+	Url             *string                `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	LlmProvider     *string                `protobuf:"bytes,3,opt,name=llm_provider,json=llmProvider,proto3,oneof" json:"llm_provider,omitempty"`
+	LlmInputTokens  *int64                 `protobuf:"varint,4,opt,name=llm_input_tokens,json=llmInputTokens,proto3,oneof" json:"llm_input_tokens,omitempty"`
+	LlmOutputTokens *int64                 `protobuf:"varint,5,opt,name=llm_output_tokens,json=llmOutputTokens,proto3,oneof" json:"llm_output_tokens,omitempty"`
+	LlmCostUsd      *float64               `protobuf:"fixed64,6,opt,name=llm_cost_usd,json=llmCostUsd,proto3,oneof" json:"llm_cost_usd,omitempty"`
+	BlockType       *string                `protobuf:"bytes,7,opt,name=block_type,json=blockType,proto3,oneof" json:"block_type,omitempty"`
+	BlockRetryable  *bool                  `protobuf:"varint,8,opt,name=block_retryable,json=blockRetryable,proto3,oneof" json:"block_retryable,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CallbackMetadataRequest) Reset() {
@@ -73,6 +79,48 @@ func (x *CallbackMetadataRequest) GetUrl() string {
 	return ""
 }
 
+func (x *CallbackMetadataRequest) GetLlmProvider() string {
+	if x != nil && x.LlmProvider != nil {
+		return *x.LlmProvider
+	}
+	return ""
+}
+
+func (x *CallbackMetadataRequest) GetLlmInputTokens() int64 {
+	if x != nil && x.LlmInputTokens != nil {
+		return *x.LlmInputTokens
+	}
+	return 0
+}
+
+func (x *CallbackMetadataRequest) GetLlmOutputTokens() int64 {
+	if x != nil && x.LlmOutputTokens != nil {
+		return *x.LlmOutputTokens
+	}
+	return 0
+}
+
+func (x *CallbackMetadataRequest) GetLlmCostUsd() float64 {
+	if x != nil && x.LlmCostUsd != nil {
+		return *x.LlmCostUsd
+	}
+	return 0
+}
+
+func (x *CallbackMetadataRequest) GetBlockType() string {
+	if x != nil && x.BlockType != nil {
+		return *x.BlockType
+	}
+	return ""
+}
+
+func (x *CallbackMetadataRequest) GetBlockRetryable() bool {
+	if x != nil && x.BlockRetryable != nil {
+		return *x.BlockRetryable
+	}
+	return false
+}
+
 type JobDetailRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Title            string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -84,6 +132,7 @@ type JobDetailRequest struct {
 	Description      string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
 	Responsibilities []string               `protobuf:"bytes,8,rep,name=responsibilities,proto3" json:"responsibilities,omitempty"`
 	Benefits         []string               `protobuf:"bytes,9,rep,name=benefits,proto3" json:"benefits,omitempty"`
+	OriginalLanguage *string                `protobuf:"bytes,10,opt,name=original_language,json=originalLanguage,proto3,oneof" json:"original_language,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -181,6 +230,13 @@ func (x *JobDetailRequest) GetBenefits() []string {
 	return nil
 }
 
+func (x *JobDetailRequest) GetOriginalLanguage() string {
+	if x != nil && x.OriginalLanguage != nil {
+		return *x.OriginalLanguage
+	}
+	return ""
+}
+
 type JobSalaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Currency      *string                `protobuf:"bytes,1,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
@@ -250,6 +306,8 @@ type ScrapeJobCallbackRequest struct {
 	Operation      string                   `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
 	ProcessingTime string                   `protobuf:"bytes,6,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
 	Metadata       *CallbackMetadataRequest `protobuf:"bytes,7,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	Error          *string                  `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	ErrorCode      *string                  `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3,oneof" json:"error_code,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -333,6 +391,20 @@ func (x *ScrapeJobCallbackRequest) GetMetadata() *CallbackMetadataRequest {
 	return nil
 }
 
+func (x *ScrapeJobCallbackRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *ScrapeJobCallbackRequest) GetErrorCode() string {
+	if x != nil && x.ErrorCode != nil {
+		return *x.ErrorCode
+	}
+	return ""
+}
+
 type ScrapeJobDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *JobDetailRequest      `protobuf:"bytes,1,opt,name=job,proto3,oneof" json:"job,omitempty"`
@@ -441,12 +513,26 @@ var File_api_proto_letraz_v1_callback_proto protoreflect.FileDescriptor
 
 const file_api_proto_letraz_v1_callback_proto_rawDesc = "" +
 	"\n" +
-	"\"api/proto/letraz/v1/callback.proto\x12\x11letraz_server.JOB\"`\n" +
+	"\"api/proto/letraz/v1/callback.proto\x12\x11letraz_server.JOB\"\xd1\x03\n" +
 	"\x17CallbackMetadataRequest\x12\x1b\n" +
 	"\x06engine\x18\x01 \x01(\tH\x00R\x06engine\x88\x01\x01\x12\x15\n" +
-	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01B\t\n" +
+	"\x03url\x18\x02 \x01(\tH\x01R\x03url\x88\x01\x01\x12&\n" +
+	"\fllm_provider\x18\x03 \x01(\tH\x02R\vllmProvider\x88\x01\x01\x12-\n" +
+	"\x10llm_input_tokens\x18\x04 \x01(\x03H\x03R\x0ellmInputTokens\x88\x01\x01\x12/\n" +
+	"\x11llm_output_tokens\x18\x05 \x01(\x03H\x04R\x0fllmOutputTokens\x88\x01\x01\x12%\n" +
+	"\fllm_cost_usd\x18\x06 \x01(\x01H\x05R\n" +
+	"llmCostUsd\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"block_type\x18\a \x01(\tH\x06R\tblockType\x88\x01\x01\x12,\n" +
+	"\x0fblock_retryable\x18\b \x01(\bH\aR\x0eblockRetryable\x88\x01\x01B\t\n" +
 	"\a_engineB\x06\n" +
-	"\x04_url\"\xdb\x02\n" +
+	"\x04_urlB\x0f\n" +
+	"\r_llm_providerB\x13\n" +
+	"\x11_llm_input_tokensB\x14\n" +
+	"\x12_llm_output_tokensB\x0f\n" +
+	"\r_llm_cost_usdB\r\n" +
+	"\v_block_typeB\x12\n" +
+	"\x10_block_retryable\"\xa3\x03\n" +
 	"\x10JobDetailRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x17\n" +
 	"\ajob_url\x18\x02 \x01(\tR\x06jobUrl\x12!\n" +
@@ -456,15 +542,18 @@ const file_api_proto_letraz_v1_callback_proto_rawDesc = "" +
 	"\frequirements\x18\x06 \x03(\tR\frequirements\x12 \n" +
 	"\vdescription\x18\a \x01(\tR\vdescription\x12*\n" +
 	"\x10responsibilities\x18\b \x03(\tR\x10responsibilities\x12\x1a\n" +
-	"\bbenefits\x18\t \x03(\tR\bbenefitsB\t\n" +
-	"\a_salary\"~\n" +
+	"\bbenefits\x18\t \x03(\tR\bbenefits\x120\n" +
+	"\x11original_language\x18\n" +
+	" \x01(\tH\x01R\x10originalLanguage\x88\x01\x01B\t\n" +
+	"\a_salaryB\x14\n" +
+	"\x12_original_language\"~\n" +
 	"\x10JobSalaryRequest\x12\x1f\n" +
 	"\bcurrency\x18\x01 \x01(\tH\x00R\bcurrency\x88\x01\x01\x12\x15\n" +
 	"\x03max\x18\x02 \x01(\x05H\x01R\x03max\x88\x01\x01\x12\x15\n" +
 	"\x03min\x18\x03 \x01(\x05H\x02R\x03min\x88\x01\x01B\v\n" +
 	"\t_currencyB\x06\n" +
 	"\x04_maxB\x06\n" +
-	"\x04_min\"\xda\x02\n" +
+	"\x04_min\"\xb2\x03\n" +
 	"\x18ScrapeJobCallbackRequest\x12\x1c\n" +
 	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12@\n" +
@@ -472,9 +561,14 @@ const file_api_proto_letraz_v1_callback_proto_rawDesc = "" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1c\n" +
 	"\toperation\x18\x05 \x01(\tR\toperation\x12'\n" +
 	"\x0fprocessing_time\x18\x06 \x01(\tR\x0eprocessingTime\x12K\n" +
-	"\bmetadata\x18\a \x01(\v2*.letraz_server.JOB.CallbackMetadataRequestH\x01R\bmetadata\x88\x01\x01B\a\n" +
+	"\bmetadata\x18\a \x01(\v2*.letraz_server.JOB.CallbackMetadataRequestH\x01R\bmetadata\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x02R\x05error\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tH\x03R\terrorCode\x88\x01\x01B\a\n" +
 	"\x05_dataB\v\n" +
-	"\t_metadata\"\xaf\x01\n" +
+	"\t_metadataB\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_error_code\"\xaf\x01\n" +
 	"\x14ScrapeJobDataRequest\x12:\n" +
 	"\x03job\x18\x01 \x01(\v2#.letraz_server.JOB.JobDetailRequestH\x00R\x03job\x88\x01\x01\x12\x1b\n" +
 	"\x06engine\x18\x02 \x01(\tH\x01R\x06engine\x88\x01\x01\x12\x1e\n" +
@@ -82,6 +82,58 @@ func (x *ScrapeJobRequest) GetOptions() *ScrapeOptions {
 	return nil
 }
 
+type BatchScrapeJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []string               `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	Options       *ScrapeOptions         `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchScrapeJobsRequest) Reset() {
+	*x = BatchScrapeJobsRequest{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchScrapeJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchScrapeJobsRequest) ProtoMessage() {}
+
+func (x *BatchScrapeJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchScrapeJobsRequest.ProtoReflect.Descriptor instead.
+func (*BatchScrapeJobsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchScrapeJobsRequest) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *BatchScrapeJobsRequest) GetOptions() *ScrapeOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 type ScrapeJobResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	ProcessId string                 `protobuf:"bytes,1,opt,name=processId,proto3" json:"processId,omitempty"` // Process ID for async tracking (camelCase to match REST)
@@ -96,7 +148,7 @@ type ScrapeJobResponse struct {
 
 func (x *ScrapeJobResponse) Reset() {
 	*x = ScrapeJobResponse{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[1]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -108,7 +160,7 @@ func (x *ScrapeJobResponse) String() string {
 func (*ScrapeJobResponse) ProtoMessage() {}
 
 func (x *ScrapeJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[1]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,7 +173,7 @@ func (x *ScrapeJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScrapeJobResponse.ProtoReflect.Descriptor instead.
 func (*ScrapeJobResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ScrapeJobResponse) GetProcessId() string {
@@ -171,7 +223,7 @@ type BaseResume struct {
 
 func (x *BaseResume) Reset() {
 	*x = BaseResume{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[2]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -183,7 +235,7 @@ func (x *BaseResume) String() string {
 func (*BaseResume) ProtoMessage() {}
 
 func (x *BaseResume) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[2]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +248,7 @@ func (x *BaseResume) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BaseResume.ProtoReflect.Descriptor instead.
 func (*BaseResume) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BaseResume) GetId() string {
@@ -251,7 +303,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[3]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -263,7 +315,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[3]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +328,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *User) GetId() string {
@@ -404,7 +456,7 @@ type ResumeSection struct {
 
 func (x *ResumeSection) Reset() {
 	*x = ResumeSection{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[4]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +468,7 @@ func (x *ResumeSection) String() string {
 func (*ResumeSection) ProtoMessage() {}
 
 func (x *ResumeSection) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[4]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +481,7 @@ func (x *ResumeSection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResumeSection.ProtoReflect.Descriptor instead.
 func (*ResumeSection) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ResumeSection) GetId() string {
@@ -472,13 +524,14 @@ type TailorResumeRequest struct {
 	BaseResume    *BaseResume            `protobuf:"bytes,1,opt,name=base_resume,json=baseResume,proto3" json:"base_resume,omitempty"`
 	Job           *Job                   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
 	ResumeId      string                 `protobuf:"bytes,3,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"` // LLM model, one of the allowed models; empty uses the configured model
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TailorResumeRequest) Reset() {
 	*x = TailorResumeRequest{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[5]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -490,7 +543,7 @@ func (x *TailorResumeRequest) String() string {
 func (*TailorResumeRequest) ProtoMessage() {}
 
 func (x *TailorResumeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[5]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -503,7 +556,7 @@ func (x *TailorResumeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TailorResumeRequest.ProtoReflect.Descriptor instead.
 func (*TailorResumeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TailorResumeRequest) GetBaseResume() *BaseResume {
@@ -527,6 +580,13 @@ func (x *TailorResumeRequest) GetResumeId() string {
 	return ""
 }
 
+func (x *TailorResumeRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
 type TailorResumeResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	ProcessId string                 `protobuf:"bytes,1,opt,name=processId,proto3" json:"processId,omitempty"` // Process ID for async tracking (camelCase to match REST)
@@ -541,7 +601,7 @@ type TailorResumeResponse struct {
 
 func (x *TailorResumeResponse) Reset() {
 	*x = TailorResumeResponse{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[6]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -553,7 +613,7 @@ func (x *TailorResumeResponse) String() string {
 func (*TailorResumeResponse) ProtoMessage() {}
 
 func (x *TailorResumeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[6]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +626,7 @@ func (x *TailorResumeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TailorResumeResponse.ProtoReflect.Descriptor instead.
 func (*TailorResumeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TailorResumeResponse) GetProcessId() string {
@@ -604,6 +664,151 @@ func (x *TailorResumeResponse) GetError() string {
 	return ""
 }
 
+type InterviewQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseResume    *BaseResume            `protobuf:"bytes,1,opt,name=base_resume,json=baseResume,proto3" json:"base_resume,omitempty"`
+	Job           *Job                   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	ResumeId      string                 `protobuf:"bytes,3,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	QuestionCount int32                  `protobuf:"varint,4,opt,name=question_count,json=questionCount,proto3" json:"question_count,omitempty"` // Number of questions to generate, 10 when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterviewQuestionsRequest) Reset() {
+	*x = InterviewQuestionsRequest{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionsRequest) ProtoMessage() {}
+
+func (x *InterviewQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InterviewQuestionsRequest) GetBaseResume() *BaseResume {
+	if x != nil {
+		return x.BaseResume
+	}
+	return nil
+}
+
+func (x *InterviewQuestionsRequest) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *InterviewQuestionsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsRequest) GetQuestionCount() int32 {
+	if x != nil {
+		return x.QuestionCount
+	}
+	return 0
+}
+
+type InterviewQuestionsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProcessId string                 `protobuf:"bytes,1,opt,name=processId,proto3" json:"processId,omitempty"` // Process ID for async tracking (camelCase to match REST)
+	Status    string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`       // ACCEPTED, FAILURE
+	Message   string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`     // Status message
+	Timestamp string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // ISO timestamp string (to match REST format)
+	// Optional error fields (only for failures)
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // Error code/type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterviewQuestionsResponse) Reset() {
+	*x = InterviewQuestionsResponse{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionsResponse) ProtoMessage() {}
+
+func (x *InterviewQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InterviewQuestionsResponse) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsResponse) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type ResumeScreenshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ResumeId      string                 `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"` // Resume ID to generate screenshot for
@@ -613,7 +818,7 @@ type ResumeScreenshotRequest struct {
 
 func (x *ResumeScreenshotRequest) Reset() {
 	*x = ResumeScreenshotRequest{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[7]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -625,7 +830,7 @@ func (x *ResumeScreenshotRequest) String() string {
 func (*ResumeScreenshotRequest) ProtoMessage() {}
 
 func (x *ResumeScreenshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[7]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +843,7 @@ func (x *ResumeScreenshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResumeScreenshotRequest.ProtoReflect.Descriptor instead.
 func (*ResumeScreenshotRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ResumeScreenshotRequest) GetResumeId() string {
@@ -663,7 +868,7 @@ type ResumeScreenshotResponse struct {
 
 func (x *ResumeScreenshotResponse) Reset() {
 	*x = ResumeScreenshotResponse{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[8]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -675,7 +880,7 @@ func (x *ResumeScreenshotResponse) String() string {
 func (*ResumeScreenshotResponse) ProtoMessage() {}
 
 func (x *ResumeScreenshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[8]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +893,7 @@ func (x *ResumeScreenshotResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResumeScreenshotResponse.ProtoReflect.Descriptor instead.
 func (*ResumeScreenshotResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResumeScreenshotResponse) GetStatus() string {
@@ -743,7 +948,7 @@ type ExportResumeRequest struct {
 
 func (x *ExportResumeRequest) Reset() {
 	*x = ExportResumeRequest{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[9]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -755,7 +960,7 @@ func (x *ExportResumeRequest) String() string {
 func (*ExportResumeRequest) ProtoMessage() {}
 
 func (x *ExportResumeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[9]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,7 +973,7 @@ func (x *ExportResumeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportResumeRequest.ProtoReflect.Descriptor instead.
 func (*ExportResumeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ExportResumeRequest) GetResume() *BaseResume {
@@ -799,7 +1004,7 @@ type ExportResumeResponse struct {
 
 func (x *ExportResumeResponse) Reset() {
 	*x = ExportResumeResponse{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[10]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -811,7 +1016,7 @@ func (x *ExportResumeResponse) String() string {
 func (*ExportResumeResponse) ProtoMessage() {}
 
 func (x *ExportResumeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[10]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +1029,7 @@ func (x *ExportResumeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportResumeResponse.ProtoReflect.Descriptor instead.
 func (*ExportResumeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ExportResumeResponse) GetStatus() string {
@@ -877,7 +1082,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[11]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1094,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[11]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1107,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{14}
 }
 
 type HealthCheckResponse struct {
@@ -918,7 +1123,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[12]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -930,7 +1135,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[12]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,7 +1148,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HealthCheckResponse) GetStatus() string {
@@ -981,6 +1186,266 @@ func (x *HealthCheckResponse) GetChecks() map[string]string {
 	return nil
 }
 
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                    // ACCEPTED, PROCESSING, SUCCESS, FAILURE or SCHEDULED
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                        // scrape, batch_scrape, crawl, tailor, interview_questions or screenshot
+	ResumeId      string                 `protobuf:"bytes,3,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`                // resume_id recorded in task metadata
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`                                          // url recorded in task metadata
+	CreatedAfter  string                 `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // RFC 3339 time, inclusive
+	CreatedBefore string                 `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // RFC 3339 time, exclusive
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`                                    // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`                                     // tasks per page, 50 when unset, at most 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TaskSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProcessId        string                 `protobuf:"bytes,1,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status           string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error            string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode        string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // ISO timestamp string
+	CompletedAt      string                 `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"` // ISO timestamp string, empty until the task finishes
+	ProcessingTimeMs int64                  `protobuf:"varint,8,opt,name=processing_time_ms,json=processingTimeMs,proto3" json:"processing_time_ms,omitempty"`
+	Metadata         *structpb.Struct       `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskSummary) Reset() {
+	*x = TaskSummary{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSummary) ProtoMessage() {}
+
+func (x *TaskSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSummary.ProtoReflect.Descriptor instead.
+func (*TaskSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TaskSummary) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *TaskSummary) GetProcessingTimeMs() int64 {
+	if x != nil {
+		return x.ProcessingTimeMs
+	}
+	return 0
+}
+
+func (x *TaskSummary) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskSummary         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskSummary {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type Job struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -993,13 +1458,14 @@ type Job struct {
 	Description      string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
 	Responsibilities []string               `protobuf:"bytes,9,rep,name=responsibilities,proto3" json:"responsibilities,omitempty"`
 	Benefits         []string               `protobuf:"bytes,10,rep,name=benefits,proto3" json:"benefits,omitempty"`
+	OriginalLanguage string                 `protobuf:"bytes,11,opt,name=original_language,json=originalLanguage,proto3" json:"original_language,omitempty"` // ISO 639-1 code of the posting's language
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Job) Reset() {
 	*x = Job{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[13]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1011,7 +1477,7 @@ func (x *Job) String() string {
 func (*Job) ProtoMessage() {}
 
 func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[13]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1024,7 +1490,7 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Job.ProtoReflect.Descriptor instead.
 func (*Job) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Job) GetId() string {
@@ -1097,6 +1563,13 @@ func (x *Job) GetBenefits() []string {
 	return nil
 }
 
+func (x *Job) GetOriginalLanguage() string {
+	if x != nil {
+		return x.OriginalLanguage
+	}
+	return ""
+}
+
 type Salary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
@@ -1108,7 +1581,7 @@ type Salary struct {
 
 func (x *Salary) Reset() {
 	*x = Salary{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[14]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1120,7 +1593,7 @@ func (x *Salary) String() string {
 func (*Salary) ProtoMessage() {}
 
 func (x *Salary) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[14]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,7 +1606,7 @@ func (x *Salary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Salary.ProtoReflect.Descriptor instead.
 func (*Salary) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Salary) GetCurrency() string {
@@ -1164,13 +1637,17 @@ type ScrapeOptions struct {
 	LlmProvider    string                 `protobuf:"bytes,3,opt,name=llm_provider,json=llmProvider,proto3" json:"llm_provider,omitempty"` // "openai", "claude", "local"
 	UserAgent      string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	Proxy          string                 `protobuf:"bytes,5,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	Priority       string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`                              // "high", "normal" or "low"; single scrapes default to high, batches and crawls to low
+	NoCache        bool                   `protobuf:"varint,7,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`                // skip the LLM extraction cache and re-extract
+	Model          string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`                                    // LLM model for extraction, one of the allowed models; empty uses the configured model
+	ForceRefresh   bool                   `protobuf:"varint,9,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"` // scrape again instead of reusing a cached job of the same URL
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ScrapeOptions) Reset() {
 	*x = ScrapeOptions{}
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[15]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1182,7 +1659,7 @@ func (x *ScrapeOptions) String() string {
 func (*ScrapeOptions) ProtoMessage() {}
 
 func (x *ScrapeOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[15]
+	mi := &file_api_proto_letraz_v1_letraz_utils_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,7 +1672,7 @@ func (x *ScrapeOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScrapeOptions.ProtoReflect.Descriptor instead.
 func (*ScrapeOptions) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ScrapeOptions) GetEngine() string {
@@ -1233,6 +1710,34 @@ func (x *ScrapeOptions) GetProxy() string {
 	return ""
 }
 
+func (x *ScrapeOptions) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *ScrapeOptions) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+func (x *ScrapeOptions) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ScrapeOptions) GetForceRefresh() bool {
+	if x != nil {
+		return x.ForceRefresh
+	}
+	return false
+}
+
 var File_api_proto_letraz_v1_letraz_utils_proto protoreflect.FileDescriptor
 
 const file_api_proto_letraz_v1_letraz_utils_proto_rawDesc = "" +
@@ -1243,7 +1748,10 @@ const file_api_proto_letraz_v1_letraz_utils_proto_rawDesc = "" +
 	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x122\n" +
 	"\aoptions\x18\x03 \x01(\v2\x18.letraz.v1.ScrapeOptionsR\aoptionsB\x06\n" +
 	"\x04_urlB\x0e\n" +
-	"\f_description\"\x97\x01\n" +
+	"\f_description\"`\n" +
+	"\x16BatchScrapeJobsRequest\x12\x12\n" +
+	"\x04urls\x18\x01 \x03(\tR\x04urls\x122\n" +
+	"\aoptions\x18\x02 \x01(\v2\x18.letraz.v1.ScrapeOptionsR\aoptions\"\x97\x01\n" +
 	"\x11ScrapeJobResponse\x12\x1c\n" +
 	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
@@ -1282,17 +1790,30 @@ const file_api_proto_letraz_v1_letraz_utils_proto_rawDesc = "" +
 	"\x06resume\x18\x02 \x01(\tR\x06resume\x12\x14\n" +
 	"\x05index\x18\x03 \x01(\x05R\x05index\x12\x12\n" +
 	"\x04type\x18\x04 \x01(\tR\x04type\x12+\n" +
-	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04data\"\x8c\x01\n" +
+	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04data\"\xa2\x01\n" +
 	"\x13TailorResumeRequest\x126\n" +
 	"\vbase_resume\x18\x01 \x01(\v2\x15.letraz.v1.BaseResumeR\n" +
 	"baseResume\x12 \n" +
 	"\x03job\x18\x02 \x01(\v2\x0e.letraz.v1.JobR\x03job\x12\x1b\n" +
-	"\tresume_id\x18\x03 \x01(\tR\bresumeId\"\x9a\x01\n" +
+	"\tresume_id\x18\x03 \x01(\tR\bresumeId\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\"\x9a\x01\n" +
 	"\x14TailorResumeResponse\x12\x1c\n" +
 	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1c\n" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xb9\x01\n" +
+	"\x19InterviewQuestionsRequest\x126\n" +
+	"\vbase_resume\x18\x01 \x01(\v2\x15.letraz.v1.BaseResumeR\n" +
+	"baseResume\x12 \n" +
+	"\x03job\x18\x02 \x01(\v2\x0e.letraz.v1.JobR\x03job\x12\x1b\n" +
+	"\tresume_id\x18\x03 \x01(\tR\bresumeId\x12%\n" +
+	"\x0equestion_count\x18\x04 \x01(\x05R\rquestionCount\"\xa0\x01\n" +
+	"\x1aInterviewQuestionsResponse\x12\x1c\n" +
+	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x14\n" +
 	"\x05error\x18\x05 \x01(\tR\x05error\"6\n" +
 	"\x17ResumeScreenshotRequest\x12\x1b\n" +
 	"\tresume_id\x18\x01 \x01(\tR\bresumeId\"\xc6\x01\n" +
@@ -1324,7 +1845,33 @@ const file_api_proto_letraz_v1_letraz_utils_proto_rawDesc = "" +
 	"\x06checks\x18\x05 \x03(\v2*.letraz.v1.HealthCheckResponse.ChecksEntryR\x06checks\x1a9\n" +
 	"\vChecksEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe7\x01\n" +
+	"\x10ListTasksRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tresume_id\x18\x03 \x01(\tR\bresumeId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12#\n" +
+	"\rcreated_after\x18\x05 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x06 \x01(\tR\rcreatedBefore\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"\xb2\x02\n" +
+	"\vTaskSummary\x12\x1d\n" +
+	"\n" +
+	"process_id\x18\x01 \x01(\tR\tprocessId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12!\n" +
+	"\fcompleted_at\x18\a \x01(\tR\vcompletedAt\x12,\n" +
+	"\x12processing_time_ms\x18\b \x01(\x03R\x10processingTimeMs\x123\n" +
+	"\bmetadata\x18\t \x01(\v2\x17.google.protobuf.StructR\bmetadata\"b\n" +
+	"\x11ListTasksResponse\x12,\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x16.letraz.v1.TaskSummaryR\x05tasks\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xe9\x02\n" +
 	"\x03Job\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
@@ -1336,26 +1883,35 @@ const file_api_proto_letraz_v1_letraz_utils_proto_rawDesc = "" +
 	"\vdescription\x18\b \x01(\tR\vdescription\x12*\n" +
 	"\x10responsibilities\x18\t \x03(\tR\x10responsibilities\x12\x1a\n" +
 	"\bbenefits\x18\n" +
-	" \x03(\tR\bbenefits\"H\n" +
+	" \x03(\tR\bbenefits\x12+\n" +
+	"\x11original_language\x18\v \x01(\tR\x10originalLanguage\"H\n" +
 	"\x06Salary\x12\x1a\n" +
 	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x10\n" +
 	"\x03max\x18\x02 \x01(\x05R\x03max\x12\x10\n" +
-	"\x03min\x18\x03 \x01(\x05R\x03min\"\xa8\x01\n" +
+	"\x03min\x18\x03 \x01(\x05R\x03min\"\x9a\x02\n" +
 	"\rScrapeOptions\x12\x16\n" +
 	"\x06engine\x18\x01 \x01(\tR\x06engine\x12'\n" +
 	"\x0ftimeout_seconds\x18\x02 \x01(\x05R\x0etimeoutSeconds\x12!\n" +
 	"\fllm_provider\x18\x03 \x01(\tR\vllmProvider\x12\x1d\n" +
 	"\n" +
 	"user_agent\x18\x04 \x01(\tR\tuserAgent\x12\x14\n" +
-	"\x05proxy\x18\x05 \x01(\tR\x05proxy2X\n" +
+	"\x05proxy\x18\x05 \x01(\tR\x05proxy\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x19\n" +
+	"\bno_cache\x18\a \x01(\bR\anoCache\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12#\n" +
+	"\rforce_refresh\x18\t \x01(\bR\fforceRefresh2\xac\x01\n" +
 	"\x0eScraperService\x12F\n" +
-	"\tScrapeJob\x12\x1b.letraz.v1.ScrapeJobRequest\x1a\x1c.letraz.v1.ScrapeJobResponse2\x90\x02\n" +
+	"\tScrapeJob\x12\x1b.letraz.v1.ScrapeJobRequest\x1a\x1c.letraz.v1.ScrapeJobResponse\x12R\n" +
+	"\x0fBatchScrapeJobs\x12!.letraz.v1.BatchScrapeJobsRequest\x1a\x1c.letraz.v1.ScrapeJobResponse2\xfb\x02\n" +
 	"\rResumeService\x12O\n" +
 	"\fTailorResume\x12\x1e.letraz.v1.TailorResumeRequest\x1a\x1f.letraz.v1.TailorResumeResponse\x12]\n" +
 	"\x12GenerateScreenshot\x12\".letraz.v1.ResumeScreenshotRequest\x1a#.letraz.v1.ResumeScreenshotResponse\x12O\n" +
-	"\fExportResume\x12\x1e.letraz.v1.ExportResumeRequest\x1a\x1f.letraz.v1.ExportResumeResponse2]\n" +
+	"\fExportResume\x12\x1e.letraz.v1.ExportResumeRequest\x1a\x1f.letraz.v1.ExportResumeResponse\x12i\n" +
+	"\x1aGenerateInterviewQuestions\x12$.letraz.v1.InterviewQuestionsRequest\x1a%.letraz.v1.InterviewQuestionsResponse2]\n" +
 	"\rHealthService\x12L\n" +
-	"\vHealthCheck\x12\x1d.letraz.v1.HealthCheckRequest\x1a\x1e.letraz.v1.HealthCheckResponseB+Z)letraz-utils/api/proto/letraz/v1;letrazv1b\x06proto3"
+	"\vHealthCheck\x12\x1d.letraz.v1.HealthCheckRequest\x1a\x1e.letraz.v1.HealthCheckResponse2U\n" +
+	"\vTaskService\x12F\n" +
+	"\tListTasks\x12\x1b.letraz.v1.ListTasksRequest\x1a\x1c.letraz.v1.ListTasksResponseB+Z)letraz-utils/api/proto/letraz/v1;letrazv1b\x06proto3"
 
 var (
 	file_api_proto_letraz_v1_letraz_utils_proto_rawDescOnce sync.Once
@@ -1369,52 +1925,69 @@ func file_api_proto_letraz_v1_letraz_utils_proto_rawDescGZIP() []byte {
 	return file_api_proto_letraz_v1_letraz_utils_proto_rawDescData
 }
 
-var file_api_proto_letraz_v1_letraz_utils_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_api_proto_letraz_v1_letraz_utils_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_proto_letraz_v1_letraz_utils_proto_goTypes = []any{
-	(*ScrapeJobRequest)(nil),         // 0: letraz.v1.ScrapeJobRequest
-	(*ScrapeJobResponse)(nil),        // 1: letraz.v1.ScrapeJobResponse
-	(*BaseResume)(nil),               // 2: letraz.v1.BaseResume
-	(*User)(nil),                     // 3: letraz.v1.User
-	(*ResumeSection)(nil),            // 4: letraz.v1.ResumeSection
-	(*TailorResumeRequest)(nil),      // 5: letraz.v1.TailorResumeRequest
-	(*TailorResumeResponse)(nil),     // 6: letraz.v1.TailorResumeResponse
-	(*ResumeScreenshotRequest)(nil),  // 7: letraz.v1.ResumeScreenshotRequest
-	(*ResumeScreenshotResponse)(nil), // 8: letraz.v1.ResumeScreenshotResponse
-	(*ExportResumeRequest)(nil),      // 9: letraz.v1.ExportResumeRequest
-	(*ExportResumeResponse)(nil),     // 10: letraz.v1.ExportResumeResponse
-	(*HealthCheckRequest)(nil),       // 11: letraz.v1.HealthCheckRequest
-	(*HealthCheckResponse)(nil),      // 12: letraz.v1.HealthCheckResponse
-	(*Job)(nil),                      // 13: letraz.v1.Job
-	(*Salary)(nil),                   // 14: letraz.v1.Salary
-	(*ScrapeOptions)(nil),            // 15: letraz.v1.ScrapeOptions
-	nil,                              // 16: letraz.v1.HealthCheckResponse.ChecksEntry
-	(*structpb.Struct)(nil),          // 17: google.protobuf.Struct
+	(*ScrapeJobRequest)(nil),           // 0: letraz.v1.ScrapeJobRequest
+	(*BatchScrapeJobsRequest)(nil),     // 1: letraz.v1.BatchScrapeJobsRequest
+	(*ScrapeJobResponse)(nil),          // 2: letraz.v1.ScrapeJobResponse
+	(*BaseResume)(nil),                 // 3: letraz.v1.BaseResume
+	(*User)(nil),                       // 4: letraz.v1.User
+	(*ResumeSection)(nil),              // 5: letraz.v1.ResumeSection
+	(*TailorResumeRequest)(nil),        // 6: letraz.v1.TailorResumeRequest
+	(*TailorResumeResponse)(nil),       // 7: letraz.v1.TailorResumeResponse
+	(*InterviewQuestionsRequest)(nil),  // 8: letraz.v1.InterviewQuestionsRequest
+	(*InterviewQuestionsResponse)(nil), // 9: letraz.v1.InterviewQuestionsResponse
+	(*ResumeScreenshotRequest)(nil),    // 10: letraz.v1.ResumeScreenshotRequest
+	(*ResumeScreenshotResponse)(nil),   // 11: letraz.v1.ResumeScreenshotResponse
+	(*ExportResumeRequest)(nil),        // 12: letraz.v1.ExportResumeRequest
+	(*ExportResumeResponse)(nil),       // 13: letraz.v1.ExportResumeResponse
+	(*HealthCheckRequest)(nil),         // 14: letraz.v1.HealthCheckRequest
+	(*HealthCheckResponse)(nil),        // 15: letraz.v1.HealthCheckResponse
+	(*ListTasksRequest)(nil),           // 16: letraz.v1.ListTasksRequest
+	(*TaskSummary)(nil),                // 17: letraz.v1.TaskSummary
+	(*ListTasksResponse)(nil),          // 18: letraz.v1.ListTasksResponse
+	(*Job)(nil),                        // 19: letraz.v1.Job
+	(*Salary)(nil),                     // 20: letraz.v1.Salary
+	(*ScrapeOptions)(nil),              // 21: letraz.v1.ScrapeOptions
+	nil,                                // 22: letraz.v1.HealthCheckResponse.ChecksEntry
+	(*structpb.Struct)(nil),            // 23: google.protobuf.Struct
 }
 var file_api_proto_letraz_v1_letraz_utils_proto_depIdxs = []int32{
-	15, // 0: letraz.v1.ScrapeJobRequest.options:type_name -> letraz.v1.ScrapeOptions
-	3,  // 1: letraz.v1.BaseResume.user:type_name -> letraz.v1.User
-	4,  // 2: letraz.v1.BaseResume.sections:type_name -> letraz.v1.ResumeSection
-	17, // 3: letraz.v1.ResumeSection.data:type_name -> google.protobuf.Struct
-	2,  // 4: letraz.v1.TailorResumeRequest.base_resume:type_name -> letraz.v1.BaseResume
-	13, // 5: letraz.v1.TailorResumeRequest.job:type_name -> letraz.v1.Job
-	2,  // 6: letraz.v1.ExportResumeRequest.resume:type_name -> letraz.v1.BaseResume
-	16, // 7: letraz.v1.HealthCheckResponse.checks:type_name -> letraz.v1.HealthCheckResponse.ChecksEntry
-	14, // 8: letraz.v1.Job.salary:type_name -> letraz.v1.Salary
-	0,  // 9: letraz.v1.ScraperService.ScrapeJob:input_type -> letraz.v1.ScrapeJobRequest
-	5,  // 10: letraz.v1.ResumeService.TailorResume:input_type -> letraz.v1.TailorResumeRequest
-	7,  // 11: letraz.v1.ResumeService.GenerateScreenshot:input_type -> letraz.v1.ResumeScreenshotRequest
-	9,  // 12: letraz.v1.ResumeService.ExportResume:input_type -> letraz.v1.ExportResumeRequest
-	11, // 13: letraz.v1.HealthService.HealthCheck:input_type -> letraz.v1.HealthCheckRequest
-	1,  // 14: letraz.v1.ScraperService.ScrapeJob:output_type -> letraz.v1.ScrapeJobResponse
-	6,  // 15: letraz.v1.ResumeService.TailorResume:output_type -> letraz.v1.TailorResumeResponse
-	8,  // 16: letraz.v1.ResumeService.GenerateScreenshot:output_type -> letraz.v1.ResumeScreenshotResponse
-	10, // 17: letraz.v1.ResumeService.ExportResume:output_type -> letraz.v1.ExportResumeResponse
-	12, // 18: letraz.v1.HealthService.HealthCheck:output_type -> letraz.v1.HealthCheckResponse
-	14, // [14:19] is the sub-list for method output_type
-	9,  // [9:14] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	21, // 0: letraz.v1.ScrapeJobRequest.options:type_name -> letraz.v1.ScrapeOptions
+	21, // 1: letraz.v1.BatchScrapeJobsRequest.options:type_name -> letraz.v1.ScrapeOptions
+	4,  // 2: letraz.v1.BaseResume.user:type_name -> letraz.v1.User
+	5,  // 3: letraz.v1.BaseResume.sections:type_name -> letraz.v1.ResumeSection
+	23, // 4: letraz.v1.ResumeSection.data:type_name -> google.protobuf.Struct
+	3,  // 5: letraz.v1.TailorResumeRequest.base_resume:type_name -> letraz.v1.BaseResume
+	19, // 6: letraz.v1.TailorResumeRequest.job:type_name -> letraz.v1.Job
+	3,  // 7: letraz.v1.InterviewQuestionsRequest.base_resume:type_name -> letraz.v1.BaseResume
+	19, // 8: letraz.v1.InterviewQuestionsRequest.job:type_name -> letraz.v1.Job
+	3,  // 9: letraz.v1.ExportResumeRequest.resume:type_name -> letraz.v1.BaseResume
+	22, // 10: letraz.v1.HealthCheckResponse.checks:type_name -> letraz.v1.HealthCheckResponse.ChecksEntry
+	23, // 11: letraz.v1.TaskSummary.metadata:type_name -> google.protobuf.Struct
+	17, // 12: letraz.v1.ListTasksResponse.tasks:type_name -> letraz.v1.TaskSummary
+	20, // 13: letraz.v1.Job.salary:type_name -> letraz.v1.Salary
+	0,  // 14: letraz.v1.ScraperService.ScrapeJob:input_type -> letraz.v1.ScrapeJobRequest
+	1,  // 15: letraz.v1.ScraperService.BatchScrapeJobs:input_type -> letraz.v1.BatchScrapeJobsRequest
+	6,  // 16: letraz.v1.ResumeService.TailorResume:input_type -> letraz.v1.TailorResumeRequest
+	10, // 17: letraz.v1.ResumeService.GenerateScreenshot:input_type -> letraz.v1.ResumeScreenshotRequest
+	12, // 18: letraz.v1.ResumeService.ExportResume:input_type -> letraz.v1.ExportResumeRequest
+	8,  // 19: letraz.v1.ResumeService.GenerateInterviewQuestions:input_type -> letraz.v1.InterviewQuestionsRequest
+	14, // 20: letraz.v1.HealthService.HealthCheck:input_type -> letraz.v1.HealthCheckRequest
+	16, // 21: letraz.v1.TaskService.ListTasks:input_type -> letraz.v1.ListTasksRequest
+	2,  // 22: letraz.v1.ScraperService.ScrapeJob:output_type -> letraz.v1.ScrapeJobResponse
+	2,  // 23: letraz.v1.ScraperService.BatchScrapeJobs:output_type -> letraz.v1.ScrapeJobResponse
+	7,  // 24: letraz.v1.ResumeService.TailorResume:output_type -> letraz.v1.TailorResumeResponse
+	11, // 25: letraz.v1.ResumeService.GenerateScreenshot:output_type -> letraz.v1.ResumeScreenshotResponse
+	13, // 26: letraz.v1.ResumeService.ExportResume:output_type -> letraz.v1.ExportResumeResponse
+	9,  // 27: letraz.v1.ResumeService.GenerateInterviewQuestions:output_type -> letraz.v1.InterviewQuestionsResponse
+	15, // 28: letraz.v1.HealthService.HealthCheck:output_type -> letraz.v1.HealthCheckResponse
+	18, // 29: letraz.v1.TaskService.ListTasks:output_type -> letraz.v1.ListTasksResponse
+	22, // [22:30] is the sub-list for method output_type
+	14, // [14:22] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_letraz_v1_letraz_utils_proto_init() }
@@ -1429,9 +2002,9 @@ func file_api_proto_letraz_v1_letraz_utils_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_letraz_v1_letraz_utils_proto_rawDesc), len(file_api_proto_letraz_v1_letraz_utils_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   23,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_api_proto_letraz_v1_letraz_utils_proto_goTypes,
 		DependencyIndexes: file_api_proto_letraz_v1_letraz_utils_proto_depIdxs,
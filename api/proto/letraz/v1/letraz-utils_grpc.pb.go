@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScraperService_ScrapeJob_FullMethodName = "/letraz.v1.ScraperService/ScrapeJob"
+	ScraperService_ScrapeJob_FullMethodName       = "/letraz.v1.ScraperService/ScrapeJob"
+	ScraperService_BatchScrapeJobs_FullMethodName = "/letraz.v1.ScraperService/BatchScrapeJobs"
 )
 
 // ScraperServiceClient is the client API for ScraperService service.
@@ -28,6 +29,8 @@ const (
 type ScraperServiceClient interface {
 	// Scrape a job posting from a URL
 	ScrapeJob(ctx context.Context, in *ScrapeJobRequest, opts ...grpc.CallOption) (*ScrapeJobResponse, error)
+	// Scrape several job posting URLs as one task whose result collects every job and error
+	BatchScrapeJobs(ctx context.Context, in *BatchScrapeJobsRequest, opts ...grpc.CallOption) (*ScrapeJobResponse, error)
 }
 
 type scraperServiceClient struct {
@@ -48,12 +51,24 @@ func (c *scraperServiceClient) ScrapeJob(ctx context.Context, in *ScrapeJobReque
 	return out, nil
 }
 
+func (c *scraperServiceClient) BatchScrapeJobs(ctx context.Context, in *BatchScrapeJobsRequest, opts ...grpc.CallOption) (*ScrapeJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScrapeJobResponse)
+	err := c.cc.Invoke(ctx, ScraperService_BatchScrapeJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScraperServiceServer is the server API for ScraperService service.
 // All implementations must embed UnimplementedScraperServiceServer
 // for forward compatibility.
 type ScraperServiceServer interface {
 	// Scrape a job posting from a URL
 	ScrapeJob(context.Context, *ScrapeJobRequest) (*ScrapeJobResponse, error)
+	// Scrape several job posting URLs as one task whose result collects every job and error
+	BatchScrapeJobs(context.Context, *BatchScrapeJobsRequest) (*ScrapeJobResponse, error)
 	mustEmbedUnimplementedScraperServiceServer()
 }
 
@@ -67,6 +82,9 @@ type UnimplementedScraperServiceServer struct{}
 func (UnimplementedScraperServiceServer) ScrapeJob(context.Context, *ScrapeJobRequest) (*ScrapeJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ScrapeJob not implemented")
 }
+func (UnimplementedScraperServiceServer) BatchScrapeJobs(context.Context, *BatchScrapeJobsRequest) (*ScrapeJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchScrapeJobs not implemented")
+}
 func (UnimplementedScraperServiceServer) mustEmbedUnimplementedScraperServiceServer() {}
 func (UnimplementedScraperServiceServer) testEmbeddedByValue()                        {}
 
@@ -106,6 +124,24 @@ func _ScraperService_ScrapeJob_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScraperService_BatchScrapeJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchScrapeJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).BatchScrapeJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_BatchScrapeJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).BatchScrapeJobs(ctx, req.(*BatchScrapeJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScraperService_ServiceDesc is the grpc.ServiceDesc for ScraperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,15 +153,20 @@ var ScraperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ScrapeJob",
 			Handler:    _ScraperService_ScrapeJob_Handler,
 		},
+		{
+			MethodName: "BatchScrapeJobs",
+			Handler:    _ScraperService_BatchScrapeJobs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/letraz/v1/letraz-utils.proto",
 }
 
 const (
-	ResumeService_TailorResume_FullMethodName       = "/letraz.v1.ResumeService/TailorResume"
-	ResumeService_GenerateScreenshot_FullMethodName = "/letraz.v1.ResumeService/GenerateScreenshot"
-	ResumeService_ExportResume_FullMethodName       = "/letraz.v1.ResumeService/ExportResume"
+	ResumeService_TailorResume_FullMethodName               = "/letraz.v1.ResumeService/TailorResume"
+	ResumeService_GenerateScreenshot_FullMethodName         = "/letraz.v1.ResumeService/GenerateScreenshot"
+	ResumeService_ExportResume_FullMethodName               = "/letraz.v1.ResumeService/ExportResume"
+	ResumeService_GenerateInterviewQuestions_FullMethodName = "/letraz.v1.ResumeService/GenerateInterviewQuestions"
 )
 
 // ResumeServiceClient is the client API for ResumeService service.
@@ -138,6 +179,8 @@ type ResumeServiceClient interface {
 	GenerateScreenshot(ctx context.Context, in *ResumeScreenshotRequest, opts ...grpc.CallOption) (*ResumeScreenshotResponse, error)
 	// Export a resume to LaTeX and upload to object storage
 	ExportResume(ctx context.Context, in *ExportResumeRequest, opts ...grpc.CallOption) (*ExportResumeResponse, error)
+	// Generate likely interview questions with suggested answers for a job posting
+	GenerateInterviewQuestions(ctx context.Context, in *InterviewQuestionsRequest, opts ...grpc.CallOption) (*InterviewQuestionsResponse, error)
 }
 
 type resumeServiceClient struct {
@@ -178,6 +221,16 @@ func (c *resumeServiceClient) ExportResume(ctx context.Context, in *ExportResume
 	return out, nil
 }
 
+func (c *resumeServiceClient) GenerateInterviewQuestions(ctx context.Context, in *InterviewQuestionsRequest, opts ...grpc.CallOption) (*InterviewQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InterviewQuestionsResponse)
+	err := c.cc.Invoke(ctx, ResumeService_GenerateInterviewQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResumeServiceServer is the server API for ResumeService service.
 // All implementations must embed UnimplementedResumeServiceServer
 // for forward compatibility.
@@ -188,6 +241,8 @@ type ResumeServiceServer interface {
 	GenerateScreenshot(context.Context, *ResumeScreenshotRequest) (*ResumeScreenshotResponse, error)
 	// Export a resume to LaTeX and upload to object storage
 	ExportResume(context.Context, *ExportResumeRequest) (*ExportResumeResponse, error)
+	// Generate likely interview questions with suggested answers for a job posting
+	GenerateInterviewQuestions(context.Context, *InterviewQuestionsRequest) (*InterviewQuestionsResponse, error)
 	mustEmbedUnimplementedResumeServiceServer()
 }
 
@@ -207,6 +262,9 @@ func (UnimplementedResumeServiceServer) GenerateScreenshot(context.Context, *Res
 func (UnimplementedResumeServiceServer) ExportResume(context.Context, *ExportResumeRequest) (*ExportResumeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportResume not implemented")
 }
+func (UnimplementedResumeServiceServer) GenerateInterviewQuestions(context.Context, *InterviewQuestionsRequest) (*InterviewQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateInterviewQuestions not implemented")
+}
 func (UnimplementedResumeServiceServer) mustEmbedUnimplementedResumeServiceServer() {}
 func (UnimplementedResumeServiceServer) testEmbeddedByValue()                       {}
 
@@ -282,6 +340,24 @@ func _ResumeService_ExportResume_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResumeService_GenerateInterviewQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterviewQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResumeServiceServer).GenerateInterviewQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResumeService_GenerateInterviewQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResumeServiceServer).GenerateInterviewQuestions(ctx, req.(*InterviewQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ResumeService_ServiceDesc is the grpc.ServiceDesc for ResumeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -301,6 +377,10 @@ var ResumeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportResume",
 			Handler:    _ResumeService_ExportResume_Handler,
 		},
+		{
+			MethodName: "GenerateInterviewQuestions",
+			Handler:    _ResumeService_GenerateInterviewQuestions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/letraz/v1/letraz-utils.proto",
@@ -409,3 +489,107 @@ var HealthService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/letraz/v1/letraz-utils.proto",
 }
+
+const (
+	TaskService_ListTasks_FullMethodName = "/letraz.v1.TaskService/ListTasks"
+)
+
+// TaskServiceClient is the client API for TaskService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TaskServiceClient interface {
+	// List background tasks newest first, filtered and one page at a time
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+}
+
+type taskServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaskServiceClient(cc grpc.ClientConnInterface) TaskServiceClient {
+	return &taskServiceClient{cc}
+}
+
+func (c *taskServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TaskServiceServer is the server API for TaskService service.
+// All implementations must embed UnimplementedTaskServiceServer
+// for forward compatibility.
+type TaskServiceServer interface {
+	// List background tasks newest first, filtered and one page at a time
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	mustEmbedUnimplementedTaskServiceServer()
+}
+
+// UnimplementedTaskServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTaskServiceServer struct{}
+
+func (UnimplementedTaskServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
+func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeTaskServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TaskServiceServer will
+// result in compilation errors.
+type UnsafeTaskServiceServer interface {
+	mustEmbedUnimplementedTaskServiceServer()
+}
+
+func RegisterTaskServiceServer(s grpc.ServiceRegistrar, srv TaskServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTaskServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TaskService_ServiceDesc, srv)
+}
+
+func _TaskService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaskService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "letraz.v1.TaskService",
+	HandlerType: (*TaskServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTasks",
+			Handler:    _TaskService_ListTasks_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/letraz/v1/letraz-utils.proto",
+}
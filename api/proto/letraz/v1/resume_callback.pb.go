@@ -91,6 +91,8 @@ type GenerateScreenshotCallBackRequest struct {
 	Operation      string                     `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
 	ProcessingTime string                     `protobuf:"bytes,6,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
 	Metadata       *ScreenshotMetadataRequest `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Error          *string                    `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	ErrorCode      *string                    `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3,oneof" json:"error_code,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -174,6 +176,20 @@ func (x *GenerateScreenshotCallBackRequest) GetMetadata() *ScreenshotMetadataReq
 	return nil
 }
 
+func (x *GenerateScreenshotCallBackRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GenerateScreenshotCallBackRequest) GetErrorCode() string {
+	if x != nil && x.ErrorCode != nil {
+		return *x.ErrorCode
+	}
+	return ""
+}
+
 type GenerateScreenshotCallBackResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Msg           *string                `protobuf:"bytes,1,opt,name=msg,proto3,oneof" json:"msg,omitempty"`
@@ -218,18 +234,294 @@ func (x *GenerateScreenshotCallBackResponse) GetMsg() string {
 	return ""
 }
 
-type MetadataRequest struct {
+type InterviewQuestionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Question        string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Category        string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	SuggestedAnswer string                 `protobuf:"bytes,3,opt,name=suggested_answer,json=suggestedAnswer,proto3" json:"suggested_answer,omitempty"`
+	Reasoning       string                 `protobuf:"bytes,4,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *InterviewQuestionRequest) Reset() {
+	*x = InterviewQuestionRequest{}
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionRequest) ProtoMessage() {}
+
+func (x *InterviewQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionRequest.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InterviewQuestionRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *InterviewQuestionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *InterviewQuestionRequest) GetSuggestedAnswer() string {
+	if x != nil {
+		return x.SuggestedAnswer
+	}
+	return ""
+}
+
+func (x *InterviewQuestionRequest) GetReasoning() string {
+	if x != nil {
+		return x.Reasoning
+	}
+	return ""
+}
+
+type InterviewQuestionsCallBackRequest struct {
+	state          protoimpl.MessageState         `protogen:"open.v1"`
+	ProcessId      string                         `protobuf:"bytes,1,opt,name=processId,proto3" json:"processId,omitempty"`
+	Status         string                         `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Data           *InterviewQuestionsDataRequest `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Timestamp      string                         `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Operation      string                         `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	ProcessingTime string                         `protobuf:"bytes,6,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
+	Metadata       *MetadataRequest               `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Error          *string                        `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	ErrorCode      *string                        `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3,oneof" json:"error_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InterviewQuestionsCallBackRequest) Reset() {
+	*x = InterviewQuestionsCallBackRequest{}
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionsCallBackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionsCallBackRequest) ProtoMessage() {}
+
+func (x *InterviewQuestionsCallBackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionsCallBackRequest.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionsCallBackRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetProcessId() string {
+	if x != nil {
+		return x.ProcessId
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetData() *InterviewQuestionsDataRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetProcessingTime() string {
+	if x != nil {
+		return x.ProcessingTime
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetMetadata() *MetadataRequest {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *InterviewQuestionsCallBackRequest) GetErrorCode() string {
+	if x != nil && x.ErrorCode != nil {
+		return *x.ErrorCode
+	}
+	return ""
+}
+
+type InterviewQuestionsCallBackResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Company       string                 `protobuf:"bytes,1,opt,name=company,proto3" json:"company,omitempty"`
-	JobTitle      string                 `protobuf:"bytes,2,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
-	ResumeId      string                 `protobuf:"bytes,3,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	Msg           *string                `protobuf:"bytes,1,opt,name=msg,proto3,oneof" json:"msg,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *InterviewQuestionsCallBackResponse) Reset() {
+	*x = InterviewQuestionsCallBackResponse{}
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionsCallBackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionsCallBackResponse) ProtoMessage() {}
+
+func (x *InterviewQuestionsCallBackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionsCallBackResponse.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionsCallBackResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InterviewQuestionsCallBackResponse) GetMsg() string {
+	if x != nil && x.Msg != nil {
+		return *x.Msg
+	}
+	return ""
+}
+
+type InterviewQuestionsDataRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Questions     []*InterviewQuestionRequest `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	ResumeId      string                      `protobuf:"bytes,2,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterviewQuestionsDataRequest) Reset() {
+	*x = InterviewQuestionsDataRequest{}
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterviewQuestionsDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterviewQuestionsDataRequest) ProtoMessage() {}
+
+func (x *InterviewQuestionsDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterviewQuestionsDataRequest.ProtoReflect.Descriptor instead.
+func (*InterviewQuestionsDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InterviewQuestionsDataRequest) GetQuestions() []*InterviewQuestionRequest {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *InterviewQuestionsDataRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+type MetadataRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Company         string                 `protobuf:"bytes,1,opt,name=company,proto3" json:"company,omitempty"`
+	JobTitle        string                 `protobuf:"bytes,2,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
+	ResumeId        string                 `protobuf:"bytes,3,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	LlmProvider     *string                `protobuf:"bytes,4,opt,name=llm_provider,json=llmProvider,proto3,oneof" json:"llm_provider,omitempty"`
+	LlmInputTokens  *int64                 `protobuf:"varint,5,opt,name=llm_input_tokens,json=llmInputTokens,proto3,oneof" json:"llm_input_tokens,omitempty"`
+	LlmOutputTokens *int64                 `protobuf:"varint,6,opt,name=llm_output_tokens,json=llmOutputTokens,proto3,oneof" json:"llm_output_tokens,omitempty"`
+	LlmCostUsd      *float64               `protobuf:"fixed64,7,opt,name=llm_cost_usd,json=llmCostUsd,proto3,oneof" json:"llm_cost_usd,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *MetadataRequest) Reset() {
 	*x = MetadataRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[3]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -241,7 +533,7 @@ func (x *MetadataRequest) String() string {
 func (*MetadataRequest) ProtoMessage() {}
 
 func (x *MetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[3]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +546,7 @@ func (x *MetadataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetadataRequest.ProtoReflect.Descriptor instead.
 func (*MetadataRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *MetadataRequest) GetCompany() string {
@@ -278,6 +570,34 @@ func (x *MetadataRequest) GetResumeId() string {
 	return ""
 }
 
+func (x *MetadataRequest) GetLlmProvider() string {
+	if x != nil && x.LlmProvider != nil {
+		return *x.LlmProvider
+	}
+	return ""
+}
+
+func (x *MetadataRequest) GetLlmInputTokens() int64 {
+	if x != nil && x.LlmInputTokens != nil {
+		return *x.LlmInputTokens
+	}
+	return 0
+}
+
+func (x *MetadataRequest) GetLlmOutputTokens() int64 {
+	if x != nil && x.LlmOutputTokens != nil {
+		return *x.LlmOutputTokens
+	}
+	return 0
+}
+
+func (x *MetadataRequest) GetLlmCostUsd() float64 {
+	if x != nil && x.LlmCostUsd != nil {
+		return *x.LlmCostUsd
+	}
+	return 0
+}
+
 type ScreenshotDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ScreenshotUrl string                 `protobuf:"bytes,1,opt,name=screenshot_url,json=screenshotUrl,proto3" json:"screenshot_url,omitempty"`
@@ -289,7 +609,7 @@ type ScreenshotDataRequest struct {
 
 func (x *ScreenshotDataRequest) Reset() {
 	*x = ScreenshotDataRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[4]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -301,7 +621,7 @@ func (x *ScreenshotDataRequest) String() string {
 func (*ScreenshotDataRequest) ProtoMessage() {}
 
 func (x *ScreenshotDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[4]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -314,7 +634,7 @@ func (x *ScreenshotDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScreenshotDataRequest.ProtoReflect.Descriptor instead.
 func (*ScreenshotDataRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ScreenshotDataRequest) GetScreenshotUrl() string {
@@ -349,7 +669,7 @@ type ScreenshotMetadataRequest struct {
 
 func (x *ScreenshotMetadataRequest) Reset() {
 	*x = ScreenshotMetadataRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[5]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -361,7 +681,7 @@ func (x *ScreenshotMetadataRequest) String() string {
 func (*ScreenshotMetadataRequest) ProtoMessage() {}
 
 func (x *ScreenshotMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[5]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +694,7 @@ func (x *ScreenshotMetadataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScreenshotMetadataRequest.ProtoReflect.Descriptor instead.
 func (*ScreenshotMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ScreenshotMetadataRequest) GetFileSize() int32 {
@@ -408,7 +728,7 @@ type SectionRequest struct {
 
 func (x *SectionRequest) Reset() {
 	*x = SectionRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[6]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +740,7 @@ func (x *SectionRequest) String() string {
 func (*SectionRequest) ProtoMessage() {}
 
 func (x *SectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[6]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +753,7 @@ func (x *SectionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SectionRequest.ProtoReflect.Descriptor instead.
 func (*SectionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SectionRequest) GetType() string {
@@ -466,7 +786,7 @@ type SuggestionRequest struct {
 
 func (x *SuggestionRequest) Reset() {
 	*x = SuggestionRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[7]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -478,7 +798,7 @@ func (x *SuggestionRequest) String() string {
 func (*SuggestionRequest) ProtoMessage() {}
 
 func (x *SuggestionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[7]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +811,7 @@ func (x *SuggestionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuggestionRequest.ProtoReflect.Descriptor instead.
 func (*SuggestionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SuggestionRequest) GetId() string {
@@ -559,13 +879,15 @@ type TailorResumeCallBackRequest struct {
 	Operation      string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
 	ProcessingTime string                 `protobuf:"bytes,6,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
 	Metadata       *MetadataRequest       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Error          *string                `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	ErrorCode      *string                `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3,oneof" json:"error_code,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TailorResumeCallBackRequest) Reset() {
 	*x = TailorResumeCallBackRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[8]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +899,7 @@ func (x *TailorResumeCallBackRequest) String() string {
 func (*TailorResumeCallBackRequest) ProtoMessage() {}
 
 func (x *TailorResumeCallBackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[8]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +912,7 @@ func (x *TailorResumeCallBackRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TailorResumeCallBackRequest.ProtoReflect.Descriptor instead.
 func (*TailorResumeCallBackRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TailorResumeCallBackRequest) GetProcessId() string {
@@ -642,6 +964,20 @@ func (x *TailorResumeCallBackRequest) GetMetadata() *MetadataRequest {
 	return nil
 }
 
+func (x *TailorResumeCallBackRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *TailorResumeCallBackRequest) GetErrorCode() string {
+	if x != nil && x.ErrorCode != nil {
+		return *x.ErrorCode
+	}
+	return ""
+}
+
 type TailorResumeCallBackResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Msg           *string                `protobuf:"bytes,1,opt,name=msg,proto3,oneof" json:"msg,omitempty"`
@@ -651,7 +987,7 @@ type TailorResumeCallBackResponse struct {
 
 func (x *TailorResumeCallBackResponse) Reset() {
 	*x = TailorResumeCallBackResponse{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[9]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -663,7 +999,7 @@ func (x *TailorResumeCallBackResponse) String() string {
 func (*TailorResumeCallBackResponse) ProtoMessage() {}
 
 func (x *TailorResumeCallBackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[9]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +1012,7 @@ func (x *TailorResumeCallBackResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TailorResumeCallBackResponse.ProtoReflect.Descriptor instead.
 func (*TailorResumeCallBackResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TailorResumeCallBackResponse) GetMsg() string {
@@ -696,7 +1032,7 @@ type TailoredResumeRequest struct {
 
 func (x *TailoredResumeRequest) Reset() {
 	*x = TailoredResumeRequest{}
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[10]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -708,7 +1044,7 @@ func (x *TailoredResumeRequest) String() string {
 func (*TailoredResumeRequest) ProtoMessage() {}
 
 func (x *TailoredResumeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[10]
+	mi := &file_api_proto_letraz_v1_resume_callback_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +1057,7 @@ func (x *TailoredResumeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TailoredResumeRequest.ProtoReflect.Descriptor instead.
 func (*TailoredResumeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TailoredResumeRequest) GetId() string {
@@ -746,7 +1082,7 @@ const file_api_proto_letraz_v1_resume_callback_proto_rawDesc = "" +
 	"\vDataRequest\x12T\n" +
 	"\x0ftailored_resume\x18\x01 \x01(\v2+.letraz_server.RESUME.TailoredResumeRequestR\x0etailoredResume\x12I\n" +
 	"\vsuggestions\x18\x02 \x03(\v2'.letraz_server.RESUME.SuggestionRequestR\vsuggestions\x12\x1b\n" +
-	"\tthread_id\x18\x03 \x01(\tR\bthreadId\"\xcc\x02\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\"\xa4\x03\n" +
 	"!GenerateScreenshotCallBackRequest\x12\x1c\n" +
 	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12?\n" +
@@ -754,14 +1090,52 @@ const file_api_proto_letraz_v1_resume_callback_proto_rawDesc = "" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1c\n" +
 	"\toperation\x18\x05 \x01(\tR\toperation\x12'\n" +
 	"\x0fprocessing_time\x18\x06 \x01(\tR\x0eprocessingTime\x12K\n" +
-	"\bmetadata\x18\a \x01(\v2/.letraz_server.RESUME.ScreenshotMetadataRequestR\bmetadata\"C\n" +
+	"\bmetadata\x18\a \x01(\v2/.letraz_server.RESUME.ScreenshotMetadataRequestR\bmetadata\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x00R\x05error\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tH\x01R\terrorCode\x88\x01\x01B\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_error_code\"C\n" +
 	"\"GenerateScreenshotCallBackResponse\x12\x15\n" +
 	"\x03msg\x18\x01 \x01(\tH\x00R\x03msg\x88\x01\x01B\x06\n" +
-	"\x04_msg\"e\n" +
+	"\x04_msg\"\x9b\x01\n" +
+	"\x18InterviewQuestionRequest\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12)\n" +
+	"\x10suggested_answer\x18\x03 \x01(\tR\x0fsuggestedAnswer\x12\x1c\n" +
+	"\treasoning\x18\x04 \x01(\tR\treasoning\"\xa2\x03\n" +
+	"!InterviewQuestionsCallBackRequest\x12\x1c\n" +
+	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12G\n" +
+	"\x04data\x18\x03 \x01(\v23.letraz_server.RESUME.InterviewQuestionsDataRequestR\x04data\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12'\n" +
+	"\x0fprocessing_time\x18\x06 \x01(\tR\x0eprocessingTime\x12A\n" +
+	"\bmetadata\x18\a \x01(\v2%.letraz_server.RESUME.MetadataRequestR\bmetadata\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x00R\x05error\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tH\x01R\terrorCode\x88\x01\x01B\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_error_code\"C\n" +
+	"\"InterviewQuestionsCallBackResponse\x12\x15\n" +
+	"\x03msg\x18\x01 \x01(\tH\x00R\x03msg\x88\x01\x01B\x06\n" +
+	"\x04_msg\"\x8a\x01\n" +
+	"\x1dInterviewQuestionsDataRequest\x12L\n" +
+	"\tquestions\x18\x01 \x03(\v2..letraz_server.RESUME.InterviewQuestionRequestR\tquestions\x12\x1b\n" +
+	"\tresume_id\x18\x02 \x01(\tR\bresumeId\"\xe1\x02\n" +
 	"\x0fMetadataRequest\x12\x18\n" +
 	"\acompany\x18\x01 \x01(\tR\acompany\x12\x1b\n" +
 	"\tjob_title\x18\x02 \x01(\tR\bjobTitle\x12\x1b\n" +
-	"\tresume_id\x18\x03 \x01(\tR\bresumeId\"\x83\x01\n" +
+	"\tresume_id\x18\x03 \x01(\tR\bresumeId\x12&\n" +
+	"\fllm_provider\x18\x04 \x01(\tH\x00R\vllmProvider\x88\x01\x01\x12-\n" +
+	"\x10llm_input_tokens\x18\x05 \x01(\x03H\x01R\x0ellmInputTokens\x88\x01\x01\x12/\n" +
+	"\x11llm_output_tokens\x18\x06 \x01(\x03H\x02R\x0fllmOutputTokens\x88\x01\x01\x12%\n" +
+	"\fllm_cost_usd\x18\a \x01(\x01H\x03R\n" +
+	"llmCostUsd\x88\x01\x01B\x0f\n" +
+	"\r_llm_providerB\x13\n" +
+	"\x11_llm_input_tokensB\x14\n" +
+	"\x12_llm_output_tokensB\x0f\n" +
+	"\r_llm_cost_usd\"\x83\x01\n" +
 	"\x15ScreenshotDataRequest\x12%\n" +
 	"\x0escreenshot_url\x18\x01 \x01(\tR\rscreenshotUrl\x12\x1b\n" +
 	"\tresume_id\x18\x02 \x01(\tR\bresumeId\x12&\n" +
@@ -781,7 +1155,7 @@ const file_api_proto_letraz_v1_resume_callback_proto_rawDesc = "" +
 	"\asection\x18\x05 \x01(\tR\asection\x12\x18\n" +
 	"\acurrent\x18\x06 \x01(\tR\acurrent\x12\x1c\n" +
 	"\tsuggested\x18\a \x01(\tR\tsuggested\x12\x1c\n" +
-	"\treasoning\x18\b \x01(\tR\treasoning\"\xb2\x02\n" +
+	"\treasoning\x18\b \x01(\tR\treasoning\"\x8a\x03\n" +
 	"\x1bTailorResumeCallBackRequest\x12\x1c\n" +
 	"\tprocessId\x18\x01 \x01(\tR\tprocessId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x125\n" +
@@ -789,7 +1163,12 @@ const file_api_proto_letraz_v1_resume_callback_proto_rawDesc = "" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1c\n" +
 	"\toperation\x18\x05 \x01(\tR\toperation\x12'\n" +
 	"\x0fprocessing_time\x18\x06 \x01(\tR\x0eprocessingTime\x12A\n" +
-	"\bmetadata\x18\a \x01(\v2%.letraz_server.RESUME.MetadataRequestR\bmetadata\"=\n" +
+	"\bmetadata\x18\a \x01(\v2%.letraz_server.RESUME.MetadataRequestR\bmetadata\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x00R\x05error\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tH\x01R\terrorCode\x88\x01\x01B\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_error_code\"=\n" +
 	"\x1cTailorResumeCallBackResponse\x12\x15\n" +
 	"\x03msg\x18\x01 \x01(\tH\x00R\x03msg\x88\x01\x01B\x06\n" +
 	"\x04_msg\"i\n" +
@@ -799,7 +1178,9 @@ const file_api_proto_letraz_v1_resume_callback_proto_rawDesc = "" +
 	"$GenerateScreenshotCallBackController\x12\x91\x01\n" +
 	"\x1aGenerateScreenshotCallBack\x127.letraz_server.RESUME.GenerateScreenshotCallBackRequest\x1a8.letraz_server.RESUME.GenerateScreenshotCallBackResponse\"\x002\xa1\x01\n" +
 	"\x1eTailorResumeCallBackController\x12\x7f\n" +
-	"\x14TailorResumeCallBack\x121.letraz_server.RESUME.TailorResumeCallBackRequest\x1a2.letraz_server.RESUME.TailorResumeCallBackResponse\"\x00B+Z)letraz-utils/api/proto/letraz/v1;letrazv1b\x06proto3"
+	"\x14TailorResumeCallBack\x121.letraz_server.RESUME.TailorResumeCallBackRequest\x1a2.letraz_server.RESUME.TailorResumeCallBackResponse\"\x002\xba\x01\n" +
+	"$InterviewQuestionsCallBackController\x12\x91\x01\n" +
+	"\x1aInterviewQuestionsCallBack\x127.letraz_server.RESUME.InterviewQuestionsCallBackRequest\x1a8.letraz_server.RESUME.InterviewQuestionsCallBackResponse\"\x00B+Z)letraz-utils/api/proto/letraz/v1;letrazv1b\x06proto3"
 
 var (
 	file_api_proto_letraz_v1_resume_callback_proto_rawDescOnce sync.Once
@@ -813,39 +1194,48 @@ func file_api_proto_letraz_v1_resume_callback_proto_rawDescGZIP() []byte {
 	return file_api_proto_letraz_v1_resume_callback_proto_rawDescData
 }
 
-var file_api_proto_letraz_v1_resume_callback_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_proto_letraz_v1_resume_callback_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_proto_letraz_v1_resume_callback_proto_goTypes = []any{
 	(*DataRequest)(nil),                        // 0: letraz_server.RESUME.DataRequest
 	(*GenerateScreenshotCallBackRequest)(nil),  // 1: letraz_server.RESUME.GenerateScreenshotCallBackRequest
 	(*GenerateScreenshotCallBackResponse)(nil), // 2: letraz_server.RESUME.GenerateScreenshotCallBackResponse
-	(*MetadataRequest)(nil),                    // 3: letraz_server.RESUME.MetadataRequest
-	(*ScreenshotDataRequest)(nil),              // 4: letraz_server.RESUME.ScreenshotDataRequest
-	(*ScreenshotMetadataRequest)(nil),          // 5: letraz_server.RESUME.ScreenshotMetadataRequest
-	(*SectionRequest)(nil),                     // 6: letraz_server.RESUME.SectionRequest
-	(*SuggestionRequest)(nil),                  // 7: letraz_server.RESUME.SuggestionRequest
-	(*TailorResumeCallBackRequest)(nil),        // 8: letraz_server.RESUME.TailorResumeCallBackRequest
-	(*TailorResumeCallBackResponse)(nil),       // 9: letraz_server.RESUME.TailorResumeCallBackResponse
-	(*TailoredResumeRequest)(nil),              // 10: letraz_server.RESUME.TailoredResumeRequest
-	(*structpb.Struct)(nil),                    // 11: google.protobuf.Struct
+	(*InterviewQuestionRequest)(nil),           // 3: letraz_server.RESUME.InterviewQuestionRequest
+	(*InterviewQuestionsCallBackRequest)(nil),  // 4: letraz_server.RESUME.InterviewQuestionsCallBackRequest
+	(*InterviewQuestionsCallBackResponse)(nil), // 5: letraz_server.RESUME.InterviewQuestionsCallBackResponse
+	(*InterviewQuestionsDataRequest)(nil),      // 6: letraz_server.RESUME.InterviewQuestionsDataRequest
+	(*MetadataRequest)(nil),                    // 7: letraz_server.RESUME.MetadataRequest
+	(*ScreenshotDataRequest)(nil),              // 8: letraz_server.RESUME.ScreenshotDataRequest
+	(*ScreenshotMetadataRequest)(nil),          // 9: letraz_server.RESUME.ScreenshotMetadataRequest
+	(*SectionRequest)(nil),                     // 10: letraz_server.RESUME.SectionRequest
+	(*SuggestionRequest)(nil),                  // 11: letraz_server.RESUME.SuggestionRequest
+	(*TailorResumeCallBackRequest)(nil),        // 12: letraz_server.RESUME.TailorResumeCallBackRequest
+	(*TailorResumeCallBackResponse)(nil),       // 13: letraz_server.RESUME.TailorResumeCallBackResponse
+	(*TailoredResumeRequest)(nil),              // 14: letraz_server.RESUME.TailoredResumeRequest
+	(*structpb.Struct)(nil),                    // 15: google.protobuf.Struct
 }
 var file_api_proto_letraz_v1_resume_callback_proto_depIdxs = []int32{
-	10, // 0: letraz_server.RESUME.DataRequest.tailored_resume:type_name -> letraz_server.RESUME.TailoredResumeRequest
-	7,  // 1: letraz_server.RESUME.DataRequest.suggestions:type_name -> letraz_server.RESUME.SuggestionRequest
-	4,  // 2: letraz_server.RESUME.GenerateScreenshotCallBackRequest.data:type_name -> letraz_server.RESUME.ScreenshotDataRequest
-	5,  // 3: letraz_server.RESUME.GenerateScreenshotCallBackRequest.metadata:type_name -> letraz_server.RESUME.ScreenshotMetadataRequest
-	11, // 4: letraz_server.RESUME.SectionRequest.data:type_name -> google.protobuf.Struct
-	0,  // 5: letraz_server.RESUME.TailorResumeCallBackRequest.data:type_name -> letraz_server.RESUME.DataRequest
-	3,  // 6: letraz_server.RESUME.TailorResumeCallBackRequest.metadata:type_name -> letraz_server.RESUME.MetadataRequest
-	6,  // 7: letraz_server.RESUME.TailoredResumeRequest.sections:type_name -> letraz_server.RESUME.SectionRequest
-	1,  // 8: letraz_server.RESUME.GenerateScreenshotCallBackController.GenerateScreenshotCallBack:input_type -> letraz_server.RESUME.GenerateScreenshotCallBackRequest
-	8,  // 9: letraz_server.RESUME.TailorResumeCallBackController.TailorResumeCallBack:input_type -> letraz_server.RESUME.TailorResumeCallBackRequest
-	2,  // 10: letraz_server.RESUME.GenerateScreenshotCallBackController.GenerateScreenshotCallBack:output_type -> letraz_server.RESUME.GenerateScreenshotCallBackResponse
-	9,  // 11: letraz_server.RESUME.TailorResumeCallBackController.TailorResumeCallBack:output_type -> letraz_server.RESUME.TailorResumeCallBackResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	14, // 0: letraz_server.RESUME.DataRequest.tailored_resume:type_name -> letraz_server.RESUME.TailoredResumeRequest
+	11, // 1: letraz_server.RESUME.DataRequest.suggestions:type_name -> letraz_server.RESUME.SuggestionRequest
+	8,  // 2: letraz_server.RESUME.GenerateScreenshotCallBackRequest.data:type_name -> letraz_server.RESUME.ScreenshotDataRequest
+	9,  // 3: letraz_server.RESUME.GenerateScreenshotCallBackRequest.metadata:type_name -> letraz_server.RESUME.ScreenshotMetadataRequest
+	6,  // 4: letraz_server.RESUME.InterviewQuestionsCallBackRequest.data:type_name -> letraz_server.RESUME.InterviewQuestionsDataRequest
+	7,  // 5: letraz_server.RESUME.InterviewQuestionsCallBackRequest.metadata:type_name -> letraz_server.RESUME.MetadataRequest
+	3,  // 6: letraz_server.RESUME.InterviewQuestionsDataRequest.questions:type_name -> letraz_server.RESUME.InterviewQuestionRequest
+	15, // 7: letraz_server.RESUME.SectionRequest.data:type_name -> google.protobuf.Struct
+	0,  // 8: letraz_server.RESUME.TailorResumeCallBackRequest.data:type_name -> letraz_server.RESUME.DataRequest
+	7,  // 9: letraz_server.RESUME.TailorResumeCallBackRequest.metadata:type_name -> letraz_server.RESUME.MetadataRequest
+	10, // 10: letraz_server.RESUME.TailoredResumeRequest.sections:type_name -> letraz_server.RESUME.SectionRequest
+	1,  // 11: letraz_server.RESUME.GenerateScreenshotCallBackController.GenerateScreenshotCallBack:input_type -> letraz_server.RESUME.GenerateScreenshotCallBackRequest
+	12, // 12: letraz_server.RESUME.TailorResumeCallBackController.TailorResumeCallBack:input_type -> letraz_server.RESUME.TailorResumeCallBackRequest
+	4,  // 13: letraz_server.RESUME.InterviewQuestionsCallBackController.InterviewQuestionsCallBack:input_type -> letraz_server.RESUME.InterviewQuestionsCallBackRequest
+	2,  // 14: letraz_server.RESUME.GenerateScreenshotCallBackController.GenerateScreenshotCallBack:output_type -> letraz_server.RESUME.GenerateScreenshotCallBackResponse
+	13, // 15: letraz_server.RESUME.TailorResumeCallBackController.TailorResumeCallBack:output_type -> letraz_server.RESUME.TailorResumeCallBackResponse
+	5,  // 16: letraz_server.RESUME.InterviewQuestionsCallBackController.InterviewQuestionsCallBack:output_type -> letraz_server.RESUME.InterviewQuestionsCallBackResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_letraz_v1_resume_callback_proto_init() }
@@ -853,17 +1243,22 @@ func file_api_proto_letraz_v1_resume_callback_proto_init() {
 	if File_api_proto_letraz_v1_resume_callback_proto != nil {
 		return
 	}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[1].OneofWrappers = []any{}
 	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[2].OneofWrappers = []any{}
-	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[9].OneofWrappers = []any{}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[4].OneofWrappers = []any{}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[5].OneofWrappers = []any{}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[7].OneofWrappers = []any{}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[12].OneofWrappers = []any{}
+	file_api_proto_letraz_v1_resume_callback_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_letraz_v1_resume_callback_proto_rawDesc), len(file_api_proto_letraz_v1_resume_callback_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_api_proto_letraz_v1_resume_callback_proto_goTypes,
 		DependencyIndexes: file_api_proto_letraz_v1_resume_callback_proto_depIdxs,
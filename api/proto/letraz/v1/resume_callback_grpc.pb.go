@@ -223,3 +223,106 @@ var TailorResumeCallBackController_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/letraz/v1/resume_callback.proto",
 }
+
+const (
+	InterviewQuestionsCallBackController_InterviewQuestionsCallBack_FullMethodName = "/letraz_server.RESUME.InterviewQuestionsCallBackController/InterviewQuestionsCallBack"
+)
+
+// InterviewQuestionsCallBackControllerClient is the client API for InterviewQuestionsCallBackController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InterviewQuestionsCallBackControllerClient interface {
+	InterviewQuestionsCallBack(ctx context.Context, in *InterviewQuestionsCallBackRequest, opts ...grpc.CallOption) (*InterviewQuestionsCallBackResponse, error)
+}
+
+type interviewQuestionsCallBackControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInterviewQuestionsCallBackControllerClient(cc grpc.ClientConnInterface) InterviewQuestionsCallBackControllerClient {
+	return &interviewQuestionsCallBackControllerClient{cc}
+}
+
+func (c *interviewQuestionsCallBackControllerClient) InterviewQuestionsCallBack(ctx context.Context, in *InterviewQuestionsCallBackRequest, opts ...grpc.CallOption) (*InterviewQuestionsCallBackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InterviewQuestionsCallBackResponse)
+	err := c.cc.Invoke(ctx, InterviewQuestionsCallBackController_InterviewQuestionsCallBack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InterviewQuestionsCallBackControllerServer is the server API for InterviewQuestionsCallBackController service.
+// All implementations must embed UnimplementedInterviewQuestionsCallBackControllerServer
+// for forward compatibility.
+type InterviewQuestionsCallBackControllerServer interface {
+	InterviewQuestionsCallBack(context.Context, *InterviewQuestionsCallBackRequest) (*InterviewQuestionsCallBackResponse, error)
+	mustEmbedUnimplementedInterviewQuestionsCallBackControllerServer()
+}
+
+// UnimplementedInterviewQuestionsCallBackControllerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInterviewQuestionsCallBackControllerServer struct{}
+
+func (UnimplementedInterviewQuestionsCallBackControllerServer) InterviewQuestionsCallBack(context.Context, *InterviewQuestionsCallBackRequest) (*InterviewQuestionsCallBackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InterviewQuestionsCallBack not implemented")
+}
+func (UnimplementedInterviewQuestionsCallBackControllerServer) mustEmbedUnimplementedInterviewQuestionsCallBackControllerServer() {
+}
+func (UnimplementedInterviewQuestionsCallBackControllerServer) testEmbeddedByValue() {}
+
+// UnsafeInterviewQuestionsCallBackControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InterviewQuestionsCallBackControllerServer will
+// result in compilation errors.
+type UnsafeInterviewQuestionsCallBackControllerServer interface {
+	mustEmbedUnimplementedInterviewQuestionsCallBackControllerServer()
+}
+
+func RegisterInterviewQuestionsCallBackControllerServer(s grpc.ServiceRegistrar, srv InterviewQuestionsCallBackControllerServer) {
+	// If the following call pancis, it indicates UnimplementedInterviewQuestionsCallBackControllerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InterviewQuestionsCallBackController_ServiceDesc, srv)
+}
+
+func _InterviewQuestionsCallBackController_InterviewQuestionsCallBack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterviewQuestionsCallBackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InterviewQuestionsCallBackControllerServer).InterviewQuestionsCallBack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InterviewQuestionsCallBackController_InterviewQuestionsCallBack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InterviewQuestionsCallBackControllerServer).InterviewQuestionsCallBack(ctx, req.(*InterviewQuestionsCallBackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InterviewQuestionsCallBackController_ServiceDesc is the grpc.ServiceDesc for InterviewQuestionsCallBackController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InterviewQuestionsCallBackController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "letraz_server.RESUME.InterviewQuestionsCallBackController",
+	HandlerType: (*InterviewQuestionsCallBackControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InterviewQuestionsCallBack",
+			Handler:    _InterviewQuestionsCallBackController_InterviewQuestionsCallBack_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/letraz/v1/resume_callback.proto",
+}
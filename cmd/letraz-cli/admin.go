@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func newUsageCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "usage",
+		Short: "Print the daily and monthly usage of the configured API key",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getAndPrint(cmd, global, "/api/v1/usage")
+		},
+	}
+}
+
+func newHealthCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "health",
+		Short: "Print the service health",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getAndPrint(cmd, global, "/health")
+		},
+	}
+}
+
+func newAdminCommand(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Operate the service through the admin endpoints (requires --admin-token)",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := global.resolve(cmd); err != nil {
+				return err
+			}
+			if global.adminToken == "" {
+				return fmt.Errorf("an admin token is required; set --admin-token or LETRAZ_ADMIN_TOKEN")
+			}
+			return nil
+		},
+	}
+
+	workers := &cobra.Command{
+		Use:   "workers",
+		Short: "Manage the scraper worker pool",
+	}
+
+	var pool string
+	resize := &cobra.Command{
+		Use:   "resize <size>",
+		Short: "Set the target number of scraper workers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			size, err := strconv.Atoi(args[0])
+			if err != nil || size < 1 {
+				return fmt.Errorf("size must be a positive integer")
+			}
+			return sendAndPrint(cmd, global, http.MethodPut, "/api/v1/admin/workers/size", map[string]interface{}{"size": size, "pool": pool})
+		},
+	}
+	resize.Flags().StringVar(&pool, "pool", "browser", "worker pool to resize: browser or api")
+
+	var reason string
+	pause := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause job dispatch; submissions are still queued",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodPost, "/api/v1/admin/workers/pause", map[string]string{"reason": reason})
+		},
+	}
+	pause.Flags().StringVar(&reason, "reason", "", "reason recorded with the pause")
+
+	resume := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume job dispatch",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodPost, "/api/v1/admin/workers/resume", nil)
+		},
+	}
+	workers.AddCommand(resize, pause, resume)
+
+	cooldowns := &cobra.Command{
+		Use:   "cooldowns",
+		Short: "List domains in cooldown",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getAndPrint(cmd, global, "/api/v1/admin/domains/cooldowns")
+		},
+	}
+
+	clearCooldown := &cobra.Command{
+		Use:   "clear-cooldown <domain>",
+		Short: "Lift the cooldown of a domain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodDelete, "/api/v1/admin/domains/"+url.PathEscape(args[0])+"/cooldown", nil)
+		},
+	}
+
+	browsers := &cobra.Command{
+		Use:   "browsers",
+		Short: "Manage the browser pool used for screenshots",
+	}
+
+	listBrowsers := &cobra.Command{
+		Use:   "list",
+		Short: "List live browsers with their age, usage and health",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getAndPrint(cmd, global, "/api/v1/admin/browsers")
+		},
+	}
+
+	closeBrowser := &cobra.Command{
+		Use:   "close <browser-id>",
+		Short: "Force-close a browser, even one in use",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodDelete, "/api/v1/admin/browsers/"+url.PathEscape(args[0]), nil)
+		},
+	}
+
+	drainBrowsers := &cobra.Command{
+		Use:   "drain",
+		Short: "Replace every browser; browsers in use are closed when released",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodPost, "/api/v1/admin/browsers/drain", nil)
+		},
+	}
+
+	cleanupBrowsers := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Close stuck and unhealthy browsers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendAndPrint(cmd, global, http.MethodPost, "/api/v1/admin/browsers/cleanup", nil)
+		},
+	}
+	browsers.AddCommand(listBrowsers, closeBrowser, drainBrowsers, cleanupBrowsers)
+
+	usage := &cobra.Command{
+		Use:   "usage <key-name>",
+		Short: "Print the usage of any configured API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getAndPrint(cmd, global, "/api/v1/admin/usage/"+url.PathEscape(args[0]))
+		},
+	}
+
+	var date, month string
+	llmUsage := &cobra.Command{
+		Use:   "llm-usage",
+		Short: "Print LLM token usage and estimated spend per operation and provider",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			if date != "" {
+				query.Set("date", date)
+			}
+			if month != "" {
+				query.Set("month", month)
+			}
+			path := "/api/v1/usage/llm"
+			if len(query) > 0 {
+				path += "?" + query.Encode()
+			}
+			return getAndPrint(cmd, global, path)
+		},
+	}
+	llmUsage.Flags().StringVar(&date, "date", "", "day to report as YYYY-MM-DD (default today)")
+	llmUsage.Flags().StringVar(&month, "month", "", "month to report as YYYY-MM (default this month)")
+
+	var drainStatus bool
+	drain := &cobra.Command{
+		Use:   "drain",
+		Short: "Stop taking background tasks and let queued and running ones finish before a deployment",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if drainStatus {
+				return getAndPrint(cmd, global, "/api/v1/admin/drain")
+			}
+			return sendAndPrint(cmd, global, http.MethodPost, "/api/v1/admin/drain", nil)
+		},
+	}
+	drain.Flags().BoolVar(&drainStatus, "status", false, "only print the progress of the current drain")
+
+	cmd.AddCommand(workers, browsers, cooldowns, clearCooldown, drain, usage, llmUsage)
+	return cmd
+}
+
+// getAndPrint fetches path and prints the JSON response
+func getAndPrint(cmd *cobra.Command, global *globalOptions, path string) error {
+	return sendAndPrint(cmd, global, http.MethodGet, path, nil)
+}
+
+// sendAndPrint sends a request and prints the JSON response, including error bodies
+func sendAndPrint(cmd *cobra.Command, global *globalOptions, method, path string, body interface{}) error {
+	data, err := newClient(global).do(cmd.Context(), method, path, body)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && len(data) > 0 {
+		printJSON(data)
+		return fmt.Errorf("server returned %d", apiErr.StatusCode)
+	}
+	if err != nil {
+		return err
+	}
+	printJSON(data)
+	return nil
+}
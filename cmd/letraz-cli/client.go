@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client calls the letraz-utils HTTP API
+type client struct {
+	server     string
+	apiKey     string
+	apiKeyHdr  string
+	adminToken string
+	http       *http.Client
+}
+
+// apiError is returned for non-2xx responses
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("server returned %d: %s", e.StatusCode, strings.TrimSpace(e.Body))
+}
+
+// newClient creates an API client from the global options
+func newClient(opts *globalOptions) *client {
+	return &client{
+		server:     strings.TrimRight(opts.server, "/"),
+		apiKey:     opts.apiKey,
+		apiKeyHdr:  opts.apiKeyHeader,
+		adminToken: opts.adminToken,
+		http:       &http.Client{Timeout: opts.timeout},
+	}
+}
+
+// do sends a request with an optional JSON body and returns the raw response body
+func (c *client) do(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set(c.apiKeyHdr, c.apiKey)
+	}
+	if c.adminToken != "" && strings.HasPrefix(path, "/api/v1/admin/") {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return data, &apiError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+	return data, nil
+}
+
+// submit posts a task and returns its process ID
+func (c *client) submit(ctx context.Context, path string, body interface{}) (string, []byte, error) {
+	data, err := c.do(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return "", data, err
+	}
+
+	var accepted struct {
+		ProcessID string `json:"processId"`
+	}
+	if err := json.Unmarshal(data, &accepted); err != nil {
+		return "", data, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return accepted.ProcessID, data, nil
+}
+
+// taskEvent is a single timeline event
+type taskEvent struct {
+	Type      string                 `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// taskTimeline is the status and event history of a task
+type taskTimeline struct {
+	ProcessID string      `json:"processId"`
+	Status    string      `json:"status,omitempty"`
+	Events    []taskEvent `json:"events"`
+	Count     int         `json:"count"`
+}
+
+// timeline fetches the status and events of a task
+func (c *client) timeline(ctx context.Context, processID string) (*taskTimeline, error) {
+	data, err := c.do(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(processID)+"/timeline", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeline taskTimeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		return nil, fmt.Errorf("failed to decode timeline: %w", err)
+	}
+	return &timeline, nil
+}
+
+// taskResult fetches the stored result of a task as raw JSON
+func (c *client) taskResult(ctx context.Context, processID string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(processID), nil)
+}
+
+// isTerminal reports whether a task status is final
+func isTerminal(status string) bool {
+	return status == "SUCCESS" || status == "FAILURE"
+}
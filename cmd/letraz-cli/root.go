@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// globalOptions are shared by every command
+type globalOptions struct {
+	configPath   string
+	server       string
+	apiKey       string
+	apiKeyHeader string
+	adminToken   string
+	timeout      time.Duration
+}
+
+// fileConfig is the optional CLI configuration file
+type fileConfig struct {
+	Server       string `yaml:"server"`
+	APIKey       string `yaml:"api_key"`
+	APIKeyHeader string `yaml:"api_key_header"`
+	AdminToken   string `yaml:"admin_token"`
+}
+
+// newRootCommand builds the letraz-cli command tree
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+
+	root := &cobra.Command{
+		Use:          "letraz-cli",
+		Short:        "Command line client for the letraz-utils service",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.resolve(cmd)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", "", "config file (default $HOME/.letraz-cli.yaml)")
+	flags.StringVar(&opts.server, "server", "http://localhost:8080", "server base URL (LETRAZ_SERVER)")
+	flags.StringVar(&opts.apiKey, "api-key", "", "API key for metered endpoints (LETRAZ_API_KEY)")
+	flags.StringVar(&opts.apiKeyHeader, "api-key-header", "X-API-Key", "header that carries the API key")
+	flags.StringVar(&opts.adminToken, "admin-token", "", "bearer token for admin endpoints (LETRAZ_ADMIN_TOKEN)")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "HTTP request timeout")
+
+	root.AddCommand(
+		newScrapeCommand(opts),
+		newBatchScrapeCommand(opts),
+		newTailorCommand(opts),
+		newScreenshotCommand(opts),
+		newTaskCommand(opts),
+		newUsageCommand(opts),
+		newHealthCommand(opts),
+		newAdminCommand(opts),
+	)
+	return root
+}
+
+// resolve fills options that were not set by flags from the environment, then the config file
+func (o *globalOptions) resolve(cmd *cobra.Command) error {
+	var file fileConfig
+	path := o.configPath
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".letraz-cli.yaml")
+		}
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case !errors.Is(err, os.ErrNotExist) || o.configPath != "":
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	flags := cmd.Flags()
+	resolveString(flags.Changed("server"), &o.server, "LETRAZ_SERVER", file.Server)
+	resolveString(flags.Changed("api-key"), &o.apiKey, "LETRAZ_API_KEY", file.APIKey)
+	resolveString(flags.Changed("api-key-header"), &o.apiKeyHeader, "", file.APIKeyHeader)
+	resolveString(flags.Changed("admin-token"), &o.adminToken, "LETRAZ_ADMIN_TOKEN", file.AdminToken)
+	return nil
+}
+
+// resolveString applies the environment variable, then the file value, unless a flag was set
+func resolveString(flagSet bool, target *string, env string, fileValue string) {
+	if flagSet {
+		return
+	}
+	if env != "" {
+		if value := os.Getenv(env); value != "" {
+			*target = value
+			return
+		}
+	}
+	if fileValue != "" {
+		*target = fileValue
+	}
+}
+
+// printJSON pretty-prints a JSON response body, falling back to the raw text
+func printJSON(data []byte) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Println(out.String())
+}
+
+// printValue pretty-prints any value as JSON
+func printValue(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"letraz-utils/pkg/models"
+)
+
+// waitOptions control polling after a submission
+type waitOptions struct {
+	wait     bool
+	interval time.Duration
+}
+
+// addWaitFlags registers --wait and --interval on a submit command
+func addWaitFlags(cmd *cobra.Command, opts *waitOptions) {
+	cmd.Flags().BoolVar(&opts.wait, "wait", false, "poll until the task finishes and print its events")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "polling interval with --wait")
+}
+
+// finishSubmit prints the accepted response, then follows the task when --wait is set
+func finishSubmit(cmd *cobra.Command, c *client, processID string, data []byte, opts *waitOptions) error {
+	printJSON(data)
+	if !opts.wait || processID == "" {
+		return nil
+	}
+	return followTask(cmd.Context(), c, processID, opts.interval, true)
+}
+
+func newScrapeCommand(global *globalOptions) *cobra.Command {
+	var (
+		description string
+		engine      string
+		priority    string
+		model       string
+		noCache     bool
+		refresh     bool
+		wait        waitOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scrape [url]",
+		Short: "Submit a job scrape from a URL or a job description",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := models.ScrapeRequest{Description: description}
+			if len(args) == 1 {
+				req.URL = args[0]
+			}
+			if req.URL == "" && req.Description == "" {
+				return fmt.Errorf("a URL argument or --description is required")
+			}
+			if engine != "" || priority != "" || model != "" || noCache || refresh {
+				req.Options = &models.ScrapeOptions{Engine: engine, Priority: priority, Model: model, NoCache: noCache, ForceRefresh: refresh}
+			}
+
+			c := newClient(global)
+			processID, data, err := c.submit(cmd.Context(), "/api/v1/scrape", req)
+			if err != nil {
+				return err
+			}
+			return finishSubmit(cmd, c, processID, data, &wait)
+		},
+	}
+
+	cmd.Flags().StringVar(&description, "description", "", "job description text to extract instead of scraping a URL")
+	cmd.Flags().StringVar(&engine, "engine", "", "scraper engine: hybrid, firecrawl, headed, brightdata, stub or auto")
+	cmd.Flags().StringVar(&priority, "priority", "", "queue priority: high, normal or low")
+	cmd.Flags().StringVar(&model, "model", "", "LLM model for extraction, one of the server's allowed models")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "skip the LLM extraction cache and re-extract the posting")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "scrape the posting again instead of reusing a cached job")
+	addWaitFlags(cmd, &wait)
+	return cmd
+}
+
+func newBatchScrapeCommand(global *globalOptions) *cobra.Command {
+	var (
+		file    string
+		engine  string
+		model   string
+		noCache bool
+		refresh bool
+		wait    waitOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scrape-batch [url...]",
+		Short: "Submit a scrape of several job URLs as one task",
+		Long:  "Submit a batch scrape. URLs come from the arguments and from --file, one per line; use - to read them from stdin.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			urls := append([]string(nil), args...)
+			if file != "" {
+				var reader io.Reader = os.Stdin
+				if file != "-" {
+					f, err := os.Open(file)
+					if err != nil {
+						return fmt.Errorf("failed to open URL file: %w", err)
+					}
+					defer f.Close()
+					reader = f
+				}
+
+				data, err := io.ReadAll(reader)
+				if err != nil {
+					return fmt.Errorf("failed to read URL file: %w", err)
+				}
+				for _, line := range strings.Split(string(data), "\n") {
+					if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+						urls = append(urls, line)
+					}
+				}
+			}
+			if len(urls) == 0 {
+				return fmt.Errorf("at least one URL argument or --file is required")
+			}
+
+			req := models.BatchScrapeRequest{URLs: urls}
+			if engine != "" || model != "" || noCache || refresh {
+				req.Options = &models.ScrapeOptions{Engine: engine, Model: model, NoCache: noCache, ForceRefresh: refresh}
+			}
+
+			c := newClient(global)
+			processID, data, err := c.submit(cmd.Context(), "/api/v1/scrape/batch", req)
+			if err != nil {
+				return err
+			}
+			if err := finishSubmit(cmd, c, processID, data, &wait); err != nil || !wait.wait {
+				return err
+			}
+
+			result, err := c.taskResult(cmd.Context(), processID)
+			if err != nil {
+				return err
+			}
+			printJSON(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file with one URL per line, or - for stdin")
+	cmd.Flags().StringVar(&engine, "engine", "", "scraper engine: hybrid, firecrawl, headed, brightdata, stub or auto")
+	cmd.Flags().StringVar(&model, "model", "", "LLM model for extraction, one of the server's allowed models")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "skip the LLM extraction cache and re-extract the postings")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "scrape the postings again instead of reusing cached jobs")
+	addWaitFlags(cmd, &wait)
+	return cmd
+}
+
+func newTailorCommand(global *globalOptions) *cobra.Command {
+	var (
+		file string
+		wait waitOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tailor",
+		Short: "Submit a resume tailoring from a JSON request file",
+		Long:  "Submit a resume tailoring. The file holds a tailor request with base_resume, job and resume_id; use - to read it from stdin.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var reader io.Reader = os.Stdin
+			if file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					return fmt.Errorf("failed to open request file: %w", err)
+				}
+				defer f.Close()
+				reader = f
+			}
+
+			var req models.TailorResumeRequest
+			if err := json.NewDecoder(reader).Decode(&req); err != nil {
+				return fmt.Errorf("invalid tailor request: %w", err)
+			}
+
+			c := newClient(global)
+			processID, data, err := c.submit(cmd.Context(), "/api/v1/resume/tailor", req)
+			if err != nil {
+				return err
+			}
+			return finishSubmit(cmd, c, processID, data, &wait)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "tailor request JSON file, or - for stdin")
+	_ = cmd.MarkFlagRequired("file")
+	addWaitFlags(cmd, &wait)
+	return cmd
+}
+
+func newScreenshotCommand(global *globalOptions) *cobra.Command {
+	var wait waitOptions
+
+	cmd := &cobra.Command{
+		Use:   "screenshot <resume-id>",
+		Short: "Submit a resume screenshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := newClient(global)
+			processID, data, err := c.submit(cmd.Context(), "/api/v1/resume/screenshot", models.ResumeScreenshotRequest{ResumeID: args[0]})
+			if err != nil {
+				return err
+			}
+			return finishSubmit(cmd, c, processID, data, &wait)
+		},
+	}
+
+	addWaitFlags(cmd, &wait)
+	return cmd
+}
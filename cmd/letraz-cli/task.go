@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newTaskCommand(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Inspect background tasks",
+	}
+
+	var interval time.Duration
+
+	status := &cobra.Command{
+		Use:   "status <process-id>",
+		Short: "Print the status and event timeline of a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			timeline, err := newClient(global).timeline(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printValue(timeline)
+		},
+	}
+
+	result := &cobra.Command{
+		Use:   "result <process-id>",
+		Short: "Print the stored result of a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := newClient(global).taskResult(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			printJSON(data)
+			return nil
+		},
+	}
+
+	wait := &cobra.Command{
+		Use:   "wait <process-id>",
+		Short: "Poll a task until it finishes; exits non-zero when it fails",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return followTask(cmd.Context(), newClient(global), args[0], interval, false)
+		},
+	}
+	wait.Flags().DurationVar(&interval, "interval", 2*time.Second, "polling interval")
+
+	var follow bool
+	events := &cobra.Command{
+		Use:   "events <process-id>",
+		Short: "Print task events, optionally following new ones until the task finishes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := newClient(global)
+			if follow {
+				return followTask(cmd.Context(), c, args[0], interval, true)
+			}
+
+			timeline, err := c.timeline(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			for _, event := range timeline.Events {
+				printEvent(event)
+			}
+			return nil
+		},
+	}
+	events.Flags().BoolVarP(&follow, "follow", "f", false, "keep polling for new events until the task finishes")
+	events.Flags().DurationVar(&interval, "interval", 2*time.Second, "polling interval with --follow")
+
+	cmd.AddCommand(status, result, wait, events)
+	return cmd
+}
+
+// followTask polls a task until it reaches a terminal status, printing new events as they are
+// recorded when printEvents is set. A failed task is reported as an error.
+func followTask(ctx context.Context, c *client, processID string, interval time.Duration, printEvents bool) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	printed := 0
+	for {
+		timeline, err := c.timeline(ctx, processID)
+		var apiErr *apiError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			// The timeline may not exist yet right after submission
+		case err != nil:
+			return err
+		default:
+			if printEvents {
+				for ; printed < len(timeline.Events); printed++ {
+					printEvent(timeline.Events[printed])
+				}
+			}
+			if isTerminal(timeline.Status) {
+				fmt.Printf("task %s finished with status %s\n", processID, timeline.Status)
+				if timeline.Status == "FAILURE" {
+					return fmt.Errorf("task %s failed", processID)
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// printEvent prints one timeline event on a single line
+func printEvent(event taskEvent) {
+	keys := make([]string, 0, len(event.Details))
+	for key := range event.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	details := make([]string, 0, len(keys))
+	for _, key := range keys {
+		details = append(details, fmt.Sprintf("%s=%v", key, event.Details[key]))
+	}
+
+	fmt.Printf("%s  %-24s %s\n", event.Timestamp.Local().Format("15:04:05.000"), event.Type, strings.Join(details, " "))
+}
@@ -10,14 +10,36 @@ import (
 	"time"
 
 	"letraz-utils/internal/api/routes"
+	"letraz-utils/internal/archive"
+	"letraz-utils/internal/audit"
 	"letraz-utils/internal/background"
 	"letraz-utils/internal/callback"
+	"letraz-utils/internal/chaos"
+	"letraz-utils/internal/company"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/crawl"
+	"letraz-utils/internal/embeddings"
+	"letraz-utils/internal/health"
+	"letraz-utils/internal/jobmonitor"
+	"letraz-utils/internal/jobsearch"
+	"letraz-utils/internal/kv"
 	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/llm/prompts"
 	"letraz-utils/internal/logging"
+	"letraz-utils/internal/metrics"
 	"letraz-utils/internal/mux"
+	"letraz-utils/internal/quota"
+	"letraz-utils/internal/scraper/captcha"
+	"letraz-utils/internal/scraper/engines/firecrawl"
 	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/internal/scraper/policy"
+	"letraz-utils/internal/scraper/proxy"
+	"letraz-utils/internal/scraper/routing"
+	"letraz-utils/internal/scraper/session"
 	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/pkg/utils"
 
 	"github.com/labstack/echo/v4"
 )
@@ -38,6 +60,36 @@ func main() {
 	// Get the new logger instance
 	logger := logging.GetGlobalLogger()
 	logger.Info("Starting Letraz Utils Service")
+	if cfg.TestMode.Enabled {
+		logger.Warn("Test mode enabled: using the mock LLM provider, stub scraper and in-memory storage", nil)
+	}
+
+	// Initialize API audit logging
+	if err := audit.InitializeAuditing(cfg); err != nil {
+		logger.Error("Failed to initialize audit logging", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	defer audit.CloseAuditing()
+
+	// Initialize per-process event timelines
+	timeline.InitializeGlobalRecorder()
+
+	// Initialize fault injection; enabling it in production is a configuration error
+	if err := chaos.InitializeGlobalInjector(cfg); err != nil {
+		logger.Error("Failed to initialize chaos fault injection", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	// Initialize the proxy pool shared by the lite and Rod engines; without configured proxies
+	// requests go out directly
+	proxyPool := proxy.InitializeGlobalPool(cfg)
+
+	// Initialize the captcha providers, tried in order until one solves the captcha
+	captchaRegistry := captcha.InitializeGlobalRegistry(cfg)
+
+	// Initialize the scraping policy consulted before every scrape: domain allow and deny lists
+	// and robots.txt
+	policy.InitializeGlobalChecker(cfg)
 
 	// Initialize global browser pool for screenshot generation
 	logger.Info("Initializing global browser pool for screenshot generation")
@@ -47,6 +99,12 @@ func main() {
 	}
 	logger.Info("Global browser pool initialized successfully")
 
+	// Load the versioned LLM prompt templates; a missing or invalid template is a configuration error
+	if _, err := prompts.InitializeGlobalLibrary(cfg); err != nil {
+		logger.Error("Failed to load LLM prompt templates", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
 	// Initialize LLM manager
 	llmManager := llm.NewManager(cfg)
 	if err := llmManager.Start(); err != nil {
@@ -54,6 +112,26 @@ func main() {
 		return
 	}
 
+	// Initialize the embedder used for relevance pre-screening; without one the endpoint reports
+	// itself unavailable
+	embedder, err := embeddings.NewEmbedder(cfg)
+	if err != nil {
+		logger.Warn("Embeddings unavailable - relevance pre-screening disabled", map[string]interface{}{"error": err.Error()})
+	}
+
+	// Initialize cached dependency health checks; the LLM probe is billed, so seed it with the
+	// startup result and refresh it on its own, slower interval
+	healthChecker := health.InitializeGlobalChecker(cfg)
+	healthChecker.Register("llm", cfg.Health.LLMRefreshInterval, llmManager.CheckHealth)
+	healthChecker.SetResult("llm", llmManager.Health())
+
+	// Initialize monitoring service and register the LLM manager with it
+	monitoringService := logging.NewMonitoringService(logger, logging.NewMonitoringConfig(cfg))
+	monitoringService.AddComponent(llmManager)
+	if err := monitoringService.Start(); err != nil {
+		logger.Error("Failed to start monitoring service, proceeding without it", map[string]interface{}{"error": err.Error()})
+	}
+
 	// Initialize callback client if enabled
 	var callbackClient *callback.Client
 	if cfg.Callback.Enabled && cfg.Callback.ServerAddress != "" {
@@ -81,24 +159,41 @@ func main() {
 		logger.Info("Callback support disabled or no server address configured")
 	}
 
-	// Initialize background task manager with callback support
-	logger.Info("Initializing background task manager")
-	var taskManager background.TaskManager
-	if callbackClient != nil {
-		taskManager = background.NewTaskManagerWithCallback(cfg, callbackClient)
-	} else {
-		taskManager = background.NewTaskManager(cfg)
+	// Initialize the shared Redis client; an unreachable Redis only disables conversation history,
+	// and the pool reconnects on its own once Redis comes back
+	redisClient := utils.NewRedisClient(cfg)
+	if err := redisClient.Health(); err != nil {
+		logger.Warn("Redis is not reachable at startup, conversation history disabled until it recovers", map[string]interface{}{
+			"mode":  cfg.Redis.Mode,
+			"error": err.Error(),
+		})
 	}
-
-	ctx := context.Background()
-	if err := taskManager.Start(ctx); err != nil {
-		logger.Error("Failed to start task manager", map[string]interface{}{"error": err.Error()})
-		return
+	monitoringService.AddStatsProvider("redis", redisClient)
+	if injector := chaos.GetGlobalInjector(); injector.IsEnabled() {
+		redisClient.Client().AddHook(chaos.NewRedisHook(injector))
+		monitoringService.AddStatsProvider("chaos", injector)
 	}
 
+	// Conversation history lives in the key-value store: Redis in production, in-memory locally
+	kvStore := kv.NewStore(cfg, redisClient.Client())
+	conversations := utils.NewConversationStore(cfg, kvStore)
+	quota.InitializeGlobalManager(cfg, kvStore)
+	cost.InitializeGlobalLedger(cfg, kvStore)
+	session.InitializeGlobalStore(cfg, kvStore)
+	engineRouter := routing.InitializeGlobalRouter(cfg, kvStore)
+	scrapeArchive := archive.InitializeGlobalArchive(cfg)
+	scrapeArchive.StartSweeper()
+	llmManager.EnableExtractionCache(kvStore)
+	conversations.StartSweeper()
+	logger.Info("Conversation history store initialized", map[string]interface{}{
+		"backend": kvStore.Backend(),
+	})
+
 	// Initialize worker pool
 	logger.Debug("DEBUG: About to initialize worker pool")
 	poolManager := workers.NewPoolManager(cfg, llmManager)
+	poolManager.SetRedisClient(redisClient)
+	poolManager.EnableResultCache(kvStore)
 	logger.Debug("DEBUG: PoolManager created")
 
 	if err := poolManager.Initialize(); err != nil {
@@ -113,11 +208,169 @@ func main() {
 		}
 	}()
 
+	// Crawls run on the scraper pool, from requests or from the shared task queue
+	crawler := crawl.NewCrawler(cfg, llmManager)
+
+	// Initialize background task manager with callback support
+	logger.Info("Initializing background task manager")
+	var taskManagerImpl *background.TaskManagerImpl
+	if callbackClient != nil {
+		taskManagerImpl = background.NewTaskManagerWithCallback(cfg, callbackClient)
+	} else {
+		taskManagerImpl = background.NewTaskManager(cfg)
+	}
+	taskManagerImpl.SetConversationStore(conversations)
+	switch cfg.BackgroundTasks.Store {
+	case background.TaskStorePostgres:
+		storeCtx, storeCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		taskStore, err := background.NewPostgresTaskStore(storeCtx, cfg)
+		storeCancel()
+		if err != nil {
+			logger.Error("Failed to open the Postgres task store", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		defer taskStore.Close()
+		taskManagerImpl.SetTaskStore(taskStore)
+		logger.Info("Storing task results in Postgres", map[string]interface{}{
+			"retention": cfg.BackgroundTasks.Postgres.Retention.String(),
+		})
+	case "", background.TaskStoreMemory:
+	default:
+		logger.Warn("Unknown task store, keeping task results in memory", map[string]interface{}{
+			"store": cfg.BackgroundTasks.Store,
+		})
+	}
+	if cfg.BackgroundTasks.PublishEvents {
+		taskManagerImpl.SetEventPublisher(background.NewRedisTaskEventPublisher(redisClient.Client(), cfg.BackgroundTasks.EventChannelPrefix))
+		logger.Info("Publishing task events over Redis", map[string]interface{}{
+			"channel_prefix": cfg.BackgroundTasks.EventChannelPrefix,
+		})
+	}
+	switch cfg.BackgroundTasks.Queue.Backend {
+	case background.TaskQueueRedis:
+		// Replicas only see the results of each other's tasks through a shared store
+		if cfg.BackgroundTasks.Store != background.TaskStorePostgres {
+			logger.Warn("The Redis task queue needs the Postgres task store, keeping the task queue in memory", map[string]interface{}{
+				"store": cfg.BackgroundTasks.Store,
+			})
+			break
+		}
+		taskManagerImpl.SetDistributedQueue(redisClient.Client(), background.TaskResources{
+			PoolManager: poolManager,
+			LLMManager:  llmManager,
+			Crawler:     crawler,
+		})
+	case "", background.TaskQueueMemory:
+	default:
+		logger.Warn("Unknown task queue backend, keeping the task queue in memory", map[string]interface{}{
+			"backend": cfg.BackgroundTasks.Queue.Backend,
+		})
+	}
+	if cfg.Firecrawl.Webhook.URL != "" {
+		if cfg.Firecrawl.Webhook.Secret == "" {
+			logger.Warn("Firecrawl webhook URL set without a signing secret, crawls are not handed to Firecrawl", map[string]interface{}{})
+		}
+		// The webhook may reach any replica, which relays events to the one running the crawl
+		taskManagerImpl.SetFirecrawlEventRelay(redisClient.Client(), cfg.BackgroundTasks.EventChannelPrefix)
+	}
+	var taskManager background.TaskManager = taskManagerImpl
+
+	ctx := context.Background()
+	if err := taskManager.Start(ctx); err != nil {
+		logger.Error("Failed to start task manager", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	healthChecker.Register("workers", cfg.Health.RefreshInterval, func(ctx context.Context) error {
+		if !poolManager.IsHealthy() {
+			return fmt.Errorf("worker pool is not running")
+		}
+		return nil
+	})
+	healthChecker.Register("browser_pool", cfg.Health.RefreshInterval, func(ctx context.Context) error {
+		globalPool, err := headed.GetGlobalBrowserPool()
+		if err != nil {
+			return err
+		}
+		if !globalPool.IsHealthy() {
+			return fmt.Errorf("browser pool is shutting down")
+		}
+		return nil
+	})
+	healthChecker.Register(background.HealthCheckName, cfg.Health.RefreshInterval, func(ctx context.Context) error {
+		return taskManager.DrainStatus().Err()
+	})
+	healthChecker.RegisterDetailed("redis", cfg.Health.RefreshInterval, cfg.Health.RedisRequired, func(ctx context.Context) (map[string]interface{}, error) {
+		return redisClient.HealthDetails(ctx, cfg.Health.RedisMaxMemoryRatio)
+	})
+	// Firecrawl credits are read on the health schedule; the monitoring server alerts when they run low
+	var creditMonitor *firecrawl.CreditMonitor
+	if cfg.Firecrawl.APIKey != "" && !cfg.TestMode.Enabled {
+		creditMonitor = firecrawl.NewCreditMonitor(cfg)
+		healthChecker.RegisterDetailed("firecrawl", cfg.Firecrawl.CreditsCheckInterval, false, creditMonitor.Check)
+	}
+	healthChecker.Start()
+
+	// Expose worker pool, browser pool and task manager stats on the monitoring server
+	monitoringService.AddStatsProvider("worker_pool", poolManager.MonitoringStats())
+	metrics.RegisterCollector("worker_pool", poolManager)
+	metrics.RegisterCollector("llm", llmManager)
+	metrics.RegisterCollector("captcha", captchaRegistry)
+	monitoringService.AddStatsProvider("task_manager", taskManager)
+	if globalPool, err := headed.GetGlobalBrowserPool(); err == nil {
+		monitoringService.AddStatsProvider("browser_pool", globalPool)
+	}
+	if proxyPool.IsEnabled() {
+		monitoringService.AddStatsProvider("proxies", proxyPool)
+	}
+	if len(captchaRegistry.Providers()) > 0 {
+		monitoringService.AddStatsProvider("captcha", captchaRegistry)
+	}
+	if creditMonitor != nil {
+		monitoringService.AddStatsProvider("firecrawl", creditMonitor)
+		metrics.RegisterCollector("firecrawl", creditMonitor)
+	}
+	if engineRouter != nil {
+		monitoringService.AddStatsProvider("routing", engineRouter)
+		metrics.RegisterCollector("routing", engineRouter)
+	}
+
+	// Saved-job monitoring re-scrapes registered URLs on the worker pool
+	var jobMonitor *jobmonitor.Monitor
+	if cfg.JobMonitor.Enabled {
+		var monitorCallbacks *callback.Client
+		if cfg.Callback.Enabled {
+			monitorCallbacks = callbackClient
+		}
+		jobMonitor = jobmonitor.NewMonitor(cfg, kvStore, poolManager, monitorCallbacks)
+		jobMonitor.Start()
+	}
+
+	// Job search over the public boards of registered companies
+	var jobSearcher *jobsearch.Searcher
+	if cfg.JobSearch.Enabled {
+		jobSearcher = jobsearch.NewSearcher(cfg, kvStore)
+		logger.Info("Job search enabled", map[string]interface{}{
+			"boards":    jobSearcher.BoardCount(),
+			"firecrawl": cfg.JobSearch.Firecrawl,
+		})
+	}
+
+	// Company enrichment reads company websites with the scraper engines
+	enricher := company.NewEnricher(cfg, llmManager)
+
+	// Scheduled submissions are kept in the KV store and submitted once due
+	taskManagerImpl.EnableScheduling(kvStore, background.TaskResources{
+		PoolManager: poolManager,
+		LLMManager:  llmManager,
+		Crawler:     crawler,
+	})
+
 	// Initialize Echo
 	e := echo.New()
 
 	// Setup routes
-	routes.SetupRoutes(e, cfg, poolManager, llmManager, taskManager)
+	routes.SetupRoutes(e, cfg, poolManager, llmManager, taskManager, jobMonitor, jobSearcher, enricher, crawler, conversations, embedder)
 
 	// Initialize multiplexer (gRPC + HTTP)
 	multiplexer := mux.NewMultiplexer(cfg, poolManager, llmManager, taskManager, e)
@@ -130,6 +383,21 @@ func main() {
 
 		logger.Info("Shutting down server...")
 
+		// Drain background tasks while the servers still answer task status requests and event
+		// streams; a second signal skips the rest of the drain
+		drain := taskManager.Drain(cfg.BackgroundTasks.DrainTimeout)
+		healthChecker.SetResult(background.HealthCheckName, drain.Err())
+		select {
+		case <-taskManager.Drained():
+		case <-sigChan:
+			logger.Warn("Second shutdown signal received, skipping the rest of the task drain")
+		}
+		drain = taskManager.DrainStatus()
+		logger.Info("Background task drain finished", map[string]interface{}{
+			"done":      drain.Done,
+			"abandoned": len(drain.Abandoned),
+		})
+
 		// Create a shutdown context with timeout
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -140,6 +408,12 @@ func main() {
 			logger.Error("Error stopping multiplexer", map[string]interface{}{"error": err.Error()})
 		}
 
+		// Stop job monitor checks before the worker pool they run on
+		if jobMonitor != nil {
+			logger.Info("Stopping job monitor...")
+			jobMonitor.Stop()
+		}
+
 		// Stop task manager
 		logger.Info("Stopping background task manager...")
 		if err := taskManager.Stop(shutdownCtx); err != nil {
@@ -152,6 +426,15 @@ func main() {
 			logger.Error("Error stopping worker pool", map[string]interface{}{"error": err.Error()})
 		}
 
+		// Stop background health checks
+		healthChecker.Stop()
+
+		// Stop monitoring service
+		logger.Info("Stopping monitoring service...")
+		if err := monitoringService.Stop(); err != nil {
+			logger.Error("Error stopping monitoring service", map[string]interface{}{"error": err.Error()})
+		}
+
 		// Stop LLM manager
 		logger.Info("Stopping LLM manager...")
 		if err := llmManager.Stop(); err != nil {
@@ -168,6 +451,16 @@ func main() {
 			logger.Warn("Could not get global browser pool for shutdown", map[string]interface{}{"error": err.Error()})
 		}
 
+		// Stop the sweepers and close the key-value store and shared Redis client
+		conversations.Stop()
+		scrapeArchive.Stop()
+		if err := kvStore.Close(); err != nil {
+			logger.Error("Error closing key-value store", map[string]interface{}{"error": err.Error()})
+		}
+		if err := redisClient.Close(); err != nil {
+			logger.Error("Error closing Redis client", map[string]interface{}{"error": err.Error()})
+		}
+
 		// Close callback client if initialized
 		if callbackClient != nil {
 			logger.Info("Closing callback client...")
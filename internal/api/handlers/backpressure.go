@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// taskSubmissionErrorResponse writes the response for a failed task submission. Capacity errors
+// (full queues, rate limits, quotas) become 429 with a Retry-After header, and submissions to a
+// draining instance 503 with one; scrapes the scraping policy forbids become 403 with the
+// policy's reason; anything else is a 500.
+func taskSubmissionErrorResponse(c echo.Context, err error, message, processID string) error {
+	customErr, ok := utils.AsCustomError(err)
+	if ok && customErr.ErrorCode == utils.ErrCodePolicyDenied {
+		response := models.CreateAsyncErrorResponse("policy_denied", fmt.Sprintf("%s: %v", message, err), processID)
+		response.Reason = customErr.Reason
+		return c.JSON(http.StatusForbidden, response)
+	}
+	if !ok || (customErr.Code != http.StatusTooManyRequests && customErr.ErrorCode != utils.ErrCodeDraining) {
+		return c.JSON(http.StatusInternalServerError, models.CreateAsyncErrorResponse(
+			"task_submission_failed",
+			fmt.Sprintf("%s: %v", message, err),
+			processID,
+		))
+	}
+
+	errorName, status := "rate_limited", http.StatusTooManyRequests
+	switch customErr.ErrorCode {
+	case utils.ErrCodeQueueFull:
+		errorName = "queue_full"
+	case utils.ErrCodeQuotaExceeded:
+		errorName = "quota_exceeded"
+	case utils.ErrCodeDraining:
+		errorName, status = "draining", http.StatusServiceUnavailable
+	}
+
+	retryAfter := utils.RetryAfterSeconds(customErr.RetryAfter)
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+	response := models.CreateAsyncErrorResponse(errorName, fmt.Sprintf("%s: %v", message, err), processID)
+	response.RetryAfter = retryAfter
+	return c.JSON(status, response)
+}
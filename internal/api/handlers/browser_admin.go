@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// browserPoolUnavailable answers a browser pool admin request made before the pool started
+func browserPoolUnavailable(c echo.Context, requestID string) error {
+	return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+		Error:     "pool_unavailable",
+		Message:   "Browser pool is not available",
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	})
+}
+
+// ListBrowsersHandler lists the live browsers of the browser pool with their age, usage and health
+func ListBrowsersHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		globalPool, err := headed.GetGlobalBrowserPool()
+		if err != nil {
+			return browserPoolUnavailable(c, requestID)
+		}
+
+		browsers := globalPool.ListBrowsers()
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"browsers":   browsers,
+			"count":      len(browsers),
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// CloseBrowserHandler force-closes one browser of the browser pool
+func CloseBrowserHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		browserID := c.Param("id")
+
+		globalPool, err := headed.GetGlobalBrowserPool()
+		if err != nil {
+			return browserPoolUnavailable(c, requestID)
+		}
+
+		if !globalPool.CloseBrowser(browserID) {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "not_found",
+				Message:   "Browser not found",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Info("Browser closed via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"browser_id": browserID,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"browser_id": browserID,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// DrainBrowserPoolHandler replaces every browser of the browser pool
+func DrainBrowserPoolHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		globalPool, err := headed.GetGlobalBrowserPool()
+		if err != nil {
+			return browserPoolUnavailable(c, requestID)
+		}
+
+		closed, pending := globalPool.Drain()
+
+		logger.Info("Browser pool drained via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"closed":     closed,
+			"pending":    pending,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"closed":     closed,
+			"pending":    pending,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// CleanupBrowserPoolHandler closes the stuck and unhealthy browsers of the browser pool
+func CleanupBrowserPoolHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		globalPool, err := headed.GetGlobalBrowserPool()
+		if err != nil {
+			return browserPoolUnavailable(c, requestID)
+		}
+
+		closed := globalPool.ForceCleanupStuckBrowsers()
+
+		logger.Info("Browser pool cleaned up via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"closed":     closed,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"closed":     closed,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
@@ -51,12 +51,16 @@ func BrowserMetricsHandler() echo.HandlerFunc {
 			Metrics: map[string]interface{}{
 				"total_browsers_created":   metrics.TotalBrowsersCreated,
 				"total_browsers_closed":    metrics.TotalBrowsersClosed,
+				"total_browsers_retired":   metrics.TotalBrowsersRetired,
+				"total_browser_crashes":    metrics.TotalBrowserCrashes,
 				"current_active_browsers":  metrics.CurrentActiveBrowsers,
 				"available_browsers":       metrics.AvailableBrowsers,
+				"max_instances":            metrics.MaxInstances,
+				"scale_ups":                metrics.ScaleUps,
+				"scale_downs":              metrics.ScaleDowns,
 				"queued_requests":          metrics.QueuedRequests,
-				"average_acquisition_time": metrics.AverageAcquisitionTime.String(),
-				"total_acquisition_time":   metrics.TotalAcquisitionTime.String(),
-				"acquisition_count":        metrics.AcquisitionCount,
+				"acquisition_time_seconds": metrics.AcquisitionTime,
+				"acquisitions_total":       metrics.AcquisitionTime.Count,
 				"is_healthy":               globalPool.IsHealthy(),
 			},
 		}
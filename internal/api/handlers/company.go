@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/company"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// CompanyEnrichHandler handles POST /api/v1/company/enrich. The company's website, given as a
+// domain or looked up by name, is read with the scraper engines and its information extracted
+// by the LLM within the request.
+func CompanyEnrichHandler(cfg *config.Config, enricher *company.Enricher) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing company enrichment request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/company/enrich",
+			"method":     "POST",
+		})
+
+		var req models.CompanyEnrichmentRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := validate.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		problem := scrapeOptionsProblem(cfg, req.Options)
+		if req.Name == "" && req.Domain == "" {
+			problem = "Either name or domain is required"
+		}
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		if req.Options != nil {
+			ctx = llm.WithModel(ctx, req.Options.Model)
+		}
+		startTime := time.Now()
+
+		result, err := enricher.Enrich(ctx, req.Name, req.Domain, req.Options)
+		if err != nil {
+			logger.Error("Company enrichment failed", map[string]interface{}{
+				"request_id": requestID,
+				"name":       req.Name,
+				"domain":     req.Domain,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("Company enrichment completed", map[string]interface{}{
+			"request_id":      requestID,
+			"company":         result.Name,
+			"website":         result.Website,
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.CompanyEnrichmentResponse{
+			Success:        true,
+			Company:        result,
+			ProcessingTime: processingTime,
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/health"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/utils"
+)
+
+// DrainTaskManagerHandler starts draining the task manager ahead of a deployment: new tasks are
+// refused and readiness fails, while queued and running tasks get until the drain timeout to
+// finish. It answers at once with the drain's status; calling it again reports the same drain.
+func DrainTaskManagerHandler(taskManager background.TaskManager, timeout time.Duration) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		status := taskManager.Drain(timeout)
+
+		// Fail readiness right away instead of at the next health check refresh
+		if checker := health.GetGlobalChecker(); checker != nil {
+			checker.SetResult(background.HealthCheckName, status.Err())
+		}
+
+		logger.Info("Task manager drain requested via admin endpoint", map[string]interface{}{
+			"request_id":   requestID,
+			"queue_length": status.QueueLength,
+			"in_flight":    status.InFlight,
+		})
+
+		return c.JSON(http.StatusAccepted, map[string]interface{}{
+			"success":    true,
+			"drain":      status,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// DrainStatusHandler reports the progress of the task manager's drain
+func DrainStatusHandler(taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"drain":      taskManager.DrainStatus(),
+			"request_id": utils.GenerateRequestID(),
+			"timestamp":  time.Now(),
+		})
+	}
+}
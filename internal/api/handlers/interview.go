@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// InterviewQuestionsHandler handles the POST /api/v1/resume/interview-questions endpoint
+// asynchronously. The questions are delivered through the InterviewQuestions callback and the
+// task result.
+func InterviewQuestionsHandler(llmManager *llm.Manager, taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing async interview questions request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/interview-questions",
+			"method":     "POST",
+		})
+
+		var req models.InterviewQuestionsRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"invalid_request",
+				"Invalid request body: "+err.Error(),
+			))
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				"Request validation failed: "+err.Error(),
+			))
+		}
+		if problem := resumeJobProblem(&req.BaseResume, &req.Job); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		processID := utils.GenerateInterviewProcessID()
+
+		logger.Info("Submitting interview questions task for background processing", map[string]interface{}{
+			"request_id":     requestID,
+			"process_id":     processID,
+			"base_resume_id": req.BaseResume.ID,
+			"resume_id":      req.ResumeID,
+			"job_title":      req.Job.Title,
+			"question_count": req.QuestionCount,
+		})
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		priority := background.ParseTaskPriority(req.Priority, background.TaskPriorityInteractive)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeInterview, req, priority, runAt)
+		}
+		if err := taskManager.SubmitInterviewTask(ctx, processID, req, priority, llmManager); err != nil {
+			logger.Error("Failed to submit background interview questions task", map[string]interface{}{"error": err})
+			return taskSubmissionErrorResponse(c, err, "Failed to submit interview questions task", processID)
+		}
+
+		logger.Info("Interview questions task submitted successfully for background processing", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"resume_id":  req.ResumeID,
+		})
+
+		return c.JSON(http.StatusAccepted, models.CreateAsyncInterviewResponse(processID))
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/jobsearch"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// JobSearchResponse lists the jobs matching a search, best matches first
+type JobSearchResponse struct {
+	Query     string                   `json:"query"`
+	Location  string                   `json:"location,omitempty"`
+	Results   []jobsearch.Result       `json:"results"`
+	Count     int                      `json:"count"`
+	Sources   []jobsearch.SourceStatus `json:"sources"`
+	RequestID string                   `json:"request_id"`
+	Timestamp time.Time                `json:"timestamp"`
+}
+
+// JobSearchHandler searches the job boards of registered companies
+func JobSearchHandler(searcher *jobsearch.Searcher) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		if searcher == nil {
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "job_search_disabled",
+				Message:   "Job search is disabled; set JOB_SEARCH_ENABLED to enable it",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		query := jobsearch.Query{
+			Q:        strings.TrimSpace(c.QueryParam("q")),
+			Location: strings.TrimSpace(c.QueryParam("location")),
+		}
+		if query.Q == "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Query parameter q is required",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if limit := c.QueryParam("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 1 {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "limit must be a positive integer",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			query.Limit = parsed
+		}
+
+		results, err := searcher.Search(c.Request().Context(), query)
+		if err != nil {
+			logging.GetGlobalLogger().Error("Job search failed", map[string]interface{}{
+				"request_id": requestID,
+				"query":      query.Q,
+				"error":      err.Error(),
+			})
+			return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     "search_failed",
+				Message:   "Job search failed",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return c.JSON(http.StatusOK, JobSearchResponse{
+			Query:     query.Q,
+			Location:  query.Location,
+			Results:   results.Results,
+			Count:     len(results.Results),
+			Sources:   results.Sources,
+			RequestID: requestID,
+			Timestamp: time.Now(),
+		})
+	}
+}
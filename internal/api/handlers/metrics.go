@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/metrics"
+)
+
+// PrometheusMetricsHandler serves registered collectors in the Prometheus text format
+func PrometheusMetricsHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var buf bytes.Buffer
+		if err := metrics.WritePrometheus(&buf); err != nil {
+			logging.GetGlobalLogger().Error("Failed to render Prometheus metrics", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return c.String(http.StatusInternalServerError, "failed to render metrics")
+		}
+		return c.Blob(http.StatusOK, metrics.PrometheusContentType, buf.Bytes())
+	}
+}
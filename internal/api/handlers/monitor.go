@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/jobmonitor"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// JobWatchListResponse lists the monitored jobs
+type JobWatchListResponse struct {
+	Watches   []*jobmonitor.Watch `json:"watches"`
+	Count     int                 `json:"count"`
+	RequestID string              `json:"request_id"`
+	Timestamp time.Time           `json:"timestamp"`
+}
+
+// RegisterJobWatchHandler registers a job URL for periodic re-scraping and change callbacks
+func RegisterJobWatchHandler(cfg *config.Config, jobMonitor *jobmonitor.Monitor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		if jobMonitor == nil {
+			return jobMonitorDisabledResponse(c, requestID)
+		}
+
+		var req models.JobMonitorRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request format: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := validate.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		if problem := scrapeOptionsProblem(cfg, req.Options); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		var interval time.Duration
+		if req.Interval != "" {
+			parsed, err := time.ParseDuration(req.Interval)
+			if err != nil || parsed <= 0 {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "interval must be a positive duration such as \"12h\"",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			interval = parsed
+		}
+
+		watch, created, err := jobMonitor.Register(c.Request().Context(), req.URL, interval, req.Options)
+		if err != nil {
+			logger.Error("Failed to register job watch", map[string]interface{}{
+				"request_id": requestID,
+				"url":        req.URL,
+				"error":      err.Error(),
+			})
+			if errors.Is(err, jobmonitor.ErrTooManyWatches) {
+				return c.JSON(http.StatusConflict, models.ErrorResponse{
+					Error:     "too_many_watches",
+					Message:   err.Error(),
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     "registration_failed",
+				Message:   "Failed to register job watch",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		return c.JSON(status, watch)
+	}
+}
+
+// ListJobWatchesHandler returns all monitored jobs
+func ListJobWatchesHandler(jobMonitor *jobmonitor.Monitor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		if jobMonitor == nil {
+			return jobMonitorDisabledResponse(c, requestID)
+		}
+
+		watches, err := jobMonitor.List(c.Request().Context())
+		if err != nil {
+			logging.GetGlobalLogger().Error("Failed to list job watches", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err.Error(),
+			})
+			return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     "internal_error",
+				Message:   "Failed to list job watches",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return c.JSON(http.StatusOK, JobWatchListResponse{
+			Watches:   watches,
+			Count:     len(watches),
+			RequestID: requestID,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// GetJobWatchHandler returns a single monitored job with the last seen content
+func GetJobWatchHandler(jobMonitor *jobmonitor.Monitor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		if jobMonitor == nil {
+			return jobMonitorDisabledResponse(c, requestID)
+		}
+
+		watch, err := jobMonitor.Get(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return jobWatchErrorResponse(c, requestID, err)
+		}
+		return c.JSON(http.StatusOK, watch)
+	}
+}
+
+// DeleteJobWatchHandler stops monitoring a job
+func DeleteJobWatchHandler(jobMonitor *jobmonitor.Monitor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		if jobMonitor == nil {
+			return jobMonitorDisabledResponse(c, requestID)
+		}
+
+		id := c.Param("id")
+		if err := jobMonitor.Remove(c.Request().Context(), id); err != nil {
+			return jobWatchErrorResponse(c, requestID, err)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"id":         id,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// jobWatchErrorResponse maps watch lookup failures to 404 or 500
+func jobWatchErrorResponse(c echo.Context, requestID string, err error) error {
+	if errors.Is(err, jobmonitor.ErrWatchNotFound) {
+		return c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:     "not_found",
+			Message:   "Job watch not found",
+			RequestID: requestID,
+			Timestamp: time.Now(),
+		})
+	}
+
+	logging.GetGlobalLogger().Error("Job watch operation failed", map[string]interface{}{
+		"request_id": requestID,
+		"error":      err.Error(),
+	})
+	return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:     "internal_error",
+		Message:   "Job watch operation failed",
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	})
+}
+
+// jobMonitorDisabledResponse is returned by all monitoring routes when the job monitor is off
+func jobMonitorDisabledResponse(c echo.Context, requestID string) error {
+	return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+		Error:     "job_monitor_disabled",
+		Message:   "Job monitoring is disabled; set JOB_MONITOR_ENABLED to enable it",
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	})
+}
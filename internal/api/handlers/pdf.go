@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// maxPDFUploadBytes bounds the size of an uploaded PDF, matching what the lite engine reads
+const maxPDFUploadBytes = 10 << 20
+
+// ScrapePDFHandler handles POST /api/v1/scrape/pdf. The job posting PDF uploaded in the "file"
+// form field is read to text and extracted within the request like a scraped page. The optional
+// "url" field is the posting's source, and "model" picks one of the allowed LLM models.
+func ScrapePDFHandler(cfg *config.Config, llmManager *llm.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing PDF scrape request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/scrape/pdf",
+			"method":     "POST",
+		})
+
+		sourceURL := c.FormValue("url")
+		if sourceURL != "" {
+			if err := validate.Var(sourceURL, "url"); err != nil {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "url must be a valid URL",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+		}
+		model := c.FormValue("model")
+		if err := cfg.ValidateModel(model); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		data, err := readPDFUpload(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		text, err := processors.ExtractPDFText(data)
+		if err != nil {
+			status := http.StatusUnprocessableEntity
+			if errors.Is(err, processors.ErrNotPDF) {
+				status = http.StatusBadRequest
+			}
+			return c.JSON(status, models.ErrorResponse{
+				Error:     "invalid_pdf",
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		ctx = llm.WithModel(ctx, model)
+		startTime := time.Now()
+
+		job, err := llmManager.ExtractJobData(ctx, text, sourceURL)
+		if err != nil {
+			logger.Error("PDF extraction failed", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if job.JobURL == "" {
+			job.JobURL = sourceURL
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("PDF extraction completed", map[string]interface{}{
+			"request_id":      requestID,
+			"job_title":       job.Title,
+			"text_length":     len(text),
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.ScrapeResponse{
+			Success:        true,
+			Job:            job,
+			ProcessingTime: processingTime,
+			Engine:         "pdf",
+			RequestID:      requestID,
+		})
+	}
+}
+
+// readPDFUpload returns the content of the uploaded "file" field
+func readPDFUpload(c echo.Context) ([]byte, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, errors.New("a PDF must be uploaded in the file field")
+	}
+	if header.Size > maxPDFUploadBytes {
+		return nil, errors.New("the PDF is larger than 10 MB")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, errors.New("failed to read the uploaded PDF")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxPDFUploadBytes+1))
+	if err != nil || len(data) > maxPDFUploadBytes {
+		return nil, errors.New("failed to read the uploaded PDF")
+	}
+	return data, nil
+}
@@ -77,6 +77,7 @@ func ProtoMetadataHandler() echo.HandlerFunc {
 			"grpc_services": []string{
 				"letraz.v1.ScraperService",
 				"letraz.v1.ResumeService",
+				"letraz.v1.TaskService",
 			},
 			"supported_features": []string{
 				"async_processing",
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/archive"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/llm/prompts"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// ReExtractHandler handles POST /api/v1/scrape/:process_id/re-extract. The page archived by the
+// scrape task is extracted again within the request, optionally with another provider, model or
+// job_extraction prompt version, without fetching the site. The extraction cache is skipped.
+func ReExtractHandler(cfg *config.Config, llmManager *llm.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		processID := c.Param("process_id")
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing re-extract request", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"endpoint":   "/api/v1/scrape/:process_id/re-extract",
+			"method":     "POST",
+		})
+
+		if !utils.IsProcessIDForTaskType(processID, "scrape") {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "process_id must be the ID of a scrape task",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		var req models.ReExtractRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if problem := reExtractProblem(cfg, llmManager, &req); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+
+		page, err := archive.GetGlobalArchive().Load(ctx, archive.Key(processID, -1))
+		if errors.Is(err, archive.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "not_found",
+				Message:   "No archived page for this process; it was not archived or its retention has passed",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err != nil {
+			logger.Error("Failed to load archived page", map[string]interface{}{
+				"request_id": requestID,
+				"process_id": processID,
+				"error":      err.Error(),
+			})
+			return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     "internal_error",
+				Message:   "Failed to load archived page",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		usage := cost.NewTally()
+		ctx = cost.WithTally(ctx, usage)
+		ctx = llm.WithCacheBypass(ctx)
+		ctx = llm.WithProvider(ctx, req.LLMProvider)
+		ctx = llm.WithModel(ctx, req.Model)
+		ctx = prompts.WithVersion(ctx, prompts.JobExtraction, req.PromptVersion)
+
+		job, err := llmManager.ExtractJobData(ctx, page.Content, page.URL)
+		if err != nil {
+			logger.Error("Re-extraction failed", map[string]interface{}{
+				"request_id": requestID,
+				"process_id": processID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("Re-extraction completed", map[string]interface{}{
+			"request_id":      requestID,
+			"process_id":      processID,
+			"url":             page.URL,
+			"job_title":       job.Title,
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.ReExtractResponse{
+			Success:        true,
+			ProcessID:      processID,
+			URL:            page.URL,
+			Format:         page.Format,
+			Job:            job,
+			LLMUsage:       usage.Metadata(),
+			ProcessingTime: processingTime,
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
+
+// reExtractProblem returns why a re-extract request cannot be served, or an empty string
+func reExtractProblem(cfg *config.Config, llmManager *llm.Manager, req *models.ReExtractRequest) string {
+	if err := cfg.ValidateModel(req.Model); err != nil {
+		return err.Error()
+	}
+	if req.LLMProvider != "" && !llmManager.HasProvider(req.LLMProvider) {
+		return "llm_provider " + req.LLMProvider + " is not configured"
+	}
+	if req.PromptVersion != "" {
+		if err := prompts.GetGlobalLibrary().HasVersion(prompts.JobExtraction, req.PromptVersion); err != nil {
+			return "prompt_version " + req.PromptVersion + " is not available: " + err.Error()
+		}
+	}
+	return ""
+}
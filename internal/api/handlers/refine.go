@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// RefineResumeHandler handles POST /api/v1/resume/refine. The follow-up instruction is applied
+// within the request to the resume's latest tailoring, continuing the conversation stored when it
+// was tailored, and the exchange is appended to that conversation so instructions build on each
+// other.
+func RefineResumeHandler(llmManager *llm.Manager, conversations *utils.ConversationStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing resume refinement request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/refine",
+			"method":     "POST",
+		})
+
+		var req models.RefineResumeRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+
+		if err := conversations.Ping(ctx); err != nil {
+			logger.Error("Conversation store unavailable", map[string]interface{}{
+				"request_id": requestID,
+				"backend":    conversations.Backend(),
+				"error":      err.Error(),
+			})
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "conversation_store_unavailable",
+				Message:   "Conversation history is unavailable",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		history, err := conversations.GetConversationHistory(ctx, req.ResumeID)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "conversation_not_found",
+				Message:   "No tailoring conversation found for resume " + req.ResumeID,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		baseResume, conversation, err := llm.RefinementConversation(history)
+		if err != nil {
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		tailoredResume, suggestions, rawResponse, err := llmManager.RefineResume(ctx, baseResume, conversation, req.Instruction)
+		if err != nil {
+			logger.Error("Resume refinement failed", map[string]interface{}{
+				"request_id": requestID,
+				"resume_id":  req.ResumeID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		// History is best effort: the refined resume is returned even when it cannot be stored
+		for _, entry := range []utils.ConversationEntry{
+			{
+				Role:    "user",
+				Content: req.Instruction,
+				Metadata: map[string]interface{}{
+					"type":       llm.EntryTypeRefineInstruction,
+					"request_id": requestID,
+				},
+			},
+			{
+				Role:    "assistant",
+				Content: rawResponse,
+				Metadata: map[string]interface{}{
+					"type":             llm.EntryTypeRefineResponse,
+					"request_id":       requestID,
+					"provider":         llmManager.GetProviderName(),
+					"suggestion_count": len(suggestions),
+				},
+			},
+		} {
+			if err := conversations.AddConversationEntry(ctx, req.ResumeID, entry); err != nil {
+				logger.Warn("Failed to store conversation history entry", map[string]interface{}{
+					"request_id": requestID,
+					"resume_id":  req.ResumeID,
+					"role":       entry.Role,
+					"error":      err.Error(),
+				})
+				break
+			}
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("Resume refinement completed", map[string]interface{}{
+			"request_id":       requestID,
+			"resume_id":        req.ResumeID,
+			"turns":            len(conversation),
+			"suggestion_count": len(suggestions),
+			"processing_time":  processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.RefineResumeResponse{
+			Success:        true,
+			TailoredResume: tailoredResume,
+			Suggestions:    suggestions,
+			ThreadID:       history.ThreadID,
+			ProcessingTime: processingTime,
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
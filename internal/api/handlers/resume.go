@@ -1,9 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -68,31 +69,18 @@ func TailorResumeHandler(cfg *config.Config, llmManager *llm.Manager, taskManage
 		}
 
 		// Validate that required fields are present
-		if req.BaseResume.ID == "" {
+		if problem := tailorRequestProblem(cfg, &req); problem != "" {
 			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
 				"validation_failed",
-				"Base resume ID is required",
+				problem,
 			))
 		}
 
-		if req.Job.Title == "" {
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
 			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
 				"validation_failed",
-				"Job title is required",
-			))
-		}
-
-		if req.Job.CompanyName == "" {
-			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
-				"validation_failed",
-				"Job company name is required",
-			))
-		}
-
-		if req.ResumeID == "" {
-			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
-				"validation_failed",
-				"Resume ID is required",
+				problem,
 			))
 		}
 
@@ -110,15 +98,15 @@ func TailorResumeHandler(cfg *config.Config, llmManager *llm.Manager, taskManage
 		})
 
 		// Submit task to background task manager
-		ctx := c.Request().Context()
-		err := taskManager.SubmitTailorTask(ctx, processID, req, llmManager, cfg)
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		priority := background.ParseTaskPriority(req.Priority, background.TaskPriorityInteractive)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeTailor, req, priority, runAt)
+		}
+		err := taskManager.SubmitTailorTask(ctx, processID, req, priority, llmManager, cfg)
 		if err != nil {
 			logger.Error("Failed to submit background tailor task", map[string]interface{}{"error": err})
-			return c.JSON(http.StatusInternalServerError, models.CreateAsyncErrorResponse(
-				"task_submission_failed",
-				fmt.Sprintf("Failed to submit resume tailoring task: %v", err),
-				processID,
-			))
+			return taskSubmissionErrorResponse(c, err, "Failed to submit resume tailoring task", processID)
 		}
 
 		// Return immediate response with process ID
@@ -134,6 +122,127 @@ func TailorResumeHandler(cfg *config.Config, llmManager *llm.Manager, taskManage
 	}
 }
 
+// tailorStreamTimeout bounds a streamed tailoring, matching the timeout of the async endpoint
+const tailorStreamTimeout = 2 * time.Minute
+
+// TailorStreamSectionEvent is the payload of a "section" event of a streamed tailoring
+type TailorStreamSectionEvent struct {
+	Index   int                          `json:"index"`
+	Section models.TailoredResumeSection `json:"section"`
+}
+
+// TailorResumeStreamHandler handles POST /api/v1/resume/tailor/stream. The resume is tailored
+// within the request and streamed as Server-Sent Events: "started", one "section" event per
+// tailored section as soon as it is generated, then "complete" with the full resume and
+// suggestions, or "error". A section may be sent again with the same index if the LLM retries,
+// so clients should replace sections by index.
+func TailorResumeStreamHandler(cfg *config.Config, llmManager *llm.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing streamed resume tailoring request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/tailor/stream",
+			"method":     "POST",
+		})
+
+		var req models.TailorResumeRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if problem := tailorRequestProblem(cfg, &req); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request().Context(), tailorStreamTimeout)
+		defer cancel()
+		ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+		ctx = llm.WithModel(ctx, req.Model)
+		deadline, _ := ctx.Deadline()
+
+		stream := newSSEWriter(c, deadline)
+		if err := stream.send("started", map[string]interface{}{
+			"request_id": requestID,
+			"resume_id":  req.ResumeID,
+		}); err != nil {
+			return nil
+		}
+
+		startTime := time.Now()
+		tailoredResume, suggestions, _, err := llmManager.TailorResumeStream(ctx, &req.BaseResume, &req.Job, func(index int, section models.TailoredResumeSection) {
+			// A client that went away cancels the request context, which stops the LLM call
+			_ = stream.send("section", TailorStreamSectionEvent{Index: index, Section: section})
+		})
+		if err != nil {
+			logger.Error("Streamed resume tailoring failed", map[string]interface{}{
+				"request_id": requestID,
+				"resume_id":  req.ResumeID,
+				"error":      err.Error(),
+			})
+			_ = stream.send("error", models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+			return nil
+		}
+
+		logger.Info("Streamed resume tailoring completed", map[string]interface{}{
+			"request_id":        requestID,
+			"resume_id":         req.ResumeID,
+			"processing_time":   time.Since(startTime).String(),
+			"sections_count":    len(tailoredResume.Sections),
+			"suggestions_count": len(suggestions),
+		})
+
+		_ = stream.send("complete", models.TailorResumeResponse{
+			Success:     true,
+			Resume:      *tailoredResume,
+			Suggestions: suggestions,
+		})
+		return nil
+	}
+}
+
+// tailorRequestProblem returns why a tailoring request is incomplete or invalid, or an empty string
+func tailorRequestProblem(cfg *config.Config, req *models.TailorResumeRequest) string {
+	switch {
+	case req.BaseResume.ID == "":
+		return "Base resume ID is required"
+	case req.Job.Title == "":
+		return "Job title is required"
+	case req.Job.CompanyName == "":
+		return "Job company name is required"
+	case req.ResumeID == "":
+		return "Resume ID is required"
+	}
+	if err := cfg.ValidateModel(req.Model); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
 // ExportResumeHandler handles POST /api/v1/resume/export to render LaTeX and upload to Spaces
 func ExportResumeHandler(cfg *config.Config) echo.HandlerFunc {
 	// Use shared request model to avoid duplication
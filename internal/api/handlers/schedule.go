@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// scheduledRunAt returns when a submission asked to run, or the zero time when it runs right
+// away, along with a problem description when the schedule is invalid. Times already passed
+// run right away.
+func scheduledRunAt(schedule models.TaskSchedule) (time.Time, string) {
+	var runAt time.Time
+	switch {
+	case schedule.RunAt != nil && schedule.Delay != "":
+		return time.Time{}, "Set either run_at or delay, not both"
+	case schedule.RunAt != nil:
+		runAt = *schedule.RunAt
+	case schedule.Delay != "":
+		delay, err := time.ParseDuration(schedule.Delay)
+		if err != nil || delay < 0 {
+			return time.Time{}, "delay must be a non-negative duration such as \"168h\""
+		}
+		runAt = time.Now().Add(delay)
+	}
+
+	if !runAt.After(time.Now()) {
+		return time.Time{}, ""
+	}
+	return runAt, ""
+}
+
+// scheduleSubmission holds a validated submission until runAt instead of submitting it
+func scheduleSubmission(ctx context.Context, c echo.Context, taskManager background.TaskManager, processID string, taskType background.TaskType, request interface{}, priority background.TaskPriority, runAt time.Time) error {
+	if err := taskManager.ScheduleTask(ctx, processID, taskType, request, priority, runAt); err != nil {
+		if utils.IsErrorCode(err, utils.ErrCodeValidationFailed) {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse("validation_failed", err.Error()))
+		}
+		if errors.Is(err, background.ErrSchedulingUnavailable) {
+			return c.JSON(http.StatusServiceUnavailable, models.CreateAsyncErrorResponse("task_submission_failed", "Task scheduling is not available", processID))
+		}
+		return taskSubmissionErrorResponse(c, err, "Failed to schedule task", processID)
+	}
+	return c.JSON(http.StatusAccepted, models.CreateAsyncScheduledResponse(processID, runAt))
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/embeddings"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scoring"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// ATSScoreHandler handles POST /api/v1/resume/ats-score. The resume is scored within the request
+// by combining deterministic keyword, requirement and formatting checks with an LLM review.
+func ATSScoreHandler(llmManager *llm.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing ATS score request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/ats-score",
+			"method":     "POST",
+		})
+
+		var req models.ATSScoreRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if problem := resumeJobProblem(&req.BaseResume, &req.Job); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+
+		analysis := scoring.Analyze(&req.BaseResume, &req.Job)
+		review, err := llmManager.ReviewResumeForATS(ctx, &req.BaseResume, &req.Job)
+		if err != nil {
+			logger.Error("ATS review failed", map[string]interface{}{
+				"request_id": requestID,
+				"resume_id":  req.BaseResume.ID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		score := scoring.Combine(analysis, review)
+		processingTime := time.Since(startTime)
+
+		logger.Info("ATS score completed", map[string]interface{}{
+			"request_id":      requestID,
+			"resume_id":       req.BaseResume.ID,
+			"score":           score.Score,
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.ATSScoreResponse{
+			Success:        true,
+			ATSScore:       *score,
+			ProcessingTime: processingTime,
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
+
+// SkillGapHandler handles POST /api/v1/resume/skill-gap. The resume's skills are compared with
+// the job requirements within the request.
+func SkillGapHandler(llmManager *llm.Manager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing skill gap request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/skill-gap",
+			"method":     "POST",
+		})
+
+		var req models.SkillGapRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		problem := resumeJobProblem(&req.BaseResume, &req.Job)
+		if problem == "" && len(req.Job.Requirements) == 0 {
+			problem = "Job requirements are required"
+		}
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+
+		analysis, err := llmManager.AnalyzeSkillGap(ctx, &req.BaseResume, &req.Job)
+		if err != nil {
+			logger.Error("Skill gap analysis failed", map[string]interface{}{
+				"request_id": requestID,
+				"resume_id":  req.BaseResume.ID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("Skill gap analysis completed", map[string]interface{}{
+			"request_id":      requestID,
+			"resume_id":       req.BaseResume.ID,
+			"matched_count":   len(analysis.MatchedSkills),
+			"partial_count":   len(analysis.PartialSkills),
+			"missing_count":   len(analysis.MissingSkills),
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.SkillGapResponse{
+			Success:          true,
+			SkillGapAnalysis: *analysis,
+			ProcessingTime:   processingTime,
+			RequestID:        requestID,
+			Timestamp:        time.Now(),
+		})
+	}
+}
+
+// RelevanceHandler handles POST /api/v1/resume/relevance. The resume sections and the job
+// requirements are compared by embedding similarity within the request, a cheap pre-screen before
+// full tailoring. embedder is nil when no embedding provider is configured.
+func RelevanceHandler(cfg *config.Config, embedder embeddings.Embedder) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		logger.Info("Processing relevance request", map[string]interface{}{
+			"request_id": requestID,
+			"endpoint":   "/api/v1/resume/relevance",
+			"method":     "POST",
+		})
+
+		if embedder == nil {
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "embeddings_unavailable",
+				Message:   "No embedding provider is configured - set EMBEDDINGS_API_KEY",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		var req models.RelevanceRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := resumeValidator.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if problem := resumeJobProblem(&req.BaseResume, &req.Job); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   problem,
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+
+		relevance, err := embeddings.Relevance(ctx, embedder, &req.BaseResume, &req.Job, cfg.LLM.Embeddings.RelevanceThreshold)
+		if err != nil {
+			logger.Error("Relevance pre-screening failed", map[string]interface{}{
+				"request_id": requestID,
+				"resume_id":  req.BaseResume.ID,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Info("Relevance pre-screening completed", map[string]interface{}{
+			"request_id":      requestID,
+			"resume_id":       req.BaseResume.ID,
+			"score":           relevance.Score,
+			"relevant":        relevance.Relevant,
+			"model":           relevance.Model,
+			"processing_time": processingTime.String(),
+		})
+
+		return c.JSON(http.StatusOK, models.RelevanceResponse{
+			Success:        true,
+			Relevance:      *relevance,
+			ProcessingTime: processingTime,
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
+
+// resumeJobProblem returns why a resume and job pair cannot be assessed, or an empty string
+func resumeJobProblem(resume *models.BaseResume, job *models.Job) string {
+	switch {
+	case resume.ID == "":
+		return "Base resume ID is required"
+	case len(resume.Sections) == 0:
+		return "Base resume has no sections"
+	case job.Title == "":
+		return "Job title is required"
+	}
+	return ""
+}
@@ -3,12 +3,14 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 
 	"letraz-utils/internal/background"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/crawl"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/scraper/workers"
 	"letraz-utils/pkg/models"
@@ -72,6 +74,21 @@ func ScrapeHandler(cfg *config.Config, poolManager *workers.PoolManager, taskMan
 			))
 		}
 
+		if problem := scrapeOptionsProblem(cfg, req.Options); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
 		// Generate process ID for background task
 		processID := utils.GenerateScrapeProcessID()
 
@@ -84,18 +101,19 @@ func ScrapeHandler(cfg *config.Config, poolManager *workers.PoolManager, taskMan
 		})
 
 		// Submit task to background task manager
-		ctx := c.Request().Context()
-		err := taskManager.SubmitScrapeTask(ctx, processID, req, poolManager)
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		// A single scrape is a user waiting on one page; it goes ahead of batches and crawls
+		priority := background.ScrapeTaskPriority(req.Options, background.TaskPriorityInteractive)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeScrape, req, priority, runAt)
+		}
+		err := taskManager.SubmitScrapeTask(ctx, processID, req, priority, poolManager)
 		if err != nil {
 			logger.Error("Failed to submit background scrape task", map[string]interface{}{
 				"request_id": requestID,
 				"error":      err,
 			})
-			return c.JSON(http.StatusInternalServerError, models.CreateAsyncErrorResponse(
-				"task_submission_failed",
-				fmt.Sprintf("Failed to submit scraping task: %v", err),
-				processID,
-			))
+			return taskSubmissionErrorResponse(c, err, "Failed to submit scraping task", processID)
 		}
 
 		// Return immediate response with process ID
@@ -111,6 +129,249 @@ func ScrapeHandler(cfg *config.Config, poolManager *workers.PoolManager, taskMan
 	}
 }
 
+// BatchScrapeHandler handles scraping several job URLs as one background task whose result
+// collects the job or error of every URL
+func BatchScrapeHandler(cfg *config.Config, poolManager *workers.PoolManager, taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		logger.Info("Async batch scrape request received", map[string]interface{}{"request_id": requestID})
+
+		// Parse request body
+		var req models.BatchScrapeRequest
+		if err := c.Bind(&req); err != nil {
+			logger.Error("Failed to bind request", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"invalid_request",
+				"Invalid request format: "+err.Error(),
+			))
+		}
+
+		// Validate request
+		if err := validate.Struct(&req); err != nil {
+			logger.Error("Request validation failed", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				"Request validation failed: "+err.Error(),
+			))
+		}
+
+		if maxURLs := cfg.BackgroundTasks.MaxBatchURLs; maxURLs > 0 && len(req.URLs) > maxURLs {
+			logger.Error("Too many URLs in batch", map[string]interface{}{
+				"request_id": requestID,
+				"url_count":  len(req.URLs),
+				"max_urls":   maxURLs,
+			})
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				fmt.Sprintf("A batch accepts at most %d URLs", maxURLs),
+			))
+		}
+
+		if problem := scrapeOptionsProblem(cfg, req.Options); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		// Generate process ID for background task
+		processID := utils.GenerateBatchScrapeProcessID()
+
+		logger.Info("Submitting batch scrape task for background processing", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"url_count":  len(req.URLs),
+		})
+
+		// Submit task to background task manager
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		priority := background.ScrapeTaskPriority(req.Options, background.TaskPriorityBulk)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeBatch, req, priority, runAt)
+		}
+		err := taskManager.SubmitBatchScrapeTask(ctx, processID, req, priority, poolManager)
+		if err != nil {
+			logger.Error("Failed to submit background batch scrape task", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return taskSubmissionErrorResponse(c, err, "Failed to submit batch scraping task", processID)
+		}
+
+		logger.Info("Batch scrape task submitted successfully for background processing", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"url_count":  len(req.URLs),
+		})
+
+		return c.JSON(http.StatusAccepted, models.CreateAsyncBatchScrapeResponse(processID, len(req.URLs)))
+	}
+}
+
+// CrawlHandler handles career-page crawl requests. The careers page's job links are discovered in
+// the background and each job is scraped as a child task of the returned process.
+func CrawlHandler(cfg *config.Config, poolManager *workers.PoolManager, taskManager background.TaskManager, crawler *crawl.Crawler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		logger.Info("Async crawl request received", map[string]interface{}{"request_id": requestID})
+
+		var req models.CrawlRequest
+		if err := c.Bind(&req); err != nil {
+			logger.Error("Failed to bind request", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"invalid_request",
+				"Invalid request format: "+err.Error(),
+			))
+		}
+
+		if err := validate.Struct(&req); err != nil {
+			logger.Error("Request validation failed", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				"Request validation failed: "+err.Error(),
+			))
+		}
+
+		if problem := scrapeOptionsProblem(cfg, req.Options); problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
+		processID := utils.GenerateCrawlProcessID()
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		priority := background.ScrapeTaskPriority(req.Options, background.TaskPriorityBulk)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeCrawl, req, priority, runAt)
+		}
+		if err := taskManager.SubmitCrawlTask(ctx, processID, req, priority, crawler, poolManager); err != nil {
+			logger.Error("Failed to submit background crawl task", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return taskSubmissionErrorResponse(c, err, "Failed to submit crawl task", processID)
+		}
+
+		logger.Info("Crawl task submitted successfully for background processing", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"url":        req.URL,
+		})
+
+		return c.JSON(http.StatusAccepted, models.CreateAsyncCrawlResponse(processID))
+	}
+}
+
+// CrawlMapHandler handles POST /api/v1/scrape/crawl/map. The job links of a careers site are
+// listed from its Firecrawl map within the request, with no page read or scraped, so clients can
+// pick the jobs to submit as a batch.
+func CrawlMapHandler(cfg *config.Config, crawler *crawl.Crawler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		var req models.CrawlMapRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid request body: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err := validate.Struct(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "validation_failed",
+				Message:   "Request validation failed: " + err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		maxJobs := cfg.Crawl.MaxJobs
+		if req.MaxJobs > 0 && (maxJobs <= 0 || req.MaxJobs < maxJobs) {
+			maxJobs = req.MaxJobs
+		}
+
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		startTime := time.Now()
+		jobs, mapped, err := crawler.MapJobLinks(ctx, req.URL, maxJobs)
+		if err != nil {
+			logger.Warn("Careers site map failed", map[string]interface{}{
+				"request_id": requestID,
+				"url":        req.URL,
+				"error":      err.Error(),
+			})
+			return c.JSON(utils.GetHTTPStatus(err), models.ErrorResponse{
+				Error:     string(utils.GetErrorCode(err)),
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if jobs == nil {
+			jobs = []string{}
+		}
+
+		return c.JSON(http.StatusOK, models.CrawlMapResponse{
+			Success:        true,
+			URL:            req.URL,
+			Jobs:           jobs,
+			Total:          len(jobs),
+			Mapped:         mapped,
+			ProcessingTime: time.Since(startTime),
+			RequestID:      requestID,
+			Timestamp:      time.Now(),
+		})
+	}
+}
+
+// scrapeOptionsProblem returns why scrape options are invalid, or an empty string
+func scrapeOptionsProblem(cfg *config.Config, options *models.ScrapeOptions) string {
+	if options == nil {
+		return ""
+	}
+	if err := cfg.ValidateModel(options.Model); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
 // getProcessingModeFromScrapeRequest returns the processing mode based on the scrape request
 func getProcessingModeFromScrapeRequest(req models.ScrapeRequest) string {
 	if req.Description != "" {
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
@@ -84,6 +83,14 @@ func ResumeScreenshotHandler(cfg *config.Config, taskManager background.TaskMana
 			))
 		}
 
+		runAt, problem := scheduledRunAt(req.TaskSchedule)
+		if problem != "" {
+			return c.JSON(http.StatusBadRequest, models.CreateAsyncErrorResponse(
+				"validation_failed",
+				problem,
+			))
+		}
+
 		// Generate process ID for background task
 		processID := utils.GenerateScreenshotProcessID()
 
@@ -94,18 +101,18 @@ func ResumeScreenshotHandler(cfg *config.Config, taskManager background.TaskMana
 		})
 
 		// Submit task to background task manager
-		ctx := c.Request().Context()
-		err := taskManager.SubmitScreenshotTask(ctx, processID, req, cfg)
+		ctx := logging.ContextWithFields(c.Request().Context(), map[string]interface{}{logging.FieldRequestID: requestID})
+		priority := background.ParseTaskPriority(req.Priority, background.TaskPriorityInteractive)
+		if !runAt.IsZero() {
+			return scheduleSubmission(ctx, c, taskManager, processID, background.TaskTypeScreenshot, req, priority, runAt)
+		}
+		err := taskManager.SubmitScreenshotTask(ctx, processID, req, priority, cfg)
 		if err != nil {
 			logger.Error("Failed to submit background screenshot task", map[string]interface{}{
 				"request_id": requestID,
 				"error":      err,
 			})
-			return c.JSON(http.StatusInternalServerError, models.CreateAsyncErrorResponse(
-				"task_submission_failed",
-				fmt.Sprintf("Failed to submit screenshot task: %v", err),
-				processID,
-			))
+			return taskSubmissionErrorResponse(c, err, "Failed to submit screenshot task", processID)
 		}
 
 		// Return immediate response with process ID
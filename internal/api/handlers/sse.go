@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sseWriter writes Server-Sent Events to an echo response
+type sseWriter struct {
+	response *echo.Response
+}
+
+// newSSEWriter sends the event stream headers and extends the write deadline of the connection
+// to deadline, since streams outlive the server's write timeout
+func newSSEWriter(c echo.Context, deadline time.Time) *sseWriter {
+	response := c.Response()
+	header := response.Header()
+	header.Set(echo.HeaderContentType, "text/event-stream")
+	header.Set(echo.HeaderCacheControl, "no-cache")
+	header.Set(echo.HeaderConnection, "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // disable proxy buffering
+
+	_ = http.NewResponseController(response).SetWriteDeadline(deadline)
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	return &sseWriter{response: response}
+}
+
+// send writes one event with a JSON payload and flushes it to the client
+func (w *sseWriter) send(event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w.response, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	w.response.Flush()
+	return nil
+}
+
+// comment writes an SSE comment, which clients ignore, so idle streams are not closed by proxies
+func (w *sseWriter) comment(text string) error {
+	if _, err := fmt.Fprintf(w.response, ": %s\n\n", text); err != nil {
+		return err
+	}
+	w.response.Flush()
+	return nil
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// TaskResultHandler returns the status and, once finished, the result of a background task
+func TaskResultHandler(taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		processID := c.Param("id")
+
+		logger.Info("Task result request received", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+		})
+
+		result, err := taskManager.GetTaskResult(c.Request().Context(), processID)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, models.CreateAsyncErrorResponse(
+				"not_found",
+				"No task found for this process",
+				processID,
+			))
+		}
+
+		return c.JSON(http.StatusOK, taskResultResponse(result))
+	}
+}
+
+const (
+	// taskEventStreamTimeout bounds a task event stream; clients reconnect to follow longer tasks
+	taskEventStreamTimeout = time.Hour
+
+	// taskEventHeartbeat spaces the keepalive comments of an idle stream
+	taskEventHeartbeat = 15 * time.Second
+)
+
+// TaskEventsHandler streams the status transitions and progress of a background task as
+// Server-Sent Events, for clients that cannot receive callbacks. The stream opens with a
+// "status" event holding the task's current result, then relays its "accepted", "processing",
+// "progress", "completed" and "failed" events, and ends once the task has finished.
+func TaskEventsHandler(taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		processID := c.Param("id")
+
+		logger.Info("Task event stream requested", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+		})
+
+		ctx, cancel := context.WithTimeout(c.Request().Context(), taskEventStreamTimeout)
+		defer cancel()
+
+		// Subscribe before reading the status so no transition falls between the two
+		events := taskManager.SubscribeTaskEvents(ctx, processID)
+		result, err := taskManager.GetTaskResult(ctx, processID)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, models.CreateAsyncErrorResponse(
+				"not_found",
+				"No task found for this process",
+				processID,
+			))
+		}
+
+		deadline, _ := ctx.Deadline()
+		stream := newSSEWriter(c, deadline)
+		if err := stream.send("status", taskResultResponse(result)); err != nil || taskFinished(result.Status) {
+			return nil
+		}
+
+		heartbeat := time.NewTicker(taskEventHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case event, ok := <-events:
+				if !ok {
+					return nil
+				}
+				if err := stream.send(string(event.Event), event); err != nil || event.Terminal() {
+					return nil
+				}
+			case <-heartbeat.C:
+				// Events are delivered best effort, so a missed completion is caught here instead
+				if result, err := taskManager.GetTaskResult(ctx, processID); err == nil && taskFinished(result.Status) {
+					_ = stream.send("status", taskResultResponse(result))
+					return nil
+				}
+				if err := stream.comment("keepalive"); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// taskFinished reports whether a task status is final
+func taskFinished(status background.TaskStatus) bool {
+	return status == background.TaskStatusSuccess || status == background.TaskStatusFailure
+}
+
+// TaskListResponse is one page of background tasks, newest first
+type TaskListResponse struct {
+	Tasks      []models.AsyncTaskStatusResponse `json:"tasks"`
+	Count      int                              `json:"count"`
+	NextCursor string                           `json:"next_cursor,omitempty"`
+	RequestID  string                           `json:"request_id"`
+	Timestamp  time.Time                        `json:"timestamp"`
+}
+
+// TaskListHandler lists background tasks for dashboards, filtered by the status, type,
+// resume_id, url, created_after and created_before (RFC 3339) query parameters. Pages hold
+// limit tasks; the next_cursor of a page is passed as cursor to fetch the next one. Listed
+// tasks leave out their result data, which GET /tasks/:id returns.
+func TaskListHandler(taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		filter := background.TaskFilter{
+			Status:   background.TaskStatus(strings.TrimSpace(c.QueryParam("status"))),
+			Type:     background.TaskType(strings.TrimSpace(c.QueryParam("type"))),
+			ResumeID: strings.TrimSpace(c.QueryParam("resume_id")),
+			URL:      strings.TrimSpace(c.QueryParam("url")),
+			Cursor:   c.QueryParam("cursor"),
+		}
+		for param, target := range map[string]*time.Time{
+			"created_after":  &filter.CreatedAfter,
+			"created_before": &filter.CreatedBefore,
+		} {
+			value := c.QueryParam(param)
+			if value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   param + " must be an RFC 3339 time",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			*target = parsed
+		}
+		if limit := c.QueryParam("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 1 {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "limit must be a positive integer",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			filter.Limit = parsed
+		}
+
+		page, err := taskManager.ListTasks(c.Request().Context(), filter)
+		if err != nil {
+			if utils.IsErrorCode(err, utils.ErrCodeValidationFailed) {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   err.Error(),
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			logging.GetGlobalLogger().Error("Failed to list tasks", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err.Error(),
+			})
+			return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     "list_failed",
+				Message:   "Failed to list tasks",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		tasks := make([]models.AsyncTaskStatusResponse, 0, len(page.Tasks))
+		for _, task := range page.Tasks {
+			tasks = append(tasks, taskStatusResponse(task))
+		}
+		return c.JSON(http.StatusOK, TaskListResponse{
+			Tasks:      tasks,
+			Count:      len(tasks),
+			NextCursor: page.NextCursor,
+			RequestID:  requestID,
+			Timestamp:  time.Now(),
+		})
+	}
+}
+
+// taskResultResponse describes a task with its result data
+func taskResultResponse(result *background.TaskResult) models.AsyncTaskStatusResponse {
+	response := taskStatusResponse(result)
+	response.Data = result.Data
+	return response
+}
+
+// taskStatusResponse describes a task without its result data
+func taskStatusResponse(result *background.TaskResult) models.AsyncTaskStatusResponse {
+	return models.AsyncTaskStatusResponse{
+		ProcessID:      result.ProcessID,
+		Status:         result.Status,
+		Error:          result.Error,
+		ErrorCode:      result.ErrorCode,
+		CreatedAt:      result.CreatedAt,
+		CompletedAt:    result.CompletedAt,
+		ProcessingTime: result.ProcessingTime,
+		Metadata:       result.Metadata,
+	}
+}
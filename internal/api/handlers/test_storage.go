@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// TestStorageHandler serves screenshots and exports held in memory storage in test mode
+func TestStorageHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		object, found := utils.GetMemoryObject(c.Param("*"))
+		if !found {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "not_found",
+				Message:   "Object not found in test storage",
+				RequestID: utils.GenerateRequestID(),
+				Timestamp: time.Now(),
+			})
+		}
+
+		return c.Blob(http.StatusOK, object.ContentType, object.Data)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// TaskTimelineResponse represents the event timeline of a single process
+type TaskTimelineResponse struct {
+	ProcessID string           `json:"processId"`
+	Status    string           `json:"status,omitempty"`
+	Events    []timeline.Event `json:"events"`
+	Count     int              `json:"count"`
+}
+
+// TaskTimelineHandler returns the recorded events for a process ID
+func TaskTimelineHandler(taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		processID := c.Param("id")
+
+		logger.Info("Task timeline request received", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+		})
+
+		events, found := timeline.GetGlobalRecorder().Get(processID)
+		if !found {
+			return c.JSON(http.StatusNotFound, models.CreateAsyncErrorResponse(
+				"not_found",
+				"No timeline recorded for this process",
+				processID,
+			))
+		}
+
+		response := TaskTimelineResponse{
+			ProcessID: processID,
+			Events:    events,
+			Count:     len(events),
+		}
+		if result, err := taskManager.GetTaskResult(c.Request().Context(), processID); err == nil {
+			response.Status = string(result.Status)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/quota"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// APIKeyUsageHandler returns the daily and monthly usage of the calling API key
+func APIKeyUsageHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		manager := quota.GetGlobalManager()
+		if !manager.IsEnabled() {
+			return quotasDisabledResponse(c, requestID)
+		}
+
+		key := quota.APIKeyFromContext(c.Request().Context())
+		if key == nil {
+			return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:     "unauthorized",
+				Message:   "Send an API key in the " + manager.Header() + " header to see its usage",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return apiKeyUsageResponse(c, requestID, manager, key)
+	}
+}
+
+// AdminAPIKeyUsageHandler returns the usage of any configured API key by name
+func AdminAPIKeyUsageHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		manager := quota.GetGlobalManager()
+		if !manager.IsEnabled() {
+			return quotasDisabledResponse(c, requestID)
+		}
+
+		key, found := manager.Lookup(c.Param("name"))
+		if !found {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "not_found",
+				Message:   "No API key is configured with this name",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return apiKeyUsageResponse(c, requestID, manager, key)
+	}
+}
+
+// apiKeyUsageResponse writes the usage report of key
+func apiKeyUsageResponse(c echo.Context, requestID string, manager *quota.Manager, key *quota.APIKey) error {
+	usage, err := manager.Usage(c.Request().Context(), key)
+	if err != nil {
+		logging.GetGlobalLogger().Error("Failed to read API key usage", map[string]interface{}{
+			"request_id": requestID,
+			"api_key":    key.Name,
+			"error":      err.Error(),
+		})
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:     "internal_error",
+			Message:   "Failed to read API key usage",
+			RequestID: requestID,
+			Timestamp: time.Now(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, usage)
+}
+
+// quotasDisabledResponse is returned by the usage routes when quotas are off
+func quotasDisabledResponse(c echo.Context, requestID string) error {
+	return c.JSON(http.StatusNotFound, models.ErrorResponse{
+		Error:     "quotas_disabled",
+		Message:   "API key quotas are disabled; set QUOTAS_ENABLED to enable them",
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	})
+}
+
+// LLMUsageResponse reports LLM token usage and estimated spend for a day and a month
+type LLMUsageResponse struct {
+	Daily     *cost.PeriodReport `json:"daily"`
+	Monthly   *cost.PeriodReport `json:"monthly"`
+	RequestID string             `json:"request_id"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// LLMUsageHandler returns service-wide LLM token usage and estimated cost per operation type and
+// provider. The day and month default to the current ones and can be chosen with the date
+// (YYYY-MM-DD) and month (YYYY-MM) query parameters.
+func LLMUsageHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		day, month := time.Now().UTC(), time.Now().UTC()
+		if date := c.QueryParam("date"); date != "" {
+			parsed, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "date must be formatted as YYYY-MM-DD",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			day = parsed
+		}
+		if monthParam := c.QueryParam("month"); monthParam != "" {
+			parsed, err := time.Parse("2006-01", monthParam)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "validation_failed",
+					Message:   "month must be formatted as YYYY-MM",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+			month = parsed
+		}
+
+		ctx := c.Request().Context()
+		ledger := cost.GetGlobalLedger()
+		daily, err := ledger.Report(ctx, cost.PeriodDaily, day)
+		if err != nil {
+			return llmUsageErrorResponse(c, requestID, err)
+		}
+		monthly, err := ledger.Report(ctx, cost.PeriodMonthly, month)
+		if err != nil {
+			return llmUsageErrorResponse(c, requestID, err)
+		}
+
+		return c.JSON(http.StatusOK, LLMUsageResponse{
+			Daily:     daily,
+			Monthly:   monthly,
+			RequestID: requestID,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// llmUsageErrorResponse logs and reports a failure to read the usage ledger
+func llmUsageErrorResponse(c echo.Context, requestID string, err error) error {
+	logging.GetGlobalLogger().Error("Failed to read LLM usage", map[string]interface{}{
+		"request_id": requestID,
+		"error":      err.Error(),
+	})
+	return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:     "internal_error",
+		Message:   "Failed to read LLM usage",
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper/engines/firecrawl"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// maxWebhookBytes bounds the body of a webhook event; crawl.page events carry page content
+const maxWebhookBytes = 20 << 20
+
+// FirecrawlWebhookHandler handles POST /api/v1/webhooks/firecrawl, where Firecrawl reports the
+// pages and completion of crawls handed to it. Events must be signed with the webhook secret;
+// without one no crawl is handed to Firecrawl and every event is refused. Events of crawls no
+// replica awaits are answered 404.
+func FirecrawlWebhookHandler(cfg *config.Config, taskManager background.TaskManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		c.Set("request_id", requestID)
+
+		body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBytes))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Failed to read webhook body",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		if secret := cfg.Firecrawl.Webhook.Secret; secret == "" || !firecrawl.VerifySignature(secret, body, c.Request().Header.Get(firecrawl.SignatureHeader)) {
+			logger.Warn("Rejected Firecrawl webhook with an invalid signature", map[string]interface{}{
+				"request_id": requestID,
+			})
+			return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:     "unauthorized",
+				Message:   "Invalid webhook signature",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		var event firecrawl.WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_request",
+				Message:   "Invalid webhook event",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Debug("Firecrawl webhook received", map[string]interface{}{
+			"request_id": requestID,
+			"event":      event.Type,
+			"crawl_id":   event.ID,
+			"process_id": event.ProcessID(),
+			"pages":      len(event.Data),
+		})
+
+		if err := taskManager.HandleFirecrawlEvent(c.Request().Context(), &event); err != nil {
+			status := http.StatusInternalServerError
+			code := "internal_error"
+			if errors.Is(err, background.ErrTaskNotFound) {
+				status = http.StatusNotFound
+				code = "not_found"
+			}
+			return c.JSON(status, models.ErrorResponse{
+				Error:     code,
+				Message:   err.Error(),
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"received":   true,
+			"request_id": requestID,
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -172,6 +173,8 @@ func DetailedWorkerStatusHandler(poolManager *workers.PoolManager) echo.HandlerF
 			JobsFailed:     stats.PoolStats.JobsFailed,
 			Details: map[string]interface{}{
 				"rate_limiter_stats":      stats.RateLimiterStats,
+				"queued_by_domain":        stats.QueuedByDomain,
+				"queued_by_priority":      stats.QueuedByPriority,
 				"average_processing_time": stats.PoolStats.AverageProcessingTime,
 				"total_processing_time":   stats.PoolStats.TotalProcessingTime,
 			},
@@ -189,3 +192,217 @@ func DetailedWorkerStatusHandler(poolManager *workers.PoolManager) echo.HandlerF
 		return c.JSON(http.StatusOK, response)
 	}
 }
+
+// SetWorkerPoolSizeRequest is the body of the worker pool resize admin endpoint
+type SetWorkerPoolSizeRequest struct {
+	Size int    `json:"size"`
+	Pool string `json:"pool,omitempty"` // "browser" (default) or "api"
+}
+
+// SetWorkerPoolSizeHandler sets the target worker count of the scraper pool at runtime
+func SetWorkerPoolSizeHandler(poolManager *workers.PoolManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		var req SetWorkerPoolSizeRequest
+		if err := c.Bind(&req); err != nil || req.Size < 1 {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "invalid_size",
+				Message:   "Request body must contain a positive integer size",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		if req.Pool == "" {
+			req.Pool = workers.PoolBrowser
+		}
+
+		scaling, err := poolManager.SetWorkerCount(req.Pool, req.Size)
+		if errors.Is(err, workers.ErrUnknownPool) {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:     "unknown_pool",
+				Message:   "Pool must be one of the running worker pools: browser or api",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if err != nil {
+			logger.Error("Failed to resize worker pool", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "pool_unavailable",
+				Message:   "Worker pool is not available",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Info("Worker pool resized via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"pool":       req.Pool,
+			"requested":  req.Size,
+			"target":     scaling.TargetWorkers,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"pool":       req.Pool,
+			"requested":  req.Size,
+			"scaling":    scaling,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// PauseWorkerPoolRequest is the optional body of the worker pool pause admin endpoint
+type PauseWorkerPoolRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PauseWorkerPoolHandler pauses job dispatch; new jobs are still accepted and queued
+func PauseWorkerPoolHandler(poolManager *workers.PoolManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		var req PauseWorkerPoolRequest
+		if c.Request().ContentLength > 0 {
+			if err := c.Bind(&req); err != nil {
+				return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:     "invalid_request",
+					Message:   "Request body must be JSON with an optional reason",
+					RequestID: requestID,
+					Timestamp: time.Now(),
+				})
+			}
+		}
+
+		state, err := poolManager.Pause(req.Reason)
+		if err != nil {
+			logger.Error("Failed to pause worker pool", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "pool_unavailable",
+				Message:   "Worker pool is not available",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Info("Worker pool paused via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"reason":     req.Reason,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"dispatch":   state,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// ResumeWorkerPoolHandler resumes job dispatch after a pause
+func ResumeWorkerPoolHandler(poolManager *workers.PoolManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+
+		state, err := poolManager.Resume()
+		if err != nil {
+			logger.Error("Failed to resume worker pool", map[string]interface{}{
+				"request_id": requestID,
+				"error":      err,
+			})
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "pool_unavailable",
+				Message:   "Worker pool is not available",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Info("Worker pool resumed via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"dispatch":   state,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// DomainCooldownsHandler lists domains currently in cooldown
+func DomainCooldownsHandler(poolManager *workers.PoolManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+
+		cooldowns, err := poolManager.DomainCooldowns()
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "pool_unavailable",
+				Message:   "Worker pool is not available",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"cooldowns":  cooldowns,
+			"count":      len(cooldowns),
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
+
+// ClearDomainCooldownHandler ends the cooldown of a domain so it accepts jobs again
+func ClearDomainCooldownHandler(poolManager *workers.PoolManager) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := utils.GenerateRequestID()
+		logger := logging.GetGlobalLogger()
+		domain := c.Param("domain")
+
+		cleared, err := poolManager.ClearDomainCooldown(domain)
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     "pool_unavailable",
+				Message:   "Worker pool is not available",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+		if !cleared {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:     "not_found",
+				Message:   "Domain is not in cooldown",
+				RequestID: requestID,
+				Timestamp: time.Now(),
+			})
+		}
+
+		logger.Info("Domain cooldown cleared via admin endpoint", map[string]interface{}{
+			"request_id": requestID,
+			"domain":     domain,
+		})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"domain":     domain,
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// AdminAuth requires the configured admin bearer token; admin endpoints are disabled when no
+// token is configured
+func AdminAuth(token string) echo.MiddlewareFunc {
+	expected := []byte(token)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if len(expected) == 0 {
+				return c.JSON(http.StatusForbidden, models.ErrorResponse{
+					Error:     "admin_disabled",
+					Message:   "Admin endpoints are disabled; set ADMIN_TOKEN to enable them",
+					RequestID: utils.GenerateRequestID(),
+					Timestamp: time.Now(),
+				})
+			}
+
+			provided, found := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(provided), expected) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="admin"`)
+				return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+					Error:     "unauthorized",
+					Message:   "A valid admin bearer token is required",
+					RequestID: utils.GenerateRequestID(),
+					Timestamp: time.Now(),
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"letraz-utils/internal/audit"
+	"letraz-utils/internal/quota"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditLog middleware records every API call to the audit sink
+func AuditLog() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			auditor := audit.GetGlobalAuditor()
+			if !auditor.IsEnabled() {
+				return next(c)
+			}
+
+			startTime := time.Now()
+			req := c.Request()
+
+			// Read at most MaxHashedBodyBytes of the body for hashing; the handler gets what was
+			// read followed by the rest, unread
+			var body []byte
+			var truncated bool
+			if req.Body != nil {
+				read, _ := io.ReadAll(io.LimitReader(req.Body, audit.MaxHashedBodyBytes+1))
+				body, truncated = read, len(read) > audit.MaxHashedBodyBytes
+				if truncated {
+					body = read[:audit.MaxHashedBodyBytes]
+				}
+				req.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(read), req.Body), req.Body}
+			}
+
+			err := next(c)
+
+			statusCode := c.Response().Status
+			if err != nil {
+				if httpErr, ok := err.(*echo.HTTPError); ok {
+					statusCode = httpErr.Code
+				} else {
+					statusCode = http.StatusInternalServerError
+				}
+			}
+
+			// The API key middleware of the route stores the authenticated key on the request
+			var caller string
+			if key := quota.APIKeyFromContext(c.Request().Context()); key != nil {
+				caller = key.Name
+			}
+
+			auditor.Record(&audit.Record{
+				Timestamp:       startTime,
+				RequestID:       c.Response().Header().Get("X-Request-ID"),
+				Caller:          caller,
+				ClientID:        req.Header.Get(audit.ClientIDHeader),
+				RemoteAddr:      c.RealIP(),
+				Protocol:        "http",
+				Method:          req.Method,
+				Endpoint:        c.Path(),
+				ParamsHash:      audit.HashParams([]byte(req.URL.RawQuery), body),
+				ParamsTruncated: truncated,
+				Status:          http.StatusText(statusCode),
+				StatusCode:      statusCode,
+				Latency:         time.Since(startTime),
+			})
+
+			return err
+		}
+	}
+}
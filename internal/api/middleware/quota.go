@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"letraz-utils/internal/quota"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// APIKeyAuth resolves the API key header to a configured client and attributes the request to
+// it. Calls without a key pass through unmetered unless keys are required.
+func APIKeyAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			manager := quota.GetGlobalManager()
+			if !manager.IsEnabled() {
+				return next(c)
+			}
+
+			if _, ok := authenticateAPIKey(c, manager); !ok {
+				return unauthorizedAPIKeyResponse(c, manager)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Quota authenticates the API key like APIKeyAuth and rejects the submission with 429 when the
+// key has used up any of resources. Accepted submissions count one unit of each resource
+// except LLM tokens, which are recorded as the provider reports them.
+func Quota(resources ...quota.Resource) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			manager := quota.GetGlobalManager()
+			if !manager.IsEnabled() {
+				return next(c)
+			}
+
+			key, ok := authenticateAPIKey(c, manager)
+			if !ok {
+				return unauthorizedAPIKeyResponse(c, manager)
+			}
+
+			ctx := c.Request().Context()
+			if err := manager.Check(ctx, key, resources...); err != nil {
+				retryAfter := utils.RetryAfterSeconds(utils.GetRetryAfter(err))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+				response := models.CreateAsyncErrorResponse("quota_exceeded", err.Error())
+				response.RetryAfter = retryAfter
+				return c.JSON(http.StatusTooManyRequests, response)
+			}
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			if status := c.Response().Status; status >= 200 && status < 300 {
+				for _, resource := range resources {
+					if resource != quota.ResourceLLMTokens {
+						manager.Record(ctx, key, resource, 1)
+					}
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// authenticateAPIKey resolves the API key header and stores the key in the request context.
+// It reports false for unknown keys, and for missing keys when keys are required.
+func authenticateAPIKey(c echo.Context, manager *quota.Manager) (*quota.APIKey, bool) {
+	secret := c.Request().Header.Get(manager.Header())
+	if secret == "" {
+		return nil, !manager.RequireKey()
+	}
+
+	key, err := manager.Authenticate(secret)
+	if err != nil {
+		return nil, false
+	}
+
+	c.SetRequest(c.Request().WithContext(quota.WithAPIKey(c.Request().Context(), key)))
+	return key, true
+}
+
+// unauthorizedAPIKeyResponse rejects a call with a missing or unknown API key
+func unauthorizedAPIKeyResponse(c echo.Context, manager *quota.Manager) error {
+	return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+		Error:     "unauthorized",
+		Message:   "A valid API key is required in the " + manager.Header() + " header",
+		RequestID: utils.GenerateRequestID(),
+		Timestamp: time.Now(),
+	})
+}
@@ -21,8 +21,16 @@ func SelectiveTimeoutConfig(defaultTimeout time.Duration, longTimeout time.Durat
 		return func(c echo.Context) error {
 			path := c.Request().URL.Path
 
+			// Streaming endpoints bound their own duration; the timeout middleware buffers the
+			// response, which would hold back every event until the handler returns
+			if strings.HasSuffix(path, "/stream") || strings.HasSuffix(path, "/events") {
+				return next(c)
+			}
+
 			// Apply longer timeout for AI-intensive endpoints
-			if strings.Contains(path, "/resume/tailor") {
+			if strings.Contains(path, "/resume/tailor") || strings.HasSuffix(path, "/resume/ats-score") ||
+				strings.HasSuffix(path, "/resume/skill-gap") || strings.HasSuffix(path, "/resume/refine") ||
+				strings.HasSuffix(path, "/company/enrich") {
 				timeoutMiddleware := middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 					Timeout: longTimeout,
 				})
@@ -4,10 +4,17 @@ import (
 	"letraz-utils/internal/api/handlers"
 	"letraz-utils/internal/api/middleware"
 	"letraz-utils/internal/background"
+	"letraz-utils/internal/company"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/crawl"
+	"letraz-utils/internal/embeddings"
+	"letraz-utils/internal/jobmonitor"
+	"letraz-utils/internal/jobsearch"
 	"letraz-utils/internal/llm"
 	"letraz-utils/internal/logging"
+	"letraz-utils/internal/quota"
 	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/pkg/utils"
 	"net/http"
 	"time"
 
@@ -16,7 +23,7 @@ import (
 )
 
 // SetupRoutes configures all API routes
-func SetupRoutes(e *echo.Echo, cfg *config.Config, poolManager *workers.PoolManager, llmManager *llm.Manager, taskManager background.TaskManager) {
+func SetupRoutes(e *echo.Echo, cfg *config.Config, poolManager *workers.PoolManager, llmManager *llm.Manager, taskManager background.TaskManager, jobMonitor *jobmonitor.Monitor, jobSearcher *jobsearch.Searcher, enricher *company.Enricher, crawler *crawl.Crawler, conversations *utils.ConversationStore, embedder embeddings.Embedder) {
 	// Global middleware
 	e.Use(echomiddleware.Logger())
 	e.Use(echomiddleware.Recover())
@@ -52,19 +59,71 @@ func SetupRoutes(e *echo.Echo, cfg *config.Config, poolManager *workers.PoolMana
 	// Status route
 	e.GET("/status", handlers.StatusHandler)
 
+	// Prometheus scrape endpoint
+	e.GET("/metrics", handlers.PrometheusMetricsHandler())
+
+	// Objects uploaded to memory storage are served from here in test mode
+	if cfg.TestMode.Enabled {
+		e.GET(utils.MemoryStoragePath+"/*", handlers.TestStorageHandler())
+	}
+
 	// API v1 routes
-	v1 := e.Group("/api/v1")
+	v1 := e.Group("/api/v1", middleware.AuditLog())
 	{
-		v1.POST("/scrape", handlers.ScrapeHandler(cfg, poolManager, taskManager))
+		v1.POST("/scrape", handlers.ScrapeHandler(cfg, poolManager, taskManager), middleware.Quota(quota.ResourceScrapes, quota.ResourceLLMTokens))
+		v1.POST("/scrape/batch", handlers.BatchScrapeHandler(cfg, poolManager, taskManager), middleware.Quota(quota.ResourceScrapes, quota.ResourceLLMTokens))
+		v1.POST("/scrape/crawl", handlers.CrawlHandler(cfg, poolManager, taskManager, crawler), middleware.Quota(quota.ResourceScrapes, quota.ResourceLLMTokens))
+		v1.POST("/scrape/crawl/map", handlers.CrawlMapHandler(cfg, crawler))
+		v1.POST("/scrape/pdf", handlers.ScrapePDFHandler(cfg, llmManager), middleware.Quota(quota.ResourceScrapes, quota.ResourceLLMTokens))
+		v1.POST("/scrape/:process_id/re-extract", handlers.ReExtractHandler(cfg, llmManager), middleware.Quota(quota.ResourceLLMTokens))
+		v1.POST("/webhooks/firecrawl", handlers.FirecrawlWebhookHandler(cfg, taskManager))
+		v1.GET("/usage", handlers.APIKeyUsageHandler(), middleware.APIKeyAuth())
+		v1.GET("/usage/llm", handlers.LLMUsageHandler(), middleware.AdminAuth(cfg.Admin.Token))
 
 		// Resume tailoring routes
 		resume := v1.Group("/resume")
 		{
-			resume.POST("/tailor", handlers.TailorResumeHandler(cfg, llmManager, taskManager))
-			resume.POST("/screenshot", handlers.ResumeScreenshotHandler(cfg, taskManager))
+			resume.POST("/tailor", handlers.TailorResumeHandler(cfg, llmManager, taskManager), middleware.Quota(quota.ResourceTailorings, quota.ResourceLLMTokens))
+			resume.POST("/tailor/stream", handlers.TailorResumeStreamHandler(cfg, llmManager), middleware.Quota(quota.ResourceTailorings, quota.ResourceLLMTokens))
+			resume.POST("/refine", handlers.RefineResumeHandler(llmManager, conversations), middleware.Quota(quota.ResourceTailorings, quota.ResourceLLMTokens))
+			resume.POST("/ats-score", handlers.ATSScoreHandler(llmManager), middleware.Quota(quota.ResourceLLMTokens))
+			resume.POST("/relevance", handlers.RelevanceHandler(cfg, embedder), middleware.Quota(quota.ResourceLLMTokens))
+			resume.POST("/skill-gap", handlers.SkillGapHandler(llmManager), middleware.Quota(quota.ResourceLLMTokens))
+			resume.POST("/interview-questions", handlers.InterviewQuestionsHandler(llmManager, taskManager), middleware.Quota(quota.ResourceLLMTokens))
+			resume.POST("/screenshot", handlers.ResumeScreenshotHandler(cfg, taskManager), middleware.Quota(quota.ResourceScreenshots))
 			resume.POST("/export", handlers.ExportResumeHandler(cfg))
 		}
 
+		// Task debugging routes
+		tasks := v1.Group("/tasks")
+		{
+			tasks.GET("", handlers.TaskListHandler(taskManager))
+			tasks.GET("/:id", handlers.TaskResultHandler(taskManager))
+			tasks.GET("/:id/timeline", handlers.TaskTimelineHandler(taskManager))
+			tasks.GET("/:id/events", handlers.TaskEventsHandler(taskManager))
+		}
+
+		// Saved-job monitoring routes
+		monitors := v1.Group("/monitors")
+		{
+			monitors.POST("", handlers.RegisterJobWatchHandler(cfg, jobMonitor))
+			monitors.GET("", handlers.ListJobWatchesHandler(jobMonitor))
+			monitors.GET("/:id", handlers.GetJobWatchHandler(jobMonitor))
+			monitors.DELETE("/:id", handlers.DeleteJobWatchHandler(jobMonitor))
+		}
+
+		// Job discovery across registered company job boards
+		jobs := v1.Group("/jobs")
+		{
+			jobs.GET("/search", handlers.JobSearchHandler(jobSearcher))
+		}
+
+		// Company information gathered from company websites
+		companies := v1.Group("/company")
+		{
+			companies.POST("/enrich", handlers.CompanyEnrichHandler(cfg, enricher), middleware.Quota(quota.ResourceScrapes, quota.ResourceLLMTokens))
+		}
+
 		// Proto file serving routes
 		proto := v1.Group("/proto")
 		{
@@ -79,6 +138,23 @@ func SetupRoutes(e *echo.Echo, cfg *config.Config, poolManager *workers.PoolMana
 			workers.GET("/status", handlers.DetailedWorkerStatusHandler(poolManager))
 		}
 
+		// Admin routes (require the admin bearer token)
+		admin := v1.Group("/admin", middleware.AdminAuth(cfg.Admin.Token))
+		{
+			admin.PUT("/workers/size", handlers.SetWorkerPoolSizeHandler(poolManager))
+			admin.POST("/workers/pause", handlers.PauseWorkerPoolHandler(poolManager))
+			admin.POST("/workers/resume", handlers.ResumeWorkerPoolHandler(poolManager))
+			admin.GET("/domains/cooldowns", handlers.DomainCooldownsHandler(poolManager))
+			admin.DELETE("/domains/:domain/cooldown", handlers.ClearDomainCooldownHandler(poolManager))
+			admin.GET("/usage/:name", handlers.AdminAPIKeyUsageHandler())
+			admin.GET("/browsers", handlers.ListBrowsersHandler())
+			admin.DELETE("/browsers/:id", handlers.CloseBrowserHandler())
+			admin.POST("/browsers/drain", handlers.DrainBrowserPoolHandler())
+			admin.POST("/browsers/cleanup", handlers.CleanupBrowserPoolHandler())
+			admin.POST("/drain", handlers.DrainTaskManagerHandler(taskManager, cfg.BackgroundTasks.DrainTimeout))
+			admin.GET("/drain", handlers.DrainStatusHandler(taskManager))
+		}
+
 		// Metrics and monitoring routes
 		metrics := v1.Group("/metrics")
 		{
@@ -0,0 +1,281 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/utils"
+)
+
+// keyPrefix namespaces archived pages in the bucket
+const keyPrefix = "scrapes/"
+
+// ErrNotFound is returned when a task has no archived page, because it was never archived or
+// its retention ran out
+var ErrNotFound = errors.New("archive: page not found")
+
+// Page formats
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// Page is the content a scrape handed to extraction: the page's HTML after any captcha, or the
+// markdown Firecrawl converted it to
+type Page struct {
+	URL        string    `json:"url"`
+	Format     string    `json:"format"`
+	Content    string    `json:"content"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// NewPage creates a page captured now, telling HTML from markdown by its first character
+func NewPage(url, content string) *Page {
+	format := FormatMarkdown
+	if strings.HasPrefix(strings.TrimSpace(content), "<") {
+		format = FormatHTML
+	}
+	return &Page{URL: url, Format: format, Content: content, CapturedAt: time.Now()}
+}
+
+// Capture holds the last page handed to extraction during one job; engines that fall back to
+// another engine extract more than once, and the last page is the one the result came from
+type Capture struct {
+	page *Page
+	mu   sync.Mutex
+}
+
+// NewCapture creates an empty capture
+func NewCapture() *Capture {
+	return &Capture{}
+}
+
+// Set replaces the captured page
+func (c *Capture) Set(page *Page) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.page = page
+}
+
+// Page returns the captured page, or nil when nothing reached extraction
+func (c *Capture) Page() *Page {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.page
+}
+
+// captureContextKey is the context key under which the current job's capture is stored
+type captureContextKey struct{}
+
+// WithCapture returns a copy of ctx whose extracted pages are recorded into c
+func WithCapture(ctx context.Context, c *Capture) context.Context {
+	return context.WithValue(ctx, captureContextKey{}, c)
+}
+
+// FromContext returns the capture carried by ctx, if any
+func FromContext(ctx context.Context) *Capture {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(captureContextKey{}).(*Capture)
+	return c
+}
+
+// Record records the content of url handed to extraction on the capture carried by ctx; pages
+// outside a job context are dropped
+func Record(ctx context.Context, url, content string) {
+	c := FromContext(ctx)
+	if c == nil {
+		return
+	}
+	c.Set(NewPage(url, content))
+}
+
+// Archive keeps captured pages as gzipped JSON objects under scrapes/ and deletes them after
+// the retention period
+type Archive struct {
+	objects         utils.PrivateObjects
+	retention       time.Duration
+	cleanupInterval time.Duration
+	logger          types.Logger
+	stop            chan struct{}
+	stopOnce        sync.Once
+}
+
+// NewArchive creates an archive keeping pages in objects for retention
+func NewArchive(objects utils.PrivateObjects, retention, cleanupInterval time.Duration) *Archive {
+	return &Archive{
+		objects:         objects,
+		retention:       retention,
+		cleanupInterval: cleanupInterval,
+		logger:          logging.GetGlobalLogger(),
+		stop:            make(chan struct{}),
+	}
+}
+
+// Key returns the object key of the page of a scrape task, or of the URL at index of a batch
+// or crawl task when index is not negative
+func Key(processID string, index int) string {
+	if index < 0 {
+		return keyPrefix + processID + ".json.gz"
+	}
+	return fmt.Sprintf("%s%s/%d.json.gz", keyPrefix, processID, index)
+}
+
+// Store archives page under key
+func (a *Archive) Store(ctx context.Context, key string, page *Page) error {
+	if a == nil || page == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(page); err != nil {
+		return fmt.Errorf("failed to encode page: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress page: %w", err)
+	}
+	return a.objects.PutObject(ctx, key, buf.Bytes(), "application/gzip")
+}
+
+// Load returns the page archived under key, or ErrNotFound
+func (a *Archive) Load(ctx context.Context, key string) (*Page, error) {
+	if a == nil {
+		return nil, ErrNotFound
+	}
+
+	data, err := a.objects.GetObject(ctx, key)
+	if errors.Is(err, utils.ErrObjectNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress page: %w", err)
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress page: %w", err)
+	}
+
+	var page Page
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, fmt.Errorf("failed to decode page: %w", err)
+	}
+	return &page, nil
+}
+
+// Sweep deletes the pages archived longer ago than the retention period
+func (a *Archive) Sweep(ctx context.Context) (int, error) {
+	if a == nil || a.retention <= 0 {
+		return 0, nil
+	}
+	return a.objects.DeleteObjectsBefore(ctx, keyPrefix, time.Now().Add(-a.retention))
+}
+
+// StartSweeper runs Sweep on the cleanup interval until Stop is called
+func (a *Archive) StartSweeper() {
+	if a == nil || a.cleanupInterval <= 0 || a.retention <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(a.cleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), a.cleanupInterval/2)
+				deleted, err := a.Sweep(ctx)
+				cancel()
+				if err != nil {
+					a.logger.Warn("Scrape archive sweep failed", map[string]interface{}{
+						"error": err.Error(),
+					})
+					continue
+				}
+				if deleted > 0 {
+					a.logger.Info("Scrape archive sweep completed", map[string]interface{}{
+						"pages_deleted": deleted,
+					})
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the sweeper
+func (a *Archive) Stop() {
+	if a == nil {
+		return
+	}
+	a.stopOnce.Do(func() { close(a.stop) })
+}
+
+// Global archive instance
+var (
+	globalArchive *Archive
+	globalMu      sync.RWMutex
+)
+
+// InitializeGlobalArchive creates the global archive when archiving is enabled in configuration
+// and object storage is configured; otherwise the global archive stays nil and nothing is kept
+func InitializeGlobalArchive(cfg *config.Config) *Archive {
+	if !cfg.Scraper.Archive.Enabled {
+		return nil
+	}
+	logger := logging.GetGlobalLogger()
+
+	objects, err := utils.NewPrivateObjects(cfg)
+	if err != nil {
+		logger.Warn("Scrape archive disabled, object storage is not configured", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	archive := NewArchive(objects, cfg.Scraper.Archive.Retention, cfg.Scraper.Archive.CleanupInterval)
+
+	globalMu.Lock()
+	globalArchive = archive
+	globalMu.Unlock()
+
+	logger.Info("Scrape archive enabled", map[string]interface{}{
+		"retention": cfg.Scraper.Archive.Retention.String(),
+	})
+	return archive
+}
+
+// GetGlobalArchive returns the global archive, which is nil and keeps nothing unless it was
+// initialized
+func GetGlobalArchive() *Archive {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalArchive
+}
@@ -0,0 +1,145 @@
+package ats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"letraz-utils/internal/salary"
+	"letraz-utils/pkg/models"
+)
+
+// ashbyPostingAPI is the public Ashby job posting API
+const ashbyPostingAPI = "https://api.ashbyhq.com/posting-api/job-board"
+
+// AshbyProvider reads jobs from api.ashbyhq.com
+type AshbyProvider struct {
+	client    *http.Client
+	userAgent string
+}
+
+// ashbyJob is a job in the Ashby posting API
+type ashbyJob struct {
+	ID               string `json:"id"`
+	Title            string `json:"title"`
+	Location         string `json:"location"`
+	IsRemote         bool   `json:"isRemote"`
+	JobURL           string `json:"jobUrl"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	DescriptionHTML  string `json:"descriptionHtml"`
+	IsListed         *bool  `json:"isListed"`
+	Compensation     *struct {
+		SummaryComponents []struct {
+			CompensationType string  `json:"compensationType"`
+			Interval         string  `json:"interval"` // e.g. "1 YEAR", "1 HOUR"
+			CurrencyCode     string  `json:"currencyCode"`
+			MinValue         float64 `json:"minValue"`
+			MaxValue         float64 `json:"maxValue"`
+		} `json:"summaryComponents"`
+	} `json:"compensation"`
+}
+
+// Name returns the ATS name
+func (p *AshbyProvider) Name() string {
+	return ProviderAshby
+}
+
+// ListJobs returns every listed job on an Ashby job board
+func (p *AshbyProvider) ListJobs(ctx context.Context, token string) ([]models.Job, error) {
+	var response struct {
+		Jobs []ashbyJob `json:"jobs"`
+	}
+	endpoint := fmt.Sprintf("%s/%s", ashbyPostingAPI, url.PathEscape(token))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("ashby board %s: %w", token, err)
+	}
+
+	jobs := make([]models.Job, 0, len(response.Jobs))
+	for _, job := range response.Jobs {
+		if job.IsListed != nil && !*job.IsListed {
+			continue
+		}
+		location := job.Location
+		if job.IsRemote && location == "" {
+			location = "Remote"
+		}
+		jobs = append(jobs, models.Job{
+			Title:       job.Title,
+			JobURL:      job.JobURL,
+			Location:    location,
+			Description: job.DescriptionPlain,
+		})
+	}
+	return jobs, nil
+}
+
+// matchPosting matches jobs.ashbyhq.com/{board}/{id}, including the /application page
+func (p *AshbyProvider) matchPosting(u *url.URL) (postingRef, bool) {
+	if strings.ToLower(u.Hostname()) != "jobs.ashbyhq.com" {
+		return postingRef{}, false
+	}
+
+	segments := pathSegments(u)
+	if len(segments) < 2 || !uuidPattern.MatchString(segments[1]) {
+		return postingRef{}, false
+	}
+	return postingRef{board: segments[0], id: segments[1]}, true
+}
+
+// fetchPosting reads a job from the Ashby posting API. The API has no endpoint for a single
+// job, so the job is found on its board.
+func (p *AshbyProvider) fetchPosting(ctx context.Context, ref postingRef) (*Posting, error) {
+	var response struct {
+		Jobs []ashbyJob `json:"jobs"`
+	}
+	endpoint := fmt.Sprintf("%s/%s?includeCompensation=true", ashbyPostingAPI, url.PathEscape(ref.board))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("ashby board %s: %w", ref.board, err)
+	}
+
+	for _, job := range response.Jobs {
+		if !strings.EqualFold(job.ID, ref.id) {
+			continue
+		}
+
+		location := job.Location
+		if job.IsRemote && location == "" {
+			location = "Remote"
+		}
+		posting := postingFromHTML(&models.Job{
+			Title:       job.Title,
+			JobURL:      job.JobURL,
+			CompanyName: companyFromToken(ref.board),
+			Location:    location,
+		}, job.DescriptionHTML)
+		if posting.Text == "" {
+			posting.Text = job.DescriptionPlain
+			posting.Job.Description = summarize(job.DescriptionPlain)
+		}
+
+		if job.Compensation != nil {
+			for _, component := range job.Compensation.SummaryComponents {
+				if component.CompensationType != "Salary" {
+					continue
+				}
+				// The interval is a count and a unit, as in "1 YEAR"
+				period := ""
+				if fields := strings.Fields(component.Interval); len(fields) == 2 && fields[0] == "1" {
+					period = salary.NormalizePeriod(fields[1])
+				}
+				posting.Job.Currency = component.CurrencyCode
+				posting.Job.Salary = models.Salary{
+					Currency: component.CurrencyCode,
+					Min:      component.MinValue,
+					Max:      component.MaxValue,
+					Period:   period,
+				}
+				break
+			}
+		}
+		return posting, nil
+	}
+	return nil, fmt.Errorf("ashby board %s: job %s not found", ref.board, ref.id)
+}
@@ -0,0 +1,119 @@
+package ats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/pkg/models"
+)
+
+// Supported applicant tracking systems
+const (
+	ProviderGreenhouse = "greenhouse"
+	ProviderLever      = "lever"
+	ProviderAshby      = "ashby"
+	ProviderWorkday    = "workday"
+)
+
+// maxResponseBytes bounds board API responses; large boards list a few thousand jobs
+const maxResponseBytes = 32 << 20
+
+// Provider reads job postings from the public job board API of an applicant tracking system
+type Provider interface {
+	// Name returns the ATS name
+	Name() string
+
+	// ListJobs returns every open job on the board identified by token
+	ListJobs(ctx context.Context, token string) ([]models.Job, error)
+}
+
+// NewProviders creates a provider for every supported ATS, keyed by name
+func NewProviders(cfg *config.Config, client *http.Client) map[string]Provider {
+	userAgent := cfg.Scraper.UserAgent
+	return map[string]Provider{
+		ProviderGreenhouse: &GreenhouseProvider{client: client, userAgent: userAgent},
+		ProviderLever:      &LeverProvider{client: client, userAgent: userAgent},
+		ProviderAshby:      &AshbyProvider{client: client, userAgent: userAgent},
+	}
+}
+
+// SupportedProviders returns the names of the supported applicant tracking systems
+func SupportedProviders() []string {
+	return []string{ProviderAshby, ProviderGreenhouse, ProviderLever}
+}
+
+// boardHosts maps the hosts of the public job boards of each ATS with a listing API to the ATS
+var boardHosts = map[string]string{
+	"boards.greenhouse.io":     ProviderGreenhouse,
+	"job-boards.greenhouse.io": ProviderGreenhouse,
+	"jobs.lever.co":            ProviderLever,
+	"jobs.ashbyhq.com":         ProviderAshby,
+}
+
+// DetectBoard returns the ATS and board token of a job board URL such as
+// https://boards.greenhouse.io/acme, whose jobs can be listed with the ATS's provider
+func DetectBoard(rawURL string) (provider, token string, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", false
+	}
+	provider, ok = boardHosts[strings.ToLower(u.Hostname())]
+	if !ok {
+		return "", "", false
+	}
+	segments := pathSegments(u)
+	if len(segments) != 1 || segments[0] == "embed" {
+		return "", "", false
+	}
+	return provider, segments[0], true
+}
+
+// getJSON fetches endpoint and decodes the JSON response into v
+func getJSON(ctx context.Context, client *http.Client, userAgent, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("board not found")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("board API returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode board API response: %w", err)
+	}
+	return nil
+}
+
+// htmlToText converts an HTML fragment to plain text
+func htmlToText(fragment string) string {
+	if fragment == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
@@ -0,0 +1,126 @@
+package ats
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"letraz-utils/pkg/models"
+)
+
+// greenhouseBoardsAPI is the public Greenhouse job board API
+const greenhouseBoardsAPI = "https://boards-api.greenhouse.io/v1/boards"
+
+// greenhouseBoardsAPIEU is the Greenhouse job board API for boards hosted in the EU
+const greenhouseBoardsAPIEU = "https://boards-api.eu.greenhouse.io/v1/boards"
+
+// GreenhouseProvider reads jobs from boards-api.greenhouse.io
+type GreenhouseProvider struct {
+	client    *http.Client
+	userAgent string
+}
+
+// greenhouseJob is a job in the Greenhouse board API
+type greenhouseJob struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	AbsoluteURL string `json:"absolute_url"`
+	CompanyName string `json:"company_name"`
+	Content     string `json:"content"` // HTML-escaped HTML
+	Location    struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	PayInputRanges []struct {
+		MinCents     int    `json:"min_cents"`
+		MaxCents     int    `json:"max_cents"`
+		CurrencyType string `json:"currency_type"`
+	} `json:"pay_input_ranges"`
+}
+
+// Name returns the ATS name
+func (p *GreenhouseProvider) Name() string {
+	return ProviderGreenhouse
+}
+
+// ListJobs returns every open job on a Greenhouse board
+func (p *GreenhouseProvider) ListJobs(ctx context.Context, token string) ([]models.Job, error) {
+	var response struct {
+		Jobs []greenhouseJob `json:"jobs"`
+	}
+	endpoint := fmt.Sprintf("%s/%s/jobs?content=true", greenhouseBoardsAPI, url.PathEscape(token))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("greenhouse board %s: %w", token, err)
+	}
+
+	jobs := make([]models.Job, 0, len(response.Jobs))
+	for _, job := range response.Jobs {
+		jobs = append(jobs, models.Job{
+			Title:       job.Title,
+			JobURL:      job.AbsoluteURL,
+			CompanyName: job.CompanyName,
+			Location:    job.Location.Name,
+			Description: htmlToText(html.UnescapeString(job.Content)),
+		})
+	}
+	return jobs, nil
+}
+
+// matchPosting matches boards.greenhouse.io/{board}/jobs/{id}, its job-boards and EU hosts, and
+// the embedded application form at /embed/job_app?for={board}&token={id}
+func (p *GreenhouseProvider) matchPosting(u *url.URL) (postingRef, bool) {
+	switch strings.ToLower(u.Hostname()) {
+	case "boards.greenhouse.io", "job-boards.greenhouse.io", "boards.eu.greenhouse.io", "job-boards.eu.greenhouse.io":
+	default:
+		return postingRef{}, false
+	}
+
+	segments := pathSegments(u)
+	if len(segments) == 2 && segments[0] == "embed" && segments[1] == "job_app" {
+		board, id := u.Query().Get("for"), u.Query().Get("token")
+		return postingRef{board: board, id: id}, board != "" && id != ""
+	}
+	if len(segments) >= 3 && segments[1] == "jobs" {
+		return postingRef{board: segments[0], id: segments[2]}, true
+	}
+	return postingRef{}, false
+}
+
+// fetchPosting reads a job from the Greenhouse board API, including its pay transparency ranges
+func (p *GreenhouseProvider) fetchPosting(ctx context.Context, ref postingRef) (*Posting, error) {
+	api := greenhouseBoardsAPI
+	if strings.Contains(ref.host, ".eu.") {
+		api = greenhouseBoardsAPIEU
+	}
+
+	var job greenhouseJob
+	endpoint := fmt.Sprintf("%s/%s/jobs/%s?pay_transparency=true", api, url.PathEscape(ref.board), url.PathEscape(ref.id))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &job); err != nil {
+		return nil, fmt.Errorf("greenhouse job %s/%s: %w", ref.board, ref.id, err)
+	}
+
+	companyName := job.CompanyName
+	if companyName == "" {
+		companyName = companyFromToken(ref.board)
+	}
+	posting := postingFromHTML(&models.Job{
+		Title:       job.Title,
+		JobURL:      job.AbsoluteURL,
+		CompanyName: companyName,
+		Location:    job.Location.Name,
+	}, html.UnescapeString(job.Content))
+
+	// Pay ranges are in cents and name no period, which the salary normalizer infers
+	if len(job.PayInputRanges) > 0 {
+		pay := job.PayInputRanges[0]
+		posting.Job.Currency = pay.CurrencyType
+		posting.Job.Salary = models.Salary{
+			Currency: pay.CurrencyType,
+			Min:      float64(pay.MinCents) / 100,
+			Max:      float64(pay.MaxCents) / 100,
+		}
+	}
+	return posting, nil
+}
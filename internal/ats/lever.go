@@ -0,0 +1,159 @@
+package ats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/salary"
+	"letraz-utils/pkg/models"
+)
+
+// leverPostingsAPI is the public Lever postings API
+const leverPostingsAPI = "https://api.lever.co/v0/postings"
+
+// leverPostingsAPIEU is the Lever postings API for accounts hosted in the EU
+const leverPostingsAPIEU = "https://api.eu.lever.co/v0/postings"
+
+// LeverProvider reads jobs from api.lever.co
+type LeverProvider struct {
+	client    *http.Client
+	userAgent string
+}
+
+// leverPosting is a posting in the Lever postings API
+type leverPosting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	AdditionalPlain  string `json:"additionalPlain"`
+	Categories       struct {
+		Location   string `json:"location"`
+		Team       string `json:"team"`
+		Commitment string `json:"commitment"`
+	} `json:"categories"`
+	Lists []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"` // HTML list items
+	} `json:"lists"`
+	SalaryRange *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"` // e.g. per-year-salary, per-hour-wage
+	} `json:"salaryRange"`
+}
+
+// Name returns the ATS name
+func (p *LeverProvider) Name() string {
+	return ProviderLever
+}
+
+// ListJobs returns every published posting of a Lever company
+func (p *LeverProvider) ListJobs(ctx context.Context, token string) ([]models.Job, error) {
+	var postings []leverPosting
+	endpoint := fmt.Sprintf("%s/%s?mode=json", leverPostingsAPI, url.PathEscape(token))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &postings); err != nil {
+		return nil, fmt.Errorf("lever company %s: %w", token, err)
+	}
+
+	jobs := make([]models.Job, 0, len(postings))
+	for _, posting := range postings {
+		job := models.Job{
+			Title:       posting.Text,
+			JobURL:      posting.HostedURL,
+			Location:    posting.Categories.Location,
+			Description: posting.DescriptionPlain,
+		}
+		for _, list := range posting.Lists {
+			job.Requirements = append(job.Requirements, htmlToText(list.Content))
+		}
+		if posting.SalaryRange != nil {
+			job.Currency = posting.SalaryRange.Currency
+			job.Salary = models.Salary{
+				Currency: posting.SalaryRange.Currency,
+				Min:      posting.SalaryRange.Min,
+				Max:      posting.SalaryRange.Max,
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// matchPosting matches jobs.lever.co/{company}/{id} and its EU host, including the /apply page
+func (p *LeverProvider) matchPosting(u *url.URL) (postingRef, bool) {
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.lever.co", "jobs.eu.lever.co":
+	default:
+		return postingRef{}, false
+	}
+
+	segments := pathSegments(u)
+	if len(segments) < 2 || !uuidPattern.MatchString(segments[1]) {
+		return postingRef{}, false
+	}
+	return postingRef{board: segments[0], id: segments[1]}, true
+}
+
+// fetchPosting reads a posting from the Lever postings API. Lever keeps the posting's lists
+// apart from its description, each under the heading it was written with.
+func (p *LeverProvider) fetchPosting(ctx context.Context, ref postingRef) (*Posting, error) {
+	api := leverPostingsAPI
+	if strings.Contains(ref.host, ".eu.") {
+		api = leverPostingsAPIEU
+	}
+
+	var posting leverPosting
+	endpoint := fmt.Sprintf("%s/%s/%s", api, url.PathEscape(ref.board), url.PathEscape(ref.id))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &posting); err != nil {
+		return nil, fmt.Errorf("lever posting %s/%s: %w", ref.board, ref.id, err)
+	}
+
+	job := &models.Job{
+		Title:            posting.Text,
+		JobURL:           posting.HostedURL,
+		CompanyName:      companyFromToken(ref.board),
+		Location:         posting.Categories.Location,
+		Description:      summarize(posting.DescriptionPlain),
+		Requirements:     []string{},
+		Responsibilities: []string{},
+		Benefits:         []string{},
+	}
+
+	text := []string{posting.DescriptionPlain}
+	for _, list := range posting.Lists {
+		items := processors.ListItems(list.Content)
+		switch processors.ClassifySection(list.Text) {
+		case processors.SectionRequirements:
+			job.Requirements = append(job.Requirements, items...)
+		case processors.SectionResponsibilities:
+			job.Responsibilities = append(job.Responsibilities, items...)
+		case processors.SectionBenefits:
+			job.Benefits = append(job.Benefits, items...)
+		}
+		text = append(text, list.Text, strings.Join(items, "\n"))
+	}
+	text = append(text, posting.AdditionalPlain)
+
+	if posting.SalaryRange != nil {
+		// The interval names the period as its second word, as in per-year-salary
+		period := ""
+		if words := strings.Split(posting.SalaryRange.Interval, "-"); len(words) > 1 {
+			period = salary.NormalizePeriod(words[1])
+		}
+		job.Currency = posting.SalaryRange.Currency
+		job.Salary = models.Salary{
+			Currency: posting.SalaryRange.Currency,
+			Min:      posting.SalaryRange.Min,
+			Max:      posting.SalaryRange.Max,
+			Period:   period,
+		}
+	}
+
+	return &Posting{Job: job, Text: strings.TrimSpace(strings.Join(text, "\n\n"))}, nil
+}
@@ -0,0 +1,151 @@
+package ats
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/pkg/models"
+)
+
+// maxSummaryLength bounds the description taken from the start of a posting
+const maxSummaryLength = 600
+
+// ErrNoAdapter is returned for URLs that are not postings on a supported ATS
+var ErrNoAdapter = errors.New("URL is not a posting on a supported ATS")
+
+// uuidPattern matches the posting IDs of Lever and Ashby
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Posting is a single job posting read from the public API of the ATS hosting it
+type Posting struct {
+	Provider string
+	Job      *models.Job
+	Text     string // full plain text of the posting, for extracting what the API does not structure
+}
+
+// postingRef identifies a posting on an ATS
+type postingRef struct {
+	host  string
+	board string
+	id    string
+}
+
+// postingAdapter reads single postings from an ATS, given their public URL
+type postingAdapter interface {
+	Name() string
+
+	// matchPosting returns the posting u points to when it is a posting on this ATS
+	matchPosting(u *url.URL) (postingRef, bool)
+
+	// fetchPosting reads the posting from the ATS API
+	fetchPosting(ctx context.Context, ref postingRef) (*Posting, error)
+}
+
+// Registry detects job posting URLs of known applicant tracking systems and reads the postings
+// from their public JSON APIs instead of scraping the page
+type Registry struct {
+	adapters []postingAdapter
+}
+
+// NewRegistry creates a registry with an adapter for every ATS with a public posting API
+func NewRegistry(cfg *config.Config, client *http.Client) *Registry {
+	userAgent := cfg.Scraper.UserAgent
+	return &Registry{
+		adapters: []postingAdapter{
+			&GreenhouseProvider{client: client, userAgent: userAgent},
+			&LeverProvider{client: client, userAgent: userAgent},
+			&AshbyProvider{client: client, userAgent: userAgent},
+			&WorkdayProvider{client: client, userAgent: userAgent},
+		},
+	}
+}
+
+// Detect returns the name of the ATS hosting the posting at rawURL
+func (r *Registry) Detect(rawURL string) (string, bool) {
+	adapter, _, ok := r.match(rawURL)
+	if !ok {
+		return "", false
+	}
+	return adapter.Name(), true
+}
+
+// FetchPosting reads the posting at rawURL from its ATS API, returning ErrNoAdapter when no
+// supported ATS hosts it
+func (r *Registry) FetchPosting(ctx context.Context, rawURL string) (*Posting, error) {
+	adapter, ref, ok := r.match(rawURL)
+	if !ok {
+		return nil, ErrNoAdapter
+	}
+
+	posting, err := adapter.fetchPosting(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	posting.Provider = adapter.Name()
+	if posting.Job.JobURL == "" {
+		posting.Job.JobURL = rawURL
+	}
+	return posting, nil
+}
+
+// match returns the adapter and posting for rawURL
+func (r *Registry) match(rawURL string) (postingAdapter, postingRef, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, postingRef{}, false
+	}
+	for _, adapter := range r.adapters {
+		if ref, ok := adapter.matchPosting(u); ok {
+			ref.host = strings.ToLower(u.Hostname())
+			return adapter, ref, true
+		}
+	}
+	return nil, postingRef{}, false
+}
+
+// pathSegments returns the non-empty segments of a URL path
+func pathSegments(u *url.URL) []string {
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// companyFromToken derives a display name from a board token such as "acme-labs" for APIs
+// that do not return the company's name
+func companyFromToken(token string) string {
+	words := strings.FieldsFunc(token, func(r rune) bool { return r == '-' || r == '_' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// summarize returns the opening sentences of a posting's text for its description
+func summarize(text string) string {
+	return processors.Summarize(strings.ReplaceAll(text, "\n", " "), maxSummaryLength)
+}
+
+// postingFromHTML builds a posting from the HTML description of a job, splitting its lists into
+// requirements, responsibilities and benefits by the headings above them
+func postingFromHTML(job *models.Job, description string) *Posting {
+	requirements, responsibilities, benefits := processors.SectionLists(description)
+	text := processors.BlockText(description)
+
+	job.Requirements = requirements
+	job.Responsibilities = responsibilities
+	job.Benefits = benefits
+	if job.Description == "" {
+		job.Description = summarize(text)
+	}
+	return &Posting{Job: job, Text: text}
+}
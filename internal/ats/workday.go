@@ -0,0 +1,82 @@
+package ats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"letraz-utils/pkg/models"
+)
+
+// WorkdayProvider reads single postings from the JSON API behind Workday career sites. Workday
+// boards have no public listing API, so it only backs the posting registry.
+type WorkdayProvider struct {
+	client    *http.Client
+	userAgent string
+}
+
+// workdayPosting is a posting in the Workday career site API
+type workdayPosting struct {
+	JobPostingInfo struct {
+		Title          string `json:"title"`
+		JobDescription string `json:"jobDescription"` // HTML
+		Location       string `json:"location"`
+		ExternalURL    string `json:"externalUrl"`
+	} `json:"jobPostingInfo"`
+	HiringOrganization struct {
+		Name string `json:"name"`
+	} `json:"hiringOrganization"`
+}
+
+// Name returns the ATS name
+func (p *WorkdayProvider) Name() string {
+	return ProviderWorkday
+}
+
+// matchPosting matches {tenant}.wdN.myworkdayjobs.com/[{locale}/]{site}/job/{location}/{slug}
+func (p *WorkdayProvider) matchPosting(u *url.URL) (postingRef, bool) {
+	if !strings.HasSuffix(strings.ToLower(u.Hostname()), ".myworkdayjobs.com") {
+		return postingRef{}, false
+	}
+
+	segments := pathSegments(u)
+	for i, segment := range segments {
+		if segment == "job" && i > 0 && i < len(segments)-1 {
+			return postingRef{board: segments[i-1], id: strings.Join(segments[i+1:], "/")}, true
+		}
+	}
+	return postingRef{}, false
+}
+
+// fetchPosting reads a posting from the career site API, which lives on the same host under
+// /wday/cxs/{tenant}/{site}
+func (p *WorkdayProvider) fetchPosting(ctx context.Context, ref postingRef) (*Posting, error) {
+	tenant, _, _ := strings.Cut(ref.host, ".")
+	segments := strings.Split(ref.id, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	var posting workdayPosting
+	endpoint := fmt.Sprintf("https://%s/wday/cxs/%s/%s/job/%s", ref.host, url.PathEscape(tenant), url.PathEscape(ref.board), strings.Join(segments, "/"))
+	if err := getJSON(ctx, p.client, p.userAgent, endpoint, &posting); err != nil {
+		return nil, fmt.Errorf("workday posting %s/%s: %w", tenant, ref.id, err)
+	}
+
+	info := posting.JobPostingInfo
+	if info.Title == "" {
+		return nil, fmt.Errorf("workday posting %s/%s: response has no posting", tenant, ref.id)
+	}
+	companyName := posting.HiringOrganization.Name
+	if companyName == "" {
+		companyName = companyFromToken(tenant)
+	}
+	return postingFromHTML(&models.Job{
+		Title:       info.Title,
+		JobURL:      info.ExternalURL,
+		CompanyName: companyName,
+		Location:    info.Location,
+	}, info.JobDescription), nil
+}
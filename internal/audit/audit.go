@@ -0,0 +1,244 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/adapters"
+	"letraz-utils/internal/logging/types"
+)
+
+// AnonymousCaller is recorded when a request authenticated with no API key
+const AnonymousCaller = "anonymous"
+
+// ClientIDHeader is the header (or gRPC metadata key, lowercased) a client may name itself in.
+// Nothing verifies it, so it is recorded apart from the caller.
+const ClientIDHeader = "X-Client-ID"
+
+// MaxHashedBodyBytes caps how much of a request body is read to fingerprint it
+const MaxHashedBodyBytes = 1 << 20
+
+// Record represents a single audited API call
+type Record struct {
+	Timestamp       time.Time
+	RequestID       string
+	Caller          string // name of the API key the request authenticated with
+	ClientID        string // the unverified ClientIDHeader the client sent
+	RemoteAddr      string
+	Protocol        string // http or grpc
+	Method          string
+	Endpoint        string
+	ParamsHash      string
+	ParamsTruncated bool // the body was too large to hash whole; ParamsHash covers its first MaxHashedBodyBytes
+	Status          string
+	StatusCode      int
+	Latency         time.Duration
+}
+
+// Sink defines the destination audit records are written to
+type Sink interface {
+	// Write persists a single audit record
+	Write(record *Record) error
+
+	// Close flushes and releases the sink
+	Close() error
+}
+
+// Auditor records API calls to a dedicated audit sink
+type Auditor struct {
+	sink    Sink
+	enabled bool
+	logger  types.Logger
+	mu      sync.RWMutex
+}
+
+// NewAuditor creates an auditor backed by a rotating audit file
+func NewAuditor(cfg *config.Config) (*Auditor, error) {
+	logger := logging.GetGlobalLogger()
+
+	if !cfg.Audit.Enabled {
+		return &Auditor{logger: logger}, nil
+	}
+
+	sink, err := NewFileSink(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit sink: %w", err)
+	}
+
+	logger.Info("API audit logging enabled", map[string]interface{}{
+		"file_path":         cfg.Audit.FilePath,
+		"rotation_interval": cfg.Audit.RotationInterval.String(),
+		"retention_count":   cfg.Audit.RetentionCount,
+	})
+
+	return NewAuditorWithSink(sink), nil
+}
+
+// NewAuditorWithSink creates an enabled auditor that writes to the given sink
+func NewAuditorWithSink(sink Sink) *Auditor {
+	return &Auditor{
+		sink:    sink,
+		enabled: sink != nil,
+		logger:  logging.GetGlobalLogger(),
+	}
+}
+
+// IsEnabled reports whether audit records are being written
+func (a *Auditor) IsEnabled() bool {
+	if a == nil {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.enabled
+}
+
+// Record writes an audit record; failures are logged but never block the API call
+func (a *Auditor) Record(record *Record) {
+	if !a.IsEnabled() || record == nil {
+		return
+	}
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	if record.Caller == "" {
+		record.Caller = AnonymousCaller
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if err := a.sink.Write(record); err != nil {
+		a.logger.Error("Failed to write audit record", map[string]interface{}{
+			"request_id": record.RequestID,
+			"endpoint":   record.Endpoint,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// Close closes the underlying sink
+func (a *Auditor) Close() error {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.enabled || a.sink == nil {
+		return nil
+	}
+	a.enabled = false
+	return a.sink.Close()
+}
+
+// HashParams returns a stable SHA-256 fingerprint of the request parameters so audits
+// can correlate identical calls without storing potentially sensitive payloads
+func HashParams(parts ...[]byte) string {
+	if len(parts) == 0 {
+		return ""
+	}
+
+	hasher := sha256.New()
+	for _, part := range parts {
+		hasher.Write(part)
+		hasher.Write([]byte{0})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// FileSink writes audit records as JSON lines to a rotating file
+type FileSink struct {
+	adapter *adapters.FileAdapter
+}
+
+// NewFileSink creates a file sink honouring the audit rotation and retention settings
+func NewFileSink(cfg *config.Config) (*FileSink, error) {
+	adapter, err := adapters.NewFileAdapter("audit", adapters.FileConfig{
+		FilePath:       cfg.Audit.FilePath,
+		Format:         "json",
+		MaxAge:         cfg.Audit.RotationInterval,
+		MaxBackups:     cfg.Audit.RetentionCount,
+		Compress:       cfg.Audit.Compress,
+		CreateDirs:     true,
+		FileMode:       0600,
+		SyncOnWrite:    true,
+		RotationPolicy: "time",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{adapter: adapter}, nil
+}
+
+// Write writes the record as a single audit log entry
+func (s *FileSink) Write(record *Record) error {
+	fields := map[string]interface{}{
+		"request_id":  record.RequestID,
+		"caller":      record.Caller,
+		"remote_addr": record.RemoteAddr,
+		"protocol":    record.Protocol,
+		"method":      record.Method,
+		"endpoint":    record.Endpoint,
+		"params_hash": record.ParamsHash,
+		"status":      record.Status,
+		"status_code": record.StatusCode,
+		"latency_ms":  record.Latency.Milliseconds(),
+	}
+	if record.ClientID != "" {
+		fields["unverified_client_id"] = record.ClientID
+	}
+	if record.ParamsTruncated {
+		fields["params_truncated"] = true
+	}
+	return s.adapter.Write(&types.LogEntry{
+		Level:     types.InfoLevel,
+		Message:   "api_call",
+		Timestamp: record.Timestamp,
+		Fields:    fields,
+	})
+}
+
+// Close closes the audit file
+func (s *FileSink) Close() error {
+	return s.adapter.Close()
+}
+
+// Global auditor instance
+var (
+	globalAuditor *Auditor
+	globalMu      sync.RWMutex
+)
+
+// InitializeAuditing initializes the global auditor from configuration
+func InitializeAuditing(cfg *config.Config) error {
+	auditor, err := NewAuditor(cfg)
+	if err != nil {
+		return err
+	}
+
+	globalMu.Lock()
+	globalAuditor = auditor
+	globalMu.Unlock()
+	return nil
+}
+
+// GetGlobalAuditor returns the global auditor, which is a no-op until initialized
+func GetGlobalAuditor() *Auditor {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalAuditor
+}
+
+// CloseAuditing closes the global auditor
+func CloseAuditing() error {
+	return GetGlobalAuditor().Close()
+}
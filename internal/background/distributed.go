@@ -0,0 +1,339 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/quota"
+	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// streamRetryDelay is how long a task worker waits after the shared queue failed to deliver
+const streamRetryDelay = time.Second
+
+// SetDistributedQueue makes the task workers share one queue with every replica using the same
+// Redis streams: tasks submitted here may run on another replica and tasks submitted elsewhere
+// run here, with resources. Results are then only visible to every replica with a shared task
+// store. It must be called before Start.
+func (tm *TaskManagerImpl) SetDistributedQueue(client redis.UniversalClient, resources TaskResources) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.stream = newStreamQueue(client, tm.config, tm.maxQueueSize)
+	tm.resources = resources
+}
+
+// startStreamWorkers starts the task workers reading the shared queue and the routine taking
+// over the tasks of replicas that died
+func (tm *TaskManagerImpl) startStreamWorkers() {
+	ctx, cancel := context.WithTimeout(tm.ctx, 10*time.Second)
+	if err := tm.stream.ensureGroups(ctx); err != nil {
+		// Workers create the group once Redis is reachable
+		tm.appLogger.Warn("Failed to prepare the shared task queue", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	cancel()
+
+	for i := 0; i < tm.maxWorkers; i++ {
+		tm.wg.Add(1)
+		go tm.streamWorker(i)
+	}
+	tm.wg.Add(1)
+	go tm.reclaimRoutine()
+
+	tm.appLogger.Info("Task workers share the Redis task queue", map[string]interface{}{
+		"consumer":           tm.stream.consumer,
+		"group":              tm.stream.group,
+		"visibility_timeout": tm.stream.visibility.String(),
+	})
+}
+
+// enqueueShared puts a task on the shared queue. The task runs from its request on whichever
+// replica takes it, so the local execution is released.
+func (tm *TaskManagerImpl) enqueueShared(ctx context.Context, task *TaskExecution) error {
+	if task.Cancel != nil {
+		defer task.Cancel()
+	}
+	if task.Request == nil {
+		return fmt.Errorf("task %s cannot run on another replica", task.ProcessID)
+	}
+
+	request, err := json.Marshal(task.Request)
+	if err != nil {
+		return fmt.Errorf("failed to encode queued request: %w", err)
+	}
+	queued, err := tm.stream.Push(ctx, task.Priority, &queuedTask{
+		ProcessID:  task.ProcessID,
+		Type:       task.Type,
+		Request:    request,
+		APIKey:     quota.APIKeyFromContext(ctx),
+		EnqueuedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if !queued {
+		return utils.NewQueueFullError("task queue is full", tm.retryAfter())
+	}
+	return nil
+}
+
+// streamWorker runs the tasks the shared queue delivers to this replica, acknowledging each
+// once it finished; a task whose replica dies before then is delivered again elsewhere. Tasks
+// waiting on scraper pool jobs or Firecrawl run outside the workers, as they do on the replica
+// that accepted them. Once the replica drains, workers stop taking tasks and leave those
+// delivered but not started to be taken over by other replicas.
+func (tm *TaskManagerImpl) streamWorker(workerID int) {
+	defer tm.wg.Done()
+
+	for !tm.isDraining() {
+		entry, ok, err := tm.stream.Pop(tm.ctx)
+		if !ok {
+			return
+		}
+		if err != nil {
+			tm.appLogger.Warn("Failed to read the shared task queue", map[string]interface{}{
+				"worker_id": workerID,
+				"error":     err.Error(),
+			})
+			select {
+			case <-time.After(streamRetryDelay):
+			case <-tm.ctx.Done():
+				return
+			}
+			continue
+		}
+		if tm.isDraining() {
+			return
+		}
+
+		// Queuing a scrape may wait out its domain's rate limit, so the entry is kept from then on
+		stop := tm.stream.KeepAlive(tm.ctx, entry)
+		execution, handle, err := tm.queuedExecution(entry)
+		if err != nil {
+			stop()
+			tm.failQueuedTask(entry, err)
+			continue
+		}
+
+		if handle != nil || execution.Type == TaskTypeBatch || execution.Type == TaskTypeCrawl {
+			tm.wg.Add(2)
+			go func() {
+				defer tm.wg.Done()
+				if handle != nil {
+					tm.awaitPooledTask(execution, handle)
+				} else {
+					tm.awaitTask(execution)
+				}
+				// A Firecrawl crawl completes once its webhook events did, which cancels its context
+				<-execution.Context.Done()
+				stop()
+				tm.stream.Ack(context.WithoutCancel(tm.ctx), entry)
+			}()
+			continue
+		}
+
+		tm.processTask(workerID, execution)
+		stop()
+		tm.stream.Ack(context.WithoutCancel(tm.ctx), entry)
+	}
+}
+
+// reclaimRoutine takes over the tasks other replicas left unacknowledged for the visibility
+// timeout, and fails those already delivered MaxDeliveries times
+func (tm *TaskManagerImpl) reclaimRoutine() {
+	defer tm.wg.Done()
+
+	ticker := time.NewTicker(tm.stream.visibility / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-tm.ctx.Done():
+			return
+		case <-ticker.C:
+			if tm.isDraining() {
+				return
+			}
+			abandoned, err := tm.stream.Reclaim(tm.ctx)
+			if err != nil && tm.ctx.Err() == nil {
+				tm.appLogger.Warn("Failed to reclaim stale tasks from the shared queue", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			for _, entry := range abandoned {
+				tm.failQueuedTask(entry, utils.NewInternalServerError(fmt.Sprintf("task was abandoned by %d workers", entry.deliveries-1)))
+			}
+		}
+	}
+}
+
+// failQueuedTask records a task taken from the shared queue that cannot run as failed and
+// acknowledges its entry. The children an abandoned crawl left unfinished are failed with it.
+func (tm *TaskManagerImpl) failQueuedTask(entry *streamEntry, err error) {
+	ctx := context.WithoutCancel(tm.ctx)
+	if entry.task.Type == TaskTypeCrawl {
+		if crawlResult, getErr := tm.store.Get(ctx, entry.task.ProcessID); getErr == nil {
+			tm.abandonCrawlChildren(ctx, crawlResult)
+		}
+	}
+	taskCtx, cancel := tm.newTaskContext(quota.WithAPIKey(ctx, entry.task.APIKey), entry.task.ProcessID, entry.task.Type)
+	tm.completeTask(&TaskExecution{
+		ProcessID: entry.task.ProcessID,
+		Type:      entry.task.Type,
+		Priority:  entry.priority,
+		Context:   taskCtx,
+		Cancel:    cancel,
+	}, time.Now(), nil, err)
+	tm.stream.Ack(ctx, entry)
+}
+
+// queuedExecution rebuilds the execution of a task taken from the shared queue. The scrapes of
+// URL scrapes and batches are queued on this replica's scraper pool; the job of a URL scrape is
+// returned for the task to wait on.
+func (tm *TaskManagerImpl) queuedExecution(entry *streamEntry) (*TaskExecution, *workers.JobHandle, error) {
+	task := entry.task
+	taskCtx, cancel := tm.newTaskContext(quota.WithAPIKey(tm.ctx, task.APIKey), task.ProcessID, task.Type)
+	execution := &TaskExecution{
+		ProcessID:     task.ProcessID,
+		Type:          task.Type,
+		Priority:      entry.priority,
+		Context:       taskCtx,
+		Cancel:        cancel,
+		CompletedChan: make(chan *TaskResult, 1),
+		Retry:         tm.retryPolicy(task.Type),
+	}
+
+	var handle *workers.JobHandle
+	var err, enqueueErr error
+	switch task.Type {
+	case TaskTypeScrape:
+		var request models.ScrapeRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			if request.URL != "" {
+				execution.Retry = config.TaskRetryPolicy{}
+				handle, enqueueErr = tm.enqueueQueuedScrape(taskCtx, request, entry.priority)
+			}
+			execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+				return tm.executeScrapeTask(execCtx, task.ProcessID, request, handle)
+			}
+		}
+	case TaskTypeBatch:
+		var request models.BatchScrapeRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			enqueueErr = tm.queuedBatchExecution(execution, request)
+		}
+	case TaskTypeCrawl:
+		var request models.CrawlRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			execution.Retry = config.TaskRetryPolicy{}
+			execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+				return tm.executeCrawlTask(execCtx, task.ProcessID, request, tm.resources.Crawler, tm.resources.PoolManager)
+			}
+			if tm.useFirecrawlCrawl(request.Options) {
+				execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+					return tm.startFirecrawlCrawl(execCtx, execution, request, tm.resources.Crawler)
+				}
+			}
+		}
+	case TaskTypeTailor:
+		var request models.TailorResumeRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+				return tm.executeTailorTask(execCtx, task.ProcessID, request, tm.resources.LLMManager, tm.config)
+			}
+		}
+	case TaskTypeInterview:
+		var request models.InterviewQuestionsRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+				return tm.executeInterviewTask(execCtx, task.ProcessID, request, tm.resources.LLMManager)
+			}
+		}
+	case TaskTypeScreenshot:
+		var request models.ResumeScreenshotRequest
+		if err = json.Unmarshal(task.Request, &request); err == nil {
+			execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+				return tm.executeScreenshotTask(execCtx, task.ProcessID, request, tm.config)
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown queued task type %q", task.Type)
+	}
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to decode queued task: %w", err)
+	}
+	if enqueueErr != nil {
+		cancel()
+		return nil, nil, enqueueErr
+	}
+	return execution, handle, nil
+}
+
+// enqueueQueuedScrape queues a URL scrape taken from the shared queue on this replica's scraper
+// pool. The task was accepted already, so its domain's rate limit is waited out, up to the task
+// timeout, instead of failing it.
+func (tm *TaskManagerImpl) enqueueQueuedScrape(ctx context.Context, request models.ScrapeRequest, priority TaskPriority) (*workers.JobHandle, error) {
+	return tm.resources.PoolManager.EnqueuePaced(ctx, request.URL, request.Options, priority.jobPriority(), tm.taskTimeout(TaskTypeScrape))
+}
+
+// queuedBatchExecution sets up a batch taken from the shared queue: its URLs are queued on this
+// replica's scraper pool, unless Firecrawl scrapes the batch
+func (tm *TaskManagerImpl) queuedBatchExecution(execution *TaskExecution, request models.BatchScrapeRequest) error {
+	processID, priority, poolManager := execution.ProcessID, execution.Priority, tm.resources.PoolManager
+	urls, targets := dedupeURLs(request.URLs)
+	if tm.useFirecrawlBatch(request.Options, len(urls)) {
+		execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+			return tm.executeFirecrawlBatchTask(execCtx, processID, request, urls, targets)
+		}
+		return nil
+	}
+
+	handles, enqueueErrs, err := enqueueBatchURLs(execution.Context, urls, request.Options, priority, poolManager)
+	if err != nil {
+		return err
+	}
+	execution.Retry = config.TaskRetryPolicy{}
+	execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+		return tm.executeBatchScrapeTask(execCtx, processID, request, priority, urls, targets, handles, enqueueErrs, poolManager)
+	}
+	return nil
+}
+
+// errCrawlChildAbandoned fails the child scrape tasks of a crawl whose replica stopped before
+// they finished
+var errCrawlChildAbandoned = utils.NewInternalServerError("scrape was abandoned with the crawl that submitted it")
+
+// abandonCrawlChildren fails the child scrape tasks an earlier run of a crawl left unfinished.
+// Children are queued on the scraper pool of the replica running the crawl, so once the crawl
+// is taken over by another replica or given up, nothing completes them.
+func (tm *TaskManagerImpl) abandonCrawlChildren(ctx context.Context, crawlResult *TaskResult) {
+	data, ok := crawlResult.Data.(*CrawlTaskData)
+	if !ok {
+		return
+	}
+	for _, item := range data.Results {
+		if item.ProcessID == "" {
+			continue
+		}
+		child, err := tm.store.Get(ctx, item.ProcessID)
+		if err != nil || child.Status == TaskStatusSuccess || child.Status == TaskStatusFailure {
+			continue
+		}
+		childCtx, cancel := tm.newTaskContext(ctx, item.ProcessID, TaskTypeScrape)
+		tm.completeTask(&TaskExecution{
+			ProcessID: item.ProcessID,
+			Type:      TaskTypeScrape,
+			Context:   childCtx,
+			Cancel:    cancel,
+		}, time.Now(), nil, errCrawlChildAbandoned)
+	}
+}
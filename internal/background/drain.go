@@ -0,0 +1,243 @@
+package background
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"letraz-utils/pkg/utils"
+)
+
+const (
+	// HealthCheckName names the readiness check that fails while the task manager drains, so
+	// load balancers stop routing submissions to it
+	HealthCheckName = "task_manager"
+
+	// drainPollInterval is how often a drain checks whether every task finished
+	drainPollInterval = 250 * time.Millisecond
+
+	// drainAbandonGrace bounds the wait for tasks cancelled at the drain deadline to record
+	// their failure and send its callback
+	drainAbandonGrace = 10 * time.Second
+
+	// drainRetryAfter is the retry delay suggested to submissions refused while draining;
+	// another replica usually takes them
+	drainRetryAfter = 5 * time.Second
+)
+
+// errTaskAbandoned fails the tasks still queued or running when a drain's deadline passes
+var errTaskAbandoned = utils.NewDrainingError("task did not finish before the instance drained", 0)
+
+// DrainStatus reports the progress of a drain
+type DrainStatus struct {
+	Draining  bool       `json:"draining"`
+	Done      bool       `json:"done"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	Deadline  *time.Time `json:"deadline,omitempty"`
+
+	// QueueLength counts the tasks waiting for a task worker; with the shared queue, those of
+	// every replica
+	QueueLength int `json:"queue_length"`
+	InFlight    int `json:"in_flight"` // tasks running on this instance
+
+	// Abandoned lists the process IDs failed at the deadline
+	Abandoned []string `json:"abandoned,omitempty"`
+}
+
+// Err returns the error the task manager's readiness check reports: non-nil while draining
+func (s DrainStatus) Err() error {
+	if s.Draining {
+		return fmt.Errorf("task manager is draining")
+	}
+	return nil
+}
+
+// drainState tracks a drain started by Drain
+type drainState struct {
+	startedAt time.Time
+	deadline  time.Time
+	done      chan struct{} // closed once every task finished or was abandoned
+	abandoned []string
+}
+
+// Drain stops accepting tasks and waits up to timeout for the queued and running ones to
+// finish; those left are then failed as abandoned, sending their failure callbacks, and the task
+// manager stops. It returns at once; calling it again reports the drain already in progress.
+func (tm *TaskManagerImpl) Drain(timeout time.Duration) DrainStatus {
+	tm.activeMu.Lock()
+	started := tm.drain == nil
+	if started {
+		now := time.Now()
+		tm.drain = &drainState{
+			startedAt: now,
+			deadline:  now.Add(timeout),
+			done:      make(chan struct{}),
+		}
+		go tm.runDrain(tm.drain)
+	}
+	tm.activeMu.Unlock()
+
+	status := tm.DrainStatus()
+	if started {
+		tm.appLogger.Info("Draining background tasks", map[string]interface{}{
+			"queue_length": status.QueueLength,
+			"in_flight":    status.InFlight,
+			"deadline":     status.Deadline,
+		})
+	}
+	return status
+}
+
+// DrainStatus reports the progress of the current drain
+func (tm *TaskManagerImpl) DrainStatus() DrainStatus {
+	tm.activeMu.Lock()
+	active := len(tm.active)
+	drain := tm.drain
+	var status DrainStatus
+	if drain != nil {
+		status.Draining = true
+		status.StartedAt = &drain.startedAt
+		status.Deadline = &drain.deadline
+		status.Abandoned = append([]string(nil), drain.abandoned...)
+	}
+	tm.activeMu.Unlock()
+
+	if drain != nil {
+		select {
+		case <-drain.done:
+			status.Done = true
+		default:
+		}
+	}
+
+	// Tasks in the in-memory queue were accepted by this instance, so they are tracked too
+	status.QueueLength = tm.queueLen()
+	status.InFlight = active
+	if tm.stream == nil {
+		status.InFlight = max(active-tm.queue.Len(), 0)
+	}
+	return status
+}
+
+// Drained returns a channel closed once the drain finished; nil before Drain was called
+func (tm *TaskManagerImpl) Drained() <-chan struct{} {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	if tm.drain == nil {
+		return nil
+	}
+	return tm.drain.done
+}
+
+// runDrain waits for every accepted task to finish, abandoning those left at the deadline
+func (tm *TaskManagerImpl) runDrain(drain *drainState) {
+	defer close(drain.done)
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(time.Until(drain.deadline))
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if tm.activeCount() == 0 {
+				tm.appLogger.Info("Background tasks drained", map[string]interface{}{
+					"duration": time.Since(drain.startedAt).String(),
+				})
+				return
+			}
+		case <-deadline.C:
+			tm.abandonTasks(drain)
+			return
+		}
+	}
+}
+
+// abandonTasks fails every task still queued or running: cancelling the manager context stops
+// the running ones, which completeTask then records as abandoned
+func (tm *TaskManagerImpl) abandonTasks(drain *drainState) {
+	tm.activeMu.Lock()
+	abandoned := make([]string, 0, len(tm.active))
+	for processID := range tm.active {
+		abandoned = append(abandoned, processID)
+	}
+	tm.activeMu.Unlock()
+	sort.Strings(abandoned)
+
+	tm.appLogger.Warn("Drain deadline passed, abandoning unfinished tasks", map[string]interface{}{
+		"abandoned": len(abandoned),
+	})
+
+	tm.mu.RLock()
+	abort := tm.abort
+	tm.mu.RUnlock()
+	if abort != nil {
+		abort(errTaskAbandoned)
+	}
+	tm.queue.Close()
+	for _, task := range tm.queue.TakeAll() {
+		tm.completeTask(task, time.Now(), nil, errTaskAbandoned)
+	}
+
+	grace := time.After(drainAbandonGrace)
+	for tm.activeCount() > 0 {
+		select {
+		case <-grace:
+			tm.appLogger.Warn("Abandoned tasks did not record their failure in time", map[string]interface{}{
+				"remaining": tm.activeCount(),
+			})
+			tm.setAbandoned(drain, abandoned)
+			return
+		case <-time.After(drainPollInterval):
+		}
+	}
+	tm.setAbandoned(drain, abandoned)
+}
+
+// setAbandoned records the process IDs a drain abandoned
+func (tm *TaskManagerImpl) setAbandoned(drain *drainState, abandoned []string) {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	drain.abandoned = abandoned
+}
+
+// isDraining reports whether a drain has started
+func (tm *TaskManagerImpl) isDraining() bool {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	return tm.drain != nil
+}
+
+// accepting returns the error a submission is refused with, or nil when the task manager
+// takes new tasks
+func (tm *TaskManagerImpl) accepting() error {
+	if tm.isDraining() {
+		return utils.NewDrainingError("the instance is shutting down", drainRetryAfter)
+	}
+	if !tm.IsHealthy() {
+		return fmt.Errorf("task manager is not healthy")
+	}
+	return nil
+}
+
+// trackTask records a task accepted by this instance until completeTask finishes it
+func (tm *TaskManagerImpl) trackTask(task *TaskExecution) {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	tm.active[task.ProcessID] = task
+}
+
+// untrackTask forgets a finished task
+func (tm *TaskManagerImpl) untrackTask(processID string) {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	delete(tm.active, processID)
+}
+
+// activeCount returns the number of queued and running tasks this instance accepted
+func (tm *TaskManagerImpl) activeCount() int {
+	tm.activeMu.Lock()
+	defer tm.activeMu.Unlock()
+	return len(tm.active)
+}
@@ -0,0 +1,203 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/logging"
+)
+
+// TaskEventType identifies a task lifecycle transition
+type TaskEventType string
+
+const (
+	TaskEventAccepted   TaskEventType = "accepted"
+	TaskEventProcessing TaskEventType = "processing"
+	TaskEventProgress   TaskEventType = "progress"
+	TaskEventCompleted  TaskEventType = "completed"
+	TaskEventFailed     TaskEventType = "failed"
+)
+
+// TaskProgress counts the finished items of a batch or crawl
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// TaskEvent is published on every task lifecycle transition
+type TaskEvent struct {
+	Event     TaskEventType `json:"event"`
+	ProcessID string        `json:"processId"`
+	Type      TaskType      `json:"type"`
+	Status    TaskStatus    `json:"status"`
+	Error     string        `json:"error,omitempty"`
+	ErrorCode string        `json:"errorCode,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+	Progress  *TaskProgress `json:"progress,omitempty"` // Set on progress events
+	Result    *TaskResult   `json:"result,omitempty"`   // Set on completion and failure
+}
+
+// Terminal reports whether the event ends its task
+func (e *TaskEvent) Terminal() bool {
+	return e.Event == TaskEventCompleted || e.Event == TaskEventFailed
+}
+
+// TaskEventPublisher delivers task lifecycle events to other services
+type TaskEventPublisher interface {
+	// Publish sends an event; failures must never affect task processing
+	Publish(ctx context.Context, event *TaskEvent) error
+}
+
+// TaskEventSubscriber delivers the events of a process while they are published
+type TaskEventSubscriber interface {
+	// Subscribe delivers the events of processID until ctx is cancelled, then closes the channel
+	Subscribe(ctx context.Context, processID string) <-chan *TaskEvent
+}
+
+// RedisTaskEventPublisher publishes task events over Redis pub/sub. Every event goes to the
+// shared "<prefix>:events" channel and to the per-process "<prefix>:<processId>" channel.
+type RedisTaskEventPublisher struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisTaskEventPublisher creates a publisher using the given channel prefix
+func NewRedisTaskEventPublisher(client redis.UniversalClient, prefix string) *RedisTaskEventPublisher {
+	return &RedisTaskEventPublisher{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Publish sends the event to the shared and per-process channels
+func (p *RedisTaskEventPublisher) Publish(ctx context.Context, event *TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task event: %w", err)
+	}
+
+	pipe := p.client.Pipeline()
+	pipe.Publish(ctx, TaskEventsChannel(p.prefix), payload)
+	pipe.Publish(ctx, TaskEventChannel(p.prefix, event.ProcessID), payload)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish task event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe delivers the events of processID published by any instance sharing the Redis server
+func (p *RedisTaskEventPublisher) Subscribe(ctx context.Context, processID string) <-chan *TaskEvent {
+	return SubscribeTaskEvents(ctx, p.client, p.prefix, processID)
+}
+
+// TaskEventsChannel returns the channel carrying events for all tasks
+func TaskEventsChannel(prefix string) string {
+	return prefix + ":events"
+}
+
+// TaskEventChannel returns the channel carrying events for a single process
+func TaskEventChannel(prefix, processID string) string {
+	return prefix + ":" + processID
+}
+
+// SubscribeTaskEvents subscribes to the events of a single process, or of all tasks when
+// processID is empty. Events are delivered until ctx is cancelled.
+func SubscribeTaskEvents(ctx context.Context, client redis.UniversalClient, prefix, processID string) <-chan *TaskEvent {
+	channel := TaskEventsChannel(prefix)
+	if processID != "" {
+		channel = TaskEventChannel(prefix, processID)
+	}
+
+	pubsub := client.Subscribe(ctx, channel)
+	events := make(chan *TaskEvent, 16)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		logger := logging.FromContext(ctx)
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event TaskEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					logger.Warn("Discarding malformed task event", map[string]interface{}{
+						"channel": msg.Channel,
+						"error":   err.Error(),
+					})
+					continue
+				}
+				select {
+				case events <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// taskEventHub delivers the events published by this instance to its in-process subscribers
+type taskEventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *TaskEvent]struct{}
+}
+
+// newTaskEventHub creates a hub without subscribers
+func newTaskEventHub() *taskEventHub {
+	return &taskEventHub{subscribers: make(map[string]map[chan *TaskEvent]struct{})}
+}
+
+// Publish hands the event to the subscribers of its process, dropping it for subscribers that
+// are not keeping up
+func (h *taskEventHub) Publish(ctx context.Context, event *TaskEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[event.ProcessID] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe delivers the events of processID until ctx is cancelled
+func (h *taskEventHub) Subscribe(ctx context.Context, processID string) <-chan *TaskEvent {
+	events := make(chan *TaskEvent, 16)
+
+	h.mu.Lock()
+	if h.subscribers[processID] == nil {
+		h.subscribers[processID] = make(map[chan *TaskEvent]struct{})
+	}
+	h.subscribers[processID][events] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[processID], events)
+		if len(h.subscribers[processID]) == 0 {
+			delete(h.subscribers, processID)
+		}
+		close(events)
+	}()
+
+	return events
+}
@@ -0,0 +1,415 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/archive"
+	"letraz-utils/internal/crawl"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper/engines/firecrawl"
+	"letraz-utils/internal/scraper/policy"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// errTaskDetached is returned by tasks that handed their work to something that completes them
+// later, so processing returns without recording an outcome
+var errTaskDetached = errors.New("task completes asynchronously")
+
+// firecrawlCrawl is a crawl task whose pages Firecrawl reports to the webhook. Its state lives on
+// the replica that started the crawl; events reaching another replica are relayed to it.
+type firecrawlCrawl struct {
+	task      *TaskExecution
+	request   models.CrawlRequest
+	crawler   *crawl.Crawler
+	maxJobs   int
+	startTime time.Time
+	llmUsage  *cost.Tally
+	extracts  chan struct{} // bounds the extractions run at once
+	finished  chan struct{}
+
+	mu      sync.Mutex
+	crawlID string
+	seen    map[string]bool
+	items   []BatchScrapeItem
+	done    bool
+	pending sync.WaitGroup
+}
+
+// useFirecrawlCrawl reports whether a crawl is handed to Firecrawl's crawl API. The webhook must
+// have a signing secret, as its events complete tasks; test mode keeps crawls on the stub engines.
+func (tm *TaskManagerImpl) useFirecrawlCrawl(options *models.ScrapeOptions) bool {
+	return tm.config.Firecrawl.Webhook.URL != "" && tm.config.Firecrawl.Webhook.Secret != "" &&
+		tm.config.Firecrawl.APIKey != "" && !tm.config.TestMode.Enabled && getEngineFromOptions(options) == "firecrawl"
+}
+
+// SetFirecrawlEventRelay relays the Firecrawl webhook events a replica receives for crawls run by
+// another replica over Redis pub/sub, on "<prefix>:firecrawl:<processId>" channels, so the
+// webhook URL may reach any replica. It must be called before Start.
+func (tm *TaskManagerImpl) SetFirecrawlEventRelay(client redis.UniversalClient, prefix string) {
+	tm.crawlsMu.Lock()
+	defer tm.crawlsMu.Unlock()
+	tm.crawlRelay = client
+	tm.crawlRelayPrefix = prefix
+}
+
+// firecrawlEventChannel returns the channel relaying the webhook events of a crawl
+func firecrawlEventChannel(prefix, processID string) string {
+	return prefix + ":firecrawl:" + processID
+}
+
+// startFirecrawlCrawl starts a Firecrawl crawl of the careers page and detaches the task. The
+// crawl is registered before it starts so no event is missed, and fails when it has not
+// completed within the webhook timeout.
+func (tm *TaskManagerImpl) startFirecrawlCrawl(ctx context.Context, task *TaskExecution, request models.CrawlRequest, crawler *crawl.Crawler) (*TaskResult, error) {
+	maxJobs := tm.crawlMaxJobs(request)
+	state := &firecrawlCrawl{
+		task:      task,
+		request:   request,
+		crawler:   crawler,
+		maxJobs:   maxJobs,
+		startTime: time.Now(),
+		llmUsage:  cost.NewTally(),
+		extracts:  make(chan struct{}, batchExtractConcurrency),
+		finished:  make(chan struct{}),
+		seen:      make(map[string]bool),
+	}
+
+	tm.crawlsMu.Lock()
+	if tm.firecrawlCrawls == nil {
+		tm.firecrawlCrawls = make(map[string]*firecrawlCrawl)
+	}
+	tm.firecrawlCrawls[task.ProcessID] = state
+	tm.crawlsMu.Unlock()
+	if err := tm.subscribeFirecrawlEvents(ctx, state); err != nil {
+		tm.removeFirecrawlCrawl(task.ProcessID)
+		return nil, err
+	}
+
+	// Firecrawl counts every page it reads, so leave room for the listing pages
+	limit := 0
+	if maxJobs > 0 {
+		limit = maxJobs + tm.config.Crawl.MaxPages
+	}
+	crawlID, err := crawler.StartFirecrawlCrawl(ctx, request.URL, task.ProcessID, limit)
+	if err != nil {
+		tm.removeFirecrawlCrawl(task.ProcessID)
+		return nil, err
+	}
+
+	state.mu.Lock()
+	state.crawlID = crawlID
+	state.mu.Unlock()
+
+	if result, err := tm.store.Get(ctx, task.ProcessID); err == nil {
+		if result.Metadata == nil {
+			result.Metadata = map[string]interface{}{}
+		}
+		result.Metadata["firecrawl_crawl_id"] = crawlID
+		if err := tm.store.Update(ctx, result); err != nil {
+			logging.FromContext(ctx).Warn("Failed to store Firecrawl crawl ID", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	logging.FromContext(ctx).Info("Firecrawl crawl started", map[string]interface{}{
+		"crawl_id": crawlID,
+		"url":      request.URL,
+		"limit":    limit,
+	})
+
+	tm.wg.Add(1)
+	go func() {
+		defer tm.wg.Done()
+		select {
+		case <-state.finished:
+		case <-task.Context.Done():
+			tm.finishFirecrawlCrawl(state, task.Context.Err())
+		case <-time.After(tm.config.Firecrawl.Webhook.Timeout):
+			tm.finishFirecrawlCrawl(state, utils.NewEngineTimeoutError(fmt.Sprintf("Firecrawl crawl %s did not complete within %s", crawlID, tm.config.Firecrawl.Webhook.Timeout)))
+		}
+	}()
+
+	return nil, errTaskDetached
+}
+
+// subscribeFirecrawlEvents applies the events other replicas relay for a crawl until it finishes.
+// The subscription is confirmed before returning, so no event of the crawl started afterwards is
+// missed.
+func (tm *TaskManagerImpl) subscribeFirecrawlEvents(ctx context.Context, state *firecrawlCrawl) error {
+	tm.crawlsMu.Lock()
+	client, prefix := tm.crawlRelay, tm.crawlRelayPrefix
+	tm.crawlsMu.Unlock()
+	if client == nil {
+		return nil
+	}
+
+	pubsub := client.Subscribe(context.WithoutCancel(ctx), firecrawlEventChannel(prefix, state.task.ProcessID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to Firecrawl crawl events: %w", err)
+	}
+
+	tm.wg.Add(1)
+	go func() {
+		defer tm.wg.Done()
+		defer pubsub.Close()
+
+		logger := logging.FromContext(state.task.Context)
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-state.finished:
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event firecrawl.WebhookEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					logger.Warn("Discarding malformed relayed Firecrawl event", map[string]interface{}{
+						"error": err.Error(),
+					})
+					continue
+				}
+				tm.applyFirecrawlEvent(state, &event)
+			}
+		}
+	}()
+	return nil
+}
+
+// HandleFirecrawlEvent applies a crawl webhook event to the crawl task whose process ID it
+// carries. Pages that are job postings are extracted as they arrive, and the task completes
+// once the crawl has and its pages are extracted. Events of crawls run by another replica are
+// relayed to it. ErrTaskNotFound is returned for events of crawls no replica awaits.
+func (tm *TaskManagerImpl) HandleFirecrawlEvent(ctx context.Context, event *firecrawl.WebhookEvent) error {
+	tm.crawlsMu.Lock()
+	state := tm.firecrawlCrawls[event.ProcessID()]
+	tm.crawlsMu.Unlock()
+	if state == nil {
+		return tm.relayFirecrawlEvent(ctx, event)
+	}
+	return tm.applyFirecrawlEvent(state, event)
+}
+
+// relayFirecrawlEvent hands an event to the replica running its crawl
+func (tm *TaskManagerImpl) relayFirecrawlEvent(ctx context.Context, event *firecrawl.WebhookEvent) error {
+	tm.crawlsMu.Lock()
+	client, prefix := tm.crawlRelay, tm.crawlRelayPrefix
+	tm.crawlsMu.Unlock()
+	if client == nil || event.ProcessID() == "" {
+		return ErrTaskNotFound
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode Firecrawl event: %w", err)
+	}
+	receivers, err := client.Publish(ctx, firecrawlEventChannel(prefix, event.ProcessID()), payload).Result()
+	if err != nil {
+		return fmt.Errorf("failed to relay Firecrawl event: %w", err)
+	}
+	if receivers == 0 {
+		return ErrTaskNotFound
+	}
+	return nil
+}
+
+// applyFirecrawlEvent applies an event to a crawl run by this replica
+func (tm *TaskManagerImpl) applyFirecrawlEvent(state *firecrawlCrawl, event *firecrawl.WebhookEvent) error {
+	state.mu.Lock()
+	crawlID := state.crawlID
+	state.mu.Unlock()
+	if crawlID != "" && event.ID != "" && event.ID != crawlID {
+		return ErrTaskNotFound
+	}
+
+	switch event.Type {
+	case firecrawl.EventCrawlPage:
+		for i := range event.Data {
+			tm.addFirecrawlPage(state, &event.Data[i])
+		}
+	case firecrawl.EventCrawlCompleted:
+		tm.finishFirecrawlCrawl(state, nil)
+	case firecrawl.EventCrawlFailed:
+		tm.finishFirecrawlCrawl(state, utils.NewScrapingError("Firecrawl crawl failed: "+event.Error))
+	default:
+		logging.FromContext(state.task.Context).Debug("Firecrawl crawl event", map[string]interface{}{
+			"event":    event.Type,
+			"crawl_id": event.ID,
+		})
+	}
+	return nil
+}
+
+// addFirecrawlPage records a crawled page that is a job posting of the careers site and extracts
+// its job in the background, up to the crawl's job limit
+func (tm *TaskManagerImpl) addFirecrawlPage(state *firecrawlCrawl, doc *firecrawl.WebhookDocument) {
+	pageURL := doc.PageURL()
+	if !state.crawler.IsJobPage(state.request.URL, pageURL) {
+		return
+	}
+
+	state.mu.Lock()
+	key := utils.CanonicalURL(pageURL)
+	if state.done || state.seen[key] || (state.maxJobs > 0 && len(state.items) >= state.maxJobs) {
+		state.mu.Unlock()
+		return
+	}
+	state.seen[key] = true
+	index := len(state.items)
+	state.items = append(state.items, BatchScrapeItem{URL: pageURL})
+	state.pending.Add(1)
+	state.mu.Unlock()
+
+	var pageErr error
+	switch {
+	case doc.Metadata.Error != "":
+		pageErr = utils.NewScrapingError("Firecrawl could not scrape the page: " + doc.Metadata.Error)
+	case doc.Metadata.StatusCode >= 400:
+		pageErr = utils.NewScrapingError(fmt.Sprintf("page returned status %d", doc.Metadata.StatusCode))
+	case doc.Content() == "":
+		pageErr = utils.NewScrapingError("no content found in Firecrawl response")
+	default:
+		pageErr = policy.GetGlobalChecker().Check(state.task.Context, pageURL)
+	}
+	if pageErr != nil {
+		state.mu.Lock()
+		failBatchItem(&state.items[index], pageErr)
+		state.mu.Unlock()
+		state.pending.Done()
+		return
+	}
+
+	content := doc.Content()
+	go func() {
+		defer state.pending.Done()
+		state.extracts <- struct{}{}
+		defer func() { <-state.extracts }()
+
+		ctx := cost.WithTally(state.task.Context, state.llmUsage)
+		if options := state.request.Options; options != nil {
+			if options.NoCache {
+				ctx = llm.WithCacheBypass(ctx)
+			}
+			if options.Model != "" {
+				ctx = llm.WithModel(ctx, options.Model)
+			}
+		}
+
+		capture := archive.NewCapture()
+		job, err := tm.llmManager.ExtractJobData(archive.WithCapture(ctx, capture), content, pageURL)
+		archived := storePage(state.task.Context, archive.Key(state.task.ProcessID, index), capture.Page())
+
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		item := &state.items[index]
+		if archived {
+			item.Archive = archive.Key(state.task.ProcessID, index)
+		}
+		if err != nil {
+			failBatchItem(item, err)
+			return
+		}
+		if job.JobURL == "" {
+			job.JobURL = pageURL
+		}
+		item.Job = job
+		item.Engine = "firecrawl"
+	}()
+}
+
+// finishFirecrawlCrawl stops awaiting events for a crawl and, once its pages are extracted,
+// completes the task with their results. Like other crawls it fails only when no job was
+// scraped; crawlErr, why the crawl ended early, is kept on a partial result.
+func (tm *TaskManagerImpl) finishFirecrawlCrawl(state *firecrawlCrawl, crawlErr error) {
+	state.mu.Lock()
+	if state.done {
+		state.mu.Unlock()
+		return
+	}
+	state.done = true
+	state.mu.Unlock()
+	close(state.finished)
+	tm.removeFirecrawlCrawl(state.task.ProcessID)
+
+	tm.wg.Add(1)
+	go func() {
+		defer tm.wg.Done()
+		state.pending.Wait()
+		result, err := tm.firecrawlCrawlResult(state, crawlErr)
+		tm.completeTask(state.task, state.startTime, result, err)
+	}()
+}
+
+// firecrawlCrawlResult aggregates the extracted pages of a finished crawl into its result
+func (tm *TaskManagerImpl) firecrawlCrawlResult(state *firecrawlCrawl, crawlErr error) (*TaskResult, error) {
+	ctx := state.task.Context
+	existingResult, err := tm.store.Get(context.WithoutCancel(ctx), state.task.ProcessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
+	}
+
+	data := &CrawlTaskData{
+		URL:     state.request.URL,
+		Sources: map[string]int{"firecrawl_crawl": len(state.items)},
+		Results: state.items,
+		Total:   len(state.items),
+	}
+	for _, item := range data.Results {
+		if item.Error == "" {
+			data.Succeeded++
+		}
+	}
+	data.Failed = data.Total - data.Succeeded
+
+	processingTime := time.Since(state.startTime)
+	existingResult.Data = data
+	existingResult.ProcessingTime = &processingTime
+	if existingResult.Metadata == nil {
+		existingResult.Metadata = map[string]interface{}{}
+	}
+	existingResult.Metadata["job_count"] = data.Total
+	existingResult.Metadata["succeeded"] = data.Succeeded
+	existingResult.Metadata["failed"] = data.Failed
+	if crawlErr != nil {
+		existingResult.Metadata["crawl_error"] = crawlErr.Error()
+	}
+	setLLMUsage(existingResult, state.llmUsage.Metadata())
+
+	if data.Succeeded == 0 {
+		// The failure update keeps the stored result, so store the per-job errors first
+		if err := tm.store.Update(context.WithoutCancel(ctx), existingResult); err != nil {
+			logging.FromContext(ctx).Warn("Failed to store crawl results", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		if crawlErr != nil {
+			return nil, crawlErr
+		}
+		if data.Total == 0 {
+			return nil, utils.NewScrapingError(fmt.Sprintf("Firecrawl found no jobs at %s", state.request.URL))
+		}
+		return nil, utils.NewScrapingError(fmt.Sprintf("all %d jobs found at %s failed", data.Total, state.request.URL))
+	}
+
+	return existingResult, nil
+}
+
+// removeFirecrawlCrawl stops routing events to a crawl
+func (tm *TaskManagerImpl) removeFirecrawlCrawl(processID string) {
+	tm.crawlsMu.Lock()
+	delete(tm.firecrawlCrawls, processID)
+	tm.crawlsMu.Unlock()
+}
@@ -0,0 +1,214 @@
+package background
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"letraz-utils/pkg/utils"
+)
+
+const (
+	// DefaultTaskPageSize is the number of tasks listed when a filter sets no limit
+	DefaultTaskPageSize = 50
+
+	// MaxTaskPageSize caps the number of tasks listed in one page
+	MaxTaskPageSize = 500
+)
+
+// TaskFilter selects the tasks returned by ListTasks. Zero fields match every task; ResumeID
+// and URL match the resume_id and url recorded in task metadata.
+type TaskFilter struct {
+	Status        TaskStatus
+	Type          TaskType
+	ResumeID      string
+	URL           string
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+	Cursor        string    // NextCursor of the previous page
+	Limit         int
+}
+
+// TaskPage is one page of listed tasks, newest first
+type TaskPage struct {
+	Tasks []*TaskResult
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+}
+
+// knownTaskStatuses and knownTaskTypes are the values a filter may select
+var (
+	knownTaskStatuses = []TaskStatus{TaskStatusAccepted, TaskStatusProcessing, TaskStatusSuccess, TaskStatusFailure, TaskStatusScheduled}
+	knownTaskTypes    = []TaskType{TaskTypeScrape, TaskTypeBatch, TaskTypeCrawl, TaskTypeTailor, TaskTypeInterview, TaskTypeScreenshot}
+)
+
+// ListTasks lists the tasks matching filter, including submissions still scheduled, newest
+// first one page at a time. The task store filters and pages the tasks it holds; the few
+// scheduled submissions are merged in. An unknown status or type, or an invalid cursor, is a
+// validation error.
+func (tm *TaskManagerImpl) ListTasks(ctx context.Context, filter TaskFilter) (*TaskPage, error) {
+	filter, after, err := normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// One task beyond the page tells whether another page follows
+	query := filter
+	query.Limit++
+
+	var tasks []*TaskResult
+	if filter.Status != TaskStatusScheduled {
+		if tasks, err = tm.store.Query(ctx, query); err != nil {
+			return nil, err
+		}
+	}
+	scheduled, err := tm.scheduledResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks = append(tasks, filterTasks(scheduled, query, after)...)
+	sortTasks(tasks)
+
+	page := &TaskPage{Tasks: tasks}
+	if len(tasks) > filter.Limit {
+		page.Tasks = tasks[:filter.Limit]
+		page.NextCursor = taskCursorOf(page.Tasks[filter.Limit-1]).encode()
+	}
+	return page, nil
+}
+
+// filterTasks returns the tasks matching filter after the cursor position, newest first and at
+// most filter.Limit of them; it is how the in-memory store answers Query
+func filterTasks(tasks []*TaskResult, filter TaskFilter, after *taskCursor) []*TaskResult {
+	matched := make([]*TaskResult, 0, len(tasks))
+	for _, task := range tasks {
+		if filter.matches(task) && (after == nil || after.precedes(task)) {
+			matched = append(matched, task)
+		}
+	}
+	sortTasks(matched)
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched
+}
+
+// sortTasks orders tasks as listings show them: newest first, then by descending process ID
+func sortTasks(tasks []*TaskResult) {
+	sort.Slice(tasks, func(i, j int) bool {
+		return taskCursorOf(tasks[i]).precedes(tasks[j])
+	})
+}
+
+// normalizeTaskFilter validates a filter, defaulting and capping its limit, and decodes its
+// cursor
+func normalizeTaskFilter(filter TaskFilter) (TaskFilter, *taskCursor, error) {
+	if filter.Status != "" {
+		filter.Status = TaskStatus(strings.ToUpper(string(filter.Status)))
+		if !slices.Contains(knownTaskStatuses, filter.Status) {
+			return filter, nil, utils.NewValidationError(fmt.Sprintf("unknown task status %q", filter.Status))
+		}
+	}
+	if filter.Type != "" {
+		filter.Type = TaskType(strings.ToLower(string(filter.Type)))
+		if !slices.Contains(knownTaskTypes, filter.Type) {
+			return filter, nil, utils.NewValidationError(fmt.Sprintf("unknown task type %q", filter.Type))
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultTaskPageSize
+	}
+	filter.Limit = min(filter.Limit, MaxTaskPageSize)
+
+	cursor, err := filter.after()
+	return filter, cursor, err
+}
+
+// after decodes the cursor of a filter, nil when it has none
+func (f TaskFilter) after() (*taskCursor, error) {
+	if f.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := decodeTaskCursor(f.Cursor)
+	if err != nil {
+		return nil, utils.NewValidationError("invalid cursor")
+	}
+	return cursor, nil
+}
+
+// matches reports whether a task passes every field of the filter
+func (f TaskFilter) matches(task *TaskResult) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	if f.Type != "" && task.Type != f.Type {
+		return false
+	}
+	if f.ResumeID != "" && metadataString(task.Metadata, "resume_id") != f.ResumeID {
+		return false
+	}
+	if f.URL != "" && metadataString(task.Metadata, "url") != f.URL {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && task.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !task.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// taskCursor is the position of a task in a listing: creation time, then process ID, both
+// descending
+type taskCursor struct {
+	createdAt time.Time
+	processID string
+}
+
+// taskCursorOf returns the listing position of a task
+func taskCursorOf(task *TaskResult) taskCursor {
+	return taskCursor{createdAt: task.CreatedAt, processID: task.ProcessID}
+}
+
+// precedes reports whether the cursor position comes before task in a listing
+func (c taskCursor) precedes(task *TaskResult) bool {
+	if !task.CreatedAt.Equal(c.createdAt) {
+		return task.CreatedAt.Before(c.createdAt)
+	}
+	return task.ProcessID < c.processID
+}
+
+// encode returns the opaque form of the cursor handed to clients
+func (c taskCursor) encode() string {
+	raw := strconv.FormatInt(c.createdAt.UnixNano(), 10) + ":" + c.processID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTaskCursor parses a cursor produced by encode
+func decodeTaskCursor(encoded string) (*taskCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	nanos, processID, found := strings.Cut(string(raw), ":")
+	if !found || processID == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &taskCursor{createdAt: time.Unix(0, unixNano), processID: processID}, nil
+}
+
+// metadataString returns a string metadata value, or "" when it is missing or not a string
+func metadataString(metadata map[string]interface{}, key string) string {
+	value, _ := metadata[key].(string)
+	return value
+}
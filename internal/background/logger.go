@@ -10,6 +10,8 @@ import (
 	"letraz-utils/internal/callback"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/pkg/utils"
 )
 
 // TaskCompletionLogger handles structured logging for task completion
@@ -41,6 +43,7 @@ type TaskCompletionLog struct {
 	Status         string                 `json:"status"`
 	Data           interface{}            `json:"data,omitempty"`
 	Error          string                 `json:"error,omitempty"`
+	ErrorCode      string                 `json:"errorCode,omitempty"`
 	Timestamp      time.Time              `json:"timestamp"`
 	Operation      string                 `json:"operation"`
 	ProcessingTime string                 `json:"processing_time"`
@@ -62,6 +65,7 @@ func (l *TaskCompletionLogger) LogTaskCompletion(result *TaskResult) error {
 		Status:         string(result.Status),
 		Data:           result.Data,
 		Error:          result.Error,
+		ErrorCode:      result.ErrorCode,
 		Timestamp:      time.Now(),
 		Operation:      string(result.Type),
 		ProcessingTime: processingTimeStr,
@@ -102,7 +106,12 @@ func (l *TaskCompletionLogger) LogTaskCompletion(result *TaskResult) error {
 				"process_id": result.ProcessID,
 				"error":      err.Error(),
 			})
+			timeline.RecordProcess(result.ProcessID, timeline.EventCallbackFailed, map[string]interface{}{
+				"error": err.Error(),
+			})
 			// Don't return error here as logging succeeded, just callback failed
+		} else {
+			timeline.RecordProcess(result.ProcessID, timeline.EventCallbackDelivered, nil)
 		}
 	}
 
@@ -116,6 +125,7 @@ func (l *TaskCompletionLogger) LogTaskStart(processID string, taskType TaskType)
 		"operation":  taskType,
 		"status":     "PROCESSING",
 	})
+	timeline.RecordProcess(processID, timeline.EventStarted, nil)
 }
 
 // LogTaskAccepted logs when a task is accepted for processing
@@ -125,6 +135,9 @@ func (l *TaskCompletionLogger) LogTaskAccepted(processID string, taskType TaskTy
 		"operation":  taskType,
 		"status":     "ACCEPTED",
 	})
+	timeline.RecordProcess(processID, timeline.EventAccepted, map[string]interface{}{
+		"operation": taskType,
+	})
 }
 
 // LogTaskError logs task errors during processing
@@ -135,6 +148,10 @@ func (l *TaskCompletionLogger) LogTaskError(processID string, taskType TaskType,
 		"status":     "FAILURE",
 		"error":      err.Error(),
 	})
+	timeline.RecordProcess(processID, timeline.EventFailed, map[string]interface{}{
+		"error":      err.Error(),
+		"error_code": utils.GetErrorCode(err),
+	})
 }
 
 // LogTaskSuccess logs successful task completion
@@ -145,6 +162,9 @@ func (l *TaskCompletionLogger) LogTaskSuccess(processID string, taskType TaskTyp
 		"status":          "SUCCESS",
 		"processing_time": processingTime,
 	})
+	timeline.RecordProcess(processID, timeline.EventCompleted, map[string]interface{}{
+		"processing_time": processingTime.String(),
+	})
 }
 
 // LogTaskMetrics logs task metrics for monitoring
@@ -177,6 +197,7 @@ func CreateTaskCompletionLog(result *TaskResult) *TaskCompletionLog {
 		Status:         string(result.Status),
 		Data:           result.Data,
 		Error:          result.Error,
+		ErrorCode:      result.ErrorCode,
 		Timestamp:      time.Now(),
 		Operation:      string(result.Type),
 		ProcessingTime: processingTimeStr,
@@ -208,7 +229,7 @@ func LogTaskCompletionToStdout(result *TaskResult) error {
 
 // sendTaskCallback sends a task callback via gRPC
 func (l *TaskCompletionLogger) sendTaskCallback(ctx context.Context, result *TaskResult) error {
-	// Send callbacks for both scrape and tailor tasks
+	// Send callbacks for every task type the server has a callback controller for
 	switch result.Type {
 	case TaskTypeScrape:
 		return l.sendScrapeTaskCallback(ctx, result)
@@ -216,6 +237,8 @@ func (l *TaskCompletionLogger) sendTaskCallback(ctx context.Context, result *Tas
 		return l.sendTailorResumeTaskCallback(ctx, result)
 	case TaskTypeScreenshot:
 		return l.sendScreenshotTaskCallback(ctx, result)
+	case TaskTypeInterview:
+		return l.sendInterviewTaskCallback(ctx, result)
 	default:
 		return nil
 	}
@@ -227,6 +250,8 @@ func (l *TaskCompletionLogger) sendScrapeTaskCallback(ctx context.Context, resul
 	// Create callback data from task result
 	callbackData := &callback.CallbackData{
 		ProcessID: result.ProcessID,
+		Error:     result.Error,
+		ErrorCode: result.ErrorCode,
 		Status:    string(result.Status),
 		Timestamp: time.Now(),
 		Operation: string(result.Type),
@@ -260,6 +285,13 @@ func (l *TaskCompletionLogger) sendScrapeTaskCallback(ctx context.Context, resul
 		if url, ok := result.Metadata["url"].(string); ok {
 			callbackData.Metadata.URL = url
 		}
+
+		callbackData.Metadata.LLMUsage = llmUsageFromMetadata(result.Metadata)
+
+		if antiBot, ok := result.Metadata["anti_bot"].(map[string]interface{}); ok {
+			callbackData.Metadata.Block, _ = antiBot["block"].(string)
+			callbackData.Metadata.BlockRetryable, _ = antiBot["retryable"].(bool)
+		}
 	}
 
 	// Send the callback
@@ -271,6 +303,8 @@ func (l *TaskCompletionLogger) sendTailorResumeTaskCallback(ctx context.Context,
 	// Create callback data from task result
 	callbackData := &callback.TailorResumeCallbackData{
 		ProcessID: result.ProcessID,
+		Error:     result.Error,
+		ErrorCode: result.ErrorCode,
 		Status:    string(result.Status),
 		Timestamp: time.Now(),
 		Operation: string(result.Type),
@@ -293,25 +327,88 @@ func (l *TaskCompletionLogger) sendTailorResumeTaskCallback(ctx context.Context,
 		}
 	}
 
-	// Extract metadata if available
-	if result.Metadata != nil {
-		callbackData.Metadata = &callback.TailorResumeCallbackMetadata{}
+	callbackData.Metadata = resumeCallbackMetadata(result.Metadata)
 
-		if company, ok := result.Metadata["company"].(string); ok {
-			callbackData.Metadata.Company = company
-		}
+	// Send the callback
+	return l.callbackClient.SendTailorResumeCallback(ctx, callbackData)
+}
+
+// sendInterviewTaskCallback sends an InterviewQuestions task callback via gRPC
+func (l *TaskCompletionLogger) sendInterviewTaskCallback(ctx context.Context, result *TaskResult) error {
+	callbackData := &callback.InterviewQuestionsCallbackData{
+		ProcessID: result.ProcessID,
+		Error:     result.Error,
+		ErrorCode: result.ErrorCode,
+		Status:    string(result.Status),
+		Timestamp: time.Now(),
+		Operation: string(result.Type),
+		ProcessingTime: func() time.Duration {
+			if result.ProcessingTime != nil {
+				return *result.ProcessingTime
+			}
+			return 0
+		}(),
+	}
 
-		if jobTitle, ok := result.Metadata["job_title"].(string); ok {
-			callbackData.Metadata.JobTitle = jobTitle
+	if interviewData, ok := result.Data.(*InterviewTaskData); ok {
+		callbackData.Data = &callback.InterviewQuestionsJobData{
+			Questions: interviewData.Questions,
+			ResumeID:  interviewData.ResumeID,
 		}
+	}
 
-		if resumeID, ok := result.Metadata["resume_id"].(string); ok {
-			callbackData.Metadata.ResumeID = resumeID
+	callbackData.Metadata = resumeCallbackMetadata(result.Metadata)
+
+	return l.callbackClient.SendInterviewQuestionsCallback(ctx, callbackData)
+}
+
+// resumeCallbackMetadata reads the resume and job task metadata of resume callbacks, or returns nil
+func resumeCallbackMetadata(metadata map[string]interface{}) *callback.TailorResumeCallbackMetadata {
+	if metadata == nil {
+		return nil
+	}
+
+	callbackMetadata := &callback.TailorResumeCallbackMetadata{}
+	if company, ok := metadata["company"].(string); ok {
+		callbackMetadata.Company = company
+	}
+	if jobTitle, ok := metadata["job_title"].(string); ok {
+		callbackMetadata.JobTitle = jobTitle
+	}
+	if resumeID, ok := metadata["resume_id"].(string); ok {
+		callbackMetadata.ResumeID = resumeID
+	}
+	callbackMetadata.LLMUsage = llmUsageFromMetadata(metadata)
+	return callbackMetadata
+}
+
+// llmUsageFromMetadata reads the "llm_usage" task metadata. Numbers are int64 or float64
+// depending on whether the result was decoded from the task store.
+func llmUsageFromMetadata(metadata map[string]interface{}) *callback.LLMUsage {
+	usage, ok := metadata["llm_usage"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	number := func(key string) float64 {
+		switch v := usage[key].(type) {
+		case int64:
+			return float64(v)
+		case int:
+			return float64(v)
+		case float64:
+			return v
 		}
+		return 0
 	}
 
-	// Send the callback
-	return l.callbackClient.SendTailorResumeCallback(ctx, callbackData)
+	provider, _ := usage["provider"].(string)
+	return &callback.LLMUsage{
+		Provider:     provider,
+		InputTokens:  int64(number("input_tokens")),
+		OutputTokens: int64(number("output_tokens")),
+		CostUSD:      number("cost_usd"),
+	}
 }
 
 // sendScreenshotTaskCallback sends a screenshot task callback via gRPC
@@ -319,6 +416,8 @@ func (l *TaskCompletionLogger) sendScreenshotTaskCallback(ctx context.Context, r
 	// Create callback data from task result
 	callbackData := &callback.ScreenshotCallbackData{
 		ProcessID: result.ProcessID,
+		Error:     result.Error,
+		ErrorCode: result.ErrorCode,
 		Status:    string(result.Status),
 		Timestamp: time.Now(),
 		Operation: string(result.Type),
@@ -2,17 +2,34 @@ package background
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	neturl "net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/archive"
 	"letraz-utils/internal/callback"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/crawl"
 	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/cost"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/metrics"
+	"letraz-utils/internal/quota"
+	"letraz-utils/internal/scraper/engines/firecrawl"
 	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/internal/scraper/engines/stub"
+	"letraz-utils/internal/scraper/policy"
 	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -30,9 +47,24 @@ const (
 	// Maximum configuration values for safety
 	MaxWorkers   = 1000
 	MaxQueueSize = 10000
+
+	// pooledTaskWorkerID identifies tasks awaited outside the task worker pool in logs
+	pooledTaskWorkerID = -1
+
+	// Backpressure: Retry-After is estimated from task completions over drainRateWindow
+	drainRateWindow = time.Minute
+	minRetryAfter   = time.Second
+	maxRetryAfter   = 5 * time.Minute
+
+	// archiveTimeout bounds the upload of a scraped page to the archive
+	archiveTimeout = 30 * time.Second
+
+	// batchExtractConcurrency bounds the LLM extractions run at once for a Firecrawl batch
+	batchExtractConcurrency = 4
 )
 
-// TaskManager defines the interface for managing background tasks
+// TaskManager defines the interface for managing background tasks. The priority passed to the
+// Submit methods orders tasks waiting for a task worker.
 type TaskManager interface {
 	// Start starts the task manager
 	Start(ctx context.Context) error
@@ -41,13 +73,27 @@ type TaskManager interface {
 	Stop(ctx context.Context) error
 
 	// SubmitScrapeTask submits a scrape task for background processing
-	SubmitScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, poolManager *workers.PoolManager) error
+	SubmitScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, priority TaskPriority, poolManager *workers.PoolManager) error
+
+	// SubmitBatchScrapeTask submits a scrape of several URLs whose result aggregates every job
+	SubmitBatchScrapeTask(ctx context.Context, processID string, request models.BatchScrapeRequest, priority TaskPriority, poolManager *workers.PoolManager) error
+
+	// SubmitCrawlTask submits a crawl of a careers page that scrapes every job it links to as a
+	// child scrape task and aggregates their results
+	SubmitCrawlTask(ctx context.Context, processID string, request models.CrawlRequest, priority TaskPriority, crawler *crawl.Crawler, poolManager *workers.PoolManager) error
 
 	// SubmitTailorTask submits a tailor task for background processing
-	SubmitTailorTask(ctx context.Context, processID string, request models.TailorResumeRequest, llmManager *llm.Manager, cfg *config.Config) error
+	SubmitTailorTask(ctx context.Context, processID string, request models.TailorResumeRequest, priority TaskPriority, llmManager *llm.Manager, cfg *config.Config) error
+
+	// SubmitInterviewTask submits an interview questions task for background processing
+	SubmitInterviewTask(ctx context.Context, processID string, request models.InterviewQuestionsRequest, priority TaskPriority, llmManager *llm.Manager) error
 
 	// SubmitScreenshotTask submits a screenshot task for background processing
-	SubmitScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, cfg *config.Config) error
+	SubmitScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, priority TaskPriority, cfg *config.Config) error
+
+	// ScheduleTask holds a task submission until runAt, then submits it with its type's Submit
+	// method; request is the submission's request model
+	ScheduleTask(ctx context.Context, processID string, taskType TaskType, request interface{}, priority TaskPriority, runAt time.Time) error
 
 	// GetTaskResult retrieves the result of a task by process ID
 	GetTaskResult(ctx context.Context, processID string) (*TaskResult, error)
@@ -55,39 +101,89 @@ type TaskManager interface {
 	// GetTaskStatus retrieves the status of a task by process ID
 	GetTaskStatus(ctx context.Context, processID string) (TaskStatus, error)
 
-	// ListTasks lists all active tasks (for monitoring)
-	ListTasks(ctx context.Context) ([]*TaskResult, error)
+	// SubscribeTaskEvents delivers the lifecycle and progress events of a process until ctx is
+	// cancelled
+	SubscribeTaskEvents(ctx context.Context, processID string) <-chan *TaskEvent
+
+	// ListTasks lists the tasks matching filter, newest first, one page at a time
+	ListTasks(ctx context.Context, filter TaskFilter) (*TaskPage, error)
 
 	// IsHealthy checks if the task manager is healthy
 	IsHealthy() bool
+
+	// GetStats returns queue and task statistics (for monitoring)
+	GetStats() map[string]interface{}
+
+	// HandleFirecrawlEvent applies a Firecrawl crawl webhook event to the crawl task it belongs to
+	HandleFirecrawlEvent(ctx context.Context, event *firecrawl.WebhookEvent) error
+
+	// Drain stops accepting tasks and waits up to timeout for queued and running ones, failing
+	// those left as abandoned; it returns at once with the drain's status
+	Drain(timeout time.Duration) DrainStatus
+
+	// DrainStatus reports the progress of the current drain
+	DrainStatus() DrainStatus
+
+	// Drained returns a channel closed once the drain finished; nil before Drain was called
+	Drained() <-chan struct{}
 }
 
 // TaskManagerImpl implements the TaskManager interface
 type TaskManagerImpl struct {
-	config       *config.Config
-	store        TaskStore
-	logger       *TaskCompletionLogger
-	appLogger    types.Logger
-	llmManager   *llm.Manager
-	workerPool   chan struct{}
-	ctx          context.Context
-	cancel       context.CancelFunc
-	wg           sync.WaitGroup
-	mu           sync.RWMutex
-	running      bool
-	taskChan     chan *TaskExecution
-	maxWorkers   int
-	maxQueueSize int
+	config        *config.Config
+	store         TaskStore
+	logger        *TaskCompletionLogger
+	appLogger     types.Logger
+	llmManager    *llm.Manager
+	conversations *utils.ConversationStore
+	publisher     TaskEventPublisher
+	events        *taskEventHub // delivers this instance's events to in-process subscribers
+	workerPool    chan struct{}
+	ctx           context.Context
+	cancel        context.CancelFunc
+	abort         context.CancelCauseFunc // cancels ctx with a cause the tasks' failures report
+	wg            sync.WaitGroup
+	mu            sync.RWMutex
+	running       bool
+	queue         *taskQueue
+	maxWorkers    int
+	maxQueueSize  int
+	awaitingJobs  int64 // tasks waiting on scraper pool job handles
+	completions   *metrics.RateMeter
+
+	crawlsMu         sync.Mutex
+	firecrawlCrawls  map[string]*firecrawlCrawl // crawls awaiting Firecrawl webhook events, by process ID
+	crawlRelay       redis.UniversalClient      // relays webhook events between replicas; nil keeps them local
+	crawlRelayPrefix string
+
+	scheduler *scheduler // nil until EnableScheduling
+
+	stream    *streamQueue  // shared queue; nil when tasks are queued in memory
+	resources TaskResources // dependencies of tasks taken from the shared queue
+
+	activeMu sync.Mutex
+	active   map[string]*TaskExecution // tasks accepted by this instance and not finished, by process ID
+	drain    *drainState               // nil until Drain
 }
 
 // TaskExecution represents a task execution context
 type TaskExecution struct {
 	ProcessID     string
 	Type          TaskType
+	Priority      TaskPriority
 	Context       context.Context
 	Cancel        context.CancelFunc
 	ExecuteFunc   func(context.Context) (*TaskResult, error)
 	CompletedChan chan *TaskResult
+
+	// Retry bounds the runs of ExecuteFunc for transient failures; tasks whose work runs on the
+	// scraper pool leave it unset, as the pool retries its jobs itself
+	Retry    config.TaskRetryPolicy
+	Attempts int // runs of ExecuteFunc so far
+
+	// Request is the submitted request model of tasks that can run on another replica from the
+	// shared queue; nil for tasks bound to this instance
+	Request interface{}
 }
 
 // validateTaskManagerConfig validates and returns safe configuration values
@@ -146,7 +242,10 @@ func NewTaskManager(cfg *config.Config) *TaskManagerImpl {
 		workerPool:   make(chan struct{}, maxWorkers),
 		maxWorkers:   maxWorkers,
 		maxQueueSize: maxQueueSize,
-		taskChan:     make(chan *TaskExecution, maxQueueSize),
+		queue:        newTaskQueue(maxQueueSize),
+		completions:  metrics.NewRateMeter(drainRateWindow),
+		events:       newTaskEventHub(),
+		active:       make(map[string]*TaskExecution),
 	}
 }
 
@@ -194,10 +293,74 @@ func NewTaskManagerWithCallback(cfg *config.Config, callbackClient *callback.Cli
 		workerPool:   make(chan struct{}, maxWorkers),
 		maxWorkers:   maxWorkers,
 		maxQueueSize: maxQueueSize,
-		taskChan:     make(chan *TaskExecution, maxQueueSize),
+		queue:        newTaskQueue(maxQueueSize),
+		completions:  metrics.NewRateMeter(drainRateWindow),
+		events:       newTaskEventHub(),
+		active:       make(map[string]*TaskExecution),
+	}
+}
+
+// SetConversationStore injects the store used for resume tailoring conversation history
+func (tm *TaskManagerImpl) SetConversationStore(conversations *utils.ConversationStore) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.conversations = conversations
+}
+
+// SetTaskStore replaces the in-memory store of task results; call it before Start
+func (tm *TaskManagerImpl) SetTaskStore(store TaskStore) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.store = store
+}
+
+// SetEventPublisher sets the publisher that receives task lifecycle events
+func (tm *TaskManagerImpl) SetEventPublisher(publisher TaskEventPublisher) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.publisher = publisher
+}
+
+// publishEvent hands a task lifecycle event to this instance's subscribers and publishes it if a
+// publisher is configured; delivery is best effort
+func (tm *TaskManagerImpl) publishEvent(ctx context.Context, event *TaskEvent) {
+	tm.mu.RLock()
+	publisher := tm.publisher
+	tm.mu.RUnlock()
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	_ = tm.events.Publish(ctx, event)
+	if publisher == nil {
+		return
+	}
+
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+
+	if err := publisher.Publish(publishCtx, event); err != nil {
+		logging.FromContext(ctx).Warn("Failed to publish task event", map[string]interface{}{
+			"event": event.Event,
+			"error": err.Error(),
+		})
 	}
 }
 
+// SubscribeTaskEvents delivers the lifecycle and progress events of a process until ctx is
+// cancelled. A publisher that is also a subscriber, such as Redis, carries the events of every
+// instance; otherwise only this instance's events are delivered.
+func (tm *TaskManagerImpl) SubscribeTaskEvents(ctx context.Context, processID string) <-chan *TaskEvent {
+	tm.mu.RLock()
+	publisher := tm.publisher
+	tm.mu.RUnlock()
+
+	if subscriber, ok := publisher.(TaskEventSubscriber); ok {
+		return subscriber.Subscribe(ctx, processID)
+	}
+	return tm.events.Subscribe(ctx, processID)
+}
+
 // Start starts the task manager
 func (tm *TaskManagerImpl) Start(ctx context.Context) error {
 	tm.mu.Lock()
@@ -207,7 +370,8 @@ func (tm *TaskManagerImpl) Start(ctx context.Context) error {
 		return fmt.Errorf("task manager already running")
 	}
 
-	tm.ctx, tm.cancel = context.WithCancel(ctx)
+	tm.ctx, tm.abort = context.WithCancelCause(ctx)
+	tm.cancel = func() { tm.abort(nil) }
 	tm.running = true
 
 	// Start LLM manager
@@ -218,9 +382,13 @@ func (tm *TaskManagerImpl) Start(ctx context.Context) error {
 	}
 
 	// Start worker goroutines
-	for i := 0; i < tm.maxWorkers; i++ {
-		tm.wg.Add(1)
-		go tm.worker(i)
+	if tm.stream != nil {
+		tm.startStreamWorkers()
+	} else {
+		for i := 0; i < tm.maxWorkers; i++ {
+			tm.wg.Add(1)
+			go tm.worker(i)
+		}
 	}
 
 	// Start cleanup goroutine
@@ -256,8 +424,8 @@ func (tm *TaskManagerImpl) Stop(ctx context.Context) error {
 	// Cancel context to signal workers to stop
 	tm.cancel()
 
-	// Close task channel
-	close(tm.taskChan)
+	// Close the task queue
+	tm.queue.Close()
 
 	// Wait for workers to finish with timeout
 	done := make(chan struct{})
@@ -278,19 +446,44 @@ func (tm *TaskManagerImpl) Stop(ctx context.Context) error {
 }
 
 // SubmitScrapeTask submits a scrape task for background processing
-func (tm *TaskManagerImpl) SubmitScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, poolManager *workers.PoolManager) error {
-	if !tm.IsHealthy() {
-		return fmt.Errorf("task manager is not healthy")
+func (tm *TaskManagerImpl) SubmitScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, priority TaskPriority, poolManager *workers.PoolManager) error {
+	_, err := tm.submitScrapeTask(ctx, processID, request, priority, poolManager, tm.stream != nil, 0)
+	return err
+}
+
+// submitScrapeTask submits a scrape task and returns the scraper pool job of URL scrapes, which
+// parent tasks wait on to aggregate the results of their child tasks. Shared URL scrapes go on
+// the shared queue instead, and are queued on the scraper pool of the replica that takes them.
+// A URL scrape refused for its domain's rate limit is queued again at the limit's pace for up to
+// maxWait; with no wait it is refused at once.
+func (tm *TaskManagerImpl) submitScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, priority TaskPriority, poolManager *workers.PoolManager, shared bool, maxWait time.Duration) (*workers.JobHandle, error) {
+	if err := tm.accepting(); err != nil {
+		return nil, err
 	}
 
 	// Validate request - either URL or description must be provided
 	if request.URL == "" && request.Description == "" {
-		return fmt.Errorf("either URL or description is required")
+		return nil, fmt.Errorf("either URL or description is required")
 	}
 
 	// Both URL and description cannot be provided
 	if request.URL != "" && request.Description != "" {
-		return fmt.Errorf("cannot provide both URL and description - choose one")
+		return nil, fmt.Errorf("cannot provide both URL and description - choose one")
+	}
+
+	// Create task execution with derived context for better isolation
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeScrape)
+
+	// URL scrapes go straight onto the scraper pool's queue; the task waits on the job handle
+	// instead of holding a task worker, so the two queues can't stack their waits
+	var handle *workers.JobHandle
+	if request.URL != "" && !shared {
+		var err error
+		handle, err = poolManager.EnqueuePaced(taskCtx, request.URL, request.Options, priority.jobPriority(), maxWait)
+		if err != nil {
+			cancelFunc()
+			return nil, err
+		}
 	}
 
 	// Create task result
@@ -309,40 +502,209 @@ func (tm *TaskManagerImpl) SubmitScrapeTask(ctx context.Context, processID strin
 
 	// Store initial task result
 	if err := tm.store.Store(ctx, result); err != nil {
-		return fmt.Errorf("failed to store task result: %w", err)
+		cancelFunc()
+		return nil, fmt.Errorf("failed to store task result: %w", err)
 	}
 
 	// Log task acceptance
 	tm.logger.LogTaskAccepted(processID, TaskTypeScrape)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeScrape, Status: TaskStatusAccepted})
 
-	// Create task execution with derived context for better isolation
-	taskCtx, cancelFunc := context.WithCancel(tm.ctx)
 	execution := &TaskExecution{
 		ProcessID: processID,
 		Type:      TaskTypeScrape,
+		Priority:  priority,
 		Context:   taskCtx, // Use derived context for task isolation
 		Cancel:    cancelFunc,
 		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
-			return tm.executeScrapeTask(execCtx, processID, request, poolManager)
+			return tm.executeScrapeTask(execCtx, processID, request, handle)
 		},
 		CompletedChan: make(chan *TaskResult, 1),
 	}
 
+	if handle != nil {
+		tm.wg.Add(1)
+		go tm.awaitPooledTask(execution, handle)
+		return handle, nil
+	}
+	if request.URL == "" {
+		execution.Retry = tm.retryPolicy(TaskTypeScrape)
+	}
+	execution.Request = request
+
 	// Submit to worker pool
-	select {
-	case tm.taskChan <- execution:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return fmt.Errorf("task queue is full")
+	return nil, tm.enqueue(ctx, execution)
+}
+
+// SubmitBatchScrapeTask queues every distinct URL of a batch on the scraper pool and submits a
+// task that collects their jobs and per-URL errors into one result. URLs refused for their
+// domain's rate limit are queued again by the task at the limit's pace; other refusals are
+// recorded as failed items. The batch is only rejected when every URL was refused outright.
+func (tm *TaskManagerImpl) SubmitBatchScrapeTask(ctx context.Context, processID string, request models.BatchScrapeRequest, priority TaskPriority, poolManager *workers.PoolManager) error {
+	if err := tm.accepting(); err != nil {
+		return err
+	}
+	if len(request.URLs) == 0 {
+		return utils.NewValidationError("at least one URL is required")
+	}
+	if maxURLs := tm.config.BackgroundTasks.MaxBatchURLs; maxURLs > 0 && len(request.URLs) > maxURLs {
+		return utils.NewValidationError(fmt.Sprintf("a batch accepts at most %d URLs, got %d", maxURLs, len(request.URLs)))
+	}
+
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeBatch)
+
+	// Each distinct URL is scraped once. Firecrawl batches skip the scraper pool and are scraped
+	// as one Firecrawl job by the task; shared batches queue their URLs on the scraper pool of the
+	// replica that takes them.
+	urls, targets := dedupeURLs(request.URLs)
+	firecrawlBatch := tm.useFirecrawlBatch(request.Options, len(urls))
+	var handles []*workers.JobHandle
+	var enqueueErrs []error
+	if !firecrawlBatch && tm.stream == nil {
+		var err error
+		handles, enqueueErrs, err = enqueueBatchURLs(taskCtx, urls, request.Options, priority, poolManager)
+		if err != nil {
+			cancelFunc()
+			return err
+		}
+	}
+
+	result := &TaskResult{
+		ProcessID: processID,
+		Type:      TaskTypeBatch,
+		Status:    TaskStatusAccepted,
+		CreatedAt: time.Now(),
+		Metadata: map[string]interface{}{
+			"url_count":   len(request.URLs),
+			"unique_urls": len(urls),
+			"engine":      getEngineFromOptions(request.Options),
+		},
+	}
+	if firecrawlBatch {
+		result.Metadata["firecrawl_batch"] = true
+	}
+	if err := tm.store.Store(ctx, result); err != nil {
+		cancelFunc()
+		return fmt.Errorf("failed to store task result: %w", err)
+	}
+
+	tm.logger.LogTaskAccepted(processID, TaskTypeBatch)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeBatch, Status: TaskStatusAccepted})
+
+	execution := &TaskExecution{
+		ProcessID: processID,
+		Type:      TaskTypeBatch,
+		Priority:  priority,
+		Context:   taskCtx,
+		Cancel:    cancelFunc,
+		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
+			if firecrawlBatch {
+				return tm.executeFirecrawlBatchTask(execCtx, processID, request, urls, targets)
+			}
+			return tm.executeBatchScrapeTask(execCtx, processID, request, priority, urls, targets, handles, enqueueErrs, poolManager)
+		},
+		CompletedChan: make(chan *TaskResult, 1),
+	}
+	if firecrawlBatch {
+		execution.Retry = tm.retryPolicy(TaskTypeBatch)
+	}
+	if tm.stream != nil {
+		execution.Request = request
+		return tm.enqueue(ctx, execution)
+	}
+
+	// Like single URL scrapes, the batch waits on its job handles, or on Firecrawl, outside the
+	// task worker pool
+	tm.wg.Add(1)
+	go tm.awaitTask(execution)
+	return nil
+}
+
+// enqueueBatchURLs queues the distinct URLs of a batch on the scraper pool, returning the job of
+// every URL queued and the error of every URL refused. URLs turned away by their domain's rate
+// limit are queued again, paced to the limit, once the task runs; the batch is only rejected
+// when every URL was refused outright.
+func enqueueBatchURLs(ctx context.Context, urls []string, options *models.ScrapeOptions, priority TaskPriority, poolManager *workers.PoolManager) ([]*workers.JobHandle, []error, error) {
+	handles := make([]*workers.JobHandle, len(urls))
+	enqueueErrs := make([]error, len(urls))
+	queued, throttled := 0, 0
+	for i, url := range urls {
+		handle, err := poolManager.EnqueueWithPriority(ctx, url, options, priority.jobPriority())
+		if err != nil {
+			enqueueErrs[i] = err
+			if utils.IsErrorCode(err, utils.ErrCodeRateLimited) {
+				throttled++
+			}
+			continue
+		}
+		handles[i] = handle
+		queued++
+	}
+	if queued == 0 && throttled == 0 {
+		return nil, nil, enqueueErrs[0]
+	}
+	return handles, enqueueErrs, nil
+}
+
+// SubmitCrawlTask submits a crawl of a careers page. The task discovers the page's job links,
+// submits a scrape task for each of them and aggregates their results; like a batch, it waits
+// on the children's scraper pool jobs outside the task worker pool.
+func (tm *TaskManagerImpl) SubmitCrawlTask(ctx context.Context, processID string, request models.CrawlRequest, priority TaskPriority, crawler *crawl.Crawler, poolManager *workers.PoolManager) error {
+	if err := tm.accepting(); err != nil {
+		return err
+	}
+
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeCrawl)
+
+	result := &TaskResult{
+		ProcessID: processID,
+		Type:      TaskTypeCrawl,
+		Status:    TaskStatusAccepted,
+		CreatedAt: time.Now(),
+		Metadata: map[string]interface{}{
+			"url":    request.URL,
+			"engine": getEngineFromOptions(request.Options),
+		},
+	}
+	if err := tm.store.Store(ctx, result); err != nil {
+		cancelFunc()
+		return fmt.Errorf("failed to store task result: %w", err)
+	}
+
+	tm.logger.LogTaskAccepted(processID, TaskTypeCrawl)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeCrawl, Status: TaskStatusAccepted})
+
+	execution := &TaskExecution{
+		ProcessID: processID,
+		Type:      TaskTypeCrawl,
+		Priority:  priority,
+		Context:   taskCtx,
+		Cancel:    cancelFunc,
+		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
+			return tm.executeCrawlTask(execCtx, processID, request, crawler, poolManager)
+		},
+		CompletedChan: make(chan *TaskResult, 1),
+	}
+	if tm.useFirecrawlCrawl(request.Options) {
+		// Firecrawl crawls the site and reports its pages to the webhook, which completes the task
+		execution.ExecuteFunc = func(execCtx context.Context) (*TaskResult, error) {
+			return tm.startFirecrawlCrawl(execCtx, execution, request, crawler)
+		}
+	}
+	if tm.stream != nil {
+		execution.Request = request
+		return tm.enqueue(ctx, execution)
 	}
+
+	tm.wg.Add(1)
+	go tm.awaitTask(execution)
+	return nil
 }
 
 // SubmitTailorTask submits a tailor task for background processing
-func (tm *TaskManagerImpl) SubmitTailorTask(ctx context.Context, processID string, request models.TailorResumeRequest, llmManager *llm.Manager, cfg *config.Config) error {
-	if !tm.IsHealthy() {
-		return fmt.Errorf("task manager is not healthy")
+func (tm *TaskManagerImpl) SubmitTailorTask(ctx context.Context, processID string, request models.TailorResumeRequest, priority TaskPriority, llmManager *llm.Manager, cfg *config.Config) error {
+	if err := tm.accepting(); err != nil {
+		return err
 	}
 
 	// Create task result
@@ -365,35 +727,79 @@ func (tm *TaskManagerImpl) SubmitTailorTask(ctx context.Context, processID strin
 
 	// Log task acceptance
 	tm.logger.LogTaskAccepted(processID, TaskTypeTailor)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeTailor, Status: TaskStatusAccepted})
 
 	// Create task execution with derived context for better isolation
-	taskCtx, cancelFunc := context.WithCancel(tm.ctx)
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeTailor)
 	execution := &TaskExecution{
 		ProcessID: processID,
 		Type:      TaskTypeTailor,
+		Priority:  priority,
 		Context:   taskCtx, // Use derived context for task isolation
 		Cancel:    cancelFunc,
 		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
 			return tm.executeTailorTask(execCtx, processID, request, llmManager, cfg)
 		},
 		CompletedChan: make(chan *TaskResult, 1),
+		Retry:         tm.retryPolicy(TaskTypeTailor),
+		Request:       request,
 	}
 
 	// Submit to worker pool
-	select {
-	case tm.taskChan <- execution:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return fmt.Errorf("task queue is full")
+	return tm.enqueue(ctx, execution)
+}
+
+// SubmitInterviewTask submits an interview questions task for background processing
+func (tm *TaskManagerImpl) SubmitInterviewTask(ctx context.Context, processID string, request models.InterviewQuestionsRequest, priority TaskPriority, llmManager *llm.Manager) error {
+	if err := tm.accepting(); err != nil {
+		return err
+	}
+
+	// Create task result
+	result := &TaskResult{
+		ProcessID: processID,
+		Type:      TaskTypeInterview,
+		Status:    TaskStatusAccepted,
+		CreatedAt: time.Now(),
+		Metadata: map[string]interface{}{
+			"resume_id": request.ResumeID,
+			"job_title": request.Job.Title,
+			"company":   request.Job.CompanyName,
+		},
+	}
+
+	// Store initial task result
+	if err := tm.store.Store(ctx, result); err != nil {
+		return fmt.Errorf("failed to store task result: %w", err)
+	}
+
+	// Log task acceptance
+	tm.logger.LogTaskAccepted(processID, TaskTypeInterview)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeInterview, Status: TaskStatusAccepted})
+
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeInterview)
+	execution := &TaskExecution{
+		ProcessID: processID,
+		Type:      TaskTypeInterview,
+		Priority:  priority,
+		Context:   taskCtx,
+		Cancel:    cancelFunc,
+		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
+			return tm.executeInterviewTask(execCtx, processID, request, llmManager)
+		},
+		CompletedChan: make(chan *TaskResult, 1),
+		Retry:         tm.retryPolicy(TaskTypeInterview),
+		Request:       request,
 	}
+
+	// Submit to worker pool
+	return tm.enqueue(ctx, execution)
 }
 
 // SubmitScreenshotTask submits a screenshot task for background processing
-func (tm *TaskManagerImpl) SubmitScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, cfg *config.Config) error {
-	if !tm.IsHealthy() {
-		return fmt.Errorf("task manager is not healthy")
+func (tm *TaskManagerImpl) SubmitScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, priority TaskPriority, cfg *config.Config) error {
+	if err := tm.accepting(); err != nil {
+		return err
 	}
 
 	// Create task result
@@ -414,50 +820,46 @@ func (tm *TaskManagerImpl) SubmitScreenshotTask(ctx context.Context, processID s
 
 	// Log task acceptance
 	tm.logger.LogTaskAccepted(processID, TaskTypeScreenshot)
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventAccepted, ProcessID: processID, Type: TaskTypeScreenshot, Status: TaskStatusAccepted})
 
 	// Create task execution with derived context for better isolation
-	taskCtx, cancelFunc := context.WithCancel(tm.ctx)
+	taskCtx, cancelFunc := tm.newTaskContext(ctx, processID, TaskTypeScreenshot)
 	execution := &TaskExecution{
 		ProcessID: processID,
 		Type:      TaskTypeScreenshot,
+		Priority:  priority,
 		Context:   taskCtx, // Use derived context for task isolation
 		Cancel:    cancelFunc,
 		ExecuteFunc: func(execCtx context.Context) (*TaskResult, error) {
 			return tm.executeScreenshotTask(execCtx, processID, request, cfg)
 		},
 		CompletedChan: make(chan *TaskResult, 1),
+		Retry:         tm.retryPolicy(TaskTypeScreenshot),
+		Request:       request,
 	}
 
 	// Submit to worker pool
-	select {
-	case tm.taskChan <- execution:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return fmt.Errorf("task queue is full")
-	}
+	return tm.enqueue(ctx, execution)
 }
 
 // GetTaskResult retrieves the result of a task by process ID
 func (tm *TaskManagerImpl) GetTaskResult(ctx context.Context, processID string) (*TaskResult, error) {
-	return tm.store.Get(ctx, processID)
+	result, err := tm.store.Get(ctx, processID)
+	if errors.Is(err, ErrTaskNotFound) {
+		return tm.scheduledResult(ctx, processID)
+	}
+	return result, err
 }
 
 // GetTaskStatus retrieves the status of a task by process ID
 func (tm *TaskManagerImpl) GetTaskStatus(ctx context.Context, processID string) (TaskStatus, error) {
-	result, err := tm.store.Get(ctx, processID)
+	result, err := tm.GetTaskResult(ctx, processID)
 	if err != nil {
 		return "", err
 	}
 	return result.Status, nil
 }
 
-// ListTasks lists all active tasks (for monitoring)
-func (tm *TaskManagerImpl) ListTasks(ctx context.Context) ([]*TaskResult, error) {
-	return tm.store.List(ctx)
-}
-
 // IsHealthy checks if the task manager is healthy
 func (tm *TaskManagerImpl) IsHealthy() bool {
 	tm.mu.RLock()
@@ -465,7 +867,68 @@ func (tm *TaskManagerImpl) IsHealthy() bool {
 	return tm.running && tm.ctx.Err() == nil
 }
 
-// worker processes tasks from the task channel
+// GetStats returns queue utilisation and task counts by status and type
+func (tm *TaskManagerImpl) GetStats() map[string]interface{} {
+	tm.mu.RLock()
+	running := tm.running
+	tm.mu.RUnlock()
+
+	stats := map[string]interface{}{
+		"running":            running,
+		"max_workers":        tm.maxWorkers,
+		"active_workers":     len(tm.workerPool),
+		"queue_length":       tm.queue.Len(),
+		"queue_capacity":     tm.maxQueueSize,
+		"queued_by_priority": tm.queue.LenByPriority(),
+		"awaiting_jobs":      atomic.LoadInt64(&tm.awaitingJobs),
+		"drain_rate":         tm.completions.Rate(),
+		"queue_backend":      TaskQueueMemory,
+		"draining":           tm.isDraining(),
+	}
+	if tm.stream != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		byPriority, err := tm.stream.LenByPriority(ctx)
+		cancel()
+		stats["queue_backend"] = TaskQueueRedis
+		if err == nil {
+			total := 0
+			for _, depth := range byPriority {
+				total += depth
+			}
+			stats["queue_length"] = total
+			stats["queued_by_priority"] = byPriority
+		}
+	}
+
+	tasks, err := tm.store.List(context.Background())
+	if err != nil {
+		return stats
+	}
+
+	byStatus := make(map[string]int64)
+	byType := make(map[string]int64)
+	var completed, failed int64
+	for _, task := range tasks {
+		byStatus[string(task.Status)]++
+		byType[string(task.Type)]++
+		switch task.Status {
+		case TaskStatusSuccess:
+			completed++
+		case TaskStatusFailure:
+			failed++
+		}
+	}
+
+	stats["tasks_by_status"] = byStatus
+	stats["tasks_by_type"] = byType
+	stats["total_requests"] = completed + failed
+	stats["successful_requests"] = completed
+	stats["failed_requests"] = failed
+
+	return stats
+}
+
+// worker processes tasks from the task queue, highest priority first
 func (tm *TaskManagerImpl) worker(workerID int) {
 	defer tm.wg.Done()
 
@@ -474,63 +937,71 @@ func (tm *TaskManagerImpl) worker(workerID int) {
 	})
 
 	for {
-		select {
-		case <-tm.ctx.Done():
+		task, ok := tm.queue.Pop(tm.ctx)
+		if !ok {
 			tm.appLogger.Info("Task worker stopping", map[string]interface{}{
 				"worker_id": workerID,
 			})
 			return
-		case task, ok := <-tm.taskChan:
-			if !ok {
-				tm.appLogger.Info("Task channel closed, worker stopping", map[string]interface{}{
-					"worker_id": workerID,
-				})
-				return
-			}
-
-			tm.processTask(workerID, task)
 		}
+
+		tm.processTask(workerID, task)
 	}
 }
 
 // processTask processes a single task
 func (tm *TaskManagerImpl) processTask(workerID int, task *TaskExecution) {
 	startTime := time.Now()
+	logger := logging.FromContext(task.Context).WithField("worker_id", workerID)
+	tm.trackTask(task)
 
-	tm.appLogger.Info("Processing task", map[string]interface{}{
-		"worker_id":  workerID,
-		"process_id": task.ProcessID,
-		"task_type":  task.Type,
-	})
+	logger.Info("Processing task")
 
 	// Update task status to processing
 	if err := tm.updateTaskStatus(task.ProcessID, TaskStatusProcessing); err != nil {
-		tm.appLogger.Error("Failed to update task status to processing", map[string]interface{}{
+		logger.Error("Failed to update task status to processing", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
 	// Log task start
 	tm.logger.LogTaskStart(task.ProcessID, task.Type)
+	tm.publishEvent(task.Context, &TaskEvent{Event: TaskEventProcessing, ProcessID: task.ProcessID, Type: task.Type, Status: TaskStatusProcessing})
 
-	// Execute the task
-	result, err := task.ExecuteFunc(task.Context)
+	// Execute the task, again for transient failures its retry policy allows
+	result, err := tm.executeWithRetry(task)
+	if errors.Is(err, errTaskDetached) {
+		// Completed later by whatever the task handed its work to
+		return
+	}
+	tm.completeTask(task, startTime, result, err)
+}
+
+// completeTask records the outcome of a task: it stores the final result, publishes the
+// completion event, logs the completion and releases the task context
+func (tm *TaskManagerImpl) completeTask(task *TaskExecution, startTime time.Time, result *TaskResult, err error) {
 	processingTime := time.Since(startTime)
+	logger := logging.FromContext(task.Context)
+	defer tm.untrackTask(task.ProcessID)
+
+	// The task context may be cancelled by now, which must not keep the outcome from being recorded
+	ctx := context.WithoutCancel(task.Context)
+	if err != nil && errors.Is(context.Cause(task.Context), errTaskAbandoned) {
+		err = errTaskAbandoned
+	}
 
 	if err != nil {
 		// Task failed
-		tm.appLogger.Error("Task execution failed", map[string]interface{}{
-			"worker_id":       workerID,
-			"process_id":      task.ProcessID,
-			"task_type":       task.Type,
+		logger.Error("Task execution failed", map[string]interface{}{
 			"processing_time": processingTime,
 			"error":           err.Error(),
+			"error_code":      utils.GetErrorCode(err),
 		})
 
 		// Retrieve existing task result to preserve original CreatedAt
-		existingResult, getErr := tm.store.Get(task.Context, task.ProcessID)
+		existingResult, getErr := tm.store.Get(ctx, task.ProcessID)
 		if getErr != nil {
-			tm.appLogger.Error("Failed to retrieve existing task result for failure update", map[string]interface{}{
+			logger.Error("Failed to retrieve existing task result for failure update", map[string]interface{}{
 				"error": getErr.Error(),
 			})
 			// Fallback: create new result (preserving old behavior)
@@ -539,6 +1010,7 @@ func (tm *TaskManagerImpl) processTask(workerID int, task *TaskExecution) {
 				Type:           task.Type,
 				Status:         TaskStatusFailure,
 				Error:          err.Error(),
+				ErrorCode:      string(utils.GetErrorCode(err)),
 				CreatedAt:      time.Now(),
 				ProcessingTime: &processingTime,
 			}
@@ -546,6 +1018,7 @@ func (tm *TaskManagerImpl) processTask(workerID int, task *TaskExecution) {
 			// Update existing result with failure data
 			existingResult.Status = TaskStatusFailure
 			existingResult.Error = err.Error()
+			existingResult.ErrorCode = string(utils.GetErrorCode(err))
 			existingResult.ProcessingTime = &processingTime
 			result = existingResult
 		}
@@ -553,10 +1026,7 @@ func (tm *TaskManagerImpl) processTask(workerID int, task *TaskExecution) {
 		tm.logger.LogTaskError(task.ProcessID, task.Type, err)
 	} else {
 		// Task succeeded
-		tm.appLogger.Info("Task execution completed successfully", map[string]interface{}{
-			"worker_id":       workerID,
-			"process_id":      task.ProcessID,
-			"task_type":       task.Type,
+		logger.Info("Task execution completed successfully", map[string]interface{}{
 			"processing_time": processingTime,
 		})
 
@@ -568,64 +1038,247 @@ func (tm *TaskManagerImpl) processTask(workerID int, task *TaskExecution) {
 		tm.logger.LogTaskSuccess(task.ProcessID, task.Type, processingTime)
 	}
 
+	if task.Attempts > 1 {
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]interface{})
+		}
+		result.Metadata["attempts"] = task.Attempts
+	}
+
 	// Store the final result
-	if err := tm.store.Update(task.Context, result); err != nil {
-		tm.appLogger.Error("Failed to store task result", map[string]interface{}{
+	if err := tm.store.Update(ctx, result); err != nil {
+		logger.Error("Failed to store task result", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
+	// Notify subscribers without making them poll the task store
+	completionEvent := &TaskEvent{
+		Event:     TaskEventCompleted,
+		ProcessID: task.ProcessID,
+		Type:      task.Type,
+		Status:    result.Status,
+		Error:     result.Error,
+		ErrorCode: result.ErrorCode,
+		Result:    result,
+	}
+	if result.Status == TaskStatusFailure {
+		completionEvent.Event = TaskEventFailed
+	}
+	tm.publishEvent(ctx, completionEvent)
+
 	// Log structured completion to stdout
 	if err := tm.logger.LogTaskCompletion(result); err != nil {
-		tm.appLogger.Error("Failed to log task completion", map[string]interface{}{
+		logger.Error("Failed to log task completion", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
+	tm.completions.Mark()
+
 	// Cancel the task context to prevent context leaks
 	if task.Cancel != nil {
 		task.Cancel()
 	}
 }
 
-// updateTaskStatus updates the status of a task
-func (tm *TaskManagerImpl) updateTaskStatus(processID string, status TaskStatus) error {
-	result, err := tm.store.Get(context.Background(), processID)
-	if err != nil {
+// enqueue queues a task for the task workers at its priority
+func (tm *TaskManagerImpl) enqueue(ctx context.Context, task *TaskExecution) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
+	if tm.stream != nil {
+		return tm.enqueueShared(ctx, task)
+	}
+	// Queued tasks count as accepted, so a drain waits for them too
+	tm.trackTask(task)
+	if !tm.queue.Push(task) {
+		tm.untrackTask(task.ProcessID)
+		return utils.NewQueueFullError("task queue is full", tm.retryAfter())
+	}
+	return nil
+}
 
-	result.Status = status
-	return tm.store.Update(context.Background(), result)
+// retryAfter estimates how long until the task queue has room, from its recent drain rate
+func (tm *TaskManagerImpl) retryAfter() time.Duration {
+	return metrics.EstimateDrainTime(tm.queueLen(), tm.completions.Rate(), minRetryAfter, maxRetryAfter)
 }
 
-// cleanupRoutine periodically cleans up old task results
-func (tm *TaskManagerImpl) cleanupRoutine() {
-	defer tm.wg.Done()
+// queueLen returns the number of tasks waiting for a task worker; the shared queue also counts
+// the tasks still running
+func (tm *TaskManagerImpl) queueLen() int {
+	if tm.stream == nil {
+		return tm.queue.Len()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	length, _ := tm.stream.Len(ctx)
+	return length
+}
 
-	ticker := time.NewTicker(1 * time.Hour)
-	defer ticker.Stop()
+// errScrapeJobPending marks scrape tasks that stopped waiting while their job was still on the
+// scraper pool
+var errScrapeJobPending = errors.New("scraping job did not complete")
 
-	for {
-		select {
-		case <-tm.ctx.Done():
-			return
-		case <-ticker.C:
-			maxAge := 24 * time.Hour // Keep results for 24 hours
-			if err := tm.store.Cleanup(context.Background(), maxAge); err != nil {
-				tm.appLogger.Error("Failed to cleanup old task results", map[string]interface{}{
-					"error": err.Error(),
-				})
-			}
-		}
-	}
+// awaitTask runs a task that waits on scraper pool jobs or an external service outside the task
+// worker pool, so its waits do not hold a task worker
+func (tm *TaskManagerImpl) awaitTask(task *TaskExecution) {
+	defer tm.wg.Done()
+
+	atomic.AddInt64(&tm.awaitingJobs, 1)
+	defer atomic.AddInt64(&tm.awaitingJobs, -1)
+	tm.processTask(pooledTaskWorkerID, task)
 }
 
-// executeScrapeTask executes a scrape task in the background
-func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, poolManager *workers.PoolManager) (*TaskResult, error) {
-	startTime := time.Now()
+// awaitPooledTask completes a task whose work was queued on the scraper pool; it runs outside the
+// task worker pool because it only waits on the job handle
+func (tm *TaskManagerImpl) awaitPooledTask(task *TaskExecution, handle *workers.JobHandle) {
+	defer tm.wg.Done()
+
+	atomic.AddInt64(&tm.awaitingJobs, 1)
+	defer atomic.AddInt64(&tm.awaitingJobs, -1)
+
+	// A run left behind at its timeout may still report back after processTask returned
+	execute := task.ExecuteFunc
+	var pending atomic.Bool
+	task.ExecuteFunc = func(ctx context.Context) (*TaskResult, error) {
+		result, err := execute(ctx)
+		pending.Store(errors.Is(err, errScrapeJobPending))
+		return result, err
+	}
+
+	tm.processTask(pooledTaskWorkerID, task)
+
+	// The task was finalised as failed without the job's result; record the result once the
+	// worker delivers it so the task store reflects what actually happened
+	if pending.Load() {
+		ctx := context.WithoutCancel(task.Context)
+		handle.OnComplete(func(result workers.JobResult) {
+			tm.recordLateScrapeResult(ctx, task.ProcessID, result)
+		})
+	}
+}
+
+// recordLateScrapeResult updates a scrape task that timed out or was cancelled with the result its
+// job eventually produced
+func (tm *TaskManagerImpl) recordLateScrapeResult(ctx context.Context, processID string, jobResult workers.JobResult) {
+	logger := logging.FromContext(ctx)
 
-	// Retrieve the existing task result to preserve original CreatedAt
+	existingResult, err := tm.store.Get(ctx, processID)
+	if err != nil {
+		logger.Warn("Dropping late scrape result for unknown task", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	engine, _ := existingResult.Metadata["engine"].(string)
+	processingTime := time.Since(existingResult.CreatedAt)
+	existingResult.ProcessingTime = &processingTime
+	if existingResult.Metadata == nil {
+		existingResult.Metadata = map[string]interface{}{}
+	}
+	existingResult.Metadata["late_result"] = true
+	setJobTimings(existingResult, &jobResult)
+	setLLMUsage(existingResult, jobResult.LLMUsage)
+	setAntiBot(existingResult, jobResult.AntiBot)
+	setRoutedEngine(existingResult, jobResult.Routed)
+	archiveScrapePage(ctx, processID, existingResult, jobResult.Page)
+
+	taskData, resultErr := scrapeTaskData(&jobResult, engine)
+	if resultErr != nil {
+		existingResult.Status = TaskStatusFailure
+		existingResult.Error = resultErr.Error()
+		existingResult.ErrorCode = string(utils.GetErrorCode(resultErr))
+	} else {
+		completedAt := time.Now()
+		existingResult.Status = TaskStatusSuccess
+		existingResult.Data = taskData
+		existingResult.Error = ""
+		existingResult.ErrorCode = ""
+		existingResult.CompletedAt = &completedAt
+	}
+
+	if err := tm.store.Update(ctx, existingResult); err != nil {
+		logger.Error("Failed to store late scrape result", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Recorded late scrape result", map[string]interface{}{
+		"status":     existingResult.Status,
+		"error_code": existingResult.ErrorCode,
+	})
+
+	event := &TaskEvent{
+		Event:     TaskEventCompleted,
+		ProcessID: processID,
+		Type:      TaskTypeScrape,
+		Status:    existingResult.Status,
+		Error:     existingResult.Error,
+		ErrorCode: existingResult.ErrorCode,
+		Result:    existingResult,
+	}
+	if existingResult.Status == TaskStatusFailure {
+		event.Event = TaskEventFailed
+	}
+	tm.publishEvent(ctx, event)
+}
+
+// newTaskContext derives a cancellable task context from the manager context that carries
+// a logger scoped to the submitting request, process and task type, and the submitting API key
+func (tm *TaskManagerImpl) newTaskContext(ctx context.Context, processID string, taskType TaskType) (context.Context, context.CancelFunc) {
+	taskCtx, cancel := context.WithCancel(tm.ctx)
+	taskLogger := logging.FromContext(ctx).WithFields(map[string]interface{}{
+		logging.FieldProcessID: processID,
+		logging.FieldTaskType:  string(taskType),
+	})
+	taskCtx = timeline.WithProcessID(taskCtx, processID)
+	taskCtx = quota.WithAPIKey(taskCtx, quota.APIKeyFromContext(ctx))
+	return logging.NewContext(taskCtx, taskLogger), cancel
+}
+
+// updateTaskStatus updates the status of a task
+func (tm *TaskManagerImpl) updateTaskStatus(processID string, status TaskStatus) error {
+	result, err := tm.store.Get(context.Background(), processID)
+	if err != nil {
+		return err
+	}
+
+	result.Status = status
+	return tm.store.Update(context.Background(), result)
+}
+
+// cleanupRoutine periodically cleans up old task results
+func (tm *TaskManagerImpl) cleanupRoutine() {
+	defer tm.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-tm.ctx.Done():
+			return
+		case <-ticker.C:
+			maxAge := 24 * time.Hour // Keep results for 24 hours
+			if err := tm.store.Cleanup(context.Background(), maxAge); err != nil {
+				tm.appLogger.Error("Failed to cleanup old task results", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			timeline.GetGlobalRecorder().Cleanup(maxAge)
+		}
+	}
+}
+
+// executeScrapeTask executes a scrape task in the background
+func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID string, request models.ScrapeRequest, handle *workers.JobHandle) (*TaskResult, error) {
+	startTime := time.Now()
+	logger := logging.FromContext(ctx)
+
+	// Retrieve the existing task result to preserve original CreatedAt
 	existingResult, err := tm.store.Get(ctx, processID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
@@ -634,11 +1287,12 @@ func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID stri
 	// Determine processing mode
 	var taskData interface{}
 	var engine string
+	var jobResult *workers.JobResult
+	var descriptionUsage *cost.Tally
 
 	if request.Description != "" {
 		// Process description directly with LLM - no scraping needed
-		tm.appLogger.Info("Processing job description directly with LLM", map[string]interface{}{
-			"process_id":         processID,
+		logger.Info("Processing job description directly with LLM", map[string]interface{}{
 			"description_length": len(request.Description),
 		})
 
@@ -648,7 +1302,8 @@ func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID stri
 		}
 
 		// Process the description directly using the shared LLM manager
-		job, err := tm.llmManager.ExtractJobFromDescription(ctx, request.Description)
+		descriptionUsage = cost.NewTally()
+		job, err := tm.llmManager.ExtractJobFromDescription(cost.WithTally(ctx, descriptionUsage), request.Description)
 		if err != nil {
 			return nil, fmt.Errorf("failed to process job description: %w", err)
 		}
@@ -661,37 +1316,34 @@ func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID stri
 		engine = "description_llm"
 
 	} else {
-		// Execute the scraping job using the existing worker pool
-		result, err := poolManager.SubmitJob(ctx, request.URL, request.Options)
+		// Wait for the scraping job queued on the worker pool at submission
+		result, err := handle.Wait(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to submit scraping job: %w", err)
+			return nil, fmt.Errorf("%w: %w", errScrapeJobPending, err)
 		}
 
+		jobResult = result
+
 		// Determine engine used
 		engine = getEngineFromOptions(request.Options)
 
-		if result.Error != nil {
-			// Scraping failed
-			return nil, result.Error
-		}
-
-		// Scraping succeeded - create appropriate task data
-		if result.UsedLLM && result.Job != nil {
-			// New LLM-processed job
-			taskData = &ScrapeTaskData{
-				Job:     result.Job,
-				Engine:  engine + "_llm",
-				UsedLLM: true,
-			}
-		} else if result.JobPosting != nil {
-			// Legacy job posting
-			taskData = &ScrapeTaskData{
-				JobPosting: result.JobPosting,
-				Engine:     engine + "_legacy",
-				UsedLLM:    false,
+		taskData, err = scrapeTaskData(result, engine)
+		if err != nil {
+			// Keep the stage timings, LLM usage and blocks of failed jobs too; the failure update
+			// preserves stored metadata
+			timed := setJobTimings(existingResult, result)
+			setLLMUsage(existingResult, result.LLMUsage)
+			setAntiBot(existingResult, result.AntiBot)
+			setRoutedEngine(existingResult, result.Routed)
+			archived := archiveScrapePage(ctx, processID, existingResult, result.Page)
+			if timed || result.LLMUsage != nil || result.AntiBot != nil || result.Routed != "" || archived {
+				if updateErr := tm.store.Update(ctx, existingResult); updateErr != nil {
+					logger.Warn("Failed to store job timings", map[string]interface{}{
+						"error": updateErr.Error(),
+					})
+				}
 			}
-		} else {
-			return nil, fmt.Errorf("job processing completed but no data was returned")
+			return nil, err
 		}
 	}
 
@@ -706,13 +1358,541 @@ func (tm *TaskManagerImpl) executeScrapeTask(ctx context.Context, processID stri
 		"engine":      engine,
 		"mode":        getProcessingModeFromRequest(request),
 	}
+	if jobResult != nil {
+		setJobTimings(existingResult, jobResult)
+		setLLMUsage(existingResult, jobResult.LLMUsage)
+		setAntiBot(existingResult, jobResult.AntiBot)
+		setRoutedEngine(existingResult, jobResult.Routed)
+		archiveScrapePage(ctx, processID, existingResult, jobResult.Page)
+		if jobResult.Cached {
+			existingResult.Metadata["cached"] = true
+		}
+		if jobResult.Shared {
+			existingResult.Metadata["coalesced"] = true
+		}
+	}
+	if descriptionUsage != nil {
+		setLLMUsage(existingResult, descriptionUsage.Metadata())
+	}
+
+	return existingResult, nil
+}
+
+// setJobTimings records the per-stage timings of a worker pool job under "timings_ms" in the
+// task metadata, reporting whether there were any
+func setJobTimings(result *TaskResult, jobResult *workers.JobResult) bool {
+	timings := timing.Milliseconds(jobResult.Timings)
+	if timings == nil {
+		return false
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = map[string]interface{}{}
+	}
+	result.Metadata["timings_ms"] = timings
+	return true
+}
+
+// setLLMUsage records the LLM tokens and estimated cost of a task under "llm_usage" in the task
+// metadata, which is forwarded in the completion callback
+func setLLMUsage(result *TaskResult, usage map[string]interface{}) {
+	if usage == nil {
+		return
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = map[string]interface{}{}
+	}
+	result.Metadata["llm_usage"] = usage
+}
+
+// setAntiBot records the bot protection blocks a scrape met under "anti_bot" in the task
+// metadata, which is forwarded in the completion callback so the server can decide whether to
+// retry with another engine
+func setAntiBot(result *TaskResult, antiBot map[string]interface{}) {
+	if antiBot == nil {
+		return
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = map[string]interface{}{}
+	}
+	result.Metadata["anti_bot"] = antiBot
+}
+
+// setRoutedEngine records the engine an "auto" scrape was routed to under "routed_engine" in the
+// task metadata
+func setRoutedEngine(result *TaskResult, engine string) {
+	if engine == "" {
+		return
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = map[string]interface{}{}
+	}
+	result.Metadata["routed_engine"] = engine
+}
+
+// archiveScrapePage archives the page a scrape task's job handed to extraction and records its
+// object key under "archive_key" in the task metadata, reporting whether it was archived
+func archiveScrapePage(ctx context.Context, processID string, result *TaskResult, page *archive.Page) bool {
+	key := archive.Key(processID, -1)
+	if !storePage(ctx, key, page) {
+		return false
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = map[string]interface{}{}
+	}
+	result.Metadata["archive_key"] = key
+	return true
+}
+
+// storePage archives page under key, reporting whether it was stored. Archiving is best effort
+// and outlives the task's context, so a scrape that timed out still leaves its page behind.
+func storePage(ctx context.Context, key string, page *archive.Page) bool {
+	pages := archive.GetGlobalArchive()
+	if pages == nil || page == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), archiveTimeout)
+	defer cancel()
+	if err := pages.Store(ctx, key, page); err != nil {
+		logging.FromContext(ctx).Warn("Failed to archive scraped page", map[string]interface{}{
+			"key":   key,
+			"url":   page.URL,
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// scrapeTaskData converts a worker pool job result into scrape task data
+func scrapeTaskData(result *workers.JobResult, engine string) (*ScrapeTaskData, error) {
+	if result.Error != nil {
+		// Scraping failed
+		return nil, result.Error
+	}
+	if result.Routed != "" {
+		engine = result.Routed
+	}
+
+	// Scraping succeeded - create appropriate task data
+	if result.UsedLLM && result.Job != nil {
+		// New LLM-processed job
+		return &ScrapeTaskData{
+			Job:     result.Job,
+			Engine:  engine + "_llm",
+			UsedLLM: true,
+		}, nil
+	}
+	if result.JobPosting != nil {
+		// Legacy job posting
+		return &ScrapeTaskData{
+			JobPosting: result.JobPosting,
+			Engine:     engine + "_legacy",
+			UsedLLM:    false,
+		}, nil
+	}
+	return nil, fmt.Errorf("job processing completed but no data was returned")
+}
+
+// executeBatchScrapeTask waits for every job of a batch and aggregates their results. The batch
+// fails only when no URL produced a job; the per-URL outcomes are kept either way.
+func (tm *TaskManagerImpl) executeBatchScrapeTask(ctx context.Context, processID string, request models.BatchScrapeRequest, priority TaskPriority, urls []string, targets []int, handles []*workers.JobHandle, enqueueErrs []error, poolManager *workers.PoolManager) (*TaskResult, error) {
+	startTime := time.Now()
+
+	existingResult, err := tm.store.Get(ctx, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
+	}
+
+	tm.enqueueThrottled(ctx, urls, handles, enqueueErrs, request.Options, priority, poolManager)
+
+	engine := getEngineFromOptions(request.Options)
+	items := make([]BatchScrapeItem, len(urls))
+	for i, url := range urls {
+		items[i] = BatchScrapeItem{URL: url}
+	}
+	llmUsage := cost.NewTally()
+	awaitScrapeItems(ctx, processID, items, handles, enqueueErrs, engine, llmUsage, tm.progressReporter(ctx, processID, TaskTypeBatch, len(items)))
+
+	return tm.finishBatchScrapeTask(ctx, existingResult, request, items, targets, llmUsage, startTime)
+}
+
+// executeFirecrawlBatchTask scrapes the URLs of a batch as one Firecrawl batch scrape job and
+// extracts the job of every page it returns. URLs the scraping policy refuses are not sent.
+func (tm *TaskManagerImpl) executeFirecrawlBatchTask(ctx context.Context, processID string, request models.BatchScrapeRequest, urls []string, targets []int) (*TaskResult, error) {
+	startTime := time.Now()
+
+	existingResult, err := tm.store.Get(ctx, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
+	}
+
+	items := make([]BatchScrapeItem, len(urls))
+	var allowed []string
+	var indices []int
+	checker := policy.GetGlobalChecker()
+	for i, url := range urls {
+		items[i] = BatchScrapeItem{URL: url}
+		if err := checker.Check(ctx, url); err != nil {
+			failBatchItem(&items[i], err)
+			continue
+		}
+		allowed = append(allowed, url)
+		indices = append(indices, i)
+	}
+
+	llmUsage := cost.NewTally()
+	if len(allowed) > 0 {
+		pages, err := firecrawl.NewBatchClient(tm.config).Scrape(ctx, allowed)
+		if errors.Is(err, firecrawl.ErrServerError) {
+			// Every URL failed with it; failing the task lets its retry policy run the batch again
+			return nil, fmt.Errorf("Firecrawl batch scrape failed: %w", err)
+		}
+		if err != nil {
+			for _, i := range indices {
+				failBatchItem(&items[i], err)
+			}
+		} else {
+			tm.extractBatchPages(ctx, processID, items, indices, pages, request.Options, llmUsage)
+		}
+	}
+
+	return tm.finishBatchScrapeTask(ctx, existingResult, request, items, targets, llmUsage, startTime)
+}
+
+// extractBatchPages extracts the job of every page of a Firecrawl batch into the item at the
+// page's index, a few pages at a time. Pages are archived under processID.
+func (tm *TaskManagerImpl) extractBatchPages(ctx context.Context, processID string, items []BatchScrapeItem, indices []int, pages []firecrawl.BatchPage, options *models.ScrapeOptions, llmUsage *cost.Tally) {
+	extractCtx := cost.WithTally(ctx, llmUsage)
+	if options != nil && options.NoCache {
+		extractCtx = llm.WithCacheBypass(extractCtx)
+	}
+	if options != nil && options.Model != "" {
+		extractCtx = llm.WithModel(extractCtx, options.Model)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchExtractConcurrency)
+	for n, page := range pages {
+		item := &items[indices[n]]
+		if page.Error != "" {
+			failBatchItem(item, utils.NewScrapingError(page.Error))
+			continue
+		}
+
+		wg.Add(1)
+		go func(index int, page firecrawl.BatchPage) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			capture := archive.NewCapture()
+			job, err := tm.llmManager.ExtractJobData(archive.WithCapture(extractCtx, capture), page.Content, page.URL)
+			if storePage(ctx, archive.Key(processID, index), capture.Page()) {
+				item.Archive = archive.Key(processID, index)
+			}
+			if err != nil {
+				failBatchItem(item, err)
+				return
+			}
+			if job.JobURL == "" {
+				job.JobURL = page.URL
+			}
+			item.Job = job
+			item.Engine = "firecrawl"
+		}(indices[n], page)
+	}
+	wg.Wait()
+}
+
+// failBatchItem records why a batch URL produced no job
+func failBatchItem(item *BatchScrapeItem, err error) {
+	item.Error = err.Error()
+	item.ErrorCode = string(utils.GetErrorCode(err))
+}
+
+// useFirecrawlBatch reports whether a batch of count distinct URLs is scraped as one Firecrawl
+// batch scrape job; test mode keeps batches on the stub engines
+func (tm *TaskManagerImpl) useFirecrawlBatch(options *models.ScrapeOptions, count int) bool {
+	batch := tm.config.Firecrawl.Batch
+	return batch.Enabled && !tm.config.TestMode.Enabled && tm.config.Firecrawl.APIKey != "" &&
+		getEngineFromOptions(options) == "firecrawl" && count >= batch.MinURLs
+}
+
+// finishBatchScrapeTask aggregates the items of a batch into its result. Duplicates share the
+// result of the URL they were collapsed into, and the batch fails only when no URL produced a
+// job; the per-URL outcomes are kept either way.
+func (tm *TaskManagerImpl) finishBatchScrapeTask(ctx context.Context, existingResult *TaskResult, request models.BatchScrapeRequest, items []BatchScrapeItem, targets []int, llmUsage *cost.Tally, startTime time.Time) (*TaskResult, error) {
+	data := &BatchScrapeTaskData{
+		Results: make([]BatchScrapeItem, len(request.URLs)),
+		Total:   len(request.URLs),
+	}
+	for i, url := range request.URLs {
+		data.Results[i] = items[targets[i]]
+		data.Results[i].URL = url
+		if data.Results[i].Error == "" {
+			data.Succeeded++
+		}
+	}
+	data.Failed = data.Total - data.Succeeded
+
+	processingTime := time.Since(startTime)
+	existingResult.Data = data
+	existingResult.ProcessingTime = &processingTime
+	if existingResult.Metadata == nil {
+		existingResult.Metadata = map[string]interface{}{}
+	}
+	existingResult.Metadata["succeeded"] = data.Succeeded
+	existingResult.Metadata["failed"] = data.Failed
+	setLLMUsage(existingResult, llmUsage.Metadata())
+
+	if data.Succeeded == 0 {
+		// The failure update keeps the stored result, so store the per-URL errors first
+		if err := tm.store.Update(ctx, existingResult); err != nil {
+			logging.FromContext(ctx).Warn("Failed to store batch scrape results", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return nil, utils.NewScrapingError(fmt.Sprintf("all %d URLs of the batch failed", data.Total))
+	}
 
 	return existingResult, nil
 }
 
+// executeCrawlTask discovers the job links of a careers page, submits a scrape task per link and
+// aggregates their results. The child process IDs are stored as soon as they are submitted so
+// clients can follow the children while the crawl runs. The crawl fails only when no job was
+// scraped.
+func (tm *TaskManagerImpl) executeCrawlTask(ctx context.Context, processID string, request models.CrawlRequest, crawler *crawl.Crawler, poolManager *workers.PoolManager) (*TaskResult, error) {
+	startTime := time.Now()
+	logger := logging.FromContext(ctx)
+
+	existingResult, err := tm.store.Get(ctx, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
+	}
+	// A crawl taken over from a replica that stopped starts over; the children that replica
+	// left unfinished are failed
+	tm.abandonCrawlChildren(ctx, existingResult)
+
+	discovery, err := crawler.Discover(ctx, request.URL, tm.crawlMaxJobs(request), request.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := getEngineFromOptions(request.Options)
+	data := &CrawlTaskData{
+		URL:     request.URL,
+		Sources: discovery.Sources,
+		Results: make([]BatchScrapeItem, len(discovery.URLs)),
+		Total:   len(discovery.URLs),
+	}
+	handles := make([]*workers.JobHandle, len(discovery.URLs))
+	submitErrs := make([]error, len(discovery.URLs))
+	childPriority := ScrapeTaskPriority(request.Options, TaskPriorityBulk)
+	// A careers site is usually one domain, whose rate limit turns away all but the first few
+	// children; like batch URLs, they are queued at the limit's pace within the task timeout
+	deadline := time.Now().Add(tm.taskTimeout(TaskTypeCrawl))
+	for i, url := range discovery.URLs {
+		childID := utils.GenerateScrapeProcessID()
+		handle, err := tm.submitScrapeTask(ctx, childID, models.ScrapeRequest{URL: url, Options: request.Options}, childPriority, poolManager, false, time.Until(deadline))
+		if err != nil {
+			logger.Warn("Failed to submit scrape task for crawled job", map[string]interface{}{
+				"url":   url,
+				"error": err.Error(),
+			})
+			submitErrs[i] = err
+			childID = ""
+		}
+		data.Results[i] = BatchScrapeItem{URL: url, ProcessID: childID}
+		handles[i] = handle
+	}
+
+	if existingResult.Metadata == nil {
+		existingResult.Metadata = map[string]interface{}{}
+	}
+	existingResult.Metadata["job_count"] = data.Total
+	// The results are filled in while the children run, so the stored progress gets its own copy
+	progress := *data
+	progress.Results = append([]BatchScrapeItem(nil), data.Results...)
+	existingResult.Data = &progress
+	if err := tm.store.Update(ctx, existingResult); err != nil {
+		logger.Warn("Failed to store crawl progress", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	llmUsage := cost.NewTally()
+	// Crawled jobs are child scrape tasks, which archive their own pages
+	data.Succeeded = awaitScrapeItems(ctx, "", data.Results, handles, submitErrs, engine, llmUsage, tm.progressReporter(ctx, processID, TaskTypeCrawl, data.Total))
+	data.Failed = data.Total - data.Succeeded
+
+	processingTime := time.Since(startTime)
+	existingResult.Data = data
+	existingResult.ProcessingTime = &processingTime
+	existingResult.Metadata["succeeded"] = data.Succeeded
+	existingResult.Metadata["failed"] = data.Failed
+	setLLMUsage(existingResult, llmUsage.Metadata())
+
+	if data.Succeeded == 0 {
+		// The failure update keeps the stored result, so store the per-job errors first
+		if err := tm.store.Update(ctx, existingResult); err != nil {
+			logger.Warn("Failed to store crawl results", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return nil, utils.NewScrapingError(fmt.Sprintf("all %d jobs found at %s failed", data.Total, request.URL))
+	}
+
+	return existingResult, nil
+}
+
+// crawlMaxJobs returns how many jobs a crawl scrapes at most: the request's limit, capped by the
+// configured one
+func (tm *TaskManagerImpl) crawlMaxJobs(request models.CrawlRequest) int {
+	maxJobs := tm.config.Crawl.MaxJobs
+	if request.MaxJobs > 0 && (maxJobs <= 0 || request.MaxJobs < maxJobs) {
+		maxJobs = request.MaxJobs
+	}
+	return maxJobs
+}
+
+// enqueueThrottled queues again the batch URLs their domain's rate limit turned away at
+// submission, pacing each domain's URLs to its limit. Domains are paced concurrently, so one
+// slow domain does not hold back the others, and no URL waits longer than the task timeout.
+func (tm *TaskManagerImpl) enqueueThrottled(ctx context.Context, urls []string, handles []*workers.JobHandle, enqueueErrs []error, options *models.ScrapeOptions, priority TaskPriority, poolManager *workers.PoolManager) {
+	byDomain := make(map[string][]int)
+	for i, err := range enqueueErrs {
+		if err != nil && utils.IsErrorCode(err, utils.ErrCodeRateLimited) {
+			domain := urlDomain(urls[i])
+			byDomain[domain] = append(byDomain[domain], i)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, indices := range byDomain {
+		wg.Add(1)
+		go func(indices []int) {
+			defer wg.Done()
+			for _, i := range indices {
+				handles[i], enqueueErrs[i] = poolManager.EnqueuePaced(ctx, urls[i], options, priority.jobPriority(), tm.taskTimeout(TaskTypeBatch))
+			}
+		}(indices)
+	}
+	wg.Wait()
+}
+
+// dedupeURLs collapses URLs that point to the same page, returning the distinct URLs and, for
+// every requested URL, the index of the distinct URL it was collapsed into
+func dedupeURLs(urls []string) (unique []string, targets []int) {
+	targets = make([]int, len(urls))
+	seen := make(map[string]int, len(urls))
+	for i, rawURL := range urls {
+		key := utils.CanonicalURL(rawURL)
+		index, ok := seen[key]
+		if !ok {
+			index = len(unique)
+			seen[key] = index
+			unique = append(unique, strings.TrimSpace(rawURL))
+		}
+		targets[i] = index
+	}
+	return unique, targets
+}
+
+// urlDomain returns the lowercased host of rawURL, the key the scraper pool rate limits by
+func urlDomain(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// awaitScrapeItems waits for the scraper pool job of every queued item and records its job or
+// error on the item, returning how many items produced a job. Pages are archived under processID
+// unless it is empty, and progress is called with the number of items finished after each one.
+func awaitScrapeItems(ctx context.Context, processID string, items []BatchScrapeItem, handles []*workers.JobHandle, enqueueErrs []error, engine string, llmUsage *cost.Tally, progress func(completed int)) int {
+	succeeded := 0
+	for i := range items {
+		item := &items[i]
+		itemErr := enqueueErrs[i]
+		if itemErr == nil {
+			jobResult, waitErr := handles[i].Wait(ctx)
+			if waitErr != nil {
+				itemErr = fmt.Errorf("%w: %w", errScrapeJobPending, waitErr)
+			} else {
+				addLLMUsage(llmUsage, jobResult.LLMUsage)
+				if processID != "" && storePage(ctx, archive.Key(processID, i), jobResult.Page) {
+					item.Archive = archive.Key(processID, i)
+				}
+				taskData, resultErr := scrapeTaskData(jobResult, engine)
+				if resultErr != nil {
+					itemErr = resultErr
+					item.Block, _ = jobResult.AntiBot["block"].(string)
+				} else {
+					item.Job = taskData.Job
+					item.JobPosting = taskData.JobPosting
+					item.Engine = taskData.Engine
+				}
+			}
+		}
+
+		if itemErr != nil {
+			failBatchItem(item, itemErr)
+		} else {
+			succeeded++
+		}
+		progress(i + 1)
+	}
+	return succeeded
+}
+
+// progressReporter returns a function publishing the progress of a batch or crawl of total items
+func (tm *TaskManagerImpl) progressReporter(ctx context.Context, processID string, taskType TaskType, total int) func(completed int) {
+	return func(completed int) {
+		tm.publishEvent(ctx, &TaskEvent{
+			Event:     TaskEventProgress,
+			ProcessID: processID,
+			Type:      taskType,
+			Status:    TaskStatusProcessing,
+			Progress:  &TaskProgress{Completed: completed, Total: total},
+		})
+	}
+}
+
+// addLLMUsage adds the LLM usage metadata of a worker pool job to a tally
+func addLLMUsage(tally *cost.Tally, usage map[string]interface{}) {
+	if usage == nil {
+		return
+	}
+
+	provider, _ := usage["provider"].(string)
+	model, _ := usage["model"].(string)
+	calls, _ := usage["calls"].(int64)
+	inputTokens, _ := usage["input_tokens"].(int64)
+	outputTokens, _ := usage["output_tokens"].(int64)
+	costUSD, _ := usage["cost_usd"].(float64)
+	if escalatedFrom, _ := usage["escalated_from"].(string); escalatedFrom != "" {
+		tally.Escalate(escalatedFrom)
+	}
+	tally.Add(provider, model, cost.Usage{
+		Calls:        calls,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		CostUSD:      costUSD,
+	})
+}
+
 // executeTailorTask executes a tailor task in the background
 func (tm *TaskManagerImpl) executeTailorTask(ctx context.Context, processID string, request models.TailorResumeRequest, llmManager *llm.Manager, cfg *config.Config) (*TaskResult, error) {
 	startTime := time.Now()
+	logger := logging.FromContext(ctx)
 
 	// Retrieve the existing task result to preserve original CreatedAt
 	existingResult, err := tm.store.Get(ctx, processID)
@@ -725,68 +1905,77 @@ func (tm *TaskManagerImpl) executeTailorTask(ctx context.Context, processID stri
 		return nil, fmt.Errorf("LLM manager is not healthy")
 	}
 
-	// Initialize Redis client for conversation history (optional)
-	var redisClient *utils.RedisClient
-	var redisAvailable bool
-
-	// Try to initialize Redis, but don't fail if it's not available
-	func() {
-		defer func() {
-			if r := recover(); r != nil {
-				tm.appLogger.Warn("Redis initialization failed - continuing without conversation history", map[string]interface{}{
-					"process_id": processID,
-					"error":      fmt.Sprintf("%v", r),
-				})
-			}
-		}()
-
-		redisClient = utils.NewRedisClient(cfg)
-		if err := redisClient.Ping(ctx); err != nil {
-			tm.appLogger.Warn("Redis connection failed - conversation history will not be saved", map[string]interface{}{
-				"process_id": processID,
-				"error":      err.Error(),
+	// Use the shared conversation store for history (optional)
+	tm.mu.RLock()
+	conversations := tm.conversations
+	tm.mu.RUnlock()
+	historyAvailable := false
+	if conversations != nil {
+		if err := conversations.Ping(ctx); err != nil {
+			logger.Warn("Conversation store unavailable - conversation history will not be saved", map[string]interface{}{
+				"backend": conversations.Backend(),
+				"error":   err.Error(),
 			})
-			if redisClient != nil {
-				redisClient.Close()
-				redisClient = nil
-			}
 		} else {
-			redisAvailable = true
-			tm.appLogger.Debug("Redis connection successful", map[string]interface{}{
-				"process_id": processID,
-			})
+			historyAvailable = true
 		}
-	}()
-
-	// Only use Redis if it's available
-	if redisAvailable && redisClient != nil {
-		defer redisClient.Close()
+	}
 
+	// Only record history if the store is available; retries of the task already recorded the
+	// user turns on their first attempt
+	if historyAvailable && taskAttempt(ctx) == 1 {
 		// Create conversation thread with resumeID as threadID
-		if err := redisClient.CreateConversationThread(ctx, request.ResumeID); err != nil {
-			tm.appLogger.Warn("Failed to create conversation thread - continuing without history", map[string]interface{}{
-				"process_id": processID,
-				"resume_id":  request.ResumeID,
-				"error":      err.Error(),
+		if err := conversations.CreateConversationThread(ctx, request.ResumeID); err != nil {
+			logger.Warn("Failed to create conversation thread - continuing without history", map[string]interface{}{
+				"resume_id": request.ResumeID,
+				"error":     err.Error(),
 			})
 		}
 
-		// Store conversation history entries...
-		// (Similar to the existing handler logic)
+		// Record the user turn: the base resume to tailor and the job it is tailored for
+		if baseResumeJSON, err := json.Marshal(request.BaseResume); err == nil {
+			tm.storeConversationEntry(ctx, conversations, request.ResumeID, utils.ConversationEntry{
+				Role:    "user",
+				Content: string(baseResumeJSON),
+				Metadata: map[string]interface{}{
+					"type":           llm.EntryTypeBaseResume,
+					"process_id":     processID,
+					"base_resume_id": request.BaseResume.ID,
+				},
+			})
+		}
+		if jobJSON, err := json.Marshal(request.Job); err == nil {
+			tm.storeConversationEntry(ctx, conversations, request.ResumeID, utils.ConversationEntry{
+				Role:    "user",
+				Content: string(jobJSON),
+				Metadata: map[string]interface{}{
+					"type":       llm.EntryTypeJobContext,
+					"process_id": processID,
+					"job_title":  request.Job.Title,
+					"company":    request.Job.CompanyName,
+				},
+			})
+		}
 	}
 
 	// Call LLM to tailor the resume
-	tailoredResume, suggestions, _, err := llmManager.TailorResumeWithRawResponse(ctx, &request.BaseResume, &request.Job)
+	llmUsage := cost.NewTally()
+	tailoredResume, suggestions, rawResponse, err := llmManager.TailorResumeWithRawResponse(llm.WithModel(cost.WithTally(ctx, llmUsage), request.Model), &request.BaseResume, &request.Job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to tailor resume using LLM: %w", err)
 	}
 
-	// Store AI response in conversation history (if Redis is available)
-	if redisAvailable && redisClient != nil {
-		// TODO: Implement conversation history storage in background task
-		tm.appLogger.Debug("Conversation history storage not yet implemented in background task", map[string]interface{}{
-			"process_id": processID,
-			"resume_id":  request.ResumeID,
+	// Store AI response in conversation history (if the store is available)
+	if historyAvailable {
+		tm.storeConversationEntry(ctx, conversations, request.ResumeID, utils.ConversationEntry{
+			Role:    "assistant",
+			Content: rawResponse,
+			Metadata: map[string]interface{}{
+				"type":             llm.EntryTypeTailorResponse,
+				"process_id":       processID,
+				"provider":         llmManager.GetProviderName(),
+				"suggestion_count": len(suggestions),
+			},
 		})
 	}
 
@@ -807,12 +1996,13 @@ func (tm *TaskManagerImpl) executeTailorTask(ctx context.Context, processID stri
 		"job_title": request.Job.Title,
 		"company":   request.Job.CompanyName,
 	}
+	setLLMUsage(existingResult, llmUsage.Metadata())
 
 	return existingResult, nil
 }
 
-// executeScreenshotTask executes a screenshot task in the background
-func (tm *TaskManagerImpl) executeScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, cfg *config.Config) (*TaskResult, error) {
+// executeInterviewTask executes an interview questions task in the background
+func (tm *TaskManagerImpl) executeInterviewTask(ctx context.Context, processID string, request models.InterviewQuestionsRequest, llmManager *llm.Manager) (*TaskResult, error) {
 	startTime := time.Now()
 
 	// Retrieve the existing task result to preserve original CreatedAt
@@ -821,45 +2011,95 @@ func (tm *TaskManagerImpl) executeScreenshotTask(ctx context.Context, processID
 		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
 	}
 
-	tm.appLogger.Info("Starting screenshot generation", map[string]interface{}{
-		"process_id": processID,
-		"resume_id":  request.ResumeID,
-	})
+	if !llmManager.IsHealthy() {
+		return nil, fmt.Errorf("LLM manager is not healthy")
+	}
 
-	// Create screenshot service
-	screenshotService := headed.NewScreenshotService(cfg)
-	defer screenshotService.Cleanup()
+	count := request.QuestionCount
+	if count == 0 {
+		count = models.DefaultInterviewQuestionCount
+	}
 
-	// Check if screenshot service is healthy
-	if !screenshotService.IsHealthy() {
-		return nil, fmt.Errorf("screenshot service is not healthy")
+	llmUsage := cost.NewTally()
+	questions, err := llmManager.GenerateInterviewQuestions(cost.WithTally(ctx, llmUsage), &request.BaseResume, &request.Job, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate interview questions using LLM: %w", err)
 	}
 
-	// Create DigitalOcean Spaces client
-	spacesClient, err := utils.NewSpacesClient(cfg)
+	processingTime := time.Since(startTime)
+	existingResult.Status = TaskStatusSuccess
+	existingResult.Data = &InterviewTaskData{
+		Questions: questions,
+		ResumeID:  request.ResumeID,
+	}
+	existingResult.ProcessingTime = &processingTime
+	existingResult.Metadata = map[string]interface{}{
+		"resume_id":      request.ResumeID,
+		"job_title":      request.Job.Title,
+		"company":        request.Job.CompanyName,
+		"question_count": len(questions),
+	}
+	setLLMUsage(existingResult, llmUsage.Metadata())
+
+	return existingResult, nil
+}
+
+// storeConversationEntry appends a turn to the resume's conversation thread; history is best effort,
+// so failures are logged and never fail the task
+func (tm *TaskManagerImpl) storeConversationEntry(ctx context.Context, conversations *utils.ConversationStore, resumeID string, entry utils.ConversationEntry) {
+	if err := conversations.AddConversationEntry(ctx, resumeID, entry); err != nil {
+		logging.FromContext(ctx).Warn("Failed to store conversation history entry", map[string]interface{}{
+			"resume_id": resumeID,
+			"role":      entry.Role,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// executeScreenshotTask executes a screenshot task in the background
+func (tm *TaskManagerImpl) executeScreenshotTask(ctx context.Context, processID string, request models.ResumeScreenshotRequest, cfg *config.Config) (*TaskResult, error) {
+	startTime := time.Now()
+	logger := logging.FromContext(ctx)
+
+	// Retrieve the existing task result to preserve original CreatedAt
+	existingResult, err := tm.store.Get(ctx, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing task result: %w", err)
+	}
+
+	logger.Info("Starting screenshot generation", map[string]interface{}{
+		"resume_id": request.ResumeID,
+	})
+
+	// Create object storage: DigitalOcean Spaces, or memory storage in test mode
+	storage, err := utils.NewObjectStorage(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create DigitalOcean Spaces client: %w", err)
+		return nil, fmt.Errorf("failed to create object storage: %w", err)
 	}
 
-	// Check if Spaces client is healthy
-	if !spacesClient.IsHealthy() {
-		return nil, fmt.Errorf("DigitalOcean Spaces is not healthy")
+	// Check if storage is healthy
+	if !storage.IsHealthy() {
+		return nil, fmt.Errorf("object storage is not healthy")
 	}
 
-	// Capture the screenshot
-	screenshotData, err := screenshotService.CaptureResumeScreenshot(ctx, request.ResumeID)
+	// Capture the screenshot; test mode renders a placeholder instead of opening a browser
+	var screenshotData []byte
+	if cfg.TestMode.Enabled {
+		screenshotData, err = stub.PlaceholderScreenshot(request.ResumeID)
+	} else {
+		screenshotData, err = captureResumeScreenshot(ctx, cfg, request.ResumeID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
 	}
 
-	// Upload screenshot to DigitalOcean Spaces
-	screenshotURL, err := spacesClient.UploadScreenshot(request.ResumeID, screenshotData)
+	// Upload screenshot to object storage
+	screenshotURL, err := storage.UploadScreenshot(request.ResumeID, screenshotData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload screenshot: %w", err)
 	}
 
-	tm.appLogger.Info("Screenshot generated successfully", map[string]interface{}{
-		"process_id":     processID,
+	logger.Info("Screenshot generated successfully", map[string]interface{}{
 		"resume_id":      request.ResumeID,
 		"screenshot_url": screenshotURL,
 		"file_size":      len(screenshotData),
@@ -886,6 +2126,19 @@ func (tm *TaskManagerImpl) executeScreenshotTask(ctx context.Context, processID
 	return existingResult, nil
 }
 
+// captureResumeScreenshot renders the resume preview in a browser from the global pool
+func captureResumeScreenshot(ctx context.Context, cfg *config.Config, resumeID string) ([]byte, error) {
+	screenshotService := headed.NewScreenshotService(cfg)
+	defer screenshotService.Cleanup()
+
+	// Check if screenshot service is healthy
+	if !screenshotService.IsHealthy() {
+		return nil, fmt.Errorf("screenshot service is not healthy")
+	}
+
+	return screenshotService.CaptureResumeScreenshot(ctx, resumeID)
+}
+
 // getEngineFromOptions extracts the engine from scrape options
 func getEngineFromOptions(options *models.ScrapeOptions) string {
 	if options == nil {
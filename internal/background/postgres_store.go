@@ -0,0 +1,433 @@
+package background
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq" // registers the "postgres" driver
+
+	"letraz-utils/internal/config"
+)
+
+// Task store backends selected by background_tasks.store
+const (
+	TaskStoreMemory   = "memory"
+	TaskStorePostgres = "postgres"
+)
+
+// taskStoreMigrationLock is the advisory lock held while migrating, so replicas starting
+// together apply each migration once
+const taskStoreMigrationLock = 7_361_024
+
+// taskStoreMigrations are the schema changes of the task store, applied in order; the version
+// of a migration is its position in the list, starting at 1. Append, never edit.
+var taskStoreMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS background_tasks (
+		process_id         TEXT PRIMARY KEY,
+		type               TEXT NOT NULL,
+		status             TEXT NOT NULL,
+		data               JSONB,
+		error              TEXT NOT NULL DEFAULT '',
+		error_code         TEXT NOT NULL DEFAULT '',
+		created_at         TIMESTAMPTZ NOT NULL,
+		completed_at       TIMESTAMPTZ,
+		processing_time_ns BIGINT,
+		metadata           JSONB
+	);
+	CREATE INDEX IF NOT EXISTS background_tasks_created_at_idx ON background_tasks (created_at);
+	CREATE INDEX IF NOT EXISTS background_tasks_type_status_idx ON background_tasks (type, status, created_at)`,
+
+	// Keyset pagination of task listings
+	`CREATE INDEX IF NOT EXISTS background_tasks_listing_idx ON background_tasks (created_at DESC, process_id DESC)`,
+}
+
+// taskColumns are the columns of background_tasks in the order scanTask reads them
+const taskColumns = `process_id, type, status, data, error, error_code, created_at, completed_at, processing_time_ns, metadata`
+
+// PostgresTaskStore implements TaskStore on a PostgreSQL table, so task results, processing times
+// and errors survive restarts and can be queried for analytics. History is kept for the
+// configured retention and Query lists all of it, while List, behind the task stats, covers
+// only the tasks of the last max_task_age, as the in-memory store does.
+type PostgresTaskStore struct {
+	db         *sql.DB
+	retention  time.Duration
+	listWindow time.Duration
+}
+
+// NewPostgresTaskStore connects to the database of background_tasks.postgres.dsn and applies the
+// pending schema migrations
+func NewPostgresTaskStore(ctx context.Context, cfg *config.Config) (*PostgresTaskStore, error) {
+	settings := cfg.BackgroundTasks.Postgres
+	if settings.DSN == "" {
+		return nil, fmt.Errorf("background_tasks.postgres.dsn is required for the postgres task store")
+	}
+
+	db, err := sql.Open("postgres", settings.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open task store database: %w", err)
+	}
+	if settings.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(settings.MaxOpenConns)
+		db.SetMaxIdleConns(settings.MaxOpenConns)
+	}
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to task store database: %w", err)
+	}
+
+	store := &PostgresTaskStore{
+		db:         db,
+		retention:  settings.Retention,
+		listWindow: cfg.BackgroundTasks.MaxTaskAge,
+	}
+	if err := store.migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// migrate applies the migrations newer than the recorded schema version in one transaction
+func (s *PostgresTaskStore) migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start task store migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, taskStoreMigrationLock); err != nil {
+		return fmt.Errorf("failed to lock task store migrations: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_store_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("failed to create task store migrations table: %w", err)
+	}
+
+	var current int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM task_store_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read task store schema version: %w", err)
+	}
+
+	for version := current + 1; version <= len(taskStoreMigrations); version++ {
+		if _, err := tx.ExecContext(ctx, taskStoreMigrations[version-1]); err != nil {
+			return fmt.Errorf("failed to apply task store migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO task_store_migrations (version) VALUES ($1)`, version); err != nil {
+			return fmt.Errorf("failed to record task store migration %d: %w", version, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task store migrations: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections to the database
+func (s *PostgresTaskStore) Close() error {
+	return s.db.Close()
+}
+
+// Store stores a task result, replacing any result with the same process ID
+func (s *PostgresTaskStore) Store(ctx context.Context, result *TaskResult) error {
+	args, err := taskArgs(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO background_tasks (`+taskColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (process_id) DO UPDATE SET
+			type = EXCLUDED.type, status = EXCLUDED.status, data = EXCLUDED.data,
+			error = EXCLUDED.error, error_code = EXCLUDED.error_code, created_at = EXCLUDED.created_at,
+			completed_at = EXCLUDED.completed_at, processing_time_ns = EXCLUDED.processing_time_ns,
+			metadata = EXCLUDED.metadata`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to store task %s: %w", result.ProcessID, err)
+	}
+	return nil
+}
+
+// Get retrieves a task result by process ID
+func (s *PostgresTaskStore) Get(ctx context.Context, processID string) (*TaskResult, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+taskColumns+` FROM background_tasks WHERE process_id = $1`, processID)
+	result, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %s: %w", processID, err)
+	}
+	return result, nil
+}
+
+// Update updates a task result
+func (s *PostgresTaskStore) Update(ctx context.Context, result *TaskResult) error {
+	args, err := taskArgs(result)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, `UPDATE background_tasks SET
+			type = $2, status = $3, data = $4, error = $5, error_code = $6, created_at = $7,
+			completed_at = $8, processing_time_ns = $9, metadata = $10
+		WHERE process_id = $1`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update task %s: %w", result.ProcessID, err)
+	}
+	return requireRow(res)
+}
+
+// Delete removes a task result
+func (s *PostgresTaskStore) Delete(ctx context.Context, processID string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM background_tasks WHERE process_id = $1`, processID)
+	if err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", processID, err)
+	}
+	return requireRow(res)
+}
+
+// Cleanup removes task results older than the store's retention, or maxAge when no retention is
+// configured
+func (s *PostgresTaskStore) Cleanup(ctx context.Context, maxAge time.Duration) error {
+	if s.retention > 0 {
+		maxAge = s.retention
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM background_tasks WHERE created_at < $1`, time.Now().Add(-maxAge)); err != nil {
+		return fmt.Errorf("failed to clean up tasks: %w", err)
+	}
+	return nil
+}
+
+// List returns the task results created within max_task_age, oldest first
+func (s *PostgresTaskStore) List(ctx context.Context) ([]*TaskResult, error) {
+	query := `SELECT ` + taskColumns + ` FROM background_tasks`
+	var args []interface{}
+	if s.listWindow > 0 {
+		query += ` WHERE created_at >= $1`
+		args = append(args, time.Now().Add(-s.listWindow))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*TaskResult
+	for rows.Next() {
+		result, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	return results, nil
+}
+
+// Query returns at most filter.Limit task results matching filter after its cursor, newest
+// first, filtering and paging in the database
+func (s *PostgresTaskStore) Query(ctx context.Context, filter TaskFilter) ([]*TaskResult, error) {
+	after, err := filter.after()
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, values ...interface{}) {
+		for _, value := range values {
+			args = append(args, value)
+			condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(args)), 1)
+		}
+		conditions = append(conditions, condition)
+	}
+	if filter.Status != "" {
+		where(`status = ?`, string(filter.Status))
+	}
+	if filter.Type != "" {
+		where(`type = ?`, string(filter.Type))
+	}
+	if filter.ResumeID != "" {
+		where(`metadata->>'resume_id' = ?`, filter.ResumeID)
+	}
+	if filter.URL != "" {
+		where(`metadata->>'url' = ?`, filter.URL)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		where(`created_at >= ?`, filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		where(`created_at < ?`, filter.CreatedBefore)
+	}
+	if after != nil {
+		where(`(created_at, process_id) < (?, ?)`, after.createdAt, after.processID)
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM background_tasks`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	query += ` ORDER BY created_at DESC, process_id DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += ` LIMIT $` + strconv.Itoa(len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*TaskResult
+	for rows.Next() {
+		result, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	return results, nil
+}
+
+// taskArgs returns the column values of a task result in taskColumns order
+func taskArgs(result *TaskResult) ([]interface{}, error) {
+	data, err := jsonColumn(result.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data of task %s: %w", result.ProcessID, err)
+	}
+	metadata, err := jsonColumn(result.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata of task %s: %w", result.ProcessID, err)
+	}
+
+	var completedAt sql.NullTime
+	if result.CompletedAt != nil {
+		completedAt = sql.NullTime{Time: *result.CompletedAt, Valid: true}
+	}
+	var processingTime sql.NullInt64
+	if result.ProcessingTime != nil {
+		processingTime = sql.NullInt64{Int64: int64(*result.ProcessingTime), Valid: true}
+	}
+
+	return []interface{}{
+		result.ProcessID, string(result.Type), string(result.Status), data, result.Error,
+		result.ErrorCode, result.CreatedAt, completedAt, processingTime, metadata,
+	}, nil
+}
+
+// jsonColumn encodes a value for a JSONB column, nil for SQL NULL
+func jsonColumn(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "null" {
+		return nil, nil
+	}
+	return string(encoded), nil
+}
+
+// rowScanner is a *sql.Row or *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads a task result selected with taskColumns. Data is decoded into the data type of
+// the task's type, so callers see the same types as with the in-memory store.
+func scanTask(row rowScanner) (*TaskResult, error) {
+	var (
+		result         TaskResult
+		taskType       string
+		status         string
+		data           []byte
+		completedAt    sql.NullTime
+		processingTime sql.NullInt64
+		metadata       []byte
+	)
+	if err := row.Scan(&result.ProcessID, &taskType, &status, &data, &result.Error, &result.ErrorCode,
+		&result.CreatedAt, &completedAt, &processingTime, &metadata); err != nil {
+		return nil, err
+	}
+
+	result.Type = TaskType(taskType)
+	result.Status = TaskStatus(status)
+	if completedAt.Valid {
+		completedAt := completedAt.Time
+		result.CompletedAt = &completedAt
+	}
+	if processingTime.Valid {
+		duration := time.Duration(processingTime.Int64)
+		result.ProcessingTime = &duration
+	}
+
+	if len(data) > 0 {
+		if target := newTaskData(result.Type); target != nil {
+			if err := json.Unmarshal(data, target); err != nil {
+				return nil, fmt.Errorf("failed to decode data of task %s: %w", result.ProcessID, err)
+			}
+			result.Data = target
+		} else if err := json.Unmarshal(data, &result.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode data of task %s: %w", result.ProcessID, err)
+		}
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &result.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode metadata of task %s: %w", result.ProcessID, err)
+		}
+	}
+	return &result, nil
+}
+
+// newTaskData returns an empty value of the data stored by tasks of taskType, or nil when the
+// type is unknown
+func newTaskData(taskType TaskType) interface{} {
+	switch taskType {
+	case TaskTypeScrape:
+		return &ScrapeTaskData{}
+	case TaskTypeBatch:
+		return &BatchScrapeTaskData{}
+	case TaskTypeCrawl:
+		return &CrawlTaskData{}
+	case TaskTypeTailor:
+		return &TailorTaskData{}
+	case TaskTypeInterview:
+		return &InterviewTaskData{}
+	case TaskTypeScreenshot:
+		return &ScreenshotTaskData{}
+	}
+	return nil
+}
+
+// requireRow returns ErrTaskNotFound when a statement touched no row
+func requireRow(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTaskNotFound
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package background
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/pkg/models"
+)
+
+// TaskPriority orders queued tasks; higher priorities are always dispatched first
+type TaskPriority int
+
+const (
+	TaskPriorityBulk TaskPriority = iota
+	TaskPriorityNormal
+	TaskPriorityInteractive
+
+	numTaskPriorities = 3
+)
+
+// String returns the priority name used in requests, metadata and logs
+func (p TaskPriority) String() string {
+	switch p {
+	case TaskPriorityBulk:
+		return "bulk"
+	case TaskPriorityInteractive:
+		return "interactive"
+	default:
+		return "normal"
+	}
+}
+
+// ParseTaskPriority converts a priority name to a TaskPriority; the scrape option names "low"
+// and "high" are accepted too, and unknown or empty names return fallback
+func ParseTaskPriority(name string, fallback TaskPriority) TaskPriority {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "bulk", "low", "batch":
+		return TaskPriorityBulk
+	case "normal":
+		return TaskPriorityNormal
+	case "interactive", "high":
+		return TaskPriorityInteractive
+	default:
+		return fallback
+	}
+}
+
+// jobPriority returns the scraper pool priority the jobs of a task run at, so bulk batches and
+// crawls queue behind single scrapes on the pool as well as on the task queue
+func (p TaskPriority) jobPriority() workers.JobPriority {
+	switch p {
+	case TaskPriorityBulk:
+		return workers.PriorityLow
+	case TaskPriorityInteractive:
+		return workers.PriorityHigh
+	default:
+		return workers.PriorityNormal
+	}
+}
+
+// ScrapeTaskPriority returns the priority requested in scrape options, or fallback when they
+// do not set one
+func ScrapeTaskPriority(options *models.ScrapeOptions, fallback TaskPriority) TaskPriority {
+	if options == nil {
+		return fallback
+	}
+	return ParseTaskPriority(options.Priority, fallback)
+}
+
+// taskQueue is a bounded queue of tasks waiting for a task worker, served highest priority
+// first and in FIFO order within a priority, so interactive tasks never wait behind bulk work
+type taskQueue struct {
+	mu       sync.Mutex
+	levels   [numTaskPriorities][]*TaskExecution
+	size     int
+	capacity int
+	closed   bool
+	ready    chan struct{} // signalled when a task is pushed or the queue is closed
+}
+
+// newTaskQueue creates a queue holding at most capacity tasks
+func newTaskQueue(capacity int) *taskQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &taskQueue{
+		capacity: capacity,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// Push adds a task at its priority; it returns false when the queue is full or closed
+func (q *taskQueue) Push(task *TaskExecution) bool {
+	q.mu.Lock()
+	if q.closed || q.size >= q.capacity {
+		q.mu.Unlock()
+		return false
+	}
+	level := taskPriorityLevel(task.Priority)
+	q.levels[level] = append(q.levels[level], task)
+	q.size++
+	q.signal()
+	q.mu.Unlock()
+	return true
+}
+
+// Pop waits for the next task of the highest priority with one queued; it returns false once
+// ctx is done or the queue is closed
+func (q *taskQueue) Pop(ctx context.Context) (*TaskExecution, bool) {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return nil, false
+		}
+		for level := numTaskPriorities - 1; level >= 0; level-- {
+			if len(q.levels[level]) == 0 {
+				continue
+			}
+			task := q.levels[level][0]
+			q.levels[level][0] = nil
+			q.levels[level] = q.levels[level][1:]
+			q.size--
+
+			// Pass the wake-up on so another idle worker picks up the rest
+			if q.size > 0 {
+				q.signal()
+			}
+			q.mu.Unlock()
+			return task, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// Close stops the queue: pushes fail and waiting workers return
+func (q *taskQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.ready)
+	}
+}
+
+// TakeAll removes and returns every queued task, highest priority first
+func (q *taskQueue) TakeAll() []*TaskExecution {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tasks := make([]*TaskExecution, 0, q.size)
+	for level := numTaskPriorities - 1; level >= 0; level-- {
+		tasks = append(tasks, q.levels[level]...)
+		q.levels[level] = nil
+	}
+	q.size = 0
+	return tasks
+}
+
+// Len returns the number of queued tasks
+func (q *taskQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// LenByPriority returns the number of queued tasks of each priority, keyed by priority name
+func (q *taskQueue) LenByPriority() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depths := make(map[string]int, numTaskPriorities)
+	for level := range q.levels {
+		depths[TaskPriority(level).String()] = len(q.levels[level])
+	}
+	return depths
+}
+
+// signal wakes one waiting worker without blocking; callers hold mu so it never races Close
+func (q *taskQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// taskPriorityLevel maps a priority to its queue level
+func taskPriorityLevel(p TaskPriority) int {
+	if p < TaskPriorityBulk || p > TaskPriorityInteractive {
+		return int(TaskPriorityNormal)
+	}
+	return int(p)
+}
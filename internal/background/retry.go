@@ -0,0 +1,146 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper/engines/firecrawl"
+	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/pkg/utils"
+)
+
+// taskTimeoutGrace is how long a run whose timeout passed gets to return on its own, with the
+// error it ran into, before its worker moves on without it
+const taskTimeoutGrace = time.Second
+
+// attemptContextKey carries the attempt number of a task execution
+type attemptContextKey struct{}
+
+// withAttempt returns ctx carrying the attempt number of the task executing under it
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey{}, attempt)
+}
+
+// taskAttempt returns the attempt number of the task executing under ctx, 1 for its first run
+func taskAttempt(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// retryPolicy returns the retry policy of a task type
+func (tm *TaskManagerImpl) retryPolicy(taskType TaskType) config.TaskRetryPolicy {
+	if tm.config == nil {
+		return config.TaskRetryPolicy{}
+	}
+	return tm.config.RetryPolicy(string(taskType))
+}
+
+// taskTimeout returns the timeout of one run of a task type
+func (tm *TaskManagerImpl) taskTimeout(taskType TaskType) time.Duration {
+	if tm.config == nil {
+		return 0
+	}
+	return tm.config.TaskTimeout(string(taskType))
+}
+
+// runAttempt runs a task once, bounded by its type's timeout. A run that has not returned
+// within the timeout, and the grace after it, is left behind with its context cancelled so a
+// hung browser or LLM call cannot hold the worker; the attempt then fails with a timeout error.
+func (tm *TaskManagerImpl) runAttempt(task *TaskExecution, attempt int) (result *TaskResult, timedOut bool, err error) {
+	timeout := tm.taskTimeout(task.Type)
+	if timeout <= 0 {
+		result, err = task.ExecuteFunc(withAttempt(task.Context, attempt))
+		return result, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(withAttempt(task.Context, attempt), timeout)
+	defer cancel()
+
+	type outcome struct {
+		result *TaskResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := task.ExecuteFunc(ctx)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case out := <-done:
+		result, err = out.result, out.err
+	case <-ctx.Done():
+		grace := time.NewTimer(taskTimeoutGrace)
+		select {
+		case out := <-done:
+			result, err = out.result, out.err
+		case <-grace.C:
+			err = ctx.Err()
+		}
+		grace.Stop()
+	}
+
+	// Only the run's own deadline is a timeout; cancellation of the task is reported as is
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && task.Context.Err() == nil {
+		return nil, true, utils.NewTimeoutError(fmt.Sprintf("%s task did not finish within %s", task.Type, timeout))
+	}
+	return result, false, err
+}
+
+// isRetryableTaskError reports whether a task failing with err may succeed if run again: the
+// browser crashed under it, the LLM provider was overloaded, rate limiting or too slow, or
+// Firecrawl failed with a server error
+func isRetryableTaskError(err error) bool {
+	if errors.Is(err, headed.ErrBrowserCrashed) || errors.Is(err, firecrawl.ErrServerError) {
+		return true
+	}
+	switch utils.GetErrorCode(err) {
+	case utils.ErrCodeLLMUnavailable, utils.ErrCodeRateLimited, utils.ErrCodeTimeout:
+		return true
+	}
+	return false
+}
+
+// executeWithRetry runs a task, running it again while it fails with a retryable error and its
+// retry policy allows more attempts. The wait between attempts starts at the policy's initial
+// backoff and doubles up to its maximum; a run exceeding its type's timeout, or the task's
+// cancellation, ends the retries.
+func (tm *TaskManagerImpl) executeWithRetry(task *TaskExecution) (*TaskResult, error) {
+	logger := logging.FromContext(task.Context)
+	policy := task.Retry
+	backoff := policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		task.Attempts = attempt
+		result, timedOut, err := tm.runAttempt(task, attempt)
+		if err == nil || timedOut || attempt >= policy.MaxAttempts || !isRetryableTaskError(err) || task.Context.Err() != nil {
+			return result, err
+		}
+
+		logger.Warn("Task failed with a transient error, retrying", map[string]interface{}{
+			"attempt":      attempt,
+			"max_attempts": policy.MaxAttempts,
+			"backoff":      backoff.String(),
+			"error":        err.Error(),
+			"error_code":   utils.GetErrorCode(err),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-task.Context.Done():
+			timer.Stop()
+			return result, err
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 {
+			backoff = min(backoff, policy.MaxBackoff)
+		}
+	}
+}
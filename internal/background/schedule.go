@@ -0,0 +1,416 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/crawl"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/quota"
+	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// TaskStatusScheduled is reported for submissions waiting for their run_at
+const TaskStatusScheduled = models.AsyncStatusScheduled
+
+const (
+	// scheduleKeyPrefix namespaces scheduled submissions in the key-value store
+	scheduleKeyPrefix = "task_schedule:"
+
+	// scheduleClaimPrefix namespaces the claims that let one instance submit a due task
+	scheduleClaimPrefix = "task_schedule_claim:"
+
+	// minScheduleClaimTTL bounds how long a claim outlives an instance that died submitting
+	minScheduleClaimTTL = 10 * time.Minute
+)
+
+// ErrSchedulingUnavailable is returned when a task is scheduled before scheduling is enabled
+var ErrSchedulingUnavailable = errors.New("task scheduling is not enabled")
+
+// ScheduledTask is a task submission held until RunAt. The request is kept as submitted so the
+// task runs with the options it was scheduled with.
+type ScheduledTask struct {
+	ProcessID string          `json:"process_id"`
+	Type      TaskType        `json:"type"`
+	Priority  string          `json:"priority"`
+	Request   json.RawMessage `json:"request"`
+	APIKey    *quota.APIKey   `json:"api_key,omitempty"` // the key usage is attributed to
+	RunAt     time.Time       `json:"run_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// TaskResources are the dependencies scheduled tasks are submitted with once they are due
+type TaskResources struct {
+	PoolManager *workers.PoolManager
+	LLMManager  *llm.Manager
+	Crawler     *crawl.Crawler
+}
+
+// scheduler holds the store and dependencies of scheduled submissions
+type scheduler struct {
+	store     kv.Store
+	resources TaskResources
+	mu        sync.Mutex // serializes due checks
+}
+
+// EnableScheduling keeps scheduled submissions in store and submits them with resources once
+// they are due, checking every Schedule.PollInterval until the task manager stops. The task
+// manager must be started first.
+func (tm *TaskManagerImpl) EnableScheduling(store kv.Store, resources TaskResources) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.scheduler = &scheduler{store: store, resources: resources}
+	if !tm.running {
+		return
+	}
+
+	interval := tm.config.BackgroundTasks.Schedule.PollInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	tm.wg.Add(1)
+	go tm.runScheduler(tm.scheduler, interval)
+
+	tm.appLogger.Info("Task scheduling enabled", map[string]interface{}{
+		"poll_interval": interval.String(),
+		"backend":       store.Backend(),
+	})
+}
+
+// ScheduleTask holds a task submission until runAt. request is the submission's request model;
+// it is submitted at priority, with the API key of ctx, once due.
+func (tm *TaskManagerImpl) ScheduleTask(ctx context.Context, processID string, taskType TaskType, request interface{}, priority TaskPriority, runAt time.Time) error {
+	sched := tm.getScheduler()
+	if sched == nil {
+		return ErrSchedulingUnavailable
+	}
+	if maxDelay := tm.config.BackgroundTasks.Schedule.MaxDelay; maxDelay > 0 && time.Until(runAt) > maxDelay {
+		return utils.NewValidationError(fmt.Sprintf("tasks can be scheduled at most %s ahead", maxDelay))
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled request: %w", err)
+	}
+	task := &ScheduledTask{
+		ProcessID: processID,
+		Type:      taskType,
+		Priority:  priority.String(),
+		Request:   data,
+		APIKey:    quota.APIKeyFromContext(ctx),
+		RunAt:     runAt,
+		CreatedAt: time.Now(),
+	}
+	if err := sched.save(ctx, task); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("Task scheduled", map[string]interface{}{
+		"process_id": processID,
+		"task_type":  string(taskType),
+		"run_at":     runAt.Format(time.RFC3339),
+	})
+	return nil
+}
+
+// getScheduler returns the scheduler, or nil when scheduling is not enabled
+func (tm *TaskManagerImpl) getScheduler() *scheduler {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.scheduler
+}
+
+// scheduledResult returns the result reported for a submission still waiting to run
+func (tm *TaskManagerImpl) scheduledResult(ctx context.Context, processID string) (*TaskResult, error) {
+	sched := tm.getScheduler()
+	if sched == nil {
+		return nil, ErrTaskNotFound
+	}
+	task, err := sched.get(ctx, processID)
+	if err != nil {
+		return nil, err
+	}
+	return task.result(), nil
+}
+
+// scheduledResults returns the results reported for every submission still waiting to run
+func (tm *TaskManagerImpl) scheduledResults(ctx context.Context) ([]*TaskResult, error) {
+	sched := tm.getScheduler()
+	if sched == nil {
+		return nil, nil
+	}
+	tasks, err := sched.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TaskResult, 0, len(tasks))
+	for _, task := range tasks {
+		results = append(results, task.result())
+	}
+	return results, nil
+}
+
+// result returns the task result reported while the task waits to run
+func (t *ScheduledTask) result() *TaskResult {
+	return &TaskResult{
+		ProcessID: t.ProcessID,
+		Type:      t.Type,
+		Status:    TaskStatusScheduled,
+		CreatedAt: t.CreatedAt,
+		Metadata: map[string]interface{}{
+			"run_at":   t.RunAt,
+			"priority": t.Priority,
+		},
+	}
+}
+
+// runScheduler submits due tasks every interval until the task manager stops
+func (tm *TaskManagerImpl) runScheduler(sched *scheduler, interval time.Duration) {
+	defer tm.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	claimTTL := max(10*interval, minScheduleClaimTTL)
+	tm.submitDueTasks(sched, claimTTL)
+	for {
+		select {
+		case <-tm.ctx.Done():
+			return
+		case <-ticker.C:
+			tm.submitDueTasks(sched, claimTTL)
+		}
+	}
+}
+
+// submitDueTasks submits every scheduled task whose run_at has passed, earliest first. A task
+// is submitted by the instance that claims it; one refused for a full queue or a rate limit
+// stays scheduled for the next check.
+func (tm *TaskManagerImpl) submitDueTasks(sched *scheduler, claimTTL time.Duration) {
+	// A draining instance refuses submissions; other instances, or this one once restarted,
+	// submit the due tasks
+	if tm.isDraining() {
+		return
+	}
+
+	sched.mu.Lock()
+	defer sched.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(tm.ctx, 30*time.Second)
+	defer cancel()
+
+	tasks, err := sched.list(ctx)
+	if err != nil {
+		tm.appLogger.Warn("Failed to load scheduled tasks", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	for _, task := range tasks {
+		if task.RunAt.After(now) || ctx.Err() != nil {
+			continue
+		}
+
+		claimed, err := sched.claim(ctx, task.ProcessID, claimTTL)
+		if err != nil || !claimed {
+			continue
+		}
+
+		err = tm.submitScheduledTask(ctx, sched.resources, task)
+		if utils.IsErrorCode(err, utils.ErrCodeQueueFull) || utils.IsErrorCode(err, utils.ErrCodeRateLimited) || utils.IsErrorCode(err, utils.ErrCodeDraining) {
+			sched.release(ctx, task.ProcessID)
+			tm.appLogger.Warn("Scheduled task refused for capacity, deferred to the next check", map[string]interface{}{
+				"process_id": task.ProcessID,
+				"error":      err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			tm.failScheduledTask(ctx, task, err)
+		}
+		sched.remove(ctx, task.ProcessID)
+	}
+}
+
+// submitScheduledTask submits a due task with the Submit method of its type
+func (tm *TaskManagerImpl) submitScheduledTask(ctx context.Context, resources TaskResources, task *ScheduledTask) error {
+	ctx = quota.WithAPIKey(ctx, task.APIKey)
+	priority := ParseTaskPriority(task.Priority, TaskPriorityNormal)
+
+	switch task.Type {
+	case TaskTypeScrape:
+		var request models.ScrapeRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitScrapeTask(ctx, task.ProcessID, request, priority, resources.PoolManager)
+	case TaskTypeBatch:
+		var request models.BatchScrapeRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitBatchScrapeTask(ctx, task.ProcessID, request, priority, resources.PoolManager)
+	case TaskTypeCrawl:
+		var request models.CrawlRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitCrawlTask(ctx, task.ProcessID, request, priority, resources.Crawler, resources.PoolManager)
+	case TaskTypeTailor:
+		var request models.TailorResumeRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitTailorTask(ctx, task.ProcessID, request, priority, resources.LLMManager, tm.config)
+	case TaskTypeInterview:
+		var request models.InterviewQuestionsRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitInterviewTask(ctx, task.ProcessID, request, priority, resources.LLMManager)
+	case TaskTypeScreenshot:
+		var request models.ResumeScreenshotRequest
+		if err := json.Unmarshal(task.Request, &request); err != nil {
+			return fmt.Errorf("failed to decode scheduled request: %w", err)
+		}
+		return tm.SubmitScreenshotTask(ctx, task.ProcessID, request, priority, tm.config)
+	default:
+		return fmt.Errorf("unknown scheduled task type %q", task.Type)
+	}
+}
+
+// failScheduledTask records a scheduled task that could not be submitted as failed and sends
+// its failure callback
+func (tm *TaskManagerImpl) failScheduledTask(ctx context.Context, task *ScheduledTask, err error) {
+	tm.appLogger.Error("Failed to submit scheduled task", map[string]interface{}{
+		"process_id": task.ProcessID,
+		"task_type":  string(task.Type),
+		"error":      err.Error(),
+	})
+
+	completedAt := time.Now()
+	result := &TaskResult{
+		ProcessID:   task.ProcessID,
+		Type:        task.Type,
+		Status:      TaskStatusFailure,
+		Error:       err.Error(),
+		ErrorCode:   string(utils.GetErrorCode(err)),
+		CreatedAt:   task.CreatedAt,
+		CompletedAt: &completedAt,
+		Metadata: map[string]interface{}{
+			"run_at": task.RunAt,
+		},
+	}
+	if storeErr := tm.store.Store(ctx, result); storeErr != nil {
+		tm.appLogger.Error("Failed to store scheduled task failure", map[string]interface{}{
+			"process_id": task.ProcessID,
+			"error":      storeErr.Error(),
+		})
+	}
+	tm.publishEvent(ctx, &TaskEvent{Event: TaskEventFailed, ProcessID: task.ProcessID, Type: task.Type, Status: TaskStatusFailure, Error: result.Error, ErrorCode: result.ErrorCode, Result: result})
+	if logErr := tm.logger.LogTaskCompletion(result); logErr != nil {
+		tm.appLogger.Error("Failed to log task completion", map[string]interface{}{
+			"process_id": task.ProcessID,
+			"error":      logErr.Error(),
+		})
+	}
+}
+
+// save stores a scheduled task without expiration
+func (s *scheduler) save(ctx context.Context, task *ScheduledTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled task: %w", err)
+	}
+	if err := s.store.Set(ctx, scheduleKeyPrefix+task.ProcessID, data, 0); err != nil {
+		return fmt.Errorf("failed to store scheduled task: %w", err)
+	}
+	return nil
+}
+
+// get returns a scheduled task by process ID, or ErrTaskNotFound
+func (s *scheduler) get(ctx context.Context, processID string) (*ScheduledTask, error) {
+	data, err := s.store.Get(ctx, scheduleKeyPrefix+processID)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to load scheduled task: %w", err)
+	}
+
+	var task ScheduledTask
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled task: %w", err)
+	}
+	return &task, nil
+}
+
+// list loads every scheduled task ordered by run_at
+func (s *scheduler) list(ctx context.Context) ([]*ScheduledTask, error) {
+	var keys []string
+	var keysMu sync.Mutex
+	err := s.store.Scan(ctx, scheduleKeyPrefix, func(key string) error {
+		keysMu.Lock()
+		keys = append(keys, key)
+		keysMu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled tasks: %w", err)
+	}
+
+	tasks := make([]*ScheduledTask, 0, len(keys))
+	for _, key := range keys {
+		task, err := s.get(ctx, strings.TrimPrefix(key, scheduleKeyPrefix))
+		if err != nil {
+			if errors.Is(err, ErrTaskNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].RunAt.Before(tasks[j].RunAt)
+	})
+	return tasks, nil
+}
+
+// claim reserves a due task for this instance, so instances sharing the store submit it once
+func (s *scheduler) claim(ctx context.Context, processID string, ttl time.Duration) (bool, error) {
+	claims, err := s.store.IncrBy(ctx, scheduleClaimPrefix+processID, 1, ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim scheduled task: %w", err)
+	}
+	return claims == 1, nil
+}
+
+// release gives up the claim on a task left scheduled
+func (s *scheduler) release(ctx context.Context, processID string) {
+	_ = s.store.Delete(ctx, scheduleClaimPrefix+processID)
+}
+
+// remove deletes a task that was submitted, or failed to be; its claim is left to expire so
+// the task is not submitted again should the delete fail
+func (s *scheduler) remove(ctx context.Context, processID string) {
+	if err := s.store.Delete(ctx, scheduleKeyPrefix+processID); err != nil {
+		logging.GetGlobalLogger().Warn("Failed to delete scheduled task", map[string]interface{}{
+			"process_id": processID,
+			"error":      err.Error(),
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/quota"
+)
+
+const (
+	// TaskQueueMemory keeps queued tasks in this instance's memory
+	TaskQueueMemory = "memory"
+
+	// TaskQueueRedis shares queued tasks between replicas through Redis streams
+	TaskQueueRedis = "redis"
+)
+
+const (
+	// streamBlockTimeout bounds a blocking stream read, so workers notice shutdown
+	streamBlockTimeout = 2 * time.Second
+
+	// streamReclaimBatch caps the stale entries looked at per stream and reclaim pass
+	streamReclaimBatch = 16
+)
+
+// queuedTask is a task on the shared queue: what another replica needs to run it
+type queuedTask struct {
+	ProcessID  string          `json:"process_id"`
+	Type       TaskType        `json:"type"`
+	Request    json.RawMessage `json:"request"`
+	APIKey     *quota.APIKey   `json:"api_key,omitempty"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+}
+
+// streamEntry is a queued task delivered to this replica's consumer
+type streamEntry struct {
+	stream     string
+	id         string
+	priority   TaskPriority
+	task       *queuedTask
+	deliveries int64
+}
+
+// streamQueue is the task queue shared by replicas: one Redis stream per priority, read through
+// a consumer group so each task is delivered to one replica at a time. Entries are acknowledged
+// and deleted once their task finished; entries left unacknowledged for the visibility timeout
+// are claimed by another replica, so every task runs at least once.
+type streamQueue struct {
+	client        redis.UniversalClient
+	streams       [numTaskPriorities]string // by priority level
+	group         string
+	consumer      string
+	visibility    time.Duration
+	maxDeliveries int64
+	capacity      int
+
+	// delivered holds entries delivered to this consumer beyond the one a worker asked for:
+	// extra entries of a multi-stream read and entries reclaimed from dead replicas
+	delivered chan *streamEntry
+}
+
+// newStreamQueue creates a shared queue holding at most capacity tasks
+func newStreamQueue(client redis.UniversalClient, cfg *config.Config, capacity int) *streamQueue {
+	queueCfg := cfg.BackgroundTasks.Queue
+	q := &streamQueue{
+		client:        client,
+		group:         queueCfg.ConsumerGroup,
+		consumer:      streamConsumerName(),
+		visibility:    queueCfg.VisibilityTimeout,
+		maxDeliveries: int64(queueCfg.MaxDeliveries),
+		capacity:      capacity,
+		delivered:     make(chan *streamEntry, 4*numTaskPriorities),
+	}
+	if q.visibility <= 0 {
+		q.visibility = 2 * time.Minute
+	}
+	prefix := streamKeyPrefix(queueCfg.StreamPrefix)
+	for level := range q.streams {
+		q.streams[level] = prefix + ":" + TaskPriority(level).String()
+	}
+	return q
+}
+
+// streamKeyPrefix wraps prefix in a hash tag unless it has one, so the streams of every
+// priority hash to one Redis Cluster slot: Pop reads them in one XREADGROUP and lengths
+// pipelines across them, which a cluster rejects with CROSSSLOT for keys on different slots
+func streamKeyPrefix(prefix string) string {
+	if open := strings.IndexByte(prefix, '{'); open >= 0 {
+		if end := strings.IndexByte(prefix[open+1:], '}'); end > 0 {
+			return prefix
+		}
+	}
+	return "{" + prefix + "}"
+}
+
+// streamConsumerName names this process within the consumer group
+func streamConsumerName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "letraz-utils"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// ensureGroups creates the streams and their consumer group when they do not exist yet
+func (q *streamQueue) ensureGroups(ctx context.Context) error {
+	for _, stream := range q.streams {
+		err := q.client.XGroupCreateMkStream(ctx, stream, q.group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group for %s: %w", stream, err)
+		}
+	}
+	return nil
+}
+
+// Push adds a task at its priority; it returns false when the queue already holds capacity
+// tasks, counting the ones still running
+func (q *streamQueue) Push(ctx context.Context, priority TaskPriority, task *queuedTask) (bool, error) {
+	length, err := q.Len(ctx)
+	if err != nil {
+		return false, err
+	}
+	if length >= q.capacity {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode queued task: %w", err)
+	}
+	err = q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.streams[taskPriorityLevel(priority)],
+		Values: map[string]interface{}{"task": payload},
+	}).Err()
+	if err != nil {
+		return false, fmt.Errorf("failed to queue task: %w", err)
+	}
+	return true, nil
+}
+
+// Pop waits for the next task delivered to this replica, highest priority first. It returns
+// false once ctx is done; Redis errors are returned so the caller can back off.
+func (q *streamQueue) Pop(ctx context.Context) (*streamEntry, bool, error) {
+	for {
+		select {
+		case entry := <-q.delivered:
+			return entry, true, nil
+		case <-ctx.Done():
+			return nil, false, nil
+		default:
+		}
+
+		// Take the highest priority with a task waiting without blocking, then block on all
+		for level := numTaskPriorities - 1; level >= 0; level-- {
+			entries, err := q.read(ctx, []string{q.streams[level]}, -1)
+			if err != nil {
+				return nil, ctx.Err() == nil, q.recover(ctx, err)
+			}
+			if len(entries) > 0 {
+				return q.keep(ctx, entries)
+			}
+		}
+
+		streams := make([]string, 0, numTaskPriorities)
+		for level := numTaskPriorities - 1; level >= 0; level-- {
+			streams = append(streams, q.streams[level])
+		}
+		entries, err := q.read(ctx, streams, streamBlockTimeout)
+		if err != nil {
+			return nil, ctx.Err() == nil, q.recover(ctx, err)
+		}
+		if len(entries) > 0 {
+			return q.keep(ctx, entries)
+		}
+	}
+}
+
+// recover recreates the consumer group after a read found it missing, as after Redis lost its
+// data, and returns err
+func (q *streamQueue) recover(ctx context.Context, err error) error {
+	if strings.HasPrefix(err.Error(), "NOGROUP") {
+		_ = q.ensureGroups(ctx)
+	}
+	return err
+}
+
+// keep returns the first of the entries read and hands the others to the next workers asking
+func (q *streamQueue) keep(ctx context.Context, entries []*streamEntry) (*streamEntry, bool, error) {
+	for _, entry := range entries[1:] {
+		select {
+		case q.delivered <- entry:
+		case <-ctx.Done():
+		}
+	}
+	return entries[0], true, nil
+}
+
+// read reads at most one new entry from each of streams, waiting up to block for one to arrive;
+// a negative block does not wait
+func (q *streamQueue) read(ctx context.Context, streams []string, block time.Duration) ([]*streamEntry, error) {
+	ids := make([]string, len(streams))
+	for i := range ids {
+		ids[i] = ">"
+	}
+	results, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: q.consumer,
+		Streams:  append(append([]string(nil), streams...), ids...),
+		Count:    1,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*streamEntry
+	for _, result := range results {
+		for _, message := range result.Messages {
+			entries = append(entries, q.entry(ctx, result.Stream, message, 1))
+		}
+	}
+	return compactEntries(entries), nil
+}
+
+// entry decodes a stream message; undecodable messages are acknowledged and dropped
+func (q *streamQueue) entry(ctx context.Context, stream string, message redis.XMessage, deliveries int64) *streamEntry {
+	entry := &streamEntry{stream: stream, id: message.ID, priority: q.priorityOf(stream), deliveries: deliveries}
+	payload, _ := message.Values["task"].(string)
+	var task queuedTask
+	if err := json.Unmarshal([]byte(payload), &task); err != nil || task.ProcessID == "" {
+		q.Ack(ctx, entry)
+		return nil
+	}
+	entry.task = &task
+	return entry
+}
+
+// priorityOf returns the priority a stream carries
+func (q *streamQueue) priorityOf(stream string) TaskPriority {
+	for level, name := range q.streams {
+		if name == stream {
+			return TaskPriority(level)
+		}
+	}
+	return TaskPriorityNormal
+}
+
+// Ack acknowledges and deletes the entry of a finished task
+func (q *streamQueue) Ack(ctx context.Context, entry *streamEntry) {
+	pipe := q.client.Pipeline()
+	pipe.XAck(ctx, entry.stream, q.group, entry.id)
+	pipe.XDel(ctx, entry.stream, entry.id)
+	_, _ = pipe.Exec(ctx)
+}
+
+// KeepAlive keeps claiming the entry of a running task for this replica, so it is not taken
+// over while it runs longer than the visibility timeout; the returned function stops it
+func (q *streamQueue) KeepAlive(ctx context.Context, entry *streamEntry) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(q.visibility / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = q.client.XClaimJustID(ctx, &redis.XClaimArgs{
+					Stream:   entry.stream,
+					Group:    q.group,
+					Consumer: q.consumer,
+					Messages: []string{entry.id},
+				}).Err()
+			}
+		}
+	}()
+	return cancel
+}
+
+// Reclaim claims the entries other consumers left unacknowledged for the visibility timeout.
+// Entries still under their delivery limit are handed to this replica's workers; the others
+// are returned so their tasks can be failed.
+func (q *streamQueue) Reclaim(ctx context.Context) ([]*streamEntry, error) {
+	var abandoned []*streamEntry
+	for _, stream := range q.streams {
+		pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: stream,
+			Group:  q.group,
+			Idle:   q.visibility,
+			Start:  "-",
+			End:    "+",
+			Count:  streamReclaimBatch,
+		}).Result()
+		if err != nil {
+			return abandoned, err
+		}
+
+		for _, item := range pending {
+			// Leave the rest to replicas with idle workers rather than queue them up here
+			if len(q.delivered) >= cap(q.delivered)/2 {
+				return abandoned, nil
+			}
+			messages, err := q.client.XClaim(ctx, &redis.XClaimArgs{
+				Stream:   stream,
+				Group:    q.group,
+				Consumer: q.consumer,
+				MinIdle:  q.visibility,
+				Messages: []string{item.ID},
+			}).Result()
+			if err != nil {
+				return abandoned, err
+			}
+			for _, message := range messages {
+				entry := q.entry(ctx, stream, message, item.RetryCount+1)
+				if entry == nil {
+					continue
+				}
+				if q.maxDeliveries > 0 && entry.deliveries > q.maxDeliveries {
+					abandoned = append(abandoned, entry)
+					continue
+				}
+				select {
+				case q.delivered <- entry:
+				case <-ctx.Done():
+					return abandoned, ctx.Err()
+				}
+			}
+		}
+	}
+	return abandoned, nil
+}
+
+// Len returns the number of queued and running tasks
+func (q *streamQueue) Len(ctx context.Context) (int, error) {
+	depths, err := q.lengths(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, depth := range depths {
+		total += depth
+	}
+	return total, nil
+}
+
+// LenByPriority returns the number of queued and running tasks of each priority, keyed by
+// priority name
+func (q *streamQueue) LenByPriority(ctx context.Context) (map[string]int, error) {
+	depths, err := q.lengths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byPriority := make(map[string]int, numTaskPriorities)
+	for level, depth := range depths {
+		byPriority[TaskPriority(level).String()] = depth
+	}
+	return byPriority, nil
+}
+
+// lengths returns the length of each priority's stream
+func (q *streamQueue) lengths(ctx context.Context) ([numTaskPriorities]int, error) {
+	var depths [numTaskPriorities]int
+	pipe := q.client.Pipeline()
+	cmds := make([]*redis.IntCmd, numTaskPriorities)
+	for level, stream := range q.streams {
+		cmds[level] = pipe.XLen(ctx, stream)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return depths, fmt.Errorf("failed to read task queue length: %w", err)
+	}
+	for level, cmd := range cmds {
+		depths[level] = int(cmd.Val())
+	}
+	return depths, nil
+}
+
+// compactEntries drops the entries that could not be decoded
+func compactEntries(entries []*streamEntry) []*streamEntry {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry != nil {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
@@ -25,6 +25,9 @@ const (
 	TaskTypeScrape     TaskType = "scrape"
 	TaskTypeTailor     TaskType = "tailor"
 	TaskTypeScreenshot TaskType = "screenshot"
+	TaskTypeBatch      TaskType = "batch_scrape"
+	TaskTypeInterview  TaskType = "interview_questions"
+	TaskTypeCrawl      TaskType = "crawl"
 )
 
 // TaskResult represents the result of a background task
@@ -34,6 +37,7 @@ type TaskResult struct {
 	Status         TaskStatus             `json:"status"`
 	Data           interface{}            `json:"data,omitempty"`
 	Error          string                 `json:"error,omitempty"`
+	ErrorCode      string                 `json:"errorCode,omitempty"`
 	CreatedAt      time.Time              `json:"createdAt"`
 	CompletedAt    *time.Time             `json:"completedAt,omitempty"`
 	ProcessingTime *time.Duration         `json:"processingTime,omitempty"`
@@ -48,6 +52,37 @@ type ScrapeTaskData struct {
 	UsedLLM    bool               `json:"used_llm"`
 }
 
+// BatchScrapeTaskData represents the data structure for batch scrape task results
+type BatchScrapeTaskData struct {
+	Results   []BatchScrapeItem `json:"results"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+// CrawlTaskData represents the data structure for career-page crawl task results
+type CrawlTaskData struct {
+	URL       string            `json:"url"`
+	Sources   map[string]int    `json:"sources"` // job links found by each discovery source
+	Results   []BatchScrapeItem `json:"results"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+// BatchScrapeItem is the outcome of one URL of a batch scrape or crawl
+type BatchScrapeItem struct {
+	URL        string             `json:"url"`
+	ProcessID  string             `json:"processId,omitempty"` // child scrape task of a crawl
+	Job        *models.Job        `json:"job,omitempty"`
+	JobPosting *models.JobPosting `json:"job_posting,omitempty"`
+	Engine     string             `json:"engine,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	ErrorCode  string             `json:"errorCode,omitempty"`
+	Block      string             `json:"block,omitempty"`   // bot protection that stopped a failed URL
+	Archive    string             `json:"archive,omitempty"` // object key of the page handed to extraction
+}
+
 // TailorTaskData represents the data structure for tailor task results
 type TailorTaskData struct {
 	TailoredResume *models.TailoredResume `json:"tailored_resume,omitempty"`
@@ -55,6 +90,12 @@ type TailorTaskData struct {
 	ThreadID       string                 `json:"thread_id,omitempty"`
 }
 
+// InterviewTaskData represents the data structure for interview questions task results
+type InterviewTaskData struct {
+	Questions []models.InterviewQuestion `json:"questions"`
+	ResumeID  string                     `json:"resume_id"`
+}
+
 // ScreenshotTaskData represents the data structure for screenshot task results
 type ScreenshotTaskData struct {
 	ScreenshotURL string `json:"screenshot_url"`
@@ -81,6 +122,11 @@ type TaskStore interface {
 
 	// List returns all task results (for monitoring)
 	List(ctx context.Context) ([]*TaskResult, error)
+
+	// Query returns at most filter.Limit task results matching filter that come after
+	// filter.Cursor, newest first and then by descending process ID. ListTasks validates the
+	// filter before it gets here.
+	Query(ctx context.Context, filter TaskFilter) ([]*TaskResult, error)
 }
 
 // InMemoryTaskStore implements TaskStore using in-memory storage
@@ -173,6 +219,19 @@ func (s *InMemoryTaskStore) List(ctx context.Context) ([]*TaskResult, error) {
 	return results, nil
 }
 
+// Query returns at most filter.Limit task results matching filter after its cursor, newest first
+func (s *InMemoryTaskStore) Query(ctx context.Context, filter TaskFilter) ([]*TaskResult, error) {
+	after, err := filter.after()
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterTasks(results, filter, after), nil
+}
+
 // Common errors
 var (
 	ErrTaskNotFound = NewTaskError("task not found")
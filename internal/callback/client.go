@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/chaos"
 	"letraz-utils/internal/logging"
 	"letraz-utils/pkg/models"
 )
@@ -25,6 +27,7 @@ type Client struct {
 	scrapeClient       letrazv1.ScrapeJobCallbackControllerClient
 	tailorResumeClient letrazv1.TailorResumeCallBackControllerClient
 	screenshotClient   letrazv1.GenerateScreenshotCallBackControllerClient
+	interviewClient    letrazv1.InterviewQuestionsCallBackControllerClient
 	logger             logging.Logger
 }
 
@@ -58,6 +61,8 @@ func NewClient(config *ClientConfig, logger logging.Logger) (*Client, error) {
 	conn, err := grpc.NewClient(
 		serverAddr,
 		grpc.WithTransportCredentials(creds),
+		// Fault injection for resilience testing; a pass-through unless chaos is enabled
+		grpc.WithUnaryInterceptor(chaos.UnaryClientInterceptor()),
 		// Add keepalive parameters for better connection stability
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                30 * time.Second,
@@ -81,12 +86,14 @@ func NewClient(config *ClientConfig, logger logging.Logger) (*Client, error) {
 	scrapeClient := letrazv1.NewScrapeJobCallbackControllerClient(conn)
 	tailorResumeClient := letrazv1.NewTailorResumeCallBackControllerClient(conn)
 	screenshotClient := letrazv1.NewGenerateScreenshotCallBackControllerClient(conn)
+	interviewClient := letrazv1.NewInterviewQuestionsCallBackControllerClient(conn)
 
 	return &Client{
 		conn:               conn,
 		scrapeClient:       scrapeClient,
 		tailorResumeClient: tailorResumeClient,
 		screenshotClient:   screenshotClient,
+		interviewClient:    interviewClient,
 		logger:             logger,
 	}, nil
 }
@@ -216,6 +223,46 @@ func (c *Client) SendGenerateScreenshotCallback(ctx context.Context, result *Scr
 	return nil
 }
 
+// SendInterviewQuestionsCallback sends an InterviewQuestions callback to the server
+func (c *Client) SendInterviewQuestionsCallback(ctx context.Context, result *InterviewQuestionsCallbackData) error {
+	req := convertToInterviewQuestionsCallbackRequest(result)
+
+	c.logger.Info("Sending InterviewQuestions callback", map[string]interface{}{
+		"process_id":   req.ProcessId,
+		"status":       req.Status,
+		"operation":    req.Operation,
+		"method_name":  "/letraz_server.RESUME.InterviewQuestionsCallBackController/InterviewQuestionsCallBack",
+		"client_state": c.conn.GetState().String(),
+		"target":       c.conn.Target(),
+	})
+
+	// Create context with timeout
+	callCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Make the gRPC call
+	response, err := c.interviewClient.InterviewQuestionsCallBack(callCtx, req)
+	if err != nil {
+		c.logger.Error("Failed to send InterviewQuestions callback", map[string]interface{}{
+			"process_id": req.ProcessId,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to send InterviewQuestions callback: %w", err)
+	}
+
+	// Log success with response message if available
+	logFields := map[string]interface{}{
+		"process_id": req.ProcessId,
+	}
+	if response != nil && response.Msg != nil {
+		logFields["response_msg"] = *response.Msg
+	}
+
+	c.logger.Info("InterviewQuestions callback sent successfully", logFields)
+
+	return nil
+}
+
 // CallbackData represents the data structure for callbacks
 type CallbackData struct {
 	ProcessID      string
@@ -225,6 +272,8 @@ type CallbackData struct {
 	Operation      string
 	ProcessingTime time.Duration
 	Metadata       *CallbackMetadata
+	Error          string
+	ErrorCode      string
 }
 
 // CallbackJobData represents job data for callbacks
@@ -236,8 +285,19 @@ type CallbackJobData struct {
 
 // CallbackMetadata represents metadata for callbacks
 type CallbackMetadata struct {
-	Engine string
-	URL    string
+	Engine         string
+	URL            string
+	LLMUsage       *LLMUsage
+	Block          string // bot protection an engine ran into, such as "cloudflare" or "login_wall"
+	BlockRetryable bool   // whether another engine may get past Block
+}
+
+// LLMUsage is the LLM token usage and estimated cost of a task, reported for spend attribution
+type LLMUsage struct {
+	Provider     string
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
 }
 
 // TailorResumeCallbackData represents the data structure for TailorResume callbacks
@@ -249,6 +309,8 @@ type TailorResumeCallbackData struct {
 	Operation      string
 	ProcessingTime time.Duration
 	Metadata       *TailorResumeCallbackMetadata
+	Error          string
+	ErrorCode      string
 }
 
 // TailorResumeJobData represents TailorResume job data for callbacks
@@ -263,6 +325,7 @@ type TailorResumeCallbackMetadata struct {
 	Company  string
 	JobTitle string
 	ResumeID string
+	LLMUsage *LLMUsage
 }
 
 // convertToCallbackRequest converts CallbackData to the gRPC request format
@@ -275,6 +338,14 @@ func convertToCallbackRequest(data *CallbackData) *letrazv1.ScrapeJobCallbackReq
 		ProcessingTime: data.ProcessingTime.String(),
 	}
 
+	// Carry the failure reason so the server can branch on the error code
+	if data.Error != "" {
+		req.Error = &data.Error
+	}
+	if data.ErrorCode != "" {
+		req.ErrorCode = &data.ErrorCode
+	}
+
 	// Convert job data based on status
 	// For failure callbacks, explicitly set data to null for clear semantics
 	// For success callbacks, populate with actual job data if available
@@ -301,13 +372,16 @@ func convertToCallbackRequest(data *CallbackData) *letrazv1.ScrapeJobCallbackReq
 				Responsibilities: job.Responsibilities,
 				Benefits:         job.Benefits,
 			}
+			if job.OriginalLanguage != "" {
+				req.Data.Job.OriginalLanguage = &job.OriginalLanguage
+			}
 
 			// Convert salary if available
 			if job.Salary.Currency != "" || job.Salary.Max > 0 || job.Salary.Min > 0 {
 				req.Data.Job.Salary = &letrazv1.JobSalaryRequest{
 					Currency: &job.Salary.Currency,
-					Max:      func() *int32 { v := int32(job.Salary.Max); return &v }(),
-					Min:      func() *int32 { v := int32(job.Salary.Min); return &v }(),
+					Max:      func() *int32 { v := int32(math.Round(job.Salary.Max)); return &v }(),
+					Min:      func() *int32 { v := int32(math.Round(job.Salary.Min)); return &v }(),
 				}
 			}
 		}
@@ -322,6 +396,16 @@ func convertToCallbackRequest(data *CallbackData) *letrazv1.ScrapeJobCallbackReq
 			Engine: &data.Metadata.Engine,
 			Url:    &data.Metadata.URL,
 		}
+		if usage := data.Metadata.LLMUsage; usage != nil {
+			req.Metadata.LlmProvider = &usage.Provider
+			req.Metadata.LlmInputTokens = &usage.InputTokens
+			req.Metadata.LlmOutputTokens = &usage.OutputTokens
+			req.Metadata.LlmCostUsd = &usage.CostUSD
+		}
+		if data.Metadata.Block != "" {
+			req.Metadata.BlockType = &data.Metadata.Block
+			req.Metadata.BlockRetryable = &data.Metadata.BlockRetryable
+		}
 	}
 
 	return req
@@ -337,6 +421,14 @@ func convertToTailorResumeCallbackRequest(data *TailorResumeCallbackData) *letra
 		ProcessingTime: data.ProcessingTime.String(),
 	}
 
+	// Carry the failure reason so the server can branch on the error code
+	if data.Error != "" {
+		req.Error = &data.Error
+	}
+	if data.ErrorCode != "" {
+		req.ErrorCode = &data.ErrorCode
+	}
+
 	// Convert TailorResume data if available
 	if data.Data != nil {
 		req.Data = &letrazv1.DataRequest{ThreadId: data.Data.ThreadID}
@@ -388,15 +480,82 @@ func convertToTailorResumeCallbackRequest(data *TailorResumeCallbackData) *letra
 		}
 	}
 
-	// Convert metadata if available
-	if data.Metadata != nil {
-		req.Metadata = &letrazv1.MetadataRequest{
-			Company:  data.Metadata.Company,
-			JobTitle: data.Metadata.JobTitle,
-			ResumeId: data.Metadata.ResumeID,
+	req.Metadata = convertToMetadataRequest(data.Metadata)
+
+	return req
+}
+
+// InterviewQuestionsCallbackData represents the data structure for InterviewQuestions callbacks
+type InterviewQuestionsCallbackData struct {
+	ProcessID      string
+	Status         string
+	Data           *InterviewQuestionsJobData
+	Timestamp      time.Time
+	Operation      string
+	ProcessingTime time.Duration
+	Metadata       *TailorResumeCallbackMetadata // same resume and job metadata as tailoring
+	Error          string
+	ErrorCode      string
+}
+
+// InterviewQuestionsJobData represents InterviewQuestions job data for callbacks
+type InterviewQuestionsJobData struct {
+	Questions []models.InterviewQuestion
+	ResumeID  string
+}
+
+// convertToInterviewQuestionsCallbackRequest converts InterviewQuestionsCallbackData to the gRPC request format
+func convertToInterviewQuestionsCallbackRequest(data *InterviewQuestionsCallbackData) *letrazv1.InterviewQuestionsCallBackRequest {
+	req := &letrazv1.InterviewQuestionsCallBackRequest{
+		ProcessId:      data.ProcessID,
+		Status:         data.Status,
+		Timestamp:      data.Timestamp.Format(time.RFC3339Nano),
+		Operation:      data.Operation,
+		ProcessingTime: data.ProcessingTime.String(),
+	}
+
+	// Carry the failure reason so the server can branch on the error code
+	if data.Error != "" {
+		req.Error = &data.Error
+	}
+	if data.ErrorCode != "" {
+		req.ErrorCode = &data.ErrorCode
+	}
+
+	if data.Data != nil {
+		req.Data = &letrazv1.InterviewQuestionsDataRequest{ResumeId: data.Data.ResumeID}
+		for _, question := range data.Data.Questions {
+			req.Data.Questions = append(req.Data.Questions, &letrazv1.InterviewQuestionRequest{
+				Question:        question.Question,
+				Category:        question.Category,
+				SuggestedAnswer: question.SuggestedAnswer,
+				Reasoning:       question.Reasoning,
+			})
 		}
 	}
 
+	req.Metadata = convertToMetadataRequest(data.Metadata)
+
+	return req
+}
+
+// convertToMetadataRequest converts the resume and job metadata of a callback, or returns nil
+func convertToMetadataRequest(metadata *TailorResumeCallbackMetadata) *letrazv1.MetadataRequest {
+	if metadata == nil {
+		return nil
+	}
+
+	req := &letrazv1.MetadataRequest{
+		Company:  metadata.Company,
+		JobTitle: metadata.JobTitle,
+		ResumeId: metadata.ResumeID,
+	}
+	if usage := metadata.LLMUsage; usage != nil {
+		req.LlmProvider = &usage.Provider
+		req.LlmInputTokens = &usage.InputTokens
+		req.LlmOutputTokens = &usage.OutputTokens
+		req.LlmCostUsd = &usage.CostUSD
+	}
 	return req
 }
 
@@ -409,6 +568,8 @@ type ScreenshotCallbackData struct {
 	Operation      string
 	ProcessingTime time.Duration
 	Metadata       *ScreenshotCallbackMetadata
+	Error          string
+	ErrorCode      string
 }
 
 // ScreenshotJobData represents screenshot job data for callbacks
@@ -435,6 +596,14 @@ func convertToScreenshotCallbackRequest(data *ScreenshotCallbackData) *letrazv1.
 		ProcessingTime: data.ProcessingTime.String(),
 	}
 
+	// Carry the failure reason so the server can branch on the error code
+	if data.Error != "" {
+		req.Error = &data.Error
+	}
+	if data.ErrorCode != "" {
+		req.ErrorCode = &data.ErrorCode
+	}
+
 	if data.Data != nil {
 		req.Data = &letrazv1.ScreenshotDataRequest{
 			ScreenshotUrl: data.Data.ScreenshotURL,
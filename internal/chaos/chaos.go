@@ -0,0 +1,189 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+)
+
+// Point is a place where faults can be injected
+type Point string
+
+const (
+	PointEngine   Point = "engine"
+	PointCallback Point = "callback"
+	PointRedis    Point = "redis"
+)
+
+// ErrInjected is wrapped by every failure the injector produces
+var ErrInjected = errors.New("chaos: injected failure")
+
+// ErrProductionEnvironment is returned when chaos is enabled in production
+var ErrProductionEnvironment = errors.New("chaos cannot be enabled in the production environment")
+
+// Injector decides at random whether to delay or fail an operation
+type Injector struct {
+	config *config.Config
+	logger logging.Logger
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	delays   map[Point]int64
+	failures map[Point]int64
+}
+
+// NewInjector creates a fault injector from configuration. It refuses to run in production.
+func NewInjector(cfg *config.Config) (*Injector, error) {
+	if strings.EqualFold(cfg.Server.Environment, "production") {
+		return nil, ErrProductionEnvironment
+	}
+
+	seed := cfg.Chaos.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Injector{
+		config:   cfg,
+		logger:   logging.GetGlobalLogger(),
+		rand:     rand.New(rand.NewSource(seed)),
+		delays:   make(map[Point]int64),
+		failures: make(map[Point]int64),
+	}, nil
+}
+
+// IsEnabled reports whether faults are being injected
+func (i *Injector) IsEnabled() bool {
+	return i != nil && i.config.Chaos.Enabled
+}
+
+// Delay sleeps for the configured latency with the configured probability. It returns early
+// with the context error when ctx is done.
+func (i *Injector) Delay(ctx context.Context, point Point) error {
+	if !i.IsEnabled() || !i.roll(i.config.Chaos.LatencyProbability) {
+		return nil
+	}
+
+	i.mu.Lock()
+	i.delays[point]++
+	i.mu.Unlock()
+
+	logging.FromContext(ctx).Debug("Chaos: injecting latency", map[string]interface{}{
+		"point":   point,
+		"latency": i.config.Chaos.Latency.String(),
+	})
+
+	timer := time.NewTimer(i.config.Chaos.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Fail returns an injected error for point with its configured probability, or nil
+func (i *Injector) Fail(point Point) error {
+	if !i.IsEnabled() || !i.roll(i.probability(point)) {
+		return nil
+	}
+
+	i.mu.Lock()
+	i.failures[point]++
+	i.mu.Unlock()
+
+	return fmt.Errorf("%w at %s", ErrInjected, point)
+}
+
+// GetStats returns how many delays and failures were injected at each point
+func (i *Injector) GetStats() map[string]interface{} {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delays := make(map[string]int64, len(i.delays))
+	for point, count := range i.delays {
+		delays[string(point)] = count
+	}
+	failures := make(map[string]int64, len(i.failures))
+	for point, count := range i.failures {
+		failures[string(point)] = count
+	}
+
+	return map[string]interface{}{
+		"enabled":  i.config.Chaos.Enabled,
+		"delays":   delays,
+		"failures": failures,
+	}
+}
+
+// probability returns the failure probability configured for point
+func (i *Injector) probability(point Point) float64 {
+	switch point {
+	case PointEngine:
+		return i.config.Chaos.EngineFailureProbability
+	case PointCallback:
+		return i.config.Chaos.CallbackFailureProbability
+	case PointRedis:
+		return i.config.Chaos.RedisErrorProbability
+	}
+	return 0
+}
+
+// roll reports true with probability p
+func (i *Injector) roll(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rand.Float64() < p
+}
+
+// Global injector instance
+var (
+	globalInjector *Injector
+	globalMu       sync.RWMutex
+)
+
+// InitializeGlobalInjector creates the global fault injector. It is a no-op when chaos is
+// disabled and fails in the production environment.
+func InitializeGlobalInjector(cfg *config.Config) error {
+	if !cfg.Chaos.Enabled {
+		return nil
+	}
+
+	injector, err := NewInjector(cfg)
+	if err != nil {
+		return err
+	}
+
+	globalMu.Lock()
+	globalInjector = injector
+	globalMu.Unlock()
+
+	injector.logger.Warn("Chaos fault injection enabled", map[string]interface{}{
+		"environment":                  cfg.Server.Environment,
+		"latency":                      cfg.Chaos.Latency.String(),
+		"latency_probability":          cfg.Chaos.LatencyProbability,
+		"engine_failure_probability":   cfg.Chaos.EngineFailureProbability,
+		"callback_failure_probability": cfg.Chaos.CallbackFailureProbability,
+		"redis_error_probability":      cfg.Chaos.RedisErrorProbability,
+	})
+	return nil
+}
+
+// GetGlobalInjector returns the global fault injector, which is nil and disabled unless chaos
+// was initialized
+func GetGlobalInjector() *Injector {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalInjector
+}
@@ -0,0 +1,35 @@
+package chaos
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor delays and fails outgoing gRPC calls, such as callback deliveries,
+// according to the global injector. Failures surface as Unavailable like a dropped connection.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		injector := GetGlobalInjector()
+		if !injector.IsEnabled() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		if err := injector.Delay(ctx, PointCallback); err != nil {
+			return err
+		}
+		if err := injector.Fail(PointCallback); err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,201 @@
+package company
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/jobsearch"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper"
+	"letraz-utils/internal/scraper/policy"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+const (
+	// defaultEngine is the scraper engine used when the request does not name one
+	defaultEngine = "hybrid"
+
+	// maxPageChars bounds the cleaned content of each page handed to the LLM
+	maxPageChars = 20000
+
+	// websiteSearchLimit caps the web results considered when looking up a company's website
+	websiteSearchLimit = 5
+)
+
+// aboutPaths are the pages read besides the homepage, in order; the first one that loads is used
+var aboutPaths = []string{"/about", "/about-us", "/company"}
+
+// directoryHosts are sites that describe companies without being their website, skipped when
+// looking up a company's website by name
+var directoryHosts = []string{
+	"linkedin.com", "wikipedia.org", "crunchbase.com", "glassdoor.com", "indeed.com",
+	"bloomberg.com", "facebook.com", "twitter.com", "x.com", "instagram.com", "youtube.com",
+}
+
+// Enricher gathers structured company information from a company's website
+type Enricher struct {
+	config         *config.Config
+	llmManager     *llm.Manager
+	scraperFactory scraper.ScraperFactory
+	httpClient     *http.Client
+}
+
+// NewEnricher creates a company enricher that reads websites with the scraper engines
+func NewEnricher(cfg *config.Config, llmManager *llm.Manager) *Enricher {
+	return &Enricher{
+		config:         cfg,
+		llmManager:     llmManager,
+		scraperFactory: scraper.NewScraperFactory(cfg, llmManager),
+		httpClient:     &http.Client{Timeout: cfg.Firecrawl.Timeout},
+	}
+}
+
+// Enrich returns information about the company at domain or, when no domain is given, about the
+// company called name, whose website is looked up with a web search
+func (e *Enricher) Enrich(ctx context.Context, name, domain string, options *models.ScrapeOptions) (*models.Company, error) {
+	logger := logging.FromContext(ctx)
+
+	website, err := e.resolveWebsite(ctx, name, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := defaultEngine
+	if options != nil && options.Engine != "" {
+		engine = options.Engine
+	}
+	s, err := e.scraperFactory.CreateScraper(engine)
+	if err != nil {
+		return nil, utils.NewValidationError(err.Error())
+	}
+	defer s.Cleanup()
+
+	fetcher, ok := s.(scraper.PageFetcher)
+	if !ok {
+		return nil, utils.NewValidationError(fmt.Sprintf("engine %q cannot read company websites", engine))
+	}
+
+	homepage, err := e.readPage(ctx, fetcher, website, options)
+	if err != nil {
+		return nil, err
+	}
+	pages := []string{homepage}
+	sourceURLs := []string{website}
+
+	for _, path := range aboutPaths {
+		pageURL := strings.TrimRight(website, "/") + path
+		page, err := e.readPage(ctx, fetcher, pageURL, options)
+		if err != nil {
+			logger.Debug("Company page could not be read", map[string]interface{}{
+				"url":   pageURL,
+				"error": err.Error(),
+			})
+			continue
+		}
+		pages = append(pages, page)
+		sourceURLs = append(sourceURLs, pageURL)
+		break
+	}
+
+	var content strings.Builder
+	for i, page := range pages {
+		fmt.Fprintf(&content, "--- %s ---\n%s\n\n", sourceURLs[i], page)
+	}
+
+	company, err := e.llmManager.ExtractCompanyData(ctx, content.String(), name, website)
+	if err != nil {
+		return nil, err
+	}
+
+	if company.Name == "" {
+		company.Name = name
+	}
+	company.Domain = hostOf(website)
+	company.Website = website
+	company.SourceURLs = sourceURLs
+
+	logger.Info("Company enriched", map[string]interface{}{
+		"company": company.Name,
+		"website": website,
+		"pages":   len(pages),
+		"engine":  engine,
+	})
+
+	return company, nil
+}
+
+// resolveWebsite returns the homepage URL of the company, from its domain when given and from
+// the first web search result outside company directories otherwise
+func (e *Enricher) resolveWebsite(ctx context.Context, name, domain string) (string, error) {
+	if domain != "" {
+		return "https://" + strings.TrimSuffix(strings.ToLower(domain), "."), nil
+	}
+
+	if e.config.Firecrawl.APIKey == "" {
+		return "", utils.NewValidationError("domain is required when web search is not configured")
+	}
+
+	results, err := jobsearch.WebSearch(ctx, e.config, e.httpClient, name+" official website", websiteSearchLimit)
+	if err != nil {
+		return "", utils.NewScrapingError(fmt.Sprintf("failed to look up the website of %q: %v", name, err))
+	}
+	for _, result := range results {
+		host := hostOf(result.URL)
+		if host == "" || isDirectoryHost(host) {
+			continue
+		}
+		return "https://" + host, nil
+	}
+
+	return "", utils.NewScrapingError(fmt.Sprintf("no website found for %q", name))
+}
+
+// readPage fetches the page at url and returns its cleaned text, bounded to maxPageChars
+func (e *Enricher) readPage(ctx context.Context, fetcher scraper.PageFetcher, url string, options *models.ScrapeOptions) (string, error) {
+	if err := policy.GetGlobalChecker().Check(ctx, url); err != nil {
+		return "", err
+	}
+
+	raw, err := fetcher.FetchPage(ctx, url, options)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := processors.NewHTMLCleaner().ExtractJobContent(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to clean page content: %w", err)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("page has no content")
+	}
+	if len(text) > maxPageChars {
+		text = strings.ToValidUTF8(text[:maxPageChars], "")
+	}
+	return text, nil
+}
+
+// hostOf returns the host of rawURL without a leading www., or "" when it cannot be parsed
+func hostOf(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// isDirectoryHost reports whether host belongs to one of directoryHosts
+func isDirectoryHost(host string) bool {
+	for _, directory := range directoryHosts {
+		if host == directory || strings.HasSuffix(host, "."+directory) {
+			return true
+		}
+	}
+	return false
+}
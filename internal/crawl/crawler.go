@@ -0,0 +1,590 @@
+package crawl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"letraz-utils/internal/ats"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper"
+	"letraz-utils/internal/scraper/policy"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+const (
+	// defaultEngine is the scraper engine used when the request does not name one
+	defaultEngine = "hybrid"
+
+	// maxSitemaps caps the sitemap files read for one site, including those listed by an index
+	maxSitemaps = 5
+
+	// maxSitemapBytes bounds a single sitemap file
+	maxSitemapBytes = 10 << 20
+
+	// firecrawlMapLimit caps the links requested from the Firecrawl map endpoint
+	firecrawlMapLimit = 5000
+)
+
+// Sources of job links, reported in the crawl result
+const (
+	SourceBoardAPI     = "board_api"
+	SourcePages        = "pages"
+	SourceSitemap      = "sitemap"
+	SourceFirecrawlMap = "firecrawl_map"
+)
+
+// jobPathMarkers are path segments under which sites list individual postings
+var jobPathMarkers = []string{
+	"job", "jobs", "career", "careers", "position", "positions", "opening", "openings",
+	"vacancy", "vacancies", "role", "roles", "opportunities", "requisitions", "postings",
+}
+
+// listingSlugs are the last path segments of listing pages that sit under a job path marker
+var listingSlugs = map[string]bool{
+	"search": true, "all": true, "page": true, "categories": true, "departments": true,
+	"locations": true, "teams": true, "apply": true, "login": true, "alerts": true,
+}
+
+// assetExtensions are file types linked from careers pages that are never postings
+var assetExtensions = map[string]bool{
+	".pdf": true, ".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".svg": true,
+	".css": true, ".js": true, ".xml": true, ".ico": true, ".webp": true, ".zip": true,
+}
+
+// Discovery is the set of job links found for a careers page
+type Discovery struct {
+	URLs    []string
+	Sources map[string]int // job links found by each source, before links found twice are merged
+}
+
+// Crawler discovers the individual job postings linked from a company's careers page
+type Crawler struct {
+	config         *config.Config
+	scraperFactory scraper.ScraperFactory
+	boards         map[string]ats.Provider
+	registry       *ats.Registry
+	httpClient     *http.Client
+}
+
+// NewCrawler creates a crawler that reads careers pages with the scraper engines
+func NewCrawler(cfg *config.Config, llmManager *llm.Manager) *Crawler {
+	httpClient := &http.Client{Timeout: cfg.Scraper.RequestTimeout}
+	return &Crawler{
+		config:         cfg,
+		scraperFactory: scraper.NewScraperFactory(cfg, llmManager),
+		boards:         ats.NewProviders(cfg, httpClient),
+		registry:       ats.NewRegistry(cfg, httpClient),
+		httpClient:     httpClient,
+	}
+}
+
+// Discover returns up to maxJobs job links for the careers page at careersURL. Boards of a
+// supported ATS are listed through its API; other sites are read page by page following their
+// pagination, and their sitemaps and the Firecrawl map of the site add the links pages miss.
+func (c *Crawler) Discover(ctx context.Context, careersURL string, maxJobs int, options *models.ScrapeOptions) (*Discovery, error) {
+	logger := logging.FromContext(ctx)
+
+	base, err := neturl.Parse(careersURL)
+	if err != nil || base.Host == "" {
+		return nil, utils.NewValidationError("invalid careers URL: " + careersURL)
+	}
+	if err := policy.GetGlobalChecker().Check(ctx, careersURL); err != nil {
+		return nil, err
+	}
+
+	discovery := &Discovery{Sources: map[string]int{}}
+	seen := map[string]bool{}
+	add := func(source string, links []string) {
+		discovery.Sources[source] += len(links)
+		for _, link := range links {
+			if !seen[link] {
+				seen[link] = true
+				discovery.URLs = append(discovery.URLs, link)
+			}
+		}
+	}
+
+	if provider, token, ok := ats.DetectBoard(careersURL); ok {
+		jobs, err := c.boards[provider].ListJobs(ctx, token)
+		if err != nil {
+			return nil, utils.NewScrapingError(fmt.Sprintf("failed to list %s board %s: %v", provider, token, err))
+		}
+		links := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			if job.JobURL != "" {
+				links = append(links, job.JobURL)
+			}
+		}
+		add(SourceBoardAPI, links)
+	} else {
+		links, err := c.crawlPages(ctx, base, options)
+		if err != nil {
+			// Sitemaps and the site map may still find the postings
+			logger.Warn("Careers page could not be read", map[string]interface{}{
+				"url":   careersURL,
+				"error": err.Error(),
+			})
+		}
+		add(SourcePages, links)
+
+		if links, err := c.sitemapLinks(ctx, base); err != nil {
+			logger.Debug("Sitemap could not be read", map[string]interface{}{
+				"url":   careersURL,
+				"error": err.Error(),
+			})
+		} else {
+			add(SourceSitemap, links)
+		}
+
+		if c.config.Firecrawl.APIKey != "" {
+			if links, _, err := c.firecrawlMapLinks(ctx, base); err != nil {
+				logger.Warn("Firecrawl map failed", map[string]interface{}{
+					"url":   careersURL,
+					"error": err.Error(),
+				})
+			} else {
+				add(SourceFirecrawlMap, links)
+			}
+		}
+	}
+
+	if len(discovery.URLs) == 0 {
+		return nil, utils.NewScrapingError("no job links found at " + careersURL)
+	}
+	if maxJobs > 0 && len(discovery.URLs) > maxJobs {
+		discovery.URLs = discovery.URLs[:maxJobs]
+	}
+
+	logger.Info("Careers page crawled", map[string]interface{}{
+		"url":     careersURL,
+		"jobs":    len(discovery.URLs),
+		"sources": discovery.Sources,
+	})
+	return discovery, nil
+}
+
+// crawlPages reads the careers page and the pages its pagination links to, up to the configured
+// page limit, and returns the job links on them
+func (c *Crawler) crawlPages(ctx context.Context, base *neturl.URL, options *models.ScrapeOptions) ([]string, error) {
+	engine := defaultEngine
+	if options != nil && options.Engine != "" {
+		engine = options.Engine
+	}
+	s, err := c.scraperFactory.CreateScraper(engine)
+	if err != nil {
+		return nil, utils.NewValidationError(err.Error())
+	}
+	defer s.Cleanup()
+
+	fetcher, ok := s.(scraper.PageFetcher)
+	if !ok {
+		return nil, utils.NewValidationError(fmt.Sprintf("engine %q cannot read careers pages", engine))
+	}
+
+	var links []string
+	visited := map[string]bool{}
+	pageURL := base
+	for pages := 0; pageURL != nil && pages < c.config.Crawl.MaxPages; pages++ {
+		visited[pageURL.String()] = true
+
+		html, err := fetcher.FetchPage(ctx, pageURL.String(), options)
+		if err != nil {
+			if pages == 0 {
+				return nil, err
+			}
+			break
+		}
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			break
+		}
+
+		doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			if link, ok := resolveLink(pageURL, a.AttrOr("href", "")); ok && c.isJobLink(base, link) {
+				links = append(links, link.String())
+			}
+		})
+
+		pageURL = nextPage(doc, pageURL, visited)
+	}
+	return links, nil
+}
+
+// nextPage returns the page the pagination of doc links to next, or nil on the last page
+func nextPage(doc *goquery.Document, pageURL *neturl.URL, visited map[string]bool) *neturl.URL {
+	var next *neturl.URL
+	doc.Find("a[href]").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		text := strings.ToLower(strings.TrimSpace(a.Text()))
+		label := strings.ToLower(a.AttrOr("aria-label", ""))
+		isNext := a.AttrOr("rel", "") == "next" || text == "next" || text == "next page" ||
+			text == "›" || text == "»" || strings.Contains(label, "next page") || label == "next"
+		if !isNext {
+			return true
+		}
+		link, err := pageURL.Parse(a.AttrOr("href", ""))
+		if err != nil || !sameSite(link, pageURL) || visited[link.String()] {
+			return true
+		}
+		link.Fragment = ""
+		next = link
+		return false
+	})
+	return next
+}
+
+// resolveLink resolves href against the page it is on, dropping its fragment and tracking parameters
+func resolveLink(pageURL *neturl.URL, href string) (*neturl.URL, bool) {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "mailto:") || strings.HasPrefix(href, "javascript:") {
+		return nil, false
+	}
+	link, err := pageURL.Parse(href)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+		return nil, false
+	}
+	link.Fragment = ""
+
+	query := link.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") {
+			query.Del(key)
+		}
+	}
+	link.RawQuery = query.Encode()
+	return link, true
+}
+
+// isJobLink reports whether link points to an individual posting of the site at base: a posting
+// on a supported ATS, a Greenhouse embed, or a page on the site below a job path marker whose
+// last segment names a posting rather than a listing
+func (c *Crawler) isJobLink(base, link *neturl.URL) bool {
+	if _, ok := c.registry.Detect(link.String()); ok {
+		return true
+	}
+	if !withinSite(link, base) {
+		return false
+	}
+	if link.Query().Get("gh_jid") != "" {
+		return true
+	}
+
+	linkPath := strings.TrimRight(link.Path, "/")
+	if linkPath == strings.TrimRight(base.Path, "/") || assetExtensions[strings.ToLower(path.Ext(linkPath))] {
+		return false
+	}
+
+	segments := strings.Split(strings.Trim(linkPath, "/"), "/")
+	last := strings.ToLower(segments[len(segments)-1])
+	if len(segments) < 2 || listingSlugs[last] {
+		return false
+	}
+	for _, segment := range segments[:len(segments)-1] {
+		if isMarker(segment) {
+			// Postings are named by an ID or a slug; single words are departments and filters
+			return strings.ContainsAny(last, "0123456789-_")
+		}
+	}
+	return false
+}
+
+// isMarker reports whether a path segment is one under which sites list postings
+func isMarker(segment string) bool {
+	segment = strings.ToLower(segment)
+	for _, marker := range jobPathMarkers {
+		if segment == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSite reports whether two URLs are on the same host, ignoring a leading www
+func sameSite(a, b *neturl.URL) bool {
+	return strings.TrimPrefix(strings.ToLower(a.Hostname()), "www.") == strings.TrimPrefix(strings.ToLower(b.Hostname()), "www.")
+}
+
+// withinSite reports whether link is on the site at base or one of its subdomains, such as the
+// jobs.example.com board of example.com
+func withinSite(link, base *neturl.URL) bool {
+	if sameSite(link, base) {
+		return true
+	}
+	host := strings.ToLower(link.Hostname())
+	return strings.HasSuffix(host, "."+strings.TrimPrefix(strings.ToLower(base.Hostname()), "www."))
+}
+
+// sitemapDocument is a sitemap or a sitemap index
+type sitemapDocument struct {
+	URLs     []string `xml:"url>loc"`
+	Sitemaps []string `xml:"sitemap>loc"`
+}
+
+// sitemapLinks returns the job links in the site's sitemaps, found through robots.txt or at
+// /sitemap.xml. Indexes are followed to the sitemaps whose names suggest they list jobs first.
+func (c *Crawler) sitemapLinks(ctx context.Context, base *neturl.URL) ([]string, error) {
+	root := &neturl.URL{Scheme: base.Scheme, Host: base.Host}
+	queue := c.robotsSitemaps(ctx, root)
+	if len(queue) == 0 {
+		queue = []string{root.ResolveReference(&neturl.URL{Path: "/sitemap.xml"}).String()}
+	}
+
+	var links []string
+	var firstErr error
+	read := map[string]bool{}
+	for len(queue) > 0 && len(read) < maxSitemaps {
+		sitemapURL := queue[0]
+		queue = queue[1:]
+		if read[sitemapURL] {
+			continue
+		}
+		read[sitemapURL] = true
+
+		var doc sitemapDocument
+		if err := c.getXML(ctx, sitemapURL, &doc); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		for _, loc := range doc.URLs {
+			if link, err := neturl.Parse(strings.TrimSpace(loc)); err == nil && c.isJobLink(base, link) {
+				links = append(links, link.String())
+			}
+		}
+		for _, child := range doc.Sitemaps {
+			child = strings.TrimSpace(child)
+			if lower := strings.ToLower(child); strings.Contains(lower, "job") || strings.Contains(lower, "career") {
+				queue = append([]string{child}, queue...)
+			} else {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	if len(links) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return links, nil
+}
+
+// robotsSitemaps returns the sitemaps declared in the site's robots.txt
+func (c *Crawler) robotsSitemaps(ctx context.Context, root *neturl.URL) []string {
+	body, err := c.get(ctx, root.ResolveReference(&neturl.URL{Path: "/robots.txt"}).String())
+	if err != nil {
+		return nil
+	}
+	var sitemaps []string
+	for _, line := range strings.Split(string(body), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "sitemap") {
+			sitemaps = append(sitemaps, strings.TrimSpace(value))
+		}
+	}
+	return sitemaps
+}
+
+// getXML fetches an XML document and decodes it into v
+func (c *Crawler) getXML(ctx context.Context, endpoint string, v interface{}) error {
+	body, err := c.get(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// get fetches endpoint and returns its body
+func (c *Crawler) get(ctx context.Context, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.config.Scraper.UserAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s returned status %d", endpoint, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSitemapBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", endpoint, err)
+	}
+	return body, nil
+}
+
+// MapJobLinks lists the job links of the careers site at careersURL from Firecrawl's map of the
+// site alone, without reading any page, and returns up to maxJobs of them. The second result is
+// the number of site URLs the map listed before links that are not postings were dropped.
+func (c *Crawler) MapJobLinks(ctx context.Context, careersURL string, maxJobs int) ([]string, int, error) {
+	base, err := neturl.Parse(careersURL)
+	if err != nil || base.Host == "" {
+		return nil, 0, utils.NewValidationError("invalid careers URL: " + careersURL)
+	}
+	if c.config.Firecrawl.APIKey == "" {
+		return nil, 0, utils.NewValidationError("the Firecrawl map needs a Firecrawl API key")
+	}
+	if err := policy.GetGlobalChecker().Check(ctx, careersURL); err != nil {
+		return nil, 0, err
+	}
+
+	links, mapped, err := c.firecrawlMapLinks(ctx, base)
+	if err != nil {
+		return nil, 0, utils.NewScrapingError("Firecrawl map failed: " + err.Error())
+	}
+	if maxJobs > 0 && len(links) > maxJobs {
+		links = links[:maxJobs]
+	}
+
+	logging.FromContext(ctx).Info("Careers site mapped", map[string]interface{}{
+		"url":    careersURL,
+		"mapped": mapped,
+		"jobs":   len(links),
+	})
+	return links, mapped, nil
+}
+
+// firecrawlMapLinks returns the job links among the site's URLs known to Firecrawl's map
+// endpoint, which finds pages that are neither linked nor in a sitemap, and the number of URLs
+// the map listed. Subdomains are mapped too, since boards often live on one. Links differing only
+// in tracking parameters, fragments or host case are listed once.
+func (c *Crawler) firecrawlMapLinks(ctx context.Context, base *neturl.URL) ([]string, int, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"url":               base.String(),
+		"limit":             firecrawlMapLimit,
+		"includeSubdomains": true,
+	})
+
+	endpoint := strings.TrimRight(c.config.Firecrawl.APIURL, "/") + "/v1/map"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create map request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.config.Firecrawl.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("map request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, 0, fmt.Errorf("map request returned status %d", resp.StatusCode)
+	}
+	var response struct {
+		Success bool     `json:"success"`
+		Links   []string `json:"links"`
+		Error   string   `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSitemapBytes)).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse map response: %w", err)
+	}
+	if !response.Success {
+		return nil, 0, errors.New("map request failed: " + response.Error)
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	for _, raw := range response.Links {
+		link, err := neturl.Parse(strings.TrimSpace(raw))
+		if err != nil || !c.isJobLink(base, link) {
+			continue
+		}
+		key := utils.CanonicalURL(link.String())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		links = append(links, link.String())
+	}
+	return links, len(response.Links), nil
+}
+
+// IsJobPage reports whether pageURL is an individual posting of the careers site at careersURL,
+// by the same rules that pick job links from careers pages
+func (c *Crawler) IsJobPage(careersURL, pageURL string) bool {
+	base, err := neturl.Parse(careersURL)
+	if err != nil || base.Host == "" {
+		return false
+	}
+	link, err := neturl.Parse(pageURL)
+	if err != nil || link.Host == "" {
+		return false
+	}
+	return c.isJobLink(base, link)
+}
+
+// StartFirecrawlCrawl starts a Firecrawl crawl of the careers site at careersURL reading up to
+// limit pages, or Firecrawl's default when limit is 0, and returns its ID. Firecrawl reports the
+// crawl's pages and its completion to the configured webhook, with processID in the events'
+// metadata.
+func (c *Crawler) StartFirecrawlCrawl(ctx context.Context, careersURL, processID string, limit int) (string, error) {
+	payload := map[string]interface{}{
+		"url": careersURL,
+		"scrapeOptions": map[string]interface{}{
+			"formats":         c.config.Firecrawl.Formats,
+			"onlyMainContent": true,
+		},
+		"webhook": map[string]interface{}{
+			"url":      c.config.Firecrawl.Webhook.URL,
+			"metadata": map[string]string{"process_id": processID},
+			"events":   []string{"started", "page", "completed", "failed"},
+		},
+	}
+	if limit > 0 {
+		payload["limit"] = limit
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal crawl request: %w", err)
+	}
+
+	endpoint := strings.TrimRight(c.config.Firecrawl.APIURL, "/") + "/v1/crawl"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create crawl request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.config.Firecrawl.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("crawl request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Success bool   `json:"success"`
+		ID      string `json:"id"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to parse crawl response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || !response.Success || response.ID == "" {
+		return "", utils.NewScrapingError(fmt.Sprintf("Firecrawl did not start the crawl (status %d): %s", resp.StatusCode, response.Error))
+	}
+	return response.ID, nil
+}
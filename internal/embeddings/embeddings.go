@@ -0,0 +1,229 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/quota"
+	"letraz-utils/pkg/utils"
+)
+
+// Default models and endpoints of the supported embedding providers
+const (
+	defaultOpenAIModel   = "text-embedding-3-small"
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultVoyageModel   = "voyage-3-lite"
+	defaultVoyageBaseURL = "https://api.voyageai.com/v1"
+)
+
+// Embedder turns texts into embedding vectors
+type Embedder interface {
+	// Embed returns one vector per text, in the order of texts
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+
+	// Model returns the name of the embedding model
+	Model() string
+}
+
+// NewEmbedder creates the embedder selected in configuration. It fails when the provider is
+// unknown or has no API key, in which case relevance pre-screening is unavailable.
+func NewEmbedder(cfg *config.Config) (Embedder, error) {
+	settings := cfg.LLM.Embeddings
+	switch strings.ToLower(settings.Provider) {
+	case "mock":
+		return &mockEmbedder{}, nil
+	case "openai", "":
+		apiKey := settings.APIKey
+		if apiKey == "" {
+			apiKey = cfg.LLM.OpenAI.APIKey
+		}
+		if apiKey == "" && cfg.LLM.Provider == "openai" {
+			apiKey = cfg.LLM.APIKey
+		}
+		return newHTTPEmbedder("openai", apiKey, settings.Model, defaultOpenAIModel, settings.BaseURL, defaultOpenAIBaseURL, cfg)
+	case "voyage":
+		return newHTTPEmbedder("voyage", settings.APIKey, settings.Model, defaultVoyageModel, settings.BaseURL, defaultVoyageBaseURL, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported embeddings provider: %s", settings.Provider)
+	}
+}
+
+// httpEmbedder calls the embeddings API of OpenAI or Voyage AI, which share a request and
+// response format
+type httpEmbedder struct {
+	httpClient *http.Client
+	provider   string
+	apiKey     string
+	model      string
+	baseURL    string
+	batchSize  int
+}
+
+func newHTTPEmbedder(provider, apiKey, model, defaultModel, baseURL, defaultBaseURL string, cfg *config.Config) (Embedder, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s embeddings API key not configured - set EMBEDDINGS_API_KEY", provider)
+	}
+	if model == "" {
+		model = defaultModel
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	batchSize := cfg.LLM.Embeddings.BatchSize
+	if batchSize <= 0 {
+		batchSize = 64
+	}
+
+	return &httpEmbedder{
+		httpClient: &http.Client{Timeout: cfg.LLM.Embeddings.Timeout},
+		provider:   provider,
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		batchSize:  batchSize,
+	}, nil
+}
+
+// embeddingsRequest is the request body of the embeddings API
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// embeddingsResponse is the subset of the embeddings response the embedder reads. OpenAI reports
+// prompt tokens and Voyage AI total tokens.
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int64 `json:"prompt_tokens"`
+		TotalTokens  int64 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// embeddingsAPIError is a non-2xx response from an embeddings API
+type embeddingsAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *embeddingsAPIError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// Model returns the name of the embedding model
+func (he *httpEmbedder) Model() string {
+	return he.model
+}
+
+// Embed embeds texts in batches and records the tokens against the caller's quota and the
+// analysis spend
+func (he *httpEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += he.batchSize {
+		batch, err := he.embedBatch(ctx, texts[start:min(start+he.batchSize, len(texts))])
+		if err != nil {
+			return nil, he.classifyError(err)
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// embedBatch sends one embeddings request
+func (he *httpEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(embeddingsRequest{Model: he.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embeddings request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, he.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+he.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := he.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &embeddingsAPIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errorBody struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(data, &errorBody) == nil {
+			if errorBody.Error.Message != "" {
+				apiErr.Message = errorBody.Error.Message
+			} else if errorBody.Detail != "" {
+				apiErr.Message = errorBody.Detail
+			}
+		}
+		return nil, apiErr
+	}
+
+	var response embeddingsResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+
+	tokens := response.Usage.PromptTokens
+	if tokens == 0 {
+		tokens = response.Usage.TotalTokens
+	}
+	quota.RecordLLMTokens(ctx, tokens)
+	cost.Record(cost.WithOperation(ctx, cost.OperationAnalysis), he.provider, he.model, tokens, 0)
+
+	vectors := make([][]float64, len(texts))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has out of range index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, fmt.Errorf("embeddings response is missing the vector of input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
+// classifyError maps an embeddings API error onto the error taxonomy
+func (he *httpEmbedder) classifyError(err error) error {
+	var apiErr *embeddingsAPIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return utils.NewRateLimitedError(fmt.Sprintf("%s embeddings API: %v", he.provider, err))
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("%s embeddings API: %v", he.provider, err))
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("%s embeddings API: %v", he.provider, err))
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return utils.NewTimeoutError(fmt.Sprintf("%s embeddings API call timed out: %v", he.provider, err))
+	}
+	return utils.NewLLMError(fmt.Sprintf("failed to call %s embeddings API: %v", he.provider, err))
+}
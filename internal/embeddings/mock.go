@@ -0,0 +1,59 @@
+package embeddings
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/quota"
+	"letraz-utils/internal/scoring"
+)
+
+// mockDimensions is the length of the vectors of the mock embedder
+const mockDimensions = 256
+
+// mockEmbedder hashes the keyword terms of a text into a fixed-size vector, so texts sharing
+// terms are similar. It serves test mode and local development without an API key.
+type mockEmbedder struct{}
+
+// Model returns the name of the mock model
+func (me *mockEmbedder) Model() string {
+	return "mock"
+}
+
+// Embed returns the hashed term vector of each text
+func (me *mockEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	var tokens int64
+	for i, text := range texts {
+		vector := make([]float64, mockDimensions)
+		for _, term := range scoring.Terms(text) {
+			hash := fnv.New32a()
+			hash.Write([]byte(term))
+			vector[hash.Sum32()%mockDimensions]++
+		}
+		normalize(vector)
+		vectors[i] = vector
+		tokens += int64(len(text) / 4)
+	}
+
+	quota.RecordLLMTokens(ctx, tokens)
+	cost.Record(cost.WithOperation(ctx, cost.OperationAnalysis), "mock", "mock", tokens, 0)
+	return vectors, nil
+}
+
+// normalize scales vector to unit length in place
+func normalize(vector []float64) {
+	var sum float64
+	for _, value := range vector {
+		sum += value * value
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for i := range vector {
+		vector[i] /= norm
+	}
+}
@@ -0,0 +1,138 @@
+package embeddings
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"letraz-utils/internal/scoring"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// Cosine similarities of embeddings rarely leave this band: unrelated texts score around the
+// floor and near paraphrases around the ceiling. Scores are similarities rescaled from the band
+// to 0-100.
+const (
+	similarityFloor   = 0.2
+	similarityCeiling = 0.7
+)
+
+// maxTextRunes bounds each embedded text well within the input limits of embedding models
+const maxTextRunes = 8000
+
+// Relevance embeds the resume sections and the job requirements and matches every requirement
+// with its closest section. The overall score averages the requirement scores; a resume is
+// relevant when it reaches threshold.
+func Relevance(ctx context.Context, embedder Embedder, resume *models.BaseResume, job *models.Job, threshold int) (*models.Relevance, error) {
+	var sections []scoring.SectionText
+	for _, section := range scoring.ResumeSections(resume) {
+		if section.Text != "" {
+			sections = append(sections, section)
+		}
+	}
+	if len(sections) == 0 {
+		return nil, utils.NewValidationError("resume has no section text to compare")
+	}
+	requirements := jobRequirements(job)
+
+	texts := make([]string, 0, len(sections)+len(requirements))
+	for _, section := range sections {
+		texts = append(texts, truncate(section.Text))
+	}
+	for _, requirement := range requirements {
+		texts = append(texts, truncate(requirement))
+	}
+
+	vectors, err := embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	sectionVectors, requirementVectors := vectors[:len(sections)], vectors[len(sections):]
+
+	relevance := &models.Relevance{
+		Requirements: make([]models.RequirementRelevance, len(requirements)),
+		Sections:     make([]models.SectionRelevance, len(sections)),
+		Model:        embedder.Model(),
+	}
+	for i, section := range sections {
+		relevance.Sections[i] = models.SectionRelevance{Type: section.Type, Similarity: -1}
+	}
+
+	total := 0
+	for i, requirement := range requirements {
+		match := models.RequirementRelevance{Requirement: requirement, Similarity: -1}
+		for j, section := range sections {
+			similarity := cosine(requirementVectors[i], sectionVectors[j])
+			if similarity > match.Similarity {
+				match.Similarity = similarity
+				match.BestSection = section.Type
+			}
+			if similarity > relevance.Sections[j].Similarity {
+				relevance.Sections[j].Similarity = similarity
+			}
+		}
+		match.Similarity = round(match.Similarity)
+		match.Score = similarityScore(match.Similarity)
+		total += match.Score
+		relevance.Requirements[i] = match
+	}
+	for i := range relevance.Sections {
+		relevance.Sections[i].Similarity = round(relevance.Sections[i].Similarity)
+		relevance.Sections[i].Score = similarityScore(relevance.Sections[i].Similarity)
+	}
+
+	relevance.Score = int(math.Round(float64(total) / float64(len(requirements))))
+	relevance.Relevant = relevance.Score >= threshold
+	return relevance, nil
+}
+
+// jobRequirements returns the texts of a job the resume is matched against: its requirements,
+// or its responsibilities when it lists none, or else its title and description
+func jobRequirements(job *models.Job) []string {
+	for _, list := range [][]string{job.Requirements, job.Responsibilities} {
+		var texts []string
+		for _, text := range list {
+			if text = strings.TrimSpace(text); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) > 0 {
+			return texts
+		}
+	}
+	return []string{strings.TrimSpace(job.Title + "\n" + scoring.StripHTML(job.Description))}
+}
+
+// cosine returns the cosine similarity of two vectors, or zero when either is empty
+func cosine(a, b []float64) float64 {
+	var dot, normA, normB float64
+	for i := 0; i < len(a) && i < len(b); i++ {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// similarityScore rescales a cosine similarity to a 0-100 score
+func similarityScore(similarity float64) int {
+	score := (similarity - similarityFloor) / (similarityCeiling - similarityFloor) * 100
+	return int(math.Round(math.Min(100, math.Max(0, score))))
+}
+
+// round rounds a similarity to three decimals for display
+func round(similarity float64) float64 {
+	return math.Round(similarity*1000) / 1000
+}
+
+// truncate returns at most maxTextRunes runes of text
+func truncate(text string) string {
+	if runes := []rune(text); len(runes) > maxTextRunes {
+		return string(runes[:maxTextRunes])
+	}
+	return text
+}
@@ -48,8 +48,8 @@ func ExportResume(_ context.Context, cfg *config.Config, resume models.BaseResum
 		return "", "", fmt.Errorf("%w: %v", ErrCompile, err)
 	}
 
-	// Init Spaces client
-	spaces, err := utils.NewSpacesClient(cfg)
+	// Init object storage (Spaces, or memory storage in test mode)
+	spaces, err := utils.NewObjectStorage(cfg)
 	if err != nil {
 		logger.Error("Storage not configured for export", map[string]interface{}{
 			"resume_id": resume.ID,
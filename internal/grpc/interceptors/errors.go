@@ -0,0 +1,84 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"letraz-utils/pkg/utils"
+)
+
+// grpcCodes maps the error taxonomy onto gRPC status codes
+var grpcCodes = map[utils.ErrorCode]codes.Code{
+	utils.ErrCodeBadRequest:           codes.InvalidArgument,
+	utils.ErrCodeNotFound:             codes.NotFound,
+	utils.ErrCodeValidationFailed:     codes.InvalidArgument,
+	utils.ErrCodeConfiguration:        codes.FailedPrecondition,
+	utils.ErrCodeUnauthorized:         codes.Unauthenticated,
+	utils.ErrCodeNotJobPosting:        codes.FailedPrecondition,
+	utils.ErrCodeCaptchaUnsolved:      codes.Unavailable,
+	utils.ErrCodeScrapingFailed:       codes.Internal,
+	utils.ErrCodeEngineTimeout:        codes.DeadlineExceeded,
+	utils.ErrCodePolicyDenied:         codes.PermissionDenied,
+	utils.ErrCodeLLMFailed:            codes.Internal,
+	utils.ErrCodeLLMParseFailed:       codes.Internal,
+	utils.ErrCodeLLMUnavailable:       codes.Unavailable,
+	utils.ErrCodeRateLimited:          codes.ResourceExhausted,
+	utils.ErrCodeQueueFull:            codes.ResourceExhausted,
+	utils.ErrCodeQuotaExceeded:        codes.ResourceExhausted,
+	utils.ErrCodeTimeout:              codes.DeadlineExceeded,
+	utils.ErrCodeTaskSubmissionFailed: codes.Unavailable,
+	utils.ErrCodeDraining:             codes.Unavailable,
+	utils.ErrCodeInternal:             codes.Internal,
+}
+
+// GRPCCode returns the gRPC status code for an error taxonomy code
+func GRPCCode(code utils.ErrorCode) codes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+	return codes.Internal
+}
+
+// ErrorInterceptor returns a gRPC unary interceptor that converts typed errors into gRPC
+// statuses, carrying the taxonomy code as the status message prefix. Errors with a suggested
+// retry delay, such as full queues, carry it as a RetryInfo detail and a retry-after header in
+// seconds.
+func ErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		// Errors that already carry a gRPC status are passed through untouched
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		if customErr, ok := utils.AsCustomError(err); ok {
+			st := status.New(GRPCCode(customErr.ErrorCode), fmt.Sprintf("%s: %s", customErr.ErrorCode, customErr.Error()))
+			if customErr.RetryAfter > 0 {
+				if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(customErr.RetryAfter)}); detailErr == nil {
+					st = detailed
+				}
+				_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(utils.RetryAfterSeconds(customErr.RetryAfter))))
+			}
+			return resp, st.Err()
+		}
+
+		return resp, err
+	}
+}
@@ -6,13 +6,40 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"letraz-utils/internal/logging"
 	"letraz-utils/pkg/utils"
 )
 
-// LoggingInterceptor returns a gRPC unary interceptor that logs requests and responses
+// requestIDMetadataKey is the metadata key a caller may send its own request ID under
+const requestIDMetadataKey = "x-request-id"
+
+// requestIDContextKey is the context key under which LoggingInterceptor stores a call's request ID
+type requestIDContextKey struct{}
+
+// incomingRequestID returns the request ID the caller sent, or a new one
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return utils.GenerateRequestID()
+}
+
+// requestIDFromContext returns the request ID LoggingInterceptor gave the call, or a new one
+// when it did not run first
+func requestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDContextKey{}).(string); ok {
+		return requestID
+	}
+	return utils.GenerateRequestID()
+}
+
+// LoggingInterceptor returns a gRPC unary interceptor that logs requests and responses. It
+// stores the call's request ID in the context, so the interceptors after it record the same one.
 func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -23,8 +50,8 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 		startTime := time.Now()
 		logger := logging.GetGlobalLogger()
 
-		// Extract request ID from context if available
-		requestID := utils.GenerateRequestID()
+		requestID := incomingRequestID(ctx)
+		ctx = context.WithValue(ctx, requestIDContextKey{}, requestID)
 
 		// Log request start
 		logger.Info("gRPC request started", map[string]interface{}{
@@ -0,0 +1,85 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/quota"
+	"letraz-utils/pkg/utils"
+)
+
+// quotaResources lists the metered resources of each task submission method
+var quotaResources = map[string][]quota.Resource{
+	letrazv1.ScraperService_ScrapeJob_FullMethodName:         {quota.ResourceScrapes, quota.ResourceLLMTokens},
+	letrazv1.ResumeService_TailorResume_FullMethodName:       {quota.ResourceTailorings, quota.ResourceLLMTokens},
+	letrazv1.ResumeService_GenerateScreenshot_FullMethodName: {quota.ResourceScreenshots},
+}
+
+// statusResponse is implemented by responses that report FAILURE in the message body
+type statusResponse interface {
+	GetStatus() string
+}
+
+// QuotaInterceptor returns a gRPC unary interceptor that authenticates the API key sent in
+// metadata and enforces per-key quotas on task submissions. It must run inside
+// ErrorInterceptor so its errors become Unauthenticated and ResourceExhausted statuses.
+func QuotaInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		manager := quota.GetGlobalManager()
+		if !manager.IsEnabled() {
+			return handler(ctx, req)
+		}
+
+		var secret string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(strings.ToLower(manager.Header())); len(values) > 0 {
+				secret = values[0]
+			}
+		}
+
+		var key *quota.APIKey
+		if secret != "" {
+			authenticated, err := manager.Authenticate(secret)
+			if err != nil {
+				return nil, utils.NewUnauthorizedError("unknown API key")
+			}
+			key = authenticated
+			ctx = quota.WithAPIKey(ctx, key)
+		} else if manager.RequireKey() {
+			return nil, utils.NewUnauthorizedError("an API key is required in the " + manager.Header() + " metadata")
+		}
+
+		resources, metered := quotaResources[info.FullMethod]
+		if !metered {
+			return handler(ctx, req)
+		}
+
+		if err := manager.Check(ctx, key, resources...); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if status, ok := resp.(statusResponse); ok && status.GetStatus() == "FAILURE" {
+			return resp, nil
+		}
+
+		for _, resource := range resources {
+			if resource != quota.ResourceLLMTokens {
+				manager.Record(ctx, key, resource, 1)
+			}
+		}
+		return resp, nil
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/health"
 	"letraz-utils/pkg/utils"
 )
 
@@ -35,17 +36,13 @@ func (s *Server) HealthCheck(ctx context.Context, req *letrazv1.HealthCheckReque
 		"api":  "ok",
 	}
 
-	// Add additional health checks based on available services
-	if s.poolManager != nil && s.poolManager.IsHealthy() {
-		checks["workers"] = "ok"
-	} else {
-		checks["workers"] = "degraded"
-	}
-
-	if s.llmManager != nil {
-		checks["llm"] = "ok"
-	} else {
-		checks["llm"] = "unavailable"
+	// Add dependency checks from the shared health check cache
+	for name, result := range health.GetGlobalChecker().Results(ctx) {
+		if result.Healthy {
+			checks[name] = "ok"
+		} else {
+			checks[name] = "degraded"
+		}
 	}
 
 	// Create response following the same pattern as HTTP health endpoint
@@ -9,7 +9,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/background"
 	"letraz-utils/internal/exporter"
+	"letraz-utils/internal/logging"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 	"strings"
@@ -35,6 +37,9 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 	if req.GetResumeId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Resume ID is required")
 	}
+	if err := s.cfg.ValidateModel(req.GetModel()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// Check LLM manager health
 	if !s.llmManager.IsHealthy() {
@@ -47,7 +52,7 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 			Status:    "FAILURE",
 			Message:   "LLM manager is not healthy",
 			Timestamp: time.Now().Format(time.RFC3339Nano),
-			Error:     "LLM_UNAVAILABLE: LLM manager is not healthy",
+			Error:     string(utils.ErrCodeLLMUnavailable) + ": LLM manager is not healthy",
 		}, nil
 	}
 
@@ -60,6 +65,7 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 		BaseResume: *baseResume,
 		Job:        *job,
 		ResumeID:   req.GetResumeId(),
+		Model:      req.GetModel(),
 	}
 
 	// Generate process ID for background task
@@ -74,8 +80,10 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 		"company":        req.GetJob().GetCompanyName(),
 	})
 
+	ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+
 	// Submit task to background task manager (async processing)
-	err := s.taskManager.SubmitTailorTask(ctx, processID, tailorReq, s.llmManager, s.cfg)
+	err := s.taskManager.SubmitTailorTask(ctx, processID, tailorReq, background.TaskPriorityInteractive, s.llmManager, s.cfg)
 	if err != nil {
 		s.logger.Error("Failed to submit background tailor task", map[string]interface{}{
 			"request_id": requestID,
@@ -83,12 +91,16 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 			"error":      err.Error(),
 		})
 
+		if capacityError(err) {
+			return nil, err
+		}
+
 		return &letrazv1.TailorResumeResponse{
 			ProcessId: processID,
 			Status:    "FAILURE",
 			Message:   "Failed to submit resume tailoring task for background processing",
 			Timestamp: time.Now().Format(time.RFC3339Nano),
-			Error:     "TASK_SUBMISSION_FAILED: " + err.Error(),
+			Error:     submissionErrorCode(err) + ": " + err.Error(),
 		}, nil
 	}
 
@@ -108,6 +120,74 @@ func (s *Server) TailorResume(ctx context.Context, req *letrazv1.TailorResumeReq
 	}, nil
 }
 
+// GenerateInterviewQuestions implements the GenerateInterviewQuestions gRPC method (async processing)
+func (s *Server) GenerateInterviewQuestions(ctx context.Context, req *letrazv1.InterviewQuestionsRequest) (*letrazv1.InterviewQuestionsResponse, error) {
+	requestID := utils.GenerateRequestID()
+
+	s.logger.Info("gRPC async interview questions request received", map[string]interface{}{
+		"request_id": requestID,
+		"resume_id":  req.GetResumeId(),
+		"method":     "GenerateInterviewQuestions",
+	})
+
+	// Validate request
+	if req.GetBaseResume() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Base resume is required")
+	}
+	if req.GetJob() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Job information is required")
+	}
+	if req.GetResumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Resume ID is required")
+	}
+	if count := req.GetQuestionCount(); count < 0 || count > 25 {
+		return nil, status.Error(codes.InvalidArgument, "Question count must be between 1 and 25")
+	}
+
+	interviewReq := models.InterviewQuestionsRequest{
+		BaseResume:    *convertGRPCBaseResumeToModel(req.GetBaseResume()),
+		Job:           *convertGRPCJobToModel(req.GetJob()),
+		ResumeID:      req.GetResumeId(),
+		QuestionCount: int(req.GetQuestionCount()),
+	}
+
+	processID := utils.GenerateInterviewProcessID()
+	ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+
+	if err := s.taskManager.SubmitInterviewTask(ctx, processID, interviewReq, background.TaskPriorityInteractive, s.llmManager); err != nil {
+		s.logger.Error("Failed to submit background interview questions task", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"error":      err.Error(),
+		})
+
+		if capacityError(err) {
+			return nil, err
+		}
+
+		return &letrazv1.InterviewQuestionsResponse{
+			ProcessId: processID,
+			Status:    "FAILURE",
+			Message:   "Failed to submit interview questions task for background processing",
+			Timestamp: time.Now().Format(time.RFC3339Nano),
+			Error:     submissionErrorCode(err) + ": " + err.Error(),
+		}, nil
+	}
+
+	s.logger.Info("Interview questions task submitted successfully for background processing", map[string]interface{}{
+		"request_id": requestID,
+		"process_id": processID,
+		"resume_id":  req.GetResumeId(),
+	})
+
+	return &letrazv1.InterviewQuestionsResponse{
+		ProcessId: processID,
+		Status:    "ACCEPTED",
+		Message:   "Interview questions request accepted for background processing",
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+	}, nil
+}
+
 // convertGRPCBaseResumeToModel converts gRPC BaseResume to internal model
 func convertGRPCBaseResumeToModel(grpcResume *letrazv1.BaseResume) *models.BaseResume {
 	if grpcResume == nil {
@@ -190,8 +270,8 @@ func convertGRPCJobToModel(grpcJob *letrazv1.Job) *models.Job {
 	// Convert salary object
 	salary := models.Salary{
 		Currency: grpcJob.GetSalary().GetCurrency(),
-		Min:      int(grpcJob.GetSalary().GetMin()),
-		Max:      int(grpcJob.GetSalary().GetMax()),
+		Min:      float64(grpcJob.GetSalary().GetMin()),
+		Max:      float64(grpcJob.GetSalary().GetMax()),
 	}
 
 	return &models.Job{
@@ -205,6 +285,7 @@ func convertGRPCJobToModel(grpcJob *letrazv1.Job) *models.Job {
 		Description:      grpcJob.GetDescription(),
 		Responsibilities: grpcJob.GetResponsibilities(),
 		Benefits:         grpcJob.GetBenefits(),
+		OriginalLanguage: grpcJob.GetOriginalLanguage(),
 	}
 }
 
@@ -256,19 +337,25 @@ func (s *Server) GenerateScreenshot(ctx context.Context, req *letrazv1.ResumeScr
 		"resume_id":  req.GetResumeId(),
 	})
 
+	ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+
 	// Submit task to background task manager
-	err := s.taskManager.SubmitScreenshotTask(ctx, processID, screenshotReq, s.cfg)
+	err := s.taskManager.SubmitScreenshotTask(ctx, processID, screenshotReq, background.TaskPriorityInteractive, s.cfg)
 	if err != nil {
 		s.logger.Error("Failed to submit gRPC background screenshot task", map[string]interface{}{
 			"request_id": requestID,
 			"error":      err.Error(),
 		})
 
+		if capacityError(err) {
+			return nil, err
+		}
+
 		return &letrazv1.ResumeScreenshotResponse{
 			Status:    "FAILURE",
 			Message:   "Failed to submit screenshot task: " + err.Error(),
 			Timestamp: time.Now().Format(time.RFC3339Nano),
-			Error:     "TASK_SUBMISSION_FAILED",
+			Error:     submissionErrorCode(err),
 		}, nil
 	}
 
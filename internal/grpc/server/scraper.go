@@ -2,12 +2,16 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/background"
+	"letraz-utils/internal/logging"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -33,6 +37,10 @@ func (s *Server) ScrapeJob(ctx context.Context, req *letrazv1.ScrapeJobRequest)
 		return nil, status.Error(codes.InvalidArgument, "cannot provide both URL and description - choose one")
 	}
 
+	if err := s.cfg.ValidateModel(req.GetOptions().GetModel()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Convert gRPC request to internal model
 	scrapeReq := models.ScrapeRequest{
 		URL:         req.GetUrl(),
@@ -51,8 +59,10 @@ func (s *Server) ScrapeJob(ctx context.Context, req *letrazv1.ScrapeJobRequest)
 		"mode":        getProcessingMode(req.GetUrl(), req.GetDescription()),
 	})
 
+	ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+
 	// Submit task to background task manager (async processing)
-	err := s.taskManager.SubmitScrapeTask(ctx, processID, scrapeReq, s.poolManager)
+	err := s.taskManager.SubmitScrapeTask(ctx, processID, scrapeReq, background.ScrapeTaskPriority(scrapeReq.Options, background.TaskPriorityInteractive), s.poolManager)
 	if err != nil {
 		s.logger.Error("Failed to submit background scrape task", map[string]interface{}{
 			"request_id": requestID,
@@ -60,12 +70,16 @@ func (s *Server) ScrapeJob(ctx context.Context, req *letrazv1.ScrapeJobRequest)
 			"error":      err.Error(),
 		})
 
+		if capacityError(err) {
+			return nil, err
+		}
+
 		return &letrazv1.ScrapeJobResponse{
 			ProcessId: processID,
 			Status:    "FAILURE",
 			Message:   "Failed to submit scraping task for background processing",
 			Timestamp: time.Now().Format(time.RFC3339Nano),
-			Error:     "TASK_SUBMISSION_FAILED: " + err.Error(),
+			Error:     submissionErrorCode(err) + ": " + err.Error(),
 		}, nil
 	}
 
@@ -87,6 +101,87 @@ func (s *Server) ScrapeJob(ctx context.Context, req *letrazv1.ScrapeJobRequest)
 	}, nil
 }
 
+// BatchScrapeJobs handles scraping several job URLs as one background task
+func (s *Server) BatchScrapeJobs(ctx context.Context, req *letrazv1.BatchScrapeJobsRequest) (*letrazv1.ScrapeJobResponse, error) {
+	requestID := utils.GenerateRequestID()
+
+	s.logger.Info("gRPC async batch scrape request received", map[string]interface{}{
+		"request_id": requestID,
+		"url_count":  len(req.GetUrls()),
+		"method":     "BatchScrapeJobs",
+	})
+
+	// Validate request
+	if len(req.GetUrls()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one URL is required")
+	}
+	if maxURLs := s.cfg.BackgroundTasks.MaxBatchURLs; maxURLs > 0 && len(req.GetUrls()) > maxURLs {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch accepts at most %d URLs", maxURLs)
+	}
+	for _, url := range req.GetUrls() {
+		if url == "" {
+			return nil, status.Error(codes.InvalidArgument, "URLs cannot be empty")
+		}
+	}
+
+	if err := s.cfg.ValidateModel(req.GetOptions().GetModel()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Convert gRPC request to internal model
+	batchReq := models.BatchScrapeRequest{
+		URLs:    req.GetUrls(),
+		Options: convertGRPCOptionsToModel(req.GetOptions()),
+	}
+
+	// Generate process ID for background task
+	processID := utils.GenerateBatchScrapeProcessID()
+
+	s.logger.Info("Submitting batch scrape task for background processing", map[string]interface{}{
+		"request_id": requestID,
+		"process_id": processID,
+		"url_count":  len(req.GetUrls()),
+	})
+
+	ctx = logging.ContextWithFields(ctx, map[string]interface{}{logging.FieldRequestID: requestID})
+
+	// Submit task to background task manager (async processing)
+	err := s.taskManager.SubmitBatchScrapeTask(ctx, processID, batchReq, background.ScrapeTaskPriority(batchReq.Options, background.TaskPriorityBulk), s.poolManager)
+	if err != nil {
+		s.logger.Error("Failed to submit background batch scrape task", map[string]interface{}{
+			"request_id": requestID,
+			"process_id": processID,
+			"error":      err.Error(),
+		})
+
+		if capacityError(err) {
+			return nil, err
+		}
+
+		return &letrazv1.ScrapeJobResponse{
+			ProcessId: processID,
+			Status:    "FAILURE",
+			Message:   "Failed to submit batch scraping task for background processing",
+			Timestamp: time.Now().Format(time.RFC3339Nano),
+			Error:     submissionErrorCode(err) + ": " + err.Error(),
+		}, nil
+	}
+
+	s.logger.Info("Batch scrape task submitted successfully for background processing", map[string]interface{}{
+		"request_id": requestID,
+		"process_id": processID,
+		"url_count":  len(req.GetUrls()),
+	})
+
+	return &letrazv1.ScrapeJobResponse{
+		ProcessId: processID,
+		Status:    "ACCEPTED",
+		Message:   fmt.Sprintf("Batch scraping request for %d URLs accepted for background processing", len(req.GetUrls())),
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Error:     "",
+	}, nil
+}
+
 // getProcessingMode returns a string indicating the processing mode
 func getProcessingMode(url, description string) string {
 	if description != "" {
@@ -95,6 +190,23 @@ func getProcessingMode(url, description string) string {
 	return "url"
 }
 
+// submissionErrorCode returns the taxonomy code for a task submission failure, falling back
+// to TASK_SUBMISSION_FAILED for untyped errors
+func submissionErrorCode(err error) string {
+	if customErr, ok := utils.AsCustomError(err); ok {
+		return string(customErr.ErrorCode)
+	}
+	return string(utils.ErrCodeTaskSubmissionFailed)
+}
+
+// capacityError reports whether a submission failed for lack of capacity: a full queue, a rate
+// limit, a quota or a draining instance. Those are returned as errors, which become
+// RESOURCE_EXHAUSTED or UNAVAILABLE with a retry delay, so clients back off instead of reading
+// a FAILURE response.
+func capacityError(err error) bool {
+	return utils.GetHTTPStatus(err) == http.StatusTooManyRequests || utils.IsErrorCode(err, utils.ErrCodeDraining)
+}
+
 // convertGRPCOptionsToModel converts gRPC ScrapeOptions to internal model
 func convertGRPCOptionsToModel(options *letrazv1.ScrapeOptions) *models.ScrapeOptions {
 	if options == nil {
@@ -102,11 +214,15 @@ func convertGRPCOptionsToModel(options *letrazv1.ScrapeOptions) *models.ScrapeOp
 	}
 
 	return &models.ScrapeOptions{
-		Engine:      options.GetEngine(),
-		Timeout:     time.Duration(options.GetTimeoutSeconds()) * time.Second,
-		LLMProvider: options.GetLlmProvider(),
-		UserAgent:   options.GetUserAgent(),
-		Proxy:       options.GetProxy(),
+		Engine:       options.GetEngine(),
+		Timeout:      time.Duration(options.GetTimeoutSeconds()) * time.Second,
+		LLMProvider:  options.GetLlmProvider(),
+		UserAgent:    options.GetUserAgent(),
+		Proxy:        options.GetProxy(),
+		Priority:     options.GetPriority(),
+		NoCache:      options.GetNoCache(),
+		ForceRefresh: options.GetForceRefresh(),
+		Model:        options.GetModel(),
 	}
 }
 
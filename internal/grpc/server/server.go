@@ -30,6 +30,7 @@ type Server struct {
 	letrazv1.UnimplementedScraperServiceServer
 	letrazv1.UnimplementedResumeServiceServer
 	letrazv1.UnimplementedHealthServiceServer
+	letrazv1.UnimplementedTaskServiceServer
 }
 
 func NewServer(cfg *config.Config, poolManager *workers.PoolManager, llmManager *llm.Manager, taskManager background.TaskManager) *Server {
@@ -58,6 +59,9 @@ func (s *Server) Start(lis net.Listener) error {
 			interceptors.RecoveryInterceptor(),
 			interceptors.LoggingInterceptor(),
 			interceptors.MetricsInterceptor(),
+			interceptors.AuditInterceptor(),
+			interceptors.ErrorInterceptor(),
+			interceptors.QuotaInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
 			interceptors.StreamRecoveryInterceptor(),
@@ -70,6 +74,7 @@ func (s *Server) Start(lis net.Listener) error {
 	letrazv1.RegisterScraperServiceServer(s.grpcServer, s)
 	letrazv1.RegisterResumeServiceServer(s.grpcServer, s)
 	letrazv1.RegisterHealthServiceServer(s.grpcServer, s)
+	letrazv1.RegisterTaskServiceServer(s.grpcServer, s)
 
 	// Enable reflection for debugging
 	reflection.Register(s.grpcServer)
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	letrazv1 "letraz-utils/api/proto/letraz/v1"
+	"letraz-utils/internal/background"
+	"letraz-utils/pkg/utils"
+)
+
+// ListTasks implements the ListTasks gRPC method
+func (s *Server) ListTasks(ctx context.Context, req *letrazv1.ListTasksRequest) (*letrazv1.ListTasksResponse, error) {
+	requestID := utils.GenerateRequestID()
+
+	s.logger.Debug("gRPC list tasks request received", map[string]interface{}{
+		"request_id": requestID,
+		"method":     "ListTasks",
+		"status":     req.GetStatus(),
+		"type":       req.GetType(),
+	})
+
+	filter := background.TaskFilter{
+		Status:   background.TaskStatus(req.GetStatus()),
+		Type:     background.TaskType(req.GetType()),
+		ResumeID: req.GetResumeId(),
+		URL:      req.GetUrl(),
+		Cursor:   req.GetCursor(),
+		Limit:    int(req.GetLimit()),
+	}
+	var err error
+	if filter.CreatedAfter, err = parseTaskTime(req.GetCreatedAfter()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "created_after must be an RFC 3339 time")
+	}
+	if filter.CreatedBefore, err = parseTaskTime(req.GetCreatedBefore()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "created_before must be an RFC 3339 time")
+	}
+
+	page, err := s.taskManager.ListTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &letrazv1.ListTasksResponse{
+		Tasks:      make([]*letrazv1.TaskSummary, 0, len(page.Tasks)),
+		NextCursor: page.NextCursor,
+	}
+	for _, task := range page.Tasks {
+		response.Tasks = append(response.Tasks, convertTaskToSummary(task))
+	}
+	return response, nil
+}
+
+// parseTaskTime parses an optional RFC 3339 time, returning the zero time when it is empty
+func parseTaskTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// convertTaskToSummary converts a task result, without its result data, to a TaskSummary
+func convertTaskToSummary(task *background.TaskResult) *letrazv1.TaskSummary {
+	summary := &letrazv1.TaskSummary{
+		ProcessId: task.ProcessID,
+		Type:      string(task.Type),
+		Status:    string(task.Status),
+		Error:     task.Error,
+		ErrorCode: task.ErrorCode,
+		CreatedAt: task.CreatedAt.Format(time.RFC3339),
+	}
+	if task.CompletedAt != nil {
+		summary.CompletedAt = task.CompletedAt.Format(time.RFC3339)
+	}
+	if task.ProcessingTime != nil {
+		summary.ProcessingTimeMs = task.ProcessingTime.Milliseconds()
+	}
+
+	// Metadata holds values such as times that structpb does not accept, so it goes through JSON
+	if len(task.Metadata) > 0 {
+		if data, err := json.Marshal(task.Metadata); err == nil {
+			var metadata map[string]interface{}
+			if json.Unmarshal(data, &metadata) == nil {
+				summary.Metadata, _ = structpb.NewStruct(metadata)
+			}
+		}
+	}
+	return summary
+}
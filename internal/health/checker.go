@@ -0,0 +1,310 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+)
+
+// CheckFunc probes a single dependency and returns an error if it is unhealthy
+type CheckFunc func(ctx context.Context) error
+
+// DetailedCheckFunc probes a dependency and also reports measurements such as latency or memory use
+type DetailedCheckFunc func(ctx context.Context) (map[string]interface{}, error)
+
+// Result is the cached outcome of a dependency health check
+type Result struct {
+	Name      string        `json:"name"`
+	Healthy   bool          `json:"healthy"`
+	Error     string        `json:"error,omitempty"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Duration  time.Duration `json:"duration"`
+	Stale     bool          `json:"stale,omitempty"`
+
+	// Critical checks fail readiness; non-critical failures only degrade the service
+	Critical bool                   `json:"critical"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// Status returns the short status string used in health endpoint check maps
+func (r Result) Status() string {
+	if r.Healthy {
+		return "ok"
+	}
+	if !r.Critical {
+		return "degraded"
+	}
+	return "unhealthy"
+}
+
+// registeredCheck holds a check, its refresh schedule and its latest result
+type registeredCheck struct {
+	name       string
+	check      DetailedCheckFunc
+	critical   bool
+	interval   time.Duration
+	ttl        time.Duration
+	result     *Result
+	refreshing bool
+	done       chan struct{}
+}
+
+// Checker caches dependency health results and refreshes them in the background with jitter,
+// so health endpoints never fan out probes (some of which, like the LLM check, are billed)
+type Checker struct {
+	checks  map[string]*registeredCheck
+	timeout time.Duration
+	jitter  float64
+	logger  types.Logger
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+// NewChecker creates a new health checker from configuration
+func NewChecker(cfg *config.Config) *Checker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Checker{
+		checks:  make(map[string]*registeredCheck),
+		timeout: cfg.Health.CheckTimeout,
+		jitter:  cfg.Health.Jitter,
+		logger:  logging.GetGlobalLogger(),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Register adds a critical dependency check refreshed every interval. Cached results are served
+// for up to twice the interval before a read forces a fresh probe.
+func (c *Checker) Register(name string, interval time.Duration, check CheckFunc) {
+	c.RegisterDetailed(name, interval, true, func(ctx context.Context) (map[string]interface{}, error) {
+		return nil, check(ctx)
+	})
+}
+
+// RegisterDetailed adds a dependency check that reports measurements alongside its outcome.
+// Non-critical checks degrade the service instead of failing readiness.
+func (c *Checker) RegisterDetailed(name string, interval time.Duration, critical bool, check DetailedCheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rc := &registeredCheck{
+		name:     name,
+		check:    check,
+		critical: critical,
+		interval: interval,
+		ttl:      2 * interval,
+	}
+	c.checks[name] = rc
+
+	if c.started {
+		c.startRefreshLoop(rc)
+	}
+}
+
+// SetResult seeds the cached result for a check, e.g. with the outcome of a startup probe
+func (c *Checker) SetResult(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rc, exists := c.checks[name]; exists {
+		rc.result = newResult(rc, err, time.Now(), 0)
+	}
+}
+
+// Start begins jittered background refresh of all registered checks
+func (c *Checker) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started {
+		return
+	}
+	c.started = true
+
+	for _, rc := range c.checks {
+		c.startRefreshLoop(rc)
+	}
+}
+
+// Stop stops background refresh
+func (c *Checker) Stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Result returns the cached result for a check, probing only if nothing fresh is cached
+func (c *Checker) Result(ctx context.Context, name string) (Result, bool) {
+	if c == nil {
+		return Result{}, false
+	}
+
+	c.mu.Lock()
+	rc, exists := c.checks[name]
+	if !exists {
+		c.mu.Unlock()
+		return Result{}, false
+	}
+
+	if rc.result != nil && time.Since(rc.result.CheckedAt) <= rc.ttl {
+		result := *rc.result
+		c.mu.Unlock()
+		return result, true
+	}
+
+	// Serve the stale result while a refresh is already in flight
+	if rc.refreshing && rc.result != nil {
+		result := *rc.result
+		result.Stale = true
+		c.mu.Unlock()
+		return result, true
+	}
+	c.mu.Unlock()
+
+	return c.refresh(ctx, rc), true
+}
+
+// Results returns cached results for every registered check
+func (c *Checker) Results(ctx context.Context) map[string]Result {
+	results := make(map[string]Result)
+	if c == nil {
+		return results
+	}
+
+	c.mu.Lock()
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	c.mu.Unlock()
+
+	for _, name := range names {
+		if result, ok := c.Result(ctx, name); ok {
+			results[name] = result
+		}
+	}
+	return results
+}
+
+// refresh runs a check, collapsing concurrent refreshes of the same check into one probe
+func (c *Checker) refresh(ctx context.Context, rc *registeredCheck) Result {
+	c.mu.Lock()
+	if rc.refreshing {
+		done := rc.done
+		c.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if rc.result == nil {
+			return *newResult(rc, fmt.Errorf("health check did not complete"), time.Now(), 0)
+		}
+		return *rc.result
+	}
+	rc.refreshing = true
+	rc.done = make(chan struct{})
+	c.mu.Unlock()
+
+	checkCtx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	details, err := rc.check(checkCtx)
+	result := newResult(rc, err, startTime, time.Since(startTime))
+	result.Details = details
+
+	c.mu.Lock()
+	previous := rc.result
+	rc.result = result
+	rc.refreshing = false
+	close(rc.done)
+	c.mu.Unlock()
+
+	if previous == nil || previous.Healthy != result.Healthy {
+		c.logger.Info("Dependency health changed", map[string]interface{}{
+			"check":   rc.name,
+			"healthy": result.Healthy,
+			"error":   result.Error,
+		})
+	}
+
+	return *result
+}
+
+// startRefreshLoop starts the background refresh goroutine for a check. Callers must hold mu.
+func (c *Checker) startRefreshLoop(rc *registeredCheck) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			timer := time.NewTimer(c.jitteredInterval(rc.interval))
+			select {
+			case <-timer.C:
+				c.refresh(c.ctx, rc)
+			case <-c.ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// jitteredInterval spreads refreshes by +/- the configured jitter fraction to avoid probe bursts
+func (c *Checker) jitteredInterval(interval time.Duration) time.Duration {
+	if c.jitter <= 0 {
+		return interval
+	}
+	jitterRange := float64(interval) * c.jitter
+	return interval + time.Duration((rand.Float64()*2-1)*jitterRange)
+}
+
+// newResult builds a result from a check outcome
+func newResult(rc *registeredCheck, err error, checkedAt time.Time, duration time.Duration) *Result {
+	result := &Result{
+		Name:      rc.name,
+		Critical:  rc.critical,
+		Healthy:   err == nil,
+		CheckedAt: checkedAt,
+		Duration:  duration,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Global checker instance
+var (
+	globalChecker *Checker
+	globalMu      sync.RWMutex
+)
+
+// InitializeGlobalChecker creates the global health checker
+func InitializeGlobalChecker(cfg *config.Config) *Checker {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	globalChecker = NewChecker(cfg)
+	return globalChecker
+}
+
+// GetGlobalChecker returns the global health checker, or nil if not initialized
+func GetGlobalChecker() *Checker {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalChecker
+}
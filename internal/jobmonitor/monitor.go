@@ -0,0 +1,611 @@
+package jobmonitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"letraz-utils/internal/callback"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/scraper/workers"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// Callback operations sent for monitored jobs
+const (
+	OperationJobUpdated = "job_updated"
+	OperationJobClosed  = "job_closed"
+)
+
+const (
+	// watchKeyPrefix namespaces watches in the key-value store
+	watchKeyPrefix = "job_monitor:watch:"
+
+	// claimKeyPrefix namespaces the claims replicas sharing the store take on due watches, so
+	// each due watch is checked once
+	claimKeyPrefix = "job_monitor:claim:"
+
+	// claimTTL outlives a check, and bounds how long a watch stays claimed by a replica that
+	// died checking it
+	claimTTL = 10 * time.Minute
+)
+
+var (
+	// ErrWatchNotFound is returned when no watch exists with the given ID
+	ErrWatchNotFound = errors.New("watch not found")
+
+	// ErrTooManyWatches is returned when registering beyond the configured maximum
+	ErrTooManyWatches = errors.New("maximum number of watches reached")
+)
+
+// closedPhrases mark a posting that is still served but no longer open
+var closedPhrases = []string{
+	"no longer accepting applications",
+	"no longer available",
+	"position has been filled",
+	"job has expired",
+	"posting has expired",
+	"job is closed",
+	"this job has been closed",
+}
+
+// WatchStatus is the state of a monitored job
+type WatchStatus string
+
+const (
+	WatchStatusActive WatchStatus = "active"
+	WatchStatusClosed WatchStatus = "closed"
+)
+
+// Watch is a job URL registered for monitoring and what was last seen there
+type Watch struct {
+	ID                  string                `json:"id"`
+	URL                 string                `json:"url"`
+	Interval            time.Duration         `json:"interval"`
+	Options             *models.ScrapeOptions `json:"options,omitempty"`
+	Status              WatchStatus           `json:"status"`
+	ContentHash         string                `json:"content_hash,omitempty"`
+	Job                 *models.Job           `json:"job,omitempty"`
+	Checks              int64                 `json:"checks"`
+	ConsecutiveFailures int                   `json:"consecutive_failures"`
+	LastError           string                `json:"last_error,omitempty"`
+	CreatedAt           time.Time             `json:"created_at"`
+	NextCheckAt         time.Time             `json:"next_check_at"`
+	LastCheckedAt       *time.Time            `json:"last_checked_at,omitempty"`
+	LastChangedAt       *time.Time            `json:"last_changed_at,omitempty"`
+	ClosedAt            *time.Time            `json:"closed_at,omitempty"`
+}
+
+// Monitor re-scrapes registered job URLs on a schedule and sends callbacks when a posting
+// changes or closes
+type Monitor struct {
+	config         *config.Config
+	store          kv.Store
+	poolManager    *workers.PoolManager
+	callbackClient *callback.Client
+	logger         logging.Logger
+
+	mu     sync.Mutex // serializes read-modify-write of watches
+	slots  chan struct{}
+	ctx    context.Context // cancelled on Stop, aborting in-flight checks
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMonitor creates a job monitor; callbacks are only logged when callbackClient is nil
+func NewMonitor(cfg *config.Config, store kv.Store, poolManager *workers.PoolManager, callbackClient *callback.Client) *Monitor {
+	concurrency := cfg.JobMonitor.MaxConcurrentChecks
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Monitor{
+		config:         cfg,
+		store:          store,
+		poolManager:    poolManager,
+		callbackClient: callbackClient,
+		logger:         logging.GetGlobalLogger(),
+		slots:          make(chan struct{}, concurrency),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Start runs the scheduler that checks due watches every CheckInterval
+func (m *Monitor) Start() {
+	interval := m.config.JobMonitor.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	m.wg.Add(1)
+	go m.run(interval)
+
+	m.logger.Info("Job monitor started", map[string]interface{}{
+		"check_interval":   interval.String(),
+		"default_interval": m.config.JobMonitor.DefaultInterval.String(),
+		"backend":          m.store.Backend(),
+	})
+}
+
+// Stop stops the scheduler and waits for in-flight checks to finish
+func (m *Monitor) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// Register starts monitoring url. Registering a URL that is already monitored, as any URL
+// differing only in host case, fragment or utm_ parameters, returns the existing watch and false.
+func (m *Monitor) Register(ctx context.Context, url string, interval time.Duration, options *models.ScrapeOptions) (*Watch, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The watch ID is derived from the URL, so replicas registering the same URL store one watch
+	id := watchID(url)
+	if watch, err := m.Get(ctx, id); err == nil {
+		return watch, false, nil
+	} else if !errors.Is(err, ErrWatchNotFound) {
+		return nil, false, err
+	}
+	if max := m.config.JobMonitor.MaxWatches; max > 0 {
+		count, err := m.count(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if count >= max {
+			return nil, false, ErrTooManyWatches
+		}
+	}
+
+	now := time.Now()
+	watch := &Watch{
+		ID:          id,
+		URL:         url,
+		Interval:    m.clampInterval(interval),
+		Options:     options,
+		Status:      WatchStatusActive,
+		CreatedAt:   now,
+		NextCheckAt: now, // the first check records the baseline content
+	}
+	if err := m.save(ctx, watch); err != nil {
+		return nil, false, err
+	}
+
+	m.logger.Info("Job watch registered", map[string]interface{}{
+		"watch_id": watch.ID,
+		"url":      url,
+		"interval": watch.Interval.String(),
+	})
+	return watch, true, nil
+}
+
+// Get returns a watch by ID
+func (m *Monitor) Get(ctx context.Context, id string) (*Watch, error) {
+	data, err := m.store.Get(ctx, watchKeyPrefix+id)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return nil, ErrWatchNotFound
+		}
+		return nil, fmt.Errorf("failed to load watch: %w", err)
+	}
+
+	var watch Watch
+	if err := json.Unmarshal(data, &watch); err != nil {
+		return nil, fmt.Errorf("failed to decode watch: %w", err)
+	}
+	return &watch, nil
+}
+
+// List returns all watches ordered by creation time
+func (m *Monitor) List(ctx context.Context) ([]*Watch, error) {
+	return m.list(ctx)
+}
+
+// Remove stops monitoring a watch
+func (m *Monitor) Remove(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := m.store.Delete(ctx, watchKeyPrefix+id); err != nil {
+		return fmt.Errorf("failed to delete watch: %w", err)
+	}
+
+	m.logger.Info("Job watch removed", map[string]interface{}{
+		"watch_id": id,
+	})
+	return nil
+}
+
+// watchID returns the ID of the watch of url, derived from its canonical form
+func watchID(url string) string {
+	sum := sha256.Sum256([]byte(utils.CanonicalURL(url)))
+	return "monitor_" + hex.EncodeToString(sum[:12])
+}
+
+// count returns the number of stored watches without loading them
+func (m *Monitor) count(ctx context.Context) (int, error) {
+	var count atomic.Int64
+	err := m.store.Scan(ctx, watchKeyPrefix, func(string) error {
+		count.Add(1)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count watches: %w", err)
+	}
+	return int(count.Load()), nil
+}
+
+// list loads every stored watch
+func (m *Monitor) list(ctx context.Context) ([]*Watch, error) {
+	var keys []string
+	var keysMu sync.Mutex
+	err := m.store.Scan(ctx, watchKeyPrefix, func(key string) error {
+		keysMu.Lock()
+		keys = append(keys, key)
+		keysMu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watches: %w", err)
+	}
+
+	watches := make([]*Watch, 0, len(keys))
+	for _, key := range keys {
+		watch, err := m.Get(ctx, strings.TrimPrefix(key, watchKeyPrefix))
+		if err != nil {
+			if errors.Is(err, ErrWatchNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		watches = append(watches, watch)
+	}
+
+	sort.Slice(watches, func(i, j int) bool {
+		return watches[i].CreatedAt.Before(watches[j].CreatedAt)
+	})
+	return watches, nil
+}
+
+// save stores a watch without expiration
+func (m *Monitor) save(ctx context.Context, watch *Watch) error {
+	data, err := json.Marshal(watch)
+	if err != nil {
+		return fmt.Errorf("failed to encode watch: %w", err)
+	}
+	if err := m.store.Set(ctx, watchKeyPrefix+watch.ID, data, 0); err != nil {
+		return fmt.Errorf("failed to store watch: %w", err)
+	}
+	return nil
+}
+
+// clampInterval applies the default and minimum re-scrape intervals
+func (m *Monitor) clampInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		interval = m.config.JobMonitor.DefaultInterval
+	}
+	if min := m.config.JobMonitor.MinInterval; interval < min {
+		interval = min
+	}
+	return interval
+}
+
+// run checks due watches until the monitor is stopped
+func (m *Monitor) run(interval time.Duration) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.checkDue()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkDue()
+		}
+	}
+}
+
+// checkDue starts a check for every active watch whose next check time has passed, bounded
+// by MaxConcurrentChecks. Each watch is claimed first, so replicas sharing the store check it
+// and send its callbacks once.
+func (m *Monitor) checkDue() {
+	ctx, cancel := context.WithTimeout(m.ctx, 30*time.Second)
+	watches, err := m.list(ctx)
+	cancel()
+	if err != nil {
+		m.logger.Warn("Failed to load job watches", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	for _, watch := range watches {
+		if watch.Status != WatchStatusActive || watch.NextCheckAt.After(now) {
+			continue
+		}
+
+		select {
+		case m.slots <- struct{}{}:
+		case <-m.ctx.Done():
+			return
+		}
+
+		claimed := m.claim(watch.ID)
+		if claimed == nil {
+			<-m.slots
+			continue
+		}
+
+		m.wg.Add(1)
+		go func(watch *Watch) {
+			defer m.wg.Done()
+			defer func() {
+				m.release(watch.ID)
+				<-m.slots
+			}()
+			m.check(watch)
+		}(claimed)
+	}
+}
+
+// claim reserves a due watch for this instance and returns it as stored now, or nil when it is
+// claimed elsewhere or no longer due; it may have been checked since the watches were listed
+func (m *Monitor) claim(id string) *Watch {
+	ctx, cancel := context.WithTimeout(m.ctx, 5*time.Second)
+	defer cancel()
+
+	claims, err := m.store.IncrBy(ctx, claimKeyPrefix+id, 1, claimTTL)
+	if err != nil {
+		m.logger.Warn("Failed to claim job watch", map[string]interface{}{
+			"watch_id": id,
+			"error":    err.Error(),
+		})
+		return nil
+	}
+	if claims != 1 {
+		return nil
+	}
+
+	watch, err := m.Get(ctx, id)
+	if err != nil || watch.Status != WatchStatusActive || watch.NextCheckAt.After(time.Now()) {
+		m.release(id)
+		return nil
+	}
+	return watch
+}
+
+// release gives up the claim on a watch once its check stored the next check time
+func (m *Monitor) release(id string) {
+	if err := m.store.Delete(context.WithoutCancel(m.ctx), claimKeyPrefix+id); err != nil {
+		m.logger.Warn("Failed to release job watch claim", map[string]interface{}{
+			"watch_id": id,
+			"error":    err.Error(),
+		})
+	}
+}
+
+// check re-scrapes a watched URL and compares the result against the last seen content
+func (m *Monitor) check(watch *Watch) {
+	ctx := logging.ContextWithFields(m.ctx, map[string]interface{}{
+		"watch_id": watch.ID,
+		"url":      watch.URL,
+	})
+	logger := logging.FromContext(ctx)
+
+	// A check must see the live posting, never a job cached by another scrape
+	options := &models.ScrapeOptions{}
+	if watch.Options != nil {
+		*options = *watch.Options
+	}
+	options.ForceRefresh = true
+
+	result, err := m.poolManager.SubmitJobWithPriority(ctx, watch.URL, options, workers.PriorityLow)
+	if ctx.Err() != nil {
+		// Shutting down; the watch stays due and is checked after the next start
+		return
+	}
+
+	// Watch updates must land even if the monitor stops while they are written
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+	if err != nil {
+		// The pool refused the job (queue full, domain limited); try again later without
+		// counting it against the watch
+		retryAt := now.Add(m.config.JobMonitor.CheckInterval)
+		if retryAfter := utils.GetRetryAfter(err); retryAfter > 0 {
+			retryAt = now.Add(retryAfter)
+		}
+		logger.Info("Job watch check deferred", map[string]interface{}{
+			"error":    err.Error(),
+			"retry_at": retryAt,
+		})
+		m.update(ctx, watch.ID, func(w *Watch) string {
+			w.NextCheckAt = retryAt
+			return ""
+		})
+		return
+	}
+
+	m.update(ctx, watch.ID, func(w *Watch) string {
+		w.Checks++
+		w.LastCheckedAt = &now
+		w.NextCheckAt = now.Add(w.Interval)
+
+		if result.Error != nil {
+			if utils.IsErrorCode(result.Error, utils.ErrCodeNotJobPosting) {
+				w.close(now)
+				return OperationJobClosed
+			}
+			w.ConsecutiveFailures++
+			w.LastError = result.Error.Error()
+			logger.Warn("Job watch check failed", map[string]interface{}{
+				"error":                result.Error.Error(),
+				"consecutive_failures": w.ConsecutiveFailures,
+			})
+			return ""
+		}
+
+		if result.Job == nil {
+			w.ConsecutiveFailures++
+			w.LastError = "scrape returned no job data"
+			return ""
+		}
+
+		w.ConsecutiveFailures = 0
+		w.LastError = ""
+
+		if looksClosed(result.Job) {
+			w.Job = result.Job
+			w.close(now)
+			return OperationJobClosed
+		}
+
+		hash := contentHash(result.Job)
+		previous := w.ContentHash
+		w.ContentHash = hash
+		w.Job = result.Job
+		if previous == "" || previous == hash {
+			// First check records the baseline; unchanged content needs no callback
+			return ""
+		}
+
+		w.LastChangedAt = &now
+		return OperationJobUpdated
+	})
+}
+
+// update applies fn to the stored watch and sends the callback operation it returns. Only the
+// instance holding the watch's claim updates it. Watches removed while a check was running are
+// left deleted.
+func (m *Monitor) update(ctx context.Context, id string, fn func(*Watch) string) {
+	logger := logging.FromContext(ctx)
+
+	m.mu.Lock()
+	watch, err := m.Get(ctx, id)
+	if err != nil {
+		m.mu.Unlock()
+		if !errors.Is(err, ErrWatchNotFound) {
+			logger.Warn("Failed to load job watch", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	operation := fn(watch)
+	if err := m.save(ctx, watch); err != nil {
+		logger.Error("Failed to store job watch", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	m.mu.Unlock()
+
+	if operation != "" {
+		m.notify(ctx, watch, operation)
+	}
+}
+
+// notify sends a scrape callback for a changed or closed job
+func (m *Monitor) notify(ctx context.Context, watch *Watch, operation string) {
+	logger := logging.FromContext(ctx)
+	logger.Info("Monitored job changed", map[string]interface{}{
+		"operation": operation,
+		"status":    watch.Status,
+	})
+
+	if m.callbackClient == nil {
+		return
+	}
+
+	engine := "hybrid"
+	if watch.Options != nil && watch.Options.Engine != "" {
+		engine = watch.Options.Engine
+	}
+
+	data := &callback.CallbackData{
+		ProcessID: watch.ID,
+		Status:    "success",
+		Timestamp: time.Now(),
+		Operation: operation,
+		Metadata: &callback.CallbackMetadata{
+			Engine: engine,
+			URL:    watch.URL,
+		},
+	}
+	if watch.Job != nil {
+		data.Data = &callback.CallbackJobData{
+			Job:     watch.Job,
+			Engine:  engine,
+			UsedLLM: true,
+		}
+	}
+
+	if err := m.callbackClient.SendScrapeJobCallback(ctx, data); err != nil {
+		logger.Error("Failed to send job monitor callback", map[string]interface{}{
+			"operation": operation,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// close marks the watch closed; closed watches are no longer checked
+func (w *Watch) close(now time.Time) {
+	w.Status = WatchStatusClosed
+	w.ClosedAt = &now
+}
+
+// looksClosed reports whether a scraped posting says it no longer accepts applications
+func looksClosed(job *models.Job) bool {
+	text := strings.ToLower(job.Title + "\n" + job.Description)
+	for _, phrase := range closedPhrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentHash fingerprints the fields of a job that matter to applicants, ignoring whitespace
+// differences between scrapes
+func contentHash(job *models.Job) string {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	}
+
+	parts := []string{
+		normalize(job.Title),
+		normalize(job.CompanyName),
+		normalize(job.Location),
+		normalize(job.Description),
+		fmt.Sprintf("%s %g %g", job.Salary.Currency, job.Salary.Min, job.Salary.Max),
+	}
+	for _, list := range [][]string{job.Requirements, job.Responsibilities, job.Benefits} {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = normalize(item)
+		}
+		parts = append(parts, strings.Join(items, "\x1f"))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1e")))
+	return hex.EncodeToString(sum[:])
+}
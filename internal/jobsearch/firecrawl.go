@@ -0,0 +1,95 @@
+package jobsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/pkg/models"
+)
+
+// firecrawlSearchLimit caps the web results requested per search
+const firecrawlSearchLimit = 10
+
+// WebResult is a page returned by a Firecrawl web search
+type WebResult struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// firecrawlSearch runs a Firecrawl web search for job postings matching query. Results only
+// carry the title, URL and snippet of each page.
+func (s *Searcher) firecrawlSearch(ctx context.Context, query Query) ([]models.Job, error) {
+	searchQuery := query.Q + " job"
+	if query.Location != "" {
+		searchQuery += " " + query.Location
+	}
+
+	results, err := WebSearch(ctx, s.config, s.httpClient, searchQuery, firecrawlSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.Job, 0, len(results))
+	for _, result := range results {
+		if result.URL == "" || result.Title == "" {
+			continue
+		}
+		jobs = append(jobs, models.Job{
+			Title:       result.Title,
+			JobURL:      result.URL,
+			Description: result.Description,
+		})
+	}
+	return jobs, nil
+}
+
+// WebSearch runs a Firecrawl web search for query and returns up to limit results
+func WebSearch(ctx context.Context, cfg *config.Config, httpClient *http.Client, query string, limit int) ([]WebResult, error) {
+	if cfg.Firecrawl.APIKey == "" {
+		return nil, errors.New("firecrawl API key not configured")
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"query": query,
+		"limit": limit,
+	})
+
+	endpoint := strings.TrimRight(cfg.Firecrawl.APIURL, "/") + "/v1/search"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.Firecrawl.APIKey)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("search request returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Success bool        `json:"success"`
+		Data    []WebResult `json:"data"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+	return response.Data, nil
+}
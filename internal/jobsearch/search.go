@@ -0,0 +1,294 @@
+package jobsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"letraz-utils/internal/ats"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+)
+
+// cacheKeyPrefix namespaces cached board listings in the key-value store
+const cacheKeyPrefix = "job_search:board:"
+
+// SourceFirecrawl names results found by the Firecrawl web search
+const SourceFirecrawl = "firecrawl"
+
+// Query is a job search request
+type Query struct {
+	Q        string
+	Location string
+	Limit    int
+}
+
+// Result is a ranked job found by a search
+type Result struct {
+	Job    models.Job `json:"job"`
+	Source string     `json:"source"` // ATS name or firecrawl
+	Score  float64    `json:"score"`
+}
+
+// SourceStatus reports how one board or search backend contributed to a search
+type SourceStatus struct {
+	Source  string `json:"source"`
+	Company string `json:"company,omitempty"`
+	Jobs    int    `json:"jobs"`
+	Cached  bool   `json:"cached"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Results holds the ranked, deduplicated jobs and the status of every source
+type Results struct {
+	Results []Result       `json:"results"`
+	Sources []SourceStatus `json:"sources"`
+}
+
+// board is a registered company job board
+type board struct {
+	company  string
+	provider ats.Provider
+	token    string
+}
+
+// Searcher fans a query out to the job boards of registered companies and ranks the matches
+type Searcher struct {
+	config     *config.Config
+	store      kv.Store
+	boards     []board
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewSearcher creates a job searcher over the companies registered in configuration
+func NewSearcher(cfg *config.Config, store kv.Store) *Searcher {
+	httpClient := &http.Client{Timeout: cfg.JobSearch.Timeout}
+	providers := ats.NewProviders(cfg, httpClient)
+	logger := logging.GetGlobalLogger()
+
+	s := &Searcher{
+		config:     cfg,
+		store:      store,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+
+	for _, company := range cfg.JobSearch.Companies {
+		provider, supported := providers[strings.ToLower(company.ATS)]
+		if !supported || company.Board == "" {
+			logger.Warn("Skipping job search company with an unsupported ATS or no board", map[string]interface{}{
+				"company":   company.Name,
+				"ats":       company.ATS,
+				"supported": ats.SupportedProviders(),
+			})
+			continue
+		}
+		s.boards = append(s.boards, board{company: company.Name, provider: provider, token: company.Board})
+	}
+
+	return s
+}
+
+// Search returns the jobs matching query across all boards, best matches first. Sources that
+// fail are reported in the results instead of failing the search.
+func (s *Searcher) Search(ctx context.Context, query Query) (*Results, error) {
+	terms := strings.Fields(strings.ToLower(query.Q))
+	if len(terms) == 0 {
+		return nil, errors.New("a search query is required")
+	}
+
+	limit := s.config.JobSearch.MaxResults
+	if query.Limit > 0 && query.Limit < limit {
+		limit = query.Limit
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = &Results{Results: []Result{}}
+		found   []Result
+	)
+	collect := func(status SourceStatus, jobs []models.Job) {
+		mu.Lock()
+		defer mu.Unlock()
+		results.Sources = append(results.Sources, status)
+		for _, job := range jobs {
+			if score := score(job, terms, query.Location); score > 0 {
+				found = append(found, Result{Job: job, Source: status.Source, Score: score})
+			}
+		}
+	}
+
+	concurrency := s.config.JobSearch.MaxConcurrentSources
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	for _, b := range s.boards {
+		wg.Add(1)
+		go func(b board) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status := SourceStatus{Source: b.provider.Name(), Company: b.company}
+			jobs, cached, err := s.listing(ctx, b)
+			if err != nil {
+				status.Error = err.Error()
+				logging.FromContext(ctx).Warn("Job search source failed", map[string]interface{}{
+					"source":  status.Source,
+					"company": b.company,
+					"error":   err.Error(),
+				})
+			}
+			status.Jobs = len(jobs)
+			status.Cached = cached
+			collect(status, jobs)
+		}(b)
+	}
+
+	if s.config.JobSearch.Firecrawl {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := SourceStatus{Source: SourceFirecrawl}
+			jobs, err := s.firecrawlSearch(ctx, query)
+			if err != nil {
+				status.Error = err.Error()
+			}
+			status.Jobs = len(jobs)
+			collect(status, jobs)
+		}()
+	}
+
+	wg.Wait()
+
+	sort.SliceStable(results.Sources, func(i, j int) bool {
+		if results.Sources[i].Source != results.Sources[j].Source {
+			return results.Sources[i].Source < results.Sources[j].Source
+		}
+		return results.Sources[i].Company < results.Sources[j].Company
+	})
+
+	results.Results = rank(found, limit)
+	return results, nil
+}
+
+// BoardCount returns the number of registered company boards
+func (s *Searcher) BoardCount() int {
+	return len(s.boards)
+}
+
+// listing returns the jobs on a board, from the cache when a recent listing is stored
+func (s *Searcher) listing(ctx context.Context, b board) ([]models.Job, bool, error) {
+	key := cacheKeyPrefix + b.provider.Name() + ":" + b.token
+	if data, err := s.store.Get(ctx, key); err == nil {
+		var jobs []models.Job
+		if err := json.Unmarshal(data, &jobs); err == nil {
+			return jobs, true, nil
+		}
+	}
+
+	jobs, err := b.provider.ListJobs(ctx, b.token)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range jobs {
+		if jobs[i].CompanyName == "" {
+			jobs[i].CompanyName = b.company
+		}
+	}
+
+	if data, err := json.Marshal(jobs); err == nil {
+		if err := s.store.Set(ctx, key, data, s.config.JobSearch.CacheTTL); err != nil {
+			s.logger.Warn("Failed to cache job board listing", map[string]interface{}{
+				"key":   key,
+				"error": err.Error(),
+			})
+		}
+	}
+	return jobs, false, nil
+}
+
+// score rates how well job matches the query terms and location; zero means no match
+func score(job models.Job, terms []string, location string) float64 {
+	title := strings.ToLower(job.Title)
+	company := strings.ToLower(job.CompanyName)
+	description := strings.ToLower(job.Description)
+
+	var total float64
+	matched := 0
+	for _, term := range terms {
+		switch {
+		case strings.Contains(title, term):
+			total += 3
+		case strings.Contains(company, term):
+			total += 2
+		case strings.Contains(description, term):
+			total += 1
+		default:
+			continue
+		}
+		matched++
+	}
+	if matched == 0 {
+		return 0
+	}
+	if strings.Contains(title, strings.Join(terms, " ")) {
+		total += 5
+	}
+	// Jobs matching only some terms rank below jobs matching all of them
+	total *= float64(matched) / float64(len(terms))
+
+	if location != "" && job.Location != "" {
+		if !strings.Contains(strings.ToLower(job.Location), strings.ToLower(location)) {
+			return 0
+		}
+		total += 2
+	}
+	return total
+}
+
+// rank deduplicates results by job URL, or by title, company and location when there is no
+// URL, and returns up to limit results with the best scores first
+func rank(found []Result, limit int) []Result {
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Score != found[j].Score {
+			return found[i].Score > found[j].Score
+		}
+		return found[i].Job.Title < found[j].Job.Title
+	})
+
+	seen := make(map[string]bool, len(found))
+	ranked := make([]Result, 0, limit)
+	for _, result := range found {
+		key := dedupeKey(result.Job)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ranked = append(ranked, result)
+		if len(ranked) == limit {
+			break
+		}
+	}
+	return ranked
+}
+
+// dedupeKey identifies the same job listed by several sources
+func dedupeKey(job models.Job) string {
+	if parsed, err := url.Parse(job.JobURL); err == nil && parsed.Host != "" {
+		return strings.ToLower(parsed.Host) + strings.TrimRight(parsed.Path, "/")
+	}
+	return strings.ToLower(fmt.Sprintf("%s|%s|%s", job.Title, job.CompanyName, job.Location))
+}
@@ -0,0 +1,96 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+)
+
+// ErrNotFound is returned when a key does not exist or has expired
+var ErrNotFound = errors.New("kv: key not found")
+
+// NoExpiry is the TTL reported for keys stored without an expiration
+const NoExpiry time.Duration = -1
+
+// Storage backends
+const (
+	BackendAuto   = "auto"
+	BackendRedis  = "redis"
+	BackendMemory = "memory"
+)
+
+// Store is a storage-agnostic key-value store used for conversation history, caches and counters
+type Store interface {
+	// Get returns the value stored at key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value at key; a ttl of zero stores the key without expiration
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+
+	// IncrBy atomically adds delta to the integer stored at key and returns the new value. A
+	// missing key starts at zero and is given ttl; existing keys keep their expiration.
+	IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// TTL returns the remaining time to live of key, NoExpiry if it has none, or ErrNotFound
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// Scan calls fn for every key starting with prefix; fn may be called concurrently when
+	// the backend is sharded
+	Scan(ctx context.Context, prefix string, fn func(key string) error) error
+
+	// Ping checks that the store is reachable
+	Ping(ctx context.Context) error
+
+	// Backend returns the backend name
+	Backend() string
+
+	// Close releases resources owned by the store
+	Close() error
+}
+
+// NewStore creates the store selected by configuration. In auto mode Redis is used when it
+// answers a ping at startup, otherwise the in-memory store is used so local development
+// works without Redis.
+func NewStore(cfg *config.Config, client redis.UniversalClient) Store {
+	logger := logging.GetGlobalLogger()
+
+	switch cfg.KV.Backend {
+	case BackendMemory:
+		return NewMemoryStore()
+
+	case BackendRedis:
+		if client != nil {
+			return NewRedisStore(client)
+		}
+		logger.Warn("Redis KV backend requested without a Redis client, using in-memory store", nil)
+		return NewMemoryStore()
+
+	case "", BackendAuto:
+		if client != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := client.Ping(ctx).Err(); err == nil {
+				return NewRedisStore(client)
+			} else {
+				logger.Warn("Redis unreachable, using in-memory KV store", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		return NewMemoryStore()
+
+	default:
+		logger.Warn("Unknown KV backend, using in-memory store", map[string]interface{}{
+			"backend": cfg.KV.Backend,
+		})
+		return NewMemoryStore()
+	}
+}
@@ -0,0 +1,184 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a single value held by the in-memory store
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // zero when the key has no expiration
+}
+
+// expired reports whether the entry has passed its expiration
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryStore is a process-local Store with lazy and periodic expiry
+type MemoryStore struct {
+	entries   map[string]memoryEntry
+	mu        sync.RWMutex
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMemoryStore creates an in-memory store and starts its expiry janitor
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		stop:    make(chan struct{}),
+	}
+	go s.janitor(time.Minute)
+	return s
+}
+
+// Get returns the value stored at key
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	entry, exists := s.entries[key]
+	s.mu.RUnlock()
+
+	if !exists || entry.expired(time.Now()) {
+		return nil, ErrNotFound
+	}
+
+	value := make([]byte, len(entry.value))
+	copy(value, entry.value)
+	return value, nil
+}
+
+// Set stores value at key
+func (s *MemoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: make([]byte, len(value))}
+	copy(entry.value, value)
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	s.entries[key] = entry
+	s.mu.Unlock()
+	return nil
+}
+
+// Delete removes key
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// IncrBy atomically adds delta to the integer at key, setting ttl when the key is created.
+// Values are stored as decimal strings, as in Redis.
+func (s *MemoryStore) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry, exists := s.entries[key]
+	if !exists || entry.expired(now) {
+		entry = memoryEntry{}
+		if ttl > 0 {
+			entry.expiresAt = now.Add(ttl)
+		}
+	}
+
+	var current int64
+	if len(entry.value) > 0 {
+		parsed, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("kv: value at %s is not an integer", key)
+		}
+		current = parsed
+	}
+
+	current += delta
+	entry.value = []byte(strconv.FormatInt(current, 10))
+	s.entries[key] = entry
+	return current, nil
+}
+
+// TTL returns the remaining time to live of key
+func (s *MemoryStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.RLock()
+	entry, exists := s.entries[key]
+	s.mu.RUnlock()
+
+	now := time.Now()
+	if !exists || entry.expired(now) {
+		return 0, ErrNotFound
+	}
+	if entry.expiresAt.IsZero() {
+		return NoExpiry, nil
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+// Scan calls fn for every live key starting with prefix
+func (s *MemoryStore) Scan(ctx context.Context, prefix string, fn func(key string) error) error {
+	now := time.Now()
+
+	s.mu.RLock()
+	keys := make([]string, 0)
+	for key, entry := range s.entries {
+		if strings.HasPrefix(key, prefix) && !entry.expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ping always succeeds for the in-memory store
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Backend returns the backend name
+func (s *MemoryStore) Backend() string {
+	return BackendMemory
+}
+
+// Close stops the expiry janitor
+func (s *MemoryStore) Close() error {
+	s.closeOnce.Do(func() { close(s.stop) })
+	return nil
+}
+
+// janitor periodically removes expired entries so unread keys do not accumulate
+func (s *MemoryStore) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.mu.Lock()
+			for key, entry := range s.entries {
+				if entry.expired(now) {
+					delete(s.entries, key)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
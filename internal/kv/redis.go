@@ -0,0 +1,108 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store backed by a shared Redis client. The client is owned by the caller,
+// so closing the store does not close the connection pool.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store on top of an existing Redis client
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get returns the value stored at key
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Set stores value at key
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes key
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// IncrBy atomically adds delta to the integer at key, setting ttl when the key is created
+func (s *RedisStore) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := s.client.IncrBy(ctx, key, delta).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// The key was just created by this increment
+	if value == delta && ttl > 0 {
+		if err := s.client.Expire(ctx, key, ttl).Err(); err != nil {
+			return value, err
+		}
+	}
+	return value, nil
+}
+
+// TTL returns the remaining time to live of key
+func (s *RedisStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Redis reports -2 for missing keys and -1 for keys without an expiry
+	switch ttl {
+	case -2:
+		return 0, ErrNotFound
+	case -1:
+		return NoExpiry, nil
+	}
+	return ttl, nil
+}
+
+// Scan calls fn for every key starting with prefix
+func (s *RedisStore) Scan(ctx context.Context, prefix string, fn func(key string) error) error {
+	scanNode := func(ctx context.Context, node redis.UniversalClient) error {
+		iter := node.Scan(ctx, 0, prefix+"*", 100).Iterator()
+		for iter.Next(ctx) {
+			if err := fn(iter.Val()); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	}
+
+	// Cluster keys are spread across masters, so each one has to be scanned
+	if cluster, ok := s.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scanNode(ctx, node)
+		})
+	}
+	return scanNode(ctx, s.client)
+}
+
+// Ping checks that Redis is reachable
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Backend returns the backend name
+func (s *RedisStore) Backend() string {
+	return BackendRedis
+}
+
+// Close is a no-op; the Redis client is shared and closed by its owner
+func (s *RedisStore) Close() error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/llm/providers"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+)
+
+// extractionCacheKeyPrefix namespaces cached job extractions in the key-value store
+const extractionCacheKeyPrefix = "llm_cache:extract:"
+
+// extractionCache stores extracted jobs keyed by a hash of the URL and the cleaned page content,
+// so markup-only changes such as tracking attributes or scripts still hit the cache. A provider
+// or model requested for the extraction is part of the key, as they extract differently.
+type extractionCache struct {
+	store   kv.Store
+	ttl     time.Duration
+	cleaner *processors.HTMLCleaner
+	logger  types.Logger
+}
+
+func newExtractionCache(store kv.Store, ttl time.Duration, logger types.Logger) *extractionCache {
+	return &extractionCache{
+		store:   store,
+		ttl:     ttl,
+		cleaner: processors.NewHTMLCleaner(),
+		logger:  logger,
+	}
+}
+
+// key returns the cache key of a page extracted by the provider and model ctx asks for; content
+// that cannot be cleaned is hashed as it is
+func (c *extractionCache) key(ctx context.Context, html, url string) string {
+	content, err := c.cleaner.ExtractJobContent(html)
+	if err != nil || content == "" {
+		content = html
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(url))
+	hash.Write([]byte{0})
+	hash.Write([]byte(content))
+	// Extractions by the default chain and model keep the keys they had before either was chosen
+	if provider, model := requestedProvider(ctx), providers.RequestedModel(ctx); provider != "" || model != "" {
+		hash.Write([]byte{0})
+		hash.Write([]byte(provider))
+		hash.Write([]byte{0})
+		hash.Write([]byte(model))
+	}
+	return extractionCacheKeyPrefix + hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns the cached extraction stored at key, if any
+func (c *extractionCache) get(ctx context.Context, key string) (*models.Job, bool) {
+	data, err := c.store.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+
+	var job models.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, false
+	}
+	return &job, true
+}
+
+// set stores a successful extraction at key
+func (c *extractionCache) set(ctx context.Context, key string, job *models.Job) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+	if err := c.store.Set(ctx, key, data, c.ttl); err != nil {
+		c.logger.Warn("Failed to cache job extraction", map[string]interface{}{
+			"key":   key,
+			"error": err.Error(),
+		})
+	}
+}
+
+type cacheBypassContextKey struct{}
+
+// WithCacheBypass returns a copy of ctx whose job extractions skip the extraction cache; the
+// fresh result still replaces the cached one
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassContextKey{}, true)
+}
+
+// cacheBypassed reports whether ctx asks to skip the extraction cache
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassContextKey{}).(bool)
+	return bypass
+}
@@ -0,0 +1,238 @@
+package cost
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"letraz-utils/internal/config"
+)
+
+// Operation types LLM spend is attributed to
+const (
+	OperationScrape   = "scrape"
+	OperationTailor   = "tailor"
+	OperationAnalysis = "analysis" // resume assessments such as ATS scoring and skill gaps
+	OperationOther    = "other"
+)
+
+// Price is the list price of a model in USD per million tokens
+type Price struct {
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+}
+
+// defaultPrices holds list prices keyed by model name prefix; configuration can add models or
+// override these
+var defaultPrices = map[string]Price{
+	"claude-opus-4":          {InputPerMillion: 15, OutputPerMillion: 75},
+	"claude-sonnet-4":        {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-3-7-sonnet":      {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-3-5-sonnet":      {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-3-5-haiku":       {InputPerMillion: 0.8, OutputPerMillion: 4},
+	"claude-3-haiku":         {InputPerMillion: 0.25, OutputPerMillion: 1.25},
+	"gpt-4o-mini":            {InputPerMillion: 0.15, OutputPerMillion: 0.6},
+	"gpt-4o":                 {InputPerMillion: 2.5, OutputPerMillion: 10},
+	"gemini-2.5-pro":         {InputPerMillion: 1.25, OutputPerMillion: 10},
+	"gemini-2.5-flash":       {InputPerMillion: 0.3, OutputPerMillion: 2.5},
+	"text-embedding-3-small": {InputPerMillion: 0.02},
+	"text-embedding-3-large": {InputPerMillion: 0.13},
+	"voyage-3-lite":          {InputPerMillion: 0.02},
+	"voyage-3":               {InputPerMillion: 0.06},
+	"mock":                   {},
+}
+
+// PriceList resolves model prices by the longest matching model name prefix
+type PriceList struct {
+	prefixes []string // longest first
+	prices   map[string]Price
+}
+
+// NewPriceList creates a price list from the built-in prices and the configured overrides
+func NewPriceList(cfg *config.Config) *PriceList {
+	prices := make(map[string]Price, len(defaultPrices))
+	for model, price := range defaultPrices {
+		prices[model] = price
+	}
+	if cfg != nil {
+		for model, price := range cfg.LLM.Pricing {
+			prices[strings.ToLower(model)] = Price{InputPerMillion: price.InputPerMillion, OutputPerMillion: price.OutputPerMillion}
+		}
+	}
+
+	prefixes := make([]string, 0, len(prices))
+	for model := range prices {
+		prefixes = append(prefixes, model)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	return &PriceList{prefixes: prefixes, prices: prices}
+}
+
+// Lookup returns the price of model, reporting whether it is known
+func (pl *PriceList) Lookup(model string) (Price, bool) {
+	model = strings.ToLower(model)
+	for _, prefix := range pl.prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return pl.prices[prefix], true
+		}
+	}
+	return Price{}, false
+}
+
+// Estimate returns the estimated cost in USD of a call to model; unknown models cost zero
+func (pl *PriceList) Estimate(model string, inputTokens, outputTokens int64) float64 {
+	price, _ := pl.Lookup(model)
+	return (float64(inputTokens)*price.InputPerMillion + float64(outputTokens)*price.OutputPerMillion) / 1e6
+}
+
+// Usage is the token usage and estimated cost of one or more LLM calls
+type Usage struct {
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Add adds other to u
+func (u *Usage) Add(other Usage) {
+	u.Calls += other.Calls
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CostUSD += other.CostUSD
+}
+
+// Tally accumulates the LLM usage of a single task
+type Tally struct {
+	mu            sync.Mutex
+	usage         Usage
+	provider      string
+	model         string
+	escalatedFrom string
+}
+
+// NewTally creates an empty usage tally
+func NewTally() *Tally {
+	return &Tally{}
+}
+
+// Add adds the usage of a call served by provider with model
+func (t *Tally) Add(provider, model string, usage Usage) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage.Add(usage)
+	t.provider = provider
+	if model != "" {
+		t.model = model
+	}
+}
+
+// Escalate records that a call fell back from model to a fallback model
+func (t *Tally) Escalate(model string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.escalatedFrom = model
+}
+
+// Usage returns the accumulated usage and the provider of the most recent call
+func (t *Tally) Usage() (Usage, string) {
+	if t == nil {
+		return Usage{}, ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage, t.provider
+}
+
+// Metadata returns the tally in the form stored in task metadata, or nil when no call was made.
+// The model is the one that produced the final answer; escalated_from names the model a call
+// fell back from when the task needed the fallback model.
+func (t *Tally) Metadata() map[string]interface{} {
+	usage, provider := t.Usage()
+	if usage.Calls == 0 {
+		return nil
+	}
+
+	metadata := map[string]interface{}{
+		"provider":      provider,
+		"calls":         usage.Calls,
+		"input_tokens":  usage.InputTokens,
+		"output_tokens": usage.OutputTokens,
+		"cost_usd":      usage.CostUSD,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.model != "" {
+		metadata["model"] = t.model
+	}
+	if t.escalatedFrom != "" {
+		metadata["escalated_from"] = t.escalatedFrom
+	}
+	return metadata
+}
+
+type (
+	tallyContextKey     struct{}
+	operationContextKey struct{}
+)
+
+// WithTally returns a copy of ctx whose LLM usage is accumulated into t
+func WithTally(ctx context.Context, t *Tally) context.Context {
+	return context.WithValue(ctx, tallyContextKey{}, t)
+}
+
+// TallyFromContext returns the tally carried by ctx, if any
+func TallyFromContext(ctx context.Context) *Tally {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(tallyContextKey{}).(*Tally)
+	return t
+}
+
+// WithOperation returns a copy of ctx whose LLM spend is attributed to operation
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+// OperationFromContext returns the operation carried by ctx, or OperationOther
+func OperationFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if operation, ok := ctx.Value(operationContextKey{}).(string); ok && operation != "" {
+			return operation
+		}
+	}
+	return OperationOther
+}
+
+// Record attributes one LLM call to the task and operation carried by ctx and to the global
+// ledger, and returns its estimated cost in USD
+func Record(ctx context.Context, provider, model string, inputTokens, outputTokens int64) float64 {
+	ledger := GetGlobalLedger()
+	usage := Usage{
+		Calls:        1,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		CostUSD:      ledger.Prices().Estimate(model, inputTokens, outputTokens),
+	}
+
+	TallyFromContext(ctx).Add(provider, model, usage)
+	ledger.Record(ctx, OperationFromContext(ctx), provider, usage)
+	return usage.CostUSD
+}
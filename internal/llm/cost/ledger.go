@@ -0,0 +1,243 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/utils"
+)
+
+// counterKeyPrefix namespaces usage counters in the key-value store
+const counterKeyPrefix = "llm_usage:"
+
+// Counter retention after the end of their period, so past days and months stay queryable
+const (
+	dailyRetention   = 90 * 24 * time.Hour
+	monthlyRetention = 400 * 24 * time.Hour
+)
+
+// microsPerUSD converts costs to the integer micro-dollars stored in counters
+const microsPerUSD = 1e6
+
+// Counter fields
+const (
+	fieldCalls        = "calls"
+	fieldInputTokens  = "input_tokens"
+	fieldOutputTokens = "output_tokens"
+	fieldCostMicros   = "cost_micros"
+)
+
+// Period is a reporting window
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// PeriodReport is the LLM spend of one day or month
+type PeriodReport struct {
+	Period      Period           `json:"period"`
+	Start       time.Time        `json:"start"`
+	End         time.Time        `json:"end"`
+	Total       Usage            `json:"total"`
+	ByOperation map[string]Usage `json:"by_operation"`
+	ByProvider  map[string]Usage `json:"by_provider"`
+}
+
+// Ledger aggregates LLM usage and cost per operation and provider in daily and monthly counters
+// in the key-value store, so totals are shared across replicas when Redis backs the store
+type Ledger struct {
+	store  kv.Store
+	prices *PriceList
+	logger logging.Logger
+}
+
+// NewLedger creates a usage ledger
+func NewLedger(cfg *config.Config, store kv.Store) *Ledger {
+	return &Ledger{
+		store:  store,
+		prices: NewPriceList(cfg),
+		logger: logging.GetGlobalLogger(),
+	}
+}
+
+// Prices returns the price list used to estimate costs
+func (l *Ledger) Prices() *PriceList {
+	if l == nil {
+		return builtinPrices()
+	}
+	return l.prices
+}
+
+// Record adds usage to the daily and monthly counters of operation and provider
+func (l *Ledger) Record(ctx context.Context, operation, provider string, usage Usage) {
+	if l == nil || usage.Calls == 0 {
+		return
+	}
+
+	fields := map[string]int64{
+		fieldCalls:        usage.Calls,
+		fieldInputTokens:  usage.InputTokens,
+		fieldOutputTokens: usage.OutputTokens,
+		fieldCostMicros:   int64(usage.CostUSD * microsPerUSD),
+	}
+
+	now := time.Now().UTC()
+	for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+		start, end := utils.UsageWindow(now, period == PeriodMonthly)
+		ttl := time.Until(end) + dailyRetention
+		if period == PeriodMonthly {
+			ttl = time.Until(end) + monthlyRetention
+		}
+
+		prefix := periodPrefix(period, start) + operation + ":" + provider + ":"
+		for field, delta := range fields {
+			if delta == 0 {
+				continue
+			}
+			if _, err := l.store.IncrBy(ctx, prefix+field, delta, ttl); err != nil {
+				logging.FromContext(ctx).Warn("Failed to record LLM usage", map[string]interface{}{
+					"operation": operation,
+					"provider":  provider,
+					"error":     err.Error(),
+				})
+				return
+			}
+		}
+	}
+}
+
+// Report returns the usage of the day or month containing at
+func (l *Ledger) Report(ctx context.Context, period Period, at time.Time) (*PeriodReport, error) {
+	start, end := utils.UsageWindow(at, period == PeriodMonthly)
+	report := &PeriodReport{
+		Period:      period,
+		Start:       start,
+		End:         end,
+		ByOperation: make(map[string]Usage),
+		ByProvider:  make(map[string]Usage),
+	}
+	if l == nil {
+		return report, nil
+	}
+
+	prefix := periodPrefix(period, start)
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	if err := l.store.Scan(ctx, prefix, func(key string) error {
+		mu.Lock()
+		keys = append(keys, key)
+		mu.Unlock()
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list LLM usage counters: %w", err)
+	}
+
+	for _, key := range keys {
+		// The key remainder is operation:provider:field
+		parts := strings.Split(strings.TrimPrefix(key, prefix), ":")
+		if len(parts) != 3 {
+			continue
+		}
+		value, err := l.counter(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := counterUsage(parts[2], value)
+		byOperation := report.ByOperation[parts[0]]
+		byOperation.Add(usage)
+		report.ByOperation[parts[0]] = byOperation
+		byProvider := report.ByProvider[parts[1]]
+		byProvider.Add(usage)
+		report.ByProvider[parts[1]] = byProvider
+		report.Total.Add(usage)
+	}
+
+	return report, nil
+}
+
+// counter reads a counter, treating a missing counter as zero
+func (l *Ledger) counter(ctx context.Context, key string) (int64, error) {
+	data, err := l.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read LLM usage: %w", err)
+	}
+
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LLM usage counter: %w", err)
+	}
+	return value, nil
+}
+
+// counterUsage converts the value of one counter field into usage
+func counterUsage(field string, value int64) Usage {
+	switch field {
+	case fieldCalls:
+		return Usage{Calls: value}
+	case fieldInputTokens:
+		return Usage{InputTokens: value}
+	case fieldOutputTokens:
+		return Usage{OutputTokens: value}
+	case fieldCostMicros:
+		return Usage{CostUSD: float64(value) / microsPerUSD}
+	}
+	return Usage{}
+}
+
+// periodPrefix builds the counter prefix of a period, e.g. llm_usage:daily:20260101:
+func periodPrefix(period Period, start time.Time) string {
+	stamp := utils.UsageWindowStamp(start, period == PeriodMonthly)
+	return counterKeyPrefix + string(period) + ":" + stamp + ":"
+}
+
+// Global ledger instance
+var (
+	globalLedger *Ledger
+	globalMu     sync.RWMutex
+
+	defaultPriceList     *PriceList
+	defaultPriceListOnce sync.Once
+)
+
+// builtinPrices returns the price list without configured overrides
+func builtinPrices() *PriceList {
+	defaultPriceListOnce.Do(func() {
+		defaultPriceList = NewPriceList(nil)
+	})
+	return defaultPriceList
+}
+
+// InitializeGlobalLedger creates the global usage ledger
+func InitializeGlobalLedger(cfg *config.Config, store kv.Store) *Ledger {
+	ledger := NewLedger(cfg, store)
+
+	globalMu.Lock()
+	globalLedger = ledger
+	globalMu.Unlock()
+
+	return ledger
+}
+
+// GetGlobalLedger returns the global usage ledger; LLM calls are not aggregated until it is
+// initialized
+func GetGlobalLedger() *Ledger {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalLedger
+}
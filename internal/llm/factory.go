@@ -19,17 +19,28 @@ func NewLLMFactory(cfg *config.Config) *LLMFactory {
 	}
 }
 
-// CreateProvider creates an LLM provider based on the configuration
+// CreateProvider creates the primary LLM provider based on the configuration
 func (f *LLMFactory) CreateProvider() (LLMProvider, error) {
-	switch f.config.LLM.Provider {
+	return f.CreateProviderByName(f.config.LLM.Provider)
+}
+
+// CreateProviderByName creates the named LLM provider
+func (f *LLMFactory) CreateProviderByName(name string) (LLMProvider, error) {
+	switch name {
 	case "claude":
 		return providers.NewClaudeProvider(f.config), nil
+	case "openai":
+		return providers.NewOpenAIProvider(f.config), nil
+	case "gemini":
+		return providers.NewGeminiProvider(f.config), nil
+	case "mock":
+		return providers.NewMockProvider(f.config), nil
 	default:
-		return nil, fmt.Errorf("unsupported LLM provider: %s", f.config.LLM.Provider)
+		return nil, fmt.Errorf("unsupported LLM provider: %s", name)
 	}
 }
 
 // GetSupportedProviders returns a list of supported LLM providers
 func (f *LLMFactory) GetSupportedProviders() []string {
-	return []string{"claude"}
+	return []string{"claude", "openai", "gemini", "mock"}
 }
@@ -0,0 +1,110 @@
+package llm
+
+import (
+	"sync"
+	"time"
+
+	"letraz-utils/pkg/utils"
+)
+
+// Circuit breaker states
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half_open"
+)
+
+// circuitBreaker skips a provider after consecutive failures and lets a single trial request
+// through once the reset timeout has passed
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	resetTimeout     time.Duration
+	failures         int
+	state            string
+	openedAt         time.Time
+	trialInFlight    bool
+}
+
+func newCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *circuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+		state:            circuitClosed,
+	}
+}
+
+// allow reports whether a request may be sent to the provider
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.resetTimeout {
+			return false
+		}
+		cb.state = circuitHalfOpen
+		cb.trialInFlight = true
+		return true
+	case circuitHalfOpen:
+		if cb.trialInFlight {
+			return false
+		}
+		cb.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// recordSuccess closes the circuit
+func (cb *circuitBreaker) recordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.state = circuitClosed
+	cb.trialInFlight = false
+}
+
+// recordFailure counts a failure and reports whether it opened the circuit
+func (cb *circuitBreaker) recordFailure() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.trialInFlight = false
+	if cb.state == circuitHalfOpen || (cb.state == circuitClosed && cb.failures >= cb.failureThreshold) {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+		return true
+	}
+	return false
+}
+
+// cancelTrial gives back a half-open trial that never reached the provider, so the next request
+// can take it
+func (cb *circuitBreaker) cancelTrial() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trialInFlight = false
+}
+
+// currentState returns the circuit state
+func (cb *circuitBreaker) currentState() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// shouldFailover reports whether err means the provider itself is unavailable, so the request
+// should be retried on the next provider. Parse failures and non-job pages are answers from a
+// working provider and are returned as they are.
+func shouldFailover(err error) bool {
+	return utils.IsErrorCode(err, utils.ErrCodeLLMUnavailable) || utils.IsErrorCode(err, utils.ErrCodeRateLimited)
+}
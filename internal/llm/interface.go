@@ -2,6 +2,8 @@ package llm
 
 import (
 	"context"
+
+	"letraz-utils/internal/llm/providers"
 	"letraz-utils/pkg/models"
 )
 
@@ -19,6 +21,23 @@ type LLMProvider interface {
 	// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
 	TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error)
 
+	// RefineResume revises the latest tailored resume of a conversation according to a follow-up
+	// instruction and returns the raw AI response for conversation history
+	RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error)
+
+	// ReviewResumeForATS assesses how well a resume fits a job posting for ATS scoring
+	ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error)
+
+	// AnalyzeSkillGap compares a resume's skills with a job's requirements
+	AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error)
+
+	// GenerateInterviewQuestions predicts up to count likely interview questions for a job, with
+	// suggested answers drawn from the resume
+	GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error)
+
+	// ExtractCompanyData extracts company information from the cleaned pages of its website
+	ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error)
+
 	// IsHealthy checks if the LLM provider is healthy and available
 	IsHealthy(ctx context.Context) error
 
@@ -26,6 +45,14 @@ type LLMProvider interface {
 	GetProviderName() string
 }
 
+// SectionStreamer is implemented by providers that can hand out tailored resume sections while
+// the rest of the resume is still being generated
+type SectionStreamer interface {
+	// TailorResumeStream tailors a resume like TailorResumeWithRawResponse, calling onSection for
+	// each section as soon as it is complete
+	TailorResumeStream(ctx context.Context, baseResume *models.BaseResume, job *models.Job, onSection providers.SectionHandler) (*models.TailoredResume, []models.Suggestion, string, error)
+}
+
 // ExtractJobDataRequest represents the request to extract job data
 type ExtractJobDataRequest struct {
 	HTML string `json:"html"`
@@ -37,3 +64,27 @@ type ExtractJobDataResponse struct {
 	Job   *models.Job `json:"job"`
 	Error string      `json:"error,omitempty"`
 }
+
+// WithModel returns a copy of ctx whose LLM calls use model when the serving provider allows
+// it, falling back to the provider's configured model otherwise
+func WithModel(ctx context.Context, model string) context.Context {
+	return providers.WithModel(ctx, model)
+}
+
+// providerContextKey is the context key under which a requested provider is stored
+type providerContextKey struct{}
+
+// WithProvider returns a copy of ctx whose LLM calls are served by provider alone, without
+// failing over to the rest of the chain. An empty provider leaves ctx unchanged.
+func WithProvider(ctx context.Context, provider string) context.Context {
+	if provider == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, providerContextKey{}, provider)
+}
+
+// requestedProvider returns the provider ctx asks for, or an empty string
+func requestedProvider(ctx context.Context) string {
+	provider, _ := ctx.Value(providerContextKey{}).(string)
+	return provider
+}
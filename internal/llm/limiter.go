@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/metrics"
+	"letraz-utils/pkg/utils"
+)
+
+// providerLimiter caps the concurrent requests and requests per minute sent to one provider.
+// Calls over the limits wait their turn instead of reaching the provider as a burst of 429s.
+type providerLimiter struct {
+	limit        config.LLMRateLimit
+	queueTimeout time.Duration
+	slots        chan struct{} // nil when concurrency is unlimited
+	rate         *rate.Limiter // nil when the request rate is unlimited
+	queueWait    *metrics.Histogram
+
+	mu        sync.Mutex
+	queued    int
+	inFlight  int
+	admitted  int64
+	timeouts  int64
+	peakQueue int
+}
+
+// newProviderLimiter returns a limiter enforcing limit, or nil when the provider is unlimited
+func newProviderLimiter(limit config.LLMRateLimit, queueTimeout time.Duration) *providerLimiter {
+	if limit.MaxConcurrent <= 0 && limit.RequestsPerMinute <= 0 {
+		return nil
+	}
+
+	pl := &providerLimiter{
+		limit:        limit,
+		queueTimeout: queueTimeout,
+		queueWait:    metrics.NewLatencyHistogram(),
+	}
+	if limit.MaxConcurrent > 0 {
+		pl.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	if limit.RequestsPerMinute > 0 {
+		// Allow a burst of one concurrent wave, but never more than a minute's worth of requests
+		burst := max(1, min(limit.MaxConcurrent, limit.RequestsPerMinute))
+		pl.rate = rate.NewLimiter(rate.Limit(float64(limit.RequestsPerMinute)/60), burst)
+	}
+	return pl
+}
+
+// acquire waits until a request may be sent and returns the function that ends it. Waiting
+// longer than the queue timeout fails with a rate limited error, so the manager fails over to
+// the next provider. A nil limiter admits every request at once.
+func (pl *providerLimiter) acquire(ctx context.Context, provider string) (func(), error) {
+	if pl == nil {
+		return func() {}, nil
+	}
+
+	pl.mu.Lock()
+	pl.queued++
+	pl.peakQueue = max(pl.peakQueue, pl.queued)
+	pl.mu.Unlock()
+
+	startTime := time.Now()
+	waitCtx := ctx
+	if pl.queueTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, pl.queueTimeout)
+		defer cancel()
+	}
+
+	err := pl.wait(waitCtx)
+
+	pl.mu.Lock()
+	pl.queued--
+	if err == nil {
+		pl.inFlight++
+		pl.admitted++
+	} else if ctx.Err() == nil {
+		pl.timeouts++
+	}
+	pl.mu.Unlock()
+	pl.queueWait.ObserveDuration(time.Since(startTime))
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, utils.NewRateLimitedError(fmt.Sprintf("%s request queued longer than %s behind the provider's rate limits", provider, pl.queueTimeout))
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			pl.mu.Lock()
+			pl.inFlight--
+			pl.mu.Unlock()
+			if pl.slots != nil {
+				<-pl.slots
+			}
+		})
+	}, nil
+}
+
+// wait takes a concurrency slot and then a request from the rate budget, giving the slot back
+// when ctx ends first
+func (pl *providerLimiter) wait(ctx context.Context) error {
+	if pl.slots != nil {
+		select {
+		case pl.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if pl.rate != nil {
+		if err := pl.rate.Wait(ctx); err != nil {
+			if pl.slots != nil {
+				<-pl.slots
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// limiterStats is a snapshot of a provider limiter
+type limiterStats struct {
+	MaxConcurrent     int
+	RequestsPerMinute int
+	QueueDepth        int
+	PeakQueueDepth    int
+	InFlight          int
+	Admitted          int64
+	QueueTimeouts     int64
+	QueueWait         metrics.HistogramSnapshot
+}
+
+// stats returns the current limiter state
+func (pl *providerLimiter) stats() limiterStats {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	return limiterStats{
+		MaxConcurrent:     pl.limit.MaxConcurrent,
+		RequestsPerMinute: pl.limit.RequestsPerMinute,
+		QueueDepth:        pl.queued,
+		PeakQueueDepth:    pl.peakQueue,
+		InFlight:          pl.inFlight,
+		Admitted:          pl.admitted,
+		QueueTimeouts:     pl.timeouts,
+		QueueWait:         pl.queueWait.Snapshot(),
+	}
+}
+
+// toMap renders the stats for the monitoring service
+func (s limiterStats) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"max_concurrent":      s.MaxConcurrent,
+		"requests_per_minute": s.RequestsPerMinute,
+		"queue_depth":         s.QueueDepth,
+		"peak_queue_depth":    s.PeakQueueDepth,
+		"in_flight":           s.InFlight,
+		"admitted":            s.Admitted,
+		"queue_timeouts":      s.QueueTimeouts,
+		"queue_wait_seconds":  s.QueueWait,
+	}
+}
@@ -2,23 +2,46 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	"letraz-utils/internal/archive"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/llm/providers"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/salary"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
 )
 
-// Manager manages LLM providers and their lifecycle
-type Manager struct {
-	config   *config.Config
-	factory  *LLMFactory
+// providerEntry is one provider in the failover chain
+type providerEntry struct {
 	provider LLMProvider
-	logger   types.Logger
-	mu       sync.RWMutex
-	healthy  bool
+	breaker  *circuitBreaker
+	limiter  *providerLimiter // nil when the provider is unlimited
+	healthy  bool             // guarded by Manager.mu
+}
+
+// Manager manages LLM providers and their lifecycle. Requests go to the primary provider and
+// fail over, in configured order, to the next provider when one is overloaded or unavailable.
+type Manager struct {
+	config    *config.Config
+	factory   *LLMFactory
+	providers []*providerEntry
+	logger    types.Logger
+	metrics   *Metrics
+	cache     *extractionCache // nil when the extraction cache is disabled
+	salary    *salary.Normalizer
+	mu        sync.RWMutex
 }
 
 // NewManager creates a new LLM manager instance
@@ -27,162 +50,640 @@ func NewManager(cfg *config.Config) *Manager {
 		config:  cfg,
 		factory: NewLLMFactory(cfg),
 		logger:  logging.GetGlobalLogger(),
+		metrics: NewMetrics(),
+		salary:  salary.NewNormalizer(cfg),
 	}
 }
 
-// Start initializes the LLM manager and creates the provider
+// Start initializes the LLM manager and creates the provider chain
 func (m *Manager) Start() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	chain := m.providerChain()
 	m.logger.Info("Starting LLM manager", map[string]interface{}{
 		"provider": m.config.LLM.Provider,
+		"chain":    chain,
 	})
 
-	// Create provider
-	provider, err := m.factory.CreateProvider()
-	if err != nil {
-		return fmt.Errorf("failed to create LLM provider: %w", err)
+	m.providers = nil
+	for i, name := range chain {
+		provider, err := m.factory.CreateProviderByName(name)
+		if err != nil {
+			if i == 0 {
+				return fmt.Errorf("failed to create LLM provider: %w", err)
+			}
+			m.logger.Warn("Skipping failover LLM provider", map[string]interface{}{
+				"provider": name,
+				"error":    err.Error(),
+			})
+			continue
+		}
+		limit := m.config.LLM.Limits.Providers[name]
+		m.providers = append(m.providers, &providerEntry{
+			provider: provider,
+			breaker:  newCircuitBreaker(m.config.LLM.Failover.FailureThreshold, m.config.LLM.Failover.ResetTimeout),
+			limiter:  newProviderLimiter(limit, m.config.LLM.Limits.QueueTimeout),
+		})
+		if limit.MaxConcurrent > 0 || limit.RequestsPerMinute > 0 {
+			m.logger.Info("LLM provider rate limits enabled", map[string]interface{}{
+				"provider":            name,
+				"max_concurrent":      limit.MaxConcurrent,
+				"requests_per_minute": limit.RequestsPerMinute,
+				"queue_timeout":       m.config.LLM.Limits.QueueTimeout.String(),
+			})
+		}
 	}
 
-	m.provider = provider
-
 	// Test provider health
-	ctx, cancel := context.WithTimeout(context.Background(), m.config.LLM.Timeout)
-	defer cancel()
-
-	if err := m.provider.IsHealthy(ctx); err != nil {
-		m.logger.Warn("LLM provider health check failed - LLM features will be disabled", map[string]interface{}{
-			"error": err.Error(),
-		})
-		m.healthy = false
-		// Don't return error - allow server to start without LLM
-	} else {
-		m.healthy = true
-		m.logger.Info("LLM manager started successfully", map[string]interface{}{
-			"provider": m.provider.GetProviderName(),
+	for _, entry := range m.providers {
+		ctx, cancel := context.WithTimeout(context.Background(), m.config.LLM.Timeout)
+		err := entry.provider.IsHealthy(ctx)
+		cancel()
+
+		entry.healthy = err == nil
+		if err != nil {
+			// Don't return error - allow server to start without LLM
+			m.logger.Warn("LLM provider health check failed - provider will be skipped", map[string]interface{}{
+				"provider": entry.provider.GetProviderName(),
+				"error":    err.Error(),
+			})
+			continue
+		}
+		m.logger.Info("LLM provider ready", map[string]interface{}{
+			"provider": entry.provider.GetProviderName(),
 		})
 	}
 
+	if !m.anyHealthyLocked() {
+		m.logger.Warn("No healthy LLM provider - LLM features will be disabled", nil)
+	}
+
 	return nil
 }
 
+// EnableExtractionCache caches job extractions in store when the cache is enabled in configuration
+func (m *Manager) EnableExtractionCache(store kv.Store) {
+	if !m.config.LLM.Cache.Enabled || store == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.cache = newExtractionCache(store, m.config.LLM.Cache.TTL, m.logger)
+	m.mu.Unlock()
+
+	m.logger.Info("LLM extraction cache enabled", map[string]interface{}{
+		"backend": store.Backend(),
+		"ttl":     m.config.LLM.Cache.TTL.String(),
+	})
+}
+
+// providerChain returns the primary provider followed by the failover providers, without duplicates
+func (m *Manager) providerChain() []string {
+	chain := []string{m.config.LLM.Provider}
+	seen := map[string]bool{m.config.LLM.Provider: true}
+	for _, name := range m.config.LLM.Failover.Providers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		chain = append(chain, name)
+	}
+	return chain
+}
+
 // Stop shuts down the LLM manager
 func (m *Manager) Stop() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.logger.Info("Stopping LLM manager", map[string]interface{}{})
-	m.provider = nil
-	m.healthy = false
+	m.providers = nil
 	return nil
 }
 
-// ExtractJobData extracts job data from HTML using the configured LLM provider
+// ExtractJobData extracts job data from HTML using the configured LLM providers. Pages with a
+// complete schema.org JobPosting block are mapped without the LLM, and extractions of the same
+// cleaned content and URL are served from the extraction cache unless ctx bypasses it. The page
+// is recorded on the job's archive capture either way.
 func (m *Manager) ExtractJobData(ctx context.Context, html, url string) (*models.Job, error) {
+	archive.Record(ctx, url, html)
+
+	var structured *models.Job
+	if m.config.Scraper.JSONLD {
+		job, complete := processors.ExtractJSONLDJob(html, url)
+		if complete {
+			timeline.Record(ctx, timeline.EventStructuredData, map[string]interface{}{
+				"operation": "extract_job_data",
+				"format":    "json-ld",
+			})
+			logging.FromContext(ctx).Debug("Job read from JSON-LD, skipping LLM", map[string]interface{}{
+				"url": url,
+			})
+			m.salary.Apply(job)
+			return job, nil
+		}
+		structured = job
+	}
+
 	m.mu.RLock()
-	provider := m.provider
-	healthy := m.healthy
+	cache := m.cache
 	m.mu.RUnlock()
 
-	if provider == nil {
-		return nil, fmt.Errorf("LLM manager not started or provider not available")
+	var cacheKey string
+	if cache != nil {
+		cacheKey = cache.key(ctx, html, url)
+		if !cacheBypassed(ctx) {
+			if job, ok := cache.get(ctx, cacheKey); ok {
+				m.metrics.RecordCacheLookup(true)
+				timeline.Record(ctx, timeline.EventLLMCacheHit, map[string]interface{}{
+					"operation": "extract_job_data",
+				})
+				logging.FromContext(ctx).Debug("Job extraction served from cache", map[string]interface{}{
+					"url": url,
+				})
+				m.salary.Apply(job)
+				return job, nil
+			}
+			m.metrics.RecordCacheLookup(false)
+		}
 	}
 
-	if !healthy {
-		return nil, fmt.Errorf("LLM provider is not available - check API key configuration (set LLM_API_KEY environment variable)")
+	var job *models.Job
+	err := m.execute(ctx, "extract_job_data", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		job, err = provider.ExtractJobData(ctx, html, url)
+		return err
+	})
+	if err == nil {
+		fillFromStructured(job, structured)
+		m.salary.Apply(job)
 	}
+	if err == nil && cache != nil && job != nil {
+		cache.set(ctx, cacheKey, job)
+	}
+	return job, err
+}
 
-	return provider.ExtractJobData(ctx, html, url)
+// fillFromStructured fills the fields the LLM left empty from an incomplete JSON-LD posting,
+// whose salary is preferred as it is stated rather than read from the text
+func fillFromStructured(job, structured *models.Job) {
+	if job == nil || structured == nil {
+		return
+	}
+	if job.Title == "" {
+		job.Title = structured.Title
+	}
+	if job.CompanyName == "" {
+		job.CompanyName = structured.CompanyName
+	}
+	if job.Location == "" {
+		job.Location = structured.Location
+	}
+	if structured.Salary.Min > 0 || structured.Salary.Max > 0 {
+		job.Salary = structured.Salary
+		job.Currency = structured.Salary.Currency
+	}
 }
 
-// ExtractJobFromDescription extracts job data from description text using the configured LLM provider
+// ExtractJobFromDescription extracts job data from description text using the configured LLM providers
 func (m *Manager) ExtractJobFromDescription(ctx context.Context, description string) (*models.Job, error) {
-	m.mu.RLock()
-	provider := m.provider
-	healthy := m.healthy
-	m.mu.RUnlock()
-
-	if provider == nil {
-		return nil, fmt.Errorf("LLM manager not started or provider not available")
+	var job *models.Job
+	err := m.execute(ctx, "extract_job_from_description", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		job, err = provider.ExtractJobFromDescription(ctx, description)
+		return err
+	})
+	if err == nil {
+		m.salary.Apply(job)
 	}
+	return job, err
+}
 
-	if !healthy {
-		return nil, fmt.Errorf("LLM provider is not available - check API key configuration (set LLM_API_KEY environment variable)")
-	}
+// TailorResume tailors a resume for a specific job using the configured LLM providers
+func (m *Manager) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+	)
+	err := m.execute(ctx, "tailor_resume", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		tailoredResume, suggestions, err = provider.TailorResume(ctx, baseResume, job)
+		return err
+	})
+	return tailoredResume, suggestions, err
+}
 
-	return provider.ExtractJobFromDescription(ctx, description)
+// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
+func (m *Manager) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+		rawResponse    string
+	)
+	err := m.execute(ctx, "tailor_resume", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		tailoredResume, suggestions, rawResponse, err = provider.TailorResumeWithRawResponse(ctx, baseResume, job)
+		return err
+	})
+	return tailoredResume, suggestions, rawResponse, err
 }
 
-// TailorResume tailors a resume for a specific job using the configured LLM provider
-func (m *Manager) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+// TailorResumeStream tailors a resume and calls onSection for each tailored section as soon as it
+// is available. Providers that cannot stream hand out all sections once the resume is complete.
+// A section index may be sent again when a provider retries or the request fails over.
+func (m *Manager) TailorResumeStream(ctx context.Context, baseResume *models.BaseResume, job *models.Job, onSection providers.SectionHandler) (*models.TailoredResume, []models.Suggestion, string, error) {
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+		rawResponse    string
+	)
+	err := m.execute(ctx, "tailor_resume", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		if streamer, ok := provider.(SectionStreamer); ok {
+			tailoredResume, suggestions, rawResponse, err = streamer.TailorResumeStream(ctx, baseResume, job, onSection)
+			return err
+		}
+
+		tailoredResume, suggestions, rawResponse, err = provider.TailorResumeWithRawResponse(ctx, baseResume, job)
+		if err == nil {
+			for i, section := range tailoredResume.Sections {
+				onSection(i, section)
+			}
+		}
+		return err
+	})
+	return tailoredResume, suggestions, rawResponse, err
+}
+
+// ReviewResumeForATS assesses a resume's fit for a job posting using the configured LLM providers
+func (m *Manager) ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error) {
+	var review *models.ATSReview
+	err := m.execute(ctx, "ats_review", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		review, err = provider.ReviewResumeForATS(ctx, baseResume, job)
+		return err
+	})
+	return review, err
+}
+
+// AnalyzeSkillGap compares a resume's skills with a job's requirements using the configured LLM providers
+func (m *Manager) AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error) {
+	var analysis *models.SkillGapAnalysis
+	err := m.execute(ctx, "skill_gap", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		analysis, err = provider.AnalyzeSkillGap(ctx, baseResume, job)
+		return err
+	})
+	return analysis, err
+}
+
+// ExtractCompanyData extracts company information from its website's pages using the configured LLM providers
+func (m *Manager) ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error) {
+	var company *models.Company
+	err := m.execute(ctx, "company_extraction", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		company, err = provider.ExtractCompanyData(ctx, content, name, website)
+		return err
+	})
+	return company, err
+}
+
+// RefineResume revises a tailored resume in conversation using the configured LLM providers
+func (m *Manager) RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error) {
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+		rawResponse    string
+	)
+	err := m.execute(ctx, "refine_resume", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		tailoredResume, suggestions, rawResponse, err = provider.RefineResume(ctx, baseResume, conversation, instruction)
+		return err
+	})
+	return tailoredResume, suggestions, rawResponse, err
+}
+
+// GenerateInterviewQuestions predicts likely interview questions for a job using the configured LLM providers
+func (m *Manager) GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error) {
+	var questions []models.InterviewQuestion
+	err := m.execute(ctx, "interview_questions", func(ctx context.Context, provider LLMProvider) error {
+		var err error
+		questions, err = provider.GenerateInterviewQuestions(ctx, baseResume, job, count)
+		return err
+	})
+	return questions, err
+}
+
+// execute runs call against each healthy provider with a closed circuit, in chain order, until
+// one succeeds or fails with an error that is not worth failing over. Each call first waits for
+// room within its provider's rate limits.
+func (m *Manager) execute(ctx context.Context, operation string, call func(context.Context, LLMProvider) error) error {
+	requested := requestedProvider(ctx)
 	m.mu.RLock()
-	provider := m.provider
-	healthy := m.healthy
+	entries := make([]*providerEntry, 0, len(m.providers))
+	for _, entry := range m.providers {
+		if entry.healthy && (requested == "" || entry.provider.GetProviderName() == requested) {
+			entries = append(entries, entry)
+		}
+	}
+	started := len(m.providers) > 0
 	m.mu.RUnlock()
 
-	if provider == nil {
-		return nil, nil, fmt.Errorf("LLM manager not started or provider not available")
+	if !started {
+		return utils.NewLLMUnavailableError("LLM manager not started or provider not available")
+	}
+	if len(entries) == 0 && requested != "" {
+		return utils.NewLLMUnavailableError(fmt.Sprintf("LLM provider %q is not available", requested))
+	}
+	if len(entries) == 0 {
+		return utils.NewLLMUnavailableError("check API key configuration (set LLM_API_KEY environment variable)")
 	}
 
-	if !healthy {
-		return nil, nil, fmt.Errorf("LLM provider is not available - check API key configuration (set LLM_API_KEY environment variable)")
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+	ctx = cost.WithOperation(ctx, operationType(operation))
+
+	var (
+		lastErr  error
+		served   string
+		previous string
+	)
+	for _, entry := range entries {
+		name := entry.provider.GetProviderName()
+		if !entry.breaker.allow() {
+			continue
+		}
+		if previous != "" {
+			m.metrics.RecordFailover()
+			logger.Warn("Failing over to next LLM provider", map[string]interface{}{
+				"operation": operation,
+				"from":      previous,
+				"to":        name,
+			})
+		}
+
+		served = name
+		release, err := entry.limiter.acquire(ctx, name)
+		if err != nil {
+			// The provider was never called, so its circuit is left as it was
+			entry.breaker.cancelTrial()
+			lastErr = err
+			previous = name
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		lastErr = call(ctx, entry.provider)
+		release()
+		if lastErr == nil || !shouldFailover(lastErr) {
+			entry.breaker.recordSuccess()
+			break
+		}
+
+		if entry.breaker.recordFailure() {
+			logger.Warn("LLM provider circuit opened", map[string]interface{}{
+				"provider":      name,
+				"reset_timeout": m.config.LLM.Failover.ResetTimeout.String(),
+				"error":         lastErr.Error(),
+			})
+		}
+		previous = name
+		if ctx.Err() != nil {
+			break
+		}
 	}
 
-	return provider.TailorResume(ctx, baseResume, job)
+	if served == "" {
+		lastErr = utils.NewLLMUnavailableError("all LLM providers are temporarily unavailable (circuits open)")
+		served = "none"
+	}
+
+	m.record(ctx, operation, served, startTime, lastErr)
+	return lastErr
 }
 
-// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
-func (m *Manager) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
-	m.mu.RLock()
-	provider := m.provider
-	healthy := m.healthy
-	m.mu.RUnlock()
+// operationTypes groups manager operations into the operation types LLM spend is reported by
+var operationTypes = map[string]string{
+	"extract_job_data":             cost.OperationScrape,
+	"extract_job_from_description": cost.OperationScrape,
+	"tailor_resume":                cost.OperationTailor,
+	"refine_resume":                cost.OperationTailor,
+	"ats_review":                   cost.OperationAnalysis,
+	"skill_gap":                    cost.OperationAnalysis,
+	"interview_questions":          cost.OperationAnalysis,
+	"company_extraction":           cost.OperationScrape,
+}
 
-	if provider == nil {
-		return nil, nil, "", fmt.Errorf("LLM manager not started or provider not available")
+// operationType returns the spend operation type of a manager operation
+func operationType(operation string) string {
+	if operationType, ok := operationTypes[operation]; ok {
+		return operationType
 	}
+	return cost.OperationOther
+}
 
-	if !healthy {
-		return nil, nil, "", fmt.Errorf("LLM provider is not available - check API key configuration (set LLM_API_KEY environment variable)")
+// record tracks an LLM call in the manager metrics, on the timeline of the calling process and in
+// the stage timings of the calling job
+func (m *Manager) record(ctx context.Context, operation, provider string, startTime time.Time, err error) {
+	duration := time.Since(startTime)
+	m.metrics.Record(operation, provider, duration, err)
+	timing.Add(ctx, timing.StageLLM, duration)
+
+	details := map[string]interface{}{
+		"operation":   operation,
+		"provider":    provider,
+		"duration_ms": duration.Milliseconds(),
 	}
+	if err != nil {
+		details["error_code"] = utils.GetErrorCode(err)
+	}
+	timeline.Record(ctx, timeline.EventLLMCalled, details)
+}
 
-	return provider.TailorResumeWithRawResponse(ctx, baseResume, job)
+// anyHealthyLocked reports whether any provider is healthy; callers hold m.mu
+func (m *Manager) anyHealthyLocked() bool {
+	for _, entry := range m.providers {
+		if entry.healthy {
+			return true
+		}
+	}
+	return false
 }
 
-// IsHealthy checks if the LLM manager and provider are healthy
+// IsHealthy checks if the LLM manager has at least one healthy provider
 func (m *Manager) IsHealthy() bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.healthy && m.provider != nil
+	return m.anyHealthyLocked()
 }
 
-// GetProviderName returns the name of the current LLM provider
+// GetProviderName returns the name of the first healthy provider in the chain, or of the
+// primary provider when none is healthy
 func (m *Manager) GetProviderName() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if m.provider != nil {
-		return m.provider.GetProviderName()
+	for _, entry := range m.providers {
+		if entry.healthy {
+			return entry.provider.GetProviderName()
+		}
+	}
+	if len(m.providers) > 0 {
+		return m.providers[0].provider.GetProviderName()
 	}
 	return "none"
 }
 
-// CheckHealth performs a health check on the LLM provider
+// HasProvider reports whether provider is in the provider chain
+func (m *Manager) HasProvider(provider string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, entry := range m.providers {
+		if entry.provider.GetProviderName() == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHealth performs a health check on every provider in the chain and succeeds when at least
+// one of them is healthy
 func (m *Manager) CheckHealth(ctx context.Context) error {
 	m.mu.RLock()
-	provider := m.provider
+	entries := m.providers
 	m.mu.RUnlock()
 
-	if provider == nil {
+	if len(entries) == 0 {
 		return fmt.Errorf("LLM provider not available")
 	}
 
-	err := provider.IsHealthy(ctx)
+	results := make([]error, len(entries))
+	for i, entry := range entries {
+		results[i] = entry.provider.IsHealthy(ctx)
+	}
 
 	m.mu.Lock()
-	m.healthy = (err == nil)
+	for i, entry := range entries {
+		entry.healthy = results[i] == nil
+	}
+	healthy := m.anyHealthyLocked()
 	m.mu.Unlock()
 
-	return err
+	if healthy {
+		return nil
+	}
+	return errors.Join(results...)
+}
+
+// Name returns the component name used when registering with the monitoring service
+func (m *Manager) Name() string {
+	return "llm"
+}
+
+// Health reports the last known provider health without issuing a new API call
+func (m *Manager) Health() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.providers) == 0 {
+		return fmt.Errorf("LLM provider not available")
+	}
+	if !m.anyHealthyLocked() {
+		return fmt.Errorf("LLM provider %s is unhealthy", m.providers[0].provider.GetProviderName())
+	}
+	return nil
+}
+
+// GetStats returns request, token usage, parse failure and failover statistics for monitoring
+func (m *Manager) GetStats() map[string]interface{} {
+	stats := m.metrics.GetStats()
+
+	m.mu.RLock()
+	type providerState struct {
+		entry   *providerEntry
+		healthy bool
+	}
+	states := make([]providerState, len(m.providers))
+	for i, entry := range m.providers {
+		states[i] = providerState{entry: entry, healthy: entry.healthy}
+	}
+	healthy := m.anyHealthyLocked()
+	cacheEnabled := m.cache != nil
+	m.mu.RUnlock()
+
+	stats["cache_enabled"] = cacheEnabled
+
+	stats["provider"] = m.GetProviderName()
+	stats["provider_healthy"] = healthy
+	if len(states) == 0 {
+		return stats
+	}
+
+	var (
+		total      providers.UsageStats
+		queueDepth int
+	)
+	chain := make([]map[string]interface{}, 0, len(states))
+	for _, state := range states {
+		providerStats := map[string]interface{}{
+			"name":    state.entry.provider.GetProviderName(),
+			"healthy": state.healthy,
+			"circuit": state.entry.breaker.currentState(),
+		}
+		if state.entry.limiter != nil {
+			limiterStats := state.entry.limiter.stats()
+			providerStats["limits"] = limiterStats.toMap()
+			queueDepth += limiterStats.QueueDepth
+		}
+		if reporter, ok := state.entry.provider.(UsageReporter); ok {
+			usage := reporter.GetUsageStats()
+			total.InputTokens += usage.InputTokens
+			total.OutputTokens += usage.OutputTokens
+			total.Responses += usage.Responses
+			total.ParseFailures += usage.ParseFailures
+			total.CostUSD += usage.CostUSD
+			total.CacheHits += usage.CacheHits
+			total.CacheMisses += usage.CacheMisses
+			total.CacheReadTokens += usage.CacheReadTokens
+			total.CacheWriteTokens += usage.CacheWriteTokens
+			providerStats["cost_usd"] = usage.CostUSD
+			providerStats["input_tokens"] = usage.InputTokens
+			providerStats["output_tokens"] = usage.OutputTokens
+			providerStats["parse_failures"] = usage.ParseFailures
+			if usage.CacheHits+usage.CacheMisses > 0 {
+				providerStats["prompt_cache_hits"] = usage.CacheHits
+				providerStats["prompt_cache_misses"] = usage.CacheMisses
+			}
+		}
+		chain = append(chain, providerStats)
+	}
+	stats["providers"] = chain
+	stats["queue_depth"] = queueDepth
+
+	stats["input_tokens"] = total.InputTokens
+	stats["output_tokens"] = total.OutputTokens
+	stats["parse_failures"] = total.ParseFailures
+	stats["cost_usd"] = total.CostUSD
+
+	var parseFailureRate float64
+	if total.Responses > 0 {
+		parseFailureRate = float64(total.ParseFailures) / float64(total.Responses) * 100
+	}
+	stats["parse_failure_rate"] = parseFailureRate
+
+	var promptCacheHitRate float64
+	if requests := total.CacheHits + total.CacheMisses; requests > 0 {
+		promptCacheHitRate = float64(total.CacheHits) / float64(requests) * 100
+	}
+	stats["prompt_cache_hits"] = total.CacheHits
+	stats["prompt_cache_misses"] = total.CacheMisses
+	stats["prompt_cache_hit_rate"] = promptCacheHitRate
+	stats["prompt_cache_read_tokens"] = total.CacheReadTokens
+	stats["prompt_cache_write_tokens"] = total.CacheWriteTokens
+
+	return stats
 }
@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"sync"
+	"time"
+
+	"letraz-utils/internal/llm/providers"
+	"letraz-utils/internal/metrics"
+)
+
+// UsageReporter is implemented by providers that track token usage and parse failures
+type UsageReporter interface {
+	GetUsageStats() providers.UsageStats
+}
+
+// Metrics tracks request outcomes and latency for LLM operations
+type Metrics struct {
+	mu                 sync.RWMutex
+	totalRequests      int64
+	successfulRequests int64
+	failedRequests     int64
+	responseTime       *metrics.Histogram
+	operations         map[string]int64
+	servedBy           map[string]int64 // requests answered by each provider
+	failovers          int64
+	cacheHits          int64
+	cacheMisses        int64
+	lastActivity       time.Time
+}
+
+// NewMetrics creates an empty LLM metrics tracker
+func NewMetrics() *Metrics {
+	return &Metrics{
+		responseTime: metrics.NewLatencyHistogram(),
+		operations:   make(map[string]int64),
+		servedBy:     make(map[string]int64),
+	}
+}
+
+// Record records the outcome of a single LLM operation and the provider that served it
+func (lm *Metrics) Record(operation, provider string, duration time.Duration, err error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	lm.totalRequests++
+	if err != nil {
+		lm.failedRequests++
+	} else {
+		lm.successfulRequests++
+	}
+	lm.responseTime.ObserveDuration(duration)
+	lm.operations[operation]++
+	lm.servedBy[provider]++
+	lm.lastActivity = time.Now()
+}
+
+// RecordFailover records a request moving on to the next provider in the chain
+func (lm *Metrics) RecordFailover() {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	lm.failovers++
+}
+
+// RecordCacheLookup records an extraction cache lookup
+func (lm *Metrics) RecordCacheLookup(hit bool) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	if hit {
+		lm.cacheHits++
+	} else {
+		lm.cacheMisses++
+	}
+}
+
+// GetStats returns the current metrics in the format consumed by the monitoring service
+func (lm *Metrics) GetStats() map[string]interface{} {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+
+	operations := make(map[string]int64, len(lm.operations))
+	for name, count := range lm.operations {
+		operations[name] = count
+	}
+	servedBy := make(map[string]int64, len(lm.servedBy))
+	for name, count := range lm.servedBy {
+		servedBy[name] = count
+	}
+
+	return map[string]interface{}{
+		"total_requests":        lm.totalRequests,
+		"successful_requests":   lm.successfulRequests,
+		"failed_requests":       lm.failedRequests,
+		"response_time_seconds": lm.responseTime.Snapshot(),
+		"requests_by_operation": operations,
+		"requests_by_provider":  servedBy,
+		"failovers":             lm.failovers,
+		"cache_hits":            lm.cacheHits,
+		"cache_misses":          lm.cacheMisses,
+		"last_activity":         lm.lastActivity,
+	}
+}
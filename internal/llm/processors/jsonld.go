@@ -0,0 +1,243 @@
+package processors
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"letraz-utils/pkg/models"
+)
+
+// maxJSONLDDescriptionLength bounds the description taken from the start of a JSON-LD posting
+const maxJSONLDDescriptionLength = 600
+
+// salaryUnits maps schema.org unitText values to salary periods
+var salaryUnits = map[string]string{
+	"HOUR": "hourly", "DAY": "daily", "WEEK": "weekly", "MONTH": "monthly", "YEAR": "yearly",
+}
+
+// ExtractJSONLDJob reads the schema.org JobPosting JSON-LD block embedded in a page. It returns
+// nil when the page has none, and complete reports whether the posting has every field the LLM
+// would otherwise extract, in English: a title, a company, a description and its requirements
+// or responsibilities.
+func ExtractJSONLDJob(page, pageURL string) (job *models.Job, complete bool) {
+	if !strings.Contains(page, "application/ld+json") {
+		return nil, false
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil, false
+	}
+
+	var posting map[string]interface{}
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, script *goquery.Selection) bool {
+		var data interface{}
+		if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+			return true
+		}
+		posting = findJobPosting(data)
+		return posting == nil
+	})
+	if posting == nil {
+		return nil, false
+	}
+
+	job = jobFromJSONLD(posting, pageURL)
+	description := BlockText(html.UnescapeString(stringValue(posting["description"])))
+	language := DetectTextLanguage(description)
+
+	complete = job.Title != "" && job.CompanyName != "" && job.Description != "" &&
+		(len(job.Requirements) > 0 || len(job.Responsibilities) > 0) &&
+		(language == "" || language == "en")
+	return job, complete
+}
+
+// jobFromJSONLD maps a JobPosting node to a job. Lists come from the posting's own
+// qualifications, responsibilities and benefits, or else from the lists in its description.
+func jobFromJSONLD(posting map[string]interface{}, pageURL string) *models.Job {
+	descriptionHTML := html.UnescapeString(stringValue(posting["description"]))
+	requirements, responsibilities, benefits := SectionLists(descriptionHTML)
+
+	if items := textList(posting["qualifications"], posting["skills"], posting["experienceRequirements"], posting["educationRequirements"]); len(items) > 0 {
+		requirements = items
+	}
+	if items := textList(posting["responsibilities"]); len(items) > 0 {
+		responsibilities = items
+	}
+	if items := textList(posting["jobBenefits"]); len(items) > 0 {
+		benefits = items
+	}
+
+	jobURL := stringValue(posting["url"])
+	if jobURL == "" {
+		jobURL = pageURL
+	}
+	job := &models.Job{
+		Title:            strings.TrimSpace(html.UnescapeString(stringValue(posting["title"]))),
+		JobURL:           jobURL,
+		CompanyName:      strings.TrimSpace(html.UnescapeString(nameValue(posting["hiringOrganization"]))),
+		Location:         jsonLDLocation(posting),
+		Description:      Summarize(strings.ReplaceAll(BlockText(descriptionHTML), "\n", " "), maxJSONLDDescriptionLength),
+		Requirements:     requirements,
+		Responsibilities: responsibilities,
+		Benefits:         benefits,
+	}
+
+	if salary, ok := jsonLDSalary(posting["baseSalary"]); ok {
+		job.Salary = salary
+		job.Currency = salary.Currency
+	} else if salary, ok := jsonLDSalary(posting["estimatedSalary"]); ok {
+		job.Salary = salary
+		job.Currency = salary.Currency
+	}
+	return job
+}
+
+// findJobPosting returns the first node typed JobPosting in a JSON-LD document, searching
+// arrays and @graph containers
+func findJobPosting(data interface{}) map[string]interface{} {
+	switch value := data.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if posting := findJobPosting(item); posting != nil {
+				return posting
+			}
+		}
+	case map[string]interface{}:
+		if hasType(value["@type"], "JobPosting") {
+			return value
+		}
+		if graph, ok := value["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+// hasType reports whether a JSON-LD @type, a string or an array of strings, includes name
+func hasType(value interface{}, name string) bool {
+	switch t := value.(type) {
+	case string:
+		return t == name
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonLDLocation joins the address of the posting's first location, or returns "Remote" for
+// remote postings without one
+func jsonLDLocation(posting map[string]interface{}) string {
+	location := posting["jobLocation"]
+	if locations, ok := location.([]interface{}); ok && len(locations) > 0 {
+		location = locations[0]
+	}
+
+	var parts []string
+	if place, ok := location.(map[string]interface{}); ok {
+		switch address := place["address"].(type) {
+		case map[string]interface{}:
+			for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+				if part := strings.TrimSpace(nameValue(address[key])); part != "" {
+					parts = append(parts, part)
+				}
+			}
+		case string:
+			parts = append(parts, strings.TrimSpace(address))
+		}
+	}
+	if len(parts) == 0 && strings.EqualFold(stringValue(posting["jobLocationType"]), "TELECOMMUTE") {
+		return "Remote"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// jsonLDSalary reads a MonetaryAmount, whose value is a number or a QuantitativeValue with a
+// range and a unit
+func jsonLDSalary(value interface{}) (models.Salary, bool) {
+	amount, ok := value.(map[string]interface{})
+	if !ok {
+		return models.Salary{}, false
+	}
+
+	salary := models.Salary{Currency: strings.ToUpper(stringValue(amount["currency"]))}
+	switch quantity := amount["value"].(type) {
+	case map[string]interface{}:
+		salary.Min = floatValue(quantity["minValue"])
+		salary.Max = floatValue(quantity["maxValue"])
+		if exact := floatValue(quantity["value"]); salary.Min == 0 && salary.Max == 0 {
+			salary.Min, salary.Max = exact, exact
+		}
+		salary.Period = salaryUnits[strings.ToUpper(stringValue(quantity["unitText"]))]
+	default:
+		exact := floatValue(quantity)
+		salary.Min, salary.Max = exact, exact
+	}
+	if salary.Period == "" {
+		salary.Period = salaryUnits[strings.ToUpper(stringValue(amount["unitText"]))]
+	}
+	return salary, salary.Min > 0 || salary.Max > 0
+}
+
+// textList returns the items of JSON-LD text properties, which hold a string of HTML or plain
+// lines, or an array of strings
+func textList(values ...interface{}) []string {
+	var items []string
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			v = html.UnescapeString(v)
+			if listed := ListItems(v); len(listed) > 0 {
+				items = append(items, listed...)
+				continue
+			}
+			for _, line := range strings.Split(BlockText(v), "\n") {
+				if line = strings.TrimSpace(strings.TrimLeft(line, "•-*·")); line != "" {
+					items = append(items, line)
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				if s := strings.TrimSpace(stringValue(item)); s != "" {
+					items = append(items, s)
+				}
+			}
+		}
+	}
+	return items
+}
+
+// stringValue returns a JSON string, or "" for other values
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// nameValue returns a JSON-LD value that is either a string or a node with a name
+func nameValue(value interface{}) string {
+	if node, ok := value.(map[string]interface{}); ok {
+		return stringValue(node["name"])
+	}
+	return stringValue(value)
+}
+
+// floatValue returns a JSON number, or a number written as a string, as an amount
+func floatValue(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		var f float64
+		if _, err := fmt.Sscan(strings.ReplaceAll(v, ",", ""), &f); err == nil {
+			return f
+		}
+	}
+	return 0
+}
@@ -0,0 +1,200 @@
+package processors
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// languageSampleRunes bounds how much text is read to detect its language
+const languageSampleRunes = 5000
+
+// minStopWordHits is how many stop words of a language a text needs before it is attributed to it
+const minStopWordHits = 5
+
+// languageNames maps the ISO 639-1 codes the detector returns to English language names
+var languageNames = map[string]string{
+	"en": "English", "de": "German", "fr": "French", "es": "Spanish", "it": "Italian",
+	"pt": "Portuguese", "nl": "Dutch", "ja": "Japanese", "zh": "Chinese", "ko": "Korean",
+	"ru": "Russian", "uk": "Ukrainian", "ar": "Arabic", "he": "Hebrew", "el": "Greek",
+	"th": "Thai", "hi": "Hindi",
+}
+
+// languageStopWords are frequent words that tell Latin-script languages apart. Words shared by
+// several languages are kept in only one list or left out.
+var languageStopWords = map[string]map[string]bool{
+	"en": wordSet("the and with you your our will are this for we of to is as"),
+	"de": wordSet("der die das und mit für ist wir sie ihre eine einen von auf bei oder werden nicht sowie zur zum"),
+	"fr": wordSet("le les et pour avec est sont nous vous une du dans au sur vos notre des aux votre"),
+	"es": wordSet("el los las y para con es somos del por tu nuestro nuestra experiencia trabajo"),
+	"it": wordSet("il gli di sono siamo della nel che nostro nostra esperienza lavoro degli delle"),
+	"pt": wordSet("os com é são uma um da em na você nosso nossa experiência não"),
+	"nl": wordSet("het een van voor met zijn wij je jouw onze bij op te naar ook"),
+}
+
+// DetectLanguage returns the ISO 639-1 code of the language of a page. The page text decides when
+// it is conclusive, since job boards often serve translated postings inside a template that
+// declares another language; otherwise the language the page declares is used. Empty when neither
+// is known.
+func (hc *HTMLCleaner) DetectLanguage(html string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+
+	doc.Find("script, style, noscript").Remove()
+	if language := DetectTextLanguage(doc.Find("body").Text()); language != "" {
+		return language
+	}
+	return declaredLanguage(doc)
+}
+
+// DetectTextLanguage returns the ISO 639-1 code of the language text is written in, or an empty
+// string when the text is too short or too mixed to tell
+func DetectTextLanguage(text string) string {
+	if language := scriptLanguage(text); language != "" {
+		return language
+	}
+
+	hits := make(map[string]int, len(languageStopWords))
+	for _, word := range strings.FieldsFunc(strings.ToLower(truncateRunes(text, languageSampleRunes)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for language, words := range languageStopWords {
+			if words[word] {
+				hits[language]++
+			}
+		}
+	}
+
+	best, bestHits, secondHits := "", 0, 0
+	for language, count := range hits {
+		switch {
+		case count > bestHits:
+			best, bestHits, secondHits = language, count, bestHits
+		case count > secondHits:
+			secondHits = count
+		}
+	}
+	// The winner needs enough evidence and a clear lead over the runner-up
+	if bestHits < minStopWordHits || bestHits*2 < secondHits*3 {
+		return ""
+	}
+	return best
+}
+
+// LanguageName returns the English name of an ISO 639-1 code, or the code itself when unknown
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// NormalizeLanguageCode reduces a language tag such as "de-DE" or "pt_BR" to its lowercased
+// primary language subtag
+func NormalizeLanguageCode(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// scriptLanguage attributes text written mostly in a non-Latin script to its language
+func scriptLanguage(text string) string {
+	var letters, kana, han, hangul, cyrillic, ukrainian, arabic, hebrew, greek, thai, devanagari int
+	for _, r := range truncateRunes(text, languageSampleRunes) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		case unicode.Is(unicode.Greek, r):
+			greek++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		case unicode.Is(unicode.Devanagari, r):
+			devanagari++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	share := func(count int) float64 { return float64(count) / float64(letters) }
+	switch {
+	// Japanese mixes kana with kanji; any notable share of kana sets it apart from Chinese
+	case share(kana) > 0.05:
+		return "ja"
+	case share(han) > 0.3:
+		return "zh"
+	case share(hangul) > 0.3:
+		return "ko"
+	case share(cyrillic) > 0.5:
+		if ukrainian > 0 {
+			return "uk"
+		}
+		return "ru"
+	case share(arabic) > 0.5:
+		return "ar"
+	case share(hebrew) > 0.5:
+		return "he"
+	case share(greek) > 0.5:
+		return "el"
+	case share(thai) > 0.5:
+		return "th"
+	case share(devanagari) > 0.5:
+		return "hi"
+	}
+	return ""
+}
+
+// declaredLanguage returns the language a page declares in its markup, if any
+func declaredLanguage(doc *goquery.Document) string {
+	if lang, ok := doc.Find("html").Attr("lang"); ok && strings.TrimSpace(lang) != "" {
+		return NormalizeLanguageCode(lang)
+	}
+	for _, selector := range []string{"meta[http-equiv='content-language' i]", "meta[property='og:locale']"} {
+		if content, ok := doc.Find(selector).Attr("content"); ok && strings.TrimSpace(content) != "" {
+			return NormalizeLanguageCode(content)
+		}
+	}
+	return ""
+}
+
+// truncateRunes returns at most n runes of text
+func truncateRunes(text string, n int) string {
+	count := 0
+	for i := range text {
+		if count == n {
+			return text[:i]
+		}
+		count++
+	}
+	return text
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,974 @@
+package processors
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxPDFStreamBytes bounds the decoded size of one stream of a PDF
+const maxPDFStreamBytes = 32 << 20
+
+// maxPDFFormDepth bounds how deep form XObjects drawn inside each other are followed
+const maxPDFFormDepth = 8
+
+// maxPDFObjectDepth bounds how deep arrays and dictionaries nest; deeper ones are read as empty
+const maxPDFObjectDepth = 64
+
+// PDF errors
+var (
+	ErrNotPDF       = errors.New("content is not a PDF")
+	ErrPDFEncrypted = errors.New("PDF is encrypted")
+	ErrPDFNoText    = errors.New("PDF has no extractable text, it may be a scan")
+	ErrPDFMalformed = errors.New("PDF is malformed")
+)
+
+// IsPDF reports whether data starts with a PDF header; some servers send a few bytes of junk
+// before it, which readers tolerate
+func IsPDF(data []byte) bool {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	return bytes.Contains(head, []byte("%PDF-"))
+}
+
+// ExtractPDFText returns the text of a PDF in page order, one line per line of text and pages
+// separated by a blank line. Text is mapped to Unicode through the fonts' ToUnicode CMaps, and
+// single-byte fonts without one are read as WinAnsi. Only Flate-compressed and uncompressed
+// streams are read, which covers what word processors and browsers produce. A file the parser
+// cannot cope with returns ErrPDFMalformed rather than panicking in the caller's goroutine.
+func ExtractPDFText(data []byte) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("%w: %v", ErrPDFMalformed, r)
+		}
+	}()
+	return extractPDFText(data)
+}
+
+// extractPDFText does the work of ExtractPDFText without recovering, so fuzzing sees panics
+func extractPDFText(data []byte) (string, error) {
+	if !IsPDF(data) {
+		return "", ErrNotPDF
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", ErrPDFEncrypted
+	}
+
+	doc := parsePDF(data)
+	var pages []string
+	for _, page := range doc.pages() {
+		text := &pdfTextWriter{}
+		for _, content := range doc.contents(page.dict["Contents"]) {
+			doc.showContent(text, content, page.resources, 0)
+		}
+		if s := text.String(); s != "" {
+			pages = append(pages, s)
+		}
+	}
+	if len(pages) == 0 {
+		return "", ErrPDFNoText
+	}
+	return strings.Join(pages, "\n\n"), nil
+}
+
+// PDF object types
+type (
+	pdfName    string
+	pdfString  []byte
+	pdfKeyword string
+	pdfArray   []interface{}
+	pdfDict    map[string]interface{}
+	pdfRef     int
+)
+
+// pdfStream is a stream object; data is still encoded
+type pdfStream struct {
+	dict pdfDict
+	data []byte
+}
+
+// pdfLexer reads the tokens of PDF syntax; pos never passes len(data)
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int // nesting of the arrays and dictionaries being read
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// skipSpace skips whitespace and comments
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token returns the next token: a number, name, string, keyword or one of the delimiters
+// "[", "]", "<<" and ">>" as a keyword. It returns nil at the end of the data.
+func (l *pdfLexer) token() interface{} {
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			return nil
+		}
+
+		c := l.data[l.pos]
+		switch {
+		case c == '(':
+			return l.literalString()
+		case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+			l.pos += 2
+			return pdfKeyword("<<")
+		case c == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+			l.pos += 2
+			return pdfKeyword(">>")
+		case c == '<':
+			return l.hexString()
+		case c == '/':
+			l.pos++
+			return pdfName(l.regular(true))
+		case c == '[' || c == ']' || c == '{' || c == '}':
+			l.pos++
+			return pdfKeyword(c)
+		case c == ')' || c == '>':
+			// Stray delimiter in a damaged file
+			l.pos++
+			continue
+		}
+
+		word := l.regular(false)
+		if n, err := strconv.ParseFloat(word, 64); err == nil {
+			return n
+		}
+		return pdfKeyword(word)
+	}
+}
+
+// regular reads a run of regular characters, decoding #xx escapes in names
+func (l *pdfLexer) regular(name bool) string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if name && strings.Contains(word, "#") {
+		var b strings.Builder
+		for i := 0; i < len(word); i++ {
+			if word[i] == '#' && i+2 < len(word) {
+				if v, err := strconv.ParseUint(word[i+1:i+3], 16, 8); err == nil {
+					b.WriteByte(byte(v))
+					i += 2
+					continue
+				}
+			}
+			b.WriteByte(word[i])
+		}
+		word = b.String()
+	}
+	return word
+}
+
+// literalString reads a (string) with its escapes and balanced parentheses
+func (l *pdfLexer) literalString() pdfString {
+	l.pos++ // (
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// hexString reads a <hex string>; a missing final digit is zero
+func (l *pdfLexer) hexString() pdfString {
+	l.pos++ // <
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	if l.pos < len(l.data) {
+		l.pos++ // >
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(v)
+	}
+	return out
+}
+
+// object reads a complete object: an array, a dictionary, a reference or a single token
+func (l *pdfLexer) object() interface{} {
+	tok := l.token()
+	switch t := tok.(type) {
+	case pdfKeyword:
+		switch t {
+		case "[":
+			if l.depth >= maxPDFObjectDepth {
+				return pdfArray{}
+			}
+			l.depth++
+			defer func() { l.depth-- }()
+			var arr pdfArray
+			for {
+				l.skipSpace()
+				if l.pos >= len(l.data) {
+					return arr
+				}
+				if l.data[l.pos] == ']' {
+					l.pos++
+					return arr
+				}
+				arr = append(arr, l.object())
+			}
+		case "<<":
+			dict := pdfDict{}
+			if l.depth >= maxPDFObjectDepth {
+				return dict
+			}
+			l.depth++
+			defer func() { l.depth-- }()
+			for {
+				key := l.token()
+				if key == nil || key == pdfKeyword(">>") {
+					return dict
+				}
+				if name, ok := key.(pdfName); ok {
+					dict[string(name)] = l.object()
+				}
+			}
+		}
+	case float64:
+		// A reference is "num gen R"
+		save := l.pos
+		if gen, ok := l.token().(float64); ok && gen >= 0 {
+			if l.token() == pdfKeyword("R") {
+				return pdfRef(int(t))
+			}
+		}
+		l.pos = save
+	}
+	return tok
+}
+
+// pdfDocument holds the objects of a PDF by number
+type pdfDocument struct {
+	objects map[int]interface{}
+	cmaps   map[int]*pdfCMap
+}
+
+// objectHeader finds "num gen obj" headers
+var objectHeader = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+// parsePDF reads every object in the file, later definitions replacing earlier ones as in
+// incremental updates, then the objects packed in object streams. The cross-reference table is
+// not used, so damaged files are read as far as they go.
+func parsePDF(data []byte) *pdfDocument {
+	doc := &pdfDocument{objects: make(map[int]interface{}), cmaps: make(map[int]*pdfCMap)}
+
+	for _, m := range objectHeader.FindAllSubmatchIndex(data, -1) {
+		num, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		l := &pdfLexer{data: data, pos: m[1]}
+		obj := l.object()
+		if dict, ok := obj.(pdfDict); ok {
+			l.skipSpace()
+			if l.pos < len(data) && bytes.HasPrefix(data[l.pos:], []byte("stream")) {
+				obj = &pdfStream{dict: dict, data: streamData(data, l.pos+len("stream"), dict)}
+			}
+		}
+		doc.objects[num] = obj
+	}
+
+	var packed []*pdfStream
+	for _, obj := range doc.objects {
+		if s, ok := obj.(*pdfStream); ok && s.dict["Type"] == pdfName("ObjStm") {
+			packed = append(packed, s)
+		}
+	}
+	for _, s := range packed {
+		doc.unpack(s)
+	}
+	return doc
+}
+
+// streamData returns the raw data of a stream starting after its "stream" keyword, using its
+// Length when it is direct and lands on "endstream"
+func streamData(data []byte, start int, dict pdfDict) []byte {
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+	if start > len(data) {
+		return nil
+	}
+
+	if n, ok := dict["Length"].(float64); ok && n >= 0 && n <= float64(len(data)-start) {
+		end := start + int(n)
+		rest := bytes.TrimLeft(data[end:min(end+32, len(data))], " \r\n\t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return data[start:end]
+		}
+	}
+	end := bytes.Index(data[start:], []byte("endstream"))
+	if end < 0 {
+		return data[start:]
+	}
+	return bytes.TrimRight(data[start:start+end], "\r\n")
+}
+
+// unpack adds the objects packed in an object stream, unless the file also defines them
+func (doc *pdfDocument) unpack(s *pdfStream) {
+	data, ok := doc.decode(s)
+	if !ok {
+		return
+	}
+	count, _ := s.dict["N"].(float64)
+	first, _ := s.dict["First"].(float64)
+
+	header := &pdfLexer{data: data}
+	for i := 0; i < int(count); i++ {
+		num, ok1 := header.token().(float64)
+		offset, ok2 := header.token().(float64)
+		if !ok1 || !ok2 {
+			return
+		}
+		pos := int(first) + int(offset)
+		if pos < 0 || pos >= len(data) {
+			continue
+		}
+		if _, exists := doc.objects[int(num)]; !exists {
+			doc.objects[int(num)] = (&pdfLexer{data: data, pos: pos}).object()
+		}
+	}
+}
+
+// resolve follows references to the object they point to
+func (doc *pdfDocument) resolve(obj interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+		obj = doc.objects[int(ref)]
+	}
+	return nil
+}
+
+// dict resolves obj to a dictionary, taking the dictionary of a stream
+func (doc *pdfDocument) dict(obj interface{}) pdfDict {
+	switch v := doc.resolve(obj).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.dict
+	}
+	return nil
+}
+
+// decode returns the decoded data of a stream, or false when one of its filters is not
+// supported
+func (doc *pdfDocument) decode(s *pdfStream) ([]byte, bool) {
+	var filters []interface{}
+	switch f := doc.resolve(s.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{f}
+	case pdfArray:
+		filters = f
+	}
+
+	data := s.data
+	for _, f := range filters {
+		switch doc.resolve(f) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, false
+			}
+			// Truncated streams are common; keep what inflated
+			decoded, _ := io.ReadAll(io.LimitReader(zr, maxPDFStreamBytes))
+			zr.Close()
+			data = decoded
+		default:
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// pdfPage is a page with the resources it draws with, inherited from the page tree
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages returns the pages in order, walking the page tree from the catalog, or every page
+// object in object order when the file has no usable tree
+func (doc *pdfDocument) pages() []pdfPage {
+	var pages []pdfPage
+	visited := make(map[pdfRef]bool)
+
+	var walk func(node interface{}, resources pdfDict)
+	walk = func(node interface{}, resources pdfDict) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict := doc.dict(node)
+		if dict == nil {
+			return
+		}
+		if r := doc.dict(dict["Resources"]); r != nil {
+			resources = r
+		}
+		if dict["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: dict, resources: resources})
+			return
+		}
+		if kids, ok := doc.resolve(dict["Kids"]).(pdfArray); ok {
+			for _, kid := range kids {
+				walk(kid, resources)
+			}
+		}
+	}
+
+	for _, num := range doc.sortedNumbers() {
+		if dict := doc.dict(pdfRef(num)); dict != nil && dict["Type"] == pdfName("Catalog") {
+			walk(dict["Pages"], nil)
+			break
+		}
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	for _, num := range doc.sortedNumbers() {
+		if dict := doc.dict(pdfRef(num)); dict != nil && dict["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: dict, resources: doc.inheritedResources(dict)})
+		}
+	}
+	return pages
+}
+
+// inheritedResources returns the resources of a page, looking up its parents when it has none
+func (doc *pdfDocument) inheritedResources(dict pdfDict) pdfDict {
+	for i := 0; dict != nil && i < 32; i++ {
+		if r := doc.dict(dict["Resources"]); r != nil {
+			return r
+		}
+		dict = doc.dict(dict["Parent"])
+	}
+	return nil
+}
+
+func (doc *pdfDocument) sortedNumbers() []int {
+	nums := make([]int, 0, len(doc.objects))
+	for num := range doc.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// contents returns the decoded content streams of a page, which may be one stream or an array
+func (doc *pdfDocument) contents(obj interface{}) [][]byte {
+	var streams []interface{}
+	switch v := doc.resolve(obj).(type) {
+	case *pdfStream:
+		streams = []interface{}{v}
+	case pdfArray:
+		streams = v
+	}
+
+	var out [][]byte
+	for _, s := range streams {
+		if stream, ok := doc.resolve(s).(*pdfStream); ok {
+			if data, ok := doc.decode(stream); ok {
+				out = append(out, data)
+			}
+		}
+	}
+	return out
+}
+
+// pdfFont decodes the strings shown with a font
+type pdfFont struct {
+	cmap      *pdfCMap
+	composite bool // Type0 fonts use multi-byte codes
+}
+
+// font returns the font of a resource dictionary by name
+func (doc *pdfDocument) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts := doc.dict(resources["Font"])
+	if fonts == nil {
+		return nil
+	}
+	fontRef := fonts[string(name)]
+	dict := doc.dict(fontRef)
+	if dict == nil {
+		return nil
+	}
+
+	font := &pdfFont{composite: dict["Subtype"] == pdfName("Type0")}
+	ref, ok := dict["ToUnicode"].(pdfRef)
+	if !ok {
+		return font
+	}
+	if cmap, cached := doc.cmaps[int(ref)]; cached {
+		font.cmap = cmap
+		return font
+	}
+	if stream, ok := doc.resolve(ref).(*pdfStream); ok {
+		if data, ok := doc.decode(stream); ok {
+			font.cmap = parseCMap(data)
+		}
+	}
+	doc.cmaps[int(ref)] = font.cmap
+	return font
+}
+
+// showContent interprets a content stream, writing the text it shows to w. Form XObjects it
+// draws are followed with their own resources.
+func (doc *pdfDocument) showContent(w *pdfTextWriter, content []byte, resources pdfDict, depth int) {
+	l := &pdfLexer{data: content}
+	var operands []interface{}
+	var font *pdfFont
+	var lineY float64
+	var haveLineY bool
+
+	show := func(s interface{}) {
+		if str, ok := s.(pdfString); ok {
+			w.text(font.decode(str))
+		}
+	}
+
+	for {
+		obj := l.object()
+		if obj == nil {
+			return
+		}
+		op, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch op {
+		case "BT":
+			haveLineY = false
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(pdfName); ok {
+					font = doc.font(resources, name)
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, _ := operands[1].(float64); ty != 0 {
+					w.newline()
+				} else {
+					w.space()
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				y, _ := operands[5].(float64)
+				if haveLineY && y == lineY {
+					w.space()
+				} else {
+					w.newline()
+				}
+				lineY, haveLineY = y, true
+			}
+		case "T*":
+			w.newline()
+		case "Tj":
+			if len(operands) >= 1 {
+				show(operands[len(operands)-1])
+			}
+		case "'":
+			w.newline()
+			if len(operands) >= 1 {
+				show(operands[len(operands)-1])
+			}
+		case "\"":
+			w.newline()
+			if len(operands) >= 3 {
+				show(operands[2])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				arr, _ := operands[len(operands)-1].(pdfArray)
+				for _, item := range arr {
+					// Adjustments are in thousandths of the font size; a wide one is a word gap
+					if n, ok := item.(float64); ok && n < -200 {
+						w.space()
+					}
+					show(item)
+				}
+			}
+		case "ET":
+			w.space()
+		case "Do":
+			if len(operands) >= 1 && depth < maxPDFFormDepth {
+				if name, ok := operands[0].(pdfName); ok {
+					doc.showForm(w, resources, name, depth)
+				}
+			}
+		case "BI":
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+// showForm writes the text of a form XObject drawn by a content stream
+func (doc *pdfDocument) showForm(w *pdfTextWriter, resources pdfDict, name pdfName, depth int) {
+	xobjects := doc.dict(resources["XObject"])
+	if xobjects == nil {
+		return
+	}
+	stream, ok := doc.resolve(xobjects[string(name)]).(*pdfStream)
+	if !ok || stream.dict["Subtype"] != pdfName("Form") {
+		return
+	}
+	data, ok := doc.decode(stream)
+	if !ok {
+		return
+	}
+	formResources := doc.dict(stream.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	w.newline()
+	doc.showContent(w, data, formResources, depth+1)
+	w.newline()
+}
+
+// skipInlineImage skips the binary data of an inline image up to its EI operator
+func (l *pdfLexer) skipInlineImage() {
+	idx := bytes.Index(l.data[l.pos:], []byte("ID"))
+	if idx < 0 {
+		l.pos = len(l.data)
+		return
+	}
+	l.pos += idx + 2
+	for l.pos < len(l.data) {
+		idx := bytes.Index(l.data[l.pos:], []byte("EI"))
+		if idx < 0 {
+			l.pos = len(l.data)
+			return
+		}
+		end := l.pos + idx
+		l.pos = end + 2
+		if end > 0 && isPDFSpace(l.data[end-1]) && (l.pos >= len(l.data) || isPDFSpace(l.data[l.pos])) {
+			return
+		}
+	}
+}
+
+// winAnsiHigh maps the WinAnsi codes 0x80-0x9F that differ from Latin-1
+var winAnsiHigh = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// decode maps the codes of a shown string to text
+func (f *pdfFont) decode(s pdfString) string {
+	if f != nil && f.cmap != nil {
+		return f.cmap.decode(s, f.composite)
+	}
+	// Codes of a composite font without a CMap are glyph IDs and cannot be read
+	if f != nil && f.composite {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range s {
+		switch r, ok := winAnsiHigh[c]; {
+		case ok:
+			b.WriteRune(r)
+		case c >= 0x20 || c == '\t':
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+// pdfCodeRange is a codespace range of a CMap
+type pdfCodeRange struct {
+	lo, hi []byte
+}
+
+// pdfCMap maps character codes to Unicode text
+type pdfCMap struct {
+	ranges []pdfCodeRange
+	chars  map[string]string // code bytes -> text
+}
+
+// parseCMap reads the codespace ranges and bfchar and bfrange mappings of a ToUnicode CMap
+func parseCMap(data []byte) *pdfCMap {
+	cmap := &pdfCMap{chars: make(map[string]string)}
+	l := &pdfLexer{data: data}
+	var operands []interface{}
+
+	for {
+		obj := l.object()
+		if obj == nil {
+			return cmap
+		}
+		op, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch op {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, ok1 := operands[i].(pdfString)
+				hi, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 && len(lo) == len(hi) && len(lo) > 0 {
+					cmap.ranges = append(cmap.ranges, pdfCodeRange{lo: lo, hi: hi})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfString)
+				dst, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 {
+					cmap.chars[string(src)] = utf16Text(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(pdfString)
+				hi, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 || len(lo) > 4 {
+					continue
+				}
+				cmap.addRange(lo, hi, operands[i+2])
+			}
+		}
+		if strings.HasPrefix(string(op), "end") || strings.HasPrefix(string(op), "begin") {
+			operands = operands[:0]
+		}
+	}
+}
+
+// addRange maps the codes lo to hi, either to consecutive text starting at dst or to the
+// entries of a dst array
+func (c *pdfCMap) addRange(lo, hi []byte, dst interface{}) {
+	start, end := codeValue(lo), codeValue(hi)
+	if end < start || end-start > 0xFFFF {
+		return
+	}
+	for code := start; code <= end; code++ {
+		key := codeBytes(code, len(lo))
+		switch d := dst.(type) {
+		case pdfString:
+			text := utf16Units(d)
+			if len(text) == 0 {
+				return
+			}
+			text[len(text)-1] += uint16(code - start)
+			c.chars[key] = string(utf16.Decode(text))
+		case pdfArray:
+			if i := int(code - start); i < len(d) {
+				if s, ok := d[i].(pdfString); ok {
+					c.chars[key] = utf16Text(s)
+				}
+			}
+		}
+	}
+}
+
+// decode maps the codes of s to text, splitting s into codes by the codespace ranges
+func (c *pdfCMap) decode(s pdfString, composite bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		n := c.codeLength(s[i:], composite)
+		if i+n > len(s) {
+			n = len(s) - i
+		}
+		if text, ok := c.chars[string(s[i:i+n])]; ok {
+			b.WriteString(text)
+		} else if !composite && s[i] >= 0x20 {
+			b.WriteRune(rune(s[i]))
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// codeLength returns the length of the code at the start of s
+func (c *pdfCMap) codeLength(s []byte, composite bool) int {
+	for _, r := range c.ranges {
+		n := len(r.lo)
+		if n > len(s) {
+			continue
+		}
+		inRange := true
+		for j := 0; j < n; j++ {
+			if s[j] < r.lo[j] || s[j] > r.hi[j] {
+				inRange = false
+				break
+			}
+		}
+		if inRange {
+			return n
+		}
+	}
+	if composite {
+		return 2
+	}
+	return 1
+}
+
+func codeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func codeBytes(v uint32, n int) string {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return string(b)
+}
+
+// utf16Units splits big-endian UTF-16 bytes into code units
+func utf16Units(b []byte) []uint16 {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return units
+}
+
+func utf16Text(b []byte) string {
+	return string(utf16.Decode(utf16Units(b)))
+}
+
+// pdfTextWriter collects shown text, keeping at most one space or line break between runs
+type pdfTextWriter struct {
+	buf []byte
+}
+
+func (w *pdfTextWriter) last() byte {
+	if len(w.buf) == 0 {
+		return '\n'
+	}
+	return w.buf[len(w.buf)-1]
+}
+
+func (w *pdfTextWriter) text(s string) {
+	w.buf = append(w.buf, s...)
+}
+
+func (w *pdfTextWriter) space() {
+	if c := w.last(); c != ' ' && c != '\n' {
+		w.buf = append(w.buf, ' ')
+	}
+}
+
+func (w *pdfTextWriter) newline() {
+	w.buf = bytes.TrimRight(w.buf, " ")
+	if w.last() != '\n' {
+		w.buf = append(w.buf, '\n')
+	}
+}
+
+// String returns the text with surrounding whitespace trimmed
+func (w *pdfTextWriter) String() string {
+	return strings.TrimSpace(string(w.buf))
+}
@@ -0,0 +1,207 @@
+package processors
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF assembles a PDF from object bodies numbered from 1; no cross-reference table is
+// written since the parser does not use one
+func buildPDF(objects ...string) []byte {
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return []byte(b.String())
+}
+
+// pdfStreamObject returns the body of a stream object with its Length
+func pdfStreamObject(dict string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func flate(data string) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write([]byte(data))
+	zw.Close()
+	return buf.Bytes()
+}
+
+// singlePagePDF returns a one-page PDF drawing content with a Helvetica font named F1
+func singlePagePDF(content string) []byte {
+	return buildPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		pdfStreamObject("", []byte(content)),
+	)
+}
+
+func TestExtractPDFText(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{
+			name: "literal string",
+			data: singlePagePDF("BT /F1 12 Tf 72 700 Td (Hello World) Tj ET"),
+			want: "Hello World",
+		},
+		{
+			name: "escapes",
+			data: singlePagePDF(`BT /F1 12 Tf (Caf\351 \(Paris\)) Tj ET`),
+			want: "Café (Paris)",
+		},
+		{
+			name: "hex string",
+			data: singlePagePDF("BT /F1 12 Tf <48656C6C6F> Tj ET"),
+			want: "Hello",
+		},
+		{
+			name: "line breaks",
+			data: singlePagePDF("BT /F1 12 Tf (Line one) Tj T* (Line two) Tj 0 -14 Td (Line three) Tj ET"),
+			want: "Line one\nLine two\nLine three",
+		},
+		{
+			name: "word gap in TJ",
+			data: singlePagePDF("BT /F1 12 Tf [(Senior) -300 (Engineer)] TJ ET"),
+			want: "Senior Engineer",
+		},
+		{
+			name: "flate compressed content",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+				pdfStreamObject("/Filter /FlateDecode", flate("BT /F1 12 Tf (Compressed) Tj ET")),
+			),
+			want: "Compressed",
+		},
+		{
+			name: "ToUnicode CMap",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+				"<< /Type /Font /Subtype /Type0 /BaseFont /Custom /ToUnicode 6 0 R >>",
+				pdfStreamObject("", []byte("BT /F1 12 Tf <00010002> Tj ET")),
+				pdfStreamObject("", []byte("1 begincodespacerange <0000> <FFFF> endcodespacerange\n"+
+					"2 beginbfchar <0001> <0048> <0002> <0069> endbfchar")),
+			),
+			want: "Hi",
+		},
+		{
+			name: "pages in tree order",
+			data: buildPDF(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Kids [4 0 R 3 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+				"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+				pdfStreamObject("", []byte("BT /F1 12 Tf (Page one) Tj ET")),
+				pdfStreamObject("", []byte("BT /F1 12 Tf (Page two) Tj ET")),
+			),
+			want: "Page one\n\nPage two",
+		},
+		{
+			name:    "not a PDF",
+			data:    []byte("<html><body>Job posting</body></html>"),
+			wantErr: ErrNotPDF,
+		},
+		{
+			name:    "encrypted",
+			data:    append(singlePagePDF("BT (Secret) Tj ET"), "trailer << /Encrypt 9 0 R >>"...),
+			wantErr: ErrPDFEncrypted,
+		},
+		{
+			name:    "no text",
+			data:    singlePagePDF("0 0 100 100 re f"),
+			wantErr: ErrPDFNoText,
+		},
+		{
+			name:    "unterminated hex string at the end",
+			data:    []byte("%PDF-1.4\n1 0 obj\n<< /A <41"),
+			wantErr: ErrPDFNoText,
+		},
+		{
+			name:    "unterminated literal string at the end",
+			data:    []byte("%PDF-1.4\n1 0 obj\n<< /A (abc\\"),
+			wantErr: ErrPDFNoText,
+		},
+		{
+			name:    "huge stream length",
+			data:    []byte("%PDF-1.4\n1 0 obj\n<< /Length 1e300 >>\nstream\nabc\nendstream\nendobj\n"),
+			wantErr: ErrPDFNoText,
+		},
+		{
+			name:    "deeply nested arrays",
+			data:    []byte("%PDF-1.4\n1 0 obj\n" + strings.Repeat("[", 1<<20) + "\nendobj\n"),
+			wantErr: ErrPDFNoText,
+		},
+		{
+			name:    "stray delimiters",
+			data:    []byte("%PDF-1.4\n1 0 obj\n" + strings.Repeat(")", 1<<20) + "\nendobj\n"),
+			wantErr: ErrPDFNoText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractPDFText(tt.data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExtractPDFText() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractPDFText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractPDFText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractPDFTextObjectStreamOffsets(t *testing.T) {
+	// Offsets past the data of an object stream are skipped
+	for _, first := range []string{"99999999", "-99999999", "1e300"} {
+		data := buildPDF(pdfStreamObject("/Type /ObjStm /N 1 /First "+first, []byte("1 0")))
+		if _, err := ExtractPDFText(data); !errors.Is(err, ErrPDFNoText) {
+			t.Errorf("First %s: ExtractPDFText() error = %v, want %v", first, err, ErrPDFNoText)
+		}
+	}
+}
+
+func FuzzExtractPDFText(f *testing.F) {
+	f.Add(singlePagePDF("BT /F1 12 Tf 72 700 Td (Hello World) Tj ET"))
+	f.Add(singlePagePDF("BT /F1 12 Tf <48656C6C6F> Tj T* [(A) -300 (B)] TJ ET"))
+	f.Add(buildPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> /XObject << /X1 7 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type0 /ToUnicode 6 0 R >>",
+		pdfStreamObject("/Filter /FlateDecode", flate("BT /F1 12 Tf <00010002> Tj ET /X1 Do BI /W 1 ID \x00 EI")),
+		pdfStreamObject("", []byte("1 begincodespacerange <0000> <FFFF> endcodespacerange\n"+
+			"1 beginbfrange <0001> <0005> <0041> endbfrange")),
+		pdfStreamObject("/Type /XObject /Subtype /Form", []byte("BT (Form) Tj ET")),
+	))
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<< /A <41"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// The unrecovered parser, so panics fail the fuzz run instead of becoming errors
+		extractPDFText(data)
+	})
+}
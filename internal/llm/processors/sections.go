@@ -0,0 +1,145 @@
+package processors
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Sections of a job posting that its lists are sorted into
+const (
+	SectionRequirements     = "requirements"
+	SectionResponsibilities = "responsibilities"
+	SectionBenefits         = "benefits"
+)
+
+// maxHeadingLength bounds the text of an element taken as the heading of the list after it
+const maxHeadingLength = 120
+
+// BlockText converts an HTML fragment to plain text with a line per block element, keeping the
+// posting's structure readable for the LLM
+func BlockText(fragment string) string {
+	if fragment == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return fragment
+	}
+	doc.Find("p, div, li, h1, h2, h3, h4, h5, h6, tr, br").AppendHtml("\n")
+
+	var lines []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SectionLists returns the items of the lists in an HTML description, grouped by what the
+// heading above each list says it contains; lists under other headings are skipped
+func SectionLists(fragment string) (requirements, responsibilities, benefits []string) {
+	requirements, responsibilities, benefits = []string{}, []string{}, []string{}
+	if fragment == "" {
+		return
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return
+	}
+
+	doc.Find("ul, ol").Each(func(_ int, list *goquery.Selection) {
+		if list.ParentsFiltered("li").Length() > 0 {
+			return
+		}
+		items := selectionItems(list)
+
+		switch ClassifySection(listHeading(list)) {
+		case SectionRequirements:
+			requirements = append(requirements, items...)
+		case SectionResponsibilities:
+			responsibilities = append(responsibilities, items...)
+		case SectionBenefits:
+			benefits = append(benefits, items...)
+		}
+	})
+	return
+}
+
+// ListItems returns the text of the list items in an HTML fragment
+func ListItems(fragment string) []string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return nil
+	}
+	return selectionItems(doc.Selection)
+}
+
+// ClassifySection maps a section heading to the section its list belongs to, or "" when the
+// heading names none
+func ClassifySection(heading string) string {
+	heading = strings.ToLower(heading)
+	contains := func(words ...string) bool {
+		for _, word := range words {
+			if strings.Contains(heading, word) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case heading == "":
+		return ""
+	case contains("benefit", "perk", "we offer", "what's in it", "why join", "why you'll love"):
+		return SectionBenefits
+	case contains("requirement", "qualification", "bring", "looking for", "about you", "you have", "skills", "nice to have", "must have", "who you are"):
+		return SectionRequirements
+	case contains("responsibilit", "you'll do", "you will do", "you will", "your role", "the role", "day to day", "duties", "in this role", "impact"):
+		return SectionResponsibilities
+	default:
+		return ""
+	}
+}
+
+// Summarize returns the opening sentences of text, up to max bytes
+func Summarize(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	cut := text[:max]
+	if i := strings.LastIndex(cut, ". "); i > max/3 {
+		return cut[:i+1]
+	}
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.ToValidUTF8(cut, "") + "..."
+}
+
+// selectionItems returns the text of the list items within selection
+func selectionItems(selection *goquery.Selection) []string {
+	var items []string
+	selection.Find("li").Each(func(_ int, item *goquery.Selection) {
+		if text := strings.Join(strings.Fields(item.Text()), " "); text != "" {
+			items = append(items, text)
+		}
+	})
+	return items
+}
+
+// listHeading returns the text of the short element before list, looking one level up when the
+// list is the first element of its container
+func listHeading(list *goquery.Selection) string {
+	for selection := list; selection.Length() > 0 && !selection.Is("body"); selection = selection.Parent() {
+		if prev := selection.Prev(); prev.Length() > 0 {
+			text := strings.Join(strings.Fields(prev.Text()), " ")
+			if len(text) <= maxHeadingLength {
+				return text
+			}
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package llm
+
+import "letraz-utils/internal/metrics"
+
+// CollectPrometheus exports the rate limiter state of every provider that has limits: queue
+// depth, requests in flight, the configured limits and how long calls waited for their turn
+func (m *Manager) CollectPrometheus(w *metrics.PrometheusWriter) {
+	m.mu.RLock()
+	entries := make([]*providerEntry, 0, len(m.providers))
+	for _, entry := range m.providers {
+		if entry.limiter != nil {
+			entries = append(entries, entry)
+		}
+	}
+	m.mu.RUnlock()
+
+	stats := make([]limiterStats, len(entries))
+	labels := make([]metrics.Label, len(entries))
+	for i, entry := range entries {
+		stats[i] = entry.limiter.stats()
+		labels[i] = metrics.L("provider", entry.provider.GetProviderName())
+	}
+
+	for i := range entries {
+		w.Gauge("letraz_llm_queue_depth", "LLM calls waiting for room within their provider's rate limits.", float64(stats[i].QueueDepth), labels[i])
+	}
+	for i := range entries {
+		w.Gauge("letraz_llm_in_flight", "LLM calls currently sent to the provider.", float64(stats[i].InFlight), labels[i])
+	}
+	for i := range entries {
+		w.Gauge("letraz_llm_max_concurrent", "Maximum concurrent calls to the provider; 0 is unlimited.", float64(stats[i].MaxConcurrent), labels[i])
+	}
+	for i := range entries {
+		w.Gauge("letraz_llm_requests_per_minute_limit", "Maximum calls per minute to the provider; 0 is unlimited.", float64(stats[i].RequestsPerMinute), labels[i])
+	}
+	for i := range entries {
+		w.Counter("letraz_llm_queue_timeouts_total", "LLM calls that gave up waiting for their provider and failed over.", float64(stats[i].QueueTimeouts), labels[i])
+	}
+	for i := range entries {
+		w.Histogram("letraz_llm_queue_wait_seconds", "Time LLM calls waited for room within their provider's rate limits.", stats[i].QueueWait, labels[i])
+	}
+}
@@ -0,0 +1,351 @@
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+)
+
+// Operations with a prompt template
+const (
+	JobExtraction                = "job_extraction"
+	JobExtractionFromDescription = "job_extraction_description"
+	ResumeTailoring              = "resume_tailoring"
+	ATSReview                    = "ats_review"
+	SkillGap                     = "skill_gap"
+	InterviewQuestions           = "interview_questions"
+	ResumeRefinement             = "resume_refinement"
+	JobConsolidation             = "job_consolidation"
+	CompanyExtraction            = "company_extraction"
+)
+
+// SystemBlock names the template block holding an operation's static instructions. Providers
+// that support prompt caching send it separately so it is cached across requests; it must not
+// depend on template data.
+const SystemBlock = "system"
+
+// DefaultVersion is the template version used for operations without a configured version
+const DefaultVersion = "v1"
+
+// Operations lists every operation whose template is loaded at startup
+var Operations = []string{JobExtraction, JobExtractionFromDescription, ResumeTailoring, ATSReview, SkillGap, InterviewQuestions, ResumeRefinement, JobConsolidation, CompanyExtraction}
+
+// JobExtractionData is the data available to job_extraction templates. Long pages are extracted
+// in several parts; Part numbers the part in Content out of Parts, which is 1 for a whole page.
+type JobExtractionData struct {
+	URL      string
+	Content  string
+	Language string // name of the detected content language, empty when unknown
+	Part     int
+	Parts    int
+}
+
+// JobConsolidationData is the data available to job_consolidation templates
+type JobConsolidationData struct {
+	URL            string
+	CandidatesJSON string // jobs extracted from each part of the page, in page order
+	Language       string // name of the detected content language, empty when unknown
+}
+
+// JobExtractionFromDescriptionData is the data available to job_extraction_description templates
+type JobExtractionFromDescriptionData struct {
+	Description string
+	Language    string // name of the detected description language, empty when unknown
+}
+
+// CompanyExtractionData is the data available to company_extraction templates
+type CompanyExtractionData struct {
+	Name      string // company name given by the caller, empty when only the domain is known
+	Website   string
+	Content   string // cleaned text of the website's pages, each headed by its URL
+	SizesJSON string // allowed employee count ranges
+}
+
+// ResumeTailoringData is the data available to resume_tailoring templates
+type ResumeTailoringData struct {
+	ResumeJSON string
+	JobJSON    string
+}
+
+// ResumeRefinementData is the data available to resume_refinement templates. The resume, job and
+// earlier answers are earlier turns of the conversation the rendered prompt is appended to.
+type ResumeRefinementData struct {
+	Instruction string
+}
+
+// ATSReviewData is the data available to ats_review templates
+type ATSReviewData struct {
+	ResumeJSON string
+	JobJSON    string
+}
+
+// SkillGapData is the data available to skill_gap templates
+type SkillGapData struct {
+	SkillsJSON       string
+	ResumeJSON       string
+	JobTitle         string
+	RequirementsJSON string
+}
+
+// InterviewQuestionsData is the data available to interview_questions templates
+type InterviewQuestionsData struct {
+	ResumeJSON    string
+	JobJSON       string
+	QuestionCount int
+}
+
+// Library holds the parsed prompt template of each operation. Other versions than the selected
+// ones are loaded on first use by requests that ask for them.
+type Library struct {
+	dir       string
+	templates map[string]*template.Template
+	versions  map[string]string
+
+	mu    sync.Mutex
+	other map[string]*template.Template // operation/version -> template
+}
+
+// Load reads and parses the selected template version of every operation, failing when any
+// template is missing or invalid
+func Load(cfg *config.Config) (*Library, error) {
+	dir := cfg.LLM.Prompts.Dir
+	if dir == "" {
+		dir = "configs/prompts"
+	}
+
+	for operation := range cfg.LLM.Prompts.Versions {
+		if !isOperation(operation) {
+			return nil, fmt.Errorf("unknown prompt operation %q", operation)
+		}
+	}
+
+	library := &Library{
+		dir:       dir,
+		templates: make(map[string]*template.Template, len(Operations)),
+		versions:  make(map[string]string, len(Operations)),
+		other:     make(map[string]*template.Template),
+	}
+	for _, operation := range Operations {
+		version := cfg.LLM.Prompts.Versions[operation]
+		if version == "" {
+			version = DefaultVersion
+		}
+
+		tmpl, err := parseTemplate(dir, operation, version)
+		if err != nil {
+			return nil, err
+		}
+		library.templates[operation] = tmpl
+		library.versions[operation] = version
+	}
+
+	return library, nil
+}
+
+// parseTemplate reads and parses the template version of operation under dir
+func parseTemplate(dir, operation, version string) (*template.Template, error) {
+	path := filepath.Join(dir, operation, version+".tmpl")
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s prompt %s: %w", operation, version, err)
+	}
+
+	tmpl, err := template.New(operation + "/" + version).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s prompt %s: %w", operation, version, err)
+	}
+	return tmpl, nil
+}
+
+// template returns the template version of operation, loading it when it is not the selected
+// version; an empty version is the selected one
+func (l *Library) template(operation, version string) (*template.Template, error) {
+	tmpl, ok := l.templates[operation]
+	if !ok {
+		return nil, fmt.Errorf("no prompt template for operation %q", operation)
+	}
+	if version == "" || version == l.versions[operation] {
+		return tmpl, nil
+	}
+	if version != filepath.Base(version) || strings.HasPrefix(version, ".") {
+		return nil, fmt.Errorf("invalid prompt version %q", version)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := operation + "/" + version
+	if tmpl, ok := l.other[key]; ok {
+		return tmpl, nil
+	}
+	tmpl, err := parseTemplate(l.dir, operation, version)
+	if err != nil {
+		return nil, err
+	}
+	l.other[key] = tmpl
+	return tmpl, nil
+}
+
+// HasVersion reports an error when the template version of operation cannot be loaded
+func (l *Library) HasVersion(operation, version string) error {
+	if l == nil {
+		return errors.New("prompt library is not initialized")
+	}
+	_, err := l.template(operation, version)
+	return err
+}
+
+// Render executes the template of operation with data
+func (l *Library) Render(operation string, data interface{}) (string, error) {
+	if l == nil {
+		return "", errors.New("prompt library is not initialized")
+	}
+
+	return l.render(operation, "", data)
+}
+
+// RenderContext executes the template of operation with data, in the version ctx asks for when
+// it asks for one
+func (l *Library) RenderContext(ctx context.Context, operation string, data interface{}) (string, error) {
+	if l == nil {
+		return "", errors.New("prompt library is not initialized")
+	}
+	return l.render(operation, VersionFromContext(ctx, operation), data)
+}
+
+// render executes the template version of operation with data
+func (l *Library) render(operation, version string, data interface{}) (string, error) {
+	tmpl, err := l.template(operation, version)
+	if err != nil {
+		return "", err
+	}
+	if version == "" {
+		version = l.versions[operation]
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s prompt %s: %w", operation, version, err)
+	}
+	return buf.String(), nil
+}
+
+// RenderSystem executes the static instruction block of operation's template, returning an
+// empty string when the template has none. The main template then holds only the request's own
+// content.
+func (l *Library) RenderSystem(operation string) (string, error) {
+	if l == nil {
+		return "", errors.New("prompt library is not initialized")
+	}
+
+	tmpl, ok := l.templates[operation]
+	if !ok {
+		return "", fmt.Errorf("no prompt template for operation %q", operation)
+	}
+	system := tmpl.Lookup(SystemBlock)
+	if system == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := system.Execute(&buf, nil); err != nil {
+		return "", fmt.Errorf("failed to render %s system prompt %s: %w", operation, l.versions[operation], err)
+	}
+	return buf.String(), nil
+}
+
+// Version returns the template version selected for operation
+func (l *Library) Version(operation string) string {
+	if l == nil {
+		return ""
+	}
+	return l.versions[operation]
+}
+
+// Versions returns the template version selected for each operation
+func (l *Library) Versions() map[string]string {
+	versions := make(map[string]string)
+	if l == nil {
+		return versions
+	}
+	for operation, version := range l.versions {
+		versions[operation] = version
+	}
+	return versions
+}
+
+// versionContextKey is the context key under which requested template versions are stored
+type versionContextKey struct{}
+
+// WithVersion returns a copy of ctx whose prompts for operation use template version in place
+// of the configured one. An empty version leaves ctx unchanged.
+func WithVersion(ctx context.Context, operation, version string) context.Context {
+	if version == "" {
+		return ctx
+	}
+	versions := map[string]string{operation: version}
+	if existing, ok := ctx.Value(versionContextKey{}).(map[string]string); ok {
+		for op, v := range existing {
+			if op != operation {
+				versions[op] = v
+			}
+		}
+	}
+	return context.WithValue(ctx, versionContextKey{}, versions)
+}
+
+// VersionFromContext returns the template version of operation ctx asks for, or an empty string
+func VersionFromContext(ctx context.Context, operation string) string {
+	versions, _ := ctx.Value(versionContextKey{}).(map[string]string)
+	return versions[operation]
+}
+
+// isOperation reports whether operation has a prompt template
+func isOperation(operation string) bool {
+	for _, known := range Operations {
+		if known == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// Global prompt library instance
+var (
+	globalLibrary *Library
+	globalMu      sync.RWMutex
+)
+
+// InitializeGlobalLibrary loads the prompt templates and makes them the global library
+func InitializeGlobalLibrary(cfg *config.Config) (*Library, error) {
+	library, err := Load(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	globalMu.Lock()
+	globalLibrary = library
+	globalMu.Unlock()
+
+	logging.GetGlobalLogger().Info("LLM prompt templates loaded", map[string]interface{}{
+		"dir":      cfg.LLM.Prompts.Dir,
+		"versions": library.Versions(),
+	})
+
+	return library, nil
+}
+
+// GetGlobalLibrary returns the global prompt library; rendering fails until it is initialized
+func GetGlobalLibrary() *Library {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalLibrary
+}
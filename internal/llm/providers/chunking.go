@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// charsPerToken is the rough number of characters per token used to size content for prompts
+const charsPerToken = 3
+
+// jobParser decodes the JSON answer to a job extraction prompt
+type jobParser func(data []byte) (*models.Job, error)
+
+// jobPromptRunner sends a job extraction prompt to the provider and decodes the answer with
+// parse. Errors from parse are handled as the provider handles any unparseable answer.
+type jobPromptRunner func(ctx context.Context, prompt string, parse jobParser) (*models.Job, error)
+
+// extractJob extracts the job posting in cleaned page content with run. Content that fits in one
+// chunk is extracted with a single prompt. Longer content is split into chunks whose partial jobs
+// are extracted concurrently and then merged by a consolidation prompt, so requirements at the
+// end of a long posting are not lost.
+func extractJob(ctx context.Context, cfg *config.Config, content, url, language string, parse jobParser, run jobPromptRunner) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+
+	chunks := splitContent(content, cfg.LLM.Chunking.ChunkTokens*charsPerToken)
+	if len(chunks) <= 1 {
+		prompt, err := buildJobExtractionPrompt(ctx, content, url, language)
+		if err != nil {
+			return nil, err
+		}
+		return run(ctx, prompt, parse)
+	}
+
+	if maxChunks := cfg.LLM.Chunking.MaxChunks; maxChunks > 0 && len(chunks) > maxChunks {
+		logger.Warn("Content exceeds the chunk limit, dropping the remainder", map[string]interface{}{
+			"url":        url,
+			"chunks":     len(chunks),
+			"max_chunks": maxChunks,
+		})
+		chunks = chunks[:maxChunks]
+	}
+
+	logger.Info("Extracting long content in chunks", map[string]interface{}{
+		"url":            url,
+		"content_length": len(content),
+		"chunks":         len(chunks),
+	})
+
+	candidates := make([]*models.Job, len(chunks))
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk string) {
+			defer wg.Done()
+			prompt, err := buildJobChunkExtractionPrompt(ctx, chunk, url, language, i+1, len(chunks))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			candidates[i], errs[i] = run(ctx, prompt, func(data []byte) (*models.Job, error) {
+				return parseJobCandidateJSON(data, url)
+			})
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	var found []*models.Job
+	for i, err := range errs {
+		if err != nil {
+			logger.Error("Chunk extraction failed", map[string]interface{}{
+				"url":    url,
+				"chunk":  i + 1,
+				"chunks": len(chunks),
+				"error":  err.Error(),
+			})
+			return nil, err
+		}
+		if candidates[i] != nil {
+			found = append(found, candidates[i])
+		}
+	}
+	if len(found) == 0 {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("URL '%s' is not a job posting: no part of the page contains a job posting", url))
+	}
+
+	prompt, err := buildJobConsolidationPrompt(found, url, language)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, prompt, parse)
+}
+
+// splitContent splits content into chunks of at most maxChars characters, breaking between lines
+// where possible and within a line only when the line alone exceeds maxChars
+func splitContent(content string, maxChars int) []string {
+	if maxChars <= 0 || len(content) <= maxChars {
+		return []string{content}
+	}
+
+	var (
+		chunks  []string
+		current strings.Builder
+	)
+	flush := func() {
+		if chunk := strings.TrimSpace(current.String()); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if current.Len()+len(line) > maxChars {
+			flush()
+		}
+		for len(line) > maxChars {
+			cut := splitPoint(line, maxChars)
+			current.WriteString(line[:cut])
+			flush()
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+	flush()
+	return chunks
+}
+
+// splitPoint returns where to cut a line longer than maxChars: after the last space within
+// maxChars, or at the last rune boundary when there is none
+func splitPoint(line string, maxChars int) int {
+	if i := strings.LastIndexByte(line[:maxChars], ' '); i > 0 {
+		return i + 1
+	}
+	cut := maxChars
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		return maxChars
+	}
+	return cut
+}
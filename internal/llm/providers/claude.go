@@ -2,15 +2,16 @@ package providers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
 
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm/cost"
 	"letraz-utils/internal/llm/processors"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
@@ -24,6 +25,7 @@ type ClaudeProvider struct {
 	config      *config.Config
 	htmlCleaner *processors.HTMLCleaner
 	logger      types.Logger
+	usage       usageTracker
 }
 
 // NewClaudeProvider creates a new Claude provider instance
@@ -42,9 +44,10 @@ func NewClaudeProvider(cfg *config.Config) *ClaudeProvider {
 
 // ExtractJobData processes HTML content and extracts structured job data using Claude
 func (cp *ClaudeProvider) ExtractJobData(ctx context.Context, html, url string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	cp.logger.Info("Starting job data extraction with Claude", map[string]interface{}{
+	logger.Info("Starting job data extraction with Claude", map[string]interface{}{
 		"url":         url,
 		"html_length": len(html),
 		"provider":    "claude",
@@ -56,64 +59,32 @@ func (cp *ClaudeProvider) ExtractJobData(ctx context.Context, html, url string)
 		return nil, fmt.Errorf("failed to clean HTML: %w", err)
 	}
 
-	// Check content length and truncate if necessary to fit token limits
-	maxContentLength := cp.config.LLM.MaxTokens * 3 // Rough estimation: 3 chars per token
-	if len(cleanedContent) > maxContentLength {
-		cleanedContent = cleanedContent[:maxContentLength] + "..."
-		cp.logger.Debug("Content truncated to fit token limits", map[string]interface{}{
-			"url": url,
-		})
-	}
-
-	// Create the prompt for Claude
-	prompt := cp.buildJobExtractionPrompt(cleanedContent, url)
-
-	// Make request to Claude
-	response, err := cp.client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:       anthropic.ModelClaude3_7SonnetLatest,
-		MaxTokens:   int64(cp.config.LLM.MaxTokens),
-		Temperature: anthropic.Float(float64(cp.config.LLM.Temperature)),
-		Messages: []anthropic.MessageParam{{
-			Content: []anthropic.ContentBlockParamUnion{{
-				OfText: &anthropic.TextBlockParam{Text: prompt},
-			}},
-			Role: anthropic.MessageParamRoleUser,
-		}},
+	// Postings in other languages are translated to English by the LLM
+	language := cp.htmlCleaner.DetectLanguage(html)
+
+	// Ask Claude to record the posting through the extraction tool, in chunks when the content is long
+	job, err := extractJob(ctx, cp.config, cleanedContent, url, language, func(data []byte) (*models.Job, error) {
+		return parseJobExtractionJSON(cp.logger, data, url, language)
+	}, func(ctx context.Context, prompt string, parse jobParser) (*models.Job, error) {
+		var job *models.Job
+		_, err := cp.callTool(ctx, prompt, jobExtractionTool, func(input []byte) error {
+			var err error
+			job, err = parse(input)
+			return err
+		}, nil)
+		return job, err
 	})
-
 	if err != nil {
-		cp.logger.Error("Claude API call failed", map[string]interface{}{
+		logger.Error("Claude job data extraction failed", map[string]interface{}{
 			"url":      url,
 			"provider": "claude",
 			"error":    err.Error(),
 		})
-		return nil, fmt.Errorf("failed to call Claude API: %w", err)
-	}
-
-	cp.logger.Debug("Claude API call successful, parsing response", map[string]interface{}{
-		"url":      url,
-		"provider": "claude",
-	})
-
-	// Parse the response
-	job, err := cp.parseClaudeResponse(response, url)
-	if err != nil {
-		cp.logger.Error("Failed to parse Claude response", map[string]interface{}{
-			"url":      url,
-			"provider": "claude",
-			"error":    err.Error(),
-		})
-
-		// Don't wrap CustomError types so they can be properly handled upstream
-		if _, ok := err.(*utils.CustomError); ok {
-			return nil, err
-		}
-
-		return nil, fmt.Errorf("failed to parse Claude response: %w", err)
+		return nil, err
 	}
 
 	processingTime := time.Since(startTime)
-	cp.logger.Info("Job data extraction completed successfully", map[string]interface{}{
+	logger.Info("Job data extraction completed successfully", map[string]interface{}{
 		"url":             url,
 		"processing_time": processingTime,
 		"provider":        "claude",
@@ -124,9 +95,10 @@ func (cp *ClaudeProvider) ExtractJobData(ctx context.Context, html, url string)
 
 // ExtractJobFromDescription processes job description text directly and extracts structured job data using Claude
 func (cp *ClaudeProvider) ExtractJobFromDescription(ctx context.Context, description string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	cp.logger.Info("Starting job data extraction from description with Claude", map[string]interface{}{
+	logger.Info("Starting job data extraction from description with Claude", map[string]interface{}{
 		"description_length": len(description),
 		"provider":           "claude",
 	})
@@ -140,57 +112,37 @@ func (cp *ClaudeProvider) ExtractJobFromDescription(ctx context.Context, descrip
 	maxContentLength := cp.config.LLM.MaxTokens * 3 // Rough estimation: 3 chars per token
 	if len(description) > maxContentLength {
 		description = description[:maxContentLength] + "..."
-		cp.logger.Debug("Description truncated to fit token limits", map[string]interface{}{
+		logger.Debug("Description truncated to fit token limits", map[string]interface{}{
 			"original_length": len(description),
 		})
 	}
 
-	// Create the prompt for Claude
-	prompt := cp.buildJobExtractionFromDescriptionPrompt(description)
-
-	// Make request to Claude
-	response, err := cp.client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:       anthropic.ModelClaude3_7SonnetLatest,
-		MaxTokens:   int64(cp.config.LLM.MaxTokens),
-		Temperature: anthropic.Float(float64(cp.config.LLM.Temperature)),
-		Messages: []anthropic.MessageParam{{
-			Content: []anthropic.ContentBlockParamUnion{{
-				OfText: &anthropic.TextBlockParam{Text: prompt},
-			}},
-			Role: anthropic.MessageParamRoleUser,
-		}},
-	})
+	// Descriptions in other languages are translated to English by the LLM
+	language := processors.DetectTextLanguage(description)
 
+	// Create the prompt for Claude
+	prompt, err := buildJobExtractionFromDescriptionPrompt(description, language)
 	if err != nil {
-		cp.logger.Error("Claude API call failed for description processing", map[string]interface{}{
-			"provider": "claude",
-			"error":    err.Error(),
-		})
-		return nil, fmt.Errorf("failed to call Claude API: %w", err)
+		return nil, err
 	}
 
-	cp.logger.Debug("Claude API call successful for description processing, parsing response", map[string]interface{}{
-		"provider": "claude",
-	})
-
-	// Parse the response (reuse existing parsing logic)
-	job, err := cp.parseClaudeResponse(response, "")
+	// Ask Claude to record the posting through the extraction tool
+	var job *models.Job
+	_, err = cp.callTool(ctx, prompt, jobExtractionTool, func(input []byte) error {
+		var err error
+		job, err = parseJobExtractionJSON(cp.logger, input, "", language)
+		return err
+	}, nil)
 	if err != nil {
-		cp.logger.Error("Failed to parse Claude response for description", map[string]interface{}{
+		logger.Error("Claude job data extraction from description failed", map[string]interface{}{
 			"provider": "claude",
 			"error":    err.Error(),
 		})
-
-		// Don't wrap CustomError types so they can be properly handled upstream
-		if _, ok := err.(*utils.CustomError); ok {
-			return nil, err
-		}
-
-		return nil, fmt.Errorf("failed to parse Claude response: %w", err)
+		return nil, err
 	}
 
 	processingTime := time.Since(startTime)
-	cp.logger.Info("Job data extraction from description completed successfully", map[string]interface{}{
+	logger.Info("Job data extraction from description completed successfully", map[string]interface{}{
 		"processing_time": processingTime,
 		"provider":        "claude",
 	})
@@ -198,686 +150,529 @@ func (cp *ClaudeProvider) ExtractJobFromDescription(ctx context.Context, descrip
 	return job, nil
 }
 
-// buildJobExtractionFromDescriptionPrompt creates the prompt for Claude to extract job data from description
-func (cp *ClaudeProvider) buildJobExtractionFromDescriptionPrompt(description string) string {
-	return fmt.Sprintf(`
-The content below is a job description provided directly by the user. Please extract and structure the job information.
-
-Return a JSON object with exactly these fields:
-
-{
-  "is_job_posting": true,
-  "confidence": 1.0,
-  "title": "string - The job title",
-  "job_url": "",
-  "company_name": "string - The company name (extract from description or use 'Company Name Not Specified' if not mentioned)",
-  "location": "string - The job location (city, state, country, or 'Remote')",
-  "salary": {
-    "currency": "string - The currency salary is being mentioned in (e.g., 'USD' or 'INR')",
-    "max": number - Maximum salary as integer (0 if not specified),
-    "min": number - Minimum salary as integer (0 if not specified)
-  },
-  "requirements": ["array of strings - Required qualifications, skills, experience"],
-  "description": "string - Brief job description or summary (2-3 sentences max)",
-  "responsibilities": ["array of strings - Key job responsibilities and duties"],
-  "benefits": ["array of strings - Employee benefits, perks, compensation details"],
-  "reason": ""
-}
-
-EXTRACTION RULES:
-- Return ONLY valid JSON, no additional text or explanation
-- Extract all available information from the description
-- For salary: extract any monetary values mentioned (annual, hourly, etc.)
-- Keep descriptions concise but informative
-- If company name is not mentioned, use empty string
-- If location is not specified, use "Not specified"
-- Set is_job_posting to true and confidence to 1.0 since this is a direct job description
-
-JOB DESCRIPTION TO ANALYZE:
-%s
-`, description)
+// TailorResume tailors a base resume for a specific job posting using Claude
+func (cp *ClaudeProvider) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+	tailoredResume, suggestions, _, err := cp.TailorResumeWithRawResponse(ctx, baseResume, job)
+	return tailoredResume, suggestions, err
 }
 
-// buildJobExtractionPrompt creates the prompt for Claude to extract job data
-func (cp *ClaudeProvider) buildJobExtractionPrompt(content, url string) string {
-	return fmt.Sprintf(`You are a job posting analyzer. Analyze the provided content to determine if it contains a job posting, and if so, extract structured job information.
-
-The content below is from a webpage. Please first determine if this is actually a job posting, then extract information accordingly.
-
-Return a JSON object with exactly these fields:
-
-{
-  "is_job_posting": boolean - true if this content contains a job posting, false otherwise,
-  "confidence": number - confidence score from 0.0 to 1.0 (only if is_job_posting is true),
-  "title": "string - The job title (empty if not a job posting)",
-  "job_url": "string - The URL of the job posting (%s)",
-  "company_name": "string - The company name (empty if not a job posting)",
-  "location": "string - The job location (city, state, country, or 'Remote')",
-  "salary": {
-    "currency": "string - The currency salary is being mentioned in (e.g., 'USD' or 'INR')",
-    "max": number - Maximum salary as integer (0 if not specified),
-    "min": number - Minimum salary as integer (0 if not specified)
-  },
-  "requirements": ["array of strings - Required qualifications, skills, experience"],
-  "description": "string - Brief job description or summary (2-3 sentences max)",
-  "responsibilities": ["array of strings - Key job responsibilities and duties"],
-  "benefits": ["array of strings - Employee benefits, perks, compensation details"],
-  "reason": "string - Brief explanation if not a job posting (e.g., 'This appears to be a company homepage', 'This is a news article')"
+// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation
+// history; the raw response is the JSON input of Claude's tailoring tool call
+func (cp *ClaudeProvider) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+	return cp.tailorResume(ctx, baseResume, job, nil)
 }
 
-IMPORTANT CLASSIFICATION RULES:
-1. A job posting should contain:
-   - A specific job title/position
-   - Job responsibilities or description
-   - Company information
-   - Usually requirements or qualifications
-   
-2. NOT job postings include:
-   - Company homepages or about pages
-   - News articles or blog posts
-   - Product pages or marketing content
-   - Search results or listing pages
-   - Error pages or redirects
-   - General career pages without specific positions
-
-EXTRACTION RULES:
-- Return ONLY valid JSON, no additional text or explanation
-- If is_job_posting is false, fill title, company_name, and other job fields with empty strings/arrays
-- If is_job_posting is true, extract all available information
-- For salary: extract any monetary values mentioned (annual, hourly, etc.)
-- Keep descriptions concise but informative
-- Set confidence to at least 0.7 for clear job postings, lower for ambiguous content
-
-CONTENT TO ANALYZE:
-%s`, url, content)
+// TailorResumeStream tailors a resume like TailorResumeWithRawResponse, streaming the response and
+// handing each tailored section to onSection as soon as Claude has generated it. Sections are
+// sent again, from index zero, if Claude is asked to correct an invalid answer.
+func (cp *ClaudeProvider) TailorResumeStream(ctx context.Context, baseResume *models.BaseResume, job *models.Job, onSection SectionHandler) (*models.TailoredResume, []models.Suggestion, string, error) {
+	return cp.tailorResume(ctx, baseResume, job, newSectionScanner(onSection))
 }
 
-// parseClaudeResponse parses the Claude API response and extracts the job data
-func (cp *ClaudeProvider) parseClaudeResponse(response *anthropic.Message, url string) (*models.Job, error) {
-	if len(response.Content) == 0 {
-		return nil, fmt.Errorf("empty response from Claude")
-	}
+// tailorResume asks Claude to record the tailored resume through the tailoring tool
+func (cp *ClaudeProvider) tailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job, listener toolInputListener) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
 
-	// Get the text content from the response
-	var responseText string
-	for _, content := range response.Content {
-		textContent := content.AsText()
-		responseText = textContent.Text
-		break
-	}
+	logger.WithFields(map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"job_title": job.Title,
+		"company":   job.CompanyName,
+		"provider":  "claude",
+	}).Info("Starting resume tailoring with Claude")
 
-	if responseText == "" {
-		return nil, fmt.Errorf("no text content in Claude response")
+	// Create the comprehensive prompt for resume tailoring
+	prompt, err := buildResumeTailoringPrompt(baseResume, job)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	// Clean the response - remove any markdown code blocks if present
-	responseText = strings.TrimSpace(responseText)
-	if strings.HasPrefix(responseText, "```json") {
-		responseText = strings.TrimPrefix(responseText, "```json")
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	} else if strings.HasPrefix(responseText, "```") {
-		responseText = strings.TrimPrefix(responseText, "```")
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
+	// Ask Claude to record the tailored resume through the tailoring tool
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+	)
+	// The static instructions go in a cached system prompt, so only the resume and job are
+	// processed at the full input price on repeated tailorings
+	messages := []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock(prompt.user))}
+	rawResponse, err := cp.callToolInConversation(ctx, prompt.system, messages, resumeTailoringTool, func(input []byte) error {
+		var err error
+		tailoredResume, suggestions, err = parseResumeTailoringJSON(cp.logger, input, baseResume)
+		return err
+	}, listener)
+	if err != nil {
+		logger.Error("Claude resume tailoring failed", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "claude",
+			"error":     err.Error(),
+		})
+		return nil, nil, rawResponse, err
 	}
 
-	cp.logger.Debug("Claude response received", map[string]interface{}{
-		"response_text": responseText,
+	processingTime := time.Since(startTime)
+	logger.Info("Resume tailoring completed successfully", map[string]interface{}{
+		"resume_id":         baseResume.ID,
+		"processing_time":   processingTime,
+		"provider":          "claude",
+		"suggestions_count": len(suggestions),
 	})
 
-	// Parse JSON response with validation fields
-	var rawResponse struct {
-		IsJobPosting     bool          `json:"is_job_posting"`
-		Confidence       float64       `json:"confidence"`
-		Title            string        `json:"title"`
-		JobURL           string        `json:"job_url"`
-		CompanyName      string        `json:"company_name"`
-		Location         string        `json:"location"`
-		Salary           models.Salary `json:"salary"`
-		Requirements     []string      `json:"requirements"`
-		Description      string        `json:"description"`
-		Responsibilities []string      `json:"responsibilities"`
-		Benefits         []string      `json:"benefits"`
-		Reason           string        `json:"reason"`
-	}
-
-	if err := json.Unmarshal([]byte(responseText), &rawResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response from Claude: %w, response: %s", err, responseText)
-	}
-
-	// Check if the content is actually a job posting
-	if !rawResponse.IsJobPosting {
-		reason := rawResponse.Reason
-		if reason == "" {
-			reason = "The provided URL does not contain a job posting"
-		}
-		return nil, utils.NewNotJobPostingError(fmt.Sprintf("URL '%s' is not a job posting: %s", url, reason))
-	}
-
-	// Check confidence level for job postings
-	if rawResponse.Confidence < 0.7 {
-		return nil, utils.NewNotJobPostingError(fmt.Sprintf("Low confidence (%.2f) that URL '%s' contains a valid job posting", rawResponse.Confidence, url))
-	}
-
-	// Create job object from validated response
-	job := &models.Job{
-		Title:            rawResponse.Title,
-		JobURL:           rawResponse.JobURL,
-		CompanyName:      rawResponse.CompanyName,
-		Location:         rawResponse.Location,
-		Salary:           rawResponse.Salary,
-		Requirements:     rawResponse.Requirements,
-		Description:      rawResponse.Description,
-		Responsibilities: rawResponse.Responsibilities,
-		Benefits:         rawResponse.Benefits,
-	}
-
-	// Ensure job_url is set correctly
-	if job.JobURL == "" {
-		job.JobURL = url
-	}
-
-	// Validate required fields for confirmed job postings
-	if job.Title == "" {
-		return nil, utils.NewNotJobPostingError(fmt.Sprintf("No job title found in URL '%s' - content may not be a valid job posting", url))
-	}
-	if job.CompanyName == "" {
-		return nil, utils.NewNotJobPostingError(fmt.Sprintf("No company name found in URL '%s' - content may not be a valid job posting", url))
-	}
-
-	cp.logger.Info("Successfully validated and extracted job posting")
-
-	return job, nil
+	return tailoredResume, suggestions, rawResponse, nil
 }
 
-// TailorResume tailors a base resume for a specific job posting using Claude
-func (cp *ClaudeProvider) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+// ReviewResumeForATS assesses how well a resume fits a job posting, asking Claude to record the
+// assessment through the ATS review tool
+func (cp *ClaudeProvider) ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	cp.logger.Info("Starting resume tailoring with Claude", map[string]interface{}{
+	logger.Info("Starting ATS review with Claude", map[string]interface{}{
 		"resume_id": baseResume.ID,
 		"job_title": job.Title,
 		"company":   job.CompanyName,
 		"provider":  "claude",
 	})
 
-	// Create the comprehensive prompt for resume tailoring
-	prompt := cp.buildResumeTailoringPrompt(baseResume, job)
-
-	// Make request to Claude
-	response, err := cp.client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:       anthropic.ModelClaude3_7SonnetLatest,
-		MaxTokens:   int64(cp.config.LLM.MaxTokens),
-		Temperature: anthropic.Float(float64(cp.config.LLM.Temperature)),
-		Messages: []anthropic.MessageParam{{
-			Content: []anthropic.ContentBlockParamUnion{{
-				OfText: &anthropic.TextBlockParam{Text: prompt},
-			}},
-			Role: anthropic.MessageParamRoleUser,
-		}},
-	})
+	prompt, err := buildATSReviewPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
 
+	var review *models.ATSReview
+	_, err = cp.callTool(ctx, prompt, atsReviewTool, func(input []byte) error {
+		var err error
+		review, err = parseATSReviewJSON(input)
+		return err
+	}, nil)
 	if err != nil {
-		cp.logger.Error("Claude API call failed for resume tailoring", map[string]interface{}{
+		logger.Error("Claude ATS review failed", map[string]interface{}{
 			"resume_id": baseResume.ID,
 			"provider":  "claude",
 			"error":     err.Error(),
 		})
-		return nil, nil, fmt.Errorf("failed to call Claude API for resume tailoring: %w", err)
+		return nil, err
 	}
 
-	cp.logger.Debug("Claude API call successful for resume tailoring, parsing response", map[string]interface{}{
-		"resume_id": baseResume.ID,
-		"provider":  "claude",
+	logger.Info("ATS review completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "claude",
+		"score":           review.Score,
+	})
+
+	return review, nil
+}
+
+// AnalyzeSkillGap compares a resume's skills with a job's requirements, asking Claude to record
+// the comparison through the skill gap tool
+func (cp *ClaudeProvider) AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting skill gap analysis with Claude", map[string]interface{}{
+		"resume_id":          baseResume.ID,
+		"job_title":          job.Title,
+		"requirements_count": len(job.Requirements),
+		"provider":           "claude",
 	})
 
-	// Parse the response
-	tailoredResume, suggestions, err := cp.parseResumeTailoringResponse(response, baseResume, job)
+	prompt, err := buildSkillGapPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
+
+	var analysis *models.SkillGapAnalysis
+	_, err = cp.callTool(ctx, prompt, skillGapTool, func(input []byte) error {
+		var err error
+		analysis, err = parseSkillGapJSON(input)
+		return err
+	}, nil)
 	if err != nil {
-		cp.logger.Error("Failed to parse Claude resume tailoring response", map[string]interface{}{
+		logger.Error("Claude skill gap analysis failed", map[string]interface{}{
 			"resume_id": baseResume.ID,
 			"provider":  "claude",
 			"error":     err.Error(),
 		})
-		return nil, nil, fmt.Errorf("failed to parse Claude resume tailoring response: %w", err)
+		return nil, err
 	}
 
-	processingTime := time.Since(startTime)
-	cp.logger.Info("Resume tailoring completed successfully", map[string]interface{}{
-		"resume_id":         baseResume.ID,
-		"processing_time":   processingTime,
-		"provider":          "claude",
-		"suggestions_count": len(suggestions),
+	logger.Info("Skill gap analysis completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "claude",
+		"missing_count":   len(analysis.MissingSkills),
 	})
 
-	return tailoredResume, suggestions, nil
+	return analysis, nil
 }
 
-// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
-func (cp *ClaudeProvider) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+// ExtractCompanyData extracts company information from the cleaned pages of its website, asking
+// Claude to record it through the company tool
+func (cp *ClaudeProvider) ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	cp.logger.WithFields(map[string]interface{}{
-		"resume_id": baseResume.ID,
-		"job_title": job.Title,
-		"company":   job.CompanyName,
-		"provider":  "claude",
-	}).Info("Starting resume tailoring with Claude (with raw response)")
+	logger.Info("Starting company extraction with Claude", map[string]interface{}{
+		"website":        website,
+		"content_length": len(content),
+		"provider":       "claude",
+	})
 
-	// Create the comprehensive prompt for resume tailoring
-	prompt := cp.buildResumeTailoringPrompt(baseResume, job)
+	prompt, err := buildCompanyExtractionPrompt(content, name, website)
+	if err != nil {
+		return nil, err
+	}
 
-	// Make request to Claude
-	response, err := cp.client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:       anthropic.ModelClaude3_7SonnetLatest,
-		MaxTokens:   int64(cp.config.LLM.MaxTokens),
-		Temperature: anthropic.Float(float64(cp.config.LLM.Temperature)),
-		Messages: []anthropic.MessageParam{{
-			Content: []anthropic.ContentBlockParamUnion{{
-				OfText: &anthropic.TextBlockParam{Text: prompt},
-			}},
-			Role: anthropic.MessageParamRoleUser,
-		}},
+	var company *models.Company
+	_, err = cp.callTool(ctx, prompt, companyExtractionTool, func(input []byte) error {
+		var err error
+		company, err = parseCompanyJSON(input)
+		return err
+	}, nil)
+	if err != nil {
+		logger.Error("Claude company extraction failed", map[string]interface{}{
+			"website":  website,
+			"provider": "claude",
+			"error":    err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.Info("Company extraction completed successfully", map[string]interface{}{
+		"website":         website,
+		"company":         company.Name,
+		"processing_time": time.Since(startTime),
+		"provider":        "claude",
 	})
 
+	return company, nil
+}
+
+// GenerateInterviewQuestions predicts the questions a candidate is likely to be asked for a job,
+// asking Claude to record them through the interview questions tool
+func (cp *ClaudeProvider) GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting interview question generation with Claude", map[string]interface{}{
+		"resume_id":      baseResume.ID,
+		"job_title":      job.Title,
+		"question_count": count,
+		"provider":       "claude",
+	})
+
+	prompt, err := buildInterviewQuestionsPrompt(baseResume, job, count)
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []models.InterviewQuestion
+	_, err = cp.callTool(ctx, prompt, interviewQuestionsTool, func(input []byte) error {
+		var err error
+		questions, err = parseInterviewQuestionsJSON(input, count)
+		return err
+	}, nil)
 	if err != nil {
-		cp.logger.Error("Claude API call failed for resume tailoring", map[string]interface{}{
+		logger.Error("Claude interview question generation failed", map[string]interface{}{
 			"resume_id": baseResume.ID,
 			"provider":  "claude",
 			"error":     err.Error(),
 		})
-		return nil, nil, "", fmt.Errorf("failed to call Claude API for resume tailoring: %w", err)
+		return nil, err
 	}
 
-	cp.logger.Debug("Claude API call successful for resume tailoring, parsing response", map[string]interface{}{
+	logger.Info("Interview question generation completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "claude",
+		"question_count":  len(questions),
+	})
+
+	return questions, nil
+}
+
+// RefineResume revises the tailored resume of a conversation according to a follow-up
+// instruction, continuing the conversation with Claude as alternating user and assistant messages
+func (cp *ClaudeProvider) RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting resume refinement with Claude", map[string]interface{}{
 		"resume_id": baseResume.ID,
+		"turns":     len(conversation),
 		"provider":  "claude",
 	})
 
-	// Get the raw response text first
-	var rawResponse string
-	if len(response.Content) > 0 {
-		textContent := response.Content[0].AsText()
-		rawResponse = textContent.Text
+	prompt, err := buildResumeRefinementPrompt(instruction)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	// Parse the response
-	tailoredResume, suggestions, err := cp.parseResumeTailoringResponse(response, baseResume, job)
+	var (
+		tailoredResume *models.TailoredResume
+		suggestions    []models.Suggestion
+	)
+	rawResponse, err := cp.callToolInConversation(ctx, "", claudeMessages(conversation, prompt), resumeTailoringTool, func(input []byte) error {
+		var err error
+		tailoredResume, suggestions, err = parseResumeTailoringJSON(cp.logger, input, baseResume)
+		return err
+	}, nil)
 	if err != nil {
-		cp.logger.Error("Failed to parse Claude resume tailoring response", map[string]interface{}{
+		logger.Error("Claude resume refinement failed", map[string]interface{}{
 			"resume_id": baseResume.ID,
 			"provider":  "claude",
 			"error":     err.Error(),
 		})
-		return nil, nil, rawResponse, fmt.Errorf("failed to parse Claude resume tailoring response: %w", err)
+		return nil, nil, rawResponse, err
 	}
 
-	processingTime := time.Since(startTime)
-	cp.logger.Info("Resume tailoring with raw response completed successfully", map[string]interface{}{
-		"resume_id":         baseResume.ID,
-		"processing_time":   processingTime,
-		"provider":          "claude",
-		"suggestions_count": len(suggestions),
+	logger.Info("Resume refinement completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "claude",
 	})
 
 	return tailoredResume, suggestions, rawResponse, nil
 }
 
-// createFilteredResumeForLLM creates a filtered version of BaseResume for LLM processing,
-// removing unnecessary fields to reduce prompt size
-func (cp *ClaudeProvider) createFilteredResumeForLLM(baseResume *models.BaseResume) map[string]interface{} {
-	// Filter sections - remove id, index, resume fields and filter data objects
-	filteredSections := make([]map[string]interface{}, len(baseResume.Sections))
-	for i, section := range baseResume.Sections {
-		filteredSection := map[string]interface{}{
-			"type": section.Type,
-			"data": cp.filterSectionData(section.Data),
-		}
-		filteredSections[i] = filteredSection
-	}
-
-	return map[string]interface{}{
-		"sections": filteredSections,
-	}
-}
-
-// filterSectionData filters data objects within resume sections,
-// removing unnecessary metadata fields
-func (cp *ClaudeProvider) filterSectionData(data interface{}) interface{} {
-	if data == nil {
-		return nil
-	}
+// claudeMessages converts a conversation followed by prompt into Claude messages. Claude requires
+// the roles to alternate starting with the user, so consecutive turns of one role are merged and
+// leading assistant turns dropped.
+func claudeMessages(conversation []models.ConversationTurn, prompt string) []anthropic.MessageParam {
+	turns := append(append([]models.ConversationTurn{}, conversation...), models.ConversationTurn{Role: "user", Content: prompt})
 
-	// Convert to map to manipulate
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return data
+	var (
+		messages []anthropic.MessageParam
+		blocks   []anthropic.ContentBlockParamUnion
+		role     string
+	)
+	flush := func() {
+		switch role {
+		case "user":
+			messages = append(messages, anthropic.NewUserMessage(blocks...))
+		case "assistant":
+			messages = append(messages, anthropic.NewAssistantMessage(blocks...))
+		}
+		blocks = nil
 	}
-
-	// Create filtered map excluding unwanted fields
-	filtered := make(map[string]interface{})
-	for key, value := range dataMap {
-		// Skip unwanted fields
-		if key == "id" || key == "created_at" || key == "updated_at" ||
-			key == "user" || key == "resume_section" {
+	for _, turn := range turns {
+		if (turn.Role != "user" && turn.Role != "assistant") || turn.Content == "" {
+			continue
+		}
+		if role == "" && turn.Role == "assistant" {
 			continue
 		}
-		filtered[key] = value
+		if turn.Role != role {
+			flush()
+			role = turn.Role
+		}
+		blocks = append(blocks, anthropic.NewTextBlock(turn.Content))
 	}
-
-	return filtered
-}
-
-// buildResumeTailoringPrompt creates the comprehensive prompt for Claude to tailor the resume
-func (cp *ClaudeProvider) buildResumeTailoringPrompt(baseResume *models.BaseResume, job *models.Job) string {
-	// Create filtered version of the resume for LLM processing
-	filteredResume := cp.createFilteredResumeForLLM(baseResume)
-	resumeJSON, _ := json.MarshalIndent(filteredResume, "", "  ")
-	jobJSON, _ := json.MarshalIndent(job, "", "  ")
-
-	return fmt.Sprintf(`You are an expert resume optimization specialist with years of experience helping professionals tailor their resumes for specific job applications. Your task is to analyze the provided base resume and job posting, then create a tailored version that maximizes the candidate's chances of success.
-
-**CRITICAL INSTRUCTION - NO HALLUCINATIONS:**
-- Use ONLY information that is directly provided in the base resume
-- Do NOT add skills, experiences, technologies, or achievements not mentioned in the original resume
-- Do NOT infer or assume qualifications beyond what is explicitly stated
-- Do NOT add company names, project names, or specific details not in the original data
-- You may REFRAME and EMPHASIZE existing information to align with job requirements
-- You may use synonyms or industry-standard terms for existing skills/technologies
-- If the resume lacks alignment with job requirements, note this in suggestions rather than fabricating missing elements
-
-**BASE RESUME:**
-%s
-
-**TARGET JOB POSTING:**
-%s
-
-**YOUR TASK:**
-1. **ANALYZE**: Carefully study both the resume and job posting to understand:
-   - Key requirements and qualifications the employer is seeking
-   - Skills, technologies, and experiences mentioned in the job description
-   - Company culture and values (if evident)
-   - Priority areas where the candidate's experience aligns with provided resume data
-
-2. **TAILOR**: Optimize the resume content to align with the job requirements using ONLY existing information:
-   - Rewrite experience descriptions to emphasize relevant achievements already mentioned
-   - Highlight skills and technologies that match job requirements (only if already in resume)
-   - Quantify accomplishments where numbers are already provided
-   - Use keywords and terminology from the job posting naturally to describe existing experience
-   - Adjust the professional summary/profile text to reflect the target role using existing background
-   - Maintain truthfulness - never fabricate experience, skills, or specific details
-
-3. **IMPROVE**: Enhance the overall quality and impact using only existing content:
-   - Use strong action verbs and result-oriented language for existing accomplishments
-   - Remove or de-emphasize less relevant experiences already in the resume
-   - Improve clarity and readability of existing descriptions
-   - Ensure consistency in formatting and style
-
-4. **OPTIMIZE STRUCTURE**: Strategically reorder sections to maximize impact:
-   - Place most job-relevant sections early in the resume
-   - Consider industry norms and hiring manager expectations
-   - Ensure the most compelling content appears first for quick scanning
-   - Update section index values to reflect the new optimal ordering
-
-**RESPONSE FORMAT:**
-Return a JSON object with exactly this structure:
-
-{
-  "tailored_resume": {
-    "sections": [
-      // Array of resume sections with tailored content and optimized ordering
-      // You may reorder sections to maximize relevance for this specific job
-      // Each section should have:
-      // {
-      //   "type": "string - section type",
-      //   "data": { ... tailored content without id, created_at, updated_at, user, resume_section fields ... }
-      // }
-      // For Experience sections: rewrite descriptions to emphasize job-relevant achievements using only existing information
-      // For Education sections: highlight relevant coursework or projects only if already mentioned
-      // Keep all section content and structure, but optimize the order for maximum impact
-    ]
-  },
-  "suggestions": [
-    {
-      "id": "sug_001",
-      "type": "experience",
-      "priority": "high",
-      "impact": "Emphasizing Python and Django skills would directly align with the job requirements and increase selection chances by 40%%",
-      "section": "Experience",
-      "current": "Developed web applications using various technologies",
-      "suggested": "Add specific mention of Python frameworks and API development experience in the experience descriptions",
-      "reasoning": "The job specifically requires Python and Django expertise, which matches the candidate's background"
-    },
-    {
-      "id": "sug_002",
-      "type": "skills",
-      "priority": "high",
-      "impact": "Adding a dedicated skills section would immediately show job requirement alignment and improve screening chances",
-      "section": "Skills",
-      "current": "No dedicated skills section present",
-      "suggested": "Create a skills section highlighting Python, Django, REST APIs, and database management",
-      "reasoning": "Job posting emphasizes technical skills and having them prominently displayed would match ATS requirements"
-    },
-    {
-      "id": "sug_003",
-      "type": "profile",
-      "priority": "medium",
-      "impact": "Quantifying achievements with metrics would strengthen the profile and demonstrate measurable impact",
-      "section": "Profile",
-      "current": "Generic statements about experience",
-      "suggested": "Include specific metrics from existing projects (e.g., 'improved system performance by X%%', 'handled Y requests per day')",
-      "reasoning": "Quantified achievements are more compelling to hiring managers and show concrete value delivery"
-    }
-  ]
+	flush()
+	return messages
 }
 
-**CRITICAL: SUGGESTIONS MUST BE OBJECTS, NOT STRINGS**
-- Each suggestion MUST be a JSON object with all fields: id, type, priority, impact, section, current, suggested, reasoning
-- DO NOT return suggestions as an array of strings like ["suggestion 1", "suggestion 2"]
-- Return EXACTLY 3 suggestions, no more, no less
-- Each suggestion must have meaningful, specific content for all fields
-
-**EXAMPLE WRONG FORMAT (DO NOT USE):**
-"suggestions": [
-  "Add more technical skills",
-  "Improve experience descriptions",
-  "Quantify achievements"
-]
-
-**EXAMPLE CORRECT FORMAT (USE THIS):**
-"suggestions": [
-  {
-    "id": "sug_001",
-    "type": "experience",
-    "priority": "high",
-    "impact": "Specific description of how this increases job selection chances",
-    "section": "Experience",
-    "current": "Current state of the content",
-    "suggested": "Specific actionable improvement",
-    "reasoning": "Why this change helps for this specific job"
-  }
-]
-
-**SUGGESTION GUIDELINES:**
-- Limit to EXACTLY 3 suggestions maximum
-- Focus on changes that would have the highest impact on job selection for this specific role
-- Prioritize suggestions that address clear gaps between the resume and job requirements
-- Be specific and actionable - avoid generic advice
-- Consider which changes would make the biggest difference to a hiring manager for this role
-- Think from the perspective: "If implemented, which 3 changes would most increase the chances of this resume being selected?"
-
-**IMPORTANT GUIDELINES:**
-- Preserve all IDs, timestamps, and metadata for each section
-- Focus on relevance while maintaining authenticity and not adding fabricated information
-- Use HTML formatting in descriptions where the original uses it
-- Suggestions should be specific and actionable, not generic advice
-- Never suggest adding information that wasn't in the original resume
-
-**SECTION ORDERING GUIDELINES:**
-- Strategically reorder sections to maximize relevance for the specific job
-- Update the "index" field to reflect new ordering (start from 0, increment by 1)
-- Consider these ordering strategies:
-  * Technical roles: Skills/Technical sections early, then Experience
-  * Senior positions: Experience first to show career progression
-  * Entry-level/Recent graduates: Education before Experience
-  * Creative roles: Portfolio/Projects prominently placed
-  * Industry-specific: Move most relevant sections to top positions
-- Always keep user profile/summary at the top if present
-- Maintain logical flow while prioritizing job-relevant sections
-
-Return ONLY the JSON response, no additional text or explanations.`, string(resumeJSON), string(jobJSON))
+// callTool sends prompt and forces Claude to answer by calling tool. The tool input is passed to
+// accept; input rejected with a plain error is sent back as a failed tool result so Claude can
+// correct it, up to maxSchemaRetries times. CustomErrors from accept, such as a page that is not a
+// job posting, are answers and returned as they are. When listener is set the response is streamed
+// and the tool input handed to it as it is generated. The raw input of the last call is returned.
+func (cp *ClaudeProvider) callTool(ctx context.Context, prompt string, tool toolSchema, accept func(input []byte) error, listener toolInputListener) (string, error) {
+	return cp.callToolInConversation(ctx, "", []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock(prompt))}, tool, accept, listener)
 }
 
-// parseResumeTailoringResponse parses Claude's response for resume tailoring
-func (cp *ClaudeProvider) parseResumeTailoringResponse(response *anthropic.Message, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
-	if len(response.Content) == 0 {
-		return nil, nil, fmt.Errorf("empty response from Claude")
-	}
+// callToolInConversation works like callTool, continuing the conversation in messages, whose
+// last message must be from the user. A non-empty system prompt is marked for prompt caching,
+// which caches it together with the tool definition.
+func (cp *ClaudeProvider) callToolInConversation(ctx context.Context, system string, messages []anthropic.MessageParam, tool toolSchema, accept func(input []byte) error, listener toolInputListener) (string, error) {
+	logger := logging.FromContext(ctx)
+	params := anthropic.MessageNewParams{
+		Model:       anthropic.Model(requestedModel(ctx, cp.config, "claude", string(anthropic.ModelClaude3_7SonnetLatest))),
+		MaxTokens:   int64(cp.config.LLM.MaxTokens),
+		Temperature: anthropic.Float(float64(cp.config.LLM.Temperature)),
+		Messages:    messages,
+		Tools:       []anthropic.ToolUnionParam{claudeTool(tool)},
+		ToolChoice:  anthropic.ToolChoiceParamOfTool(tool.name),
+	}
+	if system != "" {
+		params.System = []anthropic.TextBlockParam{{
+			Text:         system,
+			CacheControl: anthropic.NewCacheControlEphemeralParam(),
+		}}
+	}
+
+	var rawInput string
+	for attempt := 0; ; attempt++ {
+		response, err := cp.sendWithFallback(ctx, &params, listener)
+		if err != nil {
+			return rawInput, classifyAPIError(err)
+		}
+		cp.usage.record(ctx, "claude", string(response.Model), response.Usage.InputTokens, response.Usage.OutputTokens)
+		if system != "" {
+			cp.recordPromptCache(ctx, tool.name, response.Usage)
+		}
 
-	// Get the text content from the response
-	var responseText string
-	for _, content := range response.Content {
-		textContent := content.AsText()
-		responseText = textContent.Text
-		break
-	}
+		var (
+			feedback  anthropic.ContentBlockParamUnion
+			violation error
+		)
+		block, ok := claudeToolUse(response, tool.name)
+		if ok {
+			rawInput = string(block.Input)
+			violation = accept(block.Input)
+			if violation == nil {
+				return rawInput, nil
+			}
+			var customErr *utils.CustomError
+			if errors.As(violation, &customErr) {
+				return rawInput, violation
+			}
+			feedback = anthropic.NewToolResultBlock(block.ID, fmt.Sprintf("Invalid input: %v. Call %s again with input that satisfies its schema.", violation, tool.name), true)
+		} else {
+			violation = fmt.Errorf("response did not call the %s tool (stop reason %s)", tool.name, response.StopReason)
+			feedback = anthropic.NewTextBlock(fmt.Sprintf("Answer by calling the %s tool.", tool.name))
+		}
 
-	if responseText == "" {
-		return nil, nil, fmt.Errorf("no text content in Claude response")
-	}
+		if attempt >= maxSchemaRetries {
+			cp.usage.recordParseFailure()
+			return rawInput, utils.NewLLMParseError(violation.Error())
+		}
 
-	// Clean the response - remove any markdown code blocks if present
-	responseText = strings.TrimSpace(responseText)
-	if strings.HasPrefix(responseText, "```json") {
-		responseText = strings.TrimPrefix(responseText, "```json")
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
-	} else if strings.HasPrefix(responseText, "```") {
-		responseText = strings.TrimPrefix(responseText, "```")
-		responseText = strings.TrimSuffix(responseText, "```")
-		responseText = strings.TrimSpace(responseText)
+		logger.Warn("Claude tool input failed validation, asking again", map[string]interface{}{
+			"tool":     tool.name,
+			"attempt":  attempt + 1,
+			"provider": "claude",
+			"error":    violation.Error(),
+		})
+		params.Messages = append(params.Messages, response.ToParam(), anthropic.NewUserMessage(feedback))
 	}
+}
 
-	cp.logger.Debug("Claude resume tailoring response received", map[string]interface{}{
-		"response_length": len(responseText),
-	})
+// recordPromptCache tracks and logs how much of a request's prompt was read from or written to
+// the prompt cache
+func (cp *ClaudeProvider) recordPromptCache(ctx context.Context, tool string, usage anthropic.Usage) {
+	cp.usage.recordPromptCache(usage.CacheReadInputTokens, usage.CacheCreationInputTokens)
 
-	// Log the actual response for debugging
-	cp.logger.Debug("Raw Claude response for debugging", map[string]interface{}{
-		"raw_response": responseText,
+	logging.FromContext(ctx).Info("Claude prompt cache usage", map[string]interface{}{
+		"tool":               tool,
+		"provider":           "claude",
+		"cache_hit":          usage.CacheReadInputTokens > 0,
+		"cache_read_tokens":  usage.CacheReadInputTokens,
+		"cache_write_tokens": usage.CacheCreationInputTokens,
+		"input_tokens":       usage.InputTokens,
 	})
+}
 
-	// Parse JSON response using simplified structure that matches LLM output
-	var tailoringResponse struct {
-		TailoredResume struct {
-			Sections []struct {
-				Type string      `json:"type"`
-				Data interface{} `json:"data"`
-			} `json:"sections"`
-		} `json:"tailored_resume"`
-		Suggestions []models.Suggestion `json:"suggestions"`
-	}
-
-	if err := json.Unmarshal([]byte(responseText), &tailoringResponse); err != nil {
-		// Try to parse as old format with string suggestions as fallback
-		cp.logger.Warn("Failed to parse structured suggestions, trying fallback", map[string]interface{}{
-			"parse_error": err.Error(),
+// sendWithFallback sends a message request and, while Claude is overloaded or rate limiting,
+// retries it on the fallback model with exponential backoff. Once escalated, params keeps the
+// fallback model so the rest of the conversation stays on it.
+func (cp *ClaudeProvider) sendWithFallback(ctx context.Context, params *anthropic.MessageNewParams, listener toolInputListener) (*anthropic.Message, error) {
+	response, err := cp.send(ctx, *params, listener)
+	fallback := cp.config.LLM.Fallback
+	if err == nil || fallback.Model == "" || params.Model == anthropic.Model(fallback.Model) || !isOverloadError(err) {
+		return response, err
+	}
+
+	logger := logging.FromContext(ctx)
+	primary := params.Model
+	params.Model = anthropic.Model(fallback.Model)
+	backoff := fallback.InitialBackoff
+	for attempt := 1; attempt <= fallback.MaxRetries; attempt++ {
+		logger.Warn("Claude overloaded, retrying on fallback model", map[string]interface{}{
+			"model":          string(primary),
+			"fallback_model": fallback.Model,
+			"attempt":        attempt,
+			"backoff":        backoff.String(),
+			"error":          err.Error(),
 		})
 
-		var fallbackResponse struct {
-			TailoredResume struct {
-				Sections []struct {
-					Type string      `json:"type"`
-					Data interface{} `json:"data"`
-				} `json:"sections"`
-			} `json:"tailored_resume"`
-			Suggestions []string `json:"suggestions"`
-		}
-
-		if fallbackErr := json.Unmarshal([]byte(responseText), &fallbackResponse); fallbackErr != nil {
-			return nil, nil, fmt.Errorf("failed to parse JSON response from Claude (both formats): primary error: %w, fallback error: %v, response: %s", err, fallbackErr, responseText)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
 		}
 
-		// Convert string suggestions to structured format
-		structuredSuggestions := make([]models.Suggestion, 0)
-		maxSuggestions := 3
-		if len(fallbackResponse.Suggestions) < maxSuggestions {
-			maxSuggestions = len(fallbackResponse.Suggestions)
-		}
-
-		for i := 0; i < maxSuggestions; i++ {
-			structuredSuggestions = append(structuredSuggestions, models.Suggestion{
-				ID:        fmt.Sprintf("sug_%03d", i+1),
-				Type:      "general",
-				Priority:  "high",
-				Impact:    "This change would improve resume alignment with job requirements",
-				Section:   "general",
-				Current:   "",
-				Suggested: fallbackResponse.Suggestions[i],
-				Reasoning: "Legacy suggestion format - manual review recommended",
+		response, err = cp.send(ctx, *params, listener)
+		if err == nil {
+			cost.TallyFromContext(ctx).Escalate(string(primary))
+			logger.Info("Claude call served by fallback model", map[string]interface{}{
+				"model":          string(primary),
+				"fallback_model": fallback.Model,
+				"attempts":       attempt,
 			})
+			return response, nil
 		}
-
-		tailoringResponse.TailoredResume = fallbackResponse.TailoredResume
-		tailoringResponse.Suggestions = structuredSuggestions
-
-		cp.logger.Warn("Converted legacy string suggestions to structured format")
+		if !isOverloadError(err) {
+			return nil, err
+		}
+		backoff = min(backoff*2, fallback.MaxBackoff)
 	}
+	return nil, err
+}
 
-	// Validate the response
-	if len(tailoringResponse.TailoredResume.Sections) == 0 {
-		return nil, nil, fmt.Errorf("invalid tailored resume: no sections provided")
+// isOverloadError reports whether err means Claude is overloaded or rate limiting the request
+func isOverloadError(err error) bool {
+	var apiErr *anthropic.Error
+	if !errors.As(err, &apiErr) {
+		return false
 	}
-
-	if len(tailoringResponse.Suggestions) == 0 {
-		return nil, nil, fmt.Errorf("invalid response: no suggestions provided")
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, statusOverloaded:
+		return true
 	}
+	return false
+}
 
-	// Validate that we have exactly 3 suggestions with required fields
-	if len(tailoringResponse.Suggestions) > 3 {
-		tailoringResponse.Suggestions = tailoringResponse.Suggestions[:3] // Limit to 3
+// send sends a message request, streaming the response into listener when it is set
+func (cp *ClaudeProvider) send(ctx context.Context, params anthropic.MessageNewParams, listener toolInputListener) (*anthropic.Message, error) {
+	if listener == nil {
+		return cp.client.Messages.New(ctx, params)
 	}
 
-	for i, suggestion := range tailoringResponse.Suggestions {
-		if suggestion.ID == "" {
-			tailoringResponse.Suggestions[i].ID = fmt.Sprintf("sug_%03d", i+1)
-		}
-		if suggestion.Type == "" {
-			return nil, nil, fmt.Errorf("invalid suggestion %d: missing type", i+1)
-		}
-		if suggestion.Impact == "" {
-			return nil, nil, fmt.Errorf("invalid suggestion %d: missing impact description", i+1)
-		}
-		if suggestion.Suggested == "" {
-			return nil, nil, fmt.Errorf("invalid suggestion %d: missing suggested improvement", i+1)
-		}
-		if suggestion.Reasoning == "" {
-			return nil, nil, fmt.Errorf("invalid suggestion %d: missing reasoning", i+1)
+	listener.reset()
+	stream := cp.client.Messages.NewStreaming(ctx, params)
+	defer stream.Close()
+
+	var message anthropic.Message
+	for stream.Next() {
+		event := stream.Current()
+		if err := message.Accumulate(event); err != nil {
+			return nil, err
 		}
-		// Set default priority if not provided
-		if suggestion.Priority == "" {
-			tailoringResponse.Suggestions[i].Priority = "high"
+		if delta, ok := event.AsAny().(anthropic.ContentBlockDeltaEvent); ok {
+			if input, ok := delta.Delta.AsAny().(anthropic.InputJSONDelta); ok {
+				listener.write(input.PartialJSON)
+			}
 		}
 	}
-
-	// Create simplified TailoredResume response
-	tailoredResume := &models.TailoredResume{
-		ID:       baseResume.ID, // Keep original ID for reference
-		Sections: make([]models.TailoredResumeSection, len(tailoringResponse.TailoredResume.Sections)),
+	if err := stream.Err(); err != nil {
+		return nil, err
 	}
+	return &message, nil
+}
 
-	// Convert LLM sections to final format
-	for i, llmSection := range tailoringResponse.TailoredResume.Sections {
-		tailoredResume.Sections[i] = models.TailoredResumeSection{
-			Type: llmSection.Type,
-			Data: llmSection.Data,
+// claudeTool converts a tool schema into a Claude tool definition
+func claudeTool(tool toolSchema) anthropic.ToolUnionParam {
+	return anthropic.ToolUnionParam{OfTool: &anthropic.ToolParam{
+		Name:        tool.name,
+		Description: anthropic.String(tool.description),
+		InputSchema: anthropic.ToolInputSchemaParam{
+			Properties: tool.properties,
+			Required:   tool.required,
+		},
+	}}
+}
+
+// claudeToolUse returns the call of the named tool in a Claude response
+func claudeToolUse(response *anthropic.Message, name string) (anthropic.ContentBlockUnion, bool) {
+	for _, block := range response.Content {
+		if block.Type == "tool_use" && block.Name == name {
+			return block, true
 		}
 	}
-
-	cp.logger.Info("Successfully parsed and validated resume tailoring response")
-
-	return tailoredResume, tailoringResponse.Suggestions, nil
+	return anthropic.ContentBlockUnion{}, false
 }
 
 // IsHealthy checks if the Claude provider is healthy and available
@@ -910,3 +705,30 @@ func (cp *ClaudeProvider) IsHealthy(ctx context.Context) error {
 func (cp *ClaudeProvider) GetProviderName() string {
 	return "claude"
 }
+
+// statusOverloaded is the status Anthropic responds with when its API is overloaded
+const statusOverloaded = 529
+
+// classifyAPIError maps an Anthropic API error onto the error taxonomy
+func classifyAPIError(err error) *utils.CustomError {
+	var apiErr *anthropic.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return utils.NewRateLimitedError(fmt.Sprintf("Claude API: %v", err))
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("Claude API: %v", err))
+		case apiErr.StatusCode >= http.StatusInternalServerError: // includes 529 overloaded
+			return utils.NewLLMUnavailableError(fmt.Sprintf("Claude API: %v", err))
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return utils.NewTimeoutError(fmt.Sprintf("Claude API call timed out: %v", err))
+	}
+	return utils.NewLLMError(fmt.Sprintf("failed to call Claude API: %v", err))
+}
+
+// GetUsageStats returns cumulative token usage and parse failure counts
+func (cp *ClaudeProvider) GetUsageStats() UsageStats {
+	return cp.usage.snapshot()
+}
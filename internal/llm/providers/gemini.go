@@ -0,0 +1,657 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// geminiRequest is the request body of the generateContent API
+type geminiRequest struct {
+	Contents         []geminiContent        `json:"contents"`
+	GenerationConfig geminiGenerationConfig `json:"generationConfig"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiGenerationConfig struct {
+	Temperature      float32 `json:"temperature"`
+	MaxOutputTokens  int     `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType string  `json:"responseMimeType,omitempty"`
+}
+
+// geminiResponse is the subset of the generateContent response the provider reads
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	UsageMetadata struct {
+		PromptTokenCount     int64 `json:"promptTokenCount"`
+		CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+// geminiAPIError is a non-2xx response from the Gemini API
+type geminiAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *geminiAPIError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// GeminiProvider implements the LLM provider interface using Google Gemini
+type GeminiProvider struct {
+	httpClient  *http.Client
+	config      *config.Config
+	apiKey      string
+	model       string
+	baseURL     string
+	htmlCleaner *processors.HTMLCleaner
+	logger      types.Logger
+	usage       usageTracker
+}
+
+// NewGeminiProvider creates a new Gemini provider instance
+func NewGeminiProvider(cfg *config.Config) *GeminiProvider {
+	apiKey := cfg.LLM.Gemini.APIKey
+	if apiKey == "" && cfg.LLM.Provider == "gemini" {
+		apiKey = cfg.LLM.APIKey
+	}
+
+	return &GeminiProvider{
+		httpClient:  &http.Client{Timeout: cfg.LLM.Timeout},
+		config:      cfg,
+		apiKey:      apiKey,
+		model:       cfg.LLM.Gemini.Model,
+		baseURL:     strings.TrimRight(cfg.LLM.Gemini.BaseURL, "/"),
+		htmlCleaner: processors.NewHTMLCleaner(),
+		logger:      logging.GetGlobalLogger(),
+	}
+}
+
+// ExtractJobData processes HTML content and extracts structured job data using Gemini
+func (gp *GeminiProvider) ExtractJobData(ctx context.Context, html, url string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting job data extraction with Gemini", map[string]interface{}{
+		"url":         url,
+		"html_length": len(html),
+		"provider":    "gemini",
+	})
+
+	cleanedContent, err := gp.htmlCleaner.ExtractJobContent(html)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean HTML: %w", err)
+	}
+
+	// Postings in other languages are translated to English by the LLM
+	language := gp.htmlCleaner.DetectLanguage(html)
+
+	// Long content is extracted in chunks that a final call merges
+	job, err := extractJob(ctx, gp.config, cleanedContent, url, language, func(data []byte) (*models.Job, error) {
+		return parseJobExtractionJSON(gp.logger, data, url, language)
+	}, func(ctx context.Context, prompt string, parse jobParser) (*models.Job, error) {
+		responseText, err := gp.complete(ctx, prompt)
+		if err != nil {
+			logger.Error("Gemini API call failed", map[string]interface{}{
+				"url":      url,
+				"provider": "gemini",
+				"error":    err.Error(),
+			})
+			return nil, classifyGeminiError(err)
+		}
+
+		job, err := parse([]byte(stripCodeFence(responseText)))
+		if err != nil {
+			logger.Error("Failed to parse Gemini response", map[string]interface{}{
+				"url":      url,
+				"provider": "gemini",
+				"error":    err.Error(),
+			})
+
+			// Don't wrap CustomError types so they can be properly handled upstream
+			if _, ok := err.(*utils.CustomError); ok {
+				return nil, err
+			}
+
+			gp.usage.recordParseFailure()
+			return nil, utils.NewLLMParseError(err.Error())
+		}
+		return job, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Job data extraction completed successfully", map[string]interface{}{
+		"url":             url,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+	})
+
+	return job, nil
+}
+
+// ExtractJobFromDescription processes job description text directly and extracts structured job data using Gemini
+func (gp *GeminiProvider) ExtractJobFromDescription(ctx context.Context, description string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting job data extraction from description with Gemini", map[string]interface{}{
+		"description_length": len(description),
+		"provider":           "gemini",
+	})
+
+	if len(description) == 0 {
+		return nil, fmt.Errorf("description cannot be empty")
+	}
+
+	maxContentLength := gp.config.LLM.MaxTokens * 3 // Rough estimation: 3 chars per token
+	if len(description) > maxContentLength {
+		description = description[:maxContentLength] + "..."
+	}
+
+	// Descriptions in other languages are translated to English by the LLM
+	language := processors.DetectTextLanguage(description)
+
+	prompt, err := buildJobExtractionFromDescriptionPrompt(description, language)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := gp.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("Gemini API call failed for description processing", map[string]interface{}{
+			"provider": "gemini",
+			"error":    err.Error(),
+		})
+		return nil, classifyGeminiError(err)
+	}
+
+	job, err := parseJobExtractionResponse(gp.logger, responseText, "", language)
+	if err != nil {
+		logger.Error("Failed to parse Gemini response for description", map[string]interface{}{
+			"provider": "gemini",
+			"error":    err.Error(),
+		})
+
+		if _, ok := err.(*utils.CustomError); ok {
+			return nil, err
+		}
+
+		gp.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Job data extraction from description completed successfully", map[string]interface{}{
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+	})
+
+	return job, nil
+}
+
+// TailorResume tailors a base resume for a specific job posting using Gemini
+func (gp *GeminiProvider) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+	tailoredResume, suggestions, _, err := gp.TailorResumeWithRawResponse(ctx, baseResume, job)
+	return tailoredResume, suggestions, err
+}
+
+// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
+func (gp *GeminiProvider) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting resume tailoring with Gemini", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"job_title": job.Title,
+		"company":   job.CompanyName,
+		"provider":  "gemini",
+	})
+
+	prompt, err := buildResumeTailoringPrompt(baseResume, job)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	rawResponse, err := gp.complete(ctx, prompt.text())
+	if err != nil {
+		logger.Error("Gemini API call failed for resume tailoring", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		return nil, nil, "", classifyGeminiError(err)
+	}
+
+	tailoredResume, suggestions, err := parseResumeTailoringText(gp.logger, rawResponse, baseResume)
+	if err != nil {
+		logger.Error("Failed to parse Gemini resume tailoring response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, nil, rawResponse, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Resume tailoring completed successfully", map[string]interface{}{
+		"resume_id":         baseResume.ID,
+		"processing_time":   time.Since(startTime),
+		"provider":          "gemini",
+		"suggestions_count": len(suggestions),
+	})
+
+	return tailoredResume, suggestions, rawResponse, nil
+}
+
+// ReviewResumeForATS assesses how well a resume fits a job posting using Gemini
+func (gp *GeminiProvider) ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting ATS review with Gemini", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"job_title": job.Title,
+		"company":   job.CompanyName,
+		"provider":  "gemini",
+	})
+
+	prompt, err := buildATSReviewPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := gp.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("Gemini API call failed for ATS review", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		return nil, classifyGeminiError(err)
+	}
+
+	review, err := parseATSReviewText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse Gemini ATS review response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("ATS review completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+		"score":           review.Score,
+	})
+
+	return review, nil
+}
+
+// AnalyzeSkillGap compares a resume's skills with a job's requirements using Gemini
+func (gp *GeminiProvider) AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting skill gap analysis with Gemini", map[string]interface{}{
+		"resume_id":          baseResume.ID,
+		"job_title":          job.Title,
+		"requirements_count": len(job.Requirements),
+		"provider":           "gemini",
+	})
+
+	prompt, err := buildSkillGapPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := gp.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("Gemini API call failed for skill gap analysis", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		return nil, classifyGeminiError(err)
+	}
+
+	analysis, err := parseSkillGapText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse Gemini skill gap response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Skill gap analysis completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+		"missing_count":   len(analysis.MissingSkills),
+	})
+
+	return analysis, nil
+}
+
+// ExtractCompanyData extracts company information from the cleaned pages of its website using Gemini
+func (gp *GeminiProvider) ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting company extraction with Gemini", map[string]interface{}{
+		"website":        website,
+		"content_length": len(content),
+		"provider":       "gemini",
+	})
+
+	prompt, err := buildCompanyExtractionPrompt(content, name, website)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := gp.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("Gemini API call failed for company extraction", map[string]interface{}{
+			"website":  website,
+			"provider": "gemini",
+			"error":    err.Error(),
+		})
+		return nil, classifyGeminiError(err)
+	}
+
+	company, err := parseCompanyText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse Gemini company response", map[string]interface{}{
+			"website":  website,
+			"provider": "gemini",
+			"error":    err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Company extraction completed successfully", map[string]interface{}{
+		"website":         website,
+		"company":         company.Name,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+	})
+
+	return company, nil
+}
+
+// GenerateInterviewQuestions predicts the questions a candidate is likely to be asked for a job using Gemini
+func (gp *GeminiProvider) GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting interview question generation with Gemini", map[string]interface{}{
+		"resume_id":      baseResume.ID,
+		"job_title":      job.Title,
+		"question_count": count,
+		"provider":       "gemini",
+	})
+
+	prompt, err := buildInterviewQuestionsPrompt(baseResume, job, count)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := gp.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("Gemini API call failed for interview question generation", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		return nil, classifyGeminiError(err)
+	}
+
+	questions, err := parseInterviewQuestionsText(responseText, count)
+	if err != nil {
+		logger.Error("Failed to parse Gemini interview questions response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Interview question generation completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+		"question_count":  len(questions),
+	})
+
+	return questions, nil
+}
+
+// RefineResume revises the tailored resume of a conversation according to a follow-up
+// instruction, sending the conversation to Gemini as chat messages
+func (gp *GeminiProvider) RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting resume refinement with Gemini", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"turns":     len(conversation),
+		"provider":  "gemini",
+	})
+
+	prompt, err := buildResumeRefinementPrompt(instruction)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	turns := append(append([]models.ConversationTurn{}, conversation...), models.ConversationTurn{Role: "user", Content: prompt})
+	responseText, err := gp.completeConversation(ctx, turns)
+	if err != nil {
+		logger.Error("Gemini API call failed for resume refinement", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		return nil, nil, "", classifyGeminiError(err)
+	}
+
+	tailoredResume, suggestions, err := parseResumeTailoringText(gp.logger, responseText, baseResume)
+	if err != nil {
+		logger.Error("Failed to parse Gemini resume refinement response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "gemini",
+			"error":     err.Error(),
+		})
+		gp.usage.recordParseFailure()
+		return nil, nil, responseText, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Resume refinement completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "gemini",
+	})
+
+	return tailoredResume, suggestions, responseText, nil
+}
+
+// complete sends prompt as a single user turn with JSON output and returns the response text
+func (gp *GeminiProvider) complete(ctx context.Context, prompt string) (string, error) {
+	return gp.completeConversation(ctx, []models.ConversationTurn{{Role: "user", Content: prompt}})
+}
+
+// completeConversation sends the turns as conversation contents with JSON output and returns the
+// text of the next model turn. Gemini names the assistant role "model".
+func (gp *GeminiProvider) completeConversation(ctx context.Context, turns []models.ConversationTurn) (string, error) {
+	contents := make([]geminiContent, len(turns))
+	for i, turn := range turns {
+		role := turn.Role
+		if role == "assistant" {
+			role = "model"
+		}
+		contents[i] = geminiContent{Role: role, Parts: []geminiPart{{Text: turn.Content}}}
+	}
+
+	model := requestedModel(ctx, gp.config, "gemini", gp.model)
+	body, err := json.Marshal(geminiRequest{
+		Contents: contents,
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:      gp.config.LLM.Temperature,
+			MaxOutputTokens:  gp.config.LLM.MaxTokens,
+			ResponseMimeType: "application/json",
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	data, err := gp.send(ctx, http.MethodPost, "/models/"+model+":generateContent", body)
+	if err != nil {
+		return "", err
+	}
+
+	var response geminiResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("failed to decode Gemini response: %w", err)
+	}
+
+	gp.usage.record(ctx, "gemini", model, response.UsageMetadata.PromptTokenCount, response.UsageMetadata.CandidatesTokenCount)
+
+	if response.PromptFeedback.BlockReason != "" {
+		return "", fmt.Errorf("prompt blocked by Gemini: %s", response.PromptFeedback.BlockReason)
+	}
+	if len(response.Candidates) == 0 {
+		return "", fmt.Errorf("empty response from Gemini")
+	}
+
+	var text strings.Builder
+	for _, part := range response.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+	}
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no text content in Gemini response (finish reason %s)", response.Candidates[0].FinishReason)
+	}
+	return text.String(), nil
+}
+
+// send issues an authenticated request to the Gemini API and returns the response body
+func (gp *GeminiProvider) send(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, gp.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("x-goog-api-key", gp.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := gp.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Gemini response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &geminiAPIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errorBody struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &errorBody) == nil && errorBody.Error.Message != "" {
+			apiErr.Message = errorBody.Error.Message
+		}
+		return nil, apiErr
+	}
+
+	return data, nil
+}
+
+// IsHealthy checks if the Gemini provider is healthy and available. It looks up the configured
+// model, which verifies the key and model without paying for a completion.
+func (gp *GeminiProvider) IsHealthy(ctx context.Context) error {
+	if gp.apiKey == "" {
+		return fmt.Errorf("Gemini API key not configured - set GEMINI_API_KEY environment variable")
+	}
+
+	if _, err := gp.send(ctx, http.MethodGet, "/models/"+gp.model, nil); err != nil {
+		return fmt.Errorf("Gemini API health check failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetProviderName returns the name of the LLM provider
+func (gp *GeminiProvider) GetProviderName() string {
+	return "gemini"
+}
+
+// GetUsageStats returns cumulative token usage and parse failure counts
+func (gp *GeminiProvider) GetUsageStats() UsageStats {
+	return gp.usage.snapshot()
+}
+
+// classifyGeminiError maps an Gemini API error onto the error taxonomy
+func classifyGeminiError(err error) *utils.CustomError {
+	var apiErr *geminiAPIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return utils.NewRateLimitedError(fmt.Sprintf("Gemini API: %v", err))
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("Gemini API: %v", err))
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("Gemini API: %v", err))
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return utils.NewTimeoutError(fmt.Sprintf("Gemini API call timed out: %v", err))
+	}
+	return utils.NewLLMError(fmt.Sprintf("failed to call Gemini API: %v", err))
+}
@@ -0,0 +1,372 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// MockNotJobMarker makes the mock provider reject a URL or description as not a job posting, so
+// tests can exercise the failure path deterministically
+const MockNotJobMarker = "not-a-job"
+
+// mockCompanies and mockTitles are combined by hashing the input, so the same URL always
+// yields the same job
+var (
+	mockCompanies = []string{"Acme Corp", "Globex", "Initech", "Umbrella Labs", "Hooli"}
+	mockTitles    = []string{"Software Engineer", "Backend Engineer", "Frontend Engineer", "Data Engineer", "Site Reliability Engineer"}
+	mockLocations = []string{"Remote", "Berlin, Germany", "New York, NY", "Bengaluru, India", "London, UK"}
+)
+
+// MockProvider implements the LLM provider interface without calling any external API. Its
+// output depends only on its input, which makes it suitable for integration tests and local
+// frontend development.
+type MockProvider struct {
+	config *config.Config
+	logger types.Logger
+	usage  usageTracker
+}
+
+// NewMockProvider creates a new mock provider instance
+func NewMockProvider(cfg *config.Config) *MockProvider {
+	return &MockProvider{
+		config: cfg,
+		logger: logging.GetGlobalLogger(),
+	}
+}
+
+// ExtractJobData returns a deterministic job derived from the URL
+func (mp *MockProvider) ExtractJobData(ctx context.Context, html, url string) (*models.Job, error) {
+	if strings.Contains(url, MockNotJobMarker) {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("URL '%s' is not a job posting: mock provider rejected it", url))
+	}
+
+	mp.recordUsage(ctx, html, 400)
+	job := mockJob(url)
+	job.JobURL = url
+	return job, nil
+}
+
+// ExtractJobFromDescription returns a deterministic job derived from the description
+func (mp *MockProvider) ExtractJobFromDescription(ctx context.Context, description string) (*models.Job, error) {
+	if strings.Contains(description, MockNotJobMarker) {
+		return nil, utils.NewNotJobPostingError("The provided description is not a job posting: mock provider rejected it")
+	}
+
+	mp.recordUsage(ctx, description, 400)
+	return mockJob(description), nil
+}
+
+// TailorResume returns the base resume sections unchanged with three fixed suggestions
+func (mp *MockProvider) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+	tailoredResume, suggestions, _, err := mp.TailorResumeWithRawResponse(ctx, baseResume, job)
+	return tailoredResume, suggestions, err
+}
+
+// TailorResumeWithRawResponse tailors a resume and returns the JSON a real provider would have
+// produced as the raw response
+func (mp *MockProvider) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+	tailoredResume := &models.TailoredResume{
+		ID:       baseResume.ID,
+		Sections: make([]models.TailoredResumeSection, len(baseResume.Sections)),
+	}
+	for i, section := range baseResume.Sections {
+		tailoredResume.Sections[i] = models.TailoredResumeSection{
+			Type: section.Type,
+			Data: section.Data,
+		}
+	}
+
+	suggestions := []models.Suggestion{
+		{
+			ID:        "sug_001",
+			Type:      "profile",
+			Priority:  "high",
+			Impact:    "Aligns the profile summary with the target role",
+			Section:   "profile",
+			Suggested: fmt.Sprintf("Lead the profile with your fit for the %s role at %s", job.Title, job.CompanyName),
+			Reasoning: "Recruiters read the summary first",
+		},
+		{
+			ID:        "sug_002",
+			Type:      "experience",
+			Priority:  "medium",
+			Impact:    "Makes relevant achievements easier to find",
+			Section:   "experience",
+			Suggested: "Quantify the outcome of your most relevant project",
+			Reasoning: "Measured results stand out against the job requirements",
+		},
+		{
+			ID:        "sug_003",
+			Type:      "skills",
+			Priority:  "low",
+			Impact:    "Improves keyword matching",
+			Section:   "skills",
+			Suggested: "List the skills named in the job requirements first",
+			Reasoning: "Applicant tracking systems rank keyword matches",
+		},
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"tailored_resume": map[string]interface{}{"sections": tailoredResume.Sections},
+		"suggestions":     suggestions,
+	})
+	if err != nil {
+		return nil, nil, "", utils.NewLLMParseError(err.Error())
+	}
+
+	mp.recordUsage(ctx, string(raw), int64(len(raw)/4))
+	return tailoredResume, suggestions, string(raw), nil
+}
+
+// RefineResume returns the base resume sections unchanged with one suggestion repeating the
+// instruction, so the conversation round trip can be exercised without an API key
+func (mp *MockProvider) RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error) {
+	tailoredResume := &models.TailoredResume{
+		ID:       baseResume.ID,
+		Sections: make([]models.TailoredResumeSection, len(baseResume.Sections)),
+	}
+	for i, section := range baseResume.Sections {
+		tailoredResume.Sections[i] = models.TailoredResumeSection{
+			Type: section.Type,
+			Data: section.Data,
+		}
+	}
+
+	suggestions := []models.Suggestion{
+		{
+			ID:        "sug_001",
+			Type:      "refinement",
+			Priority:  "medium",
+			Impact:    "Applies the requested change across the resume",
+			Section:   "profile",
+			Suggested: fmt.Sprintf("Review the resume for: %s", instruction),
+			Reasoning: fmt.Sprintf("Follow-up instruction %d of the conversation", len(conversation)/2),
+		},
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"tailored_resume": map[string]interface{}{"sections": tailoredResume.Sections},
+		"suggestions":     suggestions,
+	})
+	if err != nil {
+		return nil, nil, "", utils.NewLLMParseError(err.Error())
+	}
+
+	input := instruction
+	for _, turn := range conversation {
+		input += turn.Content
+	}
+	mp.recordUsage(ctx, input, int64(len(raw)/4))
+	return tailoredResume, suggestions, string(raw), nil
+}
+
+// ReviewResumeForATS scores the resume by how many job requirements mention a word that also
+// appears in the resume, so the same input always gets the same review
+func (mp *MockProvider) ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error) {
+	resumeJSON, err := json.Marshal(baseResume)
+	if err != nil {
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+	resumeText := strings.ToLower(string(resumeJSON))
+
+	matched := 0
+	for _, requirement := range job.Requirements {
+		if mockMentionsWord(resumeText, requirement) {
+			matched++
+		}
+	}
+	score := 50
+	if len(job.Requirements) > 0 {
+		score = matched * 100 / len(job.Requirements)
+	}
+
+	review := &models.ATSReview{
+		Score:            score,
+		Summary:          fmt.Sprintf("The resume covers %d of %d requirements of the %s role.", matched, len(job.Requirements), job.Title),
+		FormattingIssues: []string{},
+	}
+	for _, section := range baseResume.Sections {
+		review.Sections = append(review.Sections, models.ATSSectionReview{
+			Type:     strings.ToLower(section.Type),
+			Score:    score,
+			Feedback: "Mention the job's requirements where this section demonstrates them",
+		})
+	}
+
+	mp.recordUsage(ctx, string(resumeJSON), 300)
+	return review, nil
+}
+
+// AnalyzeSkillGap treats a requirement as matched when the resume names it, as partially matched
+// when the resume contains one of its longer words, and as missing otherwise
+func (mp *MockProvider) AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error) {
+	resumeJSON, err := json.Marshal(baseResume)
+	if err != nil {
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+	resumeText := strings.ToLower(string(resumeJSON))
+
+	analysis := &models.SkillGapAnalysis{
+		MatchedSkills:       []models.SkillGapItem{},
+		PartialSkills:       []models.SkillGapItem{},
+		MissingSkills:       []models.SkillGapItem{},
+		LearningSuggestions: []models.LearningSuggestion{},
+	}
+	for _, requirement := range job.Requirements {
+		item := models.SkillGapItem{Skill: requirement, Requirement: requirement}
+		switch {
+		case strings.Contains(resumeText, strings.ToLower(requirement)):
+			item.Evidence = "Named in the resume"
+			analysis.MatchedSkills = append(analysis.MatchedSkills, item)
+		case mockMentionsWord(resumeText, requirement):
+			item.Evidence = "Related terms appear in the resume"
+			item.Gap = "Not shown as required"
+			analysis.PartialSkills = append(analysis.PartialSkills, item)
+		default:
+			item.Gap = "Not mentioned in the resume"
+			analysis.MissingSkills = append(analysis.MissingSkills, item)
+			if len(analysis.LearningSuggestions) < 5 {
+				analysis.LearningSuggestions = append(analysis.LearningSuggestions, models.LearningSuggestion{
+					Skill:         requirement,
+					Priority:      "high",
+					Suggestion:    "Build a small project that demonstrates this requirement",
+					EstimatedTime: "2 weeks",
+				})
+			}
+		}
+	}
+	analysis.Summary = fmt.Sprintf("The resume matches %d of %d requirements of the %s role.", len(analysis.MatchedSkills), len(job.Requirements), job.Title)
+
+	mp.recordUsage(ctx, string(resumeJSON), 300)
+	return analysis, nil
+}
+
+// GenerateInterviewQuestions asks one technical question per job requirement, followed by a
+// behavioral question per resume section, so the same input always gets the same questions
+func (mp *MockProvider) GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error) {
+	resumeJSON, err := json.Marshal(baseResume)
+	if err != nil {
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	var questions []models.InterviewQuestion
+	for _, requirement := range job.Requirements {
+		questions = append(questions, models.InterviewQuestion{
+			Question:        fmt.Sprintf("How have you demonstrated %q in your work?", requirement),
+			Category:        models.InterviewCategoryTechnical,
+			SuggestedAnswer: "Describe the resume entry where you applied this most recently and the result it had.",
+			Reasoning:       fmt.Sprintf("The %s role lists it as a requirement.", job.Title),
+		})
+	}
+	for _, section := range baseResume.Sections {
+		questions = append(questions, models.InterviewQuestion{
+			Question:        fmt.Sprintf("Walk me through the most challenging part of your %s.", strings.ToLower(section.Type)),
+			Category:        models.InterviewCategoryBehavioral,
+			SuggestedAnswer: "Describe the situation, your task, the actions you took and the result.",
+			Reasoning:       "Interviewers probe the entries listed on the resume.",
+		})
+	}
+	if len(questions) == 0 {
+		questions = append(questions, models.InterviewQuestion{
+			Question:        fmt.Sprintf("Why do you want to work as a %s?", job.Title),
+			Category:        models.InterviewCategoryExperience,
+			SuggestedAnswer: "Connect your most relevant experience to what the role needs.",
+			Reasoning:       "Most interviews open with motivation.",
+		})
+	}
+	if count > 0 && len(questions) > count {
+		questions = questions[:count]
+	}
+
+	mp.recordUsage(ctx, string(resumeJSON), 500)
+	return questions, nil
+}
+
+// ExtractCompanyData returns a deterministic company named after name, or after the website's
+// host when no name is given
+func (mp *MockProvider) ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error) {
+	if name == "" {
+		name = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(website, "https://"), "http://"), "www.")
+		name = strings.TrimSuffix(name, "/")
+	}
+
+	mp.recordUsage(ctx, content, 200)
+	return &models.Company{
+		Name:         name,
+		Description:  fmt.Sprintf("%s builds software products for businesses.", name),
+		Industry:     "Software",
+		Size:         "51-200",
+		Headquarters: "San Francisco, United States",
+		FoundedYear:  2015,
+	}, nil
+}
+
+// mockMentionsWord reports whether text contains a word of more than three letters from phrase
+func mockMentionsWord(text, phrase string) bool {
+	for _, word := range strings.Fields(strings.ToLower(phrase)) {
+		if len(word) > 3 && strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHealthy always succeeds since the mock provider has no dependencies
+func (mp *MockProvider) IsHealthy(ctx context.Context) error {
+	return nil
+}
+
+// GetProviderName returns the name of the LLM provider
+func (mp *MockProvider) GetProviderName() string {
+	return "mock"
+}
+
+// GetUsageStats returns the simulated token usage
+func (mp *MockProvider) GetUsageStats() UsageStats {
+	return mp.usage.snapshot()
+}
+
+// recordUsage simulates token accounting at roughly four characters per input token, so
+// usage metrics and quotas behave as they would with a real provider
+func (mp *MockProvider) recordUsage(ctx context.Context, input string, outputTokens int64) {
+	inputTokens := int64(len(input)/4) + 1
+	mp.usage.record(ctx, "mock", "mock", inputTokens, outputTokens)
+}
+
+// mockJob builds the job for seed, picking each field by a hash of the seed
+func mockJob(seed string) *models.Job {
+	h := fnv.New32a()
+	h.Write([]byte(seed))
+	n := int(h.Sum32())
+
+	title := mockTitles[n%len(mockTitles)]
+	company := mockCompanies[(n/len(mockTitles))%len(mockCompanies)]
+	minSalary := 80000 + (n%8)*10000
+
+	return &models.Job{
+		Title:       title,
+		CompanyName: company,
+		Location:    mockLocations[(n/7)%len(mockLocations)],
+		Currency:    "USD",
+		Salary: models.Salary{
+			Currency: "USD",
+			Min:      float64(minSalary),
+			Max:      float64(minSalary + 40000),
+			Period:   "yearly",
+		},
+		Requirements:     []string{"3+ years of professional experience", "Proficiency in Go or a similar language", "Experience with distributed systems"},
+		Description:      fmt.Sprintf("%s is hiring a %s to build and operate its core platform.", company, title),
+		Responsibilities: []string{"Design and ship new features", "Review code and mentor teammates", "Keep services reliable in production"},
+		Benefits:         []string{"Health insurance", "Flexible working hours", "Learning budget"},
+		OriginalLanguage: "en",
+	}
+}
@@ -0,0 +1,36 @@
+package providers
+
+import (
+	"context"
+	"slices"
+
+	"letraz-utils/internal/config"
+)
+
+type modelContextKey struct{}
+
+// WithModel returns a copy of ctx whose LLM calls ask for model in place of the provider's
+// configured model. An empty model leaves ctx unchanged.
+func WithModel(ctx context.Context, model string) context.Context {
+	if model == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, modelContextKey{}, model)
+}
+
+// RequestedModel returns the model ctx asks for, whether or not a provider allows it, or an
+// empty string
+func RequestedModel(ctx context.Context) string {
+	model, _ := ctx.Value(modelContextKey{}).(string)
+	return model
+}
+
+// requestedModel returns the model ctx asks for when it is allowed for provider, and
+// defaultModel otherwise, so a provider reached through failover keeps its own model
+func requestedModel(ctx context.Context, cfg *config.Config, provider, defaultModel string) string {
+	model := RequestedModel(ctx)
+	if model != "" && slices.Contains(cfg.LLM.AllowedModels[provider], model) {
+		return model
+	}
+	return defaultModel
+}
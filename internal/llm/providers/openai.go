@@ -0,0 +1,635 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// openAIChatRequest is the request body of the chat completions API
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	Temperature    float32               `json:"temperature"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// openAIChatResponse is the subset of the chat completions response the provider reads
+type openAIChatResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int64 `json:"prompt_tokens"`
+		CompletionTokens int64 `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// openAIAPIError is a non-2xx response from the OpenAI API
+type openAIAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *openAIAPIError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// OpenAIProvider implements the LLM provider interface using OpenAI chat completions
+type OpenAIProvider struct {
+	httpClient  *http.Client
+	config      *config.Config
+	apiKey      string
+	model       string
+	baseURL     string
+	htmlCleaner *processors.HTMLCleaner
+	logger      types.Logger
+	usage       usageTracker
+}
+
+// NewOpenAIProvider creates a new OpenAI provider instance
+func NewOpenAIProvider(cfg *config.Config) *OpenAIProvider {
+	apiKey := cfg.LLM.OpenAI.APIKey
+	if apiKey == "" && cfg.LLM.Provider == "openai" {
+		apiKey = cfg.LLM.APIKey
+	}
+
+	return &OpenAIProvider{
+		httpClient:  &http.Client{Timeout: cfg.LLM.Timeout},
+		config:      cfg,
+		apiKey:      apiKey,
+		model:       cfg.LLM.OpenAI.Model,
+		baseURL:     strings.TrimRight(cfg.LLM.OpenAI.BaseURL, "/"),
+		htmlCleaner: processors.NewHTMLCleaner(),
+		logger:      logging.GetGlobalLogger(),
+	}
+}
+
+// ExtractJobData processes HTML content and extracts structured job data using OpenAI
+func (op *OpenAIProvider) ExtractJobData(ctx context.Context, html, url string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting job data extraction with OpenAI", map[string]interface{}{
+		"url":         url,
+		"html_length": len(html),
+		"provider":    "openai",
+	})
+
+	cleanedContent, err := op.htmlCleaner.ExtractJobContent(html)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean HTML: %w", err)
+	}
+
+	// Postings in other languages are translated to English by the LLM
+	language := op.htmlCleaner.DetectLanguage(html)
+
+	// Long content is extracted in chunks that a final call merges
+	job, err := extractJob(ctx, op.config, cleanedContent, url, language, func(data []byte) (*models.Job, error) {
+		return parseJobExtractionJSON(op.logger, data, url, language)
+	}, func(ctx context.Context, prompt string, parse jobParser) (*models.Job, error) {
+		responseText, err := op.complete(ctx, prompt)
+		if err != nil {
+			logger.Error("OpenAI API call failed", map[string]interface{}{
+				"url":      url,
+				"provider": "openai",
+				"error":    err.Error(),
+			})
+			return nil, classifyOpenAIError(err)
+		}
+
+		job, err := parse([]byte(stripCodeFence(responseText)))
+		if err != nil {
+			logger.Error("Failed to parse OpenAI response", map[string]interface{}{
+				"url":      url,
+				"provider": "openai",
+				"error":    err.Error(),
+			})
+
+			// Don't wrap CustomError types so they can be properly handled upstream
+			if _, ok := err.(*utils.CustomError); ok {
+				return nil, err
+			}
+
+			op.usage.recordParseFailure()
+			return nil, utils.NewLLMParseError(err.Error())
+		}
+		return job, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Job data extraction completed successfully", map[string]interface{}{
+		"url":             url,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+	})
+
+	return job, nil
+}
+
+// ExtractJobFromDescription processes job description text directly and extracts structured job data using OpenAI
+func (op *OpenAIProvider) ExtractJobFromDescription(ctx context.Context, description string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting job data extraction from description with OpenAI", map[string]interface{}{
+		"description_length": len(description),
+		"provider":           "openai",
+	})
+
+	if len(description) == 0 {
+		return nil, fmt.Errorf("description cannot be empty")
+	}
+
+	maxContentLength := op.config.LLM.MaxTokens * 3 // Rough estimation: 3 chars per token
+	if len(description) > maxContentLength {
+		description = description[:maxContentLength] + "..."
+	}
+
+	// Descriptions in other languages are translated to English by the LLM
+	language := processors.DetectTextLanguage(description)
+
+	prompt, err := buildJobExtractionFromDescriptionPrompt(description, language)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := op.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("OpenAI API call failed for description processing", map[string]interface{}{
+			"provider": "openai",
+			"error":    err.Error(),
+		})
+		return nil, classifyOpenAIError(err)
+	}
+
+	job, err := parseJobExtractionResponse(op.logger, responseText, "", language)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI response for description", map[string]interface{}{
+			"provider": "openai",
+			"error":    err.Error(),
+		})
+
+		if _, ok := err.(*utils.CustomError); ok {
+			return nil, err
+		}
+
+		op.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Job data extraction from description completed successfully", map[string]interface{}{
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+	})
+
+	return job, nil
+}
+
+// TailorResume tailors a base resume for a specific job posting using OpenAI
+func (op *OpenAIProvider) TailorResume(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, error) {
+	tailoredResume, suggestions, _, err := op.TailorResumeWithRawResponse(ctx, baseResume, job)
+	return tailoredResume, suggestions, err
+}
+
+// TailorResumeWithRawResponse tailors a resume and returns the raw AI response for conversation history
+func (op *OpenAIProvider) TailorResumeWithRawResponse(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting resume tailoring with OpenAI", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"job_title": job.Title,
+		"company":   job.CompanyName,
+		"provider":  "openai",
+	})
+
+	prompt, err := buildResumeTailoringPrompt(baseResume, job)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	rawResponse, err := op.complete(ctx, prompt.text())
+	if err != nil {
+		logger.Error("OpenAI API call failed for resume tailoring", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		return nil, nil, "", classifyOpenAIError(err)
+	}
+
+	tailoredResume, suggestions, err := parseResumeTailoringText(op.logger, rawResponse, baseResume)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI resume tailoring response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, nil, rawResponse, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Resume tailoring completed successfully", map[string]interface{}{
+		"resume_id":         baseResume.ID,
+		"processing_time":   time.Since(startTime),
+		"provider":          "openai",
+		"suggestions_count": len(suggestions),
+	})
+
+	return tailoredResume, suggestions, rawResponse, nil
+}
+
+// ReviewResumeForATS assesses how well a resume fits a job posting using OpenAI
+func (op *OpenAIProvider) ReviewResumeForATS(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.ATSReview, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting ATS review with OpenAI", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"job_title": job.Title,
+		"company":   job.CompanyName,
+		"provider":  "openai",
+	})
+
+	prompt, err := buildATSReviewPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := op.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("OpenAI API call failed for ATS review", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		return nil, classifyOpenAIError(err)
+	}
+
+	review, err := parseATSReviewText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI ATS review response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("ATS review completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+		"score":           review.Score,
+	})
+
+	return review, nil
+}
+
+// AnalyzeSkillGap compares a resume's skills with a job's requirements using OpenAI
+func (op *OpenAIProvider) AnalyzeSkillGap(ctx context.Context, baseResume *models.BaseResume, job *models.Job) (*models.SkillGapAnalysis, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting skill gap analysis with OpenAI", map[string]interface{}{
+		"resume_id":          baseResume.ID,
+		"job_title":          job.Title,
+		"requirements_count": len(job.Requirements),
+		"provider":           "openai",
+	})
+
+	prompt, err := buildSkillGapPrompt(baseResume, job)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := op.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("OpenAI API call failed for skill gap analysis", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		return nil, classifyOpenAIError(err)
+	}
+
+	analysis, err := parseSkillGapText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI skill gap response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Skill gap analysis completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+		"missing_count":   len(analysis.MissingSkills),
+	})
+
+	return analysis, nil
+}
+
+// ExtractCompanyData extracts company information from the cleaned pages of its website using OpenAI
+func (op *OpenAIProvider) ExtractCompanyData(ctx context.Context, content, name, website string) (*models.Company, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting company extraction with OpenAI", map[string]interface{}{
+		"website":        website,
+		"content_length": len(content),
+		"provider":       "openai",
+	})
+
+	prompt, err := buildCompanyExtractionPrompt(content, name, website)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := op.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("OpenAI API call failed for company extraction", map[string]interface{}{
+			"website":  website,
+			"provider": "openai",
+			"error":    err.Error(),
+		})
+		return nil, classifyOpenAIError(err)
+	}
+
+	company, err := parseCompanyText(responseText)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI company response", map[string]interface{}{
+			"website":  website,
+			"provider": "openai",
+			"error":    err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Company extraction completed successfully", map[string]interface{}{
+		"website":         website,
+		"company":         company.Name,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+	})
+
+	return company, nil
+}
+
+// GenerateInterviewQuestions predicts the questions a candidate is likely to be asked for a job using OpenAI
+func (op *OpenAIProvider) GenerateInterviewQuestions(ctx context.Context, baseResume *models.BaseResume, job *models.Job, count int) ([]models.InterviewQuestion, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting interview question generation with OpenAI", map[string]interface{}{
+		"resume_id":      baseResume.ID,
+		"job_title":      job.Title,
+		"question_count": count,
+		"provider":       "openai",
+	})
+
+	prompt, err := buildInterviewQuestionsPrompt(baseResume, job, count)
+	if err != nil {
+		return nil, err
+	}
+
+	responseText, err := op.complete(ctx, prompt)
+	if err != nil {
+		logger.Error("OpenAI API call failed for interview question generation", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		return nil, classifyOpenAIError(err)
+	}
+
+	questions, err := parseInterviewQuestionsText(responseText, count)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI interview questions response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Interview question generation completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+		"question_count":  len(questions),
+	})
+
+	return questions, nil
+}
+
+// RefineResume revises the tailored resume of a conversation according to a follow-up
+// instruction, sending the conversation to OpenAI as chat messages
+func (op *OpenAIProvider) RefineResume(ctx context.Context, baseResume *models.BaseResume, conversation []models.ConversationTurn, instruction string) (*models.TailoredResume, []models.Suggestion, string, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting resume refinement with OpenAI", map[string]interface{}{
+		"resume_id": baseResume.ID,
+		"turns":     len(conversation),
+		"provider":  "openai",
+	})
+
+	prompt, err := buildResumeRefinementPrompt(instruction)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	turns := append(append([]models.ConversationTurn{}, conversation...), models.ConversationTurn{Role: "user", Content: prompt})
+	responseText, err := op.completeConversation(ctx, turns)
+	if err != nil {
+		logger.Error("OpenAI API call failed for resume refinement", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		return nil, nil, "", classifyOpenAIError(err)
+	}
+
+	tailoredResume, suggestions, err := parseResumeTailoringText(op.logger, responseText, baseResume)
+	if err != nil {
+		logger.Error("Failed to parse OpenAI resume refinement response", map[string]interface{}{
+			"resume_id": baseResume.ID,
+			"provider":  "openai",
+			"error":     err.Error(),
+		})
+		op.usage.recordParseFailure()
+		return nil, nil, responseText, utils.NewLLMParseError(err.Error())
+	}
+
+	logger.Info("Resume refinement completed successfully", map[string]interface{}{
+		"resume_id":       baseResume.ID,
+		"processing_time": time.Since(startTime),
+		"provider":        "openai",
+	})
+
+	return tailoredResume, suggestions, responseText, nil
+}
+
+// complete sends prompt as a single user message in JSON mode and returns the response text
+func (op *OpenAIProvider) complete(ctx context.Context, prompt string) (string, error) {
+	return op.completeConversation(ctx, []models.ConversationTurn{{Role: "user", Content: prompt}})
+}
+
+// completeConversation sends the turns as chat messages in JSON mode and returns the text of the
+// next assistant message
+func (op *OpenAIProvider) completeConversation(ctx context.Context, turns []models.ConversationTurn) (string, error) {
+	messages := make([]openAIMessage, len(turns))
+	for i, turn := range turns {
+		messages[i] = openAIMessage{Role: turn.Role, Content: turn.Content}
+	}
+
+	model := requestedModel(ctx, op.config, "openai", op.model)
+	body, err := json.Marshal(openAIChatRequest{
+		Model:          model,
+		Messages:       messages,
+		MaxTokens:      op.config.LLM.MaxTokens,
+		Temperature:    op.config.LLM.Temperature,
+		ResponseFormat: &openAIResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	data, err := op.send(ctx, http.MethodPost, "/chat/completions", body)
+	if err != nil {
+		return "", err
+	}
+
+	var response openAIChatResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("failed to decode OpenAI response: %w", err)
+	}
+
+	op.usage.record(ctx, "openai", model, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("empty response from OpenAI")
+	}
+	return response.Choices[0].Message.Content, nil
+}
+
+// send issues an authenticated request to the OpenAI API and returns the response body
+func (op *OpenAIProvider) send(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, op.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+op.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := op.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAI response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &openAIAPIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errorBody struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &errorBody) == nil && errorBody.Error.Message != "" {
+			apiErr.Message = errorBody.Error.Message
+		}
+		return nil, apiErr
+	}
+
+	return data, nil
+}
+
+// IsHealthy checks if the OpenAI provider is healthy and available. It looks up the configured
+// model, which verifies the key and model without paying for a completion.
+func (op *OpenAIProvider) IsHealthy(ctx context.Context) error {
+	if op.apiKey == "" {
+		return fmt.Errorf("OpenAI API key not configured - set OPENAI_API_KEY environment variable")
+	}
+
+	if _, err := op.send(ctx, http.MethodGet, "/models/"+op.model, nil); err != nil {
+		return fmt.Errorf("OpenAI API health check failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetProviderName returns the name of the LLM provider
+func (op *OpenAIProvider) GetProviderName() string {
+	return "openai"
+}
+
+// GetUsageStats returns cumulative token usage and parse failure counts
+func (op *OpenAIProvider) GetUsageStats() UsageStats {
+	return op.usage.snapshot()
+}
+
+// classifyOpenAIError maps an OpenAI API error onto the error taxonomy
+func classifyOpenAIError(err error) *utils.CustomError {
+	var apiErr *openAIAPIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return utils.NewRateLimitedError(fmt.Sprintf("OpenAI API: %v", err))
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("OpenAI API: %v", err))
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return utils.NewLLMUnavailableError(fmt.Sprintf("OpenAI API: %v", err))
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return utils.NewTimeoutError(fmt.Sprintf("OpenAI API call timed out: %v", err))
+	}
+	return utils.NewLLMError(fmt.Sprintf("failed to call OpenAI API: %v", err))
+}
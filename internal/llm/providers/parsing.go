@@ -0,0 +1,381 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// stripCodeFence removes the markdown code block models sometimes wrap JSON output in
+func stripCodeFence(responseText string) string {
+	responseText = strings.TrimSpace(responseText)
+	if strings.HasPrefix(responseText, "```json") {
+		responseText = strings.TrimPrefix(responseText, "```json")
+		responseText = strings.TrimSuffix(responseText, "```")
+		responseText = strings.TrimSpace(responseText)
+	} else if strings.HasPrefix(responseText, "```") {
+		responseText = strings.TrimPrefix(responseText, "```")
+		responseText = strings.TrimSuffix(responseText, "```")
+		responseText = strings.TrimSpace(responseText)
+	}
+	return responseText
+}
+
+// parseJobExtractionResponse parses the JSON text of a job extraction response and validates
+// that it describes a job posting
+func parseJobExtractionResponse(logger types.Logger, responseText, url, language string) (*models.Job, error) {
+	return parseJobExtractionJSON(logger, []byte(stripCodeFence(responseText)), url, language)
+}
+
+// parseJobExtractionJSON decodes a job extraction and validates that it describes a job posting.
+// Decoding failures are returned as plain errors, rejected postings as CustomErrors. language is
+// the detected content language, used when the response reports none.
+func parseJobExtractionJSON(logger types.Logger, data []byte, url, language string) (*models.Job, error) {
+	logger.Debug("LLM response received", map[string]interface{}{
+		"response_text": string(data),
+	})
+
+	var rawResponse jobExtractionResponse
+	if err := json.Unmarshal(data, &rawResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	// Check if the content is actually a job posting
+	if !rawResponse.IsJobPosting {
+		reason := rawResponse.Reason
+		if reason == "" {
+			reason = "The provided URL does not contain a job posting"
+		}
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("URL '%s' is not a job posting: %s", url, reason))
+	}
+
+	// Check confidence level for job postings
+	if rawResponse.Confidence < 0.7 {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("Low confidence (%.2f) that URL '%s' contains a valid job posting", rawResponse.Confidence, url))
+	}
+
+	job := rawResponse.job(url, language)
+
+	// Validate required fields for confirmed job postings
+	if job.Title == "" {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("No job title found in URL '%s' - content may not be a valid job posting", url))
+	}
+	if job.CompanyName == "" {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("No company name found in URL '%s' - content may not be a valid job posting", url))
+	}
+
+	logger.Info("Successfully validated and extracted job posting")
+
+	return job, nil
+}
+
+// parseJobCandidateJSON decodes the job extracted from one part of a page extracted in parts.
+// Parts hold only some of the posting's fields, so the job is not validated; nil is returned when
+// the part does not belong to a job posting.
+func parseJobCandidateJSON(data []byte, url string) (*models.Job, error) {
+	var rawResponse jobExtractionResponse
+	if err := json.Unmarshal(data, &rawResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+	if !rawResponse.IsJobPosting {
+		return nil, nil
+	}
+	return rawResponse.job(url, ""), nil
+}
+
+// jobExtractionResponse is the answer to a job extraction prompt
+type jobExtractionResponse struct {
+	IsJobPosting     bool          `json:"is_job_posting"`
+	Confidence       float64       `json:"confidence"`
+	Title            string        `json:"title"`
+	JobURL           string        `json:"job_url"`
+	CompanyName      string        `json:"company_name"`
+	Location         string        `json:"location"`
+	Salary           models.Salary `json:"salary"`
+	Requirements     []string      `json:"requirements"`
+	Description      string        `json:"description"`
+	Responsibilities []string      `json:"responsibilities"`
+	Benefits         []string      `json:"benefits"`
+	OriginalLanguage string        `json:"original_language"`
+	Reason           string        `json:"reason"`
+}
+
+// job converts the response to a job, defaulting the URL and the detected language
+func (r jobExtractionResponse) job(url, language string) *models.Job {
+	job := &models.Job{
+		Title:            r.Title,
+		JobURL:           r.JobURL,
+		CompanyName:      r.CompanyName,
+		Location:         r.Location,
+		Salary:           r.Salary,
+		Requirements:     r.Requirements,
+		Description:      r.Description,
+		Responsibilities: r.Responsibilities,
+		Benefits:         r.Benefits,
+		OriginalLanguage: processors.NormalizeLanguageCode(r.OriginalLanguage),
+	}
+	if job.OriginalLanguage == "" {
+		job.OriginalLanguage = language
+	}
+	if job.JobURL == "" {
+		job.JobURL = url
+	}
+	return job
+}
+
+// parseResumeTailoringText parses the JSON text of a resume tailoring response
+func parseResumeTailoringText(logger types.Logger, responseText string, baseResume *models.BaseResume) (*models.TailoredResume, []models.Suggestion, error) {
+	return parseResumeTailoringJSON(logger, []byte(stripCodeFence(responseText)), baseResume)
+}
+
+// parseResumeTailoringJSON decodes a resume tailoring and validates its sections and suggestions
+func parseResumeTailoringJSON(logger types.Logger, data []byte, baseResume *models.BaseResume) (*models.TailoredResume, []models.Suggestion, error) {
+	logger.Debug("LLM resume tailoring response received", map[string]interface{}{
+		"response_length": len(data),
+	})
+
+	// Log the actual response for debugging
+	logger.Debug("Raw LLM response for debugging", map[string]interface{}{
+		"raw_response": string(data),
+	})
+
+	// Parse JSON response using simplified structure that matches LLM output
+	var tailoringResponse struct {
+		TailoredResume struct {
+			Sections []struct {
+				Type string      `json:"type"`
+				Data interface{} `json:"data"`
+			} `json:"sections"`
+		} `json:"tailored_resume"`
+		Suggestions []models.Suggestion `json:"suggestions"`
+	}
+
+	if err := json.Unmarshal(data, &tailoringResponse); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	// Validate the response
+	if len(tailoringResponse.TailoredResume.Sections) == 0 {
+		return nil, nil, fmt.Errorf("invalid tailored resume: no sections provided")
+	}
+
+	if len(tailoringResponse.Suggestions) == 0 {
+		return nil, nil, fmt.Errorf("invalid response: no suggestions provided")
+	}
+
+	// Validate that we have exactly 3 suggestions with required fields
+	if len(tailoringResponse.Suggestions) > 3 {
+		tailoringResponse.Suggestions = tailoringResponse.Suggestions[:3] // Limit to 3
+	}
+
+	for i, suggestion := range tailoringResponse.Suggestions {
+		if suggestion.ID == "" {
+			tailoringResponse.Suggestions[i].ID = fmt.Sprintf("sug_%03d", i+1)
+		}
+		if suggestion.Type == "" {
+			return nil, nil, fmt.Errorf("invalid suggestion %d: missing type", i+1)
+		}
+		if suggestion.Impact == "" {
+			return nil, nil, fmt.Errorf("invalid suggestion %d: missing impact description", i+1)
+		}
+		if suggestion.Suggested == "" {
+			return nil, nil, fmt.Errorf("invalid suggestion %d: missing suggested improvement", i+1)
+		}
+		if suggestion.Reasoning == "" {
+			return nil, nil, fmt.Errorf("invalid suggestion %d: missing reasoning", i+1)
+		}
+		// Set default priority if not provided
+		if suggestion.Priority == "" {
+			tailoringResponse.Suggestions[i].Priority = "high"
+		}
+	}
+
+	// Create simplified TailoredResume response
+	tailoredResume := &models.TailoredResume{
+		ID:       baseResume.ID, // Keep original ID for reference
+		Sections: make([]models.TailoredResumeSection, len(tailoringResponse.TailoredResume.Sections)),
+	}
+
+	// Convert LLM sections to final format
+	for i, llmSection := range tailoringResponse.TailoredResume.Sections {
+		tailoredResume.Sections[i] = models.TailoredResumeSection{
+			Type: llmSection.Type,
+			Data: llmSection.Data,
+		}
+	}
+
+	logger.Info("Successfully parsed and validated resume tailoring response")
+
+	return tailoredResume, tailoringResponse.Suggestions, nil
+}
+
+// parseATSReviewText parses the JSON text of an ATS review response
+func parseATSReviewText(responseText string) (*models.ATSReview, error) {
+	return parseATSReviewJSON([]byte(stripCodeFence(responseText)))
+}
+
+// parseATSReviewJSON decodes an ATS review and validates its scores
+func parseATSReviewJSON(data []byte) (*models.ATSReview, error) {
+	var review models.ATSReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	if review.Score < 0 || review.Score > 100 {
+		return nil, fmt.Errorf("invalid score %d: must be between 0 and 100", review.Score)
+	}
+	for i, section := range review.Sections {
+		if section.Type == "" {
+			return nil, fmt.Errorf("invalid section %d: missing type", i+1)
+		}
+		if section.Score < 0 || section.Score > 100 {
+			return nil, fmt.Errorf("invalid section %d: score %d must be between 0 and 100", i+1, section.Score)
+		}
+	}
+	if review.Summary == "" {
+		return nil, fmt.Errorf("invalid response: no summary provided")
+	}
+
+	return &review, nil
+}
+
+// parseCompanyText parses the JSON text of a company extraction response
+func parseCompanyText(responseText string) (*models.Company, error) {
+	return parseCompanyJSON([]byte(stripCodeFence(responseText)))
+}
+
+// parseCompanyJSON decodes extracted company information, dropping a size that is not one of
+// models.CompanySizes and a founding year that cannot be right
+func parseCompanyJSON(data []byte) (*models.Company, error) {
+	var company models.Company
+	if err := json.Unmarshal(data, &company); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	if company.Name == "" {
+		return nil, fmt.Errorf("invalid response: no company name provided")
+	}
+	if !slices.Contains(models.CompanySizes, company.Size) {
+		company.Size = ""
+	}
+	if company.FoundedYear < 1600 || company.FoundedYear > time.Now().Year() {
+		company.FoundedYear = 0
+	}
+
+	return &company, nil
+}
+
+// maxLearningSuggestions bounds the learning suggestions of a skill gap analysis
+const maxLearningSuggestions = 5
+
+// parseSkillGapText parses the JSON text of a skill gap response
+func parseSkillGapText(responseText string) (*models.SkillGapAnalysis, error) {
+	return parseSkillGapJSON([]byte(stripCodeFence(responseText)))
+}
+
+// parseSkillGapJSON decodes a skill gap analysis and validates its skills and suggestions
+func parseSkillGapJSON(data []byte) (*models.SkillGapAnalysis, error) {
+	var analysis models.SkillGapAnalysis
+	if err := json.Unmarshal(data, &analysis); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	lists := []struct {
+		name  string
+		items []models.SkillGapItem
+	}{
+		{"matched", analysis.MatchedSkills},
+		{"partial", analysis.PartialSkills},
+		{"missing", analysis.MissingSkills},
+	}
+	total := 0
+	for _, list := range lists {
+		for i, item := range list.items {
+			if item.Skill == "" {
+				return nil, fmt.Errorf("invalid %s skill %d: missing skill", list.name, i+1)
+			}
+		}
+		total += len(list.items)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("invalid response: no skills classified")
+	}
+
+	if len(analysis.LearningSuggestions) > maxLearningSuggestions {
+		analysis.LearningSuggestions = analysis.LearningSuggestions[:maxLearningSuggestions]
+	}
+	for i, suggestion := range analysis.LearningSuggestions {
+		if suggestion.Skill == "" || suggestion.Suggestion == "" {
+			return nil, fmt.Errorf("invalid learning suggestion %d: missing skill or suggestion", i+1)
+		}
+		if suggestion.Priority == "" {
+			analysis.LearningSuggestions[i].Priority = "medium"
+		}
+	}
+
+	// Clients iterate the lists, so never return them as null
+	if analysis.MatchedSkills == nil {
+		analysis.MatchedSkills = []models.SkillGapItem{}
+	}
+	if analysis.PartialSkills == nil {
+		analysis.PartialSkills = []models.SkillGapItem{}
+	}
+	if analysis.MissingSkills == nil {
+		analysis.MissingSkills = []models.SkillGapItem{}
+	}
+	if analysis.LearningSuggestions == nil {
+		analysis.LearningSuggestions = []models.LearningSuggestion{}
+	}
+
+	return &analysis, nil
+}
+
+// interviewCategories are the categories an interview question can have
+var interviewCategories = map[string]bool{
+	models.InterviewCategoryTechnical:   true,
+	models.InterviewCategoryBehavioral:  true,
+	models.InterviewCategorySituational: true,
+	models.InterviewCategoryExperience:  true,
+}
+
+// parseInterviewQuestionsText parses the JSON text of an interview questions response
+func parseInterviewQuestionsText(responseText string, count int) ([]models.InterviewQuestion, error) {
+	return parseInterviewQuestionsJSON([]byte(stripCodeFence(responseText)), count)
+}
+
+// parseInterviewQuestionsJSON decodes interview questions, validates them and keeps at most count
+func parseInterviewQuestionsJSON(data []byte, count int) ([]models.InterviewQuestion, error) {
+	var response struct {
+		Questions []models.InterviewQuestion `json:"questions"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w, response: %s", err, data)
+	}
+
+	if len(response.Questions) == 0 {
+		return nil, fmt.Errorf("invalid response: no questions provided")
+	}
+	for i, question := range response.Questions {
+		if question.Question == "" || question.SuggestedAnswer == "" {
+			return nil, fmt.Errorf("invalid question %d: missing question or suggested answer", i+1)
+		}
+		category := strings.ToLower(strings.TrimSpace(question.Category))
+		if !interviewCategories[category] {
+			// An unknown category is not worth a retry; the question itself is still useful
+			category = models.InterviewCategoryExperience
+		}
+		response.Questions[i].Category = category
+	}
+
+	if count > 0 && len(response.Questions) > count {
+		response.Questions = response.Questions[:count]
+	}
+	return response.Questions, nil
+}
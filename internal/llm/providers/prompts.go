@@ -0,0 +1,229 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/llm/prompts"
+	"letraz-utils/internal/scoring"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// The prompts below are shared by every provider; each provider only differs in how it sends them
+// and reads back the response text. The prompt texts are versioned templates under
+// configs/prompts, see internal/llm/prompts.
+
+// buildJobExtractionFromDescriptionPrompt renders the prompt to extract job data from a description
+// written in language, an ISO 639-1 code or empty when unknown
+func buildJobExtractionFromDescriptionPrompt(description, language string) (string, error) {
+	return renderPrompt(prompts.JobExtractionFromDescription, prompts.JobExtractionFromDescriptionData{
+		Description: description,
+		Language:    languagePromptName(language),
+	})
+}
+
+// buildJobExtractionPrompt renders the prompt to extract job data from page content written in
+// language, an ISO 639-1 code or empty when unknown, in the template version ctx asks for
+func buildJobExtractionPrompt(ctx context.Context, content, url, language string) (string, error) {
+	return buildJobChunkExtractionPrompt(ctx, content, url, language, 1, 1)
+}
+
+// buildJobChunkExtractionPrompt renders the prompt to extract job data from part of the content of
+// a page that is extracted in parts
+func buildJobChunkExtractionPrompt(ctx context.Context, content, url, language string, part, parts int) (string, error) {
+	return renderPromptContext(ctx, prompts.JobExtraction, prompts.JobExtractionData{
+		URL:      url,
+		Content:  content,
+		Language: languagePromptName(language),
+		Part:     part,
+		Parts:    parts,
+	})
+}
+
+// buildJobConsolidationPrompt renders the prompt to merge the jobs extracted from the parts of a
+// page into one
+func buildJobConsolidationPrompt(candidates []*models.Job, url, language string) (string, error) {
+	candidatesJSON, err := json.MarshalIndent(candidates, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job candidates: %w", err)
+	}
+
+	return renderPrompt(prompts.JobConsolidation, prompts.JobConsolidationData{
+		URL:            url,
+		CandidatesJSON: string(candidatesJSON),
+		Language:       languagePromptName(language),
+	})
+}
+
+// buildCompanyExtractionPrompt renders the prompt to extract company information from the
+// cleaned pages of its website
+func buildCompanyExtractionPrompt(content, name, website string) (string, error) {
+	sizesJSON, _ := json.Marshal(models.CompanySizes)
+
+	return renderPrompt(prompts.CompanyExtraction, prompts.CompanyExtractionData{
+		Name:      name,
+		Website:   website,
+		Content:   content,
+		SizesJSON: string(sizesJSON),
+	})
+}
+
+// languagePromptName names a detected language for a prompt, e.g. "German (de)"
+func languagePromptName(language string) string {
+	if language == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", processors.LanguageName(language), language)
+}
+
+// createFilteredResumeForLLM creates a filtered version of BaseResume for LLM processing,
+// removing unnecessary fields to reduce prompt size
+func createFilteredResumeForLLM(baseResume *models.BaseResume) map[string]interface{} {
+	// Filter sections - remove id, index, resume fields and filter data objects
+	filteredSections := make([]map[string]interface{}, len(baseResume.Sections))
+	for i, section := range baseResume.Sections {
+		filteredSection := map[string]interface{}{
+			"type": section.Type,
+			"data": filterSectionData(section.Data),
+		}
+		filteredSections[i] = filteredSection
+	}
+
+	return map[string]interface{}{
+		"sections": filteredSections,
+	}
+}
+
+// filterSectionData filters data objects within resume sections,
+// removing unnecessary metadata fields
+func filterSectionData(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	// Convert to map to manipulate
+	dataMap, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+
+	// Create filtered map excluding unwanted fields
+	filtered := make(map[string]interface{})
+	for key, value := range dataMap {
+		// Skip unwanted fields
+		if key == "id" || key == "created_at" || key == "updated_at" ||
+			key == "user" || key == "resume_section" {
+			continue
+		}
+		filtered[key] = value
+	}
+
+	return filtered
+}
+
+// promptParts is a prompt split into the static instructions shared by every request and the
+// content of the request itself
+type promptParts struct {
+	system string
+	user   string
+}
+
+// text joins the parts for providers that take the prompt as a single message
+func (p promptParts) text() string {
+	if p.system == "" {
+		return p.user
+	}
+	return p.system + "\n\n" + p.user
+}
+
+// buildResumeTailoringPrompt renders the prompt to tailor the resume
+func buildResumeTailoringPrompt(baseResume *models.BaseResume, job *models.Job) (promptParts, error) {
+	// Create filtered version of the resume for LLM processing
+	filteredResume := createFilteredResumeForLLM(baseResume)
+	resumeJSON, _ := json.MarshalIndent(filteredResume, "", "  ")
+	jobJSON, _ := json.MarshalIndent(job, "", "  ")
+
+	system, err := prompts.GetGlobalLibrary().RenderSystem(prompts.ResumeTailoring)
+	if err != nil {
+		return promptParts{}, utils.NewLLMError(fmt.Sprintf("failed to render prompt: %v", err))
+	}
+	user, err := renderPrompt(prompts.ResumeTailoring, prompts.ResumeTailoringData{
+		ResumeJSON: string(resumeJSON),
+		JobJSON:    string(jobJSON),
+	})
+	if err != nil {
+		return promptParts{}, err
+	}
+	return promptParts{system: system, user: user}, nil
+}
+
+// buildResumeRefinementPrompt renders the follow-up turn that asks to revise the tailored resume
+func buildResumeRefinementPrompt(instruction string) (string, error) {
+	return renderPrompt(prompts.ResumeRefinement, prompts.ResumeRefinementData{
+		Instruction: instruction,
+	})
+}
+
+// buildATSReviewPrompt renders the prompt to assess a resume's ATS compatibility for a job
+func buildATSReviewPrompt(baseResume *models.BaseResume, job *models.Job) (string, error) {
+	filteredResume := createFilteredResumeForLLM(baseResume)
+	filteredResume["profile"] = baseResume.User.ProfileText
+	resumeJSON, _ := json.MarshalIndent(filteredResume, "", "  ")
+	jobJSON, _ := json.MarshalIndent(job, "", "  ")
+
+	return renderPrompt(prompts.ATSReview, prompts.ATSReviewData{
+		ResumeJSON: string(resumeJSON),
+		JobJSON:    string(jobJSON),
+	})
+}
+
+// buildSkillGapPrompt renders the prompt to compare the resume's skills with the job requirements
+func buildSkillGapPrompt(baseResume *models.BaseResume, job *models.Job) (string, error) {
+	filteredResume := createFilteredResumeForLLM(baseResume)
+	resumeJSON, _ := json.MarshalIndent(filteredResume, "", "  ")
+	skillsJSON, _ := json.Marshal(scoring.ResumeSkills(baseResume))
+	requirementsJSON, _ := json.MarshalIndent(job.Requirements, "", "  ")
+
+	return renderPrompt(prompts.SkillGap, prompts.SkillGapData{
+		SkillsJSON:       string(skillsJSON),
+		ResumeJSON:       string(resumeJSON),
+		JobTitle:         job.Title,
+		RequirementsJSON: string(requirementsJSON),
+	})
+}
+
+// buildInterviewQuestionsPrompt renders the prompt to predict interview questions for a job
+func buildInterviewQuestionsPrompt(baseResume *models.BaseResume, job *models.Job, count int) (string, error) {
+	filteredResume := createFilteredResumeForLLM(baseResume)
+	filteredResume["profile"] = baseResume.User.ProfileText
+	resumeJSON, _ := json.MarshalIndent(filteredResume, "", "  ")
+	jobJSON, _ := json.MarshalIndent(job, "", "  ")
+
+	return renderPrompt(prompts.InterviewQuestions, prompts.InterviewQuestionsData{
+		ResumeJSON:    string(resumeJSON),
+		JobJSON:       string(jobJSON),
+		QuestionCount: count,
+	})
+}
+
+// renderPrompt renders the selected template version of operation from the global prompt library
+func renderPrompt(operation string, data interface{}) (string, error) {
+	prompt, err := prompts.GetGlobalLibrary().Render(operation, data)
+	if err != nil {
+		return "", utils.NewLLMError(fmt.Sprintf("failed to render prompt: %v", err))
+	}
+	return prompt, nil
+}
+
+// renderPromptContext renders the template version of operation ctx asks for, or the selected
+// version, from the global prompt library
+func renderPromptContext(ctx context.Context, operation string, data interface{}) (string, error) {
+	prompt, err := prompts.GetGlobalLibrary().RenderContext(ctx, operation, data)
+	if err != nil {
+		return "", utils.NewLLMError(fmt.Sprintf("failed to render prompt: %v", err))
+	}
+	return prompt, nil
+}
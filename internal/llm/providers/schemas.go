@@ -0,0 +1,214 @@
+package providers
+
+import "letraz-utils/pkg/models"
+
+// toolSchema describes a tool the model is forced to call, so its answer arrives as JSON input
+// matching the schema instead of free text
+type toolSchema struct {
+	name        string
+	description string
+	properties  map[string]interface{}
+	required    []string
+}
+
+// maxSchemaRetries is how many times the model is re-asked after answering with input that
+// fails validation
+const maxSchemaRetries = 2
+
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+func stringListProperty(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": description,
+		"items":       map[string]interface{}{"type": "string"},
+	}
+}
+
+// jobExtractionTool records the job posting found in page content or a job description
+var jobExtractionTool = toolSchema{
+	name:        "record_job_posting",
+	description: "Record whether the content is a job posting and the structured job information extracted from it.",
+	properties: map[string]interface{}{
+		"is_job_posting": map[string]interface{}{"type": "boolean", "description": "Whether the content is a single job posting"},
+		"confidence":     map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1, "description": "Confidence that the content is a job posting"},
+		"title":          stringProperty("The job title"),
+		"job_url":        stringProperty("The URL of the job posting"),
+		"company_name":   stringProperty("The company name"),
+		"location":       stringProperty("The job location (city, state, country, or 'Remote')"),
+		"salary": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"currency": stringProperty("The salary currency, e.g. USD or INR"),
+				"max":      map[string]interface{}{"type": "number", "description": "Maximum salary, 0 if not specified"},
+				"min":      map[string]interface{}{"type": "number", "description": "Minimum salary, 0 if not specified"},
+				"period":   map[string]interface{}{"type": "string", "enum": []string{"hourly", "daily", "weekly", "monthly", "yearly", ""}, "description": "What the amounts are paid per, empty if not stated"},
+			},
+			"required": []string{"currency", "max", "min", "period"},
+		},
+		"requirements":      stringListProperty("Required qualifications, skills and experience"),
+		"description":       stringProperty("Brief job description or summary (2-3 sentences max)"),
+		"responsibilities":  stringListProperty("Key job responsibilities and duties"),
+		"benefits":          stringListProperty("Employee benefits, perks and compensation details"),
+		"original_language": stringProperty("ISO 639-1 code of the language the posting is written in, e.g. en, de or ja"),
+		"reason":            stringProperty("Why the content is not a job posting, empty for job postings"),
+	},
+	required: []string{
+		"is_job_posting", "confidence", "title", "job_url", "company_name", "location", "salary",
+		"requirements", "description", "responsibilities", "benefits", "original_language", "reason",
+	},
+}
+
+// resumeTailoringTool records the tailored resume and the suggestions for it
+var resumeTailoringTool = toolSchema{
+	name:        "record_tailored_resume",
+	description: "Record the tailored resume sections and exactly 3 improvement suggestions.",
+	properties: map[string]interface{}{
+		"tailored_resume": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"sections": map[string]interface{}{
+					"type":     "array",
+					"minItems": 1,
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"type": stringProperty("The section type, unchanged from the base resume"),
+							"data": map[string]interface{}{"type": "object", "description": "The tailored section data"},
+						},
+						"required": []string{"type", "data"},
+					},
+				},
+			},
+			"required": []string{"sections"},
+		},
+		"suggestions": map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"maxItems": 3,
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id":        stringProperty("Suggestion ID, e.g. sug_001"),
+					"type":      stringProperty("Suggestion type, e.g. experience, skills, profile or education"),
+					"priority":  map[string]interface{}{"type": "string", "enum": []string{"high", "medium", "low"}},
+					"impact":    stringProperty("How this increases the chances of being selected for the job"),
+					"section":   stringProperty("The resume section the suggestion applies to"),
+					"current":   stringProperty("Current state of the content"),
+					"suggested": stringProperty("Specific actionable improvement"),
+					"reasoning": stringProperty("Why this change helps for this specific job"),
+				},
+				"required": []string{"id", "type", "priority", "impact", "section", "current", "suggested", "reasoning"},
+			},
+		},
+	},
+	required: []string{"tailored_resume", "suggestions"},
+}
+
+// atsReviewTool records the assessment of a resume's fit for a job posting
+var atsReviewTool = toolSchema{
+	name:        "record_ats_review",
+	description: "Record the ATS compatibility assessment of the resume for the job posting.",
+	properties: map[string]interface{}{
+		"score":   map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100, "description": "Overall fit of the resume for the job"},
+		"summary": stringProperty("The strongest match and the biggest gap in two or three sentences"),
+		"sections": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type":     stringProperty("The lowercased section type, or profile for the profile text"),
+					"score":    map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100},
+					"feedback": stringProperty("Specific feedback on the section for this job"),
+				},
+				"required": []string{"type", "score", "feedback"},
+			},
+		},
+		"formatting_issues": stringListProperty("Problems that hurt ATS parsing or quick scanning"),
+	},
+	required: []string{"score", "summary", "sections", "formatting_issues"},
+}
+
+// skillGapItemSchema describes one skill of a skill gap analysis
+var skillGapItemSchema = map[string]interface{}{
+	"type": "array",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"skill":       stringProperty("The skill, technology or qualification"),
+			"requirement": stringProperty("The job requirement the skill comes from"),
+			"evidence":    stringProperty("Where the resume shows the skill, empty for missing skills"),
+			"gap":         stringProperty("What is missing for a full match, empty for matched skills"),
+		},
+		"required": []string{"skill", "requirement"},
+	},
+}
+
+// skillGapTool records how the resume's skills compare with the job requirements
+var skillGapTool = toolSchema{
+	name:        "record_skill_gap",
+	description: "Record the matched, partially matched and missing skills and the learning suggestions.",
+	properties: map[string]interface{}{
+		"matched_skills": skillGapItemSchema,
+		"partial_skills": skillGapItemSchema,
+		"missing_skills": skillGapItemSchema,
+		"learning_suggestions": map[string]interface{}{
+			"type":     "array",
+			"maxItems": 5,
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"skill":          stringProperty("The skill to learn"),
+					"priority":       map[string]interface{}{"type": "string", "enum": []string{"high", "medium", "low"}},
+					"suggestion":     stringProperty("A specific course topic, certification or project"),
+					"estimated_time": stringProperty("Rough time to close the gap, e.g. 2 weeks"),
+				},
+				"required": []string{"skill", "priority", "suggestion"},
+			},
+		},
+		"summary": stringProperty("The candidate's fit and the most important gap in two sentences"),
+	},
+	required: []string{"matched_skills", "partial_skills", "missing_skills", "learning_suggestions", "summary"},
+}
+
+// interviewQuestionsTool records the questions a candidate is likely to be asked for a job
+var interviewQuestionsTool = toolSchema{
+	name:        "record_interview_questions",
+	description: "Record the likely interview questions with suggested answers drawn from the resume.",
+	properties: map[string]interface{}{
+		"questions": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"question": stringProperty("The question as the interviewer would ask it"),
+					"category": map[string]interface{}{"type": "string", "enum": []string{
+						models.InterviewCategoryTechnical, models.InterviewCategoryBehavioral,
+						models.InterviewCategorySituational, models.InterviewCategoryExperience,
+					}},
+					"suggested_answer": stringProperty("A first-person answer built only from the resume"),
+					"reasoning":        stringProperty("Why the interviewer is likely to ask it"),
+				},
+				"required": []string{"question", "category", "suggested_answer", "reasoning"},
+			},
+		},
+	},
+	required: []string{"questions"},
+}
+
+// companyExtractionTool records the company information found on a company's website
+var companyExtractionTool = toolSchema{
+	name:        "record_company",
+	description: "Record the structured company information found on the company's website.",
+	properties: map[string]interface{}{
+		"name":         stringProperty("The company's name as it presents itself"),
+		"description":  stringProperty("What the company does, in 2-3 sentences"),
+		"industry":     stringProperty("The company's primary industry"),
+		"size":         map[string]interface{}{"type": "string", "enum": append(append([]string{}, models.CompanySizes...), ""), "description": "Employee count range, empty if the pages do not indicate it"},
+		"headquarters": stringProperty("City and country of the headquarters, empty if not stated"),
+		"founded_year": map[string]interface{}{"type": "integer", "description": "Year the company was founded, 0 if not stated"},
+	},
+	required: []string{"name", "description", "industry", "size", "headquarters", "founded_year"},
+}
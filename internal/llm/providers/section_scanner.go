@@ -0,0 +1,131 @@
+package providers
+
+import (
+	"encoding/json"
+
+	"letraz-utils/pkg/models"
+)
+
+// SectionHandler receives each tailored resume section as soon as the model has finished
+// generating it, with its position in the tailored resume
+type SectionHandler func(index int, section models.TailoredResumeSection)
+
+// toolInputListener receives the input of a tool call while the model generates it
+type toolInputListener interface {
+	// reset discards input seen so far, because the model is answering again
+	reset()
+
+	// write appends a fragment of the tool input JSON
+	write(partialJSON string)
+}
+
+// scanFrame is a JSON object or array the scanner is inside of
+type scanFrame struct {
+	array     bool
+	key       string // key of this container in its parent object
+	lastKey   string // last key read in this object
+	expectKey bool   // the next string in this object is a key
+}
+
+// sectionScanner incrementally scans the tailoring tool input and hands every completed element
+// of tailored_resume.sections to a SectionHandler, so sections reach the client before the model
+// has finished the whole resume
+type sectionScanner struct {
+	onSection SectionHandler
+	buf       []byte
+	pos       int
+	stack     []scanFrame
+	inString  bool
+	escaped   bool
+	strStart  int
+	elemStart int
+	emitted   int
+}
+
+func newSectionScanner(onSection SectionHandler) *sectionScanner {
+	return &sectionScanner{onSection: onSection}
+}
+
+func (s *sectionScanner) reset() {
+	*s = sectionScanner{onSection: s.onSection}
+}
+
+func (s *sectionScanner) write(partialJSON string) {
+	s.buf = append(s.buf, partialJSON...)
+	for ; s.pos < len(s.buf); s.pos++ {
+		s.scan(s.buf[s.pos])
+	}
+}
+
+// scan advances the scanner over the byte at s.pos
+func (s *sectionScanner) scan(c byte) {
+	if s.inString {
+		switch {
+		case s.escaped:
+			s.escaped = false
+		case c == '\\':
+			s.escaped = true
+		case c == '"':
+			s.inString = false
+			if top := s.top(); top != nil && !top.array && top.expectKey {
+				top.lastKey = string(s.buf[s.strStart+1 : s.pos])
+			}
+		}
+		return
+	}
+
+	switch c {
+	case '"':
+		s.inString = true
+		s.strStart = s.pos
+	case ':':
+		if top := s.top(); top != nil && !top.array {
+			top.expectKey = false
+		}
+	case ',':
+		if top := s.top(); top != nil && !top.array {
+			top.expectKey = true
+		}
+	case '{', '[':
+		frame := scanFrame{array: c == '[', expectKey: c == '{'}
+		if top := s.top(); top != nil && !top.array {
+			frame.key = top.lastKey
+		}
+		if c == '{' && s.inSections() {
+			s.elemStart = s.pos
+		}
+		s.stack = append(s.stack, frame)
+	case '}', ']':
+		if len(s.stack) == 0 {
+			return
+		}
+		s.stack = s.stack[:len(s.stack)-1]
+		if c == '}' && s.inSections() {
+			s.emit(s.buf[s.elemStart : s.pos+1])
+		}
+	}
+}
+
+// inSections reports whether the scanner is directly inside the tailored_resume.sections array
+func (s *sectionScanner) inSections() bool {
+	return len(s.stack) == 3 &&
+		s.stack[1].key == "tailored_resume" &&
+		s.stack[2].array && s.stack[2].key == "sections"
+}
+
+func (s *sectionScanner) top() *scanFrame {
+	if len(s.stack) == 0 {
+		return nil
+	}
+	return &s.stack[len(s.stack)-1]
+}
+
+// emit decodes a completed section and hands it to the handler
+func (s *sectionScanner) emit(raw []byte) {
+	var section models.TailoredResumeSection
+	if err := json.Unmarshal(raw, &section); err != nil {
+		return
+	}
+	s.onSection(s.emitted, section)
+	s.emitted++
+}
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"context"
+	"sync"
+
+	"letraz-utils/internal/llm/cost"
+	"letraz-utils/internal/quota"
+)
+
+// UsageStats holds cumulative token usage and response quality counters for a provider
+type UsageStats struct {
+	InputTokens   int64
+	OutputTokens  int64
+	CostUSD       float64 // estimated from the model list price
+	Responses     int64   // successful API responses that were handed to a parser
+	ParseFailures int64   // responses that could not be parsed into the expected structure
+
+	// Prompt caching counters of requests sent with a cacheable prompt
+	CacheHits        int64 // requests that read their prompt prefix from the cache
+	CacheMisses      int64 // requests that had to write the prompt prefix to the cache
+	CacheReadTokens  int64
+	CacheWriteTokens int64
+}
+
+// usageTracker accumulates usage statistics across concurrent requests
+type usageTracker struct {
+	mu    sync.Mutex
+	stats UsageStats
+}
+
+// record records token usage for a successful API response and attributes the tokens and their
+// estimated cost to the calling API key, task and operation
+func (t *usageTracker) record(ctx context.Context, provider, model string, inputTokens, outputTokens int64) {
+	quota.RecordLLMTokens(ctx, inputTokens+outputTokens)
+	costUSD := cost.Record(ctx, provider, model, inputTokens, outputTokens)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.Responses++
+	t.stats.InputTokens += inputTokens
+	t.stats.OutputTokens += outputTokens
+	t.stats.CostUSD += costUSD
+}
+
+// recordPromptCache records the prompt cache usage of a request sent with a cacheable prompt
+func (t *usageTracker) recordPromptCache(readTokens, writeTokens int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if readTokens > 0 {
+		t.stats.CacheHits++
+	} else {
+		t.stats.CacheMisses++
+	}
+	t.stats.CacheReadTokens += readTokens
+	t.stats.CacheWriteTokens += writeTokens
+}
+
+// recordParseFailure records a response that failed to parse
+func (t *usageTracker) recordParseFailure() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.ParseFailures++
+}
+
+// snapshot returns a copy of the current statistics
+func (t *usageTracker) snapshot() UsageStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stats
+}
@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// Conversation entry types stored in a resume's conversation thread
+const (
+	EntryTypeBaseResume        = "base_resume"
+	EntryTypeJobContext        = "job_context"
+	EntryTypeTailorResponse    = "tailor_response"
+	EntryTypeRefineInstruction = "refine_instruction"
+	EntryTypeRefineResponse    = "refine_response"
+)
+
+// RefinementConversation rebuilds the conversation of the latest tailoring in a thread: the base
+// resume and job the resume was tailored from, followed by every response and follow-up
+// instruction since. It fails when the thread holds no complete tailoring, which happens when
+// the resume was never tailored or the opening entries were trimmed from a long thread.
+func RefinementConversation(history *utils.ConversationHistory) (*models.BaseResume, []models.ConversationTurn, error) {
+	start := -1
+	for i, entry := range history.Entries {
+		if entryType(entry) == EntryTypeBaseResume {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, nil, utils.NewValidationError("conversation history holds no base resume; tailor the resume before refining it")
+	}
+
+	var baseResume models.BaseResume
+	if err := json.Unmarshal([]byte(history.Entries[start].Content), &baseResume); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode base resume from conversation history: %w", err)
+	}
+
+	var (
+		turns     []models.ConversationTurn
+		responded bool
+	)
+	for _, entry := range history.Entries[start:] {
+		switch entryType(entry) {
+		case EntryTypeBaseResume:
+			turns = append(turns, models.ConversationTurn{Role: "user", Content: "This is my base resume:\n" + entry.Content})
+		case EntryTypeJobContext:
+			turns = append(turns, models.ConversationTurn{Role: "user", Content: "Tailor it for this job posting:\n" + entry.Content})
+		case EntryTypeRefineInstruction:
+			turns = append(turns, models.ConversationTurn{Role: "user", Content: entry.Content})
+		case EntryTypeTailorResponse, EntryTypeRefineResponse:
+			turns = append(turns, models.ConversationTurn{Role: "assistant", Content: entry.Content})
+			responded = true
+		}
+	}
+	if !responded {
+		return nil, nil, utils.NewValidationError("the latest tailoring of this resume has not completed yet")
+	}
+
+	// An instruction whose response was never stored is dropped so the conversation ends on the
+	// latest tailored resume
+	for len(turns) > 0 && turns[len(turns)-1].Role != "assistant" {
+		turns = turns[:len(turns)-1]
+	}
+
+	return &baseResume, turns, nil
+}
+
+// entryType returns the type recorded in a conversation entry's metadata
+func entryType(entry utils.ConversationEntry) string {
+	entryType, _ := entry.Metadata["type"].(string)
+	return entryType
+}
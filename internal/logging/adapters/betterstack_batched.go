@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/metrics"
 )
 
 // BetterstackBatchedAdapter implements batched logging to Betterstack
@@ -86,8 +87,7 @@ type BatchedAdapterStats struct {
 	bufferOverflows     int64
 	immediateFlushes    int64
 	timerFlushes        int64
-	averageResponseTime time.Duration
-	responseTimeCount   int64
+	responseTime        *metrics.Histogram
 }
 
 // NewBetterstackBatchedAdapter creates a new batched Betterstack adapter
@@ -179,7 +179,7 @@ func NewBetterstackBatchedAdapter(name string, config BetterstackBatchedConfig)
 		circuitBreaker: circuitBreaker,
 		buffer:         buffer,
 		healthy:        true,
-		stats:          &BatchedAdapterStats{},
+		stats:          &BatchedAdapterStats{responseTime: metrics.NewLatencyHistogram()},
 		stopCh:         make(chan struct{}),
 	}
 
@@ -536,11 +536,7 @@ func (s *BatchedAdapterStats) recordBatch(batchSize int, duration time.Duration,
 		s.failedRequests++
 	}
 
-	// Update average response time
-	s.averageResponseTime = time.Duration(
-		(int64(s.averageResponseTime)*s.responseTimeCount + int64(duration)) / (s.responseTimeCount + 1),
-	)
-	s.responseTimeCount++
+	s.responseTime.ObserveDuration(duration)
 
 	s.totalRequests++
 }
@@ -588,6 +584,6 @@ func (s *BatchedAdapterStats) GetStats() map[string]interface{} {
 		"immediate_flushes":     s.immediateFlushes,
 		"timer_flushes":         s.timerFlushes,
 		"last_batch_time":       s.lastBatchTime,
-		"average_response_time": s.averageResponseTime.String(),
+		"response_time_seconds": s.responseTime.Snapshot(),
 	}
 }
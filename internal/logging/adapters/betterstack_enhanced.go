@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/metrics"
 )
 
 // BetterstackEnhancedAdapter implements the LogAdapter interface with circuit breaker and retry logic
@@ -84,8 +85,7 @@ type AdapterStats struct {
 	failedRequests      int64
 	circuitBreakerTrips int64
 	lastRequestTime     time.Time
-	averageResponseTime time.Duration
-	responseTimeCount   int64
+	responseTime        *metrics.Histogram
 }
 
 // NewBetterstackEnhancedAdapter creates a new enhanced Betterstack adapter
@@ -161,7 +161,7 @@ func NewBetterstackEnhancedAdapter(name string, config BetterstackEnhancedConfig
 		httpClient:     httpClient,
 		circuitBreaker: circuitBreaker,
 		healthy:        true,
-		stats:          &AdapterStats{},
+		stats:          &AdapterStats{responseTime: metrics.NewLatencyHistogram()},
 	}
 
 	return adapter, nil
@@ -478,11 +478,7 @@ func (s *AdapterStats) recordRequest(duration time.Duration, success bool) {
 		s.failedRequests++
 	}
 
-	// Update average response time
-	s.averageResponseTime = time.Duration(
-		(int64(s.averageResponseTime)*s.responseTimeCount + int64(duration)) / (s.responseTimeCount + 1),
-	)
-	s.responseTimeCount++
+	s.responseTime.ObserveDuration(duration)
 }
 
 // recordCircuitBreakerTrip records a circuit breaker trip
@@ -509,7 +505,7 @@ func (s *AdapterStats) GetStats() map[string]interface{} {
 		"success_rate":          successRate,
 		"circuit_breaker_trips": s.circuitBreakerTrips,
 		"last_request_time":     s.lastRequestTime,
-		"average_response_time": s.averageResponseTime.String(),
+		"response_time_seconds": s.responseTime.Snapshot(),
 	}
 }
 
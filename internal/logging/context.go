@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"context"
+)
+
+// Standard correlation fields carried by request-scoped loggers
+const (
+	FieldRequestID = "request_id"
+	FieldProcessID = "process_id"
+	FieldTaskType  = "task_type"
+)
+
+// loggerContextKey is the context key under which a request-scoped logger is stored
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx that carries the given logger
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, falling back to the global logger
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return GetGlobalLogger()
+}
+
+// ContextWithFields derives a logger from ctx with the given fields and returns a context carrying it
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	return NewContext(ctx, FromContext(ctx).WithFields(fields))
+}
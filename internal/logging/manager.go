@@ -133,7 +133,7 @@ func CloseLogging() error {
 
 // LogWithRequestID creates a logger with request ID context (compatibility function)
 func LogWithRequestID(requestID string) Logger {
-	return GetGlobalLogger().WithField("request_id", requestID)
+	return GetGlobalLogger().WithField(FieldRequestID, requestID)
 }
 
 // Legacy compatibility functions to maintain backward compatibility
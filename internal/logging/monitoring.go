@@ -2,20 +2,64 @@ package logging
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"letraz-utils/internal/config"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/metrics"
 )
 
+// MonitoredComponent is anything that can be health checked by the monitoring service.
+// Logging adapters satisfy it directly; other components such as the LLM manager can be
+// registered too, and may expose GetStats() map[string]interface{} for metrics collection.
+type MonitoredComponent interface {
+	Name() string
+	Health() error
+}
+
+// StatsProvider is implemented by components that expose operational statistics, such as the
+// worker pool, browser pool and task manager. Providers that also implement Health() error or
+// IsHealthy() bool are health checked alongside the logging adapters.
+type StatsProvider interface {
+	GetStats() map[string]interface{}
+}
+
+// statsComponent adapts a named StatsProvider to a MonitoredComponent
+type statsComponent struct {
+	StatsProvider
+	name string
+}
+
+// Name returns the name the provider was registered under
+func (sc *statsComponent) Name() string {
+	return sc.name
+}
+
+// Health delegates to the provider's health check when it has one
+func (sc *statsComponent) Health() error {
+	switch provider := sc.StatsProvider.(type) {
+	case interface{ Health() error }:
+		return provider.Health()
+	case interface{ IsHealthy() bool }:
+		if !provider.IsHealthy() {
+			return fmt.Errorf("%s is unhealthy", sc.name)
+		}
+	}
+	return nil
+}
+
 // MonitoringService manages health monitoring and metrics collection for logging adapters
+// and other registered components
 type MonitoringService struct {
 	logger           Logger
-	adapters         map[string]types.LogAdapter
+	adapters         map[string]MonitoredComponent
 	healthCheckers   map[string]*AdapterHealthChecker
 	metricsCollector *MetricsCollector
 	alertManager     *AlertManager
@@ -28,21 +72,44 @@ type MonitoringService struct {
 // MonitoringConfig configures the monitoring service
 type MonitoringConfig struct {
 	Enabled             bool          `yaml:"enabled"`
+	BindAddress         string        `yaml:"bind_address"` // empty binds all interfaces
 	Port                int           `yaml:"port"`
+	AuthToken           string        `yaml:"auth_token"` // optional bearer token required on every endpoint
 	HealthCheckInterval time.Duration `yaml:"health_check_interval"`
 	MetricsInterval     time.Duration `yaml:"metrics_interval"`
 	RetentionPeriod     time.Duration `yaml:"retention_period"`
 	AlertThresholds     struct {
-		ErrorRate      float64       `yaml:"error_rate"`      // Error rate threshold (%)
-		ResponseTime   time.Duration `yaml:"response_time"`   // Response time threshold
-		CircuitBreaker int           `yaml:"circuit_breaker"` // Circuit breaker trips threshold
+		ErrorRate        float64       `yaml:"error_rate"`         // Error rate threshold (%)
+		ResponseTime     time.Duration `yaml:"response_time"`      // Response time threshold
+		CircuitBreaker   int           `yaml:"circuit_breaker"`    // Circuit breaker trips threshold
+		ParseFailureRate float64       `yaml:"parse_failure_rate"` // LLM response parse failure rate threshold (%)
+		FirecrawlCredits int           `yaml:"firecrawl_credits"`  // Remaining Firecrawl credits threshold; 0 disables
 	} `yaml:"alert_thresholds"`
 }
 
+// NewMonitoringConfig builds the monitoring configuration from the application config
+func NewMonitoringConfig(cfg *config.Config) MonitoringConfig {
+	monitoringConfig := MonitoringConfig{
+		Enabled:             cfg.Monitoring.Enabled,
+		BindAddress:         cfg.Monitoring.BindAddress,
+		Port:                cfg.Monitoring.Port,
+		AuthToken:           cfg.Monitoring.AuthToken,
+		HealthCheckInterval: cfg.Monitoring.HealthCheckInterval,
+		MetricsInterval:     cfg.Monitoring.MetricsInterval,
+		RetentionPeriod:     cfg.Monitoring.RetentionPeriod,
+	}
+	monitoringConfig.AlertThresholds.ErrorRate = cfg.Monitoring.AlertThresholds.ErrorRate
+	monitoringConfig.AlertThresholds.ResponseTime = cfg.Monitoring.AlertThresholds.ResponseTime
+	monitoringConfig.AlertThresholds.CircuitBreaker = cfg.Monitoring.AlertThresholds.CircuitBreaker
+	monitoringConfig.AlertThresholds.ParseFailureRate = cfg.Monitoring.AlertThresholds.ParseFailureRate
+	monitoringConfig.AlertThresholds.FirecrawlCredits = cfg.Monitoring.AlertThresholds.FirecrawlCredits
+	return monitoringConfig
+}
+
 // AdapterHealthChecker monitors the health of a specific adapter
 type AdapterHealthChecker struct {
 	name                string
-	adapter             types.LogAdapter
+	adapter             MonitoredComponent
 	lastHealthCheck     time.Time
 	isHealthy           bool
 	consecutiveFailures int
@@ -66,15 +133,15 @@ type MetricsCollector struct {
 
 // AdapterMetrics stores metrics for a specific adapter
 type AdapterMetrics struct {
-	Name                string                 `json:"name"`
-	TotalRequests       int64                  `json:"total_requests"`
-	SuccessfulRequests  int64                  `json:"successful_requests"`
-	FailedRequests      int64                  `json:"failed_requests"`
-	ErrorRate           float64                `json:"error_rate"`
-	AverageResponseTime time.Duration          `json:"average_response_time"`
-	CircuitBreakerTrips int64                  `json:"circuit_breaker_trips"`
-	LastActivity        time.Time              `json:"last_activity"`
-	CustomMetrics       map[string]interface{} `json:"custom_metrics,omitempty"`
+	Name                string                    `json:"name"`
+	TotalRequests       int64                     `json:"total_requests"`
+	SuccessfulRequests  int64                     `json:"successful_requests"`
+	FailedRequests      int64                     `json:"failed_requests"`
+	ErrorRate           float64                   `json:"error_rate"`
+	ResponseTime        metrics.HistogramSnapshot `json:"response_time_seconds"`
+	CircuitBreakerTrips int64                     `json:"circuit_breaker_trips"`
+	LastActivity        time.Time                 `json:"last_activity"`
+	CustomMetrics       map[string]interface{}    `json:"custom_metrics,omitempty"`
 }
 
 // AlertManager manages alerts for adapter health issues
@@ -106,6 +173,8 @@ const (
 	AlertTypeErrorRate      AlertType = "error_rate"
 	AlertTypeResponseTime   AlertType = "response_time"
 	AlertTypeCircuitBreaker AlertType = "circuit_breaker"
+	AlertTypeParseFailure   AlertType = "parse_failure"
+	AlertTypeLowCredits     AlertType = "low_credits"
 )
 
 // AlertSeverity represents the severity of an alert
@@ -146,10 +215,13 @@ func NewMonitoringService(logger Logger, config MonitoringConfig) *MonitoringSer
 	if config.AlertThresholds.CircuitBreaker == 0 {
 		config.AlertThresholds.CircuitBreaker = 5
 	}
+	if config.AlertThresholds.ParseFailureRate == 0 {
+		config.AlertThresholds.ParseFailureRate = 10.0 // 10% parse failure rate
+	}
 
 	return &MonitoringService{
 		logger:         logger,
-		adapters:       make(map[string]types.LogAdapter),
+		adapters:       make(map[string]MonitoredComponent),
 		healthCheckers: make(map[string]*AdapterHealthChecker),
 		metricsCollector: &MetricsCollector{
 			metrics: make(map[string]*AdapterMetrics),
@@ -181,7 +253,8 @@ func (ms *MonitoringService) Start() error {
 	go ms.alertProcessingLoop()
 
 	ms.logger.Info("Monitoring service started", map[string]interface{}{
-		"port":                  ms.config.Port,
+		"address":               ms.httpServer.Addr,
+		"auth_enabled":          ms.config.AuthToken != "",
 		"health_check_interval": ms.config.HealthCheckInterval.String(),
 		"metrics_interval":      ms.config.MetricsInterval.String(),
 	})
@@ -205,14 +278,19 @@ func (ms *MonitoringService) Stop() error {
 
 // AddAdapter adds an adapter to monitoring
 func (ms *MonitoringService) AddAdapter(adapter types.LogAdapter) {
+	ms.AddComponent(adapter)
+}
+
+// AddComponent adds any health-checkable component to monitoring
+func (ms *MonitoringService) AddComponent(component MonitoredComponent) {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	name := adapter.Name()
-	ms.adapters[name] = adapter
+	name := component.Name()
+	ms.adapters[name] = component
 	ms.healthCheckers[name] = &AdapterHealthChecker{
 		name:          name,
-		adapter:       adapter,
+		adapter:       component,
 		isHealthy:     true,
 		healthHistory: make([]HealthCheckResult, 0),
 	}
@@ -226,6 +304,11 @@ func (ms *MonitoringService) AddAdapter(adapter types.LogAdapter) {
 	})
 }
 
+// AddStatsProvider registers a non-logging component under the given name
+func (ms *MonitoringService) AddStatsProvider(name string, provider StatsProvider) {
+	ms.AddComponent(&statsComponent{StatsProvider: provider, name: name})
+}
+
 // RemoveAdapter removes an adapter from monitoring
 func (ms *MonitoringService) RemoveAdapter(name string) {
 	ms.mu.Lock()
@@ -305,7 +388,7 @@ func (ms *MonitoringService) healthCheckLoop() {
 // performHealthChecks performs health checks on all adapters
 func (ms *MonitoringService) performHealthChecks() {
 	ms.mu.RLock()
-	adapters := make(map[string]types.LogAdapter)
+	adapters := make(map[string]MonitoredComponent)
 	for name, adapter := range ms.adapters {
 		adapters[name] = adapter
 	}
@@ -317,7 +400,7 @@ func (ms *MonitoringService) performHealthChecks() {
 }
 
 // performHealthCheck performs a health check on a specific adapter
-func (ms *MonitoringService) performHealthCheck(name string, adapter types.LogAdapter) {
+func (ms *MonitoringService) performHealthCheck(name string, adapter MonitoredComponent) {
 	ms.mu.RLock()
 	checker, exists := ms.healthCheckers[name]
 	ms.mu.RUnlock()
@@ -389,7 +472,7 @@ func (ms *MonitoringService) metricsCollectionLoop() {
 // collectMetrics collects metrics from all adapters
 func (ms *MonitoringService) collectMetrics() {
 	ms.mu.RLock()
-	adapters := make(map[string]types.LogAdapter)
+	adapters := make(map[string]MonitoredComponent)
 	for name, adapter := range ms.adapters {
 		adapters[name] = adapter
 	}
@@ -401,17 +484,17 @@ func (ms *MonitoringService) collectMetrics() {
 }
 
 // collectAdapterMetrics collects metrics from a specific adapter
-func (ms *MonitoringService) collectAdapterMetrics(name string, adapter types.LogAdapter) {
+func (ms *MonitoringService) collectAdapterMetrics(name string, adapter MonitoredComponent) {
 	// Get adapter statistics if available
 	var stats map[string]interface{}
-	if statsProvider, ok := adapter.(interface{ GetStats() map[string]interface{} }); ok {
+	if statsProvider, ok := adapter.(StatsProvider); ok {
 		stats = statsProvider.GetStats()
 	}
 
 	ms.metricsCollector.mu.Lock()
 	defer ms.metricsCollector.mu.Unlock()
 
-	metrics, exists := ms.metricsCollector.metrics[name]
+	adapterMetrics, exists := ms.metricsCollector.metrics[name]
 	if !exists {
 		return
 	}
@@ -419,63 +502,85 @@ func (ms *MonitoringService) collectAdapterMetrics(name string, adapter types.Lo
 	// Update metrics from stats
 	if stats != nil {
 		if totalReqs, ok := stats["total_requests"].(int64); ok {
-			metrics.TotalRequests = totalReqs
+			adapterMetrics.TotalRequests = totalReqs
 		}
 		if successReqs, ok := stats["successful_requests"].(int64); ok {
-			metrics.SuccessfulRequests = successReqs
+			adapterMetrics.SuccessfulRequests = successReqs
 		}
 		if failedReqs, ok := stats["failed_requests"].(int64); ok {
-			metrics.FailedRequests = failedReqs
+			adapterMetrics.FailedRequests = failedReqs
 		}
-		if avgResponseTime, ok := stats["average_response_time"].(time.Duration); ok {
-			metrics.AverageResponseTime = avgResponseTime
+		if responseTime, ok := stats["response_time_seconds"].(metrics.HistogramSnapshot); ok {
+			adapterMetrics.ResponseTime = responseTime
 		}
 		if cbTrips, ok := stats["circuit_breaker_trips"].(int64); ok {
-			metrics.CircuitBreakerTrips = cbTrips
+			adapterMetrics.CircuitBreakerTrips = cbTrips
 		}
 
 		// Calculate error rate
-		if metrics.TotalRequests > 0 {
-			metrics.ErrorRate = float64(metrics.FailedRequests) / float64(metrics.TotalRequests) * 100
+		if adapterMetrics.TotalRequests > 0 {
+			adapterMetrics.ErrorRate = float64(adapterMetrics.FailedRequests) / float64(adapterMetrics.TotalRequests) * 100
 		}
 
 		// Store custom metrics
 		for key, value := range stats {
 			if key != "total_requests" && key != "successful_requests" &&
-				key != "failed_requests" && key != "average_response_time" &&
+				key != "failed_requests" && key != "response_time_seconds" &&
 				key != "circuit_breaker_trips" {
-				metrics.CustomMetrics[key] = value
+				adapterMetrics.CustomMetrics[key] = value
 			}
 		}
 	}
 
-	metrics.LastActivity = time.Now()
+	adapterMetrics.LastActivity = time.Now()
 
 	// Check alert thresholds
-	ms.checkAlertThresholds(name, metrics)
+	ms.checkAlertThresholds(name, adapterMetrics)
 }
 
 // checkAlertThresholds checks if any alert thresholds are exceeded
-func (ms *MonitoringService) checkAlertThresholds(name string, metrics *AdapterMetrics) {
+func (ms *MonitoringService) checkAlertThresholds(name string, adapterMetrics *AdapterMetrics) {
 	// Check error rate threshold
-	if metrics.ErrorRate > ms.config.AlertThresholds.ErrorRate {
+	if adapterMetrics.ErrorRate > ms.config.AlertThresholds.ErrorRate {
 		ms.alertManager.createAlert(name, AlertTypeErrorRate, AlertSeverityWarning,
 			fmt.Sprintf("High error rate: %.2f%% (threshold: %.2f%%)",
-				metrics.ErrorRate, ms.config.AlertThresholds.ErrorRate))
+				adapterMetrics.ErrorRate, ms.config.AlertThresholds.ErrorRate))
 	}
 
-	// Check response time threshold
-	if metrics.AverageResponseTime > ms.config.AlertThresholds.ResponseTime {
+	// Check p95 response time threshold
+	if p95 := metrics.Duration(adapterMetrics.ResponseTime.P95); p95 > ms.config.AlertThresholds.ResponseTime {
 		ms.alertManager.createAlert(name, AlertTypeResponseTime, AlertSeverityWarning,
-			fmt.Sprintf("High response time: %v (threshold: %v)",
-				metrics.AverageResponseTime, ms.config.AlertThresholds.ResponseTime))
+			fmt.Sprintf("High p95 response time: %v (threshold: %v)",
+				p95, ms.config.AlertThresholds.ResponseTime))
 	}
 
 	// Check circuit breaker trips threshold
-	if metrics.CircuitBreakerTrips > int64(ms.config.AlertThresholds.CircuitBreaker) {
+	if adapterMetrics.CircuitBreakerTrips > int64(ms.config.AlertThresholds.CircuitBreaker) {
 		ms.alertManager.createAlert(name, AlertTypeCircuitBreaker, AlertSeverityCritical,
 			fmt.Sprintf("High circuit breaker trips: %d (threshold: %d)",
-				metrics.CircuitBreakerTrips, ms.config.AlertThresholds.CircuitBreaker))
+				adapterMetrics.CircuitBreakerTrips, ms.config.AlertThresholds.CircuitBreaker))
+	}
+
+	// Check LLM response parse failure rate threshold
+	if parseFailureRate, ok := adapterMetrics.CustomMetrics["parse_failure_rate"].(float64); ok {
+		if parseFailureRate > ms.config.AlertThresholds.ParseFailureRate {
+			ms.alertManager.createAlert(name, AlertTypeParseFailure, AlertSeverityWarning,
+				fmt.Sprintf("High parse failure rate: %.2f%% (threshold: %.2f%%)",
+					parseFailureRate, ms.config.AlertThresholds.ParseFailureRate))
+		} else {
+			ms.alertManager.resolveAlert(name, AlertTypeParseFailure)
+		}
+	}
+
+	// Check remaining Firecrawl credits threshold
+	if remaining, ok := adapterMetrics.CustomMetrics["remaining_credits"].(int64); ok && ms.config.AlertThresholds.FirecrawlCredits > 0 {
+		if remaining < int64(ms.config.AlertThresholds.FirecrawlCredits) {
+			ms.alertManager.createAlert(name, AlertTypeLowCredits, AlertSeverityWarning,
+				fmt.Sprintf("Low remaining credits: %d (threshold: %d)",
+					remaining, ms.config.AlertThresholds.FirecrawlCredits))
+		} else {
+			ms.alertManager.resolveAlert(name, AlertTypeLowCredits)
+		}
 	}
 }
 
@@ -529,8 +634,8 @@ func (ms *MonitoringService) startHTTPServer() error {
 	mux.HandleFunc("/alerts", ms.handleAlerts)
 
 	ms.httpServer = &http.Server{
-		Addr:    fmt.Sprintf(":%d", ms.config.Port),
-		Handler: mux,
+		Addr:    fmt.Sprintf("%s:%d", ms.config.BindAddress, ms.config.Port),
+		Handler: ms.authMiddleware(mux),
 	}
 
 	errCh := make(chan error, 1)
@@ -559,6 +664,24 @@ func (ms *MonitoringService) startHTTPServer() error {
 
 // HTTP handlers
 
+// authMiddleware requires a matching bearer token when one is configured
+func (ms *MonitoringService) authMiddleware(next http.Handler) http.Handler {
+	if ms.config.AuthToken == "" {
+		return next
+	}
+
+	expected := []byte(ms.config.AuthToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="monitoring"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // handleHealth handles the overall health endpoint
 func (ms *MonitoringService) handleHealth(w http.ResponseWriter, r *http.Request) {
 	health := ms.GetOverallHealth()
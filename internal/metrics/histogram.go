@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metric naming conventions used across the service:
+//   - names are snake_case and end with their unit, e.g. acquisition_time_seconds
+//   - monotonically increasing counters end with _total
+//   - latency distributions are exported as HistogramSnapshot values rather than averages
+
+// DefaultLatencyBuckets are the upper bounds (in seconds) used for latency histograms.
+// They span fast in-process operations up to slow LLM calls and page loads.
+var DefaultLatencyBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120,
+}
+
+// Histogram is a thread-safe cumulative histogram with fixed bucket boundaries
+type Histogram struct {
+	mu     sync.RWMutex
+	bounds []float64
+	counts []int64 // per bucket, last entry is the +Inf bucket
+	count  int64
+	sum    float64
+}
+
+// Bucket is a cumulative histogram bucket
+type Bucket struct {
+	UpperBound string `json:"le"` // formatted bound, "+Inf" for the overflow bucket
+	Count      int64  `json:"count"`
+}
+
+// HistogramSnapshot is a point-in-time, JSON-serializable view of a histogram
+type HistogramSnapshot struct {
+	Count   int64    `json:"count"`
+	Sum     float64  `json:"sum"`
+	P50     float64  `json:"p50"`
+	P95     float64  `json:"p95"`
+	P99     float64  `json:"p99"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// NewHistogram creates a histogram with the given bucket upper bounds
+func NewHistogram(bounds []float64) *Histogram {
+	sorted := make([]float64, len(bounds))
+	copy(sorted, bounds)
+	sort.Float64s(sorted)
+
+	return &Histogram{
+		bounds: sorted,
+		counts: make([]int64, len(sorted)+1),
+	}
+}
+
+// NewLatencyHistogram creates a histogram using DefaultLatencyBuckets
+func NewLatencyHistogram() *Histogram {
+	return NewHistogram(DefaultLatencyBuckets)
+}
+
+// Observe records a single value
+func (h *Histogram) Observe(value float64) {
+	idx := sort.SearchFloat64s(h.bounds, value)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.counts[idx]++
+	h.count++
+	h.sum += value
+}
+
+// ObserveDuration records a duration in seconds
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// Snapshot returns the current state of the histogram including estimated percentiles
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	snapshot := HistogramSnapshot{
+		Count:   h.count,
+		Sum:     h.sum,
+		Buckets: make([]Bucket, 0, len(h.counts)),
+	}
+
+	var cumulative int64
+	for i, count := range h.counts {
+		cumulative += count
+		bound := "+Inf"
+		if i < len(h.bounds) {
+			bound = strconv.FormatFloat(h.bounds[i], 'g', -1, 64)
+		}
+		snapshot.Buckets = append(snapshot.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+	}
+
+	snapshot.P50 = h.quantile(0.50)
+	snapshot.P95 = h.quantile(0.95)
+	snapshot.P99 = h.quantile(0.99)
+
+	return snapshot
+}
+
+// quantile estimates the q-th quantile by linear interpolation within the matching bucket.
+// Callers must hold the read lock.
+func (h *Histogram) quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := q * float64(h.count)
+	var cumulative int64
+	for i, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+		if float64(cumulative+count) >= rank {
+			// Values beyond the largest bound are reported as that bound
+			if i == len(h.bounds) {
+				if len(h.bounds) == 0 {
+					return h.sum / float64(h.count)
+				}
+				return h.bounds[len(h.bounds)-1]
+			}
+
+			lower := 0.0
+			if i > 0 {
+				lower = h.bounds[i-1]
+			}
+			upper := h.bounds[i]
+			fraction := (rank - float64(cumulative)) / float64(count)
+			return lower + (upper-lower)*math.Max(0, math.Min(1, fraction))
+		}
+		cumulative += count
+	}
+
+	return h.bounds[len(h.bounds)-1]
+}
+
+// Duration converts a value in seconds, such as a percentile, back to a time.Duration
+func Duration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
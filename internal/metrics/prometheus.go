@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector contributes samples to the Prometheus text exposition served at /metrics
+type Collector interface {
+	CollectPrometheus(w *PrometheusWriter)
+}
+
+// CollectorFunc adapts a function to the Collector interface
+type CollectorFunc func(w *PrometheusWriter)
+
+// CollectPrometheus calls f(w)
+func (f CollectorFunc) CollectPrometheus(w *PrometheusWriter) {
+	f(w)
+}
+
+var registry = struct {
+	mu         sync.RWMutex
+	names      []string
+	collectors map[string]Collector
+}{collectors: make(map[string]Collector)}
+
+// RegisterCollector adds a collector to the exposition; registering a name again replaces it
+func RegisterCollector(name string, collector Collector) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, exists := registry.collectors[name]; !exists {
+		registry.names = append(registry.names, name)
+	}
+	registry.collectors[name] = collector
+}
+
+// WritePrometheus writes all registered collectors in Prometheus text format (version 0.0.4)
+func WritePrometheus(out io.Writer) error {
+	registry.mu.RLock()
+	collectors := make([]Collector, 0, len(registry.names))
+	for _, name := range registry.names {
+		collectors = append(collectors, registry.collectors[name])
+	}
+	registry.mu.RUnlock()
+
+	w := NewPrometheusWriter()
+	for _, collector := range collectors {
+		collector.CollectPrometheus(w)
+	}
+	_, err := out.Write(w.Bytes())
+	return err
+}
+
+// PrometheusContentType is the content type of the text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Label is a Prometheus label pair
+type Label struct {
+	Name  string
+	Value string
+}
+
+// L creates a label pair
+func L(name, value string) Label {
+	return Label{Name: name, Value: value}
+}
+
+// PrometheusWriter renders samples in the Prometheus text format. HELP and TYPE lines are written
+// the first time a metric name is seen, so all samples of a metric must be written together.
+type PrometheusWriter struct {
+	buf      bytes.Buffer
+	declared map[string]bool
+}
+
+// NewPrometheusWriter creates an empty writer
+func NewPrometheusWriter() *PrometheusWriter {
+	return &PrometheusWriter{declared: make(map[string]bool)}
+}
+
+// Bytes returns the rendered exposition
+func (w *PrometheusWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// Counter writes a monotonically increasing counter sample; name should end in _total
+func (w *PrometheusWriter) Counter(name, help string, value float64, labels ...Label) {
+	w.declare(name, help, "counter")
+	w.sample(name, value, labels)
+}
+
+// Gauge writes a gauge sample
+func (w *PrometheusWriter) Gauge(name, help string, value float64, labels ...Label) {
+	w.declare(name, help, "gauge")
+	w.sample(name, value, labels)
+}
+
+// Histogram writes the cumulative buckets, sum and count of a histogram snapshot
+func (w *PrometheusWriter) Histogram(name, help string, snapshot HistogramSnapshot, labels ...Label) {
+	w.declare(name, help, "histogram")
+	for _, bucket := range snapshot.Buckets {
+		w.sample(name+"_bucket", float64(bucket.Count), append(labels[:len(labels):len(labels)], L("le", bucket.UpperBound)))
+	}
+	w.sample(name+"_sum", snapshot.Sum, labels)
+	w.sample(name+"_count", float64(snapshot.Count), labels)
+}
+
+// declare writes the HELP and TYPE lines for a metric once
+func (w *PrometheusWriter) declare(name, help, metricType string) {
+	if w.declared[name] {
+		return
+	}
+	w.declared[name] = true
+
+	w.buf.WriteString("# HELP " + name + " " + escapeHelp(help) + "\n")
+	w.buf.WriteString("# TYPE " + name + " " + metricType + "\n")
+}
+
+// sample writes a single sample line
+func (w *PrometheusWriter) sample(name string, value float64, labels []Label) {
+	w.buf.WriteString(name)
+	if len(labels) > 0 {
+		w.buf.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			w.buf.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(formatValue(value))
+	w.buf.WriteByte('\n')
+}
+
+// formatValue formats a sample value, spelling out infinities and NaN as Prometheus expects
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateMeter counts events in one-second buckets over a sliding window to estimate a recent
+// events-per-second rate, e.g. how fast a queue is draining
+type RateMeter struct {
+	mu      sync.Mutex
+	buckets []int64
+	stamps  []int64 // unix second each bucket was last written
+	started time.Time
+}
+
+// NewRateMeter creates a meter averaging over the given window (rounded to whole seconds)
+func NewRateMeter(window time.Duration) *RateMeter {
+	seconds := int(window / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &RateMeter{
+		buckets: make([]int64, seconds),
+		stamps:  make([]int64, seconds),
+		started: time.Now(),
+	}
+}
+
+// Mark records a single event
+func (m *RateMeter) Mark() {
+	now := time.Now().Unix()
+	idx := int(now % int64(len(m.buckets)))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stamps[idx] != now {
+		m.stamps[idx] = now
+		m.buckets[idx] = 0
+	}
+	m.buckets[idx]++
+}
+
+// Rate returns events per second over the window, or over the meter's lifetime if shorter
+func (m *RateMeter) Rate() float64 {
+	now := time.Now()
+	oldest := now.Unix() - int64(len(m.buckets)) + 1
+
+	m.mu.Lock()
+	var total int64
+	for i, stamp := range m.stamps {
+		if stamp >= oldest {
+			total += m.buckets[i]
+		}
+	}
+	m.mu.Unlock()
+
+	elapsed := math.Min(float64(len(m.buckets)), math.Max(1, now.Sub(m.started).Seconds()))
+	return float64(total) / elapsed
+}
+
+// EstimateDrainTime estimates how long a backlog takes to clear at the given rate, bounded to
+// [minWait, maxWait]; maxWait is returned when nothing is draining
+func EstimateDrainTime(backlog int, ratePerSecond float64, minWait, maxWait time.Duration) time.Duration {
+	if ratePerSecond <= 0 {
+		return maxWait
+	}
+
+	wait := time.Duration(float64(backlog) / ratePerSecond * float64(time.Second))
+	if wait < minWait {
+		return minWait
+	}
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
@@ -0,0 +1,337 @@
+package quota
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/utils"
+)
+
+// Resource is a metered unit of work
+type Resource string
+
+const (
+	ResourceScrapes     Resource = "scrapes"
+	ResourceTailorings  Resource = "tailorings"
+	ResourceScreenshots Resource = "screenshots"
+	ResourceLLMTokens   Resource = "llm_tokens"
+)
+
+// Resources lists every metered resource
+var Resources = []Resource{ResourceScrapes, ResourceTailorings, ResourceScreenshots, ResourceLLMTokens}
+
+// Period is a quota window
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// counterKeyPrefix namespaces usage counters in the key-value store
+const counterKeyPrefix = "quota:"
+
+// counterGrace keeps counters readable for a while after their period ends
+const counterGrace = 24 * time.Hour
+
+// ErrUnknownKey is returned for API keys that are not configured
+var ErrUnknownKey = errors.New("unknown API key")
+
+// APIKey identifies a configured client; the secret itself is never kept here
+type APIKey struct {
+	Name string `json:"name"`
+	Tier string `json:"tier"`
+}
+
+// PeriodUsage reports usage against the limits of one period
+type PeriodUsage struct {
+	Period   Period             `json:"period"`
+	Start    time.Time          `json:"start"`
+	ResetsAt time.Time          `json:"resets_at"`
+	Used     map[Resource]int64 `json:"used"`
+	Limits   map[Resource]int64 `json:"limits"` // zero means unlimited
+}
+
+// Usage reports the current usage of an API key
+type Usage struct {
+	Key     string        `json:"key"`
+	Tier    string        `json:"tier"`
+	Periods []PeriodUsage `json:"periods"`
+}
+
+// tierLimits holds the limits of a tier per period
+type tierLimits map[Period]config.QuotaLimits
+
+// Manager authenticates API keys and meters their usage in the key-value store, so counters
+// are shared across replicas when Redis backs the store
+type Manager struct {
+	config  *config.Config
+	store   kv.Store
+	secrets map[string]APIKey
+	byName  map[string]APIKey
+	tiers   map[string]tierLimits
+	logger  logging.Logger
+}
+
+// NewManager creates a quota manager from configuration
+func NewManager(cfg *config.Config, store kv.Store) *Manager {
+	m := &Manager{
+		config:  cfg,
+		store:   store,
+		secrets: make(map[string]APIKey),
+		byName:  make(map[string]APIKey),
+		tiers:   make(map[string]tierLimits),
+		logger:  logging.GetGlobalLogger(),
+	}
+
+	for name, tier := range cfg.Quotas.Tiers {
+		m.tiers[name] = tierLimits{PeriodDaily: tier.Daily, PeriodMonthly: tier.Monthly}
+	}
+
+	for _, key := range cfg.Quotas.Keys {
+		if key.Key == "" || key.Name == "" {
+			m.logger.Warn("Skipping API key without a name or key", map[string]interface{}{
+				"name": key.Name,
+			})
+			continue
+		}
+		if _, exists := m.tiers[key.Tier]; !exists {
+			m.logger.Warn("API key references an unknown tier and is unmetered", map[string]interface{}{
+				"name": key.Name,
+				"tier": key.Tier,
+			})
+		}
+		apiKey := APIKey{Name: key.Name, Tier: key.Tier}
+		m.secrets[key.Key] = apiKey
+		m.byName[key.Name] = apiKey
+	}
+
+	return m
+}
+
+// IsEnabled reports whether quotas are enforced
+func (m *Manager) IsEnabled() bool {
+	return m != nil && m.config.Quotas.Enabled
+}
+
+// Header returns the header or gRPC metadata key that carries the API key
+func (m *Manager) Header() string {
+	return m.config.Quotas.Header
+}
+
+// RequireKey reports whether calls without an API key are rejected
+func (m *Manager) RequireKey() bool {
+	return m.config.Quotas.RequireKey
+}
+
+// Authenticate resolves an API key secret to its configured client
+func (m *Manager) Authenticate(secret string) (*APIKey, error) {
+	for candidate, key := range m.secrets {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(secret)) == 1 {
+			apiKey := key
+			return &apiKey, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// Lookup returns the configured client with the given name
+func (m *Manager) Lookup(name string) (*APIKey, bool) {
+	key, exists := m.byName[name]
+	if !exists {
+		return nil, false
+	}
+	return &key, true
+}
+
+// Check returns a quota exceeded error when key has used up any of resources in the current
+// day or month. Calls without a key, or whose tier has no limit, always pass.
+func (m *Manager) Check(ctx context.Context, key *APIKey, resources ...Resource) error {
+	if !m.IsEnabled() || key == nil {
+		return nil
+	}
+
+	limits, exists := m.tiers[key.Tier]
+	if !exists {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+		start, resetsAt := utils.UsageWindow(now, period == PeriodMonthly)
+		for _, resource := range resources {
+			limit := limitFor(limits[period], resource)
+			if limit <= 0 {
+				continue
+			}
+
+			used, err := m.used(ctx, key, period, start, resource)
+			if err != nil {
+				// Fail open: a store outage must not block all submissions
+				logging.FromContext(ctx).Warn("Failed to read quota usage", map[string]interface{}{
+					"api_key":  key.Name,
+					"resource": resource,
+					"error":    err.Error(),
+				})
+				continue
+			}
+			if used >= limit {
+				return utils.NewQuotaExceededError(
+					fmt.Sprintf("%s %s quota of %d %s used up for API key %s", period, key.Tier, limit, resource, key.Name),
+					time.Until(resetsAt),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// Record adds n units of resource to the daily and monthly usage of key
+func (m *Manager) Record(ctx context.Context, key *APIKey, resource Resource, n int64) {
+	if !m.IsEnabled() || key == nil || n <= 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+		start, resetsAt := utils.UsageWindow(now, period == PeriodMonthly)
+		counterKey := counterKey(key, period, start, resource)
+		if _, err := m.store.IncrBy(ctx, counterKey, n, time.Until(resetsAt)+counterGrace); err != nil {
+			logging.FromContext(ctx).Warn("Failed to record quota usage", map[string]interface{}{
+				"api_key":  key.Name,
+				"resource": resource,
+				"error":    err.Error(),
+			})
+		}
+	}
+}
+
+// Usage returns the current daily and monthly usage of key against its tier limits
+func (m *Manager) Usage(ctx context.Context, key *APIKey) (*Usage, error) {
+	limits := m.tiers[key.Tier]
+	now := time.Now().UTC()
+
+	usage := &Usage{Key: key.Name, Tier: key.Tier}
+	for _, period := range []Period{PeriodDaily, PeriodMonthly} {
+		start, resetsAt := utils.UsageWindow(now, period == PeriodMonthly)
+		periodUsage := PeriodUsage{
+			Period:   period,
+			Start:    start,
+			ResetsAt: resetsAt,
+			Used:     make(map[Resource]int64, len(Resources)),
+			Limits:   make(map[Resource]int64, len(Resources)),
+		}
+		for _, resource := range Resources {
+			used, err := m.used(ctx, key, period, start, resource)
+			if err != nil {
+				return nil, err
+			}
+			periodUsage.Used[resource] = used
+			periodUsage.Limits[resource] = limitFor(limits[period], resource)
+		}
+		usage.Periods = append(usage.Periods, periodUsage)
+	}
+	return usage, nil
+}
+
+// used reads a usage counter, treating a missing counter as zero
+func (m *Manager) used(ctx context.Context, key *APIKey, period Period, start time.Time, resource Resource) (int64, error) {
+	data, err := m.store.Get(ctx, counterKey(key, period, start, resource))
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read quota usage: %w", err)
+	}
+
+	used, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quota counter: %w", err)
+	}
+	return used, nil
+}
+
+// counterKey builds the key of a usage counter, e.g. quota:letraz-server:daily:20260101:scrapes
+func counterKey(key *APIKey, period Period, start time.Time, resource Resource) string {
+	stamp := utils.UsageWindowStamp(start, period == PeriodMonthly)
+	return counterKeyPrefix + key.Name + ":" + string(period) + ":" + stamp + ":" + string(resource)
+}
+
+// limitFor returns the limit configured for resource
+func limitFor(limits config.QuotaLimits, resource Resource) int64 {
+	switch resource {
+	case ResourceScrapes:
+		return limits.Scrapes
+	case ResourceTailorings:
+		return limits.Tailorings
+	case ResourceScreenshots:
+		return limits.Screenshots
+	case ResourceLLMTokens:
+		return limits.LLMTokens
+	}
+	return 0
+}
+
+// apiKeyContextKey is the context key under which the calling API key is stored
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns a copy of ctx that attributes usage to key
+func WithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	if key == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext returns the API key carried by ctx, if any
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	if ctx == nil {
+		return nil
+	}
+	key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey)
+	return key
+}
+
+// Global manager instance
+var (
+	globalManager *Manager
+	globalMu      sync.RWMutex
+)
+
+// InitializeGlobalManager creates the global quota manager
+func InitializeGlobalManager(cfg *config.Config, store kv.Store) *Manager {
+	manager := NewManager(cfg, store)
+
+	globalMu.Lock()
+	globalManager = manager
+	globalMu.Unlock()
+
+	if manager.IsEnabled() {
+		manager.logger.Info("API key quotas enabled", map[string]interface{}{
+			"keys":        len(manager.secrets),
+			"tiers":       len(manager.tiers),
+			"require_key": cfg.Quotas.RequireKey,
+		})
+	}
+	return manager
+}
+
+// GetGlobalManager returns the global quota manager, which is disabled until initialized
+func GetGlobalManager() *Manager {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalManager
+}
+
+// RecordLLMTokens attributes LLM tokens to the API key carried by ctx
+func RecordLLMTokens(ctx context.Context, tokens int64) {
+	GetGlobalManager().Record(ctx, APIKeyFromContext(ctx), ResourceLLMTokens, tokens)
+}
@@ -0,0 +1,170 @@
+package salary
+
+import (
+	"math"
+	"strings"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/pkg/models"
+)
+
+// Salary periods, as stated in postings and reported on normalized salaries
+const (
+	PeriodHourly  = "hourly"
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+	PeriodYearly  = "yearly"
+)
+
+// periodsPerYear is how many times a year a salary stated per period is paid, assuming a
+// 40-hour, five-day week
+var periodsPerYear = map[string]float64{
+	PeriodHourly:  2080,
+	PeriodDaily:   260,
+	PeriodWeekly:  52,
+	PeriodMonthly: 12,
+	PeriodYearly:  1,
+}
+
+// periodAliases maps the ways postings and models name a period to the period
+var periodAliases = map[string]string{
+	"hour": PeriodHourly, "hourly": PeriodHourly, "per hour": PeriodHourly, "hr": PeriodHourly,
+	"day": PeriodDaily, "daily": PeriodDaily, "per day": PeriodDaily,
+	"week": PeriodWeekly, "weekly": PeriodWeekly, "per week": PeriodWeekly, "wk": PeriodWeekly,
+	"month": PeriodMonthly, "monthly": PeriodMonthly, "per month": PeriodMonthly, "mo": PeriodMonthly,
+	"year": PeriodYearly, "yearly": PeriodYearly, "per year": PeriodYearly, "annual": PeriodYearly,
+	"annually": PeriodYearly, "per annum": PeriodYearly, "yr": PeriodYearly,
+}
+
+// currencyAliases maps currency symbols and common spellings to ISO 4217 codes
+var currencyAliases = map[string]string{
+	"$": "USD", "US$": "USD", "€": "EUR", "£": "GBP", "₹": "INR", "RS": "INR", "RS.": "INR",
+	"¥": "JPY", "C$": "CAD", "A$": "AUD", "CA$": "CAD", "AU$": "AUD", "S$": "SGD",
+}
+
+// Normalizer annualizes extracted salaries and converts them to the configured currency.
+// It uses fixed exchange rates, so the same salary always normalizes to the same values.
+type Normalizer struct {
+	target string
+	rates  map[string]float64
+}
+
+// NewNormalizer creates a salary normalizer from the salary configuration
+func NewNormalizer(cfg *config.Config) *Normalizer {
+	rates := make(map[string]float64, len(cfg.Salary.ExchangeRates))
+	for currency, rate := range cfg.Salary.ExchangeRates {
+		if rate > 0 {
+			rates[strings.ToUpper(currency)] = rate
+		}
+	}
+	return &Normalizer{
+		target: strings.ToUpper(cfg.Salary.TargetCurrency),
+		rates:  rates,
+	}
+}
+
+// Apply sets the job's normalized salary from its extracted salary, falling back to the job's
+// currency when the salary names none
+func (n *Normalizer) Apply(job *models.Job) {
+	if job == nil {
+		return
+	}
+	job.NormalizedSalary = n.Normalize(job.Salary, job.Currency)
+}
+
+// Normalize returns salary as a yearly range in the target currency, or nil when it has no
+// amounts or its currency has no exchange rate. A salary without a stated period is read as
+// hourly, monthly or yearly depending on the size of its amounts.
+func (n *Normalizer) Normalize(salary models.Salary, fallbackCurrency string) *models.NormalizedSalary {
+	low, high := salary.Min, salary.Max
+	if low <= 0 {
+		low = high
+	}
+	if high <= 0 {
+		high = low
+	}
+	if low <= 0 {
+		return nil
+	}
+	if low > high {
+		low, high = high, low
+	}
+
+	currency := normalizeCurrency(salary.Currency)
+	if currency == "" {
+		currency = normalizeCurrency(fallbackCurrency)
+	}
+	rate, ok := n.exchangeRate(currency)
+	if !ok {
+		return nil
+	}
+
+	period := NormalizePeriod(salary.Period)
+	if period == "" {
+		period = inferPeriod(n.inUSD(high, currency))
+	}
+	multiplier := periodsPerYear[period] * rate
+
+	return &models.NormalizedSalary{
+		Currency:     n.target,
+		AnnualMin:    int(math.Round(low * multiplier)),
+		AnnualMax:    int(math.Round(high * multiplier)),
+		Period:       period,
+		ExchangeRate: rate,
+	}
+}
+
+// exchangeRate returns the target currency value of one unit of currency
+func (n *Normalizer) exchangeRate(currency string) (float64, bool) {
+	if currency == n.target {
+		return 1, true
+	}
+	from, ok := n.rates[currency]
+	if !ok {
+		return 0, false
+	}
+	to, ok := n.rates[n.target]
+	if !ok {
+		return 0, false
+	}
+	return from / to, true
+}
+
+// inUSD converts amount in currency to US dollars, returning it unchanged when either rate is
+// missing so period inference still has a magnitude to work with
+func (n *Normalizer) inUSD(amount float64, currency string) float64 {
+	from, ok := n.rates[currency]
+	usd, usdOK := n.rates["USD"]
+	if !ok || !usdOK {
+		return amount
+	}
+	return amount * from / usd
+}
+
+// inferPeriod guesses the period of a salary whose largest amount is worth usd US dollars
+func inferPeriod(usd float64) string {
+	switch {
+	case usd < 500:
+		return PeriodHourly
+	case usd < 10000:
+		return PeriodMonthly
+	default:
+		return PeriodYearly
+	}
+}
+
+// normalizeCurrency returns the ISO 4217 code for a currency code, symbol or common spelling
+func normalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if code, ok := currencyAliases[currency]; ok {
+		return code
+	}
+	return currency
+}
+
+// NormalizePeriod returns the period named by period, such as "hourly" for "per hour" or "yr",
+// or "" when it names none
+func NormalizePeriod(period string) string {
+	return periodAliases[strings.ToLower(strings.TrimSpace(period))]
+}
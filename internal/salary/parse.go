@@ -0,0 +1,226 @@
+package salary
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"letraz-utils/pkg/models"
+)
+
+var (
+	// currencyPattern finds a currency symbol or code; prefixed dollars come before the bare sign
+	currencyPattern = regexp.MustCompile(`US\$|CA\$|AU\$|C\$|A\$|S\$|\$|€|£|₹|¥|\bRs\.?|\b(?:USD|EUR|GBP|INR|CAD|AUD|SGD|JPY|CHF|NZD|SEK|NOK|DKK|PLN|BRL|MXN|ZAR|AED|HKD|CNY)\b`)
+
+	// currencyBefore and currencyAfter find a currency written right next to an amount, as in
+	// "$ 120,000" and "120,000 EUR"
+	currencyBefore = regexp.MustCompile(`(?:` + currencyPattern.String() + `)[\s\x{00A0}]*$`)
+	currencyAfter  = regexp.MustCompile(`^[\s\x{00A0}]*(?:` + currencyPattern.String() + `)`)
+
+	// amountPattern finds an amount with its thousands and decimal separators and an optional
+	// magnitude such as "k" or "lakh"
+	amountPattern = regexp.MustCompile(`(?i)(\d+(?:[,.'\x{00A0}]\d{3})*(?:[.,]\d+)?)(?:\s*(k|mn|m|lakhs?|lacs?|lpa|l|crores?|cr)\b)?`)
+
+	// periodPattern finds the period a salary is paid for, such as "/hr", "per annum" or "a year"
+	periodPattern = regexp.MustCompile(`(?i)(?:/\s*|\bper\s+|\ban?\s+)(hour|hr|h|day|week|wk|month|mo|year|yr|annum)\b|\b(hourly|daily|weekly|monthly|yearly|annually|annual|lpa|pa)\b|\bp\.a\.?`)
+
+	// rangeSeparator is what may stand between the two amounts of a range once currencies and
+	// periods are removed
+	rangeSeparator = regexp.MustCompile(`(?i)^(?:-|to|and)$`)
+
+	upToPattern = regexp.MustCompile(`(?i)\b(?:up\s+to|upto|max(?:imum)?|under|below)\b`)
+	fromPattern = regexp.MustCompile(`(?i)\b(?:from|starting\s+(?:at|from)|at\s+least|min(?:imum)?)\b`)
+)
+
+// magnitudes scales amounts written with a magnitude suffix
+var magnitudes = map[string]float64{
+	"k": 1e3, "m": 1e6, "mn": 1e6,
+	"l": 1e5, "lakh": 1e5, "lakhs": 1e5, "lac": 1e5, "lacs": 1e5, "lpa": 1e5,
+	"cr": 1e7, "crore": 1e7, "crores": 1e7,
+}
+
+// periodWords maps the period words periodAliases does not know to the period
+var periodWords = map[string]string{
+	"h": PeriodHourly, "annum": PeriodYearly, "lpa": PeriodYearly, "pa": PeriodYearly, "p.a": PeriodYearly,
+}
+
+// amount is a number found in salary text
+type amount struct {
+	value     float64
+	magnitude string
+	currency  string // the currency written next to it
+	start     int
+	end       int
+}
+
+// isPay reports whether the amount is marked as pay by a currency next to it or a magnitude;
+// other numbers, such as the "3" of "3+ years", are not salaries
+func (a amount) isPay() bool {
+	return a.currency != "" || a.magnitude != ""
+}
+
+// ParseText reads a salary written in prose, such as "$120K - $150K a year", "£45,000 per annum",
+// "$25 to $30 an hour", "Up to €60.000" or "₹ 12-18 LPA". A range missing its upper or lower end
+// ("up to", "from", "100k+") leaves that end zero. Only amounts marked as pay count: those with
+// a currency written next to them or a magnitude such as "k", and the other end of a range one
+// of them starts or ends. It reports false when the text holds no such amount.
+func ParseText(text string) (models.Salary, bool) {
+	text = strings.NewReplacer("–", "-", "—", "-", "−", "-").Replace(text)
+
+	low, high, ok := findSalaryAmounts(findAmounts(text), text)
+	if !ok {
+		return models.Salary{}, false
+	}
+
+	var salary models.Salary
+	switch {
+	case low.currency != "":
+		salary.Currency = normalizeCurrency(low.currency)
+	case high != nil && high.currency != "":
+		salary.Currency = normalizeCurrency(high.currency)
+	default:
+		if match := currencyPattern.FindString(text); match != "" {
+			salary.Currency = normalizeCurrency(match)
+		}
+	}
+	if match := periodPattern.FindStringSubmatch(text); match != nil {
+		salary.Period = parsePeriod(match)
+	}
+
+	if high != nil && low.magnitude == "" && high.magnitude != "" && low.value < high.value {
+		// "120-150k" writes the magnitude once, for both ends
+		low.magnitude = high.magnitude
+	}
+	if salary.Currency == "" && isIndianMagnitude(low.magnitude) {
+		salary.Currency = "INR"
+	}
+
+	switch {
+	case high != nil:
+		salary.Min, salary.Max = low.scaled(), high.scaled()
+		if salary.Min > salary.Max {
+			salary.Min, salary.Max = salary.Max, salary.Min
+		}
+	case upToPattern.MatchString(text[:low.start]):
+		salary.Max = low.scaled()
+	case fromPattern.MatchString(text[:low.start]) || strings.HasPrefix(strings.TrimSpace(text[low.end:]), "+"):
+		salary.Min = low.scaled()
+	default:
+		salary.Min, salary.Max = low.scaled(), low.scaled()
+	}
+	return salary, salary.Min > 0 || salary.Max > 0
+}
+
+// findSalaryAmounts picks the salary among the amounts of text: the first amount marked as pay,
+// with the amount it forms a range with, if any
+func findSalaryAmounts(amounts []amount, text string) (low amount, high *amount, ok bool) {
+	for i, a := range amounts {
+		if !a.isPay() {
+			continue
+		}
+		if i > 0 && isRangeGap(text[amounts[i-1].end:a.start]) {
+			return amounts[i-1], &amounts[i], true
+		}
+		if i+1 < len(amounts) && isRangeGap(text[a.end:amounts[i+1].start]) {
+			return a, &amounts[i+1], true
+		}
+		return a, nil, true
+	}
+	return amount{}, nil, false
+}
+
+// findAmounts returns the amounts in text, skipping percentages and the "401" of "401(k)"
+func findAmounts(text string) []amount {
+	var amounts []amount
+	for _, match := range amountPattern.FindAllStringSubmatchIndex(text, -1) {
+		rest := text[match[1]:]
+		if strings.HasPrefix(strings.TrimSpace(rest), "%") || strings.HasPrefix(rest, "(k)") {
+			continue
+		}
+		value, ok := parseNumber(text[match[2]:match[3]])
+		if !ok {
+			continue
+		}
+		found := amount{value: value, start: match[0], end: match[1]}
+		if match[4] >= 0 {
+			found.magnitude = strings.ToLower(text[match[4]:match[5]])
+		}
+		if next := currencyBefore.FindString(text[:match[0]]); next != "" {
+			found.currency = currencyPattern.FindString(next)
+		} else if next := currencyAfter.FindString(rest); next != "" {
+			found.currency = currencyPattern.FindString(next)
+		}
+		amounts = append(amounts, found)
+	}
+	return amounts
+}
+
+// parseNumber reads a number written with "," or "." as thousands separator or decimal point.
+// When both appear the last one is the decimal point; a single separator followed by exactly
+// three digits groups thousands, as in "120,000" and "120.000".
+func parseNumber(number string) (float64, bool) {
+	number = strings.NewReplacer("'", "", "\u00a0", "").Replace(number)
+
+	decimal := -1
+	lastComma, lastDot := strings.LastIndex(number, ","), strings.LastIndex(number, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		decimal = max(lastComma, lastDot)
+	case lastComma >= 0 || lastDot >= 0:
+		last := max(lastComma, lastDot)
+		if strings.Count(number, number[last:last+1]) == 1 && len(number)-last-1 != 3 {
+			decimal = last
+		}
+	}
+
+	var normalized strings.Builder
+	for i, r := range number {
+		switch {
+		case i == decimal:
+			normalized.WriteByte('.')
+		case r >= '0' && r <= '9':
+			normalized.WriteRune(r)
+		}
+	}
+	value, err := strconv.ParseFloat(normalized.String(), 64)
+	return value, err == nil
+}
+
+// scaled returns the amount with its magnitude applied, rounded to the cent
+func (a amount) scaled() float64 {
+	value := a.value
+	if multiplier, ok := magnitudes[a.magnitude]; ok {
+		value *= multiplier
+	}
+	return math.Round(value*100) / 100
+}
+
+// isRangeGap reports whether gap, the text between two amounts, joins them into a range
+func isRangeGap(gap string) bool {
+	gap = currencyPattern.ReplaceAllString(gap, "")
+	gap = periodPattern.ReplaceAllString(gap, "")
+	return rangeSeparator.MatchString(strings.TrimSpace(gap))
+}
+
+// parsePeriod returns the period named by a periodPattern match
+func parsePeriod(match []string) string {
+	word := strings.ToLower(match[0])
+	for _, group := range match[1:] {
+		if group != "" {
+			word = strings.ToLower(group)
+			break
+		}
+	}
+	word = strings.TrimSuffix(word, ".")
+	if period := NormalizePeriod(word); period != "" {
+		return period
+	}
+	return periodWords[word]
+}
+
+// isIndianMagnitude reports whether magnitude is a lakh or crore, which only Indian postings use
+func isIndianMagnitude(magnitude string) bool {
+	multiplier := magnitudes[magnitude]
+	return multiplier == 1e5 || multiplier == 1e7
+}
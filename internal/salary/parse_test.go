@@ -0,0 +1,59 @@
+package salary
+
+import (
+	"testing"
+
+	"letraz-utils/pkg/models"
+)
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		want   models.Salary
+		wantOK bool
+	}{
+		// Indeed
+		{"indeed yearly range", "$50,000 - $70,000 a year", models.Salary{Currency: "USD", Min: 50000, Max: 70000, Period: PeriodYearly}, true},
+		{"indeed hourly range", "$25 - $30 an hour", models.Salary{Currency: "USD", Min: 25, Max: 30, Period: PeriodHourly}, true},
+		{"indeed from", "From $60,000 a year", models.Salary{Currency: "USD", Min: 60000, Period: PeriodYearly}, true},
+		{"indeed up to", "Up to $85,000 a year", models.Salary{Currency: "USD", Max: 85000, Period: PeriodYearly}, true},
+		{"indeed uk", "£35,000 - £42,000 a year", models.Salary{Currency: "GBP", Min: 35000, Max: 42000, Period: PeriodYearly}, true},
+
+		// LinkedIn
+		{"linkedin yearly", "$120K/yr - $150K/yr", models.Salary{Currency: "USD", Min: 120000, Max: 150000, Period: PeriodYearly}, true},
+		{"linkedin hourly", "$45/hr - $55/hr", models.Salary{Currency: "USD", Min: 45, Max: 55, Period: PeriodHourly}, true},
+		{"linkedin with benefits", "$90K/yr - $110K/yr · Medical, 401(k)", models.Salary{Currency: "USD", Min: 90000, Max: 110000, Period: PeriodYearly}, true},
+
+		// Glassdoor
+		{"glassdoor estimate", "$95K - $130K (Glassdoor est.)", models.Salary{Currency: "USD", Min: 95000, Max: 130000}, true},
+		{"glassdoor employer estimate", "£35K - £45K (Employer est.)", models.Salary{Currency: "GBP", Min: 35000, Max: 45000}, true},
+		{"glassdoor hourly", "$18.50 - $22.00 Per Hour (Employer est.)", models.Salary{Currency: "USD", Min: 18.5, Max: 22, Period: PeriodHourly}, true},
+		{"glassdoor fractional hourly", "$18.50/hr", models.Salary{Currency: "USD", Min: 18.5, Max: 18.5, Period: PeriodHourly}, true},
+
+		// Greenhouse
+		{"greenhouse pay range", "$140,000—$180,000 USD", models.Salary{Currency: "USD", Min: 140000, Max: 180000}, true},
+		{"greenhouse sentence", "The base salary range for this role is $150,000 - $190,000 per year.", models.Salary{Currency: "USD", Min: 150000, Max: 190000, Period: PeriodYearly}, true},
+		{"greenhouse euros", "€60.000 - €75.000 per year", models.Salary{Currency: "EUR", Min: 60000, Max: 75000, Period: PeriodYearly}, true},
+		{"greenhouse trailing code", "150,000 - 190,000 CAD", models.Salary{Currency: "CAD", Min: 150000, Max: 190000}, true},
+
+		// Amounts that are not pay
+		{"years of experience first", "3+ years experience. $100k-$120k", models.Salary{Currency: "USD", Min: 100000, Max: 120000}, true},
+		{"experience range first", "5-7 years of experience, $130,000 - $160,000 a year", models.Salary{Currency: "USD", Min: 130000, Max: 160000, Period: PeriodYearly}, true},
+		{"shared magnitude", "120-150k", models.Salary{Min: 120000, Max: 150000}, true},
+		{"unmarked upper end", "$100,000 - 120,000", models.Salary{Currency: "USD", Min: 100000, Max: 120000}, true},
+		{"lakhs", "₹ 12-18 LPA", models.Salary{Currency: "INR", Min: 1200000, Max: 1800000, Period: PeriodYearly}, true},
+		{"experience only", "3+ years experience", models.Salary{}, false},
+		{"team size", "Join a team of 500 engineers across 12 offices", models.Salary{}, false},
+		{"percentages and 401(k)", "401(k) with 4% match", models.Salary{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseText(tt.text)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseText(%q) = %+v, %v, want %+v, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"letraz-utils/pkg/models"
+)
+
+// maxKeywords bounds the job keywords a resume is checked against
+const maxKeywords = 30
+
+// Weights of the component scores in the overall ATS score
+const (
+	keywordWeight    = 0.35
+	skillsWeight     = 0.25
+	formattingWeight = 0.15
+	llmWeight        = 0.25
+)
+
+// formattingPenalty is deducted from the formatting score for each formatting issue
+const formattingPenalty = 15
+
+// maxDescriptionLength is the length beyond which an entry description is too long to scan
+const maxDescriptionLength = 1500
+
+// Analysis is the deterministic part of an ATS score: keyword and requirement matching and
+// formatting checks, computed without the LLM
+type Analysis struct {
+	Keywords         models.ATSKeywordMatch
+	RequiredSkills   models.ATSKeywordMatch
+	FormattingIssues []string
+	Sections         []models.ATSSectionScore
+}
+
+// Analyze matches a resume against the keywords and requirements of a job posting and checks
+// the resume for structural issues that trip up applicant tracking systems
+func Analyze(resume *models.BaseResume, job *models.Job) *Analysis {
+	sections := ResumeSections(resume)
+	resumeTerms := make(map[string]bool)
+	for _, section := range sections {
+		for term := range TermSet(section.Text) {
+			resumeTerms[term] = true
+		}
+	}
+	for _, skill := range ResumeSkills(resume) {
+		for term := range TermSet(skill) {
+			resumeTerms[term] = true
+		}
+	}
+
+	keywords := JobKeywords(job)
+	analysis := &Analysis{
+		Keywords:         matchTerms(keywords, resumeTerms),
+		RequiredSkills:   matchRequirements(job.Requirements, resumeTerms),
+		FormattingIssues: formattingIssues(resume, sections),
+	}
+	if len(job.Requirements) == 0 {
+		// Without listed requirements the keywords are the best measure of skill fit
+		analysis.RequiredSkills.Score = analysis.Keywords.Score
+	}
+
+	for _, section := range sections {
+		match := matchTerms(keywords, TermSet(section.Text))
+		analysis.Sections = append(analysis.Sections, models.ATSSectionScore{
+			Type:            section.Type,
+			Score:           match.Score,
+			KeywordScore:    match.Score,
+			MatchedKeywords: match.Matched,
+		})
+	}
+	return analysis
+}
+
+// FormattingScore returns the formatting component score
+func (a *Analysis) FormattingScore() int {
+	return max(0, 100-formattingPenalty*len(a.FormattingIssues))
+}
+
+// Combine weighs the deterministic analysis and the LLM review into the overall ATS score. A
+// section's score averages its keyword coverage with the LLM's score for it.
+func Combine(analysis *Analysis, review *models.ATSReview) *models.ATSScore {
+	llmScore := clamp(review.Score)
+	formattingScore := analysis.FormattingScore()
+
+	score := &models.ATSScore{
+		Score: int(math.Round(keywordWeight*float64(analysis.Keywords.Score) +
+			skillsWeight*float64(analysis.RequiredSkills.Score) +
+			formattingWeight*float64(formattingScore) +
+			llmWeight*float64(llmScore))),
+		Breakdown: models.ATSScoreBreakdown{
+			KeywordCoverage: analysis.Keywords,
+			RequiredSkills:  analysis.RequiredSkills,
+			FormattingScore: formattingScore,
+			LLMScore:        llmScore,
+		},
+		Sections:         make([]models.ATSSectionScore, len(analysis.Sections)),
+		FormattingIssues: append([]string{}, analysis.FormattingIssues...),
+		Summary:          review.Summary,
+	}
+
+	reviews := make(map[string]models.ATSSectionReview, len(review.Sections))
+	for _, section := range review.Sections {
+		reviews[strings.ToLower(section.Type)] = section
+	}
+	for i, section := range analysis.Sections {
+		if sectionReview, ok := reviews[section.Type]; ok {
+			section.Score = int(math.Round(float64(section.KeywordScore+clamp(sectionReview.Score)) / 2))
+			section.Feedback = sectionReview.Feedback
+		}
+		score.Sections[i] = section
+	}
+
+	seen := make(map[string]bool, len(score.FormattingIssues))
+	for _, issue := range score.FormattingIssues {
+		seen[strings.ToLower(issue)] = true
+	}
+	for _, issue := range review.FormattingIssues {
+		if issue = strings.TrimSpace(issue); issue != "" && !seen[strings.ToLower(issue)] {
+			seen[strings.ToLower(issue)] = true
+			score.FormattingIssues = append(score.FormattingIssues, issue)
+		}
+	}
+
+	return score
+}
+
+// JobKeywords returns the most frequent terms of a job posting. Terms from the title and the
+// requirements count double, since those are what screening filters are built from.
+func JobKeywords(job *models.Job) []string {
+	counts := make(map[string]int)
+	addText := func(text string, weight int) {
+		for _, term := range Terms(text) {
+			counts[term] += weight
+		}
+	}
+
+	addText(job.Title, 2)
+	for _, requirement := range job.Requirements {
+		addText(requirement, 2)
+	}
+	for _, responsibility := range job.Responsibilities {
+		addText(responsibility, 1)
+	}
+	addText(job.Description, 1)
+
+	keywords := make([]string, 0, len(counts))
+	for term := range counts {
+		keywords = append(keywords, term)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if counts[keywords[i]] != counts[keywords[j]] {
+			return counts[keywords[i]] > counts[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+	if len(keywords) > maxKeywords {
+		keywords = keywords[:maxKeywords]
+	}
+	return keywords
+}
+
+// matchTerms reports which of terms are in set
+func matchTerms(terms []string, set map[string]bool) models.ATSKeywordMatch {
+	match := models.ATSKeywordMatch{Matched: []string{}, Missing: []string{}}
+	for _, term := range terms {
+		if set[term] {
+			match.Matched = append(match.Matched, term)
+		} else {
+			match.Missing = append(match.Missing, term)
+		}
+	}
+	match.Score = percentage(len(match.Matched), len(terms))
+	return match
+}
+
+// matchRequirements reports which job requirements the resume covers. A requirement counts as
+// covered when at least half of its terms appear in the resume.
+func matchRequirements(requirements []string, resumeTerms map[string]bool) models.ATSKeywordMatch {
+	match := models.ATSKeywordMatch{Matched: []string{}, Missing: []string{}}
+	total := 0
+	for _, requirement := range requirements {
+		terms := TermSet(requirement)
+		if len(terms) == 0 {
+			continue
+		}
+		total++
+
+		found := 0
+		for term := range terms {
+			if resumeTerms[term] {
+				found++
+			}
+		}
+		if found*2 >= len(terms) {
+			match.Matched = append(match.Matched, requirement)
+		} else {
+			match.Missing = append(match.Missing, requirement)
+		}
+	}
+	match.Score = percentage(len(match.Matched), total)
+	return match
+}
+
+// formattingIssues finds missing contact details, missing or empty core sections and entries
+// that are hard to scan
+func formattingIssues(resume *models.BaseResume, sections []SectionText) []string {
+	var issues []string
+	if strings.TrimSpace(resume.User.Email) == "" {
+		issues = append(issues, "No email address in the contact details")
+	}
+	if strings.TrimSpace(resume.User.Phone) == "" {
+		issues = append(issues, "No phone number in the contact details")
+	}
+
+	present := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		present[section.Type] = true
+		if section.Text == "" {
+			issues = append(issues, fmt.Sprintf("The %s section is empty", section.Type))
+		}
+	}
+	for _, required := range []string{"experience", "education", "skill"} {
+		if !present[required] {
+			issues = append(issues, fmt.Sprintf("No %s section; applicant tracking systems look for it by name", required))
+		}
+	}
+
+	for _, section := range resume.Sections {
+		data, ok := section.Data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sectionType := strings.ToLower(section.Type)
+		description, _ := data["description"].(string)
+		description = StripHTML(description)
+		switch {
+		case sectionType == "experience" && description == "":
+			issues = append(issues, fmt.Sprintf("The experience entry %s has no description", entryName(data)))
+		case len(description) > maxDescriptionLength:
+			issues = append(issues, fmt.Sprintf("The %s entry %s has a description of %d characters; use short bullet points", sectionType, entryName(data), len(description)))
+		}
+	}
+	return issues
+}
+
+// entryName returns a readable name of a resume entry for issue messages
+func entryName(data map[string]interface{}) string {
+	for _, key := range []string{"job_title", "name", "degree", "institution_name", "company_name"} {
+		if name, ok := data[key].(string); ok && strings.TrimSpace(name) != "" {
+			if company, ok := data["company_name"].(string); ok && key == "job_title" && company != "" {
+				return fmt.Sprintf("%q at %s", name, company)
+			}
+			return fmt.Sprintf("%q", name)
+		}
+	}
+	return "without a title"
+}
+
+// percentage returns part of total as a whole percentage; an empty total scores 100
+func percentage(part, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return int(math.Round(float64(part) / float64(total) * 100))
+}
+
+// clamp bounds a score to 0-100
+func clamp(score int) int {
+	return min(100, max(0, score))
+}
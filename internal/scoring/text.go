@@ -0,0 +1,208 @@
+package scoring
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"letraz-utils/pkg/models"
+)
+
+// SectionProfile is the pseudo section type of the resume's profile text
+const SectionProfile = "profile"
+
+// ignoredDataKeys are resume section fields that carry identifiers or links rather than content
+var ignoredDataKeys = map[string]bool{
+	"id": true, "user": true, "resume": true, "resume_section": true, "index": true,
+	"created_at": true, "updated_at": true, "github_url": true, "live_url": true, "credential_url": true,
+}
+
+// stopWords are common words that never count as keywords
+var stopWords = toSet(strings.Fields(`
+	a about above across after all also an and any are as at be been being both but by can could
+	do does etc for from has have having he her here his how i if in including into is it its
+	like may more most must not of on or other our out over own per plus preferred required
+	role should so some such than that the their them then there these they this those through
+	to under up us use using very via was we well were what when where which while who will
+	with within work working would you your years year experience strong ability skills skill
+	knowledge understanding team teams new good great excellent e.g i.e job candidate
+	candidates responsibilities requirements position company looking join help support
+	familiarity familiar proficiency proficient senior junior build building develop developing
+`))
+
+var tagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+
+// SectionText is the plain text of every resume section of one type
+type SectionText struct {
+	Type string
+	Text string
+}
+
+// ResumeSections returns the plain text of the resume grouped by lowercased section type, in
+// the order the types first appear, starting with the profile text when there is one
+func ResumeSections(resume *models.BaseResume) []SectionText {
+	var sections []SectionText
+	index := make(map[string]int)
+	add := func(sectionType, text string) {
+		text = strings.TrimSpace(text)
+		if i, ok := index[sectionType]; ok {
+			if text != "" {
+				sections[i].Text = strings.TrimSpace(sections[i].Text + "\n" + text)
+			}
+			return
+		}
+		index[sectionType] = len(sections)
+		sections = append(sections, SectionText{Type: sectionType, Text: text})
+	}
+
+	if profile := StripHTML(resume.User.ProfileText); profile != "" {
+		add(SectionProfile, profile)
+	}
+	for _, section := range resume.Sections {
+		var parts []string
+		collectText(section.Data, &parts)
+		add(strings.ToLower(section.Type), strings.Join(parts, "\n"))
+	}
+	return sections
+}
+
+// ResumeSkills returns the names listed in the resume's skill sections and the skills used in its
+// projects, without duplicates
+func ResumeSkills(resume *models.BaseResume) []string {
+	var skills []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		skills = append(skills, name)
+	}
+
+	for _, section := range resume.Sections {
+		data, ok := section.Data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(section.Type) {
+		case "skill":
+			items, _ := data["skills"].([]interface{})
+			for _, item := range items {
+				entry, _ := item.(map[string]interface{})
+				if skill, ok := entry["skill"].(map[string]interface{}); ok {
+					add(fmt.Sprint(skill["name"]))
+				} else if name, ok := entry["name"].(string); ok {
+					add(name)
+				}
+			}
+		case "project":
+			items, _ := data["skills_used"].([]interface{})
+			for _, item := range items {
+				if skill, ok := item.(map[string]interface{}); ok {
+					add(fmt.Sprint(skill["name"]))
+				}
+			}
+		}
+	}
+	return skills
+}
+
+// collectText appends the text values of section data to parts, skipping identifier fields
+func collectText(data interface{}, parts *[]string) {
+	switch value := data.(type) {
+	case string:
+		if text := StripHTML(value); text != "" {
+			*parts = append(*parts, text)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			if !ignoredDataKeys[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectText(value[key], parts)
+		}
+	case []interface{}:
+		for _, item := range value {
+			collectText(item, parts)
+		}
+	}
+}
+
+// StripHTML converts rich text to plain text
+func StripHTML(text string) string {
+	text = tagPattern.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+// Tokenize splits text into lowercased terms. Characters that are part of technology names,
+// as in C++, C# or Node.js, are kept inside terms.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.-", r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, ".-")
+		if field != "" {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// Terms returns the tokens of text that can be keywords
+func Terms(text string) []string {
+	tokens := Tokenize(text)
+	terms := tokens[:0]
+	for _, token := range tokens {
+		if isTerm(token) {
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
+
+// TermSet returns the set of terms in text
+func TermSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, term := range Terms(text) {
+		set[term] = true
+	}
+	return set
+}
+
+// isTerm reports whether a token can be a keyword: a word of two or more characters that is not a
+// stop word or a number
+func isTerm(token string) bool {
+	if stopWords[token] {
+		return false
+	}
+	hasLetter := false
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			break
+		}
+	}
+	if !hasLetter {
+		return false
+	}
+	return len(token) >= 2
+}
+
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,266 @@
+package antibot
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// BlockType names what stopped a page from being read
+type BlockType string
+
+const (
+	BlockCloudflare  BlockType = "cloudflare"
+	BlockPerimeterX  BlockType = "perimeterx"
+	BlockAkamai      BlockType = "akamai"
+	BlockDataDome    BlockType = "datadome"
+	BlockImperva     BlockType = "imperva"
+	BlockCaptcha     BlockType = "captcha" // a captcha of no recognized bot protection vendor
+	BlockLoginWall   BlockType = "login_wall"
+	BlockGeo         BlockType = "geo_block"
+	BlockRateLimited BlockType = "rate_limited"
+	BlockUnknown     BlockType = "unknown" // refused with 403 without a recognizable page
+)
+
+// Retryable reports whether another engine, with its own IP addresses and browser, may get past
+// a block. Login walls stop every engine alike.
+func Retryable(block BlockType) bool {
+	return block != "" && block != BlockLoginWall
+}
+
+// vendorMarkers are the markers of each vendor's block and challenge pages. They are specific to
+// those pages; the vendors' scripts on pages they let through do not carry them.
+var vendorMarkers = []struct {
+	block   BlockType
+	markers []string
+}{
+	{BlockCloudflare, []string{
+		"<title>just a moment...</title>",
+		"attention required! | cloudflare",
+		"cf-browser-verification",
+		"cf-chl-",
+		"__cf_chl_",
+		"cf-error-details",
+		"challenges.cloudflare.com/turnstile",
+	}},
+	{BlockPerimeterX, []string{
+		"px-captcha",
+		"captcha.px-cdn.net",
+		"_pxcaptcha",
+		"press &amp; hold",
+		"press & hold",
+	}},
+	{BlockDataDome, []string{
+		"captcha-delivery.com",
+	}},
+	{BlockImperva, []string{
+		"incapsula incident id",
+		"_incapsula_resource",
+	}},
+	{BlockAkamai, []string{
+		"errors.edgesuite.net",
+		"akamai reference",
+	}},
+}
+
+// geoMarkers are phrases of pages refusing visitors from the requester's country
+var geoMarkers = []string{
+	"not available in your country",
+	"not available in your region",
+	"not available in your location",
+	"not available in your area",
+	"isn't available in your country",
+	"is unavailable in your region",
+	"unavailable in your country",
+	"not accessible from your country",
+	"access from your country",
+	"geo-restricted",
+	"georestricted",
+}
+
+// loginMarkers are phrases of pages that show nothing until the visitor signs in
+var loginMarkers = []string{
+	"authwall",
+	"sign in to view",
+	"log in to view",
+	"login to view",
+	"join to view",
+	"sign in to continue",
+	"log in to continue",
+	"you must be logged in",
+	"you need to sign in",
+	"login required",
+}
+
+var (
+	titlePattern    = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	passwordPattern = regexp.MustCompile(`(?i)<input[^>]+type=["']?password`)
+)
+
+// Classify names the block a response is, from its status, headers and body, or returns "" for
+// a page that looks readable. Status and headers may be zero when only the rendered page is known.
+func Classify(status int, header http.Header, html string) BlockType {
+	lower := strings.ToLower(html)
+	refused := status == http.StatusForbidden || status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+
+	if header != nil {
+		if header.Get("Cf-Mitigated") != "" {
+			return BlockCloudflare
+		}
+		if refused {
+			server := strings.ToLower(header.Get("Server"))
+			switch {
+			case strings.Contains(server, "cloudflare"):
+				return BlockCloudflare
+			case strings.Contains(server, "akamaighost"):
+				return BlockAkamai
+			case header.Get("X-Datadome") != "" || header.Get("X-Dd-B") != "":
+				return BlockDataDome
+			}
+		}
+	}
+
+	for _, vendor := range vendorMarkers {
+		for _, marker := range vendor.markers {
+			if strings.Contains(lower, marker) {
+				return vendor.block
+			}
+		}
+	}
+	// Akamai's own denial page carries no vendor name, only its reference number
+	if strings.Contains(lower, "access denied") && strings.Contains(lower, "reference #") {
+		return BlockAkamai
+	}
+
+	if status == http.StatusUnavailableForLegalReasons || containsAny(lower, geoMarkers) {
+		return BlockGeo
+	}
+	if isLoginWall(lower) {
+		return BlockLoginWall
+	}
+	if strings.Contains(lower, "g-recaptcha") || strings.Contains(lower, "h-captcha") || strings.Contains(lower, "hcaptcha.com") {
+		return BlockCaptcha
+	}
+
+	switch status {
+	case http.StatusTooManyRequests:
+		return BlockRateLimited
+	case http.StatusForbidden:
+		return BlockUnknown
+	}
+	return ""
+}
+
+// ClassifyChallenge names the block of a response already known to be a challenge or refusal,
+// such as a page with a captcha, falling back to BlockCaptcha when nothing more specific shows
+func ClassifyChallenge(status int, header http.Header, html string) BlockType {
+	if block := Classify(status, header, html); block != "" {
+		return block
+	}
+	return BlockCaptcha
+}
+
+// isLoginWall reports whether a page asks the visitor to sign in instead of showing content:
+// it says so outright, or it is titled as a sign-in page and has a password field
+func isLoginWall(lower string) bool {
+	if containsAny(lower, loginMarkers) {
+		return true
+	}
+	if !passwordPattern.MatchString(lower) {
+		return false
+	}
+	match := titlePattern.FindStringSubmatch(lower)
+	return match != nil && containsAny(match[1], []string{"sign in", "sign-in", "log in", "login"})
+}
+
+func containsAny(s string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(s, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encounter is a block an engine ran into while scraping a page
+type Encounter struct {
+	Engine string    `json:"engine"`
+	Block  BlockType `json:"block"`
+}
+
+// Log collects the blocks met while scraping one page, across the engines that tried it
+type Log struct {
+	encounters []Encounter
+	mu         sync.Mutex
+}
+
+// NewLog creates an empty block log
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Add records that engine ran into block
+func (l *Log) Add(engine string, block BlockType) {
+	if l == nil || block == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.encounters = append(l.encounters, Encounter{Engine: engine, Block: block})
+}
+
+// Metadata returns the blocks met in the form stored under "anti_bot" in task metadata: the
+// last block, whether another engine may get past it, and every encounter. It returns nil when
+// no block was met.
+func (l *Log) Metadata() map[string]interface{} {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.encounters) == 0 {
+		return nil
+	}
+
+	encounters := make([]map[string]interface{}, len(l.encounters))
+	for i, encounter := range l.encounters {
+		encounters[i] = map[string]interface{}{
+			"engine": encounter.Engine,
+			"block":  string(encounter.Block),
+		}
+	}
+	last := l.encounters[len(l.encounters)-1]
+	return map[string]interface{}{
+		"block":      string(last.Block),
+		"engine":     last.Engine,
+		"retryable":  Retryable(last.Block),
+		"encounters": encounters,
+	}
+}
+
+// logContextKey is the context key under which the current job's block log is stored
+type logContextKey struct{}
+
+// WithLog returns a copy of ctx whose blocks are recorded into l
+func WithLog(ctx context.Context, l *Log) context.Context {
+	return context.WithValue(ctx, logContextKey{}, l)
+}
+
+// FromContext returns the block log carried by ctx, if any
+func FromContext(ctx context.Context) *Log {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(logContextKey{}).(*Log)
+	return l
+}
+
+// Record records that engine ran into block on the log carried by ctx; blocks outside a job
+// context are dropped
+func Record(ctx context.Context, engine string, block BlockType) {
+	FromContext(ctx).Add(engine, block)
+}
@@ -0,0 +1,109 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"letraz-utils/internal/ats"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/salary"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/internal/timing"
+	"letraz-utils/pkg/models"
+)
+
+// boardScraper reads postings hosted on a known applicant tracking system from the ATS's
+// public JSON API, and hands every other URL, and postings the API fails on, to the engine
+type boardScraper struct {
+	Scraper
+	engine     string
+	registry   *ats.Registry
+	llmManager *llm.Manager
+	salary     *salary.Normalizer
+}
+
+// ScrapeJob reads the posting from its ATS API when the URL is on a known board, otherwise
+// scrapes it with the wrapped engine
+func (s *boardScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
+	provider, ok := s.registry.Detect(url)
+	if !ok {
+		return s.Scraper.ScrapeJob(ctx, url, options)
+	}
+
+	job, err := s.fetchPosting(ctx, url)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Board API failed, scraping the page instead", map[string]interface{}{
+			"url":      url,
+			"provider": provider,
+			"engine":   s.engine,
+			"error":    err.Error(),
+		})
+		return s.Scraper.ScrapeJob(ctx, url, options)
+	}
+
+	timeline.Record(ctx, timeline.EventEngineSelected, map[string]interface{}{
+		"engine":   "ats:" + provider,
+		"replaces": s.engine,
+	})
+	return job, nil
+}
+
+// FetchPage fetches with the wrapped engine when it can fetch pages; board APIs return
+// postings, not pages
+func (s *boardScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	fetcher, ok := s.Scraper.(PageFetcher)
+	if !ok {
+		return "", fmt.Errorf("%s engine cannot fetch pages", s.engine)
+	}
+	return fetcher.FetchPage(ctx, url, options)
+}
+
+// fetchPosting reads the posting from its ATS API. Lists the API does not structure under
+// recognizable headings are extracted from the posting's text by the LLM.
+func (s *boardScraper) fetchPosting(ctx context.Context, url string) (*models.Job, error) {
+	stop := timing.Start(ctx, timing.StageNavigation)
+	posting, err := s.registry.FetchPosting(ctx, url)
+	stop()
+	if err != nil {
+		return nil, err
+	}
+	job := posting.Job
+	if job.Title == "" {
+		return nil, errors.New("posting has no title")
+	}
+
+	if s.llmManager != nil && len(job.Requirements) == 0 && len(job.Responsibilities) == 0 && posting.Text != "" {
+		details, err := s.llmManager.ExtractJobFromDescription(ctx, posting.Text)
+		if err != nil {
+			logging.FromContext(ctx).Warn("Failed to extract details from board posting", map[string]interface{}{
+				"url":      url,
+				"provider": posting.Provider,
+				"error":    err.Error(),
+			})
+		} else {
+			job.Requirements = details.Requirements
+			job.Responsibilities = details.Responsibilities
+			if len(job.Benefits) == 0 {
+				job.Benefits = details.Benefits
+			}
+			job.OriginalLanguage = details.OriginalLanguage
+			if details.Description != "" {
+				job.Description = details.Description
+			}
+			if job.Salary.Min == 0 && job.Salary.Max == 0 {
+				job.Salary = details.Salary
+				job.Currency = details.Salary.Currency
+			}
+		}
+	}
+
+	s.salary.Apply(job)
+	logging.FromContext(ctx).Info("Read job posting from board API", map[string]interface{}{
+		"url":      url,
+		"provider": posting.Provider,
+		"title":    job.Title,
+	})
+	return job, nil
+}
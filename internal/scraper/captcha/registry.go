@@ -0,0 +1,370 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/metrics"
+)
+
+// Captcha provider names, as used in configuration and metrics
+const (
+	ProviderTwoCaptcha  = "2captcha"
+	ProviderCapSolver   = "capsolver"
+	ProviderAntiCaptcha = "anticaptcha"
+)
+
+// listCostPerSolve is the approximate list price of a reCAPTCHA v2 solve at each provider, in US
+// dollars, used when configuration sets no price
+var listCostPerSolve = map[string]float64{
+	ProviderTwoCaptcha:  0.003,
+	ProviderCapSolver:   0.0008,
+	ProviderAntiCaptcha: 0.002,
+}
+
+// balanceRecheckInterval is how long a provider that ran out of funds is skipped before it is
+// tried again, giving time for the account to be topped up
+const balanceRecheckInterval = 15 * time.Minute
+
+// providerSettings returns the settings of the named provider. The top-level API key belongs to
+// the primary provider when its own settings carry none.
+func providerSettings(cfg *config.Config, name string) config.CaptchaProvider {
+	settings := cfg.Scraper.Captcha.Providers[name]
+	if settings.APIKey == "" && name == cfg.Scraper.Captcha.Provider {
+		settings.APIKey = cfg.Scraper.Captcha.APIKey
+	}
+	return settings
+}
+
+// newProvider creates the named provider, reporting false for unknown names
+func newProvider(cfg *config.Config, name string) (Provider, bool) {
+	switch name {
+	case ProviderTwoCaptcha:
+		return NewTwoCaptchaSolver(cfg), true
+	case ProviderCapSolver:
+		return NewCapSolverSolver(cfg), true
+	case ProviderAntiCaptcha:
+		return NewAntiCaptchaSolver(cfg), true
+	default:
+		return nil, false
+	}
+}
+
+// providerEntry is a provider of the registry with its solve counters
+type providerEntry struct {
+	provider     Provider
+	costPerSolve float64
+	solveTime    *metrics.Histogram
+
+	mu                sync.Mutex
+	attempts          int64
+	solves            int64
+	failures          int64
+	outOfBalanceUntil time.Time
+}
+
+// Registry solves captchas with the configured provider, failing over to the next provider in
+// the chain when one errors or has run out of funds. It counts solves and their cost per provider.
+type Registry struct {
+	config    *config.Config
+	entries   []*providerEntry
+	logger    logging.Logger
+	mu        sync.Mutex
+	failovers int64
+}
+
+// NewRegistry creates the provider chain from configuration: the primary provider followed by
+// the failover providers. Unknown providers and providers without an API key are left out.
+func NewRegistry(cfg *config.Config) *Registry {
+	registry := &Registry{
+		config: cfg,
+		logger: logging.GetGlobalLogger(),
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range append([]string{cfg.Scraper.Captcha.Provider}, cfg.Scraper.Captcha.Failover...) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		settings := providerSettings(cfg, name)
+		if settings.APIKey == "" {
+			continue
+		}
+		provider, ok := newProvider(cfg, name)
+		if !ok {
+			registry.logger.Warn("Skipping unknown captcha provider", map[string]interface{}{
+				"provider": name,
+			})
+			continue
+		}
+		cost := settings.CostPerSolve
+		if cost <= 0 {
+			cost = listCostPerSolve[name]
+		}
+		registry.entries = append(registry.entries, &providerEntry{
+			provider:     provider,
+			costPerSolve: cost,
+			solveTime:    metrics.NewLatencyHistogram(),
+		})
+	}
+	return registry
+}
+
+// Providers returns the names of the providers in the chain, in the order they are tried
+func (r *Registry) Providers() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, len(r.entries))
+	for i, entry := range r.entries {
+		names[i] = entry.provider.Name()
+	}
+	return names
+}
+
+// SolveRecaptcha solves a reCAPTCHA challenge with the first provider of the chain that can
+func (r *Registry) SolveRecaptcha(ctx context.Context, siteKey, pageURL string) (string, error) {
+	return r.solve(ctx, "reCAPTCHA", func(provider Provider) (string, error) {
+		return provider.SolveRecaptcha(ctx, siteKey, pageURL)
+	})
+}
+
+// SolveTurnstile solves a Cloudflare Turnstile challenge with the first provider of the chain
+// that can
+func (r *Registry) SolveTurnstile(ctx context.Context, siteKey, pageURL string) (string, error) {
+	return r.solve(ctx, "Turnstile", func(provider Provider) (string, error) {
+		return provider.SolveTurnstile(ctx, siteKey, pageURL)
+	})
+}
+
+// IsHealthy reports whether a provider of the chain can solve captchas
+func (r *Registry) IsHealthy() bool {
+	if r == nil {
+		return false
+	}
+	for _, entry := range r.entries {
+		if entry.available(time.Now()) && entry.provider.IsHealthy() {
+			return true
+		}
+	}
+	return false
+}
+
+// solve tries each available provider in turn until one solves the captcha
+func (r *Registry) solve(ctx context.Context, kind string, solve func(Provider) (string, error)) (string, error) {
+	if r == nil || len(r.entries) == 0 {
+		return "", fmt.Errorf("no captcha provider configured")
+	}
+	if !r.config.Scraper.Captcha.EnableAutoSolve {
+		return "", fmt.Errorf("captcha auto-solve is disabled")
+	}
+
+	var lastErr error
+	tried := 0
+	for _, entry := range r.entries {
+		if !entry.available(time.Now()) {
+			continue
+		}
+		if tried > 0 {
+			r.mu.Lock()
+			r.failovers++
+			r.mu.Unlock()
+			r.logger.Warn("Captcha provider failed, trying the next provider", map[string]interface{}{
+				"kind":     kind,
+				"provider": entry.provider.Name(),
+				"error":    lastErr.Error(),
+			})
+		}
+		tried++
+
+		start := time.Now()
+		token, err := solve(entry.provider)
+		entry.record(time.Since(start), err)
+		if err == nil {
+			return token, nil
+		}
+		lastErr = err
+
+		if errors.Is(err, ErrInsufficientBalance) {
+			r.logger.Error("Captcha provider is out of funds, skipping it", map[string]interface{}{
+				"provider": entry.provider.Name(),
+				"duration": balanceRecheckInterval.String(),
+			})
+		}
+		if ctx.Err() != nil {
+			return "", err
+		}
+	}
+
+	if lastErr == nil {
+		return "", fmt.Errorf("every captcha provider is out of funds")
+	}
+	return "", lastErr
+}
+
+// available reports whether the provider may be tried, which it may unless it recently ran out
+// of funds
+func (e *providerEntry) available(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !e.outOfBalanceUntil.After(now)
+}
+
+// record counts a solve attempt and how long it took
+func (e *providerEntry) record(duration time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.attempts++
+	if err != nil {
+		e.failures++
+		if errors.Is(err, ErrInsufficientBalance) {
+			e.outOfBalanceUntil = time.Now().Add(balanceRecheckInterval)
+		}
+		return
+	}
+	e.solves++
+	e.solveTime.ObserveDuration(duration)
+}
+
+// providerStats is a snapshot of a provider's counters
+type providerStats struct {
+	Name         string
+	Attempts     int64
+	Solves       int64
+	Failures     int64
+	Cost         float64
+	OutOfBalance bool
+	SolveTime    metrics.HistogramSnapshot
+}
+
+// snapshot returns the provider's counters
+func (e *providerEntry) snapshot(now time.Time) providerStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return providerStats{
+		Name:         e.provider.Name(),
+		Attempts:     e.attempts,
+		Solves:       e.solves,
+		Failures:     e.failures,
+		Cost:         float64(e.solves) * e.costPerSolve,
+		OutOfBalance: e.outOfBalanceUntil.After(now),
+		SolveTime:    e.solveTime.Snapshot(),
+	}
+}
+
+// GetStats returns the solves, failures and spend of every provider for the monitoring server
+func (r *Registry) GetStats() map[string]interface{} {
+	if r == nil || len(r.entries) == 0 {
+		return map[string]interface{}{"enabled": false}
+	}
+	now := time.Now()
+
+	providers := make([]map[string]interface{}, 0, len(r.entries))
+	for _, entry := range r.entries {
+		stats := entry.snapshot(now)
+		providers = append(providers, map[string]interface{}{
+			"provider":       stats.Name,
+			"attempts":       stats.Attempts,
+			"solves":         stats.Solves,
+			"failures":       stats.Failures,
+			"cost_usd":       stats.Cost,
+			"cost_per_solve": entry.costPerSolve,
+			"out_of_balance": stats.OutOfBalance,
+		})
+	}
+
+	r.mu.Lock()
+	failovers := r.failovers
+	r.mu.Unlock()
+
+	return map[string]interface{}{
+		"enabled":   r.config.Scraper.Captcha.EnableAutoSolve,
+		"providers": providers,
+		"failovers": failovers,
+	}
+}
+
+// CollectPrometheus exports solve attempts, outcomes, spend and solve time per provider
+func (r *Registry) CollectPrometheus(w *metrics.PrometheusWriter) {
+	if r == nil || len(r.entries) == 0 {
+		return
+	}
+	now := time.Now()
+
+	stats := make([]providerStats, len(r.entries))
+	labels := make([]metrics.Label, len(r.entries))
+	for i, entry := range r.entries {
+		stats[i] = entry.snapshot(now)
+		labels[i] = metrics.L("provider", stats[i].Name)
+	}
+
+	for i := range stats {
+		w.Counter("letraz_captcha_attempts_total", "Captcha solves sent to the provider.", float64(stats[i].Attempts), labels[i])
+	}
+	for i := range stats {
+		w.Counter("letraz_captcha_solves_total", "Captchas the provider solved.", float64(stats[i].Solves), labels[i])
+	}
+	for i := range stats {
+		w.Counter("letraz_captcha_failures_total", "Captcha solves the provider failed.", float64(stats[i].Failures), labels[i])
+	}
+	for i := range stats {
+		w.Counter("letraz_captcha_cost_usd_total", "Estimated spend on solved captchas, in US dollars.", stats[i].Cost, labels[i])
+	}
+	for i := range stats {
+		out := 0.0
+		if stats[i].OutOfBalance {
+			out = 1
+		}
+		w.Gauge("letraz_captcha_out_of_balance", "Whether the provider is skipped after running out of funds.", out, labels[i])
+	}
+	for i := range stats {
+		w.Histogram("letraz_captcha_solve_seconds", "Time the provider took to solve a captcha.", stats[i].SolveTime, labels[i])
+	}
+
+	r.mu.Lock()
+	failovers := r.failovers
+	r.mu.Unlock()
+	w.Counter("letraz_captcha_failovers_total", "Captcha solves moved on to the next provider.", float64(failovers))
+}
+
+// Global registry instance
+var (
+	globalRegistry *Registry
+	globalMu       sync.RWMutex
+)
+
+// InitializeGlobalRegistry creates the global captcha provider registry from configuration
+func InitializeGlobalRegistry(cfg *config.Config) *Registry {
+	registry := NewRegistry(cfg)
+
+	globalMu.Lock()
+	globalRegistry = registry
+	globalMu.Unlock()
+
+	if len(registry.entries) == 0 {
+		registry.logger.Warn("No captcha provider has an API key - captcha solving is disabled", nil)
+	} else {
+		registry.logger.Info("Captcha providers initialized", map[string]interface{}{
+			"providers": registry.Providers(),
+		})
+	}
+	return registry
+}
+
+// GetGlobalRegistry returns the global captcha provider registry, or nil when it was not initialized
+func GetGlobalRegistry() *Registry {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalRegistry
+}
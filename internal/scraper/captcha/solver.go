@@ -2,6 +2,7 @@ package captcha
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,9 +10,13 @@ import (
 	"github.com/2captcha/2captcha-go"
 	"letraz-utils/internal/config"
 	"letraz-utils/internal/logging"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/utils"
 )
 
+// ErrInsufficientBalance marks a solve that failed because the provider account has run out of funds
+var ErrInsufficientBalance = errors.New("captcha provider balance exhausted")
+
 // CaptchaSolver interface for different captcha solving services
 type CaptchaSolver interface {
 	SolveRecaptcha(ctx context.Context, siteKey, pageURL string) (string, error)
@@ -19,9 +24,17 @@ type CaptchaSolver interface {
 	IsHealthy() bool
 }
 
+// Provider is a captcha solving service that can be placed in the Registry
+type Provider interface {
+	CaptchaSolver
+	Name() string
+	Balance(ctx context.Context) (float64, error)
+}
+
 // TwoCaptchaSolver implements 2CAPTCHA service integration using official library
 type TwoCaptchaSolver struct {
 	config *config.Config
+	apiKey string
 	client *api2captcha.Client
 	logger logging.Logger
 }
@@ -29,16 +42,17 @@ type TwoCaptchaSolver struct {
 // NewTwoCaptchaSolver creates a new 2CAPTCHA solver instance
 func NewTwoCaptchaSolver(cfg *config.Config) *TwoCaptchaSolver {
 	logger := logging.GetGlobalLogger()
+	apiKey := providerSettings(cfg, ProviderTwoCaptcha).APIKey
 
-	if cfg.Scraper.Captcha.APIKey == "" {
+	if apiKey == "" {
 		logger.Warn("2CAPTCHA API key not configured - captcha solving will be disabled", nil)
 	} else {
 		logger.Info("2CAPTCHA solver initialized with API key", map[string]interface{}{
-			"api_key_length": len(cfg.Scraper.Captcha.APIKey),
+			"api_key_length": len(apiKey),
 		})
 	}
 
-	client := api2captcha.NewClient(cfg.Scraper.Captcha.APIKey)
+	client := api2captcha.NewClient(apiKey)
 
 	// Configure timeouts
 	client.DefaultTimeout = int(cfg.Scraper.Captcha.Timeout.Seconds())
@@ -54,18 +68,32 @@ func NewTwoCaptchaSolver(cfg *config.Config) *TwoCaptchaSolver {
 
 	return &TwoCaptchaSolver{
 		config: cfg,
+		apiKey: apiKey,
 		client: client,
 		logger: logger,
 	}
 }
 
+// Name returns the provider name used in configuration and metrics
+func (tcs *TwoCaptchaSolver) Name() string {
+	return ProviderTwoCaptcha
+}
+
+// Balance returns the funds left on the 2CAPTCHA account, in US dollars
+func (tcs *TwoCaptchaSolver) Balance(ctx context.Context) (float64, error) {
+	if tcs.apiKey == "" {
+		return 0, fmt.Errorf("2CAPTCHA API key not configured")
+	}
+	return tcs.client.GetBalance()
+}
+
 // SolveRecaptcha solves a reCAPTCHA challenge using 2CAPTCHA service
 func (tcs *TwoCaptchaSolver) SolveRecaptcha(ctx context.Context, siteKey, pageURL string) (string, error) {
 	if !tcs.config.Scraper.Captcha.EnableAutoSolve {
 		return "", fmt.Errorf("captcha auto-solve is disabled")
 	}
 
-	if tcs.config.Scraper.Captcha.APIKey == "" {
+	if tcs.apiKey == "" {
 		return "", fmt.Errorf("2CAPTCHA API key not configured")
 	}
 
@@ -75,6 +103,7 @@ func (tcs *TwoCaptchaSolver) SolveRecaptcha(ctx context.Context, siteKey, pageUR
 	})
 
 	startTime := time.Now()
+	defer timing.Start(ctx, timing.StageCaptcha)()
 
 	// Create reCAPTCHA v2 task
 	captcha := api2captcha.ReCaptcha{
@@ -91,7 +120,7 @@ func (tcs *TwoCaptchaSolver) SolveRecaptcha(ctx context.Context, siteKey, pageUR
 			"page_url": pageURL,
 			"error":    err.Error(),
 		})
-		return "", fmt.Errorf("failed to solve reCAPTCHA: %w", err)
+		return "", fmt.Errorf("failed to solve reCAPTCHA: %w", twoCaptchaError(err))
 	}
 
 	solvingTime := time.Since(startTime)
@@ -110,7 +139,7 @@ func (tcs *TwoCaptchaSolver) SolveTurnstile(ctx context.Context, siteKey, pageUR
 		return "", fmt.Errorf("captcha auto-solve is disabled")
 	}
 
-	if tcs.config.Scraper.Captcha.APIKey == "" {
+	if tcs.apiKey == "" {
 		return "", fmt.Errorf("2CAPTCHA API key not configured")
 	}
 
@@ -120,6 +149,7 @@ func (tcs *TwoCaptchaSolver) SolveTurnstile(ctx context.Context, siteKey, pageUR
 	})
 
 	startTime := time.Now()
+	defer timing.Start(ctx, timing.StageCaptcha)()
 
 	// Create Cloudflare Turnstile task
 	captcha := api2captcha.CloudflareTurnstile{
@@ -138,7 +168,7 @@ func (tcs *TwoCaptchaSolver) SolveTurnstile(ctx context.Context, siteKey, pageUR
 			"error":      err.Error(),
 			"error_type": fmt.Sprintf("%T", err),
 		})
-		return "", fmt.Errorf("failed to solve Cloudflare Turnstile: %w", err)
+		return "", fmt.Errorf("failed to solve Cloudflare Turnstile: %w", twoCaptchaError(err))
 	}
 
 	solvingTime := time.Since(startTime)
@@ -153,7 +183,7 @@ func (tcs *TwoCaptchaSolver) SolveTurnstile(ctx context.Context, siteKey, pageUR
 
 // IsHealthy checks if the 2CAPTCHA service is available
 func (tcs *TwoCaptchaSolver) IsHealthy() bool {
-	if tcs.config.Scraper.Captcha.APIKey == "" {
+	if tcs.apiKey == "" {
 		tcs.logger.Debug("2CAPTCHA health check failed: no API key configured", nil)
 		return false
 	}
@@ -163,7 +193,7 @@ func (tcs *TwoCaptchaSolver) IsHealthy() bool {
 	if err != nil {
 		tcs.logger.Error("2CAPTCHA health check failed - API call error", map[string]interface{}{
 			"error":          err.Error(),
-			"api_key_length": len(tcs.config.Scraper.Captcha.APIKey),
+			"api_key_length": len(tcs.apiKey),
 		})
 		return false
 	}
@@ -174,6 +204,14 @@ func (tcs *TwoCaptchaSolver) IsHealthy() bool {
 	return balance >= 0 // Allow zero balance for now
 }
 
+// twoCaptchaError marks 2CAPTCHA's zero balance error as ErrInsufficientBalance
+func twoCaptchaError(err error) error {
+	if strings.Contains(err.Error(), "ZERO_BALANCE") {
+		return fmt.Errorf("%w: %v", ErrInsufficientBalance, err)
+	}
+	return err
+}
+
 // DetectCaptcha detects if a page contains a captcha challenge
 func DetectCaptcha(pageContent string) (bool, string, error) {
 	pageContentLower := strings.ToLower(pageContent)
@@ -0,0 +1,201 @@
+package captcha
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/timing"
+)
+
+// taskPollInterval is how often a created task is checked for its solution
+const taskPollInterval = 3 * time.Second
+
+// TaskAPISolver solves captchas with a service speaking the createTask/getTaskResult protocol
+// that Anti-Captcha introduced and CapSolver adopted. The services differ only in their address
+// and the names of their task types.
+type TaskAPISolver struct {
+	name          string
+	baseURL       string
+	recaptchaTask string
+	turnstileTask string
+	apiKey        string
+	config        *config.Config
+	client        *http.Client
+	logger        logging.Logger
+}
+
+// NewCapSolverSolver creates a CapSolver solver instance
+func NewCapSolverSolver(cfg *config.Config) *TaskAPISolver {
+	return newTaskAPISolver(cfg, ProviderCapSolver, "https://api.capsolver.com",
+		"ReCaptchaV2TaskProxyLess", "AntiTurnstileTaskProxyLess")
+}
+
+// NewAntiCaptchaSolver creates an Anti-Captcha solver instance
+func NewAntiCaptchaSolver(cfg *config.Config) *TaskAPISolver {
+	return newTaskAPISolver(cfg, ProviderAntiCaptcha, "https://api.anti-captcha.com",
+		"RecaptchaV2TaskProxyless", "TurnstileTaskProxyless")
+}
+
+func newTaskAPISolver(cfg *config.Config, name, baseURL, recaptchaTask, turnstileTask string) *TaskAPISolver {
+	return &TaskAPISolver{
+		name:          name,
+		baseURL:       baseURL,
+		recaptchaTask: recaptchaTask,
+		turnstileTask: turnstileTask,
+		apiKey:        providerSettings(cfg, name).APIKey,
+		config:        cfg,
+		client:        &http.Client{Timeout: 30 * time.Second},
+		logger:        logging.GetGlobalLogger(),
+	}
+}
+
+// taskResponse is the envelope of every createTask, getTaskResult and getBalance response
+type taskResponse struct {
+	ErrorID          int                    `json:"errorId"`
+	ErrorCode        string                 `json:"errorCode"`
+	ErrorDescription string                 `json:"errorDescription"`
+	TaskID           json.RawMessage        `json:"taskId"` // a number at Anti-Captcha, a string at CapSolver
+	Status           string                 `json:"status"`
+	Solution         map[string]interface{} `json:"solution"`
+	Balance          float64                `json:"balance"`
+}
+
+// Name returns the provider name used in configuration and metrics
+func (ts *TaskAPISolver) Name() string {
+	return ts.name
+}
+
+// SolveRecaptcha solves a reCAPTCHA v2 challenge
+func (ts *TaskAPISolver) SolveRecaptcha(ctx context.Context, siteKey, pageURL string) (string, error) {
+	return ts.solve(ctx, ts.recaptchaTask, "gRecaptchaResponse", siteKey, pageURL)
+}
+
+// SolveTurnstile solves a Cloudflare Turnstile challenge
+func (ts *TaskAPISolver) SolveTurnstile(ctx context.Context, siteKey, pageURL string) (string, error) {
+	return ts.solve(ctx, ts.turnstileTask, "token", siteKey, pageURL)
+}
+
+// Balance returns the funds left on the account, in US dollars
+func (ts *TaskAPISolver) Balance(ctx context.Context) (float64, error) {
+	if ts.apiKey == "" {
+		return 0, fmt.Errorf("%s API key not configured", ts.name)
+	}
+	response, err := ts.call(ctx, "getBalance", map[string]interface{}{})
+	if err != nil {
+		return 0, err
+	}
+	return response.Balance, nil
+}
+
+// IsHealthy reports whether the account can be reached and has funds left
+func (ts *TaskAPISolver) IsHealthy() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	balance, err := ts.Balance(ctx)
+	if err != nil {
+		ts.logger.Debug("Captcha provider health check failed", map[string]interface{}{
+			"provider": ts.name,
+			"error":    err.Error(),
+		})
+		return false
+	}
+	return balance > 0
+}
+
+// solve creates a task and polls for its solution until it is ready or the captcha timeout passes
+func (ts *TaskAPISolver) solve(ctx context.Context, taskType, solutionField, siteKey, pageURL string) (string, error) {
+	if !ts.config.Scraper.Captcha.EnableAutoSolve {
+		return "", fmt.Errorf("captcha auto-solve is disabled")
+	}
+	if ts.apiKey == "" {
+		return "", fmt.Errorf("%s API key not configured", ts.name)
+	}
+	defer timing.Start(ctx, timing.StageCaptcha)()
+
+	if timeout := ts.config.Scraper.Captcha.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	created, err := ts.call(ctx, "createTask", map[string]interface{}{
+		"task": map[string]interface{}{
+			"type":       taskType,
+			"websiteURL": pageURL,
+			"websiteKey": siteKey,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s task: %w", taskType, err)
+	}
+
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("%s task was not solved in time: %w", taskType, ctx.Err())
+		case <-ticker.C:
+		}
+
+		result, err := ts.call(ctx, "getTaskResult", map[string]interface{}{"taskId": created.TaskID})
+		if err != nil {
+			return "", fmt.Errorf("failed to solve %s task: %w", taskType, err)
+		}
+		if result.Status != "ready" {
+			continue
+		}
+		token, _ := result.Solution[solutionField].(string)
+		if token == "" {
+			return "", fmt.Errorf("%s returned a solution without %s", ts.name, solutionField)
+		}
+		return token, nil
+	}
+}
+
+// call posts a request to method with the client key added, returning an error for responses
+// that carry one; running out of funds is reported as ErrInsufficientBalance
+func (ts *TaskAPISolver) call(ctx context.Context, method string, payload map[string]interface{}) (*taskResponse, error) {
+	payload["clientKey"] = ts.apiKey
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.baseURL+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", ts.name, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", ts.name, err)
+	}
+	var response taskResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("%s returned status %d with an unreadable body", ts.name, resp.StatusCode)
+	}
+	if response.ErrorID != 0 {
+		if strings.Contains(response.ErrorCode, "BALANCE") {
+			return nil, fmt.Errorf("%w: %s %s", ErrInsufficientBalance, ts.name, response.ErrorCode)
+		}
+		return nil, fmt.Errorf("%s error %s: %s", ts.name, response.ErrorCode, response.ErrorDescription)
+	}
+	return &response, nil
+}
@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+
+	"letraz-utils/internal/chaos"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// chaosScraper delays and fails scrape attempts according to the fault injector before
+// delegating to the real engine
+type chaosScraper struct {
+	Scraper
+	engine   string
+	injector *chaos.Injector
+}
+
+// ScrapeJob injects faults, then scrapes with the wrapped engine
+func (s *chaosScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.Scraper.ScrapeJob(ctx, url, options)
+}
+
+// ScrapeJobLegacy injects faults, then scrapes with the wrapped engine
+func (s *chaosScraper) ScrapeJobLegacy(ctx context.Context, url string, options *models.ScrapeOptions) (*models.JobPosting, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.Scraper.ScrapeJobLegacy(ctx, url, options)
+}
+
+// FetchPage injects faults, then fetches with the wrapped engine when it can fetch pages
+func (s *chaosScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	fetcher, ok := s.Scraper.(PageFetcher)
+	if !ok {
+		return "", fmt.Errorf("%s engine cannot fetch pages", s.engine)
+	}
+	if err := s.inject(ctx); err != nil {
+		return "", err
+	}
+	return fetcher.FetchPage(ctx, url, options)
+}
+
+// inject applies the configured latency and engine failure probability
+func (s *chaosScraper) inject(ctx context.Context) error {
+	if err := s.injector.Delay(ctx, chaos.PointEngine); err != nil {
+		return err
+	}
+	if err := s.injector.Fail(chaos.PointEngine); err != nil {
+		return utils.NewScrapingError(fmt.Sprintf("%s engine: %v", s.engine, err))
+	}
+	return nil
+}
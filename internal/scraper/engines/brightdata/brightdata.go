@@ -13,6 +13,8 @@ import (
 	"letraz-utils/internal/llm"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/salary"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -22,20 +24,15 @@ type BrightDataScraper struct {
 	config     *config.Config
 	llmManager *llm.Manager
 	httpClient *http.Client
+	salary     *salary.Normalizer
 	logger     types.Logger
 }
 
-// BrightDataRequest represents the request structure for BrightData API
+// BrightDataRequest is one input of a BrightData dataset collection
 type BrightDataRequest struct {
 	URL string `json:"url"`
 }
 
-// BrightDataResponse represents the response from BrightData API
-type BrightDataResponse struct {
-	Data []interface{} `json:"data,omitempty"`
-	// BrightData returns varying JSON structures, so we'll handle it as interface{}
-}
-
 // NewBrightDataScraper creates a new BrightData scraper instance
 func NewBrightDataScraper(cfg *config.Config, llmManager *llm.Manager) *BrightDataScraper {
 	logger := logging.GetGlobalLogger()
@@ -55,15 +52,17 @@ func NewBrightDataScraper(cfg *config.Config, llmManager *llm.Manager) *BrightDa
 		config:     cfg,
 		llmManager: llmManager,
 		httpClient: httpClient,
+		salary:     salary.NewNormalizer(cfg),
 		logger:     logger,
 	}
 }
 
 // ScrapeJob scrapes a LinkedIn job posting using BrightData API
 func (bs *BrightDataScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	bs.logger.Info("Starting LinkedIn job scrape with BrightData engine", map[string]interface{}{
+	logger.Info("Starting LinkedIn job scrape with BrightData engine", map[string]interface{}{
 		"url":    url,
 		"engine": "brightdata",
 	})
@@ -82,46 +81,38 @@ func (bs *BrightDataScraper) ScrapeJob(ctx context.Context, url string, options
 	// Extract job ID for logging
 	jobID, _ := utils.ExtractLinkedInJobID(publicURL)
 
-	bs.logger.Info("Processing LinkedIn job URL", map[string]interface{}{
+	logger.Info("Processing LinkedIn job URL", map[string]interface{}{
 		"original_url": url,
 		"public_url":   publicURL,
 		"job_id":       jobID,
 	})
 
-	// Call BrightData API
-	jsonData, err := bs.callBrightDataAPI(ctx, publicURL)
-	if err != nil {
-		return nil, fmt.Errorf("BrightData API call failed: %w", err)
-	}
-
-	// Convert JSON response to string for LLM processing
-	jsonString, err := json.Marshal(jsonData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal BrightData response: %w", err)
-	}
-
-	bs.logger.Info("Received response from BrightData, sending to LLM for processing", map[string]interface{}{
-		"response_size": len(jsonString),
-		"job_id":        jobID,
-	})
-
-	// Use LLM to extract job information from JSON data
-	job, err := bs.llmManager.ExtractJobData(ctx, string(jsonString), publicURL)
+	record, err := bs.collect(ctx, publicURL)
 	if err != nil {
 		// Don't wrap CustomError types so they can be properly handled upstream
 		if customErr, ok := err.(*utils.CustomError); ok {
 			return nil, customErr
 		}
-		return nil, fmt.Errorf("LLM job extraction failed: %w", err)
+		return nil, fmt.Errorf("BrightData collection failed: %w", err)
+	}
+
+	if record.Error != "" {
+		if record.ErrorCode == "dead_page" {
+			return nil, utils.NewNotJobPostingError(fmt.Sprintf("LinkedIn job %s is no longer available", jobID))
+		}
+		return nil, utils.NewScrapingError(fmt.Sprintf("BrightData could not collect %s: %s", publicURL, record.Error))
 	}
 
-	// Ensure the job URL is set correctly
-	if job.JobURL == "" {
-		job.JobURL = publicURL
+	job := record.job(publicURL)
+	if job.Title == "" || job.CompanyName == "" {
+		return nil, utils.NewNotJobPostingError(fmt.Sprintf("BrightData record for %s has no job title or company", publicURL))
 	}
 
+	bs.addDescriptionDetails(ctx, job, record)
+	bs.salary.Apply(job)
+
 	processingTime := time.Since(startTime)
-	bs.logger.Info("LinkedIn job scrape completed successfully", map[string]interface{}{
+	logger.Info("LinkedIn job scrape completed successfully", map[string]interface{}{
 		"url":             publicURL,
 		"job_id":          jobID,
 		"title":           job.Title,
@@ -138,53 +129,168 @@ func (bs *BrightDataScraper) ScrapeJobLegacy(ctx context.Context, url string, op
 	return nil, fmt.Errorf("legacy scraping not supported by BrightData engine")
 }
 
-// callBrightDataAPI makes the HTTP request to BrightData API
-func (bs *BrightDataScraper) callBrightDataAPI(ctx context.Context, url string) (interface{}, error) {
-	// Prepare request payload
-	requestData := []BrightDataRequest{
-		{URL: url},
+// addDescriptionDetails fills the job's requirements, responsibilities and benefits, which the
+// dataset only has as free text, by extracting them from the posting's description with the
+// LLM. The structured fields of the record are kept; if the LLM fails the job is returned
+// without the lists.
+func (bs *BrightDataScraper) addDescriptionDetails(ctx context.Context, job *models.Job, record *jobRecord) {
+	description := record.description()
+	if description == "" || bs.llmManager == nil {
+		return
+	}
+
+	details, err := bs.llmManager.ExtractJobFromDescription(ctx, description)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to extract details from LinkedIn job description", map[string]interface{}{
+			"url":   job.JobURL,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	job.Requirements = details.Requirements
+	job.Responsibilities = details.Responsibilities
+	job.Benefits = details.Benefits
+	job.OriginalLanguage = details.OriginalLanguage
+	if details.Description != "" {
+		job.Description = details.Description
+	}
+	if job.Salary.Min == 0 && job.Salary.Max == 0 {
+		job.Salary = details.Salary
+		job.Currency = details.Salary.Currency
+	}
+}
+
+// collect triggers a collection of url from the dataset, waits for its snapshot and returns the
+// snapshot's record
+func (bs *BrightDataScraper) collect(ctx context.Context, url string) (*jobRecord, error) {
+	logger := logging.FromContext(ctx)
+	// The dataset collection stands in for browser navigation in the job timings
+	defer timing.Start(ctx, timing.StageNavigation)()
+
+	snapshotID, err := bs.trigger(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("BrightData collection triggered", map[string]interface{}{
+		"url":         url,
+		"snapshot_id": snapshotID,
+	})
+
+	deadline := time.Now().Add(bs.config.BrightData.CollectionTimeout)
+	for {
+		records, ready, err := bs.fetchSnapshot(ctx, snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if ready {
+			if len(records) == 0 {
+				return nil, utils.NewScrapingError(fmt.Sprintf("BrightData snapshot %s for %s is empty", snapshotID, url))
+			}
+			logger.Info("BrightData snapshot ready", map[string]interface{}{
+				"url":         url,
+				"snapshot_id": snapshotID,
+			})
+			return &records[0], nil
+		}
+
+		if time.Now().Add(bs.config.BrightData.PollInterval).After(deadline) {
+			return nil, utils.NewEngineTimeoutError(fmt.Sprintf("BrightData snapshot %s for %s was not ready within %s", snapshotID, url, bs.config.BrightData.CollectionTimeout))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(bs.config.BrightData.PollInterval):
+		}
 	}
+}
 
-	jsonPayload, err := json.Marshal(requestData)
+// trigger starts a dataset collection for url and returns its snapshot ID
+func (bs *BrightDataScraper) trigger(ctx context.Context, url string) (string, error) {
+	payload, err := json.Marshal([]BrightDataRequest{{URL: url}})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request data: %w", err)
+		return "", fmt.Errorf("failed to marshal request data: %w", err)
 	}
 
-	// Build API URL
-	apiURL := fmt.Sprintf("%s/datasets/v3/scrape?dataset_id=%s&include_errors=true",
+	apiURL := fmt.Sprintf("%s/datasets/v3/trigger?dataset_id=%s&include_errors=true",
 		bs.config.BrightData.BaseURL,
 		bs.config.BrightData.DatasetID)
 
-	bs.logger.Debug("Making BrightData API request", map[string]interface{}{
-		"url":        url,
-		"api_url":    apiURL,
-		"dataset_id": bs.config.BrightData.DatasetID,
-	})
+	_, body, err := bs.do(ctx, http.MethodPost, apiURL, payload)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		SnapshotID string `json:"snapshot_id"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse trigger response: %w", err)
+	}
+	if response.SnapshotID == "" {
+		return "", fmt.Errorf("BrightData trigger response has no snapshot ID: %s", string(body))
+	}
+	return response.SnapshotID, nil
+}
+
+// fetchSnapshot downloads the records of a snapshot, reporting ready as false while the
+// collection is still running
+func (bs *BrightDataScraper) fetchSnapshot(ctx context.Context, snapshotID string) ([]jobRecord, bool, error) {
+	apiURL := fmt.Sprintf("%s/datasets/v3/snapshot/%s?format=json", bs.config.BrightData.BaseURL, snapshotID)
+
+	status, body, err := bs.do(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	// The snapshot endpoint answers 202 with a status message until the collection finishes
+	if status == http.StatusAccepted {
+		return nil, false, nil
+	}
+
+	var records []jobRecord
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, false, fmt.Errorf("failed to parse snapshot %s: %w", snapshotID, err)
+	}
+	return records, true, nil
+}
+
+// do sends an API request, retrying network failures and server errors with exponential
+// backoff, and returns the status code and body of a successful response
+func (bs *BrightDataScraper) do(ctx context.Context, method, apiURL string, payload []byte) (int, []byte, error) {
+	logger := logging.FromContext(ctx)
 
-	// Execute request with retry logic
 	var lastErr error
 	maxRetries := bs.config.BrightData.MaxRetries
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			bs.logger.Debug("Retrying BrightData API request", map[string]interface{}{
+			logger.Debug("Retrying BrightData API request", map[string]interface{}{
 				"attempt": attempt + 1,
-				"url":     url,
+				"api_url": apiURL,
 			})
 
 			// Exponential backoff
 			backoffDelay := time.Duration(1<<uint(attempt-1)) * time.Second
-			time.Sleep(backoffDelay)
+			select {
+			case <-ctx.Done():
+				return 0, nil, ctx.Err()
+			case <-time.After(backoffDelay):
+			}
 		}
 
-		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonPayload))
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+			return 0, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
 		}
-
-		// Set headers
-		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", bs.config.BrightData.APIKey))
 
 		resp, err := bs.httpClient.Do(req)
@@ -193,17 +299,14 @@ func (bs *BrightDataScraper) callBrightDataAPI(ctx context.Context, url string)
 			continue
 		}
 
-		// Read response body
 		body, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
-
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
 			continue
 		}
 
-		// Check HTTP status
-		if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			lastErr = fmt.Errorf("BrightData API returned status %d: %s", resp.StatusCode, string(body))
 
 			// Don't retry on client errors (4xx)
@@ -213,23 +316,10 @@ func (bs *BrightDataScraper) callBrightDataAPI(ctx context.Context, url string)
 			continue
 		}
 
-		// Parse JSON response
-		var response interface{}
-		if err := json.Unmarshal(body, &response); err != nil {
-			lastErr = fmt.Errorf("failed to parse JSON response: %w", err)
-			continue
-		}
-
-		bs.logger.Info("BrightData API request successful", map[string]interface{}{
-			"url":           url,
-			"response_size": len(body),
-			"status_code":   resp.StatusCode,
-		})
-
-		return response, nil
+		return resp.StatusCode, body, nil
 	}
 
-	return nil, fmt.Errorf("BrightData API failed after %d attempts: %v", maxRetries+1, lastErr)
+	return 0, nil, fmt.Errorf("BrightData API failed after %d attempts: %v", maxRetries+1, lastErr)
 }
 
 // Cleanup releases any resources used by the scraper
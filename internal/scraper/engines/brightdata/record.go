@@ -0,0 +1,83 @@
+package brightdata
+
+import (
+	"strings"
+
+	"letraz-utils/internal/salary"
+	"letraz-utils/pkg/models"
+)
+
+// maxDescriptionLength bounds the job summary used as a job's description when the LLM does
+// not provide a shorter one
+const maxDescriptionLength = 1000
+
+// jobRecord is one record of the LinkedIn job postings dataset. Records for URLs that could not
+// be collected carry only the input URL and an error.
+type jobRecord struct {
+	URL                     string `json:"url"`
+	JobPostingID            string `json:"job_posting_id"`
+	JobTitle                string `json:"job_title"`
+	CompanyName             string `json:"company_name"`
+	JobLocation             string `json:"job_location"`
+	JobSummary              string `json:"job_summary"`
+	JobDescriptionFormatted string `json:"job_description_formatted"`
+	JobSeniorityLevel       string `json:"job_seniority_level"`
+	JobEmploymentType       string `json:"job_employment_type"`
+	BaseSalary              *struct {
+		MinAmount     float64 `json:"min_amount"`
+		MaxAmount     float64 `json:"max_amount"`
+		Currency      string  `json:"currency"`
+		PaymentPeriod string  `json:"payment_period"`
+	} `json:"base_salary"`
+
+	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"`
+}
+
+// job maps the record's structured fields to a job. Requirements, responsibilities and benefits
+// are only present in the free-text description and are left to the LLM.
+func (r *jobRecord) job(url string) *models.Job {
+	job := &models.Job{
+		Title:            strings.TrimSpace(r.JobTitle),
+		JobURL:           url,
+		CompanyName:      strings.TrimSpace(r.CompanyName),
+		Location:         strings.TrimSpace(r.JobLocation),
+		Description:      truncate(strings.TrimSpace(r.JobSummary), maxDescriptionLength),
+		Requirements:     []string{},
+		Responsibilities: []string{},
+		Benefits:         []string{},
+	}
+
+	if r.BaseSalary != nil {
+		job.Salary = models.Salary{
+			Currency: strings.ToUpper(r.BaseSalary.Currency),
+			Min:      r.BaseSalary.MinAmount,
+			Max:      r.BaseSalary.MaxAmount,
+			Period:   salary.NormalizePeriod(r.BaseSalary.PaymentPeriod),
+		}
+		job.Currency = job.Salary.Currency
+	}
+
+	return job
+}
+
+// description returns the full text of the posting for the LLM, preferring the formatted
+// description, which keeps the posting's lists
+func (r *jobRecord) description() string {
+	if r.JobDescriptionFormatted != "" {
+		return r.JobDescriptionFormatted
+	}
+	return r.JobSummary
+}
+
+// truncate shortens s to at most max bytes, cutting at the last space before the limit
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[:max]
+	if i := strings.LastIndexByte(s, ' '); i > 0 {
+		s = s[:i]
+	}
+	return strings.ToValidUTF8(s, "") + "..."
+}
@@ -0,0 +1,300 @@
+package firecrawl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/utils"
+)
+
+// BatchPage is the outcome of one URL of a batch scrape: its content, markdown or HTML as
+// configured in the Firecrawl formats, or why Firecrawl could not scrape it
+type BatchPage struct {
+	URL     string
+	Content string
+	Error   string
+}
+
+// BatchClient scrapes several URLs as one job of Firecrawl's batch scrape API, which costs one
+// submission and a few status checks instead of a request per URL
+type BatchClient struct {
+	config     *config.Config
+	httpClient *http.Client
+}
+
+// NewBatchClient creates a batch scrape client
+func NewBatchClient(cfg *config.Config) *BatchClient {
+	return &BatchClient{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.Firecrawl.Timeout},
+	}
+}
+
+// batchDocument is a scraped page in a batch status response
+type batchDocument struct {
+	Markdown string `json:"markdown"`
+	HTML     string `json:"html"`
+	Metadata struct {
+		SourceURL  string `json:"sourceURL"`
+		URL        string `json:"url"`
+		StatusCode int    `json:"statusCode"`
+		Error      string `json:"error"`
+	} `json:"metadata"`
+}
+
+// batchStatus is the status of a batch scrape job
+type batchStatus struct {
+	Status      string          `json:"status"`
+	Total       int             `json:"total"`
+	Completed   int             `json:"completed"`
+	CreditsUsed int             `json:"creditsUsed"`
+	Next        string          `json:"next"`
+	Data        []batchDocument `json:"data"`
+}
+
+// Scrape submits urls as one batch scrape job, checks its status every poll interval until it
+// completes, and returns a page per URL in the order of urls. The job is cancelled when ctx ends
+// or the batch timeout passes first.
+func (b *BatchClient) Scrape(ctx context.Context, urls []string) ([]BatchPage, error) {
+	logger := logging.FromContext(ctx)
+
+	id, invalid, err := b.submit(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Firecrawl batch scrape submitted", map[string]interface{}{
+		"batch_id":     id,
+		"url_count":    len(urls),
+		"invalid_urls": len(invalid),
+	})
+
+	status, err := b.await(ctx, id)
+	if err != nil {
+		b.cancel(id)
+		return nil, err
+	}
+
+	docs := status.Data
+	for next := status.Next; next != ""; {
+		var page batchStatus
+		if err := b.getJSON(ctx, next, &page); err != nil {
+			return nil, fmt.Errorf("failed to read Firecrawl batch %s results: %w", id, err)
+		}
+		docs = append(docs, page.Data...)
+		next = page.Next
+	}
+
+	logger.Info("Firecrawl batch scrape completed", map[string]interface{}{
+		"batch_id":     id,
+		"pages":        len(docs),
+		"credits_used": status.CreditsUsed,
+	})
+	return matchPages(urls, invalid, docs), nil
+}
+
+// submit starts a batch scrape job and returns its ID and the URLs Firecrawl refused
+func (b *BatchClient) submit(ctx context.Context, urls []string) (string, []string, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"urls":              urls,
+		"formats":           b.config.Firecrawl.Formats,
+		"onlyMainContent":   true,
+		"ignoreInvalidURLs": true,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal batch request: %w", err)
+	}
+
+	body, err := b.do(ctx, http.MethodPost, b.endpoint(""), payload)
+	if err != nil {
+		return "", nil, err
+	}
+	var response struct {
+		Success     bool     `json:"success"`
+		ID          string   `json:"id"`
+		InvalidURLs []string `json:"invalidURLs"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", nil, fmt.Errorf("failed to parse batch scrape response: %w", err)
+	}
+	if !response.Success || response.ID == "" {
+		return "", nil, utils.NewScrapingError("Firecrawl did not accept the batch scrape: " + truncateForLog(string(body), 300))
+	}
+	return response.ID, response.InvalidURLs, nil
+}
+
+// await checks the status of a batch every poll interval until it completes
+func (b *BatchClient) await(ctx context.Context, id string) (*batchStatus, error) {
+	logger := logging.FromContext(ctx)
+	deadline := time.Now().Add(b.config.Firecrawl.Batch.Timeout)
+
+	for {
+		var status batchStatus
+		if err := b.getJSON(ctx, b.endpoint(id), &status); err != nil {
+			return nil, fmt.Errorf("failed to check Firecrawl batch %s: %w", id, err)
+		}
+		switch status.Status {
+		case "completed":
+			return &status, nil
+		case "failed", "cancelled":
+			return nil, utils.NewScrapingError(fmt.Sprintf("Firecrawl batch %s %s", id, status.Status))
+		}
+
+		logger.Debug("Firecrawl batch scrape in progress", map[string]interface{}{
+			"batch_id":  id,
+			"completed": status.Completed,
+			"total":     status.Total,
+		})
+		if time.Now().Add(b.config.Firecrawl.Batch.PollInterval).After(deadline) {
+			return nil, utils.NewEngineTimeoutError(fmt.Sprintf("Firecrawl batch %s did not complete within %s", id, b.config.Firecrawl.Batch.Timeout))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(b.config.Firecrawl.Batch.PollInterval):
+		}
+	}
+}
+
+// cancel asks Firecrawl to stop a batch that is no longer awaited, so it stops spending credits
+func (b *BatchClient) cancel(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.Firecrawl.Timeout)
+	defer cancel()
+	_, _ = b.do(ctx, http.MethodDelete, b.endpoint(id), nil)
+}
+
+// endpoint returns the URL of the batch scrape API, or of one batch when id is set
+func (b *BatchClient) endpoint(id string) string {
+	url := strings.TrimRight(b.config.Firecrawl.APIURL, "/") + "/v1/batch/scrape"
+	if id != "" {
+		url += "/" + id
+	}
+	return url
+}
+
+// getJSON reads a status response into v
+func (b *BatchClient) getJSON(ctx context.Context, url string, v interface{}) error {
+	body, err := b.do(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// do sends an API request, retrying network failures and server errors up to the configured
+// retries, and returns the body of a successful response
+func (b *BatchClient) do(ctx context.Context, method, url string, payload []byte) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= b.config.Firecrawl.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("Authorization", "Bearer "+b.config.Firecrawl.APIKey)
+
+		resp, err := b.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed: %w", err)
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			lastErr = fmt.Errorf("Firecrawl API returned status %d: %s", resp.StatusCode, truncateForLog(string(body), 300))
+			if resp.StatusCode >= http.StatusInternalServerError {
+				lastErr = fmt.Errorf("%w: %w", ErrServerError, lastErr)
+			}
+			// Client errors, including running out of credits, are not retried
+			if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+				break
+			}
+			continue
+		}
+		return body, nil
+	}
+	return nil, lastErr
+}
+
+// matchPages pairs the scraped documents with the submitted URLs. Firecrawl reports the URL a
+// page was requested as, so documents are matched on it, falling back to canonical URLs.
+func matchPages(urls, invalid []string, docs []batchDocument) []BatchPage {
+	byURL := make(map[string]*batchDocument, len(docs))
+	for i := range docs {
+		doc := &docs[i]
+		for _, u := range []string{doc.Metadata.SourceURL, doc.Metadata.URL} {
+			if u == "" {
+				continue
+			}
+			if _, ok := byURL[u]; !ok {
+				byURL[u] = doc
+			}
+			if key := utils.CanonicalURL(u); key != "" {
+				if _, ok := byURL[key]; !ok {
+					byURL[key] = doc
+				}
+			}
+		}
+	}
+	refused := make(map[string]bool, len(invalid))
+	for _, u := range invalid {
+		refused[u] = true
+	}
+
+	pages := make([]BatchPage, len(urls))
+	for i, u := range urls {
+		page := &pages[i]
+		page.URL = u
+		if refused[u] {
+			page.Error = "Firecrawl refused the URL as invalid"
+			continue
+		}
+
+		doc, ok := byURL[u]
+		if !ok {
+			doc, ok = byURL[utils.CanonicalURL(u)]
+		}
+		switch {
+		case !ok:
+			page.Error = "Firecrawl returned no page for the URL"
+		case doc.Metadata.Error != "":
+			page.Error = "Firecrawl could not scrape the page: " + doc.Metadata.Error
+		case doc.Metadata.StatusCode >= 400:
+			page.Error = fmt.Sprintf("page returned status %d", doc.Metadata.StatusCode)
+		case doc.Markdown != "":
+			page.Content = doc.Markdown
+		case doc.HTML != "":
+			page.Content = doc.HTML
+		default:
+			page.Error = "no content found in Firecrawl response"
+		}
+	}
+	return pages
+}
@@ -0,0 +1,130 @@
+package firecrawl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/metrics"
+)
+
+// CreditMonitor reads the remaining credits of the Firecrawl account. The health checker calls
+// Check on its refresh schedule, and the last reading is reported to the monitoring server,
+// which alerts when credits run low, and to /metrics.
+type CreditMonitor struct {
+	config     *config.Config
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	remaining int64
+	known     bool
+	checkedAt time.Time
+	lastErr   string
+}
+
+// NewCreditMonitor creates a credit monitor for the configured Firecrawl account
+func NewCreditMonitor(cfg *config.Config) *CreditMonitor {
+	return &CreditMonitor{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: cfg.Firecrawl.Timeout},
+	}
+}
+
+// Check reads the remaining credits. A depleted account is reported as an error, since every
+// Firecrawl scrape fails until credits are added.
+func (m *CreditMonitor) Check(ctx context.Context) (map[string]interface{}, error) {
+	remaining, err := m.fetch(ctx)
+
+	m.mu.Lock()
+	m.checkedAt = time.Now()
+	if err != nil {
+		m.lastErr = err.Error()
+	} else {
+		m.lastErr = ""
+		m.remaining = remaining
+		m.known = true
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	details := map[string]interface{}{"remaining_credits": remaining}
+	if remaining <= 0 {
+		return details, errors.New("Firecrawl account has no credits left")
+	}
+	return details, nil
+}
+
+// fetch asks Firecrawl for the account's remaining credits
+func (m *CreditMonitor) fetch(ctx context.Context) (int64, error) {
+	endpoint := strings.TrimRight(m.config.Firecrawl.APIURL, "/") + "/v1/team/credit-usage"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credit usage request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+m.config.Firecrawl.APIKey)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("credit usage request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read credit usage response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Firecrawl API returned status %d: %s", resp.StatusCode, truncateForLog(string(body), 300))
+	}
+
+	var response struct {
+		Success bool `json:"success"`
+		Data    struct {
+			RemainingCredits *float64 `json:"remaining_credits"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("failed to parse credit usage response: %w", err)
+	}
+	if !response.Success || response.Data.RemainingCredits == nil {
+		return 0, fmt.Errorf("Firecrawl returned no credit usage: %s", truncateForLog(string(body), 300))
+	}
+	return int64(*response.Data.RemainingCredits), nil
+}
+
+// GetStats returns the last reading of the remaining credits for the monitoring server
+func (m *CreditMonitor) GetStats() map[string]interface{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := map[string]interface{}{}
+	if m.known {
+		stats["remaining_credits"] = m.remaining
+	}
+	if !m.checkedAt.IsZero() {
+		stats["checked_at"] = m.checkedAt
+	}
+	if m.lastErr != "" {
+		stats["last_error"] = m.lastErr
+	}
+	return stats
+}
+
+// CollectPrometheus exports the last reading of the remaining credits
+func (m *CreditMonitor) CollectPrometheus(w *metrics.PrometheusWriter) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.known {
+		w.Gauge("letraz_firecrawl_remaining_credits", "Credits left on the Firecrawl account at the last check.", float64(m.remaining))
+	}
+}
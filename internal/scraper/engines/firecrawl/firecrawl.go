@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,15 +17,21 @@ import (
 	"letraz-utils/internal/llm"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/salary"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
 
+// ErrServerError marks requests Firecrawl failed with a 5xx status, which are worth retrying later
+var ErrServerError = errors.New("Firecrawl server error")
+
 // FirecrawlScraper implements the Scraper interface using Firecrawl API
 type FirecrawlScraper struct {
 	config     *config.Config
 	llmManager *llm.Manager
 	app        *firecrawl.FirecrawlApp
+	salary     *salary.Normalizer
 	logger     types.Logger
 }
 
@@ -53,28 +60,30 @@ func NewFirecrawlScraper(cfg *config.Config, llmManager *llm.Manager) *Firecrawl
 		config:     cfg,
 		llmManager: llmManager,
 		app:        app,
+		salary:     salary.NewNormalizer(cfg),
 		logger:     logger,
 	}
 }
 
 // ScrapeJob scrapes a job posting from the given URL using Firecrawl and LLM processing
 func (f *FirecrawlScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
-	f.logger.Info("Starting Firecrawl job scraping", map[string]interface{}{
+	logger := logging.FromContext(ctx)
+	logger.Info("Starting Firecrawl job scraping", map[string]interface{}{
 		"url": url,
 	})
 
 	// Try Firecrawl extract first if enabled
-	f.logger.Info("Checking Firecrawl extract configuration", map[string]interface{}{
+	logger.Info("Checking Firecrawl extract configuration", map[string]interface{}{
 		"use_extract": f.config.Firecrawl.UseExtract,
 	})
 
 	if f.config.Firecrawl.UseExtract {
-		f.logger.Info("Attempting Firecrawl extract with schema", map[string]interface{}{
+		logger.Info("Attempting Firecrawl extract with schema", map[string]interface{}{
 			"url": url,
 		})
 		job, err := f.extractJobWithFirecrawl(ctx, url)
 		if err == nil && job != nil {
-			f.logger.Info("Firecrawl extract succeeded", map[string]interface{}{
+			logger.Info("Firecrawl extract succeeded", map[string]interface{}{
 				"url":       url,
 				"job_title": job.Title,
 				"company":   job.CompanyName,
@@ -83,12 +92,12 @@ func (f *FirecrawlScraper) ScrapeJob(ctx context.Context, url string, options *m
 			return job, nil
 		}
 		if err != nil {
-			f.logger.Warn("Firecrawl extract failed; falling back to scrape + LLM", map[string]interface{}{
+			logger.Warn("Firecrawl extract failed; falling back to scrape + LLM", map[string]interface{}{
 				"url":   url,
 				"error": err.Error(),
 			})
 		} else {
-			f.logger.Warn("Firecrawl extract returned empty result; falling back to scrape + LLM", map[string]interface{}{
+			logger.Warn("Firecrawl extract returned empty result; falling back to scrape + LLM", map[string]interface{}{
 				"url": url,
 			})
 		}
@@ -115,7 +124,7 @@ func (f *FirecrawlScraper) ScrapeJob(ctx context.Context, url string, options *m
 		return nil, fmt.Errorf("failed to parse job from content: %w", err)
 	}
 
-	f.logger.Info("Successfully scraped and parsed job", map[string]interface{}{
+	logger.Info("Successfully scraped and parsed job", map[string]interface{}{
 		"job_title": job.Title,
 		"company":   job.CompanyName,
 	})
@@ -124,7 +133,8 @@ func (f *FirecrawlScraper) ScrapeJob(ctx context.Context, url string, options *m
 
 // ScrapeJobLegacy scrapes a job posting using legacy HTML parsing (returns basic extracted data)
 func (f *FirecrawlScraper) ScrapeJobLegacy(ctx context.Context, url string, options *models.ScrapeOptions) (*models.JobPosting, error) {
-	f.logger.Info("Starting Firecrawl legacy job scraping", map[string]interface{}{"url": url})
+	logger := logging.FromContext(ctx)
+	logger.Info("Starting Firecrawl legacy job scraping", map[string]interface{}{"url": url})
 
 	// Scrape the URL using Firecrawl
 	content, err := f.scrapeContent(ctx, url, options)
@@ -149,12 +159,21 @@ func (f *FirecrawlScraper) ScrapeJobLegacy(ctx context.Context, url string, opti
 		jobPosting.Title = title
 	}
 
-	f.logger.Info("Successfully scraped job posting (legacy mode)", map[string]interface{}{"url": url})
+	logger.Info("Successfully scraped job posting (legacy mode)", map[string]interface{}{"url": url})
 	return jobPosting, nil
 }
 
+// FetchPage returns the content of the page at url as scraped by Firecrawl
+func (f *FirecrawlScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	return f.scrapeContent(ctx, url, options)
+}
+
 // scrapeContent performs the actual Firecrawl scraping
 func (f *FirecrawlScraper) scrapeContent(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	logger := logging.FromContext(ctx)
+	// The remote page fetch stands in for browser navigation in the job timings
+	defer timing.Start(ctx, timing.StageNavigation)()
+
 	// Prepare scrape parameters
 	scrapeParams := &firecrawl.ScrapeParams{
 		Formats: f.config.Firecrawl.Formats,
@@ -168,7 +187,7 @@ func (f *FirecrawlScraper) scrapeContent(ctx context.Context, url string, option
 	var err error
 
 	for attempt := 1; attempt <= f.config.Firecrawl.MaxRetries; attempt++ {
-		f.logger.Info("Firecrawl scrape attempt", map[string]interface{}{
+		logger.Info("Firecrawl scrape attempt", map[string]interface{}{
 			"attempt":     attempt,
 			"max_retries": f.config.Firecrawl.MaxRetries,
 			"url":         url,
@@ -179,7 +198,7 @@ func (f *FirecrawlScraper) scrapeContent(ctx context.Context, url string, option
 			break
 		}
 
-		f.logger.Info("Firecrawl scrape attempt failed", map[string]interface{}{
+		logger.Info("Firecrawl scrape attempt failed", map[string]interface{}{
 			"attempt": attempt,
 			"error":   err.Error(),
 		})
@@ -208,7 +227,7 @@ func (f *FirecrawlScraper) scrapeContent(ctx context.Context, url string, option
 		return "", fmt.Errorf("no content found in Firecrawl response")
 	}
 
-	f.logger.Info("Successfully scraped content", map[string]interface{}{
+	logger.Info("Successfully scraped content", map[string]interface{}{
 		"content_length": len(content),
 		"url":            url,
 	})
@@ -267,6 +286,7 @@ func extractSimpleTitle(content string) string {
 
 // extractJobWithFirecrawl calls Firecrawl's extract API with a JSON schema and maps the response to models.Job
 func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url string) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
 	// Build endpoint: always use v2 for schema-based extraction
 	base := strings.TrimRight(f.config.Firecrawl.APIURL, "/")
 	endpoint := base + "/v2/scrape"
@@ -286,7 +306,7 @@ func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url stri
 
 	bodyBytes, _ := json.Marshal(payload)
 
-	f.logger.Info("Sending Firecrawl v2/scrape request", map[string]interface{}{
+	logger.Info("Sending Firecrawl v2/scrape request", map[string]interface{}{
 		"endpoint":     endpoint,
 		"url":          url,
 		"payload_size": len(bodyBytes),
@@ -313,20 +333,23 @@ func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url stri
 		return nil, fmt.Errorf("failed to read extract response body: %w", readErr)
 	}
 
-	f.logger.Debug("Received Firecrawl response", map[string]interface{}{
+	logger.Debug("Received Firecrawl response", map[string]interface{}{
 		"status_code":   resp.StatusCode,
 		"response_size": len(respBody),
 		"content_type":  resp.Header.Get("Content-Type"),
 	})
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		f.logger.Warn("Firecrawl extract failed", map[string]interface{}{
+		logger.Warn("Firecrawl extract failed", map[string]interface{}{
 			"status_code": resp.StatusCode,
 			"endpoint":    endpoint,
 		})
-		f.logger.Debug("Firecrawl extract error details", map[string]interface{}{
+		logger.Debug("Firecrawl extract error details", map[string]interface{}{
 			"response_body": truncateForLog(string(respBody), 1000),
 		})
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: extract request returned status %d", ErrServerError, resp.StatusCode)
+		}
 		return nil, fmt.Errorf("extract request returned status %d", resp.StatusCode)
 	}
 
@@ -336,7 +359,7 @@ func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url stri
 		return nil, fmt.Errorf("failed to parse extract response: %w", err)
 	}
 
-	f.logger.Debug("Parsed Firecrawl response, looking for job object", map[string]interface{}{
+	logger.Debug("Parsed Firecrawl response, looking for job object", map[string]interface{}{
 		"response_type": fmt.Sprintf("%T", root),
 	})
 
@@ -353,16 +376,16 @@ func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url stri
 	}
 
 	if match == nil {
-		f.logger.Warn("Could not find job object in response", map[string]interface{}{
+		logger.Warn("Could not find job object in response", map[string]interface{}{
 			"response_type": fmt.Sprintf("%T", root),
 		})
-		f.logger.Debug("Response details for missing job object", map[string]interface{}{
+		logger.Debug("Response details for missing job object", map[string]interface{}{
 			"response_sample": truncateForLog(string(respBody), 500),
 		})
 		return nil, fmt.Errorf("extract response did not contain a matching job object")
 	}
 
-	f.logger.Debug("Found job object in response", map[string]interface{}{
+	logger.Debug("Found job object in response", map[string]interface{}{
 		"job_keys": getMapKeys(match),
 	})
 
@@ -379,6 +402,7 @@ func (f *FirecrawlScraper) extractJobWithFirecrawl(ctx context.Context, url stri
 	if err := f.validateExtractedJob(job); err != nil {
 		return nil, err
 	}
+	f.salary.Apply(&job)
 
 	return &job, nil
 }
@@ -456,7 +480,8 @@ const jobExtractionSchema = `{
       "properties": {
         "currency": { "type": "string" },
         "min": { "type": "number" },
-        "max": { "type": "number" }
+        "max": { "type": "number" },
+        "period": { "type": "string", "enum": ["hourly", "daily", "weekly", "monthly", "yearly", ""] }
       }
     },
     "requirements": { "type": "array", "items": { "type": "string" } },
@@ -0,0 +1,77 @@
+package firecrawl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Webhook event types of a crawl
+const (
+	EventCrawlStarted   = "crawl.started"
+	EventCrawlPage      = "crawl.page"
+	EventCrawlCompleted = "crawl.completed"
+	EventCrawlFailed    = "crawl.failed"
+)
+
+// SignatureHeader is the header Firecrawl signs webhook bodies in
+const SignatureHeader = "X-Firecrawl-Signature"
+
+// WebhookDocument is a page delivered by a crawl.page event
+type WebhookDocument struct {
+	Markdown string `json:"markdown"`
+	HTML     string `json:"html"`
+	Metadata struct {
+		SourceURL  string `json:"sourceURL"`
+		URL        string `json:"url"`
+		StatusCode int    `json:"statusCode"`
+		Error      string `json:"error"`
+	} `json:"metadata"`
+}
+
+// PageURL returns the URL the page was requested as
+func (d *WebhookDocument) PageURL() string {
+	if d.Metadata.SourceURL != "" {
+		return d.Metadata.SourceURL
+	}
+	return d.Metadata.URL
+}
+
+// Content returns the page's markdown, or its HTML when no markdown was returned
+func (d *WebhookDocument) Content() string {
+	if d.Markdown != "" {
+		return d.Markdown
+	}
+	return d.HTML
+}
+
+// WebhookEvent is a notification Firecrawl sends about a crawl. Metadata echoes what the crawl
+// was started with, which carries the local process ID.
+type WebhookEvent struct {
+	Success  bool                   `json:"success"`
+	Type     string                 `json:"type"`
+	ID       string                 `json:"id"`
+	Data     []WebhookDocument      `json:"data"`
+	Metadata map[string]interface{} `json:"metadata"`
+	Error    string                 `json:"error"`
+}
+
+// ProcessID returns the local process ID the crawl was started for
+func (e *WebhookEvent) ProcessID() string {
+	processID, _ := e.Metadata["process_id"].(string)
+	return processID
+}
+
+// VerifySignature reports whether signature, the value of the signature header, is the
+// HMAC-SHA256 of body under secret
+func VerifySignature(secret string, body []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
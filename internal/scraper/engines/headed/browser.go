@@ -3,6 +3,7 @@ package headed
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
 	"sync"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"letraz-utils/internal/config"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/scraper/proxy"
+	"letraz-utils/internal/timing"
 )
 
 // BrowserManager manages browser instances and pools
@@ -21,6 +24,8 @@ type BrowserManager struct {
 	config       *config.Config
 	launcher     *launcher.Launcher
 	browsers     []*rod.Browser
+	fingerprints map[*rod.Browser]*Fingerprint
+	rng          *rand.Rand
 	mu           sync.RWMutex
 	maxInstances int
 	logger       types.Logger
@@ -33,6 +38,11 @@ type BrowserInstance struct {
 	manager   *BrowserManager
 	createdAt time.Time
 	inUse     bool
+
+	// A page loaded through a proxy lives in its own browser context, disposed on release, and
+	// answers the proxy's authentication challenges until stopAuth is called
+	contextID proto.BrowserBrowserContextID
+	stopAuth  context.CancelFunc
 }
 
 // NewBrowserManager creates a new browser manager
@@ -70,13 +80,20 @@ func NewBrowserManager(cfg *config.Config) *BrowserManager {
 		config:       cfg,
 		launcher:     l,
 		browsers:     make([]*rod.Browser, 0),
+		fingerprints: make(map[*rod.Browser]*Fingerprint),
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
 		maxInstances: cfg.Workers.PoolSize,
 		logger:       logger,
 	}
 }
 
-// GetBrowser returns an available browser instance
+// GetBrowser returns an available browser instance whose page goes through the proxy carried
+// by ctx, if any
 func (bm *BrowserManager) GetBrowser(ctx context.Context) (*BrowserInstance, error) {
+	defer timing.Start(ctx, timing.StageBrowserAcquisition)()
+
+	via := proxy.FromContext(ctx)
+
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
 
@@ -84,21 +101,14 @@ func (bm *BrowserManager) GetBrowser(ctx context.Context) (*BrowserInstance, err
 	for _, browser := range bm.browsers {
 		// Check if browser is still connected by trying to get a page
 		if bm.isBrowserHealthy(browser) {
-			page, err := bm.createStealthPage(browser)
+			instance, err := bm.newInstance(browser, via)
 			if err != nil {
 				bm.logger.Warn("Failed to create page from existing browser", map[string]interface{}{
 					"error": err.Error(),
 				})
 				continue
 			}
-
-			return &BrowserInstance{
-				Browser:   browser,
-				Page:      page,
-				manager:   bm,
-				createdAt: time.Now(),
-				inUse:     true,
-			}, nil
+			return instance, nil
 		}
 	}
 
@@ -109,21 +119,14 @@ func (bm *BrowserManager) GetBrowser(ctx context.Context) (*BrowserInstance, err
 			return nil, fmt.Errorf("failed to create browser: %w", err)
 		}
 
-		page, err := bm.createStealthPage(browser)
+		instance, err := bm.newInstance(browser, via)
 		if err != nil {
 			browser.MustClose()
 			return nil, fmt.Errorf("failed to create stealth page: %w", err)
 		}
 
 		bm.browsers = append(bm.browsers, browser)
-
-		return &BrowserInstance{
-			Browser:   browser,
-			Page:      page,
-			manager:   bm,
-			createdAt: time.Now(),
-			inUse:     true,
-		}, nil
+		return instance, nil
 	}
 
 	return nil, fmt.Errorf("browser pool exhausted, max instances: %d", bm.maxInstances)
@@ -145,138 +148,137 @@ func (bm *BrowserManager) createBrowser(ctx context.Context) (*rod.Browser, erro
 		return nil, fmt.Errorf("failed to connect to browser: %w", err)
 	}
 
-	bm.logger.Info("New browser instance created", map[string]interface{}{})
+	fingerprint := StaticFingerprint(bm.config)
+	if bm.config.Scraper.Fingerprint.Randomize {
+		fingerprint = NewFingerprint(bm.rng, browserChromeVersion(browser, bm.config.Scraper.UserAgent))
+	}
+	bm.fingerprints[browser] = fingerprint
+
+	bm.logger.Info("New browser instance created", map[string]interface{}{
+		"platform": fingerprint.Platform,
+		"screen":   fmt.Sprintf("%dx%d", fingerprint.ScreenWidth, fingerprint.ScreenHeight),
+		"timezone": fingerprint.Timezone,
+		"locale":   fingerprint.Locale,
+	})
 	return browser, nil
 }
 
-// createStealthPage creates a new page with stealth mode enabled
-func (bm *BrowserManager) createStealthPage(browser *rod.Browser) (*rod.Page, error) {
-	page, err := stealth.Page(browser)
+// newInstance opens a stealth page in browser, in a browser context of its own routed through
+// via when a proxy is given
+func (bm *BrowserManager) newInstance(browser *rod.Browser, via *proxy.Proxy) (*BrowserInstance, error) {
+	instance := &BrowserInstance{
+		Browser:   browser,
+		manager:   bm,
+		createdAt: time.Now(),
+		inUse:     true,
+	}
+
+	var page *rod.Page
+	var err error
+	if via == nil {
+		page, err = stealth.Page(browser)
+	} else {
+		page, err = instance.openProxiedPage(via)
+	}
 	if err != nil {
+		instance.close()
 		return nil, fmt.Errorf("failed to create stealth page: %w", err)
 	}
+	instance.Page = page
 
-	// Set viewport to common desktop resolution
-	err = page.SetViewport(&proto.EmulationSetDeviceMetricsOverride{
-		Width:             1920,
-		Height:            1080,
-		DeviceScaleFactor: 1,
-	})
+	bm.configureStealthPage(page, bm.fingerprints[browser])
+	return instance, nil
+}
+
+// openProxiedPage creates a browser context that sends its traffic through via and opens a
+// stealth page in it. Proxies with credentials are answered through the Fetch domain, which
+// pauses every request of the page until it is continued.
+func (bi *BrowserInstance) openProxiedPage(via *proxy.Proxy) (*rod.Page, error) {
+	browserContext, err := proto.TargetCreateBrowserContext{ProxyServer: via.Server(), DisposeOnDetach: true}.Call(bi.Browser)
 	if err != nil {
-		bm.logger.Warn("Failed to set viewport", map[string]interface{}{
-			"error": err.Error(),
-		})
+		return nil, fmt.Errorf("failed to create proxied browser context: %w", err)
 	}
+	bi.contextID = browserContext.BrowserContextID
 
-	// Set user agent if configured
-	if bm.config.Scraper.UserAgent != "" {
-		err = page.SetUserAgent(&proto.NetworkSetUserAgentOverride{
-			UserAgent: bm.config.Scraper.UserAgent,
-		})
-		if err != nil {
-			bm.logger.Warn("Failed to set user agent", map[string]interface{}{
-				"error": err.Error(),
-			})
-		}
+	target, err := proto.TargetCreateTarget{URL: "about:blank", BrowserContextID: bi.contextID}.Call(bi.Browser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	page, err := bi.Browser.PageFromTarget(target.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach to page: %w", err)
 	}
+	// Set before anything can fail so Release closes the page
+	bi.Page = page
 
-	// Set additional headers to appear more human-like
-	headers := map[string]string{
-		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,image/apng,*/*;q=0.8",
-		"Accept-Language":           "en-US,en;q=0.9",
-		"Accept-Encoding":           "gzip, deflate, br",
-		"Cache-Control":             "no-cache",
-		"Pragma":                    "no-cache",
-		"Sec-Fetch-Dest":            "document",
-		"Sec-Fetch-Mode":            "navigate",
-		"Sec-Fetch-Site":            "none",
-		"Sec-Fetch-User":            "?1",
-		"Upgrade-Insecure-Requests": "1",
+	if _, err := page.EvalOnNewDocument(stealth.JS); err != nil {
+		return nil, fmt.Errorf("failed to apply stealth script: %w", err)
 	}
 
-	for name, value := range headers {
-		_, err := page.SetExtraHeaders([]string{name, value})
-		if err != nil {
-			bm.logger.Debug("Failed to set header", map[string]interface{}{
-				"error":  err.Error(),
-				"header": name,
-			})
-		}
+	username, password, ok := via.Credentials()
+	if !ok {
+		return page, nil
 	}
 
-	// Inject additional stealth JavaScript to mask automation
-	err = rod.Try(func() {
-		page.MustEval(`() => {
-			// Override webdriver property
-			Object.defineProperty(navigator, 'webdriver', {
-				get: () => undefined,
-			});
-			
-			// Override automation-related properties
-			Object.defineProperty(navigator, 'plugins', {
-				get: () => [1, 2, 3, 4, 5],
-			});
-			
-			Object.defineProperty(navigator, 'languages', {
-				get: () => ['en-US', 'en'],
-			});
-			
-			// Override chrome property
-			window.chrome = {
-				runtime: {},
-			};
-			
-			// Override permissions
-			const originalQuery = window.navigator.permissions.query;
-			window.navigator.permissions.query = (parameters) => (
-				parameters.name === 'notifications' ?
-					Promise.resolve({ state: Notification.permission }) :
-					originalQuery(parameters)
-			);
-			
-			// Randomize screen properties slightly
-			Object.defineProperty(screen, 'width', {
-				get: () => 1920,
-			});
-			Object.defineProperty(screen, 'height', {
-				get: () => 1080,
-			});
-			Object.defineProperty(screen, 'availWidth', {
-				get: () => 1920,
-			});
-			Object.defineProperty(screen, 'availHeight', {
-				get: () => 1050,
-			});
-			
-			// Override WebRTC
-			let RTCPeerConnection = window.RTCPeerConnection || window.mozRTCPeerConnection || window.webkitRTCPeerConnection;
-			if (RTCPeerConnection) {
-				window.RTCPeerConnection = function() {
-					throw new Error('WebRTC is disabled');
-				};
-			}
-		}`)
+	authCtx, cancel := context.WithCancel(context.Background())
+	bi.stopAuth = cancel
+	wait := page.Context(authCtx).EachEvent(func(e *proto.FetchRequestPaused) {
+		_ = proto.FetchContinueRequest{RequestID: e.RequestID}.Call(page)
+	}, func(e *proto.FetchAuthRequired) {
+		_ = proto.FetchContinueWithAuth{
+			RequestID: e.RequestID,
+			AuthChallengeResponse: &proto.FetchAuthChallengeResponse{
+				Response: proto.FetchAuthChallengeResponseResponseProvideCredentials,
+				Username: username,
+				Password: password,
+			},
+		}.Call(page)
 	})
-	if err != nil {
-		bm.logger.Warn("Failed to inject stealth JavaScript", map[string]interface{}{
+	go wait()
+	if err := (proto.FetchEnable{HandleAuthRequests: true}).Call(page); err != nil {
+		return nil, fmt.Errorf("failed to handle proxy authentication: %w", err)
+	}
+	return page, nil
+}
+
+// configureStealthPage makes the page present the fingerprint of its browser. Chrome's own
+// Accept and Sec-Fetch headers are left alone, since overriding them for every request,
+// subresources included, would give the page away.
+func (bm *BrowserManager) configureStealthPage(page *rod.Page, fingerprint *Fingerprint) {
+	if fingerprint == nil {
+		fingerprint = StaticFingerprint(bm.config)
+	}
+	if err := fingerprint.Apply(page); err != nil {
+		bm.logger.Warn("Failed to apply browser fingerprint", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
-
-	return page, nil
 }
 
 // ReleaseBrowser releases a browser instance back to the pool
 func (bi *BrowserInstance) Release() {
-	if bi.Page != nil {
-		bi.Page.MustClose()
-	}
+	bi.close()
 	bi.inUse = false
 	bi.manager.logger.Debug("Browser instance released")
 }
 
+// close closes the page of the instance and disposes of its proxied browser context
+func (bi *BrowserInstance) close() {
+	if bi.stopAuth != nil {
+		bi.stopAuth()
+	}
+	if bi.Page != nil {
+		_ = bi.Page.Close()
+	}
+	if bi.contextID != "" {
+		_ = proto.TargetDisposeBrowserContext{BrowserContextID: bi.contextID}.Call(bi.Browser)
+	}
+}
+
 // Navigate navigates the page to the specified URL with timeout
 func (bi *BrowserInstance) Navigate(ctx context.Context, url string, timeout time.Duration) error {
+	defer timing.Start(ctx, timing.StageNavigation)()
+
 	// Set navigation timeout
 	navCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -473,6 +475,7 @@ func (bm *BrowserManager) Cleanup() {
 	}
 
 	bm.browsers = nil
+	bm.fingerprints = make(map[*rod.Browser]*Fingerprint)
 	bm.launcher.Cleanup()
 	bm.logger.Info("Browser manager cleanup completed")
 }
@@ -0,0 +1,406 @@
+package headed
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"letraz-utils/internal/config"
+)
+
+// platformProfile is an operating system a fingerprint can claim, with the screens, graphics
+// and processor architecture of machines that run it
+type platformProfile struct {
+	weight            int
+	navigatorPlatform string // navigator.platform
+	uaPlatform        string // platform token of the user agent
+	hintPlatform      string // Sec-CH-UA-Platform
+	platformVersions  []string
+	architecture      string
+	screens           [][2]int
+	scaleFactors      []float64
+	taskbarHeight     int // screen height taken by the taskbar, dock or menu bar
+	webglVendor       string
+	webglRenderers    []string
+}
+
+var platformProfiles = []platformProfile{
+	{
+		weight:            6,
+		navigatorPlatform: "Win32",
+		uaPlatform:        "Windows NT 10.0; Win64; x64",
+		hintPlatform:      "Windows",
+		platformVersions:  []string{"10.0.0", "15.0.0"},
+		architecture:      "x86",
+		screens:           [][2]int{{1920, 1080}, {1536, 864}, {1366, 768}, {2560, 1440}, {1600, 900}, {1440, 900}},
+		scaleFactors:      []float64{1, 1, 1.25, 1.5},
+		taskbarHeight:     48,
+		webglVendor:       "Google Inc. (NVIDIA)",
+		webglRenderers: []string{
+			"ANGLE (NVIDIA, NVIDIA GeForce GTX 1660 SUPER Direct3D11 vs_5_0 ps_5_0, D3D11)",
+			"ANGLE (NVIDIA, NVIDIA GeForce RTX 3060 Direct3D11 vs_5_0 ps_5_0, D3D11)",
+			"ANGLE (NVIDIA, NVIDIA GeForce RTX 2070 SUPER Direct3D11 vs_5_0 ps_5_0, D3D11)",
+		},
+	},
+	{
+		weight:            3,
+		navigatorPlatform: "MacIntel",
+		uaPlatform:        "Macintosh; Intel Mac OS X 10_15_7",
+		hintPlatform:      "macOS",
+		platformVersions:  []string{"13.6.0", "14.4.1", "14.6.1", "15.0.0"},
+		architecture:      "arm",
+		screens:           [][2]int{{1440, 900}, {1512, 982}, {1728, 1117}, {1680, 1050}, {1920, 1080}},
+		scaleFactors:      []float64{2},
+		taskbarHeight:     25,
+		webglVendor:       "Google Inc. (Apple)",
+		webglRenderers: []string{
+			"ANGLE (Apple, ANGLE Metal Renderer: Apple M1, Unspecified Version)",
+			"ANGLE (Apple, ANGLE Metal Renderer: Apple M2, Unspecified Version)",
+			"ANGLE (Apple, ANGLE Metal Renderer: Apple M1 Pro, Unspecified Version)",
+		},
+	},
+	{
+		weight:            1,
+		navigatorPlatform: "Linux x86_64",
+		uaPlatform:        "X11; Linux x86_64",
+		hintPlatform:      "Linux",
+		platformVersions:  []string{"6.5.0", "6.8.0"},
+		architecture:      "x86",
+		screens:           [][2]int{{1920, 1080}, {2560, 1440}, {1366, 768}},
+		scaleFactors:      []float64{1},
+		taskbarHeight:     27,
+		webglVendor:       "Google Inc. (Intel)",
+		webglRenderers: []string{
+			"ANGLE (Intel, Mesa Intel(R) UHD Graphics 620 (KBL GT2), OpenGL 4.6)",
+			"ANGLE (Intel, Mesa Intel(R) Xe Graphics (TGL GT2), OpenGL 4.6)",
+		},
+	},
+}
+
+// localeTimezones pairs each locale a fingerprint can claim with the timezones of its country.
+// Only English locales are used so that job pages are served in the language the extractors read.
+var localeTimezones = map[string][]string{
+	"en-US": {"America/New_York", "America/Chicago", "America/Denver", "America/Los_Angeles"},
+	"en-GB": {"Europe/London"},
+	"en-CA": {"America/Toronto", "America/Vancouver"},
+	"en-AU": {"Australia/Sydney", "Australia/Melbourne"},
+	"en-IN": {"Asia/Kolkata"},
+}
+
+// browserChromeHeight is the height of the tabs and address bar above the viewport
+const browserChromeHeight = 85
+
+// Fingerprint is what a browser reveals about the machine it runs on. A browser keeps one
+// fingerprint for its lifetime, so every page it opens tells the same story.
+type Fingerprint struct {
+	UserAgent           string
+	Platform            string // navigator.platform
+	ScreenWidth         int
+	ScreenHeight        int
+	AvailHeight         int
+	ViewportWidth       int
+	ViewportHeight      int
+	DeviceScaleFactor   float64
+	Timezone            string // empty to keep the host's
+	Locale              string
+	Languages           []string
+	HardwareConcurrency int
+	DeviceMemory        int
+	WebGLVendor         string // empty to keep the real graphics
+	WebGLRenderer       string
+	CanvasSeed          uint32 // zero for no canvas noise
+
+	// hints are the client hints sent as Sec-CH-UA headers and returned by
+	// navigator.userAgentData; nil to keep Chrome's own
+	hints *proto.EmulationUserAgentMetadata
+}
+
+// chromeVersionPattern finds the Chrome version in a product name or user agent
+var chromeVersionPattern = regexp.MustCompile(`Chrome/(\d+)\.([\d.]+)`)
+
+// NewFingerprint picks a random desktop fingerprint claiming chromeVersion, the full version of
+// the Chrome that presents it, so that the user agent agrees with the features pages can probe
+func NewFingerprint(rng *rand.Rand, chromeVersion string) *Fingerprint {
+	profile := pickProfile(rng)
+	major := strings.SplitN(chromeVersion, ".", 2)[0]
+
+	screen := profile.screens[rng.Intn(len(profile.screens))]
+	availHeight := screen[1] - profile.taskbarHeight
+
+	locales := make([]string, 0, len(localeTimezones))
+	for locale := range localeTimezones {
+		locales = append(locales, locale)
+	}
+	// Map order is random but not seeded by rng; sort for reproducible picks
+	sort.Strings(locales)
+	locale := locales[rng.Intn(len(locales))]
+	timezones := localeTimezones[locale]
+
+	languages := []string{locale, "en"}
+	if locale != "en-US" {
+		languages = []string{locale, "en-US", "en"}
+	}
+
+	brands := []*proto.EmulationUserAgentBrandVersion{
+		{Brand: "Not_A Brand", Version: "8"},
+		{Brand: "Chromium", Version: major},
+		{Brand: "Google Chrome", Version: major},
+	}
+	fullVersions := []*proto.EmulationUserAgentBrandVersion{
+		{Brand: "Not_A Brand", Version: "8.0.0.0"},
+		{Brand: "Chromium", Version: chromeVersion},
+		{Brand: "Google Chrome", Version: chromeVersion},
+	}
+
+	return &Fingerprint{
+		UserAgent:           fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s.0.0.0 Safari/537.36", profile.uaPlatform, major),
+		Platform:            profile.navigatorPlatform,
+		ScreenWidth:         screen[0],
+		ScreenHeight:        screen[1],
+		AvailHeight:         availHeight,
+		ViewportWidth:       screen[0],
+		ViewportHeight:      availHeight - browserChromeHeight,
+		DeviceScaleFactor:   profile.scaleFactors[rng.Intn(len(profile.scaleFactors))],
+		Timezone:            timezones[rng.Intn(len(timezones))],
+		Locale:              locale,
+		Languages:           languages,
+		HardwareConcurrency: []int{4, 8, 8, 12, 16}[rng.Intn(5)],
+		DeviceMemory:        []int{4, 8, 8}[rng.Intn(3)],
+		WebGLVendor:         profile.webglVendor,
+		WebGLRenderer:       profile.webglRenderers[rng.Intn(len(profile.webglRenderers))],
+		CanvasSeed:          rng.Uint32() | 1,
+		hints: &proto.EmulationUserAgentMetadata{
+			Brands:          brands,
+			FullVersionList: fullVersions,
+			FullVersion:     chromeVersion,
+			Platform:        profile.hintPlatform,
+			PlatformVersion: profile.platformVersions[rng.Intn(len(profile.platformVersions))],
+			Architecture:    profile.architecture,
+			Bitness:         "64",
+		},
+	}
+}
+
+// StaticFingerprint is the fixed fingerprint used when randomization is off: a 1920x1080 desktop
+// sending the configured user agent, with the host's timezone, graphics and canvas
+func StaticFingerprint(cfg *config.Config) *Fingerprint {
+	return &Fingerprint{
+		UserAgent:           cfg.Scraper.UserAgent,
+		ScreenWidth:         1920,
+		ScreenHeight:        1080,
+		AvailHeight:         1050,
+		ViewportWidth:       1920,
+		ViewportHeight:      1080,
+		DeviceScaleFactor:   1,
+		Locale:              "en-US",
+		Languages:           []string{"en-US", "en"},
+		HardwareConcurrency: 8,
+		DeviceMemory:        8,
+	}
+}
+
+// pickProfile picks a platform by its weight
+func pickProfile(rng *rand.Rand) platformProfile {
+	total := 0
+	for _, profile := range platformProfiles {
+		total += profile.weight
+	}
+	n := rng.Intn(total)
+	for _, profile := range platformProfiles {
+		if n < profile.weight {
+			return profile
+		}
+		n -= profile.weight
+	}
+	return platformProfiles[0]
+}
+
+// browserChromeVersion returns the full version of the Chrome running browser, falling back to
+// the version in fallbackUA, or Chrome 120, when the browser cannot tell
+func browserChromeVersion(browser *rod.Browser, fallbackUA string) string {
+	if version, err := (proto.BrowserGetVersion{}).Call(browser); err == nil {
+		if m := chromeVersionPattern.FindStringSubmatch(version.Product); m != nil {
+			return m[1] + "." + m[2]
+		}
+	}
+	if m := chromeVersionPattern.FindStringSubmatch(fallbackUA); m != nil {
+		return m[1] + "." + m[2]
+	}
+	return "120.0.0.0"
+}
+
+// AcceptLanguage returns the Accept-Language header matching the fingerprint's languages
+func (fp *Fingerprint) AcceptLanguage() string {
+	parts := make([]string, len(fp.Languages))
+	for i, language := range fp.Languages {
+		if i == 0 {
+			parts[i] = language
+			continue
+		}
+		parts[i] = fmt.Sprintf("%s;q=%.1f", language, 1-0.1*float64(i))
+	}
+	return strings.Join(parts, ",")
+}
+
+// fingerprintScript makes the page's scripts see the fingerprint: screen and window sizes,
+// languages, hardware, graphics and slightly noised canvas reads. It also hides the usual
+// automation tells. %s is replaced by the fingerprint as JSON.
+const fingerprintScript = `(() => {
+	const fp = %s;
+	const define = (target, name, value) => {
+		try {
+			Object.defineProperty(target, name, { get: () => value, configurable: true });
+		} catch (e) {}
+	};
+
+	define(navigator, 'webdriver', undefined);
+	define(navigator, 'languages', Object.freeze(fp.languages.slice()));
+	define(navigator, 'hardwareConcurrency', fp.hardwareConcurrency);
+	define(navigator, 'deviceMemory', fp.deviceMemory);
+
+	define(screen, 'width', fp.screenWidth);
+	define(screen, 'height', fp.screenHeight);
+	define(screen, 'availWidth', fp.screenWidth);
+	define(screen, 'availHeight', fp.availHeight);
+	define(screen, 'colorDepth', 24);
+	define(screen, 'pixelDepth', 24);
+	define(window, 'outerWidth', fp.screenWidth);
+	define(window, 'outerHeight', fp.availHeight);
+
+	if (!window.chrome) {
+		window.chrome = { runtime: {} };
+	}
+
+	if (navigator.permissions && navigator.permissions.query) {
+		const query = navigator.permissions.query.bind(navigator.permissions);
+		navigator.permissions.query = (parameters) => (
+			parameters && parameters.name === 'notifications' ?
+				Promise.resolve({ state: typeof Notification !== 'undefined' ? Notification.permission : 'default' }) :
+				query(parameters)
+		);
+	}
+
+	if (window.RTCPeerConnection) {
+		window.RTCPeerConnection = function() {
+			throw new Error('WebRTC is disabled');
+		};
+	}
+
+	if (fp.webglVendor) {
+		for (const context of [window.WebGLRenderingContext, window.WebGL2RenderingContext]) {
+			if (!context) continue;
+			const getParameter = context.prototype.getParameter;
+			context.prototype.getParameter = function(parameter) {
+				if (parameter === 37445) return fp.webglVendor;   // UNMASKED_VENDOR_WEBGL
+				if (parameter === 37446) return fp.webglRenderer; // UNMASKED_RENDERER_WEBGL
+				return getParameter.call(this, parameter);
+			};
+		}
+	}
+
+	if (fp.canvasSeed) {
+		// Flip the low bit of a few pixels, chosen by the seed, so canvas hashes differ between
+		// browsers but stay the same for the same drawing in one browser
+		const noise = (data) => {
+			for (let i = 0; i < data.length; i += 4) {
+				if ((Math.imul((i >> 2) ^ fp.canvasSeed, 2654435761) >>> 0) %% 61 === 0) {
+					data[i] ^= 1;
+				}
+			}
+		};
+		const getImageData = CanvasRenderingContext2D.prototype.getImageData;
+		CanvasRenderingContext2D.prototype.getImageData = function(...args) {
+			const image = getImageData.apply(this, args);
+			noise(image.data);
+			return image;
+		};
+		const noisedCopy = (canvas) => {
+			const copy = document.createElement('canvas');
+			copy.width = canvas.width;
+			copy.height = canvas.height;
+			const context = copy.getContext('2d');
+			context.drawImage(canvas, 0, 0);
+			const image = getImageData.call(context, 0, 0, copy.width, copy.height);
+			noise(image.data);
+			context.putImageData(image, 0, 0);
+			return copy;
+		};
+		for (const method of ['toDataURL', 'toBlob']) {
+			const original = HTMLCanvasElement.prototype[method];
+			HTMLCanvasElement.prototype[method] = function(...args) {
+				if (!this.width || !this.height) return original.apply(this, args);
+				return original.apply(noisedCopy(this), args);
+			};
+		}
+	}
+})()`
+
+// script returns the fingerprint script for the fingerprint
+func (fp *Fingerprint) script() (string, error) {
+	values, err := json.Marshal(map[string]interface{}{
+		"languages":           fp.Languages,
+		"hardwareConcurrency": fp.HardwareConcurrency,
+		"deviceMemory":        fp.DeviceMemory,
+		"screenWidth":         fp.ScreenWidth,
+		"screenHeight":        fp.ScreenHeight,
+		"availHeight":         fp.AvailHeight,
+		"webglVendor":         fp.WebGLVendor,
+		"webglRenderer":       fp.WebGLRenderer,
+		"canvasSeed":          fp.CanvasSeed,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(fingerprintScript, values), nil
+}
+
+// Apply makes page present the fingerprint. It must be called before the page navigates.
+func (fp *Fingerprint) Apply(page *rod.Page) error {
+	screenWidth, screenHeight := fp.ScreenWidth, fp.ScreenHeight
+	err := page.SetViewport(&proto.EmulationSetDeviceMetricsOverride{
+		Width:             fp.ViewportWidth,
+		Height:            fp.ViewportHeight,
+		DeviceScaleFactor: fp.DeviceScaleFactor,
+		ScreenWidth:       &screenWidth,
+		ScreenHeight:      &screenHeight,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set viewport: %w", err)
+	}
+
+	if fp.UserAgent != "" {
+		err = proto.NetworkSetUserAgentOverride{
+			UserAgent:         fp.UserAgent,
+			AcceptLanguage:    fp.AcceptLanguage(),
+			Platform:          fp.Platform,
+			UserAgentMetadata: fp.hints,
+		}.Call(page)
+		if err != nil {
+			return fmt.Errorf("failed to set user agent: %w", err)
+		}
+	}
+
+	if fp.Timezone != "" {
+		if err := (proto.EmulationSetTimezoneOverride{TimezoneID: fp.Timezone}).Call(page); err != nil {
+			return fmt.Errorf("failed to set timezone: %w", err)
+		}
+	}
+	if fp.Locale != "" {
+		// Chrome refuses a second override on a page that already has one; the first one stands
+		_ = proto.EmulationSetLocaleOverride{Locale: strings.ReplaceAll(fp.Locale, "-", "_")}.Call(page)
+	}
+
+	script, err := fp.script()
+	if err != nil {
+		return fmt.Errorf("failed to encode fingerprint: %w", err)
+	}
+	if _, err := page.EvalOnNewDocument(script); err != nil {
+		return fmt.Errorf("failed to add fingerprint script: %w", err)
+	}
+	return nil
+}
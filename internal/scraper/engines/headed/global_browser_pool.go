@@ -3,6 +3,7 @@ package headed
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"letraz-utils/internal/config"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/metrics"
 )
 
 // GlobalBrowserPool manages a shared pool of browser instances across the entire application
@@ -21,7 +23,15 @@ type GlobalBrowserPool struct {
 	browsers          []*ManagedBrowser
 	availableBrowsers chan *ManagedBrowser
 	mu                sync.RWMutex
-	maxInstances      int
+	maxInstances      int                       // browsers allowed now, moved between floor and ceiling by the autoscaler
+	minInstances      int                       // browsers kept launched, idle or not
+	floorInstances    int                       // lowest maxInstances the autoscaler goes to
+	ceilingInstances  int                       // highest maxInstances the autoscaler goes to
+	lastAcquisitions  metrics.HistogramSnapshot // acquisition times up to the previous scaling check
+	scaleUps          int64
+	scaleDowns        int64
+	nextRemote        int       // index of the remote browser endpoint the next browser connects to
+	drainedAt         time.Time // browsers created before the last drain are closed on release
 	currentInstances  int
 	logger            types.Logger
 	ctx               context.Context
@@ -39,28 +49,37 @@ type ManagedBrowser struct {
 	InUse       bool
 	UsageCount  int
 	MaxIdleTime time.Duration
+	pid         int // process of the browser, whose children render its pages
 	mu          sync.RWMutex
 }
 
 // BrowserPoolMetrics tracks browser pool statistics
 type BrowserPoolMetrics struct {
-	mu                     sync.RWMutex
-	TotalBrowsersCreated   int64
-	TotalBrowsersClosed    int64
-	CurrentActiveBrowsers  int64
-	AvailableBrowsers      int64
-	QueuedRequests         int64
-	AverageAcquisitionTime time.Duration
-	TotalAcquisitionTime   time.Duration
-	AcquisitionCount       int64
-}
-
-// GlobalBrowserInstance represents a browser instance with a page for use
+	mu                    sync.RWMutex
+	TotalBrowsersCreated  int64
+	TotalBrowsersClosed   int64
+	TotalBrowsersRetired  int64 // closed on reaching their page or memory limit
+	TotalBrowserCrashes   int64 // browsers found dead while in use
+	CurrentActiveBrowsers int64
+	AvailableBrowsers     int64
+	MaxInstances          int64 // browsers allowed now, set by the autoscaler
+	ScaleUps              int64
+	ScaleDowns            int64
+	QueuedRequests        int64
+	AcquisitionTime       metrics.HistogramSnapshot // acquisition_time_seconds
+	acquisitionHistogram  *metrics.Histogram
+}
+
+// GlobalBrowserInstance represents a browser instance with a page for use. The page lives in an
+// incognito browser context of its own, disposed on release, so no cookies or storage are shared
+// between acquisitions.
 type GlobalBrowserInstance struct {
 	Browser   *ManagedBrowser
 	Page      *rod.Page
 	pool      *GlobalBrowserPool
 	createdAt time.Time
+	contextID proto.BrowserBrowserContextID
+	crashed   bool // the browser died while in use and is evicted on release
 }
 
 var (
@@ -118,7 +137,12 @@ func InitializeGlobalBrowserPool(cfg *config.Config) error {
 		}
 
 		// Use system-installed Chrome/Chromium instead of downloading
-		if chromePath := getSystemChromePath(); chromePath != "" {
+		if len(cfg.BrowserPool.RemoteURLs) > 0 {
+			logger.Info("Global browser pool using remote browsers", map[string]interface{}{
+				"remote_endpoints": len(cfg.BrowserPool.RemoteURLs),
+				"max_instances":    maxInstances,
+			})
+		} else if chromePath := getSystemChromePath(); chromePath != "" {
 			l = l.Bin(chromePath)
 			logger.Info("Global browser pool using system Chrome", map[string]interface{}{
 				"chrome_path":   chromePath,
@@ -141,17 +165,30 @@ func InitializeGlobalBrowserPool(cfg *config.Config) error {
 			return
 		}
 
+		minInstances := minBrowserInstances(cfg, maxInstances)
+		floorInstances := maxInstances
+		if cfg.BrowserPool.ScaleInterval > 0 {
+			// The autoscaler starts at the minimum and grows the pool under load
+			floorInstances = minInstances
+			if floorInstances < 1 {
+				floorInstances = 1
+			}
+		}
+
 		globalPool = &GlobalBrowserPool{
 			config:            cfg,
 			launcherTemplate:  l,
 			browsers:          make([]*ManagedBrowser, 0, maxInstances),
 			availableBrowsers: make(chan *ManagedBrowser, maxInstances),
-			maxInstances:      maxInstances,
+			maxInstances:      floorInstances,
+			minInstances:      minInstances,
+			floorInstances:    floorInstances,
+			ceilingInstances:  maxInstances,
 			currentInstances:  0,
 			logger:            logger,
 			ctx:               ctx,
 			cancel:            cancel,
-			metrics:           &BrowserPoolMetrics{},
+			metrics:           &BrowserPoolMetrics{acquisitionHistogram: metrics.NewLatencyHistogram()},
 		}
 
 		if globalPool == nil {
@@ -160,11 +197,19 @@ func InitializeGlobalBrowserPool(cfg *config.Config) error {
 			return
 		}
 
+		// Launch the minimum browsers now so the first scrape doesn't wait for one to start
+		globalPool.ensureMinBrowsers()
+
 		// Start background cleanup routine
 		globalPool.startCleanupRoutine()
 
+		if floorInstances < maxInstances {
+			globalPool.startAutoscaler()
+		}
+
 		logger.Info("Global browser pool initialized", map[string]interface{}{
 			"max_instances":    maxInstances,
+			"floor_instances":  floorInstances,
 			"cleanup_interval": cfg.BrowserPool.CleanupInterval.String(),
 			"max_idle_time":    cfg.BrowserPool.MaxIdleTime.String(),
 			"max_browsers":     cfg.BrowserPool.MaxBrowsers,
@@ -203,12 +248,8 @@ func (gbp *GlobalBrowserPool) AcquireBrowser(ctx context.Context) (*GlobalBrowse
 		acquisitionTime := time.Since(startTime)
 		gbp.metrics.mu.Lock()
 		gbp.metrics.QueuedRequests--
-		gbp.metrics.TotalAcquisitionTime += acquisitionTime
-		gbp.metrics.AcquisitionCount++
-		if gbp.metrics.AcquisitionCount > 0 {
-			gbp.metrics.AverageAcquisitionTime = gbp.metrics.TotalAcquisitionTime / time.Duration(gbp.metrics.AcquisitionCount)
-		}
 		gbp.metrics.mu.Unlock()
+		gbp.metrics.acquisitionHistogram.ObserveDuration(acquisitionTime)
 	}()
 
 	// Try to get an available browser from the pool with a shorter wait
@@ -275,20 +316,49 @@ func (gbp *GlobalBrowserPool) AcquireBrowser(ctx context.Context) (*GlobalBrowse
 	}
 }
 
+// Crashed reports whether the browser of the instance has died or disconnected, as when Chrome
+// is killed mid-navigation. A crashed browser is evicted from the pool on release.
+func (gbi *GlobalBrowserInstance) Crashed() bool {
+	if gbi.crashed {
+		return true
+	}
+	if gbi.pool.isManagedBrowserHealthy(gbi.Browser) {
+		return false
+	}
+	gbi.crashed = true
+	return true
+}
+
 // ReleaseBrowser returns a browser instance to the pool
 func (gbi *GlobalBrowserInstance) Release() {
+	managedBrowser := gbi.Browser
+
+	if gbi.crashed {
+		gbi.pool.evictCrashedBrowser(managedBrowser)
+		return
+	}
+
 	if gbi.Page != nil {
 		// Close the page but keep the browser
 		_ = gbi.Page.Close()
 	}
+	if gbi.contextID != "" {
+		// Drop the cookies and storage the page left behind
+		_ = proto.TargetDisposeBrowserContext{BrowserContextID: gbi.contextID}.Call(managedBrowser.Browser)
+	}
 
-	managedBrowser := gbi.Browser
 	managedBrowser.mu.Lock()
 	managedBrowser.InUse = false
 	managedBrowser.LastUsedAt = time.Now()
 	managedBrowser.UsageCount++
 	managedBrowser.mu.Unlock()
 
+	// Replace a browser that served its pages or outgrew its memory instead of reusing it
+	if reason := gbi.pool.retirementReason(managedBrowser); reason != "" {
+		go gbi.pool.retireBrowser(managedBrowser, reason)
+		return
+	}
+
 	// Return browser to available pool
 	select {
 	case gbi.pool.availableBrowsers <- managedBrowser:
@@ -307,17 +377,13 @@ func (gbi *GlobalBrowserInstance) Release() {
 
 // createManagedBrowser creates a new managed browser instance
 func (gbp *GlobalBrowserPool) createManagedBrowser(ctx context.Context) (*ManagedBrowser, error) {
-	// Create a fresh launcher for each browser to avoid "already launched" errors
-	freshLauncher := gbp.createFreshLauncher()
-
 	// Use a longer timeout for browser creation to avoid premature cancellation
 	browserCtx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
 
-	// Launch browser with fresh launcher and extended timeout
-	url, err := freshLauncher.Context(browserCtx).Launch()
+	url, pid, err := gbp.launchBrowser(browserCtx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to launch browser: %w", err)
+		return nil, err
 	}
 
 	// Connect to browser with timeout
@@ -336,6 +402,7 @@ func (gbp *GlobalBrowserPool) createManagedBrowser(ctx context.Context) (*Manage
 		InUse:       false,
 		UsageCount:  0,
 		MaxIdleTime: gbp.config.BrowserPool.MaxIdleTime,
+		pid:         pid,
 	}
 
 	gbp.mu.Lock()
@@ -355,6 +422,166 @@ func (gbp *GlobalBrowserPool) createManagedBrowser(ctx context.Context) (*Manage
 	return managedBrowser, nil
 }
 
+// retirementReason returns why a browser should be closed rather than returned to the pool, or
+// "" when it may be reused: it served BrowserPool.MaxPagesPerBrowser pages, or its processes use
+// more than BrowserPool.MaxBrowserMemoryMB. Chrome's memory creeps up over hundreds of pages,
+// so a fresh browser is cheaper than a bloated one.
+func (gbp *GlobalBrowserPool) retirementReason(managedBrowser *ManagedBrowser) string {
+	managedBrowser.mu.RLock()
+	usageCount := managedBrowser.UsageCount
+	pid := managedBrowser.pid
+	managedBrowser.mu.RUnlock()
+
+	gbp.mu.RLock()
+	drained := managedBrowser.CreatedAt.Before(gbp.drainedAt)
+	gbp.mu.RUnlock()
+	if drained {
+		return "pool drained"
+	}
+
+	if maxPages := gbp.config.BrowserPool.MaxPagesPerBrowser; maxPages > 0 && usageCount >= maxPages {
+		return fmt.Sprintf("served %d pages", usageCount)
+	}
+
+	if maxMemoryMB := gbp.config.BrowserPool.MaxBrowserMemoryMB; maxMemoryMB > 0 && pid > 0 {
+		rss, err := processTreeRSS(pid)
+		if err != nil {
+			gbp.logger.Debug("Failed to read browser memory", map[string]interface{}{
+				"browser_id": managedBrowser.ID,
+				"error":      err.Error(),
+			})
+			return ""
+		}
+		if rss > int64(maxMemoryMB)<<20 {
+			return fmt.Sprintf("uses %d MB", rss>>20)
+		}
+	}
+	return ""
+}
+
+// evictCrashedBrowser drops a browser that died while in use and launches its replacement when
+// the pool falls below its minimum
+func (gbp *GlobalBrowserPool) evictCrashedBrowser(managedBrowser *ManagedBrowser) {
+	gbp.logger.Warn("Evicting crashed browser", map[string]interface{}{
+		"browser_id":  managedBrowser.ID,
+		"usage_count": managedBrowser.UsageCount,
+	})
+
+	gbp.metrics.mu.Lock()
+	gbp.metrics.TotalBrowserCrashes++
+	gbp.metrics.mu.Unlock()
+
+	go func() {
+		gbp.closeManagedBrowser(managedBrowser)
+		gbp.ensureMinBrowsers()
+	}()
+}
+
+// retireBrowser closes a browser that reached its page or memory limit and launches its
+// replacement when the pool falls below its minimum
+func (gbp *GlobalBrowserPool) retireBrowser(managedBrowser *ManagedBrowser, reason string) {
+	gbp.logger.Info("Retiring browser", map[string]interface{}{
+		"browser_id":  managedBrowser.ID,
+		"usage_count": managedBrowser.UsageCount,
+		"reason":      reason,
+	})
+
+	gbp.metrics.mu.Lock()
+	gbp.metrics.TotalBrowsersRetired++
+	gbp.metrics.mu.Unlock()
+
+	gbp.closeManagedBrowser(managedBrowser)
+	gbp.ensureMinBrowsers()
+}
+
+// ensureMinBrowsers launches browsers in parallel until the pool holds its minimum. A browser
+// that fails to launch is logged and retried by the next cleanup.
+func (gbp *GlobalBrowserPool) ensureMinBrowsers() {
+	gbp.mu.Lock()
+	missing := gbp.minInstances - gbp.currentInstances
+	if missing <= 0 || gbp.ctx.Err() != nil {
+		gbp.mu.Unlock()
+		return
+	}
+	// Reserve the instances up front, as AcquireBrowser does, so the limit holds while launching
+	gbp.currentInstances += missing
+	gbp.mu.Unlock()
+
+	var wg sync.WaitGroup
+	var launchedMu sync.Mutex
+	launched := 0
+	for i := 0; i < missing; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			managedBrowser, err := gbp.createManagedBrowser(gbp.ctx)
+			if err != nil {
+				gbp.mu.Lock()
+				gbp.currentInstances--
+				gbp.mu.Unlock()
+				gbp.logger.Warn("Failed to pre-launch browser", map[string]interface{}{
+					"error": err.Error(),
+				})
+				return
+			}
+
+			select {
+			case gbp.availableBrowsers <- managedBrowser:
+				launchedMu.Lock()
+				launched++
+				launchedMu.Unlock()
+			default:
+				gbp.closeManagedBrowser(managedBrowser)
+			}
+		}()
+	}
+	wg.Wait()
+
+	gbp.mu.RLock()
+	currentCount := gbp.currentInstances
+	gbp.mu.RUnlock()
+
+	gbp.logger.Info("Browser pool topped up", map[string]interface{}{
+		"launched":          launched,
+		"min_instances":     gbp.minInstances,
+		"current_instances": currentCount,
+	})
+}
+
+// launchBrowser returns the control URL of a new browser and the ID of its local process. With
+// BrowserPool.RemoteURLs set, it connects to the remote endpoints in turn and the process is 0;
+// otherwise it launches a local Chrome.
+func (gbp *GlobalBrowserPool) launchBrowser(ctx context.Context) (string, int, error) {
+	if remoteURLs := gbp.config.BrowserPool.RemoteURLs; len(remoteURLs) > 0 {
+		gbp.mu.Lock()
+		remoteURL := remoteURLs[gbp.nextRemote%len(remoteURLs)]
+		gbp.nextRemote++
+		gbp.mu.Unlock()
+
+		// WebSocket URLs are used as given, keeping any token in their query; anything else is
+		// an HTTP debugging endpoint asked for its browser's WebSocket URL
+		if strings.HasPrefix(remoteURL, "ws://") || strings.HasPrefix(remoteURL, "wss://") {
+			return remoteURL, 0, nil
+		}
+		controlURL, err := launcher.ResolveURL(remoteURL)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to resolve remote browser %s: %w", remoteURL, err)
+		}
+		return controlURL, 0, nil
+	}
+
+	// Create a fresh launcher for each browser to avoid "already launched" errors
+	freshLauncher := gbp.createFreshLauncher()
+
+	// Launch browser with fresh launcher and extended timeout
+	controlURL, err := freshLauncher.Context(ctx).Launch()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to launch browser: %w", err)
+	}
+	return controlURL, freshLauncher.PID(), nil
+}
+
 // createFreshLauncher creates a new launcher instance based on the template
 func (gbp *GlobalBrowserPool) createFreshLauncher() *launcher.Launcher {
 	// Create a new launcher with the same configuration as the template
@@ -390,8 +617,17 @@ func (gbp *GlobalBrowserPool) createGlobalInstance(managedBrowser *ManagedBrowse
 	pageCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	page, err := gbp.createStealthPageWithContext(pageCtx, managedBrowser.Browser)
+	// Give every acquisition a fresh incognito context so jobs of different users never see each
+	// other's cookies or storage
+	incognito, err := managedBrowser.Browser.Context(pageCtx).Incognito()
 	if err != nil {
+		gbp.closeManagedBrowser(managedBrowser)
+		return nil, fmt.Errorf("failed to create incognito context: %w", err)
+	}
+
+	page, err := gbp.createStealthPageWithContext(pageCtx, incognito)
+	if err != nil {
+		_ = proto.TargetDisposeBrowserContext{BrowserContextID: incognito.BrowserContextID}.Call(managedBrowser.Browser)
 		gbp.closeManagedBrowser(managedBrowser)
 		return nil, fmt.Errorf("failed to create page: %w", err)
 	}
@@ -406,6 +642,7 @@ func (gbp *GlobalBrowserPool) createGlobalInstance(managedBrowser *ManagedBrowse
 		Page:      page,
 		pool:      gbp,
 		createdAt: time.Now(),
+		contextID: incognito.BrowserContextID,
 	}, nil
 }
 
@@ -605,6 +842,7 @@ func (gbp *GlobalBrowserPool) startCleanupRoutine() {
 			select {
 			case <-gbp.cleanupTicker.C:
 				gbp.cleanupIdleBrowsers()
+				gbp.ensureMinBrowsers()
 			case <-gbp.ctx.Done():
 				return
 			}
@@ -612,13 +850,90 @@ func (gbp *GlobalBrowserPool) startCleanupRoutine() {
 	}()
 }
 
-// cleanupIdleBrowsers removes browsers that have been idle too long
+// startAutoscaler periodically resizes the pool to the demand for browsers
+func (gbp *GlobalBrowserPool) startAutoscaler() {
+	ticker := time.NewTicker(gbp.config.BrowserPool.ScaleInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				gbp.scaleToDemand()
+			case <-gbp.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// scaleToDemand adjusts maxInstances once. While screenshots wait for a browser, or recent
+// acquisitions took longer than BrowserPool.ScaleTargetAcquisition on average, it allows a
+// browser per waiting request more, up to the ceiling. Once nothing waits and browsers sit idle,
+// it allows one browser fewer, down to the floor, and closes an idle browser above the new limit.
+func (gbp *GlobalBrowserPool) scaleToDemand() {
+	gbp.metrics.mu.RLock()
+	queued := int(gbp.metrics.QueuedRequests)
+	gbp.metrics.mu.RUnlock()
+	acquisitions := gbp.metrics.acquisitionHistogram.Snapshot()
+
+	gbp.mu.Lock()
+	var averageAcquisition time.Duration
+	if count := acquisitions.Count - gbp.lastAcquisitions.Count; count > 0 {
+		seconds := (acquisitions.Sum - gbp.lastAcquisitions.Sum) / float64(count)
+		averageAcquisition = time.Duration(seconds * float64(time.Second))
+	}
+	gbp.lastAcquisitions = acquisitions
+
+	slow := gbp.config.BrowserPool.ScaleTargetAcquisition > 0 && averageAcquisition > gbp.config.BrowserPool.ScaleTargetAcquisition
+	previous := gbp.maxInstances
+	surplus := 0
+	switch {
+	case (queued > 0 || slow) && gbp.maxInstances < gbp.ceilingInstances:
+		gbp.maxInstances += max(queued, 1)
+		if gbp.maxInstances > gbp.ceilingInstances {
+			gbp.maxInstances = gbp.ceilingInstances
+		}
+		gbp.scaleUps++
+	case queued == 0 && !slow && gbp.maxInstances > gbp.floorInstances && len(gbp.availableBrowsers) > 0:
+		gbp.maxInstances--
+		gbp.scaleDowns++
+		surplus = gbp.currentInstances - gbp.maxInstances
+	}
+	current := gbp.maxInstances
+	gbp.mu.Unlock()
+
+	if current == previous {
+		return
+	}
+	gbp.logger.Info("Browser pool resized", map[string]interface{}{
+		"from":                previous,
+		"to":                  current,
+		"queued_requests":     queued,
+		"average_acquisition": averageAcquisition.String(),
+	})
+
+	// Close idle browsers above the new limit; browsers in use stay until they are idle
+	for ; surplus > 0; surplus-- {
+		select {
+		case managedBrowser := <-gbp.availableBrowsers:
+			gbp.closeManagedBrowser(managedBrowser)
+		default:
+			return
+		}
+	}
+}
+
+// cleanupIdleBrowsers removes browsers that have been idle too long, keeping the pool's minimum
 func (gbp *GlobalBrowserPool) cleanupIdleBrowsers() {
 	now := time.Now()
 	var browsersToClose []*ManagedBrowser
 	var unhealthyBrowsers []*ManagedBrowser
 
 	gbp.mu.RLock()
+	// Idle browsers beyond the minimum may be closed; the minimum stays warm for the next scrape
+	closable := gbp.currentInstances - gbp.minInstances
 	for _, browser := range gbp.browsers {
 		browser.mu.RLock()
 		idleTime := now.Sub(browser.LastUsedAt)
@@ -632,7 +947,13 @@ func (gbp *GlobalBrowserPool) cleanupIdleBrowsers() {
 		}
 		browser.mu.RUnlock()
 
-		if isIdle {
+		if isUnhealthy {
+			gbp.logger.Warn("Found unhealthy browser", map[string]interface{}{
+				"browser_id": browser.ID,
+				"idle_time":  idleTime,
+			})
+			unhealthyBrowsers = append(unhealthyBrowsers, browser)
+		} else if isIdle && len(browsersToClose) < closable {
 			browsersToClose = append(browsersToClose, browser)
 		} else if isStuck {
 			gbp.logger.Warn("Found stuck browser", map[string]interface{}{
@@ -640,12 +961,6 @@ func (gbp *GlobalBrowserPool) cleanupIdleBrowsers() {
 				"stuck_time": idleTime,
 			})
 			unhealthyBrowsers = append(unhealthyBrowsers, browser)
-		} else if isUnhealthy {
-			gbp.logger.Warn("Found unhealthy browser", map[string]interface{}{
-				"browser_id": browser.ID,
-				"idle_time":  idleTime,
-			})
-			unhealthyBrowsers = append(unhealthyBrowsers, browser)
 		}
 	}
 	gbp.mu.RUnlock()
@@ -694,23 +1009,163 @@ func (gbp *GlobalBrowserPool) cleanupIdleBrowsers() {
 
 // GetMetrics returns current browser pool metrics
 func (gbp *GlobalBrowserPool) GetMetrics() *BrowserPoolMetrics {
+	gbp.mu.RLock()
+	maxInstances, scaleUps, scaleDowns := gbp.maxInstances, gbp.scaleUps, gbp.scaleDowns
+	gbp.mu.RUnlock()
+
 	gbp.metrics.mu.RLock()
 	defer gbp.metrics.mu.RUnlock()
 
 	return &BrowserPoolMetrics{
-		TotalBrowsersCreated:   gbp.metrics.TotalBrowsersCreated,
-		TotalBrowsersClosed:    gbp.metrics.TotalBrowsersClosed,
-		CurrentActiveBrowsers:  gbp.metrics.CurrentActiveBrowsers,
-		AvailableBrowsers:      int64(len(gbp.availableBrowsers)),
-		QueuedRequests:         gbp.metrics.QueuedRequests,
-		AverageAcquisitionTime: gbp.metrics.AverageAcquisitionTime,
-		TotalAcquisitionTime:   gbp.metrics.TotalAcquisitionTime,
-		AcquisitionCount:       gbp.metrics.AcquisitionCount,
+		TotalBrowsersCreated:  gbp.metrics.TotalBrowsersCreated,
+		TotalBrowsersClosed:   gbp.metrics.TotalBrowsersClosed,
+		TotalBrowsersRetired:  gbp.metrics.TotalBrowsersRetired,
+		TotalBrowserCrashes:   gbp.metrics.TotalBrowserCrashes,
+		CurrentActiveBrowsers: gbp.metrics.CurrentActiveBrowsers,
+		AvailableBrowsers:     int64(len(gbp.availableBrowsers)),
+		MaxInstances:          int64(maxInstances),
+		ScaleUps:              scaleUps,
+		ScaleDowns:            scaleDowns,
+		QueuedRequests:        gbp.metrics.QueuedRequests,
+		AcquisitionTime:       gbp.metrics.acquisitionHistogram.Snapshot(),
+	}
+}
+
+// GetStats returns browser pool statistics for the monitoring service
+func (gbp *GlobalBrowserPool) GetStats() map[string]interface{} {
+	metrics := gbp.GetMetrics()
+
+	return map[string]interface{}{
+		"browsers_created_total":   metrics.TotalBrowsersCreated,
+		"browsers_closed_total":    metrics.TotalBrowsersClosed,
+		"browsers_retired_total":   metrics.TotalBrowsersRetired,
+		"browser_crashes_total":    metrics.TotalBrowserCrashes,
+		"active_browsers":          metrics.CurrentActiveBrowsers,
+		"available_browsers":       metrics.AvailableBrowsers,
+		"max_instances":            metrics.MaxInstances,
+		"scale_ups":                metrics.ScaleUps,
+		"scale_downs":              metrics.ScaleDowns,
+		"queued_requests":          metrics.QueuedRequests,
+		"acquisition_time_seconds": metrics.AcquisitionTime,
 	}
 }
 
-// ForceCleanupStuckBrowsers forcefully closes browsers that may be stuck
-func (gbp *GlobalBrowserPool) ForceCleanupStuckBrowsers() {
+// BrowserInfo describes a live browser of the pool
+type BrowserInfo struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	AgeSeconds  float64   `json:"age_seconds"`
+	IdleSeconds float64   `json:"idle_seconds"`
+	InUse       bool      `json:"in_use"`
+	UsageCount  int       `json:"usage_count"`
+	Healthy     bool      `json:"healthy"`
+}
+
+// ListBrowsers returns the live browsers of the pool, oldest first, checking the health of
+// each in parallel
+func (gbp *GlobalBrowserPool) ListBrowsers() []BrowserInfo {
+	gbp.mu.RLock()
+	browsers := make([]*ManagedBrowser, len(gbp.browsers))
+	copy(browsers, gbp.browsers)
+	gbp.mu.RUnlock()
+
+	now := time.Now()
+	infos := make([]BrowserInfo, len(browsers))
+	var wg sync.WaitGroup
+	for i, browser := range browsers {
+		browser.mu.RLock()
+		infos[i] = BrowserInfo{
+			ID:          browser.ID,
+			CreatedAt:   browser.CreatedAt,
+			LastUsedAt:  browser.LastUsedAt,
+			AgeSeconds:  now.Sub(browser.CreatedAt).Seconds(),
+			IdleSeconds: now.Sub(browser.LastUsedAt).Seconds(),
+			InUse:       browser.InUse,
+			UsageCount:  browser.UsageCount,
+		}
+		browser.mu.RUnlock()
+
+		wg.Add(1)
+		go func(i int, browser *ManagedBrowser) {
+			defer wg.Done()
+			infos[i].Healthy = gbp.isManagedBrowserHealthy(browser)
+		}(i, browser)
+	}
+	wg.Wait()
+	return infos
+}
+
+// CloseBrowser force-closes the browser with the given ID, in use or not, returning false when
+// the pool has no such browser. A page of the browser still in use fails.
+func (gbp *GlobalBrowserPool) CloseBrowser(id string) bool {
+	var target *ManagedBrowser
+	gbp.mu.RLock()
+	for _, browser := range gbp.browsers {
+		if browser.ID == id {
+			target = browser
+			break
+		}
+	}
+	gbp.mu.RUnlock()
+	if target == nil {
+		return false
+	}
+
+	gbp.logger.Warn("Force closing browser", map[string]interface{}{
+		"browser_id": target.ID,
+		"in_use":     target.InUse,
+	})
+	// An idle browser stays queued in availableBrowsers; AcquireBrowser discards it as unhealthy
+	gbp.closeManagedBrowser(target)
+	return true
+}
+
+// Drain replaces every browser of the pool: idle browsers are closed now and browsers in use are
+// closed when their page is released, after which the pool launches its minimum afresh. It
+// returns the browsers closed and those left to close on release.
+func (gbp *GlobalBrowserPool) Drain() (closed int, pending int) {
+	gbp.mu.Lock()
+	gbp.drainedAt = time.Now()
+	drainedAt := gbp.drainedAt
+	gbp.mu.Unlock()
+
+	for i := len(gbp.availableBrowsers); i > 0; i-- {
+		select {
+		case managedBrowser := <-gbp.availableBrowsers:
+			if !managedBrowser.CreatedAt.Before(drainedAt) {
+				select {
+				case gbp.availableBrowsers <- managedBrowser:
+					continue
+				default:
+				}
+			}
+			gbp.closeManagedBrowser(managedBrowser)
+			closed++
+		default:
+		}
+	}
+
+	gbp.mu.RLock()
+	for _, browser := range gbp.browsers {
+		if browser.CreatedAt.Before(drainedAt) {
+			pending++
+		}
+	}
+	gbp.mu.RUnlock()
+
+	gbp.logger.Info("Browser pool drained", map[string]interface{}{
+		"closed":  closed,
+		"pending": pending,
+	})
+
+	go gbp.ensureMinBrowsers()
+	return closed, pending
+}
+
+// ForceCleanupStuckBrowsers forcefully closes browsers that may be stuck, returning how many it
+// closed
+func (gbp *GlobalBrowserPool) ForceCleanupStuckBrowsers() int {
 	gbp.logger.Info("Starting force cleanup of stuck browsers")
 
 	var stuckBrowsers []*ManagedBrowser
@@ -743,6 +1198,7 @@ func (gbp *GlobalBrowserPool) ForceCleanupStuckBrowsers() {
 			"closed_browsers": len(stuckBrowsers),
 		})
 	}
+	return len(stuckBrowsers)
 }
 
 // Shutdown gracefully shuts down the global browser pool
@@ -805,6 +1261,18 @@ func (gbp *GlobalBrowserPool) IsHealthy() bool {
 	return gbp.ctx.Err() == nil && gbp.currentInstances >= 0
 }
 
+// minBrowserInstances returns the browsers to keep launched: the configured minimum, at most
+// maxInstances, and none in test mode, which starts without launching Chrome
+func minBrowserInstances(cfg *config.Config, maxInstances int) int {
+	if cfg.TestMode.Enabled || cfg.BrowserPool.MinBrowsers <= 0 {
+		return 0
+	}
+	if cfg.BrowserPool.MinBrowsers > maxInstances {
+		return maxInstances
+	}
+	return cfg.BrowserPool.MinBrowsers
+}
+
 // calculateOptimalBrowserInstances calculates optimal number of browser instances
 func calculateOptimalBrowserInstances(cfg *config.Config) int {
 	// Get configurable max browsers
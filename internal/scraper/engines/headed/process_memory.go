@@ -0,0 +1,87 @@
+package headed
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// processTreeRSS returns the resident memory in bytes of the process pid and all of its
+// descendants, read from /proc. Chrome renders pages in child processes, so the memory of the
+// browser process alone misses most of what a browser holds. Systems without /proc return an
+// error.
+func processTreeRSS(pid int) (int64, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	children := make(map[int][]int)
+	for _, entry := range entries {
+		child, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if parent, ok := parentPID(child); ok {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	var total int64
+	pending := []int{pid}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		rss, err := processRSS(current)
+		if err != nil {
+			if current == pid {
+				return 0, err
+			}
+			continue // the process exited while we looked
+		}
+		total += rss
+		pending = append(pending, children[current]...)
+	}
+	return total, nil
+}
+
+// parentPID returns the parent of the process pid from /proc/<pid>/stat
+func parentPID(pid int) (int, bool) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, false
+	}
+	// The command name in parentheses may contain spaces; the state and parent follow it
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return 0, false
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 2 {
+		return 0, false
+	}
+	parent, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, false
+	}
+	return parent, true
+}
+
+// processRSS returns the resident memory in bytes of the process pid from /proc/<pid>/statm
+func processRSS(pid int) (int64, error) {
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read memory of process %d: %w", pid, err)
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("unexpected memory stats of process %d", pid)
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected memory stats of process %d: %w", pid, err)
+	}
+	return pages * int64(os.Getpagesize()), nil
+}
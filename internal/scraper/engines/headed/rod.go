@@ -11,7 +11,12 @@ import (
 	"letraz-utils/internal/llm"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/salary"
+	"letraz-utils/internal/scraper/antibot"
 	"letraz-utils/internal/scraper/captcha"
+	"letraz-utils/internal/scraper/proxy"
+	"letraz-utils/internal/scraper/session"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -22,6 +27,8 @@ type RodScraper struct {
 	browserManager *BrowserManager
 	llmManager     *llm.Manager
 	captchaSolver  captcha.CaptchaSolver
+	proxies        *proxy.Pool
+	sessions       *session.Store // nil when session persistence is disabled
 	logger         types.Logger
 }
 
@@ -37,25 +44,34 @@ type ScrapingResult struct {
 
 // NewRodScraper creates a new Rod scraper instance
 func NewRodScraper(cfg *config.Config, llmManager *llm.Manager) *RodScraper {
+	captchaSolver := captcha.GetGlobalRegistry()
+	if captchaSolver == nil {
+		captchaSolver = captcha.NewRegistry(cfg)
+	}
+
 	return &RodScraper{
 		config:         cfg,
 		browserManager: NewBrowserManager(cfg),
 		llmManager:     llmManager,
-		captchaSolver:  captcha.NewTwoCaptchaSolver(cfg),
+		captchaSolver:  captchaSolver,
+		proxies:        proxy.GetGlobalPool(),
+		sessions:       session.GetGlobalStore(),
 		logger:         logging.GetGlobalLogger(),
 	}
 }
 
 // ScrapeJob scrapes a job posting from the given URL using LLM processing
 func (rs *RodScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	rs.logger.Info("Starting job scrape with Rod engine and LLM processing", map[string]interface{}{
+	logger.Info("Starting job scrape with Rod engine and LLM processing", map[string]interface{}{
 		"url":    url,
 		"engine": "rod_llm",
 	})
 
 	// Get browser instance
+	ctx, via := rs.pickProxy(ctx, url, options)
 	browser, err := rs.browserManager.GetBrowser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get browser instance: %w", err)
@@ -68,14 +84,18 @@ func (rs *RodScraper) ScrapeJob(ctx context.Context, url string, options *models
 		timeout = options.Timeout
 	}
 
-	// Navigate to the URL
+	// Navigate to the URL with the domain's saved session
+	restored := rs.restoreSession(ctx, browser, url)
 	err = browser.Navigate(ctx, url, timeout)
 	if err != nil {
+		rs.reportProxy(ctx, via, url, proxy.OutcomeFailure)
 		return nil, fmt.Errorf("failed to navigate to URL: %w", err)
 	}
 
-	// Wait for page to be fully loaded
+	// Wait for page to be fully loaded; counted as navigation time
+	settled := timing.Start(ctx, timing.StageNavigation)
 	time.Sleep(2 * time.Second)
+	settled()
 
 	// Get initial page HTML to check for captcha
 	initialHTML, err := browser.GetPageHTML()
@@ -84,27 +104,37 @@ func (rs *RodScraper) ScrapeJob(ctx context.Context, url string, options *models
 	}
 
 	// Check for captcha - if detected, return error for hybrid fallback
+	detected := timing.Start(ctx, timing.StageCaptcha)
 	hasCaptcha, siteKey, err := captcha.DetectCaptcha(initialHTML)
+	detected()
 	if err != nil {
-		rs.logger.Debug("Error detecting captcha, continuing with scraping", map[string]interface{}{
+		logger.Debug("Error detecting captcha, continuing with scraping", map[string]interface{}{
 			"url": url,
 		})
 	} else if hasCaptcha {
-		rs.logger.Info("Captcha detected, triggering fallback to Firecrawl", map[string]interface{}{
+		logger.Info("Captcha detected, triggering fallback to Firecrawl", map[string]interface{}{
 			"url":      url,
 			"site_key": siteKey,
 		})
 
 		// Return captcha error to trigger fallback instead of solving
+		rs.reportProxy(ctx, via, url, proxy.OutcomeBlocked)
+		rs.forgetSession(ctx, url, restored)
+		antibot.Record(ctx, "rod", antibot.ClassifyChallenge(0, nil, initialHTML))
 		return nil, utils.NewCaptchaDetectedError(fmt.Sprintf("Captcha detected (type: %s) for URL: %s", siteKey, url))
 	}
 
 	// Use the HTML (either original or post-captcha)
 	html := initialHTML
+	rs.reportProxy(ctx, via, url, proxy.OutcomeSuccess)
+	rs.saveSession(ctx, browser, url)
 
 	// Use LLM to extract job information from HTML
 	job, err := rs.llmManager.ExtractJobData(ctx, html, url)
 	if err != nil {
+		// A login wall or geo-block page loads like any other; it is only worth naming once
+		// nothing could be extracted from it
+		antibot.Record(ctx, "rod", antibot.Classify(0, nil, html))
 		// Don't wrap CustomError types so they can be properly handled upstream
 		if _, ok := err.(*utils.CustomError); ok {
 			return nil, err
@@ -114,7 +144,7 @@ func (rs *RodScraper) ScrapeJob(ctx context.Context, url string, options *models
 
 	processingTime := time.Since(startTime)
 
-	rs.logger.Info("Job scraping completed successfully with LLM processing", map[string]interface{}{
+	logger.Info("Job scraping completed successfully with LLM processing", map[string]interface{}{
 		"url":             url,
 		"processing_time": processingTime,
 		"engine":          "rod_llm",
@@ -123,16 +153,129 @@ func (rs *RodScraper) ScrapeJob(ctx context.Context, url string, options *models
 	return job, nil
 }
 
+// FetchPage returns the rendered HTML of the page at url, or a captcha error when the page is
+// behind a captcha so the hybrid engine can fall back
+func (rs *RodScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	ctx, via := rs.pickProxy(ctx, url, options)
+	browser, err := rs.browserManager.GetBrowser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get browser instance: %w", err)
+	}
+	defer browser.Release()
+
+	timeout := rs.config.Scraper.RequestTimeout
+	if options != nil && options.Timeout > 0 {
+		timeout = options.Timeout
+	}
+
+	restored := rs.restoreSession(ctx, browser, url)
+	if err := browser.Navigate(ctx, url, timeout); err != nil {
+		rs.reportProxy(ctx, via, url, proxy.OutcomeFailure)
+		return "", fmt.Errorf("failed to navigate to URL: %w", err)
+	}
+
+	settled := timing.Start(ctx, timing.StageNavigation)
+	time.Sleep(2 * time.Second)
+	settled()
+
+	html, err := browser.GetPageHTML()
+	if err != nil {
+		return "", fmt.Errorf("failed to get page HTML: %w", err)
+	}
+
+	if hasCaptcha, siteKey, err := captcha.DetectCaptcha(html); err == nil && hasCaptcha {
+		rs.reportProxy(ctx, via, url, proxy.OutcomeBlocked)
+		rs.forgetSession(ctx, url, restored)
+		antibot.Record(ctx, "rod", antibot.ClassifyChallenge(0, nil, html))
+		return "", utils.NewCaptchaDetectedError(fmt.Sprintf("Captcha detected (type: %s) for URL: %s", siteKey, url))
+	}
+	rs.reportProxy(ctx, via, url, proxy.OutcomeSuccess)
+	rs.saveSession(ctx, browser, url)
+
+	return html, nil
+}
+
+// pickProxy picks the proxy a navigation to url goes through and returns ctx carrying it, for
+// the browser manager to route the page through
+func (rs *RodScraper) pickProxy(ctx context.Context, url string, options *models.ScrapeOptions) (context.Context, *proxy.Proxy) {
+	via := rs.proxies.ForRequest(utils.ExtractDomainFromURL(url), options)
+	if via == nil {
+		return ctx, nil
+	}
+	return proxy.WithProxy(ctx, via), via
+}
+
+// reportProxy records the outcome of a navigation through via; failures of cancelled requests
+// say nothing about the proxy and are not recorded
+func (rs *RodScraper) reportProxy(ctx context.Context, via *proxy.Proxy, url string, outcome proxy.Outcome) {
+	if outcome == proxy.OutcomeFailure && ctx.Err() != nil {
+		return
+	}
+	rs.proxies.Report(via, utils.ExtractDomainFromURL(url), outcome)
+}
+
+// restoreSession loads the saved session of url's domain into the page before it navigates, so a
+// clearance earned by an earlier page is presented again. It reports whether one was restored.
+func (rs *RodScraper) restoreSession(ctx context.Context, browser *BrowserInstance, url string) bool {
+	domain := utils.ExtractDomainFromURL(url)
+	saved, ok := rs.sessions.Load(ctx, domain)
+	if !ok {
+		return false
+	}
+	if err := browser.RestoreSession(saved); err != nil {
+		logging.FromContext(ctx).Debug("Failed to restore browser session", map[string]interface{}{
+			"domain": domain,
+			"error":  err.Error(),
+		})
+		return false
+	}
+	logging.FromContext(ctx).Debug("Restored browser session", map[string]interface{}{
+		"domain":  domain,
+		"cookies": len(saved.Cookies),
+	})
+	return true
+}
+
+// saveSession keeps the cookies and localStorage of a page that loaded without a challenge for
+// later pages of url's domain
+func (rs *RodScraper) saveSession(ctx context.Context, browser *BrowserInstance, url string) {
+	if rs.sessions == nil {
+		return
+	}
+	captured, err := browser.CaptureSession()
+	if err != nil {
+		logging.FromContext(ctx).Debug("Failed to capture browser session", map[string]interface{}{
+			"url":   url,
+			"error": err.Error(),
+		})
+		return
+	}
+	if len(captured.Cookies) == 0 && len(captured.LocalStorage) == 0 {
+		return
+	}
+	rs.sessions.Save(ctx, utils.ExtractDomainFromURL(url), captured)
+}
+
+// forgetSession drops the saved session of url's domain when it was restored into a page that
+// was challenged anyway, since the site no longer accepts it
+func (rs *RodScraper) forgetSession(ctx context.Context, url string, restored bool) {
+	if restored {
+		rs.sessions.Delete(ctx, utils.ExtractDomainFromURL(url))
+	}
+}
+
 // ScrapeJobLegacy scrapes a job posting using legacy HTML parsing (for backward compatibility)
 func (rs *RodScraper) ScrapeJobLegacy(ctx context.Context, url string, options *models.ScrapeOptions) (*models.JobPosting, error) {
+	logger := logging.FromContext(ctx)
 	startTime := time.Now()
 
-	rs.logger.Info("Starting job scrape with Rod engine (legacy mode)", map[string]interface{}{
+	logger.Info("Starting job scrape with Rod engine (legacy mode)", map[string]interface{}{
 		"url":    url,
 		"engine": "rod_legacy",
 	})
 
 	// Get browser instance
+	ctx, via := rs.pickProxy(ctx, url, options)
 	browser, err := rs.browserManager.GetBrowser(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get browser instance: %w", err)
@@ -145,20 +288,31 @@ func (rs *RodScraper) ScrapeJobLegacy(ctx context.Context, url string, options *
 		timeout = options.Timeout
 	}
 
-	// Navigate to the URL
+	// Navigate to the URL with the domain's saved session
+	restored := rs.restoreSession(ctx, browser, url)
 	err = browser.Navigate(ctx, url, timeout)
 	if err != nil {
+		rs.reportProxy(ctx, via, url, proxy.OutcomeFailure)
 		return nil, fmt.Errorf("failed to navigate to URL: %w", err)
 	}
 
-	// Wait for page to be fully loaded
+	// Wait for page to be fully loaded; counted as navigation time
+	settled := timing.Start(ctx, timing.StageNavigation)
 	time.Sleep(2 * time.Second)
+	settled()
 
 	// Get page HTML
 	html, err := browser.GetPageHTML()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get page HTML: %w", err)
 	}
+	rs.reportProxy(ctx, via, url, proxy.OutcomeSuccess)
+	if hasCaptcha, _, err := captcha.DetectCaptcha(html); err == nil && hasCaptcha {
+		rs.forgetSession(ctx, url, restored)
+		antibot.Record(ctx, "rod", antibot.ClassifyChallenge(0, nil, html))
+	} else {
+		rs.saveSession(ctx, browser, url)
+	}
 
 	// Extract job information from HTML using legacy method
 	jobPosting, err := rs.extractJobFromHTML(html, url)
@@ -171,7 +325,7 @@ func (rs *RodScraper) ScrapeJobLegacy(ctx context.Context, url string, options *
 	jobPosting.Metadata["processing_time"] = processingTime.String()
 	jobPosting.Metadata["engine"] = "rod_legacy"
 
-	rs.logger.Info("Job scraping completed successfully (legacy mode)", map[string]interface{}{
+	logger.Info("Job scraping completed successfully (legacy mode)", map[string]interface{}{
 		"url":             url,
 		"job_title":       jobPosting.Title,
 		"company":         jobPosting.Company,
@@ -458,15 +612,24 @@ func (rs *RodScraper) extractSalary(doc *goquery.Document) *models.SalaryRange {
 
 	for _, selector := range selectors {
 		if text := strings.TrimSpace(doc.Find(selector).First().Text()); text != "" {
-			return rs.parseSalaryFromText(text)
+			if salaryRange := rs.parseSalaryFromText(text); salaryRange != nil {
+				return salaryRange
+			}
 		}
 	}
 
 	return nil
 }
 
-// extractPostedDate extracts when the job was posted
-func (rs *RodScraper) extractPostedDate(doc *goquery.Document) time.Time {
+// extractPostedDate extracts when the job was posted, preferring the machine-readable datetime
+// of a <time> element over the text of the page; nil when the page does not say
+func (rs *RodScraper) extractPostedDate(doc *goquery.Document) *time.Time {
+	if datetime, ok := doc.Find("time[datetime]").First().Attr("datetime"); ok {
+		if date := rs.parseDateFromText(datetime); !date.IsZero() {
+			return &date
+		}
+	}
+
 	selectors := []string{
 		"[class*='posted'], [class*='date'], time",
 		".posted, .date, .timestamp",
@@ -475,12 +638,12 @@ func (rs *RodScraper) extractPostedDate(doc *goquery.Document) time.Time {
 	for _, selector := range selectors {
 		if text := strings.TrimSpace(doc.Find(selector).First().Text()); text != "" {
 			if date := rs.parseDateFromText(text); !date.IsZero() {
-				return date
+				return &date
 			}
 		}
 	}
 
-	return time.Now() // Default to current time if not found
+	return nil
 }
 
 // Helper methods for text processing and validation
@@ -582,16 +745,23 @@ func (rs *RodScraper) extractSkillsFromText(text string) []string {
 	return foundSkills
 }
 
+// parseSalaryFromText reads the salary range in text, or returns nil when it states none
 func (rs *RodScraper) parseSalaryFromText(text string) *models.SalaryRange {
-	// This is a basic implementation - could be enhanced with more sophisticated parsing
-	// For now, return nil to indicate salary parsing is not implemented
-	return nil
+	parsed, ok := salary.ParseText(text)
+	if !ok {
+		return nil
+	}
+	return &models.SalaryRange{
+		Min:      parsed.Min,
+		Max:      parsed.Max,
+		Currency: parsed.Currency,
+		Period:   parsed.Period,
+	}
 }
 
+// parseDateFromText reads a posted date, absolute or relative to now, or returns the zero time
 func (rs *RodScraper) parseDateFromText(text string) time.Time {
-	// Basic date parsing - could be enhanced
-	// For now, return zero time to indicate date parsing is not implemented
-	return time.Time{}
+	return utils.ParsePostedDate(text, time.Now())
 }
 
 func (rs *RodScraper) deduplicateStrings(slice []string) []string {
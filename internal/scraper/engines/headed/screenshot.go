@@ -2,6 +2,7 @@ package headed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -30,16 +31,17 @@ func NewScreenshotService(cfg *config.Config) *ScreenshotService {
 	}
 }
 
-// CaptureResumeScreenshot captures a screenshot of a resume from letraz-client
+// ErrBrowserCrashed marks a screenshot that failed because its browser died under it
+var ErrBrowserCrashed = errors.New("browser crashed")
+
+// CaptureResumeScreenshot captures a screenshot of a resume from letraz-client. When the browser
+// crashes during the capture, it is evicted from the pool and the capture is retried once on a
+// fresh browser.
 func (ss *ScreenshotService) CaptureResumeScreenshot(ctx context.Context, resumeID string) ([]byte, error) {
 	ss.logger.Info("Starting resume screenshot capture", map[string]interface{}{
 		"resume_id": resumeID,
 	})
 
-	// Create a timeout context for the entire screenshot operation
-	screenshotCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
-	defer cancel()
-
 	// Get global browser pool
 	globalPool, err := GetGlobalBrowserPool()
 	if err != nil {
@@ -50,6 +52,24 @@ func (ss *ScreenshotService) CaptureResumeScreenshot(ctx context.Context, resume
 		return nil, fmt.Errorf("failed to get global browser pool: %w", err)
 	}
 
+	screenshot, err := ss.captureScreenshot(ctx, globalPool, resumeID)
+	if errors.Is(err, ErrBrowserCrashed) && ctx.Err() == nil {
+		ss.logger.Warn("Browser crashed during screenshot, retrying on a fresh browser", map[string]interface{}{
+			"resume_id": resumeID,
+			"error":     err.Error(),
+		})
+		screenshot, err = ss.captureScreenshot(ctx, globalPool, resumeID)
+	}
+	return screenshot, err
+}
+
+// captureScreenshot makes one attempt at a screenshot of a resume on a browser of the pool. An
+// error caused by the browser dying wraps ErrBrowserCrashed.
+func (ss *ScreenshotService) captureScreenshot(ctx context.Context, globalPool *GlobalBrowserPool, resumeID string) (_ []byte, err error) {
+	// Create a timeout context for the entire screenshot operation
+	screenshotCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
 	// Acquire a browser instance from the global pool
 	browserInstance, err := globalPool.AcquireBrowser(screenshotCtx)
 	if err != nil {
@@ -59,7 +79,13 @@ func (ss *ScreenshotService) CaptureResumeScreenshot(ctx context.Context, resume
 		})
 		return nil, fmt.Errorf("failed to acquire browser instance: %w", err)
 	}
-	defer browserInstance.Release()
+	defer func() {
+		// A failure on a dead browser is worth retrying elsewhere; Release evicts the browser
+		if err != nil && browserInstance.Crashed() {
+			err = fmt.Errorf("%w: %w", ErrBrowserCrashed, err)
+		}
+		browserInstance.Release()
+	}()
 
 	// Construct the URL for the resume preview with proper escaping
 	escapedID := url.PathEscape(resumeID)
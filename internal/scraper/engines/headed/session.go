@@ -0,0 +1,110 @@
+package headed
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+
+	"github.com/go-rod/rod/lib/proto"
+	"letraz-utils/internal/scraper/session"
+)
+
+// restoreStorageScript fills the localStorage of an origin before the page's own scripts run,
+// leaving keys the page already has alone
+const restoreStorageScript = `(() => {
+	const origin = %q;
+	const items = %s;
+	if (location.origin !== origin) return;
+	try {
+		for (const [key, value] of Object.entries(items)) {
+			if (localStorage.getItem(key) === null) localStorage.setItem(key, value);
+		}
+	} catch (e) {}
+})()`
+
+// RestoreSession sets the cookies of a saved session on the page and restores its localStorage
+// when the page opens the session's origin. It must be called before navigating.
+func (bi *BrowserInstance) RestoreSession(saved *session.Session) error {
+	if len(saved.Cookies) > 0 {
+		cookies := make([]*proto.NetworkCookieParam, 0, len(saved.Cookies))
+		for _, cookie := range saved.Cookies {
+			param := &proto.NetworkCookieParam{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   cookie.Domain,
+				Path:     cookie.Path,
+				Secure:   cookie.Secure,
+				HTTPOnly: cookie.HTTPOnly,
+				SameSite: proto.NetworkCookieSameSite(cookie.SameSite),
+			}
+			if cookie.Expires > 0 {
+				param.Expires = proto.TimeSinceEpoch(cookie.Expires)
+			}
+			cookies = append(cookies, param)
+		}
+		if err := bi.Page.SetCookies(cookies); err != nil {
+			return fmt.Errorf("failed to restore cookies: %w", err)
+		}
+	}
+
+	if len(saved.LocalStorage) > 0 && saved.Origin != "" {
+		items, err := json.Marshal(saved.LocalStorage)
+		if err != nil {
+			return fmt.Errorf("failed to encode localStorage: %w", err)
+		}
+		if _, err := bi.Page.EvalOnNewDocument(fmt.Sprintf(restoreStorageScript, saved.Origin, items)); err != nil {
+			return fmt.Errorf("failed to restore localStorage: %w", err)
+		}
+	}
+	return nil
+}
+
+// CaptureSession returns the cookies that apply to the page's URL and the localStorage of its
+// origin
+func (bi *BrowserInstance) CaptureSession() (*session.Session, error) {
+	info, err := bi.Page.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page URL: %w", err)
+	}
+	pageURL, err := neturl.Parse(info.URL)
+	if err != nil || pageURL.Host == "" {
+		return nil, fmt.Errorf("page has no origin to capture")
+	}
+
+	cookies, err := bi.Page.Cookies([]string{info.URL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookies: %w", err)
+	}
+	captured := &session.Session{
+		Origin:  pageURL.Scheme + "://" + pageURL.Host,
+		Cookies: make([]session.Cookie, 0, len(cookies)),
+	}
+	for _, cookie := range cookies {
+		saved := session.Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   cookie.Domain,
+			Path:     cookie.Path,
+			HTTPOnly: cookie.HTTPOnly,
+			Secure:   cookie.Secure,
+			SameSite: string(cookie.SameSite),
+		}
+		if !cookie.Session {
+			saved.Expires = float64(cookie.Expires)
+		}
+		captured.Cookies = append(captured.Cookies, saved)
+	}
+
+	// Pages on opaque origins throw when localStorage is touched; they keep cookies only
+	storage, err := bi.Page.Eval(`() => {
+		try {
+			return JSON.stringify(Object.assign({}, window.localStorage));
+		} catch (e) {
+			return "{}";
+		}
+	}`)
+	if err == nil {
+		_ = json.Unmarshal([]byte(storage.Value.Str()), &captured.LocalStorage)
+	}
+	return captured, nil
+}
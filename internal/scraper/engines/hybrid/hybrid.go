@@ -3,7 +3,6 @@ package hybrid
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"letraz-utils/internal/config"
@@ -12,6 +11,7 @@ import (
 	"letraz-utils/internal/logging/types"
 	"letraz-utils/internal/scraper/engines/firecrawl"
 	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/internal/timeline"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -108,7 +108,8 @@ func (h *HybridScraper) isNavigationError(err error) bool {
 
 // ScrapeJob scrapes a job posting using hybrid approach: Rod first, Firecrawl on captcha or navigation errors
 func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
-	h.logger.Info("Starting hybrid job scraping (Rod → Firecrawl fallback)", map[string]interface{}{
+	logger := logging.FromContext(ctx)
+	logger.Info("Starting hybrid job scraping (Rod → Firecrawl fallback)", map[string]interface{}{
 		"url": url,
 	})
 
@@ -118,27 +119,32 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 
 	// Check if this domain is known to have captcha protection
 	if h.captchaDomainMgr.IsKnownCaptchaDomain(url) {
-		h.logger.Info("Domain is known to have captcha protection, skipping Rod and using Firecrawl directly", map[string]interface{}{
+		logger.Info("Domain is known to have captcha protection, skipping Rod and using Firecrawl directly", map[string]interface{}{
 			"url": url,
 		})
 
-		h.logger.Debug("DEBUG: About to call Firecrawl directly", map[string]interface{}{
+		logger.Debug("DEBUG: About to call Firecrawl directly", map[string]interface{}{
 			"url": url,
 		})
 
 		// Mark Firecrawl as used
 		h.usedFirecrawl = true
 
+		timeline.Record(ctx, timeline.EventCaptchaDetected, map[string]interface{}{
+			"known_domain": true,
+			"fallback":     "firecrawl",
+		})
+
 		// Go straight to Firecrawl for known captcha domains
 		job, err := h.firecrawlScraper.ScrapeJob(ctx, url, options)
 
-		h.logger.Debug("DEBUG: Firecrawl direct call completed", map[string]interface{}{
+		logger.Debug("DEBUG: Firecrawl direct call completed", map[string]interface{}{
 			"url":     url,
 			"success": err == nil,
 		})
 
 		if err != nil {
-			h.logger.Debug("DEBUG: Firecrawl direct call failed, returning error", map[string]interface{}{
+			logger.Debug("DEBUG: Firecrawl direct call failed, returning error", map[string]interface{}{
 				"url": url,
 			})
 			// Don't wrap CustomError types so they can be properly handled upstream
@@ -148,21 +154,21 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 			return nil, fmt.Errorf("firecrawl scraping failed for known captcha domain: %w", err)
 		}
 
-		h.logger.Info("Successfully scraped job using Firecrawl (known captcha domain)", map[string]interface{}{
+		logger.Info("Successfully scraped job using Firecrawl (known captcha domain)", map[string]interface{}{
 			"url":       url,
 			"job_title": job.Title,
 			"company":   job.CompanyName,
 			"engine":    "firecrawl_direct",
 		})
 
-		h.logger.Debug("DEBUG: About to return job result from direct path", map[string]interface{}{
+		logger.Debug("DEBUG: About to return job result from direct path", map[string]interface{}{
 			"url": url,
 		})
 		return job, nil
 	}
 
 	// Try Rod scraper first for unknown domains
-	h.logger.Info("Attempting scrape with Rod engine", map[string]interface{}{
+	logger.Info("Attempting scrape with Rod engine", map[string]interface{}{
 		"url": url,
 	})
 
@@ -174,15 +180,19 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 	// Check if it's a captcha error or navigation error - if so, fallback to Firecrawl
 	if err != nil {
 		// Check for captcha errors first
-		if customErr, ok := err.(*utils.CustomError); ok && customErr.Code == http.StatusTemporaryRedirect {
-			h.logger.Info("Rod scraper detected captcha, adding domain to captcha list and falling back to Firecrawl", map[string]interface{}{
+		if customErr, ok := utils.AsCustomError(err); ok && customErr.ErrorCode == utils.ErrCodeCaptchaUnsolved {
+			logger.Info("Rod scraper detected captcha, adding domain to captcha list and falling back to Firecrawl", map[string]interface{}{
 				"url":    url,
 				"reason": customErr.Detail,
 			})
 
+			timeline.Record(ctx, timeline.EventCaptchaDetected, map[string]interface{}{
+				"fallback": "firecrawl",
+			})
+
 			// Add this domain to the captcha domains list for future optimization
 			if addErr := h.captchaDomainMgr.AddCaptchaDomain(url); addErr != nil {
-				h.logger.Warn("Failed to add domain to captcha list", map[string]interface{}{
+				logger.Warn("Failed to add domain to captcha list", map[string]interface{}{
 					"url":   url,
 					"error": addErr.Error(),
 				})
@@ -192,13 +202,13 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 			h.usedFirecrawl = true
 
 			// Fallback to Firecrawl
-			h.logger.Info("Attempting scrape with Firecrawl engine (captcha fallback)", map[string]interface{}{
+			logger.Info("Attempting scrape with Firecrawl engine (captcha fallback)", map[string]interface{}{
 				"url": url,
 			})
 			job, err = h.firecrawlScraper.ScrapeJob(ctx, url, options)
 
 			if err != nil {
-				h.logger.Error("Firecrawl fallback also failed", map[string]interface{}{
+				logger.Error("Firecrawl fallback also failed", map[string]interface{}{
 					"url":   url,
 					"error": err.Error(),
 				})
@@ -210,7 +220,7 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 				return nil, fmt.Errorf("hybrid scraping failed - Rod: captcha detected, Firecrawl: %w", err)
 			}
 
-			h.logger.Info("Successfully scraped job using Firecrawl fallback", map[string]interface{}{
+			logger.Info("Successfully scraped job using Firecrawl fallback", map[string]interface{}{
 				"url":       url,
 				"job_title": job.Title,
 				"company":   job.CompanyName,
@@ -221,7 +231,7 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 
 		// Check for navigation/protocol errors that should trigger Firecrawl fallback
 		if h.isNavigationError(err) {
-			h.logger.Info("Rod scraper failed with navigation/protocol error, falling back to Firecrawl", map[string]interface{}{
+			logger.Info("Rod scraper failed with navigation/protocol error, falling back to Firecrawl", map[string]interface{}{
 				"url":   url,
 				"error": err.Error(),
 			})
@@ -230,13 +240,13 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 			h.usedFirecrawl = true
 
 			// Fallback to Firecrawl
-			h.logger.Info("Attempting scrape with Firecrawl engine (navigation error fallback)", map[string]interface{}{
+			logger.Info("Attempting scrape with Firecrawl engine (navigation error fallback)", map[string]interface{}{
 				"url": url,
 			})
 			job, err = h.firecrawlScraper.ScrapeJob(ctx, url, options)
 
 			if err != nil {
-				h.logger.Error("Firecrawl fallback also failed", map[string]interface{}{
+				logger.Error("Firecrawl fallback also failed", map[string]interface{}{
 					"url":   url,
 					"error": err.Error(),
 				})
@@ -248,7 +258,7 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 				return nil, fmt.Errorf("hybrid scraping failed - Rod: navigation error (%s), Firecrawl: %w", err.Error(), err)
 			}
 
-			h.logger.Info("Successfully scraped job using Firecrawl fallback", map[string]interface{}{
+			logger.Info("Successfully scraped job using Firecrawl fallback", map[string]interface{}{
 				"url":       url,
 				"job_title": job.Title,
 				"company":   job.CompanyName,
@@ -258,7 +268,7 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 		}
 
 		// Non-captcha, non-navigation error from Rod scraper - preserve CustomError types
-		h.logger.Error("Rod scraper failed with non-fallback error", map[string]interface{}{
+		logger.Error("Rod scraper failed with non-fallback error", map[string]interface{}{
 			"url":   url,
 			"error": err.Error(),
 		})
@@ -271,7 +281,7 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 	}
 
 	// Rod scraper succeeded without captcha
-	h.logger.Info("Successfully scraped job using Rod engine (no captcha)", map[string]interface{}{
+	logger.Info("Successfully scraped job using Rod engine (no captcha)", map[string]interface{}{
 		"url":       url,
 		"job_title": job.Title,
 		"company":   job.CompanyName,
@@ -280,9 +290,46 @@ func (h *HybridScraper) ScrapeJob(ctx context.Context, url string, options *mode
 	return job, nil
 }
 
+// FetchPage returns the content of the page at url, fetched with Rod first and with Firecrawl
+// for known captcha domains or when Rod hits a captcha or navigation error
+func (h *HybridScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	logger := logging.FromContext(ctx)
+
+	if h.captchaDomainMgr.IsKnownCaptchaDomain(url) {
+		return h.firecrawlScraper.FetchPage(ctx, url, options)
+	}
+
+	content, err := h.rodScraper.FetchPage(ctx, url, options)
+	if err == nil {
+		return content, nil
+	}
+
+	customErr, ok := utils.AsCustomError(err)
+	captchaDetected := ok && customErr.ErrorCode == utils.ErrCodeCaptchaUnsolved
+	if !captchaDetected && !h.isNavigationError(err) {
+		return "", err
+	}
+
+	if captchaDetected {
+		if addErr := h.captchaDomainMgr.AddCaptchaDomain(url); addErr != nil {
+			logger.Warn("Failed to add domain to captcha list", map[string]interface{}{
+				"url":   url,
+				"error": addErr.Error(),
+			})
+		}
+	}
+
+	logger.Info("Rod could not fetch the page, falling back to Firecrawl", map[string]interface{}{
+		"url":   url,
+		"error": err.Error(),
+	})
+	return h.firecrawlScraper.FetchPage(ctx, url, options)
+}
+
 // ScrapeJobLegacy scrapes a job posting using legacy approach
 func (h *HybridScraper) ScrapeJobLegacy(ctx context.Context, url string, options *models.ScrapeOptions) (*models.JobPosting, error) {
-	h.logger.Info("Starting hybrid legacy job scraping", map[string]interface{}{
+	logger := logging.FromContext(ctx)
+	logger.Info("Starting hybrid legacy job scraping", map[string]interface{}{
 		"url": url,
 	})
 
@@ -292,7 +339,7 @@ func (h *HybridScraper) ScrapeJobLegacy(ctx context.Context, url string, options
 
 	// For legacy scraping, also check captcha domains but don't add new ones since legacy doesn't detect captcha
 	if h.captchaDomainMgr.IsKnownCaptchaDomain(url) {
-		h.logger.Info("Domain is known to have captcha protection, using Firecrawl directly for legacy scraping", map[string]interface{}{
+		logger.Info("Domain is known to have captcha protection, using Firecrawl directly for legacy scraping", map[string]interface{}{
 			"url": url,
 		})
 
@@ -308,7 +355,7 @@ func (h *HybridScraper) ScrapeJobLegacy(ctx context.Context, url string, options
 			return nil, fmt.Errorf("firecrawl legacy scraping failed for known captcha domain: %w", err)
 		}
 
-		h.logger.Info("Successfully scraped job using Firecrawl legacy (known captcha domain)", map[string]interface{}{
+		logger.Info("Successfully scraped job using Firecrawl legacy (known captcha domain)", map[string]interface{}{
 			"url": url,
 		})
 		return jobPosting, nil
@@ -322,12 +369,12 @@ func (h *HybridScraper) ScrapeJobLegacy(ctx context.Context, url string, options
 	if err != nil {
 		// Check if this is a navigation/protocol error that should trigger fallback
 		if h.isNavigationError(err) {
-			h.logger.Info("Rod legacy scraper failed with navigation/protocol error, falling back to Firecrawl", map[string]interface{}{
+			logger.Info("Rod legacy scraper failed with navigation/protocol error, falling back to Firecrawl", map[string]interface{}{
 				"url":   url,
 				"error": err.Error(),
 			})
 		} else {
-			h.logger.Info("Rod legacy scraper failed, falling back to Firecrawl", map[string]interface{}{
+			logger.Info("Rod legacy scraper failed, falling back to Firecrawl", map[string]interface{}{
 				"url":   url,
 				"error": err.Error(),
 			})
@@ -346,11 +393,11 @@ func (h *HybridScraper) ScrapeJobLegacy(ctx context.Context, url string, options
 			return nil, fmt.Errorf("hybrid legacy scraping failed - both Rod and Firecrawl failed: %w", err)
 		}
 
-		h.logger.Info("Successfully scraped job using Firecrawl legacy fallback", map[string]interface{}{
+		logger.Info("Successfully scraped job using Firecrawl legacy fallback", map[string]interface{}{
 			"url": url,
 		})
 	} else {
-		h.logger.Info("Successfully scraped job using Rod legacy", map[string]interface{}{
+		logger.Info("Successfully scraped job using Rod legacy", map[string]interface{}{
 			"url": url,
 		})
 	}
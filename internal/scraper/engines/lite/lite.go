@@ -0,0 +1,374 @@
+package lite
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/net/publicsuffix"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/processors"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/internal/scraper/antibot"
+	"letraz-utils/internal/scraper/captcha"
+	"letraz-utils/internal/scraper/engines/headed"
+	"letraz-utils/internal/scraper/proxy"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/internal/timing"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// maxBodyBytes bounds the decoded size of a fetched page
+const maxBodyBytes = 10 << 20
+
+// errBlocked marks a page the plain HTTP client cannot read, so the browser is needed
+var errBlocked = errors.New("page needs a browser")
+
+// LiteScraper implements job scraping with a plain HTTP client that presents itself like
+// Chrome. Pages that answer with a bot wall, a captcha or a client-rendered shell fall through
+// to the Rod scraper, so the browser pool is only used for pages that need it.
+type LiteScraper struct {
+	config     *config.Config
+	llmManager *llm.Manager
+	client     *http.Client
+	rodScraper *headed.RodScraper
+	proxies    *proxy.Pool
+	logger     types.Logger
+}
+
+// NewLiteScraper creates a new lite scraper instance
+func NewLiteScraper(cfg *config.Config, llmManager *llm.Manager) *LiteScraper {
+	// The error is always nil when options are given
+	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+
+	transport := &http.Transport{
+		Proxy: requestProxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		// Bodies are decoded by decodeBody, which also handles brotli
+		DisableCompression: true,
+	}
+
+	return &LiteScraper{
+		config:     cfg,
+		llmManager: llmManager,
+		client: &http.Client{
+			Transport: transport,
+			Jar:       jar,
+			Timeout:   cfg.Scraper.RequestTimeout,
+		},
+		rodScraper: headed.NewRodScraper(cfg, llmManager),
+		proxies:    proxy.GetGlobalPool(),
+		logger:     logging.GetGlobalLogger(),
+	}
+}
+
+// ScrapeJob scrapes a job posting over plain HTTP, falling through to Rod when the page
+// cannot be read without a browser
+func (ls *LiteScraper) ScrapeJob(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, error) {
+	logger := logging.FromContext(ctx)
+	startTime := time.Now()
+
+	logger.Info("Starting job scrape with lite engine", map[string]interface{}{
+		"url":    url,
+		"engine": "lite",
+	})
+
+	html, err := ls.fetch(ctx, url, options)
+	if err != nil {
+		if _, ok := err.(*utils.CustomError); ok {
+			return nil, err
+		}
+		ls.recordFallback(ctx, url, err)
+		return ls.rodScraper.ScrapeJob(ctx, url, options)
+	}
+
+	job, err := ls.llmManager.ExtractJobData(ctx, html, url)
+	if err != nil {
+		// Don't wrap CustomError types so they can be properly handled upstream
+		if _, ok := err.(*utils.CustomError); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to extract job information using LLM: %w", err)
+	}
+
+	logger.Info("Job scraping completed successfully with lite engine", map[string]interface{}{
+		"url":             url,
+		"processing_time": time.Since(startTime),
+		"engine":          "lite",
+	})
+
+	return job, nil
+}
+
+// ScrapeJobLegacy scrapes a job posting with Rod's legacy HTML parsing, which the lite engine
+// does not reimplement
+func (ls *LiteScraper) ScrapeJobLegacy(ctx context.Context, url string, options *models.ScrapeOptions) (*models.JobPosting, error) {
+	return ls.rodScraper.ScrapeJobLegacy(ctx, url, options)
+}
+
+// FetchPage returns the HTML of the page at url, fetched over plain HTTP or with Rod when the
+// page cannot be read without a browser
+func (ls *LiteScraper) FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	html, err := ls.fetch(ctx, url, options)
+	if err != nil {
+		if _, ok := err.(*utils.CustomError); ok {
+			return "", err
+		}
+		ls.recordFallback(ctx, url, err)
+		return ls.rodScraper.FetchPage(ctx, url, options)
+	}
+	return html, nil
+}
+
+// fetch requests the page with Chrome-like headers and returns its HTML, or an error wrapping
+// errBlocked when the response is a bot wall, a captcha or a page rendered in the browser. A PDF
+// response is returned as its text; a PDF without readable text is a scraping error the browser
+// would not do better on, so it is not wrapped in errBlocked.
+func (ls *LiteScraper) fetch(ctx context.Context, url string, options *models.ScrapeOptions) (string, error) {
+	// The HTTP fetch stands in for browser navigation in the job timings
+	defer timing.Start(ctx, timing.StageNavigation)()
+
+	if options != nil && options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	domain := utils.ExtractDomainFromURL(url)
+	via := ls.proxies.ForRequest(domain, options)
+	if via != nil {
+		ctx = proxy.WithProxy(ctx, via)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	userAgent := ls.config.Scraper.UserAgent
+	if options != nil && options.UserAgent != "" {
+		userAgent = options.UserAgent
+	}
+	setBrowserHeaders(req, userAgent)
+
+	resp, err := ls.client.Do(req)
+	if err != nil {
+		// A cancelled request says nothing about the proxy
+		if ctx.Err() == nil {
+			ls.proxies.Report(via, domain, proxy.OutcomeFailure)
+		}
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusProxyAuthRequired:
+		ls.proxies.Report(via, domain, proxy.OutcomeFailure)
+		return "", fmt.Errorf("proxy rejected its credentials: status %d", resp.StatusCode)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		ls.proxies.Report(via, domain, proxy.OutcomeBlocked)
+		antibot.Record(ctx, "lite", antibot.Classify(resp.StatusCode, resp.Header, readRefusal(resp)))
+		return "", fmt.Errorf("%w: status %d", errBlocked, resp.StatusCode)
+	case http.StatusServiceUnavailable:
+		// Cloudflare serves its JavaScript challenge as a 503; an outage is not a block
+		antibot.Record(ctx, "lite", antibot.Classify(resp.StatusCode, resp.Header, readRefusal(resp)))
+		return "", fmt.Errorf("%w: status %d", errBlocked, resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("request returned status %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if strings.Contains(contentType, "pdf") {
+		ls.proxies.Report(via, domain, proxy.OutcomeSuccess)
+		return readPDF(resp)
+	}
+	if contentType != "" && !strings.Contains(contentType, "html") && !strings.Contains(contentType, "octet-stream") {
+		return "", fmt.Errorf("%w: content type %s", errBlocked, contentType)
+	}
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		return "", err
+	}
+	// Attachments are often served without a PDF content type
+	if processors.IsPDF(body) {
+		ls.proxies.Report(via, domain, proxy.OutcomeSuccess)
+		return pdfText(body)
+	}
+	if contentType != "" && !strings.Contains(contentType, "html") {
+		return "", fmt.Errorf("%w: content type %s", errBlocked, contentType)
+	}
+	html := string(body)
+
+	if hasCaptcha, kind, _ := captcha.DetectCaptcha(html); hasCaptcha {
+		ls.proxies.Report(via, domain, proxy.OutcomeBlocked)
+		antibot.Record(ctx, "lite", antibot.ClassifyChallenge(resp.StatusCode, resp.Header, html))
+		return "", fmt.Errorf("%w: captcha or bot check (%s)", errBlocked, kind)
+	}
+	ls.proxies.Report(via, domain, proxy.OutcomeSuccess)
+
+	text, err := processors.NewHTMLCleaner().ExtractJobContent(html)
+	if err != nil {
+		return "", fmt.Errorf("failed to read page content: %w", err)
+	}
+	if minChars := ls.config.Scraper.Lite.MinContentChars; len(strings.TrimSpace(text)) < minChars {
+		return "", fmt.Errorf("%w: %d characters of text, page is likely rendered by JavaScript", errBlocked, len(text))
+	}
+
+	return html, nil
+}
+
+// recordFallback logs and records on the timeline that a page is handed to Rod
+func (ls *LiteScraper) recordFallback(ctx context.Context, url string, err error) {
+	logging.FromContext(ctx).Info("Lite engine could not read the page, falling through to Rod", map[string]interface{}{
+		"url":     url,
+		"reason":  err.Error(),
+		"blocked": errors.Is(err, errBlocked),
+	})
+	timeline.Record(ctx, timeline.EventEngineSelected, map[string]interface{}{
+		"engine":   "rod",
+		"fallback": "lite",
+		"reason":   err.Error(),
+	})
+}
+
+// Cleanup releases any resources used by the scraper
+func (ls *LiteScraper) Cleanup() {
+	ls.client.CloseIdleConnections()
+	ls.rodScraper.Cleanup()
+}
+
+// IsHealthy reports whether the scraper can process requests; the HTTP client has no state to
+// go bad, so this is the health of the Rod fallback
+func (ls *LiteScraper) IsHealthy() bool {
+	return ls.rodScraper.IsHealthy()
+}
+
+// requestProxy routes a request through the proxy picked for it, or through the proxy of the
+// environment when none was
+func requestProxy(req *http.Request) (*neturl.URL, error) {
+	if via := proxy.FromContext(req.Context()); via != nil {
+		return via.URL, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// setBrowserHeaders sets the headers Chrome sends for a top-level navigation
+func setBrowserHeaders(req *http.Request, userAgent string) {
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	req.Header.Set("Cache-Control", "max-age=0")
+	req.Header.Set("Upgrade-Insecure-Requests", "1")
+	req.Header.Set("Sec-Fetch-Dest", "document")
+	req.Header.Set("Sec-Fetch-Mode", "navigate")
+	req.Header.Set("Sec-Fetch-Site", "none")
+	req.Header.Set("Sec-Fetch-User", "?1")
+	if version := chromeMajorVersion(userAgent); version != "" {
+		req.Header.Set("Sec-Ch-Ua", fmt.Sprintf(`"Not_A Brand";v="8", "Chromium";v="%s", "Google Chrome";v="%s"`, version, version))
+		req.Header.Set("Sec-Ch-Ua-Mobile", "?0")
+		req.Header.Set("Sec-Ch-Ua-Platform", platform(userAgent))
+	}
+}
+
+// chromeMajorVersion returns the major Chrome version in a user agent, or "" for other browsers
+func chromeMajorVersion(userAgent string) string {
+	i := strings.Index(userAgent, "Chrome/")
+	if i < 0 {
+		return ""
+	}
+	version := userAgent[i+len("Chrome/"):]
+	if end := strings.IndexAny(version, ". "); end >= 0 {
+		version = version[:end]
+	}
+	return version
+}
+
+// platform returns the Sec-Ch-Ua-Platform value matching a user agent
+func platform(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "Windows"):
+		return `"Windows"`
+	case strings.Contains(userAgent, "Macintosh"):
+		return `"macOS"`
+	default:
+		return `"Linux"`
+	}
+}
+
+// readRefusal returns the body of a refused response for classifying the block, or "" when it
+// cannot be read
+func readRefusal(resp *http.Response) string {
+	body, err := decodeBody(resp)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// readPDF reads a PDF response and returns its text
+func readPDF(resp *http.Response) (string, error) {
+	body, err := decodeBody(resp)
+	if err != nil {
+		return "", err
+	}
+	return pdfText(body)
+}
+
+// pdfText returns the text of a PDF for extraction
+func pdfText(body []byte) (string, error) {
+	text, err := processors.ExtractPDFText(body)
+	if err != nil {
+		return "", utils.NewScrapingError("cannot read the PDF posting: " + err.Error())
+	}
+	return text, nil
+}
+
+// decodeBody reads the response body, decoding gzip, deflate and brotli content encodings
+func decodeBody(resp *http.Response) ([]byte, error) {
+	var reader io.Reader = resp.Body
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gzip body: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	case "deflate":
+		fl := flate.NewReader(resp.Body)
+		defer fl.Close()
+		reader = fl
+	case "br":
+		reader = brotli.NewReader(resp.Body)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, maxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
@@ -2,19 +2,26 @@ package scraper
 
 import (
 	"fmt"
+	"net/http"
 
+	"letraz-utils/internal/ats"
+	"letraz-utils/internal/chaos"
 	"letraz-utils/internal/config"
 	"letraz-utils/internal/llm"
+	"letraz-utils/internal/salary"
 	"letraz-utils/internal/scraper/engines/brightdata"
 	"letraz-utils/internal/scraper/engines/firecrawl"
 	"letraz-utils/internal/scraper/engines/headed"
 	"letraz-utils/internal/scraper/engines/hybrid"
+	"letraz-utils/internal/scraper/engines/lite"
+	"letraz-utils/internal/scraper/engines/stub"
 )
 
 // DefaultScraperFactory implements ScraperFactory
 type DefaultScraperFactory struct {
 	config     *config.Config
 	llmManager *llm.Manager
+	boards     *ats.Registry
 }
 
 // NewScraperFactory creates a new scraper factory
@@ -22,11 +29,41 @@ func NewScraperFactory(cfg *config.Config, llmManager *llm.Manager) ScraperFacto
 	return &DefaultScraperFactory{
 		config:     cfg,
 		llmManager: llmManager,
+		boards:     ats.NewRegistry(cfg, &http.Client{Timeout: cfg.Scraper.RequestTimeout}),
 	}
 }
 
-// CreateScraper creates a new scraper instance for the given engine
+// CreateScraper creates a new scraper instance for the given engine, wrapped to read postings
+// on known job boards from their APIs and with fault injection when chaos is enabled
 func (f *DefaultScraperFactory) CreateScraper(engine string) (Scraper, error) {
+	scraper, err := f.createScraper(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.config.Scraper.BoardAPIs && !f.config.TestMode.Enabled && engine != "stub" {
+		scraper = &boardScraper{
+			Scraper:    scraper,
+			engine:     engine,
+			registry:   f.boards,
+			llmManager: f.llmManager,
+			salary:     salary.NewNormalizer(f.config),
+		}
+	}
+
+	if injector := chaos.GetGlobalInjector(); injector.IsEnabled() {
+		return &chaosScraper{Scraper: scraper, engine: engine, injector: injector}, nil
+	}
+	return scraper, nil
+}
+
+// createScraper creates the engine implementation for the given engine name
+func (f *DefaultScraperFactory) createScraper(engine string) (Scraper, error) {
+	// Test mode never leaves the process, whatever engine was requested
+	if f.config.TestMode.Enabled {
+		return stub.NewStubScraper(f.config, f.llmManager), nil
+	}
+
 	switch engine {
 	case "hybrid":
 		return hybrid.NewHybridScraper(f.config, f.llmManager), nil
@@ -34,10 +71,15 @@ func (f *DefaultScraperFactory) CreateScraper(engine string) (Scraper, error) {
 		return firecrawl.NewFirecrawlScraper(f.config, f.llmManager), nil
 	case "headed", "rod":
 		return headed.NewRodScraper(f.config, f.llmManager), nil
+	case "lite":
+		return lite.NewLiteScraper(f.config, f.llmManager), nil
 	case "brightdata":
 		return brightdata.NewBrightDataScraper(f.config, f.llmManager), nil
+	case "stub":
+		return stub.NewStubScraper(f.config, f.llmManager), nil
 	case "auto":
-		// Auto mode defaults to hybrid for best performance and fallback capability
+		// The worker pool routes auto scrapes to an engine per domain; anywhere else auto mode
+		// uses hybrid for its fallback capability
 		return hybrid.NewHybridScraper(f.config, f.llmManager), nil
 	default:
 		return nil, fmt.Errorf("unsupported scraper engine: %s", engine)
@@ -46,5 +88,5 @@ func (f *DefaultScraperFactory) CreateScraper(engine string) (Scraper, error) {
 
 // GetSupportedEngines returns a list of supported engine types
 func (f *DefaultScraperFactory) GetSupportedEngines() []string {
-	return []string{"brightdata", "firecrawl", "headed", "hybrid", "auto"}
+	return []string{"brightdata", "firecrawl", "headed", "hybrid", "lite", "stub", "auto"}
 }
@@ -21,6 +21,13 @@ type Scraper interface {
 	IsHealthy() bool
 }
 
+// PageFetcher is implemented by scrapers that can return the content of any page, not only
+// job postings, for callers that do their own extraction
+type PageFetcher interface {
+	// FetchPage returns the HTML or markdown content of the page at url
+	FetchPage(ctx context.Context, url string, options *models.ScrapeOptions) (string, error)
+}
+
 // ScraperFactory creates scrapers based on engine type
 type ScraperFactory interface {
 	// CreateScraper creates a new scraper instance for the given engine
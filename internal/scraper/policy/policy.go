@@ -0,0 +1,243 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/utils"
+)
+
+// Reasons a scrape is refused, reported with POLICY_DENIED errors
+const (
+	ReasonDomainDenied     = "domain_denied"
+	ReasonDomainNotAllowed = "domain_not_allowed"
+	ReasonRobotsTxt        = "robots_txt"
+)
+
+const (
+	// maxRobotsBytes bounds the robots.txt read, as RFC 9309 allows parsers to
+	maxRobotsBytes = 500 << 10
+
+	// robotsRetryTTL is how long an unreachable robots.txt is taken to allow everything before
+	// it is fetched again
+	robotsRetryTTL = 5 * time.Minute
+
+	// robotsFetchTimeout bounds a robots.txt request
+	robotsFetchTimeout = 10 * time.Second
+)
+
+// robotsEntry is a cached robots.txt of a host
+type robotsEntry struct {
+	robots    *robots // nil when the file is missing or unreachable, which allows everything
+	expiresAt time.Time
+}
+
+// Checker decides whether a URL may be scraped, from the configured domain lists and the site's
+// robots.txt, and spaces the scrapes of domains whose robots.txt sets a Crawl-delay
+type Checker struct {
+	config *config.Config
+	client *http.Client
+	logger types.Logger
+
+	mu          sync.Mutex
+	robots      map[string]*robotsEntry // scheme://host -> robots.txt
+	nextAllowed map[string]time.Time    // host -> earliest next scrape under its Crawl-delay
+}
+
+// NewChecker creates a policy checker from configuration
+func NewChecker(cfg *config.Config) *Checker {
+	return &Checker{
+		config:      cfg,
+		client:      &http.Client{Timeout: robotsFetchTimeout},
+		logger:      logging.GetGlobalLogger(),
+		robots:      make(map[string]*robotsEntry),
+		nextAllowed: make(map[string]time.Time),
+	}
+}
+
+// Check returns a POLICY_DENIED error when rawURL may not be scraped: its domain is denied or
+// outside the allow list, or its robots.txt disallows the page. LinkedIn postings are read from
+// BrightData's dataset rather than fetched from LinkedIn, so robots.txt does not apply to them.
+func (c *Checker) Check(ctx context.Context, rawURL string) error {
+	if c == nil {
+		return nil
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	policy := c.config.Scraper.Policy
+
+	if matchesDomain(host, policy.DenyDomains) {
+		return utils.NewPolicyDeniedError(ReasonDomainDenied, fmt.Sprintf("domain %s is on the deny list", host))
+	}
+	if len(policy.AllowDomains) > 0 && !matchesDomain(host, policy.AllowDomains) {
+		return utils.NewPolicyDeniedError(ReasonDomainNotAllowed, fmt.Sprintf("domain %s is not on the allow list", host))
+	}
+
+	if !policy.RespectRobots || utils.IsLinkedInURL(rawURL) {
+		return nil
+	}
+	rules := c.robotsFor(ctx, u)
+	if rules == nil {
+		return nil
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if !rules.allowed(policy.UserAgent, path) {
+		return utils.NewPolicyDeniedError(ReasonRobotsTxt, fmt.Sprintf("robots.txt of %s disallows %s", host, path))
+	}
+	return nil
+}
+
+// Reserve claims the next scrape of rawURL's domain under its robots.txt Crawl-delay, returning
+// zero when the scrape may start now or how long until it may. Domains without a Crawl-delay,
+// or whose robots.txt has not been read by Check, are never held back.
+func (c *Checker) Reserve(rawURL string) time.Duration {
+	if c == nil || !c.config.Scraper.Policy.RespectRobots {
+		return 0
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return 0
+	}
+	host := strings.ToLower(u.Hostname())
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.robots[robotsKey(u)]
+	if !ok || entry.robots == nil {
+		return 0
+	}
+	delay := entry.robots.crawlDelay(c.config.Scraper.Policy.UserAgent)
+	if maxDelay := c.config.Scraper.Policy.MaxCrawlDelay; maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	if next := c.nextAllowed[host]; next.After(now) {
+		return next.Sub(now)
+	}
+	c.nextAllowed[host] = now.Add(delay)
+	return 0
+}
+
+// robotsFor returns the robots.txt of u's site, fetching it when it is not cached or expired
+func (c *Checker) robotsFor(ctx context.Context, u *neturl.URL) *robots {
+	key := robotsKey(u)
+
+	c.mu.Lock()
+	entry, ok := c.robots[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.robots
+	}
+
+	entry = c.fetchRobots(ctx, key)
+
+	c.mu.Lock()
+	c.robots[key] = entry
+	c.mu.Unlock()
+	return entry.robots
+}
+
+// fetchRobots reads the robots.txt at site. A missing file (4xx) allows everything until it
+// expires; an unreachable one (network errors, 5xx) also allows everything, and is retried sooner.
+func (c *Checker) fetchRobots(ctx context.Context, site string) *robotsEntry {
+	retry := &robotsEntry{expiresAt: time.Now().Add(robotsRetryTTL)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return retry
+	}
+	req.Header.Set("User-Agent", c.config.Scraper.UserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.logger.Debug("robots.txt could not be read, allowing the site", map[string]interface{}{
+			"site":  site,
+			"error": err.Error(),
+		})
+		return retry
+	}
+	defer resp.Body.Close()
+
+	expiresAt := time.Now().Add(c.config.Scraper.Policy.RobotsTTL)
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsBytes))
+		if err != nil {
+			return retry
+		}
+		return &robotsEntry{robots: parseRobots(string(body)), expiresAt: expiresAt}
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &robotsEntry{expiresAt: expiresAt}
+	default:
+		return retry
+	}
+}
+
+// robotsKey returns the origin whose robots.txt governs u
+func robotsKey(u *neturl.URL) string {
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host)
+}
+
+// matchesDomain reports whether host is one of domains or a subdomain of one
+func matchesDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Global checker instance
+var (
+	globalChecker *Checker
+	globalMu      sync.RWMutex
+)
+
+// InitializeGlobalChecker creates the global policy checker
+func InitializeGlobalChecker(cfg *config.Config) *Checker {
+	checker := NewChecker(cfg)
+
+	globalMu.Lock()
+	globalChecker = checker
+	globalMu.Unlock()
+
+	checker.logger.Info("Scraping policy initialized", map[string]interface{}{
+		"respect_robots": cfg.Scraper.Policy.RespectRobots,
+		"allow_domains":  len(cfg.Scraper.Policy.AllowDomains),
+		"deny_domains":   len(cfg.Scraper.Policy.DenyDomains),
+	})
+	return checker
+}
+
+// GetGlobalChecker returns the global policy checker, which is nil and allows everything unless
+// it was initialized
+func GetGlobalChecker() *Checker {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalChecker
+}
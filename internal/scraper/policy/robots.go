@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsRule is an allow or disallow rule of a robots.txt group
+type robotsRule struct {
+	allow   bool
+	path    string
+	pattern *regexp.Regexp // nil for plain prefixes
+}
+
+// robotsGroup holds the rules that apply to a set of user agents
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robots is a parsed robots.txt file
+type robots struct {
+	groups []*robotsGroup
+}
+
+// parseRobots reads a robots.txt file following RFC 9309, with the widely supported
+// Crawl-delay extension. Consecutive user-agent lines share the group of rules after them.
+func parseRobots(body string) *robots {
+	parsed := &robots{}
+	var group *robotsGroup
+	inAgents := false
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				group = &robotsGroup{}
+				parsed.groups = append(parsed.groups, group)
+				inAgents = true
+			}
+			group.agents = append(group.agents, strings.ToLower(value))
+
+		case "allow", "disallow":
+			inAgents = false
+			// An empty disallow allows everything and adds nothing
+			if group == nil || value == "" {
+				continue
+			}
+			group.rules = append(group.rules, newRobotsRule(key == "allow", value))
+
+		case "crawl-delay":
+			inAgents = false
+			if group == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				group.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+
+		default:
+			// Sitemap and unknown lines do not end the list of user agents
+		}
+	}
+	return parsed
+}
+
+// newRobotsRule builds a rule, compiling paths with * and $ wildcards to a pattern
+func newRobotsRule(allow bool, path string) robotsRule {
+	rule := robotsRule{allow: allow, path: path}
+	if !strings.ContainsAny(path, "*$") {
+		return rule
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i, r := range path {
+		switch {
+		case r == '*':
+			pattern.WriteString(".*")
+		case r == '$' && i == len(path)-1:
+			pattern.WriteString("$")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	rule.pattern = regexp.MustCompile(pattern.String())
+	return rule
+}
+
+// matches reports whether the rule applies to path
+func (r robotsRule) matches(path string) bool {
+	if r.pattern != nil {
+		return r.pattern.MatchString(path)
+	}
+	return strings.HasPrefix(path, r.path)
+}
+
+// group returns the group for userAgent: the groups naming it, merged, or else the "*" group
+func (r *robots) group(userAgent string) *robotsGroup {
+	userAgent = strings.ToLower(userAgent)
+
+	var matched, wildcard *robotsGroup
+	for _, group := range r.groups {
+		for _, agent := range group.agents {
+			switch {
+			case agent == "*":
+				if wildcard == nil {
+					wildcard = &robotsGroup{}
+				}
+				wildcard.merge(group)
+			case agent != "" && strings.Contains(userAgent, agent):
+				if matched == nil {
+					matched = &robotsGroup{}
+				}
+				matched.merge(group)
+			default:
+				continue
+			}
+			break
+		}
+	}
+	if matched != nil {
+		return matched
+	}
+	return wildcard
+}
+
+// merge adds the rules and crawl delay of other to g
+func (g *robotsGroup) merge(other *robotsGroup) {
+	g.rules = append(g.rules, other.rules...)
+	if other.crawlDelay > g.crawlDelay {
+		g.crawlDelay = other.crawlDelay
+	}
+}
+
+// allowed reports whether userAgent may fetch path, which includes the query. The longest
+// matching rule decides, and allow wins ties; paths no rule matches are allowed.
+func (r *robots) allowed(userAgent, path string) bool {
+	group := r.group(userAgent)
+	if group == nil || path == "/robots.txt" {
+		return true
+	}
+
+	allowed, longest := true, -1
+	for _, rule := range group.rules {
+		if !rule.matches(path) {
+			continue
+		}
+		if length := len(rule.path); length > longest || (length == longest && rule.allow) {
+			allowed, longest = rule.allow, length
+		}
+	}
+	return allowed
+}
+
+// crawlDelay returns the Crawl-delay that applies to userAgent, or zero
+func (r *robots) crawlDelay(userAgent string) time.Duration {
+	if group := r.group(userAgent); group != nil {
+		return group.crawlDelay
+	}
+	return 0
+}
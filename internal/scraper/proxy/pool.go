@@ -0,0 +1,324 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+	"letraz-utils/pkg/models"
+)
+
+// Rotation strategies
+const (
+	StrategyRoundRobin = "round_robin"
+	StrategySticky     = "sticky"
+)
+
+// Outcome is the result of a request made through a proxy
+type Outcome int
+
+const (
+	// OutcomeSuccess is a page that loaded
+	OutcomeSuccess Outcome = iota
+	// OutcomeFailure is a connection that failed, such as a refused or timed out tunnel, or a
+	// proxy that rejected its credentials
+	OutcomeFailure
+	// OutcomeBlocked is a response showing the target site banned the proxy: a 403 or 429, or
+	// a captcha or bot check
+	OutcomeBlocked
+)
+
+// Proxy is a proxy server of the pool with its health
+type Proxy struct {
+	URL *url.URL
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	downUntil           time.Time
+	bannedUntil         map[string]time.Time // domain -> end of its ban
+	successes           int64
+	failures            int64
+	blocks              int64
+}
+
+// Server returns the proxy address without credentials, as Chrome's proxy settings take it
+func (p *Proxy) Server() string {
+	return p.URL.Scheme + "://" + p.URL.Host
+}
+
+// Credentials returns the username and password of the proxy, if it has any
+func (p *Proxy) Credentials() (username, password string, ok bool) {
+	if p.URL.User == nil {
+		return "", "", false
+	}
+	password, _ = p.URL.User.Password()
+	return p.URL.User.Username(), password, true
+}
+
+// String returns the proxy address without credentials, for logs
+func (p *Proxy) String() string {
+	return p.Server()
+}
+
+// availableAt returns when the proxy can next be used for domain; a zero time means now
+func (p *Proxy) availableAt(domain string, now time.Time) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	available := time.Time{}
+	if p.downUntil.After(now) {
+		available = p.downUntil
+	}
+	if banned := p.bannedUntil[domain]; banned.After(now) && banned.After(available) {
+		available = banned
+	}
+	return available
+}
+
+// Pool rotates requests over the configured proxies, resting proxies that keep failing and
+// skipping a proxy for domains that blocked it
+type Pool struct {
+	proxies     []*Proxy
+	strategy    string
+	maxFailures int
+	banDuration time.Duration
+	logger      types.Logger
+
+	mu     sync.Mutex
+	next   int
+	sticky map[string]*Proxy // domain -> proxy, for the sticky strategy
+}
+
+// NewPool creates a pool of the proxies in configuration; invalid entries are logged and skipped.
+// Entries without a scheme are taken to be HTTP proxies.
+func NewPool(cfg *config.Config) *Pool {
+	logger := logging.GetGlobalLogger()
+
+	pool := &Pool{
+		strategy:    cfg.Scraper.ProxyRotation.Strategy,
+		maxFailures: cfg.Scraper.ProxyRotation.MaxFailures,
+		banDuration: cfg.Scraper.ProxyRotation.BanDuration,
+		logger:      logger,
+		sticky:      make(map[string]*Proxy),
+	}
+	if pool.maxFailures <= 0 {
+		pool.maxFailures = 1
+	}
+	if pool.strategy != StrategySticky {
+		pool.strategy = StrategyRoundRobin
+	}
+
+	for i, raw := range cfg.Scraper.Proxies {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		proxy, err := Parse(raw)
+		if err != nil {
+			logger.Warn("Skipping invalid proxy", map[string]interface{}{
+				"index": i,
+				"error": err.Error(),
+			})
+			continue
+		}
+		pool.proxies = append(pool.proxies, proxy)
+	}
+	return pool
+}
+
+// Parse reads a proxy URL; entries without a scheme are taken to be HTTP proxies. Errors never
+// include the URL, which may hold credentials.
+func Parse(raw string) (*Proxy, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("proxy is not a valid URL")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	return &Proxy{URL: u, bannedUntil: make(map[string]time.Time)}, nil
+}
+
+// IsEnabled reports whether the pool has proxies to rotate over
+func (p *Pool) IsEnabled() bool {
+	return p != nil && len(p.proxies) > 0
+}
+
+// Pick returns the proxy for a request to domain, or nil when no proxies are configured. Proxies
+// resting after failures or banned by domain are skipped; when every proxy is, the one available
+// soonest is returned rather than exposing the server's own address.
+func (p *Pool) Pick(domain string) *Proxy {
+	if !p.IsEnabled() {
+		return nil
+	}
+	domain = strings.ToLower(domain)
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.strategy == StrategySticky {
+		if proxy, ok := p.sticky[domain]; ok && proxy.availableAt(domain, now).IsZero() {
+			return proxy
+		}
+	}
+
+	var soonest *Proxy
+	var soonestAt time.Time
+	for i := 0; i < len(p.proxies); i++ {
+		proxy := p.proxies[(p.next+i)%len(p.proxies)]
+		available := proxy.availableAt(domain, now)
+		if available.IsZero() {
+			p.next = (p.next + i + 1) % len(p.proxies)
+			if p.strategy == StrategySticky {
+				p.sticky[domain] = proxy
+			}
+			return proxy
+		}
+		if soonest == nil || available.Before(soonestAt) {
+			soonest, soonestAt = proxy, available
+		}
+	}
+	return soonest
+}
+
+// ForRequest returns the proxy a request to domain goes through: the one named in the request's
+// options when given and valid, otherwise the pool's pick. It returns nil for direct requests.
+func (p *Pool) ForRequest(domain string, options *models.ScrapeOptions) *Proxy {
+	if options != nil && options.Proxy != "" {
+		proxy, err := Parse(options.Proxy)
+		if err == nil {
+			return proxy
+		}
+		logging.GetGlobalLogger().Warn("Ignoring invalid request proxy", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return p.Pick(domain)
+}
+
+// Report records the outcome of a request to domain made through proxy
+func (p *Pool) Report(proxy *Proxy, domain string, outcome Outcome) {
+	if p == nil || proxy == nil {
+		return
+	}
+	domain = strings.ToLower(domain)
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+
+	switch outcome {
+	case OutcomeSuccess:
+		proxy.successes++
+		proxy.consecutiveFailures = 0
+		delete(proxy.bannedUntil, domain)
+
+	case OutcomeFailure:
+		proxy.failures++
+		proxy.consecutiveFailures++
+		if proxy.consecutiveFailures >= p.maxFailures {
+			proxy.downUntil = time.Now().Add(p.banDuration)
+			proxy.consecutiveFailures = 0
+			p.logger.Warn("Proxy taken out of rotation after repeated failures", map[string]interface{}{
+				"proxy":    proxy.String(),
+				"duration": p.banDuration.String(),
+			})
+		}
+
+	case OutcomeBlocked:
+		proxy.blocks++
+		proxy.bannedUntil[domain] = time.Now().Add(p.banDuration)
+		p.logger.Warn("Proxy blocked by domain, skipping it for the domain", map[string]interface{}{
+			"proxy":    proxy.String(),
+			"domain":   domain,
+			"duration": p.banDuration.String(),
+		})
+	}
+}
+
+// GetStats returns the health of every proxy for the monitoring server
+func (p *Pool) GetStats() map[string]interface{} {
+	if !p.IsEnabled() {
+		return map[string]interface{}{"enabled": false}
+	}
+	now := time.Now()
+
+	proxies := make([]map[string]interface{}, 0, len(p.proxies))
+	for _, proxy := range p.proxies {
+		proxy.mu.Lock()
+		banned := 0
+		for _, until := range proxy.bannedUntil {
+			if until.After(now) {
+				banned++
+			}
+		}
+		proxies = append(proxies, map[string]interface{}{
+			"proxy":          proxy.String(),
+			"available":      !proxy.downUntil.After(now),
+			"successes":      proxy.successes,
+			"failures":       proxy.failures,
+			"blocks":         proxy.blocks,
+			"banned_domains": banned,
+		})
+		proxy.mu.Unlock()
+	}
+	return map[string]interface{}{
+		"enabled":  true,
+		"strategy": p.strategy,
+		"proxies":  proxies,
+	}
+}
+
+type proxyContextKey struct{}
+
+// WithProxy returns a copy of ctx whose requests go through proxy
+func WithProxy(ctx context.Context, proxy *Proxy) context.Context {
+	return context.WithValue(ctx, proxyContextKey{}, proxy)
+}
+
+// FromContext returns the proxy carried by ctx, if any
+func FromContext(ctx context.Context) *Proxy {
+	proxy, _ := ctx.Value(proxyContextKey{}).(*Proxy)
+	return proxy
+}
+
+// Global pool instance
+var (
+	globalPool *Pool
+	globalMu   sync.RWMutex
+)
+
+// InitializeGlobalPool creates the global proxy pool from configuration
+func InitializeGlobalPool(cfg *config.Config) *Pool {
+	pool := NewPool(cfg)
+
+	globalMu.Lock()
+	globalPool = pool
+	globalMu.Unlock()
+
+	if pool.IsEnabled() {
+		pool.logger.Info("Proxy rotation enabled", map[string]interface{}{
+			"proxies":  len(pool.proxies),
+			"strategy": pool.strategy,
+		})
+	}
+	return pool
+}
+
+// GetGlobalPool returns the global proxy pool, which is nil and disabled unless it was initialized
+func GetGlobalPool() *Pool {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalPool
+}
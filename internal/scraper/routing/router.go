@@ -0,0 +1,391 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/metrics"
+)
+
+// keyPrefix namespaces per-domain engine statistics in the key-value store
+const keyPrefix = "engine_route:"
+
+// Reasons an engine was picked
+const (
+	ReasonOverride = "override" // the domain is pinned to the engine in configuration
+	ReasonLearned  = "learned"  // the engine has the best record on the domain
+	ReasonDefault  = "default"  // nothing is known of the domain yet
+	ReasonExplore  = "explore"  // a random candidate, so every engine keeps being measured
+)
+
+// latencyMargin is how close two success rates must be for the faster engine to win
+const latencyMargin = 0.05
+
+// latencyWeight is the weight of a new scrape in an engine's moving average latency
+const latencyWeight = 0.2
+
+// EngineStats is the record of one engine on a domain. Attempts and successes decay with age, so
+// they are fractional.
+type EngineStats struct {
+	Attempts  float64 `json:"attempts"`
+	Successes float64 `json:"successes"`
+	LatencyMS float64 `json:"latency_ms,omitempty"` // moving average of successful scrapes
+}
+
+// score is the smoothed success rate of the engine; an engine never tried scores one half
+func (s *EngineStats) score() float64 {
+	if s == nil {
+		return 0.5
+	}
+	return (s.Successes + 1) / (s.Attempts + 2)
+}
+
+// DomainStats is the record of every engine that scraped a domain
+type DomainStats struct {
+	Engines   map[string]*EngineStats `json:"engines"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// decay ages the attempts and successes of every engine to now, halving them every halfLife
+func (d *DomainStats) decay(now time.Time, halfLife time.Duration) {
+	if halfLife <= 0 || d.UpdatedAt.IsZero() || !now.After(d.UpdatedAt) {
+		return
+	}
+	factor := math.Pow(0.5, float64(now.Sub(d.UpdatedAt))/float64(halfLife))
+	for _, stats := range d.Engines {
+		stats.Attempts *= factor
+		stats.Successes *= factor
+	}
+	d.UpdatedAt = now
+}
+
+// Router picks the engine of "auto" scrapes per domain from the outcomes of earlier scrapes,
+// which are kept in the key-value store so every replica shares them
+type Router struct {
+	store       kv.Store
+	engines     []string
+	overrides   map[string]string
+	exploreRate float64
+	halfLife    time.Duration
+	ttl         time.Duration
+
+	updateMu sync.Mutex // serializes read-modify-write of domain records within the process
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	picks map[string]map[string]int64 // engine -> reason -> picks
+}
+
+// NewRouter creates a router keeping domain records in store. Firecrawl and BrightData are left
+// out of the candidates when their API key is not configured.
+func NewRouter(cfg *config.Config, store kv.Store) *Router {
+	router := &Router{
+		store:       store,
+		overrides:   make(map[string]string),
+		exploreRate: cfg.Scraper.Routing.ExploreRate,
+		halfLife:    cfg.Scraper.Routing.HalfLife,
+		ttl:         cfg.Scraper.Routing.TTL,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		picks:       make(map[string]map[string]int64),
+	}
+
+	for _, engine := range cfg.Scraper.Routing.Engines {
+		engine = Normalize(strings.TrimSpace(engine))
+		switch {
+		case engine == "":
+			continue
+		case engine == "firecrawl" && cfg.Firecrawl.APIKey == "":
+			continue
+		case engine == "brightdata" && cfg.BrightData.APIKey == "":
+			continue
+		}
+		router.engines = append(router.engines, engine)
+	}
+	if len(router.engines) == 0 {
+		router.engines = []string{"rod"}
+	}
+
+	for domain, engine := range cfg.Scraper.Routing.Overrides {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" && engine != "" {
+			router.overrides[domain] = Normalize(strings.TrimSpace(engine))
+		}
+	}
+	return router
+}
+
+// Normalize returns the name an engine is recorded under; "headed" is the Rod engine
+func Normalize(engine string) string {
+	if engine == "headed" {
+		return "rod"
+	}
+	return engine
+}
+
+// Tracked reports whether outcomes of engine are recorded; composite engines such as hybrid
+// are not, as the engine that served the page is not known
+func Tracked(engine string) bool {
+	switch Normalize(engine) {
+	case "lite", "rod", "firecrawl", "brightdata":
+		return true
+	}
+	return false
+}
+
+// Engines returns the candidate engines in order of preference
+func (r *Router) Engines() []string {
+	if r == nil {
+		return nil
+	}
+	return append([]string(nil), r.engines...)
+}
+
+// Pick returns the engine to scrape domain with and why it was picked. Without a router every
+// domain gets the hybrid engine.
+func (r *Router) Pick(ctx context.Context, domain string) (string, string) {
+	if r == nil {
+		return "hybrid", ReasonDefault
+	}
+	domain = strings.ToLower(domain)
+
+	engine, reason := r.pick(ctx, domain)
+	r.mu.Lock()
+	if r.picks[engine] == nil {
+		r.picks[engine] = make(map[string]int64)
+	}
+	r.picks[engine][reason]++
+	r.mu.Unlock()
+	return engine, reason
+}
+
+func (r *Router) pick(ctx context.Context, domain string) (string, string) {
+	if engine, ok := r.override(domain); ok {
+		return engine, ReasonOverride
+	}
+
+	if len(r.engines) > 1 && r.exploreRate > 0 {
+		r.mu.Lock()
+		explore := r.rng.Float64() < r.exploreRate
+		candidate := r.engines[r.rng.Intn(len(r.engines))]
+		r.mu.Unlock()
+		if explore {
+			return candidate, ReasonExplore
+		}
+	}
+
+	stats, ok := r.load(ctx, domain)
+	if !ok {
+		return r.engines[0], ReasonDefault
+	}
+	stats.decay(time.Now(), r.halfLife)
+	return best(r.engines, stats), ReasonLearned
+}
+
+// best returns the candidate with the highest smoothed success rate on the domain. Among
+// candidates within latencyMargin of each other the faster one wins, and candidates without a
+// known latency keep their order of preference.
+func best(engines []string, stats *DomainStats) string {
+	winner := engines[0]
+	for _, engine := range engines[1:] {
+		current, candidate := stats.Engines[winner], stats.Engines[engine]
+		diff := candidate.score() - current.score()
+		switch {
+		case diff > latencyMargin:
+			winner = engine
+		case diff >= -latencyMargin && faster(candidate, current):
+			winner = engine
+		}
+	}
+	return winner
+}
+
+// faster reports whether a has a lower known latency than b
+func faster(a, b *EngineStats) bool {
+	return a != nil && b != nil && a.LatencyMS > 0 && b.LatencyMS > 0 && a.LatencyMS < b.LatencyMS
+}
+
+// override returns the engine configured for domain, preferring the most specific entry when
+// both a domain and one of its parents are listed
+func (r *Router) override(domain string) (string, bool) {
+	matched, engine := "", ""
+	for candidate, pinned := range r.overrides {
+		if (domain == candidate || strings.HasSuffix(domain, "."+candidate)) && len(candidate) > len(matched) {
+			matched, engine = candidate, pinned
+		}
+	}
+	return engine, matched != ""
+}
+
+// Record adds the outcome of a scrape of domain by engine to the domain's record. Latency is
+// only averaged over successful scrapes, as failures often end early or on a timeout. Replicas
+// updating the same domain at the same moment may drop one of the outcomes.
+func (r *Router) Record(ctx context.Context, domain, engine string, success bool, latency time.Duration) {
+	if r == nil || !Tracked(engine) {
+		return
+	}
+	domain = strings.ToLower(domain)
+	engine = Normalize(engine)
+
+	r.updateMu.Lock()
+	defer r.updateMu.Unlock()
+
+	stats, ok := r.load(ctx, domain)
+	if !ok {
+		stats = &DomainStats{Engines: make(map[string]*EngineStats)}
+	}
+	now := time.Now()
+	stats.decay(now, r.halfLife)
+	stats.UpdatedAt = now
+
+	entry := stats.Engines[engine]
+	if entry == nil {
+		entry = &EngineStats{}
+		stats.Engines[engine] = entry
+	}
+	entry.Attempts++
+	if success {
+		entry.Successes++
+		ms := float64(latency.Milliseconds())
+		if entry.LatencyMS == 0 {
+			entry.LatencyMS = ms
+		} else {
+			entry.LatencyMS += latencyWeight * (ms - entry.LatencyMS)
+		}
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+	if err := r.store.Set(ctx, key(domain), data, r.ttl); err != nil {
+		logging.FromContext(ctx).Warn("Failed to save engine routing record", map[string]interface{}{
+			"domain": domain,
+			"engine": engine,
+			"error":  err.Error(),
+		})
+	}
+}
+
+// Stats returns the record of domain, if it has one
+func (r *Router) Stats(ctx context.Context, domain string) (*DomainStats, bool) {
+	if r == nil {
+		return nil, false
+	}
+	stats, ok := r.load(ctx, strings.ToLower(domain))
+	if ok {
+		stats.decay(time.Now(), r.halfLife)
+	}
+	return stats, ok
+}
+
+// load reads the record of domain from the store
+func (r *Router) load(ctx context.Context, domain string) (*DomainStats, bool) {
+	data, err := r.store.Get(ctx, key(domain))
+	if err != nil {
+		return nil, false
+	}
+	var stats DomainStats
+	if err := json.Unmarshal(data, &stats); err != nil || len(stats.Engines) == 0 {
+		return nil, false
+	}
+	return &stats, true
+}
+
+// key returns the store key of domain's record
+func key(domain string) string {
+	return keyPrefix + domain
+}
+
+// GetStats returns the candidates, overrides and picks made for the monitoring server
+func (r *Router) GetStats() map[string]interface{} {
+	if r == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+
+	r.mu.Lock()
+	picks := make(map[string]map[string]int64, len(r.picks))
+	for engine, reasons := range r.picks {
+		picks[engine] = make(map[string]int64, len(reasons))
+		for reason, count := range reasons {
+			picks[engine][reason] = count
+		}
+	}
+	r.mu.Unlock()
+
+	return map[string]interface{}{
+		"enabled":      true,
+		"engines":      r.engines,
+		"overrides":    r.overrides,
+		"explore_rate": r.exploreRate,
+		"picks":        picks,
+	}
+}
+
+// CollectPrometheus exports the picks made per engine and reason
+func (r *Router) CollectPrometheus(w *metrics.PrometheusWriter) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	engines := make([]string, 0, len(r.picks))
+	for engine := range r.picks {
+		engines = append(engines, engine)
+	}
+	sort.Strings(engines)
+	for _, engine := range engines {
+		reasons := make([]string, 0, len(r.picks[engine]))
+		for reason := range r.picks[engine] {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		for _, reason := range reasons {
+			w.Counter("letraz_routing_picks_total", "Engines picked for auto scrapes.", float64(r.picks[engine][reason]),
+				metrics.L("engine", engine), metrics.L("reason", reason))
+		}
+	}
+}
+
+// Global router instance
+var (
+	globalRouter *Router
+	globalMu     sync.RWMutex
+)
+
+// InitializeGlobalRouter creates the global router when routing is enabled in configuration;
+// otherwise the global router stays nil and "auto" scrapes use the hybrid engine
+func InitializeGlobalRouter(cfg *config.Config, store kv.Store) *Router {
+	if !cfg.Scraper.Routing.Enabled || store == nil {
+		return nil
+	}
+	router := NewRouter(cfg, store)
+
+	globalMu.Lock()
+	globalRouter = router
+	globalMu.Unlock()
+
+	logging.GetGlobalLogger().Info("Engine routing enabled", map[string]interface{}{
+		"backend":   store.Backend(),
+		"engines":   router.engines,
+		"overrides": len(router.overrides),
+	})
+	return router
+}
+
+// GetGlobalRouter returns the global router, which is nil unless it was initialized
+func GetGlobalRouter() *Router {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalRouter
+}
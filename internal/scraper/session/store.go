@@ -0,0 +1,140 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+)
+
+// keyPrefix namespaces browser sessions in the key-value store
+const keyPrefix = "scrape_session:"
+
+// Cookie is a browser cookie as kept between pages
+type Cookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires,omitempty"` // Unix seconds; zero for session cookies
+	HTTPOnly bool    `json:"http_only,omitempty"`
+	Secure   bool    `json:"secure,omitempty"`
+	SameSite string  `json:"same_site,omitempty"`
+}
+
+// Session is the browser state a page of a domain left behind: its cookies and the
+// localStorage of the origin it was on
+type Session struct {
+	Origin       string            `json:"origin"`
+	Cookies      []Cookie          `json:"cookies"`
+	LocalStorage map[string]string `json:"local_storage,omitempty"`
+	SavedAt      time.Time         `json:"saved_at"`
+}
+
+// Store keeps one session per domain in the key-value store, which is shared by every browser
+// and, with Redis, every replica
+type Store struct {
+	store kv.Store
+	ttl   time.Duration
+}
+
+// NewStore creates a session store keeping sessions in store for ttl
+func NewStore(store kv.Store, ttl time.Duration) *Store {
+	return &Store{store: store, ttl: ttl}
+}
+
+// Load returns the session of domain, if one was saved and has not expired
+func (s *Store) Load(ctx context.Context, domain string) (*Session, bool) {
+	if s == nil {
+		return nil, false
+	}
+	data, err := s.store.Get(ctx, key(domain))
+	if err != nil {
+		return nil, false
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, false
+	}
+
+	// Drop cookies that expired since the session was saved
+	now := float64(time.Now().Unix())
+	cookies := session.Cookies[:0]
+	for _, cookie := range session.Cookies {
+		if cookie.Expires == 0 || cookie.Expires > now {
+			cookies = append(cookies, cookie)
+		}
+	}
+	session.Cookies = cookies
+	return &session, len(session.Cookies) > 0 || len(session.LocalStorage) > 0
+}
+
+// Save replaces the session of domain
+func (s *Store) Save(ctx context.Context, domain string, session *Session) {
+	if s == nil || session == nil {
+		return
+	}
+	session.SavedAt = time.Now()
+	data, err := json.Marshal(session)
+	if err != nil {
+		return
+	}
+	if err := s.store.Set(ctx, key(domain), data, s.ttl); err != nil {
+		logging.FromContext(ctx).Warn("Failed to save browser session", map[string]interface{}{
+			"domain": domain,
+			"error":  err.Error(),
+		})
+	}
+}
+
+// Delete forgets the session of domain, such as after its clearance stopped being accepted
+func (s *Store) Delete(ctx context.Context, domain string) {
+	if s == nil {
+		return
+	}
+	_ = s.store.Delete(ctx, key(domain))
+}
+
+// key returns the store key of domain's session
+func key(domain string) string {
+	return keyPrefix + strings.ToLower(domain)
+}
+
+// Global store instance
+var (
+	globalStore *Store
+	globalMu    sync.RWMutex
+)
+
+// InitializeGlobalStore creates the global session store when sessions are enabled in
+// configuration; otherwise the global store stays nil and nothing is kept
+func InitializeGlobalStore(cfg *config.Config, store kv.Store) *Store {
+	if !cfg.Scraper.Sessions.Enabled || store == nil {
+		return nil
+	}
+	sessions := NewStore(store, cfg.Scraper.Sessions.TTL)
+
+	globalMu.Lock()
+	globalStore = sessions
+	globalMu.Unlock()
+
+	logging.GetGlobalLogger().Info("Browser session persistence enabled", map[string]interface{}{
+		"backend": store.Backend(),
+		"ttl":     cfg.Scraper.Sessions.TTL.String(),
+	})
+	return sessions
+}
+
+// GetGlobalStore returns the global session store, which is nil and keeps nothing unless it was
+// initialized
+func GetGlobalStore() *Store {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalStore
+}
@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// resultCacheKeyPrefix namespaces cached scrape results in the key-value store
+const resultCacheKeyPrefix = "scrape_cache:"
+
+// resultCache stores scraped jobs keyed by a hash of their canonical URL, so repeated scrapes
+// of a posting, such as the same job saved by several users, reuse the first result. Scrapes
+// asking for an LLM provider or model are keyed by those too, as they extract differently.
+type resultCache struct {
+	store kv.Store
+	ttl   time.Duration
+}
+
+func newResultCache(store kv.Store, ttl time.Duration) *resultCache {
+	return &resultCache{store: store, ttl: ttl}
+}
+
+// key returns the cache key of a posting URL scraped with options
+func (c *resultCache) key(url string, options *models.ScrapeOptions) string {
+	key := utils.CanonicalURL(url)
+	// Scrapes by the default provider and model keep the keys they had before either was chosen
+	if options != nil && (options.LLMProvider != "" || options.Model != "") {
+		key = strings.Join([]string{key, options.LLMProvider, options.Model}, "\x00")
+	}
+	hash := sha256.Sum256([]byte(key))
+	return resultCacheKeyPrefix + hex.EncodeToString(hash[:])
+}
+
+// get returns the cached job of url scraped with options, if any
+func (c *resultCache) get(ctx context.Context, url string, options *models.ScrapeOptions) (*models.Job, bool) {
+	data, err := c.store.Get(ctx, c.key(url, options))
+	if err != nil {
+		return nil, false
+	}
+
+	var job models.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, false
+	}
+	return &job, true
+}
+
+// set stores the job scraped from url with options
+func (c *resultCache) set(ctx context.Context, url string, options *models.ScrapeOptions, job *models.Job) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+	if err := c.store.Set(ctx, c.key(url, options), data, c.ttl); err != nil {
+		logging.FromContext(ctx).Warn("Failed to cache scrape result", map[string]interface{}{
+			"url":   url,
+			"error": err.Error(),
+		})
+	}
+}
+
+// cacheable reports whether jobs scraped with options are read from and stored in the cache;
+// legacy scrapes without the LLM return postings of another shape and are never cached
+func cacheable(options *models.ScrapeOptions) bool {
+	return options == nil || options.LLMProvider != "disabled"
+}
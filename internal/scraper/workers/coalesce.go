@@ -0,0 +1,122 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// inflightJobs tracks the jobs queued or running for each scrape key, so identical requests that
+// arrive while a posting is being scraped share that scrape instead of starting another
+type inflightJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*JobHandle
+}
+
+func newInflightJobs() *inflightJobs {
+	return &inflightJobs{jobs: make(map[string]*JobHandle)}
+}
+
+// coalesceKeyOf identifies the scrapes that produce the same result: the canonical URL and the
+// options that change how the page is fetched or extracted. Priority, timeouts and
+// force_refresh do not, since a scrape in flight is fresh.
+func coalesceKeyOf(url string, options *models.ScrapeOptions) string {
+	key := []string{utils.CanonicalURL(url)}
+	if options != nil {
+		noCache := ""
+		if options.NoCache {
+			noCache = "no_cache"
+		}
+		key = append(key, options.Engine, options.LLMProvider, options.Model, options.UserAgent, options.Proxy, noCache)
+	}
+	return strings.Join(key, "\x00")
+}
+
+// get returns the job in flight for key, if any
+func (f *inflightJobs) get(key string) (*JobHandle, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	handle, ok := f.jobs[key]
+	return handle, ok
+}
+
+// register records handle as the job in flight for key until it completes. When another job
+// got there first, that job is returned instead and handle is not recorded.
+func (f *inflightJobs) register(key string, handle *JobHandle) (*JobHandle, bool) {
+	f.mu.Lock()
+	if existing, ok := f.jobs[key]; ok {
+		f.mu.Unlock()
+		return existing, false
+	}
+	f.jobs[key] = handle
+	f.mu.Unlock()
+
+	handle.OnComplete(func(JobResult) {
+		f.mu.Lock()
+		if f.jobs[key] == handle {
+			delete(f.jobs, key)
+		}
+		f.mu.Unlock()
+	})
+	return handle, true
+}
+
+// follow returns a handle of its own for a request that joins the job in flight behind leader.
+// It completes with the leader's result, marked shared and without the LLM usage, which the
+// leader's requester was billed for. When the leader's requester cancelled the scrape, a
+// requester still waiting queues the scrape again instead of failing with it.
+func (wp *WorkerPool) follow(ctx context.Context, leader *JobHandle, url, domain string, options *models.ScrapeOptions, priority JobPriority) *JobHandle {
+	timeout := wp.config.Workers.Timeout
+	if options != nil && options.Timeout > 0 {
+		timeout = options.Timeout
+	}
+	follower := newJobHandle(ScrapeJob{
+		ID:        utils.GenerateRequestID(),
+		URL:       url,
+		Domain:    domain,
+		Priority:  priority,
+		CreatedAt: time.Now(),
+	}, timeout)
+
+	wp.stats.mu.Lock()
+	wp.stats.JobsCoalesced++
+	wp.stats.mu.Unlock()
+
+	timeline.Record(ctx, timeline.EventJobCoalesced, map[string]interface{}{"url": url, "job_id": leader.ID})
+	logging.FromContext(ctx).Info("Job joined a scrape of the same URL in flight", map[string]interface{}{
+		"job_id":    follower.ID,
+		"leader_id": leader.ID,
+		"url":       url,
+	})
+
+	leader.OnComplete(func(result JobResult) {
+		if errors.Is(result.Error, context.Canceled) && ctx.Err() == nil {
+			// Queueing reads the result cache, so it does not run on the worker goroutine
+			go func() {
+				handle, err := wp.enqueue(ctx, url, options, priority, 0)
+				if err != nil {
+					follower.complete(JobResult{RequestID: follower.ID, Error: err})
+					return
+				}
+				handle.OnComplete(func(result JobResult) {
+					result.RequestID = follower.ID
+					follower.complete(result)
+				})
+			}()
+			return
+		}
+
+		result.RequestID = follower.ID
+		result.Shared = true
+		result.LLMUsage = nil
+		follower.complete(result)
+	})
+	return follower
+}
@@ -0,0 +1,122 @@
+package workers
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"letraz-utils/internal/config"
+)
+
+// domainConcurrency caps the jobs of a domain running at once. The queue consults it when
+// dispatching, so jobs of a domain at its limit stay queued while other domains' jobs run, and
+// workers release the slot when the job finishes.
+type domainConcurrency struct {
+	defaultLimit int
+	limits       map[string]int // domain -> limit, shared with its subdomains
+
+	mu       sync.Mutex
+	running  map[string]int // jobs running per limit group
+	deferred int64          // times a queued job was held back by its domain's limit
+}
+
+// DomainConcurrency describes the jobs of a domain running against its concurrency limit
+type DomainConcurrency struct {
+	Domain  string `json:"domain"`
+	Running int    `json:"running"`
+	Limit   int    `json:"limit"` // 0 when unlimited
+}
+
+// newDomainConcurrency returns the configured limits, or nil when no domain is limited
+func newDomainConcurrency(cfg *config.Config) *domainConcurrency {
+	c := &domainConcurrency{
+		defaultLimit: cfg.Workers.DomainConcurrency.Default,
+		limits:       make(map[string]int),
+		running:      make(map[string]int),
+	}
+	if c.defaultLimit < 0 {
+		c.defaultLimit = 0
+	}
+	for domain, limit := range cfg.Workers.DomainConcurrency.Limits {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" && limit >= 0 {
+			c.limits[domain] = limit
+		}
+	}
+	if c.defaultLimit == 0 && len(c.limits) == 0 {
+		return nil
+	}
+	return c
+}
+
+// group returns the key a domain's running jobs are counted under and its limit: the most
+// specific configured domain it belongs to, or the domain itself with the default limit
+func (c *domainConcurrency) group(domain string) (string, int) {
+	matched, limit := "", c.defaultLimit
+	for candidate, candidateLimit := range c.limits {
+		if (domain == candidate || strings.HasSuffix(domain, "."+candidate)) && len(candidate) > len(matched) {
+			matched, limit = candidate, candidateLimit
+		}
+	}
+	if matched == "" {
+		return domain, limit
+	}
+	return matched, limit
+}
+
+// tryAcquire takes a slot for a job of domain, returning false when the domain is at its limit
+func (c *domainConcurrency) tryAcquire(domain string) bool {
+	key, limit := c.group(domain)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if limit > 0 && c.running[key] >= limit {
+		c.deferred++
+		return false
+	}
+	c.running[key]++
+	return true
+}
+
+// release frees the slot taken for a job of domain
+func (c *domainConcurrency) release(domain string) {
+	key, _ := c.group(domain)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running[key] <= 1 {
+		delete(c.running, key)
+		return
+	}
+	c.running[key]--
+}
+
+// remaining returns how many more jobs of domain may start now, or -1 when it is unlimited
+func (c *domainConcurrency) remaining(domain string) int {
+	key, limit := c.group(domain)
+	if limit == 0 {
+		return -1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running[key] >= limit {
+		return 0
+	}
+	return limit - c.running[key]
+}
+
+// snapshot returns the running jobs of each domain with jobs running, and how often jobs were
+// held back
+func (c *domainConcurrency) snapshot() ([]DomainConcurrency, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	domains := make([]DomainConcurrency, 0, len(c.running))
+	for key, running := range c.running {
+		_, limit := c.group(key)
+		domains = append(domains, DomainConcurrency{Domain: key, Running: running, Limit: limit})
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Domain < domains[j].Domain })
+	return domains, c.deferred
+}
@@ -0,0 +1,187 @@
+package workers
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"letraz-utils/pkg/utils"
+)
+
+// DomainCooldown describes a domain that is temporarily refusing new jobs
+type DomainCooldown struct {
+	Domain              string        `json:"domain"`
+	Until               time.Time     `json:"until"`
+	Remaining           time.Duration `json:"remaining"`
+	Level               int           `json:"level"` // consecutive cooldowns; each doubles the duration
+	Reason              string        `json:"reason"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+}
+
+// cooldownState tracks failures and the current cooldown for a domain
+type cooldownState struct {
+	consecutiveFailures int
+	level               int
+	until               time.Time
+	reason              string
+	lastApplied         time.Time
+}
+
+// countsAgainstDomain reports whether a failure says anything about the domain. LLM errors,
+// including provider rate limits, are ignored.
+func countsAgainstDomain(err error) bool {
+	switch utils.GetErrorCode(err) {
+	case utils.ErrCodeLLMFailed, utils.ErrCodeLLMParseFailed, utils.ErrCodeLLMUnavailable, utils.ErrCodeRateLimited:
+		return false
+	}
+	return true
+}
+
+// cooldownReason returns why a failure should put the domain into cooldown, if it should
+func (rl *RateLimiter) cooldownReason(state *cooldownState, err error) (string, bool) {
+	if utils.IsErrorCode(err, utils.ErrCodeCaptchaUnsolved) {
+		return "captcha", true
+	}
+
+	threshold := rl.config.Workers.Cooldown.FailureThreshold
+	if threshold > 0 && state.consecutiveFailures >= threshold {
+		return "repeated_failures", true
+	}
+	return "", false
+}
+
+// recordCooldownFailure counts a failure and starts an exponentially growing cooldown when the
+// domain is misbehaving; rl.mu must be held
+func (rl *RateLimiter) recordCooldownFailure(domain string, err error) {
+	cfg := rl.config.Workers.Cooldown
+	if !cfg.Enabled || !countsAgainstDomain(err) {
+		return
+	}
+
+	state, exists := rl.cooldowns[domain]
+	if !exists {
+		state = &cooldownState{}
+		rl.cooldowns[domain] = state
+	}
+	state.consecutiveFailures++
+
+	now := time.Now()
+	if now.Before(state.until) {
+		// Already cooling down; failures from jobs that were in flight don't extend it
+		return
+	}
+
+	reason, apply := rl.cooldownReason(state, err)
+	if !apply {
+		return
+	}
+
+	// Escalate only while the domain keeps misbehaving; a long quiet spell starts over
+	if !state.lastApplied.IsZero() && now.Sub(state.lastApplied) > 2*cfg.MaxDuration {
+		state.level = 0
+	}
+
+	duration := cfg.BaseDuration << state.level
+	if duration <= 0 || duration > cfg.MaxDuration {
+		duration = cfg.MaxDuration
+	} else {
+		state.level++
+	}
+
+	state.until = now.Add(duration)
+	state.reason = reason
+	state.lastApplied = now
+	state.consecutiveFailures = 0
+
+	rl.logger.Warn("Domain cooldown applied", map[string]interface{}{
+		"domain":   domain,
+		"reason":   reason,
+		"duration": duration.String(),
+		"level":    state.level,
+	})
+}
+
+// recordCooldownSuccess resets the failure streak and escalation level; rl.mu must be held
+func (rl *RateLimiter) recordCooldownSuccess(domain string) {
+	if state, exists := rl.cooldowns[domain]; exists {
+		state.consecutiveFailures = 0
+		state.level = 0
+	}
+}
+
+// cooldownRemaining returns how long the domain stays in cooldown; rl.mu must be held
+func (rl *RateLimiter) cooldownRemaining(domain string) time.Duration {
+	state, exists := rl.cooldowns[domain]
+	if !exists {
+		return 0
+	}
+	if remaining := time.Until(state.until); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CooldownRemaining returns how long the domain stays in cooldown, or zero
+func (rl *RateLimiter) CooldownRemaining(domain string) time.Duration {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.cooldownRemaining(strings.ToLower(domain))
+}
+
+// Cooldowns returns the domains currently in cooldown, longest remaining first
+func (rl *RateLimiter) Cooldowns() []DomainCooldown {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	now := time.Now()
+	cooldowns := make([]DomainCooldown, 0)
+	for domain, state := range rl.cooldowns {
+		if !now.Before(state.until) {
+			continue
+		}
+		cooldowns = append(cooldowns, DomainCooldown{
+			Domain:              domain,
+			Until:               state.until,
+			Remaining:           state.until.Sub(now),
+			Level:               state.level,
+			Reason:              state.reason,
+			ConsecutiveFailures: state.consecutiveFailures,
+		})
+	}
+
+	sort.Slice(cooldowns, func(i, j int) bool {
+		return cooldowns[i].Remaining > cooldowns[j].Remaining
+	})
+	return cooldowns
+}
+
+// ClearCooldown ends a domain's cooldown and resets its escalation; it reports whether the domain
+// was cooling down
+func (rl *RateLimiter) ClearCooldown(domain string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	state, exists := rl.cooldowns[domain]
+	if !exists {
+		return false
+	}
+	active := time.Now().Before(state.until)
+	delete(rl.cooldowns, domain)
+
+	rl.logger.Info("Domain cooldown cleared", map[string]interface{}{
+		"domain": domain,
+		"active": active,
+	})
+	return active
+}
+
+// cleanupCooldowns forgets domains whose cooldown ended long enough ago that it would no longer
+// escalate; rl.mu must be held
+func (rl *RateLimiter) cleanupCooldowns(now time.Time) {
+	for domain, state := range rl.cooldowns {
+		if now.Sub(state.until) > 2*rl.config.Workers.Cooldown.MaxDuration && state.consecutiveFailures == 0 {
+			delete(rl.cooldowns, domain)
+		}
+	}
+}
@@ -2,31 +2,44 @@ package workers
 
 import (
 	"sync"
+	"time"
 
 	"letraz-utils/internal/logging"
 )
 
 // Dispatcher manages job distribution to workers
 type Dispatcher struct {
-	jobQueue    chan ScrapeJob
-	workers     []*Worker
-	workerQueue chan chan ScrapeJob
-	quit        chan bool
-	logger      logging.Logger
-	mu          sync.RWMutex
-	running     bool
+	jobQueue *DomainQueue
+	// workChan is shared by all workers; whichever worker is idle receives the next job, so
+	// workers can be added or removed without the dispatcher tracking them
+	workChan chan ScrapeJob
+	quit     chan bool
+	wake     chan struct{} // signalled on resume
+	logger   logging.Logger
+	mu       sync.RWMutex
+	running  bool
+
+	// While paused, jobs keep queueing but none are handed to workers
+	paused      bool
+	pausedAt    time.Time
+	pauseReason string
 }
 
-// NewDispatcher creates a new job dispatcher
-func NewDispatcher(jobQueue chan ScrapeJob, workers []*Worker) *Dispatcher {
-	workerQueue := make(chan chan ScrapeJob, len(workers))
+// PauseState describes whether job dispatch is paused
+type PauseState struct {
+	Paused   bool       `json:"paused"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+	Reason   string     `json:"reason,omitempty"`
+}
 
+// NewDispatcher creates a new job dispatcher
+func NewDispatcher(jobQueue *DomainQueue) *Dispatcher {
 	return &Dispatcher{
-		jobQueue:    jobQueue,
-		workers:     workers,
-		workerQueue: workerQueue,
-		quit:        make(chan bool),
-		logger:      logging.GetGlobalLogger(),
+		jobQueue: jobQueue,
+		workChan: make(chan ScrapeJob),
+		quit:     make(chan bool),
+		wake:     make(chan struct{}, 1),
+		logger:   logging.GetGlobalLogger(),
 	}
 }
 
@@ -40,64 +53,120 @@ func (d *Dispatcher) Start() {
 	}
 
 	d.logger.Info("Starting job dispatcher", nil)
-	d.logger.Debug("DEBUG: About to start dispatch goroutine", nil)
 
 	// Start job dispatching
 	go d.dispatch()
 
 	d.running = true
-	d.logger.Info("Job dispatcher started", map[string]interface{}{
-		"workers": len(d.workers),
-	})
-	d.logger.Debug("DEBUG: Dispatcher Start method completed", nil)
+	d.logger.Info("Job dispatcher started", nil)
 }
 
 // Stop stops the dispatcher
 func (d *Dispatcher) Stop() {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	if !d.running {
+		d.mu.Unlock()
 		return
 	}
+	d.running = false
+	d.mu.Unlock()
 
 	d.logger.Info("Stopping job dispatcher", nil)
 
-	// Send quit signal
+	// Send quit signal without holding the lock, which the dispatch loop takes to check for a pause
 	d.quit <- true
 
-	d.running = false
 	d.logger.Info("Job dispatcher stopped", nil)
 }
 
 // dispatch handles the main job dispatching logic
 func (d *Dispatcher) dispatch() {
-	workerIndex := 0
-
 	for {
-		select {
-		case job := <-d.jobQueue:
-			// Simple round-robin assignment
-			// This ensures each job is assigned to exactly one worker
-		assignLoop:
-			for {
-				worker := d.workers[workerIndex]
-				workerIndex = (workerIndex + 1) % len(d.workers)
-				select {
-				case worker.JobChan <- job:
-					break assignLoop
-				default:
-					// Worker is busy, try next one
-					continue
-				}
+		if d.IsPaused() {
+			select {
+			case <-d.wake:
+				continue
+			case <-d.quit:
+				return
+			}
+		}
+
+		job, ok := d.jobQueue.Pop()
+		if !ok {
+			select {
+			case <-d.jobQueue.Ready():
+				continue
+			case <-d.quit:
+				return
 			}
+		}
 
+		// Block until a worker is free; each job is received by exactly one worker
+		select {
+		case d.workChan <- job:
 		case <-d.quit:
 			return
 		}
 	}
 }
 
+// Pause stops handing queued jobs to workers; jobs already running are unaffected
+func (d *Dispatcher) Pause(reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.paused {
+		return
+	}
+	d.paused = true
+	d.pausedAt = time.Now()
+	d.pauseReason = reason
+	d.logger.Warn("Job dispatch paused", map[string]interface{}{
+		"reason": reason,
+	})
+}
+
+// Resume restarts handing queued jobs to workers
+func (d *Dispatcher) Resume() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.paused {
+		return
+	}
+	d.logger.Info("Job dispatch resumed", map[string]interface{}{
+		"paused_for": time.Since(d.pausedAt).String(),
+	})
+	d.paused = false
+	d.pausedAt = time.Time{}
+	d.pauseReason = ""
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// IsPaused returns true if job dispatch is paused
+func (d *Dispatcher) IsPaused() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.paused
+}
+
+// PauseState returns the current pause state
+func (d *Dispatcher) PauseState() PauseState {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	state := PauseState{Paused: d.paused, Reason: d.pauseReason}
+	if d.paused {
+		pausedAt := d.pausedAt
+		state.PausedAt = &pausedAt
+	}
+	return state
+}
+
 // IsRunning returns true if dispatcher is running
 func (d *Dispatcher) IsRunning() bool {
 	d.mu.RLock()
@@ -0,0 +1,105 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"letraz-utils/pkg/utils"
+)
+
+// JobHandle tracks a job submitted to the pool without blocking the submitter. The worker
+// completes it exactly once; any number of callers may wait on it or register completion
+// callbacks, so a result is never lost because the original waiter has gone away.
+type JobHandle struct {
+	ID          string
+	URL         string
+	Domain      string
+	Priority    JobPriority
+	SubmittedAt time.Time
+	Timeout     time.Duration // processing deadline measured from submission
+
+	mu        sync.Mutex
+	done      chan struct{}
+	completed bool
+	result    JobResult
+	callbacks []func(JobResult)
+}
+
+// newJobHandle creates a handle for a job that is about to be queued
+func newJobHandle(job ScrapeJob, timeout time.Duration) *JobHandle {
+	return &JobHandle{
+		ID:          job.ID,
+		URL:         job.URL,
+		Domain:      job.Domain,
+		Priority:    job.Priority,
+		SubmittedAt: job.CreatedAt,
+		Timeout:     timeout,
+		done:        make(chan struct{}),
+	}
+}
+
+// complete records the job result, wakes all waiters and runs the completion callbacks; later
+// calls are ignored
+func (h *JobHandle) complete(result JobResult) {
+	h.mu.Lock()
+	if h.completed {
+		h.mu.Unlock()
+		return
+	}
+	h.completed = true
+	h.result = result
+	callbacks := h.callbacks
+	h.callbacks = nil
+	close(h.done)
+	h.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(result)
+	}
+}
+
+// OnComplete registers a callback that receives the job result; it runs immediately if the job
+// has already completed. Callbacks run on the worker goroutine and should not block.
+func (h *JobHandle) OnComplete(callback func(JobResult)) {
+	h.mu.Lock()
+	if !h.completed {
+		h.callbacks = append(h.callbacks, callback)
+		h.mu.Unlock()
+		return
+	}
+	result := h.result
+	h.mu.Unlock()
+
+	callback(result)
+}
+
+// Result returns the job result and whether the job has completed
+func (h *JobHandle) Result() (*JobResult, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.completed {
+		return nil, false
+	}
+	result := h.result
+	return &result, true
+}
+
+// Wait blocks until the job completes, its processing deadline passes or ctx is done
+func (h *JobHandle) Wait(ctx context.Context) (*JobResult, error) {
+	timer := time.NewTimer(time.Until(h.SubmittedAt.Add(h.Timeout)))
+	defer timer.Stop()
+
+	select {
+	case <-h.done:
+	case <-timer.C:
+		return nil, utils.NewEngineTimeoutError(fmt.Sprintf("job processing timed out after %v", h.Timeout))
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	result := h.result
+	return &result, nil
+}
@@ -40,11 +40,42 @@ const (
 	CircuitHalfOpen
 )
 
+// DomainRateLimiter rate limits and circuit breaks requests per domain
+type DomainRateLimiter interface {
+	// Allow reports whether a request to the domain may proceed
+	Allow(domain string) bool
+
+	// RecordSuccess records a successful request for the domain
+	RecordSuccess(domain string)
+
+	// RecordFailure records a failed request for the domain
+	RecordFailure(domain string, err error)
+
+	// GetDomainStats returns statistics for a specific domain
+	GetDomainStats(domain string) map[string]interface{}
+
+	// GetAllStats returns statistics for all domains
+	GetAllStats() map[string]map[string]interface{}
+
+	// CooldownRemaining returns how long the domain stays in cooldown, or zero
+	CooldownRemaining(domain string) time.Duration
+
+	// Cooldowns returns the domains currently in cooldown
+	Cooldowns() []DomainCooldown
+
+	// ClearCooldown ends a domain's cooldown, reporting whether one was active
+	ClearCooldown(domain string) bool
+
+	// Stop releases background resources
+	Stop()
+}
+
 // RateLimiter manages rate limiting and circuit breaking per domain
 type RateLimiter struct {
 	config          *config.Config
 	domainLimiters  map[string]*DomainLimiter
 	circuitBreakers map[string]*CircuitBreaker
+	cooldowns       map[string]*cooldownState
 	mu              sync.RWMutex
 	logger          logging.Logger
 	cleanupTicker   *time.Ticker
@@ -57,6 +88,7 @@ func NewRateLimiter(cfg *config.Config) *RateLimiter {
 		config:          cfg,
 		domainLimiters:  make(map[string]*DomainLimiter),
 		circuitBreakers: make(map[string]*CircuitBreaker),
+		cooldowns:       make(map[string]*cooldownState),
 		logger:          logging.GetGlobalLogger(),
 		cleanupTicker:   time.NewTicker(5 * time.Minute),
 		stopCleanup:     make(chan bool),
@@ -76,6 +108,15 @@ func (rl *RateLimiter) Allow(domain string) bool {
 	// Normalize domain
 	domain = strings.ToLower(domain)
 
+	// Domains in cooldown refuse new jobs outright
+	if remaining := rl.cooldownRemaining(domain); remaining > 0 {
+		rl.logger.Debug("Request rejected by domain cooldown", map[string]interface{}{
+			"domain":    domain,
+			"remaining": remaining.String(),
+		})
+		return false
+	}
+
 	// Check circuit breaker first
 	if !rl.isCircuitClosed(domain) {
 		rl.logger.Debug("Request rejected by circuit breaker", map[string]interface{}{
@@ -115,6 +156,8 @@ func (rl *RateLimiter) RecordSuccess(domain string) {
 
 	domain = strings.ToLower(domain)
 
+	rl.recordCooldownSuccess(domain)
+
 	// Reset circuit breaker failure count on success
 	if cb, exists := rl.circuitBreakers[domain]; exists {
 		cb.mu.Lock()
@@ -136,6 +179,8 @@ func (rl *RateLimiter) RecordFailure(domain string, err error) {
 
 	domain = strings.ToLower(domain)
 
+	rl.recordCooldownFailure(domain, err)
+
 	// Update domain limiter failure count
 	if limiter, exists := rl.domainLimiters[domain]; exists {
 		limiter.mu.Lock()
@@ -167,9 +212,8 @@ func (rl *RateLimiter) getDomainLimiter(domain string) *DomainLimiter {
 	}
 
 	// Create new limiter
-	// Rate limit: requests per minute converted to requests per second
-	rps := rate.Limit(float64(rl.config.Workers.RateLimit) / 60.0)
-	burst := 5 // Allow bursts of up to 5 requests
+	rps := rate.Limit(rl.requestsPerSecond())
+	burst := domainBurst
 
 	limiter := &DomainLimiter{
 		limiter:  rate.NewLimiter(rps, burst),
@@ -187,6 +231,14 @@ func (rl *RateLimiter) getDomainLimiter(domain string) *DomainLimiter {
 	return limiter
 }
 
+// domainBurst allows bursts of up to 5 requests per domain
+const domainBurst = 5
+
+// requestsPerSecond converts the configured requests-per-minute limit to requests per second
+func (rl *RateLimiter) requestsPerSecond() float64 {
+	return float64(rl.config.Workers.RateLimit) / 60.0
+}
+
 // getCircuitBreaker gets or creates a circuit breaker for a domain
 func (rl *RateLimiter) getCircuitBreaker(domain string) *CircuitBreaker {
 	if cb, exists := rl.circuitBreakers[domain]; exists {
@@ -270,6 +322,16 @@ func (rl *RateLimiter) GetDomainStats(domain string) map[string]interface{} {
 		cb.mu.RUnlock()
 	}
 
+	// Cooldown stats
+	if state, exists := rl.cooldowns[domain]; exists {
+		stats["consecutive_failures"] = state.consecutiveFailures
+		stats["cooldown_level"] = state.level
+		if remaining := rl.cooldownRemaining(domain); remaining > 0 {
+			stats["cooldown_until"] = state.until
+			stats["cooldown_reason"] = state.reason
+		}
+	}
+
 	return stats
 }
 
@@ -288,6 +350,9 @@ func (rl *RateLimiter) GetAllStats() map[string]map[string]interface{} {
 	for domain := range rl.circuitBreakers {
 		domains[domain] = true
 	}
+	for domain := range rl.cooldowns {
+		domains[domain] = true
+	}
 
 	// Get stats for each domain
 	for domain := range domains {
@@ -315,9 +380,12 @@ func (rl *RateLimiter) cleanup() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	cutoff := time.Now().Add(-10 * time.Minute)
+	now := time.Now()
+	cutoff := now.Add(-10 * time.Minute)
 	removedCount := 0
 
+	rl.cleanupCooldowns(now)
+
 	// Clean up domain limiters
 	for domain, limiter := range rl.domainLimiters {
 		limiter.mu.RLock()
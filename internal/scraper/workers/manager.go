@@ -4,20 +4,27 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
 	"letraz-utils/internal/llm"
 	"letraz-utils/internal/logging"
 	"letraz-utils/internal/scraper"
 	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
 )
 
-// PoolManager manages the worker pool lifecycle
+// PoolManager manages the lifecycle of the worker pools and sends each scrape to the pool of
+// its engine
 type PoolManager struct {
 	config         *config.Config
-	pool           *WorkerPool
+	pools          map[string]*WorkerPool
+	rateLimiter    DomainRateLimiter // shared by the pools, since limits are per domain
 	scraperFactory scraper.ScraperFactory
 	llmManager     *llm.Manager
+	redisClient    *utils.RedisClient
+	resultCache    *resultCache
 	logger         logging.Logger
 	mu             sync.RWMutex
 	initialized    bool
@@ -33,6 +40,49 @@ func NewPoolManager(cfg *config.Config, llmManager *llm.Manager) *PoolManager {
 	}
 }
 
+// SetRedisClient provides the shared Redis client used by the distributed rate limiter; it must be
+// called before Initialize
+func (pm *PoolManager) SetRedisClient(client *utils.RedisClient) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.redisClient = client
+}
+
+// EnableResultCache caches scraped jobs in store when the cache is enabled in configuration; it
+// must be called before Initialize
+func (pm *PoolManager) EnableResultCache(store kv.Store) {
+	if !pm.config.Scraper.Cache.Enabled || store == nil {
+		return
+	}
+
+	pm.mu.Lock()
+	pm.resultCache = newResultCache(store, pm.config.Scraper.Cache.TTL)
+	pm.mu.Unlock()
+
+	pm.logger.Info("Scrape result cache enabled", map[string]interface{}{
+		"backend": store.Backend(),
+		"ttl":     pm.config.Scraper.Cache.TTL.String(),
+	})
+}
+
+// newRateLimiter creates the per-domain rate limiter for the configured backend
+func (pm *PoolManager) newRateLimiter() DomainRateLimiter {
+	switch pm.config.Workers.RateLimiterBackend {
+	case "redis":
+		if pm.redisClient != nil {
+			pm.logger.Info("Using Redis-backed distributed rate limiter", nil)
+			return NewRedisRateLimiter(pm.config, pm.redisClient.Client())
+		}
+		pm.logger.Warn("Redis rate limiter requested without a Redis client, using in-memory rate limiter", nil)
+	case "", "memory":
+	default:
+		pm.logger.Warn("Unknown rate limiter backend, using in-memory rate limiter", map[string]interface{}{
+			"backend": pm.config.Workers.RateLimiterBackend,
+		})
+	}
+	return NewRateLimiter(pm.config)
+}
+
 // Initialize initializes the worker pool
 func (pm *PoolManager) Initialize() error {
 	pm.mu.Lock()
@@ -45,19 +95,33 @@ func (pm *PoolManager) Initialize() error {
 	pm.logger.Info("Initializing worker pool", nil)
 	pm.logger.Debug("DEBUG: PoolManager.Initialize() started", nil)
 
-	// Create the worker pool
+	// Create the worker pools
 	pm.logger.Debug("DEBUG: About to create worker pool", nil)
-	pm.pool = NewWorkerPool(pm.config, pm.scraperFactory)
+	pm.rateLimiter = pm.newRateLimiter()
+	pm.pools = map[string]*WorkerPool{
+		PoolBrowser: newWorkerPool(PoolBrowser, browserPoolSizing(pm.config), pm.config, pm.scraperFactory, pm.rateLimiter),
+	}
+	if pm.config.Workers.APIPool.Enabled {
+		pm.pools[PoolAPI] = newWorkerPool(PoolAPI, apiPoolSizing(pm.config), pm.config, pm.scraperFactory, pm.rateLimiter)
+	}
+	for _, pool := range pm.pools {
+		pool.cache = pm.resultCache
+	}
 	pm.logger.Debug("DEBUG: Worker pool created successfully", nil)
 
-	// Start the worker pool
+	// Start the worker pools
 	pm.logger.Debug("DEBUG: About to start worker pool", nil)
-	err := pm.pool.Start()
-	if err != nil {
-		pm.logger.Error("DEBUG: Worker pool start failed", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return fmt.Errorf("failed to start worker pool: %w", err)
+	for name, pool := range pm.pools {
+		if err := pool.Start(); err != nil {
+			pm.logger.Error("DEBUG: Worker pool start failed", map[string]interface{}{
+				"pool":  name,
+				"error": err.Error(),
+			})
+			for _, started := range pm.pools {
+				_ = started.Stop()
+			}
+			return fmt.Errorf("failed to start %s worker pool: %w", name, err)
+		}
 	}
 	pm.logger.Debug("DEBUG: Worker pool start returned successfully", nil)
 
@@ -72,67 +136,292 @@ func (pm *PoolManager) Shutdown() error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	if !pm.initialized || pm.pool == nil {
+	if !pm.initialized || len(pm.pools) == 0 {
 		return nil
 	}
 
 	pm.logger.Info("Shutting down worker pool", nil)
 
-	err := pm.pool.Stop()
-	if err != nil {
-		pm.logger.Error("Error stopping worker pool", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return err
+	for name, pool := range pm.pools {
+		if err := pool.Stop(); err != nil {
+			pm.logger.Error("Error stopping worker pool", map[string]interface{}{
+				"pool":  name,
+				"error": err.Error(),
+			})
+			return err
+		}
 	}
 
 	// Stop rate limiter cleanup
-	pm.pool.rateLimiter.Stop()
+	pm.rateLimiter.Stop()
 
 	pm.initialized = false
 	pm.logger.Info("Worker pool shutdown complete", nil)
 	return nil
 }
 
-// SubmitJob submits a scraping job to the worker pool
+// SubmitJob submits a scraping job to the worker pool and waits for it, at high priority unless
+// options request another
 func (pm *PoolManager) SubmitJob(ctx context.Context, url string, options *models.ScrapeOptions) (*JobResult, error) {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	if !pm.initialized || pm.pool == nil {
+	if !pm.initialized || len(pm.pools) == 0 {
 		return nil, fmt.Errorf("worker pool not initialized")
 	}
 
-	return pm.pool.SubmitJob(ctx, url, options)
+	return pm.poolFor(url, options).SubmitJob(ctx, url, options)
 }
 
-// GetStats returns worker pool statistics
-func (pm *PoolManager) GetStats() (*PoolManagerStats, error) {
+// Enqueue queues a scraping job without waiting for it and returns a handle to await the result
+func (pm *PoolManager) Enqueue(ctx context.Context, url string, options *models.ScrapeOptions) (*JobHandle, error) {
+	return pm.EnqueueWithPriority(ctx, url, options, optionsPriority(options, PriorityNormal))
+}
+
+// EnqueueWithPriority queues a scraping job like Enqueue at an explicit priority
+func (pm *PoolManager) EnqueueWithPriority(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority) (*JobHandle, error) {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	if !pm.initialized || pm.pool == nil {
+	if !pm.initialized || len(pm.pools) == 0 {
 		return nil, fmt.Errorf("worker pool not initialized")
 	}
 
-	poolStats := pm.pool.GetStats()
-	rateLimiterStats := pm.pool.rateLimiter.GetAllStats()
+	return pm.poolFor(url, options).Enqueue(ctx, url, options, priority)
+}
+
+// poolFor returns the pool that runs scrapes of url by the engine requested in options; without
+// the API pool every scrape runs in the browser pool. pm.mu must be held.
+func (pm *PoolManager) poolFor(url string, options *models.ScrapeOptions) *WorkerPool {
+	if pool, ok := pm.pools[poolOf(url, options)]; ok {
+		return pool
+	}
+	return pm.pools[PoolBrowser]
+}
 
-	return &PoolManagerStats{
+// EnqueuePaced queues a scraping job like EnqueueWithPriority but waits out the domain's rate
+// limit instead of failing, for work such as batches that queues many URLs of one domain at
+// once. It still fails at once while the domain cools down after repeated failures, and gives up
+// when the rate limit would hold the job past maxWait.
+func (pm *PoolManager) EnqueuePaced(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority, maxWait time.Duration) (*JobHandle, error) {
+	deadline := time.Now().Add(maxWait)
+	for {
+		handle, err := pm.EnqueueWithPriority(ctx, url, options, priority)
+		if err == nil || !utils.IsErrorCode(err, utils.ErrCodeRateLimited) || pm.coolingDown(url) {
+			return handle, err
+		}
+
+		wait := utils.GetRetryAfter(err)
+		if wait <= 0 {
+			wait = minRetryAfter
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// coolingDown reports whether the domain of url is in cooldown after repeated failures
+func (pm *PoolManager) coolingDown(url string) bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return false
+	}
+	return pm.rateLimiter.CooldownRemaining(extractDomain(url)) > 0
+}
+
+// SubmitJobWithPriority submits a scraping job to the worker pool at an explicit priority
+func (pm *PoolManager) SubmitJobWithPriority(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority) (*JobResult, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return nil, fmt.Errorf("worker pool not initialized")
+	}
+
+	return pm.poolFor(url, options).SubmitJobWithPriority(ctx, url, options, priority)
+}
+
+// GetStats returns worker pool statistics, totalled over the pools and for each pool
+func (pm *PoolManager) GetStats() (*PoolManagerStats, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return nil, fmt.Errorf("worker pool not initialized")
+	}
+
+	stats := &PoolManagerStats{
 		Initialized:      pm.initialized,
-		PoolStats:        &poolStats,
-		RateLimiterStats: rateLimiterStats,
-		WorkerCount:      len(pm.pool.workers),
-		QueueCapacity:    pm.config.Workers.QueueSize,
-	}, nil
+		PoolStats:        &PoolStatsData{},
+		RateLimiterStats: pm.rateLimiter.GetAllStats(),
+		Scaling:          &ScalingStats{TargetWait: pm.config.Workers.ScaleTargetWait},
+		QueuedByDomain:   make(map[string]int),
+		QueuedByPriority: make(map[string]int),
+		Dispatch:         pm.pools[PoolBrowser].dispatcher.PauseState(),
+		Pools:            make(map[string]*EnginePoolStats, len(pm.pools)),
+	}
+
+	var recentWeighted float64
+	for name, pool := range pm.pools {
+		summary := pool.summary()
+		stats.Pools[name] = summary
+
+		total := stats.PoolStats
+		total.JobsQueued += summary.PoolStats.JobsQueued
+		total.JobsProcessed += summary.PoolStats.JobsProcessed
+		total.JobsSuccessful += summary.PoolStats.JobsSuccessful
+		total.JobsFailed += summary.PoolStats.JobsFailed
+		total.JobsCoalesced += summary.PoolStats.JobsCoalesced
+		total.TotalProcessingTime += summary.PoolStats.TotalProcessingTime
+		recentWeighted += float64(summary.PoolStats.RecentProcessingTime) * float64(summary.PoolStats.JobsProcessed)
+
+		scaling := stats.Scaling
+		scaling.Workers += summary.Scaling.Workers
+		scaling.TargetWorkers += summary.Scaling.TargetWorkers
+		scaling.MinWorkers += summary.Scaling.MinWorkers
+		scaling.MaxWorkers += summary.Scaling.MaxWorkers
+		scaling.ActiveWorkers += summary.Scaling.ActiveWorkers
+		scaling.QueueDepth += summary.Scaling.QueueDepth
+		scaling.ScaleUps += summary.Scaling.ScaleUps
+		scaling.ScaleDowns += summary.Scaling.ScaleDowns
+
+		stats.WorkerCount += summary.WorkerCount
+		stats.QueueCapacity += summary.QueueCapacity
+		for domain, depth := range summary.QueuedByDomain {
+			stats.QueuedByDomain[domain] += depth
+		}
+		for priority, depth := range summary.QueuedByPriority {
+			stats.QueuedByPriority[priority] += depth
+		}
+		stats.ConcurrencyDeferrals += summary.ConcurrencyDeferrals
+	}
+
+	if processed := stats.PoolStats.JobsProcessed; processed > 0 {
+		stats.PoolStats.AverageProcessingTime = stats.PoolStats.TotalProcessingTime / time.Duration(processed)
+		stats.PoolStats.RecentProcessingTime = time.Duration(recentWeighted / float64(processed))
+	}
+	return stats, nil
 }
 
-// IsHealthy returns true if the worker pool is healthy
+// SetWorkerCount sets the baseline number of workers of a pool, clamped to its configured
+// bounds, and returns the resulting scaling state. ErrUnknownPool is returned for a pool that
+// does not run.
+func (pm *PoolManager) SetWorkerCount(poolName string, size int) (*ScalingStats, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return nil, fmt.Errorf("worker pool not initialized")
+	}
+	pool, ok := pm.pools[poolName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPool, poolName)
+	}
+
+	applied := pool.Resize(size)
+	pm.logger.Info("Worker pool target size updated", map[string]interface{}{
+		"pool":      poolName,
+		"requested": size,
+		"applied":   applied,
+	})
+
+	scaling := pool.ScalingStats()
+	return &scaling, nil
+}
+
+// Pause stops dispatching queued jobs to workers in every pool while continuing to accept and
+// queue new ones
+func (pm *PoolManager) Pause(reason string) (PauseState, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return PauseState{}, fmt.Errorf("worker pool not initialized")
+	}
+
+	for _, pool := range pm.pools {
+		pool.dispatcher.Pause(reason)
+	}
+	return pm.pools[PoolBrowser].dispatcher.PauseState(), nil
+}
+
+// Resume restarts dispatching queued jobs to workers in every pool
+func (pm *PoolManager) Resume() (PauseState, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return PauseState{}, fmt.Errorf("worker pool not initialized")
+	}
+
+	for _, pool := range pm.pools {
+		pool.dispatcher.Resume()
+	}
+	return pm.pools[PoolBrowser].dispatcher.PauseState(), nil
+}
+
+// IsHealthy returns true if every worker pool is running
 func (pm *PoolManager) IsHealthy() bool {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	return pm.initialized && pm.pool != nil && pm.pool.IsRunning()
+	if !pm.initialized || len(pm.pools) == 0 {
+		return false
+	}
+	for _, pool := range pm.pools {
+		if !pool.IsRunning() {
+			return false
+		}
+	}
+	return true
+}
+
+// MonitoringStats adapts the pool manager to the monitoring service's StatsProvider interface
+type MonitoringStats struct {
+	pm *PoolManager
+}
+
+// MonitoringStats returns a stats provider for registering the pool with the monitoring service
+func (pm *PoolManager) MonitoringStats() *MonitoringStats {
+	return &MonitoringStats{pm: pm}
+}
+
+// GetStats returns worker pool statistics using the monitoring service's metric names
+func (ms *MonitoringStats) GetStats() map[string]interface{} {
+	stats, err := ms.pm.GetStats()
+	if err != nil {
+		return map[string]interface{}{
+			"initialized": false,
+		}
+	}
+
+	return map[string]interface{}{
+		"initialized":           stats.Initialized,
+		"total_requests":        stats.PoolStats.JobsProcessed,
+		"successful_requests":   stats.PoolStats.JobsSuccessful,
+		"failed_requests":       stats.PoolStats.JobsFailed,
+		"jobs_queued_total":     stats.PoolStats.JobsQueued,
+		"jobs_coalesced":        stats.PoolStats.JobsCoalesced,
+		"concurrency_deferrals": stats.ConcurrencyDeferrals,
+		"worker_count":          stats.WorkerCount,
+		"active_workers":        stats.Scaling.ActiveWorkers,
+		"queue_depth":           stats.Scaling.QueueDepth,
+		"queue_capacity":        stats.QueueCapacity,
+		"dispatch_paused":       stats.Dispatch.Paused,
+	}
+}
+
+// IsHealthy reports whether the worker pool is running
+func (ms *MonitoringStats) IsHealthy() bool {
+	return ms.pm.IsHealthy()
 }
 
 // GetDomainStats returns statistics for a specific domain
@@ -140,11 +429,35 @@ func (pm *PoolManager) GetDomainStats(domain string) (map[string]interface{}, er
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	if !pm.initialized || pm.pool == nil {
+	if !pm.initialized || len(pm.pools) == 0 {
+		return nil, fmt.Errorf("worker pool not initialized")
+	}
+
+	return pm.rateLimiter.GetDomainStats(domain), nil
+}
+
+// DomainCooldowns returns the domains currently refusing new jobs after repeated failures
+func (pm *PoolManager) DomainCooldowns() ([]DomainCooldown, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
 		return nil, fmt.Errorf("worker pool not initialized")
 	}
 
-	return pm.pool.rateLimiter.GetDomainStats(domain), nil
+	return pm.rateLimiter.Cooldowns(), nil
+}
+
+// ClearDomainCooldown ends a domain's cooldown, reporting whether one was active
+func (pm *PoolManager) ClearDomainCooldown(domain string) (bool, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if !pm.initialized || len(pm.pools) == 0 {
+		return false, fmt.Errorf("worker pool not initialized")
+	}
+
+	return pm.rateLimiter.ClearCooldown(domain), nil
 }
 
 // PoolManagerStats represents comprehensive statistics for the pool manager
@@ -154,4 +467,28 @@ type PoolManagerStats struct {
 	RateLimiterStats map[string]map[string]interface{} `json:"rate_limiter_stats"`
 	WorkerCount      int                               `json:"worker_count"`
 	QueueCapacity    int                               `json:"queue_capacity"`
+	Scaling          *ScalingStats                     `json:"scaling"`
+	QueuedByDomain   map[string]int                    `json:"queued_by_domain"`
+	QueuedByPriority map[string]int                    `json:"queued_by_priority"`
+	Dispatch         PauseState                        `json:"dispatch"`
+
+	// How often queued jobs waited on their domain's concurrency limit; the jobs running against
+	// each limit are listed per pool
+	ConcurrencyDeferrals int64 `json:"concurrency_deferrals"`
+
+	Pools map[string]*EnginePoolStats `json:"pools"`
+}
+
+// EnginePoolStats describes one worker pool
+type EnginePoolStats struct {
+	PoolStats        *PoolStatsData `json:"pool_stats"`
+	WorkerCount      int            `json:"worker_count"`
+	QueueCapacity    int            `json:"queue_capacity"`
+	Scaling          *ScalingStats  `json:"scaling"`
+	QueuedByDomain   map[string]int `json:"queued_by_domain"`
+	QueuedByPriority map[string]int `json:"queued_by_priority"`
+
+	// Jobs running in domains with a concurrency limit, and how often queued jobs waited on one
+	DomainConcurrency    []DomainConcurrency `json:"domain_concurrency,omitempty"`
+	ConcurrencyDeferrals int64               `json:"concurrency_deferrals"`
 }
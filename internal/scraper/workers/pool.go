@@ -3,14 +3,24 @@ package workers
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"math"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"letraz-utils/internal/archive"
 	"letraz-utils/internal/config"
+	"letraz-utils/internal/llm"
+	"letraz-utils/internal/llm/cost"
 	"letraz-utils/internal/logging"
+	"letraz-utils/internal/metrics"
 	"letraz-utils/internal/scraper"
+	"letraz-utils/internal/scraper/antibot"
+	"letraz-utils/internal/scraper/policy"
+	"letraz-utils/internal/scraper/routing"
+	"letraz-utils/internal/timeline"
+	"letraz-utils/internal/timing"
 	"letraz-utils/pkg/models"
 	"letraz-utils/pkg/utils"
 )
@@ -22,17 +32,26 @@ type JobResult struct {
 	Error      error
 	RequestID  string
 	Duration   time.Duration
-	UsedLLM    bool // Flag to indicate if LLM was used
+	UsedLLM    bool                           // Flag to indicate if LLM was used
+	Cached     bool                           // Served from the scrape result cache without scraping
+	Shared     bool                           // Joined a scrape of the same URL already in flight
+	Timings    map[timing.Stage]time.Duration // Time spent per stage, including queue wait
+	LLMUsage   map[string]interface{}         // LLM tokens and estimated cost, nil when the LLM was not called
+	AntiBot    map[string]interface{}         // Bot protection blocks met by the engines, nil when none was
+	Routed     string                         // Engine an "auto" scrape was routed to, empty for other engines
+	Page       *archive.Page                  // Last page handed to extraction, nil when none was
 }
 
 // ScrapeJob represents a job to be processed by workers
 type ScrapeJob struct {
-	ID         string
-	URL        string
-	Options    *models.ScrapeOptions
-	ResultChan chan JobResult
-	Context    context.Context
-	CreatedAt  time.Time
+	ID        string
+	URL       string
+	Domain    string
+	Priority  JobPriority
+	Options   *models.ScrapeOptions
+	Handle    *JobHandle // completed by the worker with the job result
+	Context   context.Context
+	CreatedAt time.Time
 }
 
 // Worker represents a single worker goroutine
@@ -42,20 +61,44 @@ type Worker struct {
 	QuitChan chan bool
 	Pool     *WorkerPool
 	logger   logging.Logger
+
+	// Utilization tracking
+	createdAt     time.Time
+	busyNanos     int64
+	jobsProcessed int64
 }
 
 // WorkerPool manages multiple worker goroutines and job queue
 type WorkerPool struct {
+	name           string // PoolBrowser or PoolAPI
 	config         *config.Config
 	workers        []*Worker
-	jobQueue       chan ScrapeJob
+	jobQueue       *DomainQueue
 	dispatcher     *Dispatcher
-	rateLimiter    *RateLimiter
+	rateLimiter    DomainRateLimiter
 	scraperFactory scraper.ScraperFactory
 	logger         logging.Logger
 	mu             sync.RWMutex
 	running        bool
 	stats          *PoolStats
+	jobDuration    *metrics.Histogram                  // processing_time_seconds
+	queueWait      *metrics.Histogram                  // queue_wait_seconds
+	stageDurations map[timing.Stage]*metrics.Histogram // job_stage_seconds, for stages a job went through
+	completions    *metrics.RateMeter                  // queue drain rate used for Retry-After estimates
+	cache          *resultCache                        // nil when the scrape result cache is disabled
+	policy         *policy.Checker                     // nil allows every URL
+	inflight       *inflightJobs                       // nil when identical scrapes are not coalesced
+
+	// Scaling state: targetSize is the baseline worker count (PoolSize, or as set by an admin);
+	// the autoscaler grows beyond it up to maxSize while jobs are queued and shrinks back when idle
+	minSize       int
+	maxSize       int
+	targetSize    int
+	nextWorkerID  int
+	activeWorkers int64
+	scaleStop     chan struct{}
+	scaleUps      int64 // autoscaler resizes, counted under mu
+	scaleDowns    int64
 }
 
 // PoolStats tracks worker pool statistics (internal use with mutex)
@@ -65,55 +108,128 @@ type PoolStats struct {
 	JobsProcessed         int64
 	JobsSuccessful        int64
 	JobsFailed            int64
+	JobsCoalesced         int64
 	TotalProcessingTime   time.Duration
 	AverageProcessingTime time.Duration
+	RecentProcessingTime  time.Duration // moving average weighted towards the latest jobs
 }
 
+// recentWeight is the weight of each finished job in the moving average of processing time
+const recentWeight = 0.2
+
 // PoolStatsData represents pool statistics for external consumption (no mutex)
 type PoolStatsData struct {
 	JobsQueued            int64         `json:"jobs_queued"`
 	JobsProcessed         int64         `json:"jobs_processed"`
 	JobsSuccessful        int64         `json:"jobs_successful"`
 	JobsFailed            int64         `json:"jobs_failed"`
+	JobsCoalesced         int64         `json:"jobs_coalesced"` // requests that shared a scrape in flight
 	TotalProcessingTime   time.Duration `json:"total_processing_time"`
 	AverageProcessingTime time.Duration `json:"average_processing_time"`
+	RecentProcessingTime  time.Duration `json:"recent_processing_time"`
+}
+
+// NewWorkerPool creates a new worker pool instance sized by the top-level worker settings
+func NewWorkerPool(cfg *config.Config, scraperFactory scraper.ScraperFactory, rateLimiter DomainRateLimiter) *WorkerPool {
+	return newWorkerPool(PoolBrowser, browserPoolSizing(cfg), cfg, scraperFactory, rateLimiter)
 }
 
-// NewWorkerPool creates a new worker pool instance
-func NewWorkerPool(cfg *config.Config, scraperFactory scraper.ScraperFactory) *WorkerPool {
-	logger := logging.GetGlobalLogger()
+// newWorkerPool creates a named worker pool of the given size; pools may share a rate limiter
+func newWorkerPool(name string, sizing poolSizing, cfg *config.Config, scraperFactory scraper.ScraperFactory, rateLimiter DomainRateLimiter) *WorkerPool {
+	logger := logging.GetGlobalLogger().WithFields(map[string]interface{}{"pool": name})
+
+	jobQueue := NewDomainQueue(sizing.queueSize)
+	jobQueue.concurrency = newDomainConcurrency(cfg)
 
 	pool := &WorkerPool{
+		name:           name,
 		config:         cfg,
-		jobQueue:       make(chan ScrapeJob, cfg.Workers.QueueSize),
-		rateLimiter:    NewRateLimiter(cfg),
+		jobQueue:       jobQueue,
+		rateLimiter:    rateLimiter,
 		scraperFactory: scraperFactory,
 		logger:         logger,
 		stats:          &PoolStats{},
+		jobDuration:    metrics.NewLatencyHistogram(),
+		queueWait:      metrics.NewLatencyHistogram(),
+		stageDurations: make(map[timing.Stage]*metrics.Histogram, len(timing.Stages)),
+		completions:    metrics.NewRateMeter(time.Minute),
+		policy:         policy.GetGlobalChecker(),
 	}
 
-	// Initialize workers
-	pool.workers = make([]*Worker, cfg.Workers.PoolSize)
-	for i := 0; i < cfg.Workers.PoolSize; i++ {
-		worker := &Worker{
-			ID:       i + 1,
-			JobChan:  make(chan ScrapeJob),
-			QuitChan: make(chan bool),
-			Pool:     pool,
-			logger:   logger,
+	for _, stage := range timing.Stages {
+		if stage != timing.StageQueueWait {
+			pool.stageDurations[stage] = metrics.NewLatencyHistogram()
 		}
-		pool.workers[i] = worker
 	}
 
+	if cfg.Workers.Coalesce {
+		pool.inflight = newInflightJobs()
+	}
+
+	pool.minSize, pool.maxSize = poolSizeBounds(sizing)
+	pool.targetSize = clampSize(sizing.poolSize, pool.minSize, pool.maxSize)
+
 	// Initialize dispatcher
-	pool.dispatcher = NewDispatcher(pool.jobQueue, pool.workers)
+	pool.dispatcher = NewDispatcher(pool.jobQueue)
+
+	// Initialize workers
+	pool.workers = make([]*Worker, 0, pool.targetSize)
+	for i := 0; i < pool.targetSize; i++ {
+		pool.workers = append(pool.workers, pool.newWorker())
+	}
 
 	logger.Info("Worker pool initialized", map[string]interface{}{
-		"pool_size": cfg.Workers.PoolSize,
+		"pool_size":     pool.targetSize,
+		"min_pool_size": pool.minSize,
+		"max_pool_size": pool.maxSize,
 	})
 	return pool
 }
 
+// poolSizeBounds returns the configured worker count bounds; unset bounds default to the pool
+// size, which keeps the pool at a fixed size
+func poolSizeBounds(sizing poolSizing) (int, int) {
+	minSize := sizing.minPoolSize
+	if minSize <= 0 {
+		minSize = sizing.poolSize
+	}
+	maxSize := sizing.maxPoolSize
+	if maxSize <= 0 {
+		maxSize = sizing.poolSize
+	}
+	if minSize < 1 {
+		minSize = 1
+	}
+	if maxSize < minSize {
+		maxSize = minSize
+	}
+	return minSize, maxSize
+}
+
+// clampSize limits a worker count to the given bounds
+func clampSize(size, minSize, maxSize int) int {
+	if size < minSize {
+		return minSize
+	}
+	if size > maxSize {
+		return maxSize
+	}
+	return size
+}
+
+// newWorker creates a worker that receives jobs from the dispatcher's shared work channel
+func (wp *WorkerPool) newWorker() *Worker {
+	wp.nextWorkerID++
+	return &Worker{
+		ID:        wp.nextWorkerID,
+		JobChan:   wp.dispatcher.workChan,
+		QuitChan:  make(chan bool),
+		Pool:      wp,
+		logger:    wp.logger.WithFields(map[string]interface{}{"worker_id": wp.nextWorkerID}),
+		createdAt: time.Now(),
+	}
+}
+
 // Start starts the worker pool
 func (wp *WorkerPool) Start() error {
 	wp.mu.Lock()
@@ -141,6 +257,12 @@ func (wp *WorkerPool) Start() error {
 		})
 	}
 
+	// Start the autoscaler when the pool is allowed to change size
+	if wp.maxSize > wp.minSize && wp.config.Workers.ScaleInterval > 0 {
+		wp.scaleStop = make(chan struct{})
+		go wp.autoscale(wp.scaleStop)
+	}
+
 	wp.running = true
 	wp.logger.Info("Worker pool started successfully", map[string]interface{}{
 		"workers": len(wp.workers),
@@ -160,6 +282,11 @@ func (wp *WorkerPool) Stop() error {
 
 	wp.logger.Info("Stopping worker pool", nil)
 
+	if wp.scaleStop != nil {
+		close(wp.scaleStop)
+		wp.scaleStop = nil
+	}
+
 	// Stop dispatcher first
 	wp.dispatcher.Stop()
 
@@ -168,34 +295,113 @@ func (wp *WorkerPool) Stop() error {
 		worker.Stop()
 	}
 
-	// Close job queue
-	close(wp.jobQueue)
-
 	wp.running = false
 	wp.logger.Info("Worker pool stopped successfully", nil)
 	return nil
 }
 
-// SubmitJob submits a new scraping job to the pool
+// queueFullWait is how long blocking submissions wait for queue space before failing
+const queueFullWait = 5 * time.Second
+
+// Bounds for the Retry-After suggested when the queue is full
+const (
+	minRetryAfter = time.Second
+	maxRetryAfter = 5 * time.Minute
+)
+
+// SubmitJob submits a new scraping job to the pool and waits for its result. A caller waiting
+// on the result is serving a request, so the job runs at high priority unless options request
+// another.
 func (wp *WorkerPool) SubmitJob(ctx context.Context, url string, options *models.ScrapeOptions) (*JobResult, error) {
+	return wp.SubmitJobWithPriority(ctx, url, options, optionsPriority(options, PriorityHigh))
+}
+
+// SubmitJobWithPriority submits a new scraping job to the pool and waits for its result;
+// higher-priority jobs are dispatched before any queued lower-priority ones
+func (wp *WorkerPool) SubmitJobWithPriority(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority) (*JobResult, error) {
+	handle, err := wp.enqueue(ctx, url, options, priority, queueFullWait)
+	if err != nil {
+		return nil, err
+	}
+	return handle.Wait(ctx)
+}
+
+// Enqueue queues a scraping job without waiting for it to run and returns a handle to await the
+// result. It fails immediately when the domain is rate limited or the queue is full.
+func (wp *WorkerPool) Enqueue(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority) (*JobHandle, error) {
+	return wp.enqueue(ctx, url, options, priority, 0)
+}
+
+// retryAfter estimates how long until the job queue has room, from its recent drain rate
+func (wp *WorkerPool) retryAfter() time.Duration {
+	return metrics.EstimateDrainTime(wp.jobQueue.Len(), wp.completions.Rate(), minRetryAfter, maxRetryAfter)
+}
+
+// domainRetryAfter returns the interval between requests the per-domain limit allows
+func (wp *WorkerPool) domainRetryAfter() time.Duration {
+	if wp.config.Workers.RateLimit <= 0 {
+		return minRetryAfter
+	}
+	return time.Minute / time.Duration(wp.config.Workers.RateLimit)
+}
+
+// optionsPriority returns the priority requested in scrape options, or fallback when they do not
+// set one
+func optionsPriority(options *models.ScrapeOptions, fallback JobPriority) JobPriority {
+	if options == nil || strings.TrimSpace(options.Priority) == "" {
+		return fallback
+	}
+	return ParsePriority(options.Priority)
+}
+
+// enqueue validates and queues a job, waiting up to queueWait for queue space
+func (wp *WorkerPool) enqueue(ctx context.Context, url string, options *models.ScrapeOptions, priority JobPriority, queueWait time.Duration) (*JobHandle, error) {
 	if !wp.IsRunning() {
 		return nil, fmt.Errorf("worker pool is not running")
 	}
 
-	// Check rate limit for the domain
+	// URLs the scraping policy refuses are never scraped, nor served from the cache
+	if err := wp.policy.Check(ctx, url); err != nil {
+		return nil, err
+	}
+
+	// A cached job of the same posting completes at once, without touching the domain's limit
 	domain := extractDomain(url)
+	if handle, ok := wp.cachedHandle(ctx, url, domain, options, priority); ok {
+		return handle, nil
+	}
+
+	// A request identical to a job in flight waits on that job instead of scraping again
+	var coalesceKey string
+	if wp.inflight != nil {
+		coalesceKey = coalesceKeyOf(url, options)
+		if leader, ok := wp.inflight.get(coalesceKey); ok {
+			return wp.follow(ctx, leader, url, domain, options, priority), nil
+		}
+	}
+
+	// Check rate limit for the domain
 	if !wp.rateLimiter.Allow(domain) {
-		return nil, fmt.Errorf("rate limit exceeded for domain: %s", domain)
+		if cooldown := wp.rateLimiter.CooldownRemaining(domain); cooldown > 0 {
+			return nil, utils.NewRateLimitedError(fmt.Sprintf("domain %s is cooling down after repeated failures", domain)).WithRetryAfter(cooldown)
+		}
+		return nil, utils.NewRateLimitedError(fmt.Sprintf("domain: %s", domain)).WithRetryAfter(wp.domainRetryAfter())
+	}
+
+	// Space the domain's scrapes by the Crawl-delay of its robots.txt
+	if wait := wp.policy.Reserve(url); wait > 0 {
+		return nil, utils.NewRateLimitedError(fmt.Sprintf("domain %s asks for a crawl delay", domain)).WithRetryAfter(wait)
 	}
 
 	// Create job
 	job := ScrapeJob{
-		ID:         utils.GenerateRequestID(),
-		URL:        url,
-		Options:    options,
-		ResultChan: make(chan JobResult, 1),
-		Context:    ctx,
-		CreatedAt:  time.Now(),
+		ID:        utils.GenerateRequestID(),
+		URL:       url,
+		Domain:    domain,
+		Priority:  priority,
+		Options:   options,
+		Context:   ctx,
+		CreatedAt: time.Now(),
 	}
 
 	// Update stats
@@ -203,31 +409,67 @@ func (wp *WorkerPool) SubmitJob(ctx context.Context, url string, options *models
 	wp.stats.JobsQueued++
 	wp.stats.mu.Unlock()
 
-	// Submit job to queue
-	select {
-	case wp.jobQueue <- job:
-		wp.logger.Info("Job submitted to queue", map[string]interface{}{
-			"job_id": job.ID,
-			"url":    url,
-		})
-	case <-time.After(5 * time.Second):
-		return nil, fmt.Errorf("job queue is full, request timed out")
-	}
-
-	// Wait for result with timeout
 	timeout := wp.config.Workers.Timeout
 	if options != nil && options.Timeout > 0 {
 		timeout = options.Timeout
 	}
+	handle := newJobHandle(job, timeout)
+	job.Handle = handle
+
+	// An identical request may have been queued since the check above
+	if wp.inflight != nil {
+		if leader, registered := wp.inflight.register(coalesceKey, handle); !registered {
+			return wp.follow(ctx, leader, url, domain, options, priority), nil
+		}
+	}
+
+	// Submit job to its domain's queue
+	if !wp.jobQueue.Push(ctx, job, queueWait) {
+		err := ctx.Err()
+		if err == nil {
+			err = utils.NewQueueFullError("scrape job queue is full", wp.retryAfter())
+		}
+		// Requests that joined the job meanwhile fail with it
+		handle.complete(JobResult{RequestID: job.ID, Error: err})
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Job submitted to queue", map[string]interface{}{
+		"job_id":   job.ID,
+		"url":      url,
+		"domain":   domain,
+		"priority": priority.String(),
+		"pool":     wp.name,
+	})
+
+	return handle, nil
+}
 
-	select {
-	case result := <-job.ResultChan:
-		return &result, nil
-	case <-time.After(timeout):
-		return nil, fmt.Errorf("job processing timed out after %v", timeout)
-	case <-ctx.Done():
-		return nil, ctx.Err()
+// cachedHandle returns a completed handle carrying the cached job of url, unless the cache is
+// disabled, misses or the options ask for a fresh scrape
+func (wp *WorkerPool) cachedHandle(ctx context.Context, url, domain string, options *models.ScrapeOptions, priority JobPriority) (*JobHandle, bool) {
+	if wp.cache == nil || !cacheable(options) || (options != nil && options.ForceRefresh) {
+		return nil, false
+	}
+	job, ok := wp.cache.get(ctx, url, options)
+	if !ok {
+		return nil, false
 	}
+
+	handle := newJobHandle(ScrapeJob{
+		ID:        utils.GenerateRequestID(),
+		URL:       url,
+		Domain:    domain,
+		Priority:  priority,
+		CreatedAt: time.Now(),
+	}, wp.config.Workers.Timeout)
+	handle.complete(JobResult{RequestID: handle.ID, Job: job, UsedLLM: true, Cached: true})
+
+	timeline.Record(ctx, timeline.EventResultCacheHit, map[string]interface{}{"url": url})
+	logging.FromContext(ctx).Info("Job served from scrape result cache", map[string]interface{}{
+		"job_id": handle.ID,
+		"url":    url,
+	})
+	return handle, true
 }
 
 // IsRunning returns true if the worker pool is running
@@ -237,6 +479,201 @@ func (wp *WorkerPool) IsRunning() bool {
 	return wp.running
 }
 
+// Resize sets the baseline worker count, clamped to the configured bounds, and starts or stops
+// workers to match. Workers being removed finish their current job before exiting.
+func (wp *WorkerPool) Resize(size int) int {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.targetSize = clampSize(size, wp.minSize, wp.maxSize)
+	wp.resizeLocked(wp.targetSize, "manual")
+	return wp.targetSize
+}
+
+// resizeLocked starts or stops workers until the pool has size workers; wp.mu must be held
+func (wp *WorkerPool) resizeLocked(size int, reason string) {
+	current := len(wp.workers)
+	if size == current {
+		return
+	}
+
+	for len(wp.workers) < size {
+		worker := wp.newWorker()
+		wp.workers = append(wp.workers, worker)
+		if wp.running {
+			go worker.Start()
+		}
+	}
+
+	for len(wp.workers) > size {
+		worker := wp.workers[len(wp.workers)-1]
+		wp.workers = wp.workers[:len(wp.workers)-1]
+		if wp.running {
+			// Stop blocks until the worker is idle, so don't hold the pool lock for it
+			go worker.Stop()
+		}
+	}
+
+	wp.logger.Info("Worker pool resized", map[string]interface{}{
+		"from":        current,
+		"to":          size,
+		"reason":      reason,
+		"queue_depth": wp.jobQueue.Len(),
+	})
+}
+
+// autoscale periodically grows the pool while jobs are waiting and shrinks it back towards the
+// target size once workers sit idle
+func (wp *WorkerPool) autoscale(stop <-chan struct{}) {
+	ticker := time.NewTicker(wp.config.Workers.ScaleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			wp.scaleToDemand()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// scaleToDemand adjusts the worker count once based on queue depth and idle workers
+func (wp *WorkerPool) scaleToDemand() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if !wp.running {
+		return
+	}
+
+	// Jobs held back by their domain's concurrency limit would not keep new workers busy
+	size := len(wp.workers)
+	queued := wp.jobQueue.Dispatchable()
+	active := int(atomic.LoadInt64(&wp.activeWorkers))
+
+	switch {
+	case queued > 0 && size < wp.maxSize && !wp.dispatcher.IsPaused():
+		wanted := clampSize(wp.workersWanted(queued, active), wp.minSize, wp.maxSize)
+		if wanted > size {
+			wp.scaleUps++
+			wp.resizeLocked(wanted, "queue_depth")
+		}
+	case queued == 0 && active < size && size > wp.targetSize:
+		// Shrink gradually so a short lull doesn't throw away capacity
+		wp.scaleDowns++
+		wp.resizeLocked(size-1, "idle")
+	}
+}
+
+// workersWanted returns the workers needed to start every queued job within the target wait:
+// the busy workers plus enough to work through the queue in that time at the recent processing
+// time per job. Before any job has finished, each queued job gets a worker.
+func (wp *WorkerPool) workersWanted(queued, active int) int {
+	wp.stats.mu.RLock()
+	recent := wp.stats.RecentProcessingTime
+	wp.stats.mu.RUnlock()
+
+	targetWait := wp.config.Workers.ScaleTargetWait
+	if recent <= 0 || targetWait <= 0 {
+		return active + queued
+	}
+	needed := int(math.Ceil(float64(queued) * recent.Seconds() / targetWait.Seconds()))
+	if needed > queued {
+		needed = queued
+	}
+	return active + needed
+}
+
+// QueueDepthByPriority returns the number of queued jobs per priority
+func (wp *WorkerPool) QueueDepthByPriority() map[string]int {
+	return wp.jobQueue.PriorityDepths()
+}
+
+// DomainConcurrency returns the jobs running against each domain's concurrency limit and how
+// often queued jobs were held back by one; nil and zero when no domain is limited
+func (wp *WorkerPool) DomainConcurrency() ([]DomainConcurrency, int64) {
+	if wp.jobQueue.concurrency == nil {
+		return nil, 0
+	}
+	return wp.jobQueue.concurrency.snapshot()
+}
+
+// QueueDepthByDomain returns the number of queued jobs per domain
+func (wp *WorkerPool) QueueDepthByDomain() map[string]int {
+	return wp.jobQueue.DomainDepths()
+}
+
+// WorkerUtilization describes how busy a single worker has been since it started
+type WorkerUtilization struct {
+	WorkerID      int     `json:"worker_id"`
+	JobsProcessed int64   `json:"jobs_processed"`
+	BusySeconds   float64 `json:"busy_seconds"`
+	Utilization   float64 `json:"utilization"` // fraction of the worker's lifetime spent processing
+}
+
+// WorkerUtilization returns utilization for each current worker
+func (wp *WorkerPool) WorkerUtilization() []WorkerUtilization {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	now := time.Now()
+	utilization := make([]WorkerUtilization, 0, len(wp.workers))
+	for _, worker := range wp.workers {
+		busy := time.Duration(atomic.LoadInt64(&worker.busyNanos))
+		entry := WorkerUtilization{
+			WorkerID:      worker.ID,
+			JobsProcessed: atomic.LoadInt64(&worker.jobsProcessed),
+			BusySeconds:   busy.Seconds(),
+		}
+		if lifetime := now.Sub(worker.createdAt); lifetime > 0 {
+			entry.Utilization = math.Min(1, busy.Seconds()/lifetime.Seconds())
+		}
+		utilization = append(utilization, entry)
+	}
+	return utilization
+}
+
+// WorkerCount returns the current number of workers
+func (wp *WorkerPool) WorkerCount() int {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return len(wp.workers)
+}
+
+// ScalingStats returns the current worker count, bounds, busy workers and queue depth
+func (wp *WorkerPool) ScalingStats() ScalingStats {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	return ScalingStats{
+		Workers:       len(wp.workers),
+		TargetWorkers: wp.targetSize,
+		MinWorkers:    wp.minSize,
+		MaxWorkers:    wp.maxSize,
+		ActiveWorkers: int(atomic.LoadInt64(&wp.activeWorkers)),
+		QueueDepth:    wp.jobQueue.Len(),
+		TargetWait:    wp.config.Workers.ScaleTargetWait,
+		ScaleUps:      wp.scaleUps,
+		ScaleDowns:    wp.scaleDowns,
+	}
+}
+
+// ScalingStats describes the size of the worker pool
+type ScalingStats struct {
+	Workers       int `json:"workers"`
+	TargetWorkers int `json:"target_workers"`
+	MinWorkers    int `json:"min_workers"`
+	MaxWorkers    int `json:"max_workers"`
+	ActiveWorkers int `json:"active_workers"`
+	QueueDepth    int `json:"queue_depth"`
+
+	// The queue wait the autoscaler aims for and how often it grew or shrank the pool
+	TargetWait time.Duration `json:"target_wait"`
+	ScaleUps   int64         `json:"scale_ups"`
+	ScaleDowns int64         `json:"scale_downs"`
+}
+
 // GetStats returns current pool statistics
 func (wp *WorkerPool) GetStats() PoolStatsData {
 	wp.stats.mu.RLock()
@@ -248,8 +685,10 @@ func (wp *WorkerPool) GetStats() PoolStatsData {
 		JobsProcessed:         wp.stats.JobsProcessed,
 		JobsSuccessful:        wp.stats.JobsSuccessful,
 		JobsFailed:            wp.stats.JobsFailed,
+		JobsCoalesced:         wp.stats.JobsCoalesced,
 		TotalProcessingTime:   wp.stats.TotalProcessingTime,
 		AverageProcessingTime: wp.stats.AverageProcessingTime,
+		RecentProcessingTime:  wp.stats.RecentProcessingTime,
 	}
 
 	if stats.JobsProcessed > 0 {
@@ -281,7 +720,43 @@ func (w *Worker) Stop() {
 
 // processJob processes a single scraping job
 func (w *Worker) processJob(job ScrapeJob) {
+	defer w.Pool.jobQueue.Release(job.Domain)
+
+	// Skip jobs whose requester cancelled while they were queued
+	if err := job.Context.Err(); err != nil {
+		logging.FromContext(job.Context).Info("Skipping cancelled job", map[string]interface{}{
+			"job_id":    job.ID,
+			"worker_id": w.ID,
+			"error":     err.Error(),
+		})
+		job.Handle.complete(JobResult{RequestID: job.ID, Error: err})
+		w.Pool.completions.Mark()
+		return
+	}
+
 	startTime := time.Now()
+	atomic.AddInt64(&w.Pool.activeWorkers, 1)
+	defer atomic.AddInt64(&w.Pool.activeWorkers, -1)
+	queueWait := startTime.Sub(job.CreatedAt)
+	w.Pool.queueWait.ObserveDuration(queueWait)
+
+	// Engines, the browser manager and the LLM manager add their stage timings to the breakdown
+	// carried by the job context
+	breakdown := timing.NewBreakdown()
+	breakdown.Add(timing.StageQueueWait, queueWait)
+	job.Context = timing.WithBreakdown(job.Context, breakdown)
+	llmUsage := cost.NewTally()
+	job.Context = cost.WithTally(job.Context, llmUsage)
+	blocks := antibot.NewLog()
+	job.Context = antibot.WithLog(job.Context, blocks)
+	capture := archive.NewCapture()
+	job.Context = archive.WithCapture(job.Context, capture)
+	if job.Options != nil && job.Options.NoCache {
+		job.Context = llm.WithCacheBypass(job.Context)
+	}
+	if job.Options != nil {
+		job.Context = llm.WithModel(job.Context, job.Options.Model)
+	}
 
 	// Update stats
 	w.Pool.stats.mu.Lock()
@@ -290,13 +765,29 @@ func (w *Worker) processJob(job ScrapeJob) {
 
 	// Process the job using the scraper
 	result := w.scrapeJob(job)
+	if w.Pool.cache != nil && result.Error == nil && result.UsedLLM && result.Job != nil && cacheable(job.Options) {
+		w.Pool.cache.set(job.Context, job.URL, job.Options, result.Job)
+	}
 
 	// Update processing time stats
 	processingTime := time.Since(startTime)
 	result.Duration = processingTime
+	result.Timings = breakdown.Durations()
+	result.LLMUsage = llmUsage.Metadata()
+	result.AntiBot = blocks.Metadata()
+	result.Page = capture.Page()
+	w.Pool.jobDuration.ObserveDuration(processingTime)
+	w.Pool.observeStages(result.Timings)
+	atomic.AddInt64(&w.busyNanos, int64(processingTime))
+	atomic.AddInt64(&w.jobsProcessed, 1)
 
 	w.Pool.stats.mu.Lock()
 	w.Pool.stats.TotalProcessingTime += processingTime
+	if w.Pool.stats.RecentProcessingTime == 0 {
+		w.Pool.stats.RecentProcessingTime = processingTime
+	} else {
+		w.Pool.stats.RecentProcessingTime += time.Duration(recentWeight * float64(processingTime-w.Pool.stats.RecentProcessingTime))
+	}
 	if result.Error != nil {
 		w.Pool.stats.JobsFailed++
 	} else {
@@ -304,14 +795,17 @@ func (w *Worker) processJob(job ScrapeJob) {
 	}
 	w.Pool.stats.mu.Unlock()
 
-	// Send result back (non-blocking with reasonable timeout)
-	select {
-	case job.ResultChan <- result:
-	case <-time.After(5 * time.Second):
-		w.logger.Error("WORKER: Result channel timeout - failed to send result back to client", map[string]interface{}{
-			"job_id":    job.ID,
-			"worker_id": w.ID,
-		})
+	// Record the result on the handle; waiters and completion callbacks pick it up from there
+	job.Handle.complete(result)
+	w.Pool.completions.Mark()
+}
+
+// observeStages records the in-worker stage timings of a finished job; queue wait has its own histogram
+func (wp *WorkerPool) observeStages(timings map[timing.Stage]time.Duration) {
+	for stage, d := range timings {
+		if histogram, exists := wp.stageDurations[stage]; exists {
+			histogram.ObserveDuration(d)
+		}
 	}
 }
 
@@ -320,6 +814,10 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 	result := JobResult{
 		RequestID: job.ID,
 	}
+	logger := logging.FromContext(job.Context).WithFields(map[string]interface{}{
+		"job_id":    job.ID,
+		"worker_id": w.ID,
+	})
 
 	// Determine the scraping engine
 	engine := "hybrid" // Default engine
@@ -327,18 +825,46 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 		engine = job.Options.Engine
 	}
 
+	// Get domain for rate limiting
+	domain := extractDomain(job.URL)
+
+	// Route "auto" scrapes to the engine with the best record on the domain; LinkedIn postings
+	// always go to BrightData below
+	if engine == "auto" && !utils.IsLinkedInURL(job.URL) {
+		router := routing.GetGlobalRouter()
+		var reason string
+		engine, reason = router.Pick(job.Context, domain)
+		result.Routed = engine
+		logger.Debug("Routed auto scrape", map[string]interface{}{
+			"domain": domain,
+			"engine": engine,
+			"reason": reason,
+		})
+	}
+
 	// Override engine for LinkedIn URLs - use BrightData exclusively
 	if utils.IsLinkedInURL(job.URL) {
 		engine = "brightdata"
-		w.logger.Info("LinkedIn URL detected, using BrightData engine", map[string]interface{}{
-			"job_id": job.ID,
+		logger.Info("LinkedIn URL detected, using BrightData engine", map[string]interface{}{
 			"url":    job.URL,
 			"engine": engine,
 		})
 	}
 
-	// Get domain for rate limiting
-	domain := extractDomain(job.URL)
+	timeline.Record(job.Context, timeline.EventEngineSelected, map[string]interface{}{
+		"engine": engine,
+		"url":    job.URL,
+	})
+
+	// Every scrape by a single engine adds to the domain's routing record, whatever chose the engine
+	if routing.Tracked(engine) {
+		started := time.Now()
+		defer func() {
+			if success, ok := routeOutcome(job.Context, result.Error); ok {
+				routing.GetGlobalRouter().Record(job.Context, domain, engine, success, time.Since(started))
+			}
+		}()
+	}
 
 	// Create scraper instance
 	scraper, err := w.Pool.scraperFactory.CreateScraper(engine)
@@ -355,11 +881,9 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			w.logger.Debug("Retrying scraping job", map[string]interface{}{
-				"job_id":    job.ID,
-				"worker_id": w.ID,
-				"attempt":   attempt + 1,
-				"url":       job.URL,
+			logger.Debug("Retrying scraping job", map[string]interface{}{
+				"attempt": attempt + 1,
+				"url":     job.URL,
 			})
 
 			// Exponential backoff
@@ -383,14 +907,12 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 				result.UsedLLM = true
 
 				// For "not job posting" errors, this is actually a successful determination
-				if customErr, ok := err.(*utils.CustomError); ok && customErr.Code == http.StatusUnprocessableEntity {
+				if utils.IsErrorCode(err, utils.ErrCodeNotJobPosting) {
 					w.Pool.rateLimiter.RecordSuccess(domain)
-					w.logger.Info("LLM successfully determined content is not a job posting", map[string]interface{}{
-						"job_id":    job.ID,
-						"worker_id": w.ID,
-						"attempt":   attempt + 1,
-						"mode":      "llm",
-						"reason":    "not_job_posting",
+					logger.Info("LLM successfully determined content is not a job posting", map[string]interface{}{
+						"attempt": attempt + 1,
+						"mode":    "llm",
+						"reason":  "not_job_posting",
 					})
 
 					// Don't retry "not job posting" errors - return immediately
@@ -400,13 +922,11 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 				// Check if this is a non-retryable error (API auth, missing key, etc.)
 				if isNonRetryableError(err) {
 					w.Pool.rateLimiter.RecordFailure(domain, err)
-					w.logger.Error("LLM processing failed with non-retryable error", map[string]interface{}{
-						"job_id":    job.ID,
-						"worker_id": w.ID,
-						"attempt":   attempt + 1,
-						"error":     err.Error(),
-						"mode":      "llm",
-						"reason":    "non_retryable_error",
+					logger.Error("LLM processing failed with non-retryable error", map[string]interface{}{
+						"attempt": attempt + 1,
+						"error":   err.Error(),
+						"mode":    "llm",
+						"reason":  "non_retryable_error",
 					})
 
 					// Return immediately for non-retryable errors
@@ -416,12 +936,10 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 				// For retryable errors, record failure and continue retry loop
 				lastErr = err
 				w.Pool.rateLimiter.RecordFailure(domain, err)
-				w.logger.Debug("LLM processing failed, will retry", map[string]interface{}{
-					"job_id":    job.ID,
-					"worker_id": w.ID,
-					"attempt":   attempt + 1,
-					"error":     err.Error(),
-					"mode":      "llm",
+				logger.Debug("LLM processing failed, will retry", map[string]interface{}{
+					"attempt": attempt + 1,
+					"error":   err.Error(),
+					"mode":    "llm",
 				})
 
 				// Continue to retry for technical failures
@@ -439,12 +957,10 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 			jobPosting, err := scraper.ScrapeJobLegacy(job.Context, job.URL, job.Options)
 			if err != nil {
 				lastErr = err
-				w.logger.Debug("Scraping attempt failed (legacy mode)", map[string]interface{}{
-					"job_id":    job.ID,
-					"worker_id": w.ID,
-					"attempt":   attempt + 1,
-					"error":     err.Error(),
-					"mode":      "legacy",
+				logger.Debug("Scraping attempt failed (legacy mode)", map[string]interface{}{
+					"attempt": attempt + 1,
+					"error":   err.Error(),
+					"mode":    "legacy",
 				})
 
 				// Record failure for rate limiting
@@ -457,9 +973,7 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 			result.UsedLLM = false
 			w.Pool.rateLimiter.RecordSuccess(domain)
 
-			w.logger.Debug("Scraping job completed successfully (legacy mode)", map[string]interface{}{
-				"job_id":    job.ID,
-				"worker_id": w.ID,
+			logger.Debug("Scraping job completed successfully (legacy mode)", map[string]interface{}{
 				"job_title": jobPosting.Title,
 				"company":   jobPosting.Company,
 				"attempt":   attempt + 1,
@@ -475,6 +989,24 @@ func (w *Worker) scrapeJob(job ScrapeJob) JobResult {
 	return result
 }
 
+// routeOutcome reports whether a scrape ending in err counts as a success of the engine, and
+// whether it counts at all: scrapes cancelled by the requester and failures of the LLM or of
+// the service itself say nothing of how well the engine reads the domain
+func routeOutcome(ctx context.Context, err error) (success bool, ok bool) {
+	if err == nil || utils.IsErrorCode(err, utils.ErrCodeNotJobPosting) {
+		return true, true
+	}
+	if ctx.Err() != nil {
+		return false, false
+	}
+	switch utils.GetErrorCode(err) {
+	case utils.ErrCodeLLMFailed, utils.ErrCodeLLMParseFailed, utils.ErrCodeLLMUnavailable,
+		utils.ErrCodePolicyDenied, utils.ErrCodeQuotaExceeded, utils.ErrCodeConfiguration:
+		return false, false
+	}
+	return false, true
+}
+
 // extractDomain extracts domain from URL for rate limiting
 func extractDomain(url string) string {
 	return extractDomainFromURL(url)
@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"errors"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/pkg/models"
+	"letraz-utils/pkg/utils"
+)
+
+// Worker pools. Each has its own queue, workers and autoscaling, so scrapes that only call an
+// API are not held up by browser scrapes, which take far longer and much more memory.
+const (
+	PoolBrowser = "browser" // rod, hybrid and auto scrapes, and every scrape without the API pool
+	PoolAPI     = "api"     // lite, firecrawl and brightdata scrapes
+)
+
+// ErrUnknownPool is returned when a worker pool name matches no pool
+var ErrUnknownPool = errors.New("unknown worker pool")
+
+// poolSizing is the worker count, its bounds and the queue capacity of a pool
+type poolSizing struct {
+	poolSize    int
+	queueSize   int
+	minPoolSize int
+	maxPoolSize int
+}
+
+// browserPoolSizing returns the size of the browser pool, set by the top-level worker settings
+func browserPoolSizing(cfg *config.Config) poolSizing {
+	return poolSizing{
+		poolSize:    cfg.Workers.PoolSize,
+		queueSize:   cfg.Workers.QueueSize,
+		minPoolSize: cfg.Workers.MinPoolSize,
+		maxPoolSize: cfg.Workers.MaxPoolSize,
+	}
+}
+
+// apiPoolSizing returns the size of the API pool
+func apiPoolSizing(cfg *config.Config) poolSizing {
+	return poolSizing{
+		poolSize:    cfg.Workers.APIPool.PoolSize,
+		queueSize:   cfg.Workers.APIPool.QueueSize,
+		minPoolSize: cfg.Workers.APIPool.MinPoolSize,
+		maxPoolSize: cfg.Workers.APIPool.MaxPoolSize,
+	}
+}
+
+// poolOf returns the pool that runs scrapes of url by the engine requested in options. LinkedIn
+// postings always go to BrightData; auto scrapes may be routed to Rod, so they run in the
+// browser pool.
+func poolOf(url string, options *models.ScrapeOptions) string {
+	if utils.IsLinkedInURL(url) {
+		return PoolAPI
+	}
+	if options == nil {
+		return PoolBrowser
+	}
+	switch options.Engine {
+	case "lite", "firecrawl", "brightdata":
+		return PoolAPI
+	}
+	return PoolBrowser
+}
+
+// summary returns the statistics of the pool
+func (wp *WorkerPool) summary() *EnginePoolStats {
+	poolStats := wp.GetStats()
+	scaling := wp.ScalingStats()
+	concurrency, deferrals := wp.DomainConcurrency()
+
+	return &EnginePoolStats{
+		PoolStats:            &poolStats,
+		WorkerCount:          scaling.Workers,
+		QueueCapacity:        wp.jobQueue.Cap(),
+		Scaling:              &scaling,
+		QueuedByDomain:       wp.QueueDepthByDomain(),
+		QueuedByPriority:     wp.QueueDepthByPriority(),
+		DomainConcurrency:    concurrency,
+		ConcurrencyDeferrals: deferrals,
+	}
+}
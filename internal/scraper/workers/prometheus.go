@@ -0,0 +1,99 @@
+package workers
+
+import (
+	"strconv"
+
+	"letraz-utils/internal/metrics"
+	"letraz-utils/internal/timing"
+)
+
+// CollectPrometheus exports worker pool counters, queue depth, per-worker utilization and
+// processing time histograms, labelled by pool
+func (pm *PoolManager) CollectPrometheus(w *metrics.PrometheusWriter) {
+	pm.mu.RLock()
+	pools := make([]*WorkerPool, 0, len(pm.pools))
+	for _, name := range []string{PoolBrowser, PoolAPI} {
+		if pool, ok := pm.pools[name]; ok {
+			pools = append(pools, pool)
+		}
+	}
+	rateLimiter := pm.rateLimiter
+	initialized := pm.initialized
+	pm.mu.RUnlock()
+
+	if len(pools) == 0 {
+		w.Gauge("letraz_worker_pool_up", "Whether the scraper worker pool is running.", 0)
+		return
+	}
+	for _, pool := range pools {
+		up := 0.0
+		if initialized && pool.IsRunning() {
+			up = 1
+		}
+		w.Gauge("letraz_worker_pool_up", "Whether the scraper worker pool is running.", up, metrics.L("pool", pool.name))
+	}
+
+	for _, pool := range pools {
+		collectPool(w, pool)
+	}
+
+	w.Gauge("letraz_worker_pool_domains_in_cooldown", "Domains refusing new jobs after captchas or repeated failures.", float64(len(rateLimiter.Cooldowns())))
+}
+
+// collectPool exports the metrics of one pool
+func collectPool(w *metrics.PrometheusWriter, pool *WorkerPool) {
+	poolLabel := metrics.L("pool", pool.name)
+
+	stats := pool.GetStats()
+	w.Counter("letraz_worker_pool_jobs_queued_total", "Scrape jobs submitted to the worker pool.", float64(stats.JobsQueued), poolLabel)
+	w.Counter("letraz_worker_pool_jobs_processed_total", "Scrape jobs picked up by a worker.", float64(stats.JobsProcessed), poolLabel)
+	w.Counter("letraz_worker_pool_jobs_successful_total", "Scrape jobs that completed successfully.", float64(stats.JobsSuccessful), poolLabel)
+	w.Counter("letraz_worker_pool_jobs_failed_total", "Scrape jobs that failed.", float64(stats.JobsFailed), poolLabel)
+	w.Counter("letraz_worker_pool_jobs_coalesced_total", "Scrape requests that shared a scrape of the same URL in flight.", float64(stats.JobsCoalesced), poolLabel)
+
+	scaling := pool.ScalingStats()
+	w.Gauge("letraz_worker_pool_workers", "Current number of workers.", float64(scaling.Workers), poolLabel)
+	w.Gauge("letraz_worker_pool_target_workers", "Baseline worker count set by config or the admin endpoint.", float64(scaling.TargetWorkers), poolLabel)
+	w.Gauge("letraz_worker_pool_max_workers", "Upper bound for autoscaling.", float64(scaling.MaxWorkers), poolLabel)
+	w.Gauge("letraz_worker_pool_active_workers", "Workers currently processing a job.", float64(scaling.ActiveWorkers), poolLabel)
+	w.Counter("letraz_worker_pool_scale_events_total", "Times the autoscaler resized the pool.", float64(scaling.ScaleUps), poolLabel, metrics.L("direction", "up"))
+	w.Counter("letraz_worker_pool_scale_events_total", "Times the autoscaler resized the pool.", float64(scaling.ScaleDowns), poolLabel, metrics.L("direction", "down"))
+	w.Gauge("letraz_worker_pool_recent_processing_seconds", "Moving average of the time workers spent on recent jobs.", stats.RecentProcessingTime.Seconds(), poolLabel)
+	w.Gauge("letraz_worker_pool_queue_depth", "Jobs waiting in the queue.", float64(scaling.QueueDepth), poolLabel)
+	w.Gauge("letraz_worker_pool_queue_capacity", "Maximum number of queued jobs.", float64(pool.jobQueue.Cap()), poolLabel)
+
+	depths := pool.QueueDepthByPriority()
+	for _, priority := range []JobPriority{PriorityHigh, PriorityNormal, PriorityLow} {
+		w.Gauge("letraz_worker_pool_queue_depth_by_priority", "Jobs waiting in the queue by priority.", float64(depths[priority.String()]), poolLabel, metrics.L("priority", priority.String()))
+	}
+
+	paused := 0.0
+	if pool.dispatcher.IsPaused() {
+		paused = 1
+	}
+	w.Gauge("letraz_worker_pool_dispatch_paused", "Whether job dispatch is paused by an admin.", paused, poolLabel)
+
+	concurrency, deferrals := pool.DomainConcurrency()
+	for _, domain := range concurrency {
+		if domain.Limit > 0 {
+			w.Gauge("letraz_worker_pool_domain_running_jobs", "Jobs running in a domain with a concurrency limit.", float64(domain.Running), poolLabel, metrics.L("domain", domain.Domain))
+		}
+	}
+	w.Counter("letraz_worker_pool_concurrency_deferrals_total", "Times a queued job was held back by its domain's concurrency limit.", float64(deferrals), poolLabel)
+
+	utilization := pool.WorkerUtilization()
+	for _, worker := range utilization {
+		w.Gauge("letraz_worker_utilization_ratio", "Fraction of its lifetime a worker has spent processing jobs.", worker.Utilization, poolLabel, metrics.L("worker", strconv.Itoa(worker.WorkerID)))
+	}
+	for _, worker := range utilization {
+		w.Counter("letraz_worker_busy_seconds_total", "Time a worker has spent processing jobs.", worker.BusySeconds, poolLabel, metrics.L("worker", strconv.Itoa(worker.WorkerID)))
+	}
+
+	w.Histogram("letraz_worker_pool_processing_time_seconds", "Time workers spent processing a job.", pool.jobDuration.Snapshot(), poolLabel)
+	w.Histogram("letraz_worker_pool_queue_wait_seconds", "Time jobs waited in the queue before a worker picked them up.", pool.queueWait.Snapshot(), poolLabel)
+	for _, stage := range timing.Stages {
+		if histogram, exists := pool.stageDurations[stage]; exists {
+			w.Histogram("letraz_worker_job_stage_seconds", "Time jobs spent in each scraping stage.", histogram.Snapshot(), poolLabel, metrics.L("stage", string(stage)))
+		}
+	}
+}
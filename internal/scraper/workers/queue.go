@@ -0,0 +1,278 @@
+package workers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobPriority orders queued jobs; higher priorities are always dispatched first
+type JobPriority int
+
+const (
+	PriorityLow JobPriority = iota
+	PriorityNormal
+	PriorityHigh
+
+	numPriorities = 3
+)
+
+// String returns the priority name used in options and logs
+func (p JobPriority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// ParsePriority converts a priority name to a JobPriority; unknown or empty names are normal
+func ParsePriority(name string) JobPriority {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "low", "batch":
+		return PriorityLow
+	case "high", "interactive":
+		return PriorityHigh
+	default:
+		return PriorityNormal
+	}
+}
+
+// DomainQueue is a bounded job queue sharded by priority and domain. Higher priorities are served
+// first; within a priority, jobs for the same domain are served in FIFO order while domains are
+// served round-robin, so a burst for one domain cannot starve the others.
+type DomainQueue struct {
+	mu      sync.Mutex
+	levels  [numPriorities]domainShard
+	size    int
+	slots   chan struct{} // one token per free queue slot
+	ready   chan struct{} // signalled when a job is pushed or a domain's slot is released
+	maxSize int
+
+	// concurrency holds back jobs of domains running as many jobs as they may; nil when no
+	// domain is limited
+	concurrency *domainConcurrency
+}
+
+// domainShard holds the per-domain queues of one priority level
+type domainShard struct {
+	queues map[string][]ScrapeJob
+	order  []string // domains with queued jobs, in round-robin order
+	next   int      // index into order of the next domain to serve
+	size   int
+}
+
+// NewDomainQueue creates a domain-sharded queue holding at most capacity jobs in total
+func NewDomainQueue(capacity int) *DomainQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	q := &DomainQueue{
+		slots:   make(chan struct{}, capacity),
+		ready:   make(chan struct{}, 1),
+		maxSize: capacity,
+	}
+	for i := range q.levels {
+		q.levels[i].queues = make(map[string][]ScrapeJob)
+	}
+	for i := 0; i < capacity; i++ {
+		q.slots <- struct{}{}
+	}
+	return q
+}
+
+// Push adds a job to its domain's queue at the job's priority, waiting up to timeout for space
+// (not at all when timeout is zero); it returns false if the queue stayed full or the context
+// was cancelled
+func (q *DomainQueue) Push(ctx context.Context, job ScrapeJob, timeout time.Duration) bool {
+	select {
+	case <-q.slots:
+	default:
+		if timeout <= 0 {
+			return false
+		}
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-q.slots:
+		case <-timer.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	q.mu.Lock()
+	q.levels[priorityLevel(job.Priority)].push(job)
+	q.size++
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Pop removes the next job from the highest priority with a job to dispatch, in round-robin
+// domain order, skipping domains at their concurrency limit; the job holds a slot of its domain
+// until Release. It returns false when no queued job can be dispatched.
+func (q *DomainQueue) Pop() (ScrapeJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for level := numPriorities - 1; level >= 0; level-- {
+		if q.levels[level].size == 0 {
+			continue
+		}
+
+		job, ok := q.levels[level].pop(q.concurrency)
+		if !ok {
+			continue
+		}
+		q.size--
+		q.slots <- struct{}{}
+		return job, true
+	}
+	return ScrapeJob{}, false
+}
+
+// priorityLevel maps a priority to its shard index
+func priorityLevel(p JobPriority) int {
+	if p < PriorityLow || p > PriorityHigh {
+		return int(PriorityNormal)
+	}
+	return int(p)
+}
+
+// push appends a job to its domain's queue
+func (s *domainShard) push(job ScrapeJob) {
+	if _, exists := s.queues[job.Domain]; !exists {
+		s.order = append(s.order, job.Domain)
+	}
+	s.queues[job.Domain] = append(s.queues[job.Domain], job)
+	s.size++
+}
+
+// pop removes the next job in round-robin domain order whose domain can take another job; it
+// returns false when every domain with queued jobs is at its limit
+func (s *domainShard) pop(limits *domainConcurrency) (ScrapeJob, bool) {
+	if s.next >= len(s.order) {
+		s.next = 0
+	}
+	for tried := 0; tried < len(s.order); tried++ {
+		index := (s.next + tried) % len(s.order)
+		domain := s.order[index]
+		if limits != nil && !limits.tryAcquire(domain) {
+			continue
+		}
+
+		s.next = index
+		jobs := s.queues[domain]
+		job := jobs[0]
+
+		if len(jobs) == 1 {
+			// Domain drained: drop it from the rotation; the next domain slides into this index
+			delete(s.queues, domain)
+			s.order = append(s.order[:s.next], s.order[s.next+1:]...)
+		} else {
+			jobs[0] = ScrapeJob{}
+			s.queues[domain] = jobs[1:]
+			s.next++
+		}
+		s.size--
+		return job, true
+	}
+	return ScrapeJob{}, false
+}
+
+// Release frees the concurrency slot a popped job of domain held, letting the domain's next
+// queued job be dispatched
+func (q *DomainQueue) Release(domain string) {
+	if q.concurrency == nil {
+		return
+	}
+	q.concurrency.release(domain)
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready is signalled after a push; receivers should drain the queue with Pop
+func (q *DomainQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Len returns the total number of queued jobs
+func (q *DomainQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Dispatchable returns the number of queued jobs that could be dispatched now, leaving out jobs
+// held back by their domain's concurrency limit
+func (q *DomainQueue) Dispatchable() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.concurrency == nil {
+		return q.size
+	}
+	// Subdomains sharing a limit are counted together
+	depths := make(map[string]int)
+	for i := range q.levels {
+		for domain, jobs := range q.levels[i].queues {
+			key, _ := q.concurrency.group(domain)
+			depths[key] += len(jobs)
+		}
+	}
+	dispatchable := 0
+	for key, depth := range depths {
+		if remaining := q.concurrency.remaining(key); remaining >= 0 && remaining < depth {
+			depth = remaining
+		}
+		dispatchable += depth
+	}
+	return dispatchable
+}
+
+// Cap returns the maximum number of queued jobs
+func (q *DomainQueue) Cap() int {
+	return q.maxSize
+}
+
+// DomainDepths returns the number of queued jobs per domain across all priorities
+func (q *DomainQueue) DomainDepths() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depths := make(map[string]int)
+	for i := range q.levels {
+		for domain, jobs := range q.levels[i].queues {
+			depths[domain] += len(jobs)
+		}
+	}
+	return depths
+}
+
+// PriorityDepths returns the number of queued jobs per priority
+func (q *DomainQueue) PriorityDepths() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depths := make(map[string]int, numPriorities)
+	for i := range q.levels {
+		depths[JobPriority(i).String()] = q.levels[i].size
+	}
+	return depths
+}
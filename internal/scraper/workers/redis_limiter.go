@@ -0,0 +1,134 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"letraz-utils/internal/config"
+)
+
+// tokenBucketScript atomically refills and takes a token from a per-domain bucket. Redis server
+// time is used so replicas with skewed clocks share one consistent refill rate.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('EXPIRE', KEYS[1], ttl)
+return allowed
+`)
+
+// redisLimiterTimeout bounds each token request so a slow Redis cannot stall job submission
+const redisLimiterTimeout = 500 * time.Millisecond
+
+// RedisRateLimiter shares per-domain token buckets across replicas through Redis. Circuit
+// breaking, domain cooldowns and request statistics stay per instance, and the in-memory limiter is used
+// whenever Redis cannot be reached.
+type RedisRateLimiter struct {
+	*RateLimiter
+	client redis.UniversalClient
+}
+
+// NewRedisRateLimiter creates a rate limiter backed by Redis token buckets
+func NewRedisRateLimiter(cfg *config.Config, client redis.UniversalClient) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		RateLimiter: NewRateLimiter(cfg),
+		client:      client,
+	}
+}
+
+// Allow checks the circuit breaker locally and then takes a token from the shared domain bucket
+func (rrl *RedisRateLimiter) Allow(domain string) bool {
+	domain = strings.ToLower(domain)
+
+	rrl.mu.Lock()
+	cooldown := rrl.cooldownRemaining(domain)
+	circuitClosed := cooldown == 0 && rrl.isCircuitClosed(domain)
+	rrl.mu.Unlock()
+
+	if cooldown > 0 {
+		rrl.logger.Debug("Request rejected by domain cooldown", map[string]interface{}{
+			"domain":    domain,
+			"remaining": cooldown.String(),
+		})
+		return false
+	}
+	if !circuitClosed {
+		rrl.logger.Debug("Request rejected by circuit breaker", map[string]interface{}{
+			"domain": domain,
+		})
+		return false
+	}
+
+	rps := rrl.requestsPerSecond()
+	// Keep idle buckets around for twice the time it takes to refill them
+	ttl := int(math.Ceil(float64(domainBurst)/rps))*2 + 1
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisLimiterTimeout)
+	defer cancel()
+
+	allowed, err := tokenBucketScript.Run(ctx, rrl.client, []string{rrl.bucketKey(domain)}, rps, domainBurst, ttl).Int()
+	if err != nil {
+		rrl.logger.Warn("Redis rate limiter unavailable, falling back to in-memory limiter", map[string]interface{}{
+			"domain": domain,
+			"error":  err.Error(),
+		})
+		return rrl.RateLimiter.Allow(domain)
+	}
+
+	if allowed != 1 {
+		rrl.logger.Debug("Request rejected by distributed rate limiter", map[string]interface{}{
+			"domain": domain,
+		})
+		return false
+	}
+
+	// Track the request locally so per-instance stats stay meaningful
+	rrl.mu.Lock()
+	limiter := rrl.getDomainLimiter(domain)
+	rrl.mu.Unlock()
+
+	limiter.mu.Lock()
+	limiter.requests++
+	limiter.lastSeen = time.Now()
+	limiter.mu.Unlock()
+
+	return true
+}
+
+// GetDomainStats returns local statistics for a domain, flagging the distributed backend
+func (rrl *RedisRateLimiter) GetDomainStats(domain string) map[string]interface{} {
+	stats := rrl.RateLimiter.GetDomainStats(domain)
+	stats["backend"] = "redis"
+	return stats
+}
+
+// bucketKey returns the Redis key holding the token bucket for a domain
+func (rrl *RedisRateLimiter) bucketKey(domain string) string {
+	return fmt.Sprintf("ratelimit:domain:%s", domain)
+}
@@ -0,0 +1,173 @@
+package timeline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies a key step in the life of a process
+type EventType string
+
+const (
+	EventAccepted          EventType = "accepted"
+	EventStarted           EventType = "started"
+	EventEngineSelected    EventType = "engine_selected"
+	EventCaptchaDetected   EventType = "captcha_detected"
+	EventLLMCalled         EventType = "llm_called"
+	EventLLMCacheHit       EventType = "llm_cache_hit"
+	EventResultCacheHit    EventType = "result_cache_hit"
+	EventJobCoalesced      EventType = "job_coalesced"
+	EventStructuredData    EventType = "structured_data"
+	EventCompleted         EventType = "completed"
+	EventFailed            EventType = "failed"
+	EventCallbackDelivered EventType = "callback_delivered"
+	EventCallbackFailed    EventType = "callback_failed"
+)
+
+// MaxEventsPerProcess bounds the events kept for a single process so a retry loop cannot grow it unbounded
+const MaxEventsPerProcess = 100
+
+// Event is a single timestamped entry in a process timeline
+type Event struct {
+	Type      EventType              `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// processTimeline holds the events recorded for one process
+type processTimeline struct {
+	events    []Event
+	updatedAt time.Time
+}
+
+// Recorder keeps an in-memory timeline of events per process ID
+type Recorder struct {
+	timelines map[string]*processTimeline
+	mu        sync.RWMutex
+}
+
+// NewRecorder creates an empty timeline recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		timelines: make(map[string]*processTimeline),
+	}
+}
+
+// Record appends an event to the timeline of the given process
+func (r *Recorder) Record(processID string, eventType EventType, details map[string]interface{}) {
+	if r == nil || processID == "" {
+		return
+	}
+
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tl, exists := r.timelines[processID]
+	if !exists {
+		tl = &processTimeline{}
+		r.timelines[processID] = tl
+	}
+	if len(tl.events) >= MaxEventsPerProcess {
+		return
+	}
+
+	tl.events = append(tl.events, Event{
+		Type:      eventType,
+		Timestamp: now,
+		Details:   details,
+	})
+	tl.updatedAt = now
+}
+
+// Get returns a copy of the events recorded for a process in chronological order
+func (r *Recorder) Get(processID string) ([]Event, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tl, exists := r.timelines[processID]
+	if !exists {
+		return nil, false
+	}
+
+	events := make([]Event, len(tl.events))
+	copy(events, tl.events)
+	return events, true
+}
+
+// Cleanup removes timelines that have not been updated within maxAge
+func (r *Recorder) Cleanup(maxAge time.Duration) int {
+	if r == nil {
+		return 0
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for processID, tl := range r.timelines {
+		if tl.updatedAt.Before(cutoff) {
+			delete(r.timelines, processID)
+			removed++
+		}
+	}
+	return removed
+}
+
+// processIDContextKey is the context key under which the current process ID is stored
+type processIDContextKey struct{}
+
+// WithProcessID returns a copy of ctx that attributes timeline events to processID
+func WithProcessID(ctx context.Context, processID string) context.Context {
+	return context.WithValue(ctx, processIDContextKey{}, processID)
+}
+
+// ProcessIDFromContext returns the process ID carried by ctx, if any
+func ProcessIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	processID, _ := ctx.Value(processIDContextKey{}).(string)
+	return processID
+}
+
+// Global recorder instance
+var (
+	globalRecorder *Recorder
+	globalMu       sync.RWMutex
+)
+
+// InitializeGlobalRecorder creates the global timeline recorder
+func InitializeGlobalRecorder() *Recorder {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	globalRecorder = NewRecorder()
+	return globalRecorder
+}
+
+// GetGlobalRecorder returns the global recorder, which is a no-op until initialized
+func GetGlobalRecorder() *Recorder {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalRecorder
+}
+
+// Record appends an event to the global timeline of the process carried by ctx; events
+// outside a process context are dropped
+func Record(ctx context.Context, eventType EventType, details map[string]interface{}) {
+	GetGlobalRecorder().Record(ProcessIDFromContext(ctx), eventType, details)
+}
+
+// RecordProcess appends an event to the global timeline of the given process
+func RecordProcess(processID string, eventType EventType, details map[string]interface{}) {
+	GetGlobalRecorder().Record(processID, eventType, details)
+}
@@ -0,0 +1,116 @@
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stage identifies a part of a scrape job whose latency is tracked separately
+type Stage string
+
+const (
+	StageQueueWait          Stage = "queue_wait"
+	StageBrowserAcquisition Stage = "browser_acquisition"
+	StageNavigation         Stage = "navigation"
+	StageCaptcha            Stage = "captcha"
+	StageLLM                Stage = "llm"
+)
+
+// Stages lists the tracked stages in the order a job normally passes through them
+var Stages = []Stage{
+	StageQueueWait,
+	StageBrowserAcquisition,
+	StageNavigation,
+	StageCaptcha,
+	StageLLM,
+}
+
+// Breakdown accumulates the time a single job spends in each stage. Stages entered more
+// than once (retries, several LLM calls) are summed.
+type Breakdown struct {
+	durations map[Stage]time.Duration
+	mu        sync.Mutex
+}
+
+// NewBreakdown creates an empty timing breakdown
+func NewBreakdown() *Breakdown {
+	return &Breakdown{
+		durations: make(map[Stage]time.Duration),
+	}
+}
+
+// Add adds d to the time spent in stage
+func (b *Breakdown) Add(stage Stage, d time.Duration) {
+	if b == nil || d < 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.durations[stage] += d
+}
+
+// Durations returns a copy of the time spent per stage
+func (b *Breakdown) Durations() map[Stage]time.Duration {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	durations := make(map[Stage]time.Duration, len(b.durations))
+	for stage, d := range b.durations {
+		durations[stage] = d
+	}
+	return durations
+}
+
+// Milliseconds converts per-stage durations into the form stored in task metadata
+func Milliseconds(durations map[Stage]time.Duration) map[string]int64 {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	ms := make(map[string]int64, len(durations))
+	for stage, d := range durations {
+		ms[string(stage)] = d.Milliseconds()
+	}
+	return ms
+}
+
+// breakdownContextKey is the context key under which the current job breakdown is stored
+type breakdownContextKey struct{}
+
+// WithBreakdown returns a copy of ctx whose stage timings are accumulated into b
+func WithBreakdown(ctx context.Context, b *Breakdown) context.Context {
+	return context.WithValue(ctx, breakdownContextKey{}, b)
+}
+
+// FromContext returns the breakdown carried by ctx, if any
+func FromContext(ctx context.Context) *Breakdown {
+	if ctx == nil {
+		return nil
+	}
+	b, _ := ctx.Value(breakdownContextKey{}).(*Breakdown)
+	return b
+}
+
+// Add adds d to stage on the breakdown carried by ctx; timings outside a job context are dropped
+func Add(ctx context.Context, stage Stage, d time.Duration) {
+	FromContext(ctx).Add(stage, d)
+}
+
+// Start begins timing stage and returns a function that records the elapsed time when called
+func Start(ctx context.Context, stage Stage) func() {
+	b := FromContext(ctx)
+	if b == nil {
+		return func() {}
+	}
+
+	startTime := time.Now()
+	return func() {
+		b.Add(stage, time.Since(startTime))
+	}
+}
@@ -1,9 +1,11 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"letraz-utils/internal/logging"
+	"letraz-utils/pkg/utils"
 )
 
 // AsyncStatus represents the status of an async operation
@@ -14,6 +16,7 @@ const (
 	AsyncStatusProcessing AsyncStatus = "PROCESSING"
 	AsyncStatusSuccess    AsyncStatus = "SUCCESS"
 	AsyncStatusFailure    AsyncStatus = "FAILURE"
+	AsyncStatusScheduled  AsyncStatus = "SCHEDULED" // submitted with run_at or delay and not run yet
 )
 
 // AsyncScrapeResponse represents the immediate response from async scrape endpoint
@@ -40,12 +43,30 @@ type AsyncScreenshotResponse struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// AsyncInterviewResponse represents the immediate response from async interview questions endpoint
+type AsyncInterviewResponse struct {
+	ProcessID string      `json:"processId"`
+	Status    AsyncStatus `json:"status"`
+	Message   string      `json:"message"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// AsyncScheduledResponse represents the immediate response to a submission scheduled for later
+type AsyncScheduledResponse struct {
+	ProcessID string      `json:"processId"`
+	Status    AsyncStatus `json:"status"`
+	RunAt     time.Time   `json:"runAt"`
+	Message   string      `json:"message"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
 // AsyncTaskStatusResponse represents the response for task status queries
 type AsyncTaskStatusResponse struct {
 	ProcessID      string                 `json:"processId"`
 	Status         AsyncStatus            `json:"status"`
 	Data           interface{}            `json:"data,omitempty"`
 	Error          string                 `json:"error,omitempty"`
+	ErrorCode      string                 `json:"errorCode,omitempty"`
 	CreatedAt      time.Time              `json:"createdAt"`
 	CompletedAt    *time.Time             `json:"completedAt,omitempty"`
 	ProcessingTime *time.Duration         `json:"processingTime,omitempty"`
@@ -84,9 +105,16 @@ type AsyncTaskListResponse struct {
 // AsyncErrorResponse represents an error response for async operations
 type AsyncErrorResponse struct {
 	Error     string    `json:"error"`
+	Code      string    `json:"code,omitempty"`
 	Message   string    `json:"message"`
 	ProcessID string    `json:"processId,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// RetryAfter is the suggested retry delay in seconds for capacity errors
+	RetryAfter int `json:"retry_after,omitempty"`
+
+	// Reason names the scraping policy rule that refused the request, for policy_denied errors
+	Reason string `json:"reason,omitempty"`
 }
 
 // CreateAsyncScrapeResponse creates a successful async scrape response
@@ -99,6 +127,26 @@ func CreateAsyncScrapeResponse(processID string) *AsyncScrapeResponse {
 	}
 }
 
+// CreateAsyncBatchScrapeResponse creates a successful async batch scrape response
+func CreateAsyncBatchScrapeResponse(processID string, urlCount int) *AsyncScrapeResponse {
+	return &AsyncScrapeResponse{
+		ProcessID: processID,
+		Status:    AsyncStatusAccepted,
+		Message:   fmt.Sprintf("Batch scraping request for %d URLs accepted for background processing", urlCount),
+		Timestamp: time.Now(),
+	}
+}
+
+// CreateAsyncCrawlResponse creates a successful async career-page crawl response
+func CreateAsyncCrawlResponse(processID string) *AsyncScrapeResponse {
+	return &AsyncScrapeResponse{
+		ProcessID: processID,
+		Status:    AsyncStatusAccepted,
+		Message:   "Crawl request accepted for background processing",
+		Timestamp: time.Now(),
+	}
+}
+
 // CreateAsyncTailorResponse creates a successful async tailor response
 func CreateAsyncTailorResponse(processID string) *AsyncTailorResponse {
 	return &AsyncTailorResponse{
@@ -119,10 +167,47 @@ func CreateAsyncScreenshotResponse(processID string) *AsyncScreenshotResponse {
 	}
 }
 
+// CreateAsyncInterviewResponse creates a successful async interview questions response
+func CreateAsyncInterviewResponse(processID string) *AsyncInterviewResponse {
+	return &AsyncInterviewResponse{
+		ProcessID: processID,
+		Status:    AsyncStatusAccepted,
+		Message:   "Interview questions request accepted for background processing",
+		Timestamp: time.Now(),
+	}
+}
+
+// CreateAsyncScheduledResponse creates a successful response to a submission scheduled for runAt
+func CreateAsyncScheduledResponse(processID string, runAt time.Time) *AsyncScheduledResponse {
+	return &AsyncScheduledResponse{
+		ProcessID: processID,
+		Status:    AsyncStatusScheduled,
+		RunAt:     runAt,
+		Message:   "Request scheduled for background processing at " + runAt.UTC().Format(time.RFC3339),
+		Timestamp: time.Now(),
+	}
+}
+
+// asyncErrorCodes maps the legacy error strings onto the machine-readable error taxonomy
+// (see utils.ErrorCode) so clients can branch on a stable code
+var asyncErrorCodes = map[string]string{
+	"invalid_request":        string(utils.ErrCodeBadRequest),
+	"not_found":              string(utils.ErrCodeNotFound),
+	"validation_failed":      string(utils.ErrCodeValidationFailed),
+	"configuration_error":    string(utils.ErrCodeConfiguration),
+	"task_submission_failed": string(utils.ErrCodeTaskSubmissionFailed),
+	"queue_full":             string(utils.ErrCodeQueueFull),
+	"rate_limited":           string(utils.ErrCodeRateLimited),
+	"quota_exceeded":         string(utils.ErrCodeQuotaExceeded),
+	"unauthorized":           string(utils.ErrCodeUnauthorized),
+	"policy_denied":          string(utils.ErrCodePolicyDenied),
+}
+
 // CreateAsyncErrorResponse creates an error response for async operations
 func CreateAsyncErrorResponse(error, message string, processID ...string) *AsyncErrorResponse {
 	response := &AsyncErrorResponse{
 		Error:     error,
+		Code:      asyncErrorCodes[error],
 		Message:   message,
 		Timestamp: time.Now(),
 	}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// CompanySizes are the employee count ranges a company's size is reported in
+var CompanySizes = []string{"1-10", "11-50", "51-200", "201-500", "501-1000", "1001-5000", "5001-10000", "10001+"}
+
+// CompanyEnrichmentRequest asks for structured information about a company, found from its
+// domain or, when only the name is known, by searching the web for its website
+type CompanyEnrichmentRequest struct {
+	Name    string         `json:"name,omitempty"`
+	Domain  string         `json:"domain,omitempty" validate:"omitempty,fqdn"`
+	Options *ScrapeOptions `json:"options,omitempty"` // engine and model used to read the website
+}
+
+// Company is structured information about a company gathered from its website
+type Company struct {
+	Name         string   `json:"name"`
+	Domain       string   `json:"domain"`
+	Website      string   `json:"website"`
+	Description  string   `json:"description"`
+	Industry     string   `json:"industry"`
+	Size         string   `json:"size"` // one of CompanySizes, empty when unknown
+	Headquarters string   `json:"headquarters"`
+	FoundedYear  int      `json:"founded_year,omitempty"`
+	SourceURLs   []string `json:"source_urls"` // pages the information was extracted from
+}
+
+// CompanyEnrichmentResponse represents the response for a company enrichment
+type CompanyEnrichmentResponse struct {
+	Success        bool          `json:"success"`
+	Company        *Company      `json:"company"`
+	ProcessingTime time.Duration `json:"processing_time"`
+	RequestID      string        `json:"request_id"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
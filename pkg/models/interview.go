@@ -0,0 +1,31 @@
+package models
+
+// Interview question categories
+const (
+	InterviewCategoryTechnical   = "technical"
+	InterviewCategoryBehavioral  = "behavioral"
+	InterviewCategorySituational = "situational"
+	InterviewCategoryExperience  = "experience"
+)
+
+// DefaultInterviewQuestionCount is the number of questions generated when a request names none
+const DefaultInterviewQuestionCount = 10
+
+// InterviewQuestionsRequest represents a request to generate likely interview questions for a job
+type InterviewQuestionsRequest struct {
+	BaseResume    BaseResume `json:"base_resume"`
+	Job           Job        `json:"job"`
+	ResumeID      string     `json:"resume_id" validate:"required,resume_id"`
+	QuestionCount int        `json:"question_count,omitempty" validate:"omitempty,min=1,max=25"`
+	Priority      string     `json:"priority,omitempty"` // "interactive" (default), "normal" or "bulk" for imports
+	TaskSchedule
+}
+
+// InterviewQuestion is a question the candidate is likely to be asked, with an answer drawn from
+// their resume
+type InterviewQuestion struct {
+	Question        string `json:"question"`
+	Category        string `json:"category"` // "technical", "behavioral", "situational", "experience"
+	SuggestedAnswer string `json:"suggested_answer"`
+	Reasoning       string `json:"reasoning"` // why the interviewer is likely to ask it
+}
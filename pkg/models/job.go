@@ -15,13 +15,28 @@ type Job struct {
 	Description      string   `json:"description"`
 	Responsibilities []string `json:"responsibilities"`
 	Benefits         []string `json:"benefits"`
+	OriginalLanguage string   `json:"original_language,omitempty"` // ISO 639-1 code of the posting's language; fields are in English
+
+	// NormalizedSalary is Salary annualized and converted to the configured currency, nil when
+	// the posting states no salary or its currency has no exchange rate
+	NormalizedSalary *NormalizedSalary `json:"normalized_salary,omitempty"`
 }
 
 // Salary represents the salary information for a job posting
 type Salary struct {
-	Currency string `json:"currency"`
-	Max      int    `json:"max"`
-	Min      int    `json:"min"`
+	Currency string  `json:"currency"`
+	Max      float64 `json:"max"` // amounts keep their fractions, such as the cents of hourly rates
+	Min      float64 `json:"min"`
+	Period   string  `json:"period,omitempty"` // hourly, daily, weekly, monthly or yearly; empty when not stated
+}
+
+// NormalizedSalary is a job's salary as a yearly range in a single currency, comparable across postings
+type NormalizedSalary struct {
+	Currency     string  `json:"currency"`
+	AnnualMin    int     `json:"annual_min"`
+	AnnualMax    int     `json:"annual_max"`
+	Period       string  `json:"period"`        // period the stated salary was read as, inferred from its size when not stated
+	ExchangeRate float64 `json:"exchange_rate"` // Currency value of one unit of the stated currency
 }
 
 // JobPosting represents a structured job posting extracted from job boards (legacy)
@@ -39,7 +54,7 @@ type JobPosting struct {
 	Benefits        []string          `json:"benefits"`
 	ExperienceLevel string            `json:"experience_level"`
 	JobType         string            `json:"job_type"`
-	PostedDate      time.Time         `json:"posted_date"`
+	PostedDate      *time.Time        `json:"posted_date,omitempty"` // nil when the page does not say
 	ApplicationURL  string            `json:"application_url"`
 	Metadata        map[string]string `json:"metadata"`
 	ProcessedAt     time.Time         `json:"processed_at"`
@@ -47,8 +62,8 @@ type JobPosting struct {
 
 // SalaryRange represents the salary information for a job posting (legacy)
 type SalaryRange struct {
-	Min      int    `json:"min"`
-	Max      int    `json:"max"`
-	Currency string `json:"currency"`
-	Period   string `json:"period"` // hourly, monthly, yearly
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+	Period   string  `json:"period"` // hourly, daily, weekly, monthly or yearly; empty when not stated
 }
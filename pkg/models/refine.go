@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ConversationTurn is one message of a multi-turn LLM conversation
+type ConversationTurn struct {
+	Role    string `json:"role"` // "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// RefineResumeRequest represents a follow-up instruction for a tailored resume, such as "make it
+// shorter" or "emphasize leadership". The conversation is looked up by resume ID.
+type RefineResumeRequest struct {
+	ResumeID    string `json:"resume_id" validate:"required,resume_id"`
+	Instruction string `json:"instruction" validate:"required,max=2000"`
+}
+
+// RefineResumeResponse represents the response for a resume refinement
+type RefineResumeResponse struct {
+	Success        bool            `json:"success"`
+	TailoredResume *TailoredResume `json:"tailored_resume"`
+	Suggestions    []Suggestion    `json:"suggestions"`
+	ThreadID       string          `json:"thread_id"`
+	ProcessingTime time.Duration   `json:"processing_time"`
+	RequestID      string          `json:"request_id"`
+	Timestamp      time.Time       `json:"timestamp"`
+}
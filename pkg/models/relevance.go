@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// RelevanceRequest represents a request to pre-screen how relevant a resume is to a job posting
+type RelevanceRequest struct {
+	BaseResume BaseResume `json:"base_resume"`
+	Job        Job        `json:"job"`
+}
+
+// RequirementRelevance relates a job requirement to the resume section closest to it
+type RequirementRelevance struct {
+	Requirement string  `json:"requirement"`
+	Score       int     `json:"score"`        // 0-100
+	Similarity  float64 `json:"similarity"`   // cosine similarity of the embeddings
+	BestSection string  `json:"best_section"` // type of the closest resume section
+}
+
+// SectionRelevance is how close one resume section type comes to the job requirements
+type SectionRelevance struct {
+	Type       string  `json:"type"`
+	Score      int     `json:"score"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Relevance is the embedding similarity of a resume and a job posting, a cheap signal of whether
+// full tailoring is worthwhile
+type Relevance struct {
+	Score        int                    `json:"score"`
+	Relevant     bool                   `json:"relevant"` // the score reaches the configured threshold
+	Requirements []RequirementRelevance `json:"requirements"`
+	Sections     []SectionRelevance     `json:"sections"`
+	Model        string                 `json:"model"`
+}
+
+// RelevanceResponse represents the response for a relevance pre-screening
+type RelevanceResponse struct {
+	Success bool `json:"success"`
+	Relevance
+	ProcessingTime time.Duration `json:"processing_time"`
+	RequestID      string        `json:"request_id"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
@@ -2,25 +2,77 @@ package models
 
 import "time"
 
+// TaskSchedule defers a task submission: RunAt runs the task at a given time, Delay after a
+// duration such as "168h". Submissions setting neither run right away.
+type TaskSchedule struct {
+	RunAt *time.Time `json:"run_at,omitempty"`
+	Delay string     `json:"delay,omitempty"`
+}
+
 // ScrapeRequest represents the request payload for scraping a job posting
 type ScrapeRequest struct {
 	URL         string         `json:"url" validate:"omitempty,url"`
 	Description string         `json:"description,omitempty"`
 	Options     *ScrapeOptions `json:"options,omitempty"`
+	TaskSchedule
 }
 
 // ScrapeOptions provides additional configuration for scraping requests
 type ScrapeOptions struct {
-	Engine      string        `json:"engine,omitempty"`       // "hybrid", "firecrawl", "headed", "rod", "auto"
-	Timeout     time.Duration `json:"timeout,omitempty"`      // Request timeout
-	LLMProvider string        `json:"llm_provider,omitempty"` // "claude", "disabled" (for legacy mode)
-	UserAgent   string        `json:"user_agent,omitempty"`   // Custom user agent
-	Proxy       string        `json:"proxy,omitempty"`        // Proxy configuration
+	Engine       string        `json:"engine,omitempty"`        // "hybrid", "lite", "firecrawl", "headed", "rod", "auto"
+	Timeout      time.Duration `json:"timeout,omitempty"`       // Request timeout
+	LLMProvider  string        `json:"llm_provider,omitempty"`  // "claude", "disabled" (for legacy mode)
+	UserAgent    string        `json:"user_agent,omitempty"`    // Custom user agent
+	Proxy        string        `json:"proxy,omitempty"`         // Proxy configuration
+	Priority     string        `json:"priority,omitempty"`      // "high", "normal" or "low"; single scrapes default to high, batches and crawls to low
+	NoCache      bool          `json:"no_cache,omitempty"`      // Skip the LLM extraction cache and re-extract
+	ForceRefresh bool          `json:"force_refresh,omitempty"` // Scrape again instead of reusing a cached job of the same URL
+	Model        string        `json:"model,omitempty"`         // LLM model for extraction, one of the allowed models; empty uses the configured model
+}
+
+// BatchScrapeRequest represents a request to scrape several job URLs as one task
+type BatchScrapeRequest struct {
+	URLs    []string       `json:"urls" validate:"required,min=1,dive,required,url"`
+	Options *ScrapeOptions `json:"options,omitempty"`
+	TaskSchedule
+}
+
+// CrawlRequest represents a request to discover the jobs on a company careers page and scrape
+// each of them
+type CrawlRequest struct {
+	URL     string         `json:"url" validate:"required,url"`
+	MaxJobs int            `json:"max_jobs,omitempty" validate:"omitempty,min=1"` // defaults to the configured maximum, which also caps it
+	Options *ScrapeOptions `json:"options,omitempty"`
+	TaskSchedule
+}
+
+// CrawlMapRequest represents a request to list the job links of a careers site from its
+// Firecrawl map, without scraping them
+type CrawlMapRequest struct {
+	URL     string `json:"url" validate:"required,url"`
+	MaxJobs int    `json:"max_jobs,omitempty" validate:"omitempty,min=1"` // defaults to the configured maximum, which also caps it
+}
+
+// ReExtractRequest selects how the archived page of a scrape is extracted again; every field
+// is optional and defaults to the configured behaviour
+type ReExtractRequest struct {
+	LLMProvider   string `json:"llm_provider,omitempty"`   // extract with this provider alone, e.g. "openai"
+	Model         string `json:"model,omitempty"`          // one of the allowed models
+	PromptVersion string `json:"prompt_version,omitempty"` // job_extraction template version, e.g. "v2"
+}
+
+// JobMonitorRequest registers a job URL for change monitoring
+type JobMonitorRequest struct {
+	URL      string         `json:"url" validate:"required,url"`
+	Interval string         `json:"interval,omitempty"` // Re-scrape interval, e.g. "12h"; defaults to the configured interval
+	Options  *ScrapeOptions `json:"options,omitempty"`
 }
 
 // ResumeScreenshotRequest represents the request payload for generating a resume screenshot
 type ResumeScreenshotRequest struct {
 	ResumeID string `json:"resume_id" validate:"required,resume_id"`
+	Priority string `json:"priority,omitempty"` // "interactive" (default), "normal" or "bulk" for imports
+	TaskSchedule
 }
 
 // ExportResumeRequest represents a REST request to export a resume to LaTeX
@@ -13,6 +13,31 @@ type ScrapeResponse struct {
 	RequestID      string        `json:"request_id"`
 }
 
+// ReExtractResponse represents the job extracted again from the archived page of a scrape
+type ReExtractResponse struct {
+	Success        bool                   `json:"success"`
+	ProcessID      string                 `json:"process_id"`
+	URL            string                 `json:"url"`
+	Format         string                 `json:"format"` // "html", or "markdown" for pages read by Firecrawl
+	Job            *Job                   `json:"job"`
+	LLMUsage       map[string]interface{} `json:"llm_usage,omitempty"`
+	ProcessingTime time.Duration          `json:"processing_time"`
+	RequestID      string                 `json:"request_id"`
+	Timestamp      time.Time              `json:"timestamp"`
+}
+
+// CrawlMapResponse lists the job links found in the Firecrawl map of a careers site
+type CrawlMapResponse struct {
+	Success        bool          `json:"success"`
+	URL            string        `json:"url"`
+	Jobs           []string      `json:"jobs"`
+	Total          int           `json:"total"`
+	Mapped         int           `json:"mapped"` // site URLs the map listed, postings or not
+	ProcessingTime time.Duration `json:"processing_time"`
+	RequestID      string        `json:"request_id"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status    string            `json:"status"`
@@ -20,6 +45,19 @@ type HealthResponse struct {
 	Version   string            `json:"version"`
 	Uptime    time.Duration     `json:"uptime"`
 	Checks    map[string]string `json:"checks,omitempty"`
+
+	// Dependencies carries per-dependency measurements (latency, memory use) from the health checker
+	Dependencies map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// DependencyHealth represents the cached health of a single dependency
+type DependencyHealth struct {
+	Status    string                 `json:"status"`
+	Critical  bool                   `json:"critical"`
+	LatencyMS int64                  `json:"latency_ms"`
+	CheckedAt time.Time              `json:"checked_at"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
 }
 
 // ErrorResponse represents an error response
@@ -175,6 +175,9 @@ type TailorResumeRequest struct {
 	BaseResume BaseResume `json:"base_resume"`
 	Job        Job        `json:"job"`
 	ResumeID   string     `json:"resume_id" validate:"required,resume_id"`
+	Model      string     `json:"model,omitempty"`    // LLM model, one of the allowed models; empty uses the configured model
+	Priority   string     `json:"priority,omitempty"` // "interactive" (default), "normal" or "bulk" for imports
+	TaskSchedule
 }
 
 // TailoredResumeSection represents a simplified section in a tailored resume
@@ -0,0 +1,106 @@
+package models
+
+import "time"
+
+// ATSScoreRequest represents a request to score a resume against a job posting
+type ATSScoreRequest struct {
+	BaseResume BaseResume `json:"base_resume"`
+	Job        Job        `json:"job"`
+}
+
+// ATSKeywordMatch reports which job terms a resume covers, scored 0-100
+type ATSKeywordMatch struct {
+	Score   int      `json:"score"`
+	Matched []string `json:"matched"`
+	Missing []string `json:"missing"`
+}
+
+// ATSSectionScore is the score of one resume section type, e.g. all experience entries
+type ATSSectionScore struct {
+	Type            string   `json:"type"`
+	Score           int      `json:"score"`
+	KeywordScore    int      `json:"keyword_score"` // share of job keywords found in the section
+	MatchedKeywords []string `json:"matched_keywords"`
+	Feedback        string   `json:"feedback,omitempty"`
+}
+
+// ATSScoreBreakdown holds the component scores the overall ATS score is weighted from
+type ATSScoreBreakdown struct {
+	KeywordCoverage ATSKeywordMatch `json:"keyword_coverage"`
+	RequiredSkills  ATSKeywordMatch `json:"required_skills"`
+	FormattingScore int             `json:"formatting_score"`
+	LLMScore        int             `json:"llm_score"`
+}
+
+// ATSScore is the ATS compatibility of a resume for a job posting
+type ATSScore struct {
+	Score            int               `json:"score"`
+	Breakdown        ATSScoreBreakdown `json:"breakdown"`
+	Sections         []ATSSectionScore `json:"sections"`
+	FormattingIssues []string          `json:"formatting_issues"`
+	Summary          string            `json:"summary,omitempty"`
+}
+
+// ATSReview is the LLM's assessment of a resume for a job posting
+type ATSReview struct {
+	Score            int                `json:"score"`
+	Summary          string             `json:"summary"`
+	Sections         []ATSSectionReview `json:"sections"`
+	FormattingIssues []string           `json:"formatting_issues"`
+}
+
+// ATSSectionReview is the LLM's assessment of one resume section type
+type ATSSectionReview struct {
+	Type     string `json:"type"`
+	Score    int    `json:"score"`
+	Feedback string `json:"feedback"`
+}
+
+// ATSScoreResponse represents the response for ATS compatibility scoring
+type ATSScoreResponse struct {
+	Success bool `json:"success"`
+	ATSScore
+	ProcessingTime time.Duration `json:"processing_time"`
+	RequestID      string        `json:"request_id"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
+
+// SkillGapRequest represents a request to compare a resume's skills with a job's requirements
+type SkillGapRequest struct {
+	BaseResume BaseResume `json:"base_resume"`
+	Job        Job        `json:"job"`
+}
+
+// SkillGapItem relates a skill the job asks for to what the resume shows of it
+type SkillGapItem struct {
+	Skill       string `json:"skill"`
+	Requirement string `json:"requirement"`        // the job requirement naming the skill
+	Evidence    string `json:"evidence,omitempty"` // where the resume shows the skill
+	Gap         string `json:"gap,omitempty"`      // what is missing for a full match
+}
+
+// LearningSuggestion is a way to close a skill gap
+type LearningSuggestion struct {
+	Skill         string `json:"skill"`
+	Priority      string `json:"priority"` // "high", "medium", "low"
+	Suggestion    string `json:"suggestion"`
+	EstimatedTime string `json:"estimated_time,omitempty"`
+}
+
+// SkillGapAnalysis compares the skills of a resume with the requirements of a job posting
+type SkillGapAnalysis struct {
+	MatchedSkills       []SkillGapItem       `json:"matched_skills"`
+	PartialSkills       []SkillGapItem       `json:"partial_skills"`
+	MissingSkills       []SkillGapItem       `json:"missing_skills"`
+	LearningSuggestions []LearningSuggestion `json:"learning_suggestions"`
+	Summary             string               `json:"summary"`
+}
+
+// SkillGapResponse represents the response for a skill gap analysis
+type SkillGapResponse struct {
+	Success bool `json:"success"`
+	SkillGapAnalysis
+	ProcessingTime time.Duration `json:"processing_time"`
+	RequestID      string        `json:"request_id"`
+	Timestamp      time.Time     `json:"timestamp"`
+}
@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/kv"
+	"letraz-utils/internal/logging"
+)
+
+// ConversationStore manages resume tailoring conversation threads on top of a key-value store,
+// so history works against Redis in production and in memory during local development
+type ConversationStore struct {
+	store     kv.Store
+	config    *config.Config
+	logger    logging.Logger
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// ConversationEntry represents a single conversation entry
+type ConversationEntry struct {
+	ID        string                 `json:"id"`
+	Role      string                 `json:"role"` // "user" or "assistant"
+	Content   string                 `json:"content"`
+	Timestamp time.Time              `json:"timestamp"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ConversationHistory represents the complete conversation history for a resume
+type ConversationHistory struct {
+	ThreadID  string              `json:"thread_id"`
+	ResumeID  string              `json:"resume_id"`
+	Entries   []ConversationEntry `json:"entries"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// NewConversationStore creates a conversation store on top of the given key-value store
+func NewConversationStore(cfg *config.Config, store kv.Store) *ConversationStore {
+	return &ConversationStore{
+		store:  store,
+		config: cfg,
+		logger: logging.GetGlobalLogger(),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Ping checks that the underlying store is reachable
+func (cs *ConversationStore) Ping(ctx context.Context) error {
+	return cs.store.Ping(ctx)
+}
+
+// Backend returns the name of the underlying storage backend
+func (cs *ConversationStore) Backend() string {
+	return cs.store.Backend()
+}
+
+// Stop stops the background sweeper
+func (cs *ConversationStore) Stop() {
+	cs.closeOnce.Do(func() { close(cs.stop) })
+}
+
+// CreateConversationThread creates a new conversation thread for a resume
+func (cs *ConversationStore) CreateConversationThread(ctx context.Context, resumeID string) error {
+	threadKey := cs.getThreadKey(resumeID)
+
+	// Check if thread already exists
+	_, err := cs.store.TTL(ctx, threadKey)
+	if err != nil && !errors.Is(err, kv.ErrNotFound) {
+		return fmt.Errorf("failed to check if thread exists: %w", err)
+	}
+
+	if err == nil {
+		// Thread already exists, just update the timestamp
+		history, err := cs.GetConversationHistory(ctx, resumeID)
+		if err != nil {
+			return fmt.Errorf("failed to get existing conversation history: %w", err)
+		}
+		history.UpdatedAt = time.Now()
+
+		if err := cs.saveHistory(ctx, history); err != nil {
+			return fmt.Errorf("failed to update conversation thread: %w", err)
+		}
+		return nil
+	}
+
+	// Create new thread
+	history := &ConversationHistory{
+		ThreadID:  GenerateRequestID(),
+		ResumeID:  resumeID,
+		Entries:   []ConversationEntry{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Store with the configured expiration
+	if err := cs.saveHistory(ctx, history); err != nil {
+		return fmt.Errorf("failed to create conversation thread: %w", err)
+	}
+
+	return nil
+}
+
+// AddConversationEntry adds a new entry to the conversation history
+func (cs *ConversationStore) AddConversationEntry(ctx context.Context, resumeID string, entry ConversationEntry) error {
+	// Get current history
+	history, err := cs.GetConversationHistory(ctx, resumeID)
+	if err != nil {
+		// If thread doesn't exist, create it first
+		if err := cs.CreateConversationThread(ctx, resumeID); err != nil {
+			return fmt.Errorf("failed to create conversation thread: %w", err)
+		}
+		history, err = cs.GetConversationHistory(ctx, resumeID)
+		if err != nil {
+			return fmt.Errorf("failed to get conversation history after creation: %w", err)
+		}
+	}
+
+	// Add new entry
+	entry.ID = GenerateRequestID()
+	entry.Timestamp = time.Now()
+	history.Entries = append(history.Entries, entry)
+	history.UpdatedAt = time.Now()
+
+	// Save updated history; trimming keeps long-lived threads bounded
+	if err := cs.saveHistory(ctx, history); err != nil {
+		cs.logger.Error("Failed to save conversation entry", map[string]interface{}{
+			"resume_id": resumeID,
+			"entry_id":  entry.ID,
+			"error":     err.Error(),
+		})
+		return fmt.Errorf("failed to save conversation entry: %w", err)
+	}
+
+	return nil
+}
+
+// saveHistory trims the history to the configured limits and stores it with the configured TTL
+func (cs *ConversationStore) saveHistory(ctx context.Context, history *ConversationHistory) error {
+	historyJSON, err := cs.trimHistory(history)
+	if err != nil {
+		return err
+	}
+
+	return cs.store.Set(ctx, cs.getThreadKey(history.ResumeID), historyJSON, cs.conversationTTL())
+}
+
+// trimHistory drops the oldest entries until the history fits both the turn and size limits,
+// returning the marshalled result
+func (cs *ConversationStore) trimHistory(history *ConversationHistory) ([]byte, error) {
+	limits := cs.config.ConversationHistory
+
+	if limits.MaxTurns > 0 && len(history.Entries) > limits.MaxTurns {
+		history.Entries = history.Entries[len(history.Entries)-limits.MaxTurns:]
+	}
+
+	for {
+		historyJSON, err := json.Marshal(history)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal conversation history: %w", err)
+		}
+		// Always keep the latest turn, even if it alone exceeds the size limit
+		if limits.MaxBytes <= 0 || len(historyJSON) <= limits.MaxBytes || len(history.Entries) <= 1 {
+			return historyJSON, nil
+		}
+		history.Entries = history.Entries[1:]
+	}
+}
+
+// conversationTTL returns the expiration applied to conversation threads on every write
+func (cs *ConversationStore) conversationTTL() time.Duration {
+	if ttl := cs.config.ConversationHistory.TTL; ttl > 0 {
+		return ttl
+	}
+	return 24 * time.Hour
+}
+
+// SweepConversationThreads scans all conversation threads, re-applying the TTL to threads that
+// lost their expiry and trimming threads that exceed the current limits. It returns the number
+// of threads that were rewritten.
+func (cs *ConversationStore) SweepConversationThreads(ctx context.Context) (int, error) {
+	swept := 0
+	var mu sync.Mutex
+
+	err := cs.store.Scan(ctx, cs.getThreadKey(""), func(key string) error {
+		rewritten, err := cs.sweepThread(ctx, key)
+		if err != nil {
+			cs.logger.Warn("Failed to sweep conversation thread", map[string]interface{}{
+				"key":   key,
+				"error": err.Error(),
+			})
+			return nil
+		}
+		if rewritten {
+			mu.Lock()
+			swept++
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	return swept, err
+}
+
+// sweepThread enforces the retention policy on a single thread key
+func (cs *ConversationStore) sweepThread(ctx context.Context, key string) (bool, error) {
+	ttl, err := cs.store.TTL(ctx, key)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	historyJSON, err := cs.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	limits := cs.config.ConversationHistory
+	oversized := limits.MaxBytes > 0 && len(historyJSON) > limits.MaxBytes
+	if !oversized && ttl != kv.NoExpiry {
+		return false, nil
+	}
+
+	var history ConversationHistory
+	if err := json.Unmarshal(historyJSON, &history); err != nil {
+		// Unreadable threads cannot be trimmed; drop them rather than let them linger
+		return true, cs.store.Delete(ctx, key)
+	}
+
+	// Rewriting re-applies both the trimming limits and the TTL
+	return true, cs.saveHistory(ctx, &history)
+}
+
+// StartSweeper runs SweepConversationThreads on the configured interval until Stop is called
+func (cs *ConversationStore) StartSweeper() {
+	interval := cs.config.ConversationHistory.CleanupInterval
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-cs.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval/2)
+				swept, err := cs.SweepConversationThreads(ctx)
+				cancel()
+				if err != nil {
+					cs.logger.Warn("Conversation history sweep failed", map[string]interface{}{
+						"error": err.Error(),
+					})
+					continue
+				}
+				if swept > 0 {
+					cs.logger.Info("Conversation history sweep completed", map[string]interface{}{
+						"threads_rewritten": swept,
+					})
+				}
+			}
+		}
+	}()
+}
+
+// GetConversationHistory retrieves the conversation history for a resume
+func (cs *ConversationStore) GetConversationHistory(ctx context.Context, resumeID string) (*ConversationHistory, error) {
+	threadKey := cs.getThreadKey(resumeID)
+
+	historyJSON, err := cs.store.Get(ctx, threadKey)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotFound) {
+			return nil, fmt.Errorf("conversation thread not found for resume %s", resumeID)
+		}
+		return nil, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+
+	var history ConversationHistory
+	err = json.Unmarshal(historyJSON, &history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal conversation history: %w", err)
+	}
+
+	return &history, nil
+}
+
+// DeleteConversationThread deletes the conversation thread for a resume
+func (cs *ConversationStore) DeleteConversationThread(ctx context.Context, resumeID string) error {
+	threadKey := cs.getThreadKey(resumeID)
+	return cs.store.Delete(ctx, threadKey)
+}
+
+// getThreadKey generates the store key for a conversation thread
+func (cs *ConversationStore) getThreadKey(resumeID string) string {
+	return fmt.Sprintf("conversation:resume:%s", resumeID)
+}
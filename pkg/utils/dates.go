@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// relativeDatePatterns match "3 days ago" and its German, French and Spanish forms, capturing
+	// the count and the unit
+	relativeDatePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(\d+|an?|one)\+?\s*(minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|wks?|w|months?|mos?|years?|yrs?|y)\s+ago\b`),
+		regexp.MustCompile(`(?i)\bvor\s+(\d+|einem|einer)\s+(minuten?|stunden?|tag(?:en)?|wochen?|monat(?:en)?|jahr(?:en)?)\b`),
+		regexp.MustCompile(`(?i)\bil\s+y\s+a\s+(\d+|une?)\s+(minutes?|heures?|jours?|semaines?|mois|ans?|années?)`),
+		regexp.MustCompile(`(?i)\bhace\s+(\d+|una?)\s+(minutos?|horas?|días?|dias?|semanas?|mes(?:es)?|años?|anos?)`),
+	}
+
+	// todayPattern and yesterdayPattern match postings dated by name rather than by count
+	todayPattern     = regexp.MustCompile(`(?i)\b(?:just\s+(?:posted|now)|today|heute|gerade\s+eben|aujourd'hui|hoy)\b`)
+	yesterdayPattern = regexp.MustCompile(`(?i)\b(?:yesterday|gestern|ayer)\b`)
+
+	// absoluteDatePattern finds a written date for the layouts in dateLayouts
+	absoluteDatePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}(?:T[\d:.]+(?:Z|[+-]\d{2}:?\d{2})?)?|\d{4}/\d{2}/\d{2}|\d{1,2}\.\d{1,2}\.\d{4}|(?i:[a-z]{3,9}\.?\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}|\d{1,2}(?:st|nd|rd|th)?\s+[a-z]{3,9}\.?,?\s+\d{4})`)
+
+	ordinalSuffix = regexp.MustCompile(`(?i)(\d)(?:st|nd|rd|th)\b`)
+)
+
+// dateLayouts are the absolute date formats job boards show; slashed dates other than
+// year-first are ambiguous between US and European order and are not read
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"2.1.2006",
+	"January 2 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// relativeUnits maps the units of relative dates, in every supported language, to the unit
+var relativeUnits = map[string]string{
+	"minute": "minute", "minutes": "minute", "min": "minute", "mins": "minute", "m": "minute",
+	"minuten": "minute", "minuto": "minute", "minutos": "minute",
+	"hour": "hour", "hours": "hour", "hr": "hour", "hrs": "hour", "h": "hour",
+	"stunde": "hour", "stunden": "hour", "heure": "hour", "heures": "hour", "hora": "hour", "horas": "hour",
+	"day": "day", "days": "day", "d": "day", "tag": "day", "tagen": "day",
+	"jour": "day", "jours": "day", "día": "day", "días": "day", "dia": "day", "dias": "day",
+	"week": "week", "weeks": "week", "wk": "week", "wks": "week", "w": "week",
+	"woche": "week", "wochen": "week", "semaine": "week", "semaines": "week", "semana": "week", "semanas": "week",
+	"month": "month", "months": "month", "mo": "month", "mos": "month",
+	"monat": "month", "monaten": "month", "mois": "month", "mes": "month", "meses": "month",
+	"year": "year", "years": "year", "yr": "year", "yrs": "year", "y": "year",
+	"jahr": "year", "jahren": "year", "an": "year", "ans": "year", "année": "year", "années": "year",
+	"año": "year", "años": "year", "ano": "year", "anos": "year",
+}
+
+// ParsePostedDate reads when a job was posted from text such as "Posted 3 days ago",
+// "vor 2 Tagen", "Just posted", "Yesterday", "2024-05-01" or "May 1, 2024", counting relative
+// dates back from now. It returns the zero time when text holds no date it can read.
+func ParsePostedDate(text string, now time.Time) time.Time {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}
+	}
+
+	for _, pattern := range relativeDatePatterns {
+		if match := pattern.FindStringSubmatch(text); match != nil {
+			if date, ok := relativeDate(match[1], match[2], now); ok {
+				return date
+			}
+		}
+	}
+
+	if match := absoluteDatePattern.FindString(text); match != "" {
+		if date, ok := absoluteDate(match); ok {
+			return date
+		}
+	}
+
+	if yesterdayPattern.MatchString(text) {
+		return now.AddDate(0, 0, -1)
+	}
+	if todayPattern.MatchString(text) {
+		return now
+	}
+	return time.Time{}
+}
+
+// relativeDate returns now moved back by count units
+func relativeDate(count, unit string, now time.Time) (time.Time, bool) {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		// "a", "an", "one", "einem", "une", "una" and the like
+		n = 1
+	}
+
+	switch relativeUnits[strings.ToLower(unit)] {
+	case "minute":
+		return now.Add(-time.Duration(n) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case "day":
+		return now.AddDate(0, 0, -n), true
+	case "week":
+		return now.AddDate(0, 0, -7*n), true
+	case "month":
+		return now.AddDate(0, -n, 0), true
+	case "year":
+		return now.AddDate(-n, 0, 0), true
+	}
+	return time.Time{}, false
+}
+
+// absoluteDate parses a date found by absoluteDatePattern. Numeric dates are read as written;
+// dates with a month name are read without their ordinals and punctuation, as in "May 1st, 2024".
+func absoluteDate(text string) (time.Time, bool) {
+	if date, ok := parseLayouts(text); ok {
+		return date, true
+	}
+	text = ordinalSuffix.ReplaceAllString(text, "$1")
+	text = strings.Join(strings.Fields(strings.NewReplacer(",", " ", ".", " ").Replace(text)), " ")
+	// Go knows September as "Sep" only
+	text = strings.NewReplacer("Sept ", "Sep ", "sept ", "sep ").Replace(text)
+	return parseLayouts(text)
+}
+
+// parseLayouts tries each of dateLayouts on text
+func parseLayouts(text string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, text); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// UsageWindow returns the start and end of the UTC month containing now when monthly, or of
+// its UTC day otherwise, the windows usage counters are kept for
+func UsageWindow(now time.Time, monthly bool) (time.Time, time.Time) {
+	now = now.UTC()
+	if monthly {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// UsageWindowStamp formats the start of a usage window for counter keys, e.g. 20260101 for a
+// day or 202601 for a month
+func UsageWindowStamp(start time.Time, monthly bool) string {
+	if monthly {
+		return start.Format("200601")
+	}
+	return start.Format("20060102")
+}
@@ -1,15 +1,59 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"time"
+)
+
+// ErrorCode is a stable, machine-readable error identifier shared by task results,
+// HTTP responses, gRPC responses and callbacks so clients can branch without string matching
+type ErrorCode string
+
+const (
+	// Request errors
+	ErrCodeBadRequest       ErrorCode = "BAD_REQUEST"
+	ErrCodeNotFound         ErrorCode = "NOT_FOUND"
+	ErrCodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	ErrCodeConfiguration    ErrorCode = "CONFIGURATION_ERROR"
+	ErrCodeUnauthorized     ErrorCode = "UNAUTHORIZED"
+
+	// Scraping errors
+	ErrCodeNotJobPosting   ErrorCode = "NOT_JOB_POSTING"
+	ErrCodeCaptchaUnsolved ErrorCode = "CAPTCHA_UNSOLVED"
+	ErrCodeScrapingFailed  ErrorCode = "SCRAPING_FAILED"
+	ErrCodeEngineTimeout   ErrorCode = "ENGINE_TIMEOUT"
+	ErrCodePolicyDenied    ErrorCode = "POLICY_DENIED"
+
+	// LLM errors
+	ErrCodeLLMFailed      ErrorCode = "LLM_FAILED"
+	ErrCodeLLMParseFailed ErrorCode = "LLM_PARSE_FAILED"
+	ErrCodeLLMUnavailable ErrorCode = "LLM_UNAVAILABLE"
+
+	// Capacity and lifecycle errors
+	ErrCodeRateLimited          ErrorCode = "RATE_LIMITED"
+	ErrCodeQueueFull            ErrorCode = "QUEUE_FULL"
+	ErrCodeQuotaExceeded        ErrorCode = "QUOTA_EXCEEDED"
+	ErrCodeTimeout              ErrorCode = "TIMEOUT"
+	ErrCodeTaskSubmissionFailed ErrorCode = "TASK_SUBMISSION_FAILED"
+	ErrCodeDraining             ErrorCode = "DRAINING"
+	ErrCodeInternal             ErrorCode = "INTERNAL"
 )
 
 // CustomError represents a custom application error
 type CustomError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Detail  string `json:"detail,omitempty"`
+	Code      int       `json:"code"` // HTTP status code
+	ErrorCode ErrorCode `json:"error_code"`
+	Message   string    `json:"message"`
+	Detail    string    `json:"detail,omitempty"`
+
+	// RetryAfter suggests when a rejected request may be retried (capacity errors only)
+	RetryAfter time.Duration `json:"-"`
+
+	// Reason is the rule that refused a scrape (policy errors only), such as "robots_txt"
+	Reason string `json:"reason,omitempty"`
 }
 
 func (e *CustomError) Error() string {
@@ -19,67 +63,226 @@ func (e *CustomError) Error() string {
 	return e.Message
 }
 
+// AsCustomError returns the first CustomError in err's chain
+func AsCustomError(err error) (*CustomError, bool) {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr, true
+	}
+	return nil, false
+}
+
+// GetErrorCode returns the taxonomy code for err, or ErrCodeInternal for untyped errors
+func GetErrorCode(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	if customErr, ok := AsCustomError(err); ok && customErr.ErrorCode != "" {
+		return customErr.ErrorCode
+	}
+	return ErrCodeInternal
+}
+
+// IsErrorCode reports whether err carries the given taxonomy code
+func IsErrorCode(err error, code ErrorCode) bool {
+	return err != nil && GetErrorCode(err) == code
+}
+
+// GetHTTPStatus returns the HTTP status for err, or 500 for untyped errors
+func GetHTTPStatus(err error) int {
+	if customErr, ok := AsCustomError(err); ok && customErr.Code != 0 {
+		return customErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
 // Common error constructors
 func NewBadRequestError(message string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusBadRequest,
-		Message: message,
+		Code:      http.StatusBadRequest,
+		ErrorCode: ErrCodeBadRequest,
+		Message:   message,
 	}
 }
 
 func NewInternalServerError(message string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusInternalServerError,
-		Message: message,
+		Code:      http.StatusInternalServerError,
+		ErrorCode: ErrCodeInternal,
+		Message:   message,
 	}
 }
 
 func NewTimeoutError(message string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusRequestTimeout,
-		Message: message,
+		Code:      http.StatusRequestTimeout,
+		ErrorCode: ErrCodeTimeout,
+		Message:   message,
 	}
 }
 
 func NewValidationError(detail string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusBadRequest,
-		Message: "Validation failed",
-		Detail:  detail,
+		Code:      http.StatusBadRequest,
+		ErrorCode: ErrCodeValidationFailed,
+		Message:   "Validation failed",
+		Detail:    detail,
+	}
+}
+
+// NewRateLimitedError returns an error when a local or upstream rate limit rejects the request
+func NewRateLimitedError(detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusTooManyRequests,
+		ErrorCode: ErrCodeRateLimited,
+		Message:   "Rate limit exceeded",
+		Detail:    detail,
+	}
+}
+
+// NewQueueFullError returns an error when a job or task queue has no room, with a suggested
+// retry delay based on how fast the queue is draining
+func NewQueueFullError(detail string, retryAfter time.Duration) *CustomError {
+	return &CustomError{
+		Code:       http.StatusTooManyRequests,
+		ErrorCode:  ErrCodeQueueFull,
+		Message:    "Queue is full",
+		Detail:     detail,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewQuotaExceededError returns an error when an API key has used up its quota, with the time
+// until the quota period resets
+func NewQuotaExceededError(detail string, retryAfter time.Duration) *CustomError {
+	return &CustomError{
+		Code:       http.StatusTooManyRequests,
+		ErrorCode:  ErrCodeQuotaExceeded,
+		Message:    "Quota exceeded",
+		Detail:     detail,
+		RetryAfter: retryAfter,
 	}
 }
 
+// NewDrainingError returns an error when an instance that is draining for shutdown refuses new
+// work, with a suggested retry delay; another replica can take the request
+func NewDrainingError(detail string, retryAfter time.Duration) *CustomError {
+	return &CustomError{
+		Code:       http.StatusServiceUnavailable,
+		ErrorCode:  ErrCodeDraining,
+		Message:    "Service is draining",
+		Detail:     detail,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewUnauthorizedError returns an error when a call carries a missing or unknown API key
+func NewUnauthorizedError(detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusUnauthorized,
+		ErrorCode: ErrCodeUnauthorized,
+		Message:   "Unauthorized",
+		Detail:    detail,
+	}
+}
+
+// WithRetryAfter sets the suggested retry delay and returns the error
+func (e *CustomError) WithRetryAfter(retryAfter time.Duration) *CustomError {
+	e.RetryAfter = retryAfter
+	return e
+}
+
+// GetRetryAfter returns the suggested retry delay carried by err, or zero
+func GetRetryAfter(err error) time.Duration {
+	if customErr, ok := AsCustomError(err); ok {
+		return customErr.RetryAfter
+	}
+	return 0
+}
+
+// RetryAfterSeconds converts a retry delay to whole seconds for a Retry-After header, rounding
+// up and never returning less than one
+func RetryAfterSeconds(retryAfter time.Duration) int {
+	return int(math.Max(1, math.Ceil(retryAfter.Seconds())))
+}
+
 // Scraping specific errors
 func NewScrapingError(detail string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusUnprocessableEntity,
-		Message: "Scraping failed",
-		Detail:  detail,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeScrapingFailed,
+		Message:   "Scraping failed",
+		Detail:    detail,
+	}
+}
+
+// NewPolicyDeniedError returns an error when the scraping policy forbids scraping a URL; reason
+// names the rule that refused it
+func NewPolicyDeniedError(reason, detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusForbidden,
+		ErrorCode: ErrCodePolicyDenied,
+		Message:   "Scraping not permitted by policy",
+		Detail:    detail,
+		Reason:    reason,
+	}
+}
+
+// NewEngineTimeoutError returns an error when a scraping engine does not finish in time
+func NewEngineTimeoutError(detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusGatewayTimeout,
+		ErrorCode: ErrCodeEngineTimeout,
+		Message:   "Scraping engine timed out",
+		Detail:    detail,
 	}
 }
 
 func NewLLMError(detail string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusBadGateway,
-		Message: "LLM processing failed",
-		Detail:  detail,
+		Code:      http.StatusBadGateway,
+		ErrorCode: ErrCodeLLMFailed,
+		Message:   "LLM processing failed",
+		Detail:    detail,
+	}
+}
+
+// NewLLMParseError returns an error when an LLM response cannot be parsed into the expected structure
+func NewLLMParseError(detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusBadGateway,
+		ErrorCode: ErrCodeLLMParseFailed,
+		Message:   "Failed to parse LLM response",
+		Detail:    detail,
+	}
+}
+
+// NewLLMUnavailableError returns an error when no healthy LLM provider is available
+func NewLLMUnavailableError(detail string) *CustomError {
+	return &CustomError{
+		Code:      http.StatusServiceUnavailable,
+		ErrorCode: ErrCodeLLMUnavailable,
+		Message:   "LLM provider unavailable",
+		Detail:    detail,
 	}
 }
 
 // NewNotJobPostingError returns an error when the URL doesn't contain a job posting
 func NewNotJobPostingError(detail string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusUnprocessableEntity,
-		Message: "Content is not a job posting",
-		Detail:  detail,
+		Code:      http.StatusUnprocessableEntity,
+		ErrorCode: ErrCodeNotJobPosting,
+		Message:   "Content is not a job posting",
+		Detail:    detail,
 	}
 }
 
 // NewCaptchaDetectedError returns an error when a captcha is detected and should trigger fallback
 func NewCaptchaDetectedError(detail string) *CustomError {
 	return &CustomError{
-		Code:    http.StatusTemporaryRedirect, // 307 - indicates should retry with different method
-		Message: "Captcha detected - fallback required",
-		Detail:  detail,
+		Code:      http.StatusTemporaryRedirect, // 307 - indicates should retry with different method
+		ErrorCode: ErrCodeCaptchaUnsolved,
+		Message:   "Captcha detected - fallback required",
+		Detail:    detail,
 	}
 }
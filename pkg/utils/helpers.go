@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	neturl "net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -56,6 +57,30 @@ func FindRegexMatch(text, pattern string) []string {
 	return re.FindStringSubmatch(text)
 }
 
+// CanonicalURL normalizes a URL so links to the same page compare equal: the scheme and host
+// are lowercased, and the fragment, utm_ tracking parameters and a bare trailing slash dropped
+func CanonicalURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	if u.Path == "/" {
+		u.Path = ""
+	}
+	return u.String()
+}
+
 // ExtractDomainFromURL extracts the domain from a URL
 func ExtractDomainFromURL(url string) string {
 	// Remove protocol
@@ -91,6 +116,16 @@ func GenerateScrapeProcessID() string {
 	return GenerateProcessIDWithPrefix("scrape")
 }
 
+// GenerateBatchScrapeProcessID generates a unique process ID for batch scrape tasks
+func GenerateBatchScrapeProcessID() string {
+	return GenerateProcessIDWithPrefix("batch")
+}
+
+// GenerateCrawlProcessID generates a unique process ID for career-page crawl tasks
+func GenerateCrawlProcessID() string {
+	return GenerateProcessIDWithPrefix("crawl")
+}
+
 // GenerateTailorProcessID generates a unique process ID for tailor tasks
 func GenerateTailorProcessID() string {
 	return GenerateProcessIDWithPrefix("tailor")
@@ -101,6 +136,11 @@ func GenerateScreenshotProcessID() string {
 	return GenerateProcessIDWithPrefix("screenshot")
 }
 
+// GenerateInterviewProcessID generates a unique process ID for interview questions tasks
+func GenerateInterviewProcessID() string {
+	return GenerateProcessIDWithPrefix("interview")
+}
+
 // IsValidProcessID validates if a string is a valid process ID format
 func IsValidProcessID(processID string) bool {
 	if processID == "" {
@@ -2,8 +2,12 @@ package utils
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -12,33 +16,107 @@ import (
 	"letraz-utils/internal/logging"
 )
 
-// RedisClient wraps the Redis client with conversation history management
+// RedisClient wraps the shared, pooled Redis client
 type RedisClient struct {
-	client *redis.Client
+	client redis.UniversalClient
 	config *config.Config
 	logger logging.Logger
 }
 
-// ConversationEntry represents a single conversation entry
-type ConversationEntry struct {
-	ID        string                 `json:"id"`
-	Role      string                 `json:"role"` // "user" or "assistant"
-	Content   string                 `json:"content"`
-	Timestamp time.Time              `json:"timestamp"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+// Redis deployment modes
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// NewRedisClient creates a new Redis client instance for the configured deployment mode
+func NewRedisClient(cfg *config.Config) *RedisClient {
+	logger := logging.GetGlobalLogger()
+
+	client, err := newUniversalClient(cfg)
+	if err != nil {
+		logger.Error("Invalid Redis configuration, falling back to standalone client", map[string]interface{}{
+			"mode":  cfg.Redis.Mode,
+			"error": err.Error(),
+		})
+		client = redis.NewClient(standaloneOptions(cfg))
+	}
+
+	return &RedisClient{
+		client: client,
+		config: cfg,
+		logger: logger,
+	}
 }
 
-// ConversationHistory represents the complete conversation history for a resume
-type ConversationHistory struct {
-	ThreadID  string              `json:"thread_id"`
-	ResumeID  string              `json:"resume_id"`
-	Entries   []ConversationEntry `json:"entries"`
-	CreatedAt time.Time           `json:"created_at"`
-	UpdatedAt time.Time           `json:"updated_at"`
+// newUniversalClient builds a standalone, sentinel (failover) or cluster client from configuration
+func newUniversalClient(cfg *config.Config) (redis.UniversalClient, error) {
+	tlsConfig, err := redisTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Redis.Mode {
+	case "", RedisModeStandalone:
+		opts := standaloneOptions(cfg)
+		if tlsConfig != nil {
+			opts.TLSConfig = tlsConfig
+		}
+		return redis.NewClient(opts), nil
+
+	case RedisModeSentinel:
+		if cfg.Redis.MasterName == "" {
+			return nil, fmt.Errorf("redis sentinel mode requires a master name")
+		}
+		if len(cfg.Redis.Addresses) == 0 {
+			return nil, fmt.Errorf("redis sentinel mode requires at least one sentinel address")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Redis.MasterName,
+			SentinelAddrs:    cfg.Redis.Addresses,
+			SentinelUsername: cfg.Redis.SentinelUsername,
+			SentinelPassword: cfg.Redis.SentinelPassword,
+			Username:         cfg.Redis.Username,
+			Password:         cfg.Redis.Password,
+			DB:               cfg.Redis.DB,
+			DialTimeout:      cfg.Redis.Timeout,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			PoolSize:         cfg.Redis.PoolSize,
+			MinIdleConns:     cfg.Redis.MinIdleConns,
+			PoolTimeout:      cfg.Redis.PoolTimeout,
+			MaxRetries:       cfg.Redis.MaxRetries,
+			MaxRetryBackoff:  cfg.Redis.MaxRetryBackoff,
+			TLSConfig:        tlsConfig,
+		}), nil
+
+	case RedisModeCluster:
+		if len(cfg.Redis.Addresses) == 0 {
+			return nil, fmt.Errorf("redis cluster mode requires at least one node address")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           cfg.Redis.Addresses,
+			Username:        cfg.Redis.Username,
+			Password:        cfg.Redis.Password,
+			DialTimeout:     cfg.Redis.Timeout,
+			ReadTimeout:     3 * time.Second,
+			WriteTimeout:    3 * time.Second,
+			PoolSize:        cfg.Redis.PoolSize,
+			MinIdleConns:    cfg.Redis.MinIdleConns,
+			PoolTimeout:     cfg.Redis.PoolTimeout,
+			MaxRetries:      cfg.Redis.MaxRetries,
+			MaxRetryBackoff: cfg.Redis.MaxRetryBackoff,
+			TLSConfig:       tlsConfig,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported redis mode: %s", cfg.Redis.Mode)
+	}
 }
 
-// NewRedisClient creates a new Redis client instance
-func NewRedisClient(cfg *config.Config) *RedisClient {
+// standaloneOptions parses the Redis URL, applying explicit credentials and timeouts from configuration
+func standaloneOptions(cfg *config.Config) *redis.Options {
 	// Parse Redis URL
 	opts, err := redis.ParseURL(cfg.Redis.URL)
 	if err != nil {
@@ -50,159 +128,145 @@ func NewRedisClient(cfg *config.Config) *RedisClient {
 		}
 	}
 
+	if cfg.Redis.Username != "" {
+		opts.Username = cfg.Redis.Username
+	}
+	if cfg.Redis.Password != "" {
+		opts.Password = cfg.Redis.Password
+	}
+	if cfg.Redis.DB != 0 {
+		opts.DB = cfg.Redis.DB
+	}
+
 	// Configure timeouts
 	opts.DialTimeout = 5 * time.Second
+	if cfg.Redis.Timeout > 0 {
+		opts.DialTimeout = cfg.Redis.Timeout
+	}
 	opts.ReadTimeout = 3 * time.Second
 	opts.WriteTimeout = 3 * time.Second
 
-	client := redis.NewClient(opts)
-
-	return &RedisClient{
-		client: client,
-		config: cfg,
-		logger: logging.GetGlobalLogger(),
-	}
-}
-
-// Ping tests the Redis connection
-func (r *RedisClient) Ping(ctx context.Context) error {
-	return r.client.Ping(ctx).Err()
-}
+	// Configure pooling and reconnect backoff
+	opts.PoolSize = cfg.Redis.PoolSize
+	opts.MinIdleConns = cfg.Redis.MinIdleConns
+	opts.PoolTimeout = cfg.Redis.PoolTimeout
+	opts.MaxRetries = cfg.Redis.MaxRetries
+	opts.MaxRetryBackoff = cfg.Redis.MaxRetryBackoff
 
-// Close closes the Redis connection
-func (r *RedisClient) Close() error {
-	return r.client.Close()
+	return opts
 }
 
-// CreateConversationThread creates a new conversation thread for a resume
-func (r *RedisClient) CreateConversationThread(ctx context.Context, resumeID string) error {
-	threadKey := r.getThreadKey(resumeID)
+// redisTLSConfig returns the TLS configuration for Redis connections, or nil when TLS is disabled
+func redisTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if !cfg.Redis.TLS.Enabled {
+		return nil, nil
+	}
 
-	// Check if thread already exists
-	exists, err := r.client.Exists(ctx, threadKey).Result()
-	if err != nil {
-		return fmt.Errorf("failed to check if thread exists: %w", err)
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.Redis.TLS.ServerName,
+		InsecureSkipVerify: cfg.Redis.TLS.InsecureSkipVerify,
 	}
 
-	if exists > 0 {
-		// Thread already exists, just update the timestamp
-		history, err := r.GetConversationHistory(ctx, resumeID)
+	if cfg.Redis.TLS.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.Redis.TLS.CAFile)
 		if err != nil {
-			return fmt.Errorf("failed to get existing conversation history: %w", err)
+			return nil, fmt.Errorf("failed to read redis CA file: %w", err)
 		}
-		history.UpdatedAt = time.Now()
-
-		historyJSON, err := json.Marshal(history)
-		if err != nil {
-			return fmt.Errorf("failed to marshal conversation history: %w", err)
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid certificates found in redis CA file %s", cfg.Redis.TLS.CAFile)
 		}
-
-		err = r.client.Set(ctx, threadKey, historyJSON, 24*time.Hour).Err()
-		if err != nil {
-			return fmt.Errorf("failed to update conversation thread: %w", err)
-		}
-		return nil
-	}
-
-	// Create new thread
-	history := &ConversationHistory{
-		ThreadID:  GenerateRequestID(),
-		ResumeID:  resumeID,
-		Entries:   []ConversationEntry{},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		tlsConfig.RootCAs = pool
 	}
 
-	historyJSON, err := json.Marshal(history)
-	if err != nil {
-		return fmt.Errorf("failed to marshal conversation history: %w", err)
-	}
+	return tlsConfig, nil
+}
 
-	// Store with 24-hour expiration
-	err = r.client.Set(ctx, threadKey, historyJSON, 24*time.Hour).Err()
-	if err != nil {
-		return fmt.Errorf("failed to create conversation thread: %w", err)
-	}
+// Client returns the underlying pooled client for components that need raw Redis commands
+func (r *RedisClient) Client() redis.UniversalClient {
+	return r.client
+}
 
-	return nil
+// Ping tests the Redis connection
+func (r *RedisClient) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
 }
 
-// AddConversationEntry adds a new entry to the conversation history
-func (r *RedisClient) AddConversationEntry(ctx context.Context, resumeID string, entry ConversationEntry) error {
-	// Get current history
-	history, err := r.GetConversationHistory(ctx, resumeID)
-	if err != nil {
-		// If thread doesn't exist, create it first
-		if err := r.CreateConversationThread(ctx, resumeID); err != nil {
-			return fmt.Errorf("failed to create conversation thread: %w", err)
-		}
-		history, err = r.GetConversationHistory(ctx, resumeID)
-		if err != nil {
-			return fmt.Errorf("failed to get conversation history after creation: %w", err)
-		}
-	}
+// Close closes the Redis connection
+func (r *RedisClient) Close() error {
+	return r.client.Close()
+}
 
-	// Add new entry
-	entry.ID = GenerateRequestID()
-	entry.Timestamp = time.Now()
-	history.Entries = append(history.Entries, entry)
-	history.UpdatedAt = time.Now()
+// Health pings Redis with a short timeout; the pool reconnects transparently once Redis is reachable again
+func (r *RedisClient) Health() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return r.Ping(ctx)
+}
 
-	// Keep only last 50 entries to manage memory
-	if len(history.Entries) > 50 {
-		history.Entries = history.Entries[len(history.Entries)-50:]
+// HealthDetails pings Redis and reports latency and memory pressure. It fails when Redis is
+// unreachable or memory use exceeds maxMemoryRatio of the configured maxmemory.
+func (r *RedisClient) HealthDetails(ctx context.Context, maxMemoryRatio float64) (map[string]interface{}, error) {
+	details := map[string]interface{}{
+		"mode": r.config.Redis.Mode,
 	}
 
-	// Save updated history
-	threadKey := r.getThreadKey(resumeID)
-	historyJSON, err := json.Marshal(history)
-	if err != nil {
-		return fmt.Errorf("failed to marshal conversation history: %w", err)
+	start := time.Now()
+	if err := r.Ping(ctx); err != nil {
+		return details, fmt.Errorf("redis ping failed: %w", err)
 	}
+	details["ping_latency_ms"] = float64(time.Since(start).Microseconds()) / 1000
 
-	err = r.client.Set(ctx, threadKey, historyJSON, 24*time.Hour).Err()
+	info, err := r.client.Info(ctx, "memory").Result()
 	if err != nil {
-		r.logger.Error("Failed to save conversation entry", map[string]interface{}{
-			"resume_id": resumeID,
-			"entry_id":  entry.ID,
-			"error":     err.Error(),
-		})
-		return fmt.Errorf("failed to save conversation entry: %w", err)
+		// Memory stats are informational; connectivity is what matters most
+		details["memory_error"] = err.Error()
+		return details, nil
 	}
 
-	return nil
-}
-
-// GetConversationHistory retrieves the conversation history for a resume
-func (r *RedisClient) GetConversationHistory(ctx context.Context, resumeID string) (*ConversationHistory, error) {
-	threadKey := r.getThreadKey(resumeID)
-
-	historyJSON, err := r.client.Get(ctx, threadKey).Result()
-	if err != nil {
-		if err == redis.Nil {
-			return nil, fmt.Errorf("conversation thread not found for resume %s", resumeID)
+	usedMemory := parseRedisInfoInt(info, "used_memory")
+	maxMemory := parseRedisInfoInt(info, "maxmemory")
+	details["used_memory_bytes"] = usedMemory
+	details["max_memory_bytes"] = maxMemory
+
+	// maxmemory of 0 means unlimited, so there is no ratio to enforce
+	if maxMemory > 0 {
+		ratio := float64(usedMemory) / float64(maxMemory)
+		details["memory_usage_ratio"] = ratio
+		if maxMemoryRatio > 0 && ratio >= maxMemoryRatio {
+			return details, fmt.Errorf("redis memory usage at %.0f%% of maxmemory", ratio*100)
 		}
-		return nil, fmt.Errorf("failed to get conversation history: %w", err)
-	}
-
-	var history ConversationHistory
-	err = json.Unmarshal([]byte(historyJSON), &history)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal conversation history: %w", err)
 	}
 
-	return &history, nil
+	return details, nil
 }
 
-// DeleteConversationThread deletes the conversation thread for a resume
-func (r *RedisClient) DeleteConversationThread(ctx context.Context, resumeID string) error {
-	threadKey := r.getThreadKey(resumeID)
-	return r.client.Del(ctx, threadKey).Err()
+// parseRedisInfoInt extracts an integer field from an INFO response
+func parseRedisInfoInt(info, field string) int64 {
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if value, found := strings.CutPrefix(line, field+":"); found {
+			n, _ := strconv.ParseInt(value, 10, 64)
+			return n
+		}
+	}
+	return 0
 }
 
-// getThreadKey generates the Redis key for a conversation thread
-func (r *RedisClient) getThreadKey(resumeID string) string {
-	return fmt.Sprintf("conversation:resume:%s", resumeID)
+// GetStats returns connection pool statistics
+func (r *RedisClient) GetStats() map[string]interface{} {
+	poolStats := r.client.PoolStats()
+	return map[string]interface{}{
+		"mode":           r.config.Redis.Mode,
+		"pool_size":      r.config.Redis.PoolSize,
+		"hits_total":     poolStats.Hits,
+		"misses_total":   poolStats.Misses,
+		"timeouts_total": poolStats.Timeouts,
+		"total_conns":    poolStats.TotalConns,
+		"idle_conns":     poolStats.IdleConns,
+		"stale_conns":    poolStats.StaleConns,
+	}
 }
 
 // IsHealthy checks if Redis is connected and healthy
@@ -2,11 +2,16 @@ package utils
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -337,3 +342,78 @@ func (sc *SpacesClient) DeleteExportObject(resumeID string, fileName string) err
 
 	return nil
 }
+
+// PutObject uploads a private object, readable only with the bucket's credentials
+func (sc *SpacesClient) PutObject(ctx context.Context, objectKey string, data []byte, contentType string) error {
+	_, err := sc.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(sc.bucketName),
+		Key:         aws.String(objectKey),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+		ACL:         aws.String("private"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	return nil
+}
+
+// GetObject downloads an object, or returns ErrObjectNotFound
+func (sc *SpacesClient) GetObject(ctx context.Context, objectKey string) ([]byte, error) {
+	output, err := sc.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(sc.bucketName),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteObjectsBefore removes the objects under prefix last modified before cutoff and returns
+// how many were removed
+func (sc *SpacesClient) DeleteObjectsBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error) {
+	var expired []*s3.ObjectIdentifier
+	err := sc.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(sc.bucketName),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			if obj.LastModified != nil && obj.LastModified.Before(cutoff) {
+				expired = append(expired, &s3.ObjectIdentifier{Key: obj.Key})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	// A delete request takes at most 1000 keys
+	deleted := 0
+	for start := 0; start < len(expired); start += 1000 {
+		end := start + 1000
+		if end > len(expired) {
+			end = len(expired)
+		}
+		output, err := sc.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(sc.bucketName),
+			Delete: &s3.Delete{Objects: expired[start:end], Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete objects: %w", err)
+		}
+		deleted += end - start - len(output.Errors)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"letraz-utils/internal/config"
+	"letraz-utils/internal/logging"
+	"letraz-utils/internal/logging/types"
+)
+
+// ObjectStorage stores resume screenshots and export artifacts and returns their public URLs
+type ObjectStorage interface {
+	UploadScreenshot(resumeID string, imageData []byte) (string, error)
+	UploadLatexExport(resumeID string, fileName string, latexData []byte) (string, error)
+	UploadPDFExport(resumeID string, fileName string, pdfData []byte) (string, error)
+	DeleteExportObject(resumeID string, fileName string) error
+	IsHealthy() bool
+}
+
+// ErrObjectNotFound is returned when a private object does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// PrivateObjects stores objects that are read back by this service rather than served to users,
+// such as archived scrapes
+type PrivateObjects interface {
+	PutObject(ctx context.Context, objectKey string, data []byte, contentType string) error
+	GetObject(ctx context.Context, objectKey string) ([]byte, error)
+	DeleteObjectsBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error)
+}
+
+// NewPrivateObjects returns in-memory storage in test mode and DigitalOcean Spaces otherwise
+func NewPrivateObjects(cfg *config.Config) (PrivateObjects, error) {
+	if cfg.TestMode.Enabled {
+		return NewMemoryStorage(cfg), nil
+	}
+	return NewSpacesClient(cfg)
+}
+
+// NewObjectStorage returns in-memory storage in test mode and DigitalOcean Spaces otherwise
+func NewObjectStorage(cfg *config.Config) (ObjectStorage, error) {
+	if cfg.TestMode.Enabled {
+		return NewMemoryStorage(cfg), nil
+	}
+	return NewSpacesClient(cfg)
+}
+
+// MemoryStoragePath is the route under which the server serves objects held in memory storage
+const MemoryStoragePath = "/test-storage"
+
+// StoredObject is an object held in memory storage
+type StoredObject struct {
+	Data        []byte
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// memoryObjects is shared by all MemoryStorage instances so an uploaded object stays readable
+// after the client that stored it is gone
+var (
+	memoryObjects   = make(map[string]StoredObject)
+	memoryObjectsMu sync.RWMutex
+)
+
+// MemoryStorage keeps objects in process memory and serves them from this service. It stands
+// in for Spaces in test mode so screenshots and exports work without credentials.
+type MemoryStorage struct {
+	baseURL string
+	logger  types.Logger
+}
+
+// NewMemoryStorage creates an in-memory object storage whose URLs point at this service
+func NewMemoryStorage(cfg *config.Config) *MemoryStorage {
+	return &MemoryStorage{
+		baseURL: fmt.Sprintf("http://localhost:%d%s", cfg.Server.Port, MemoryStoragePath),
+		logger:  logging.GetGlobalLogger(),
+	}
+}
+
+// UploadScreenshot stores a screenshot under resumes/thumbnails/<resumeID>.jpg
+func (ms *MemoryStorage) UploadScreenshot(resumeID string, imageData []byte) (string, error) {
+	return ms.put(fmt.Sprintf("resumes/thumbnails/%s.jpg", resumeID), imageData, "image/jpeg"), nil
+}
+
+// UploadLatexExport stores a LaTeX export under exports/<resumeID>/<fileName>.tex
+func (ms *MemoryStorage) UploadLatexExport(resumeID string, fileName string, latexData []byte) (string, error) {
+	return ms.putExport(resumeID, fileName, latexData, "application/x-tex", ".tex")
+}
+
+// UploadPDFExport stores a PDF export under exports/<resumeID>/<fileName>.pdf
+func (ms *MemoryStorage) UploadPDFExport(resumeID string, fileName string, pdfData []byte) (string, error) {
+	return ms.putExport(resumeID, fileName, pdfData, "application/pdf", ".pdf")
+}
+
+// DeleteExportObject removes an export artifact
+func (ms *MemoryStorage) DeleteExportObject(resumeID string, fileName string) error {
+	if resumeID == "" {
+		return fmt.Errorf("resumeID is required")
+	}
+	if strings.TrimSpace(fileName) == "" {
+		return fmt.Errorf("fileName is required")
+	}
+
+	memoryObjectsMu.Lock()
+	delete(memoryObjects, fmt.Sprintf("exports/%s/%s", resumeID, filepath.Base(strings.TrimSpace(fileName))))
+	memoryObjectsMu.Unlock()
+	return nil
+}
+
+// IsHealthy always reports true since memory storage has no dependencies
+func (ms *MemoryStorage) IsHealthy() bool {
+	return true
+}
+
+// putExport validates an export artifact the same way Spaces uploads do and stores it
+func (ms *MemoryStorage) putExport(resumeID string, fileName string, data []byte, contentType string, ext string) (string, error) {
+	if resumeID == "" {
+		return "", fmt.Errorf("resumeID is required")
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("data is empty")
+	}
+
+	fileName = filepath.Base(strings.TrimSpace(fileName))
+	if fileName == "." || fileName == "" {
+		fileName = uuid.New().String() + ext
+	}
+	if !strings.HasSuffix(strings.ToLower(fileName), ext) {
+		fileName += ext
+	}
+
+	return ms.put(fmt.Sprintf("exports/%s/%s", resumeID, fileName), data, contentType), nil
+}
+
+// put stores an object and returns its URL
+func (ms *MemoryStorage) put(objectKey string, data []byte, contentType string) string {
+	memoryObjectsMu.Lock()
+	memoryObjects[objectKey] = StoredObject{Data: data, ContentType: contentType, ModifiedAt: time.Now()}
+	memoryObjectsMu.Unlock()
+
+	objectURL := ms.baseURL + "/" + objectKey
+	ms.logger.Info("Object stored in memory storage", map[string]interface{}{
+		"object_key": objectKey,
+		"size_bytes": len(data),
+		"url":        objectURL,
+	})
+	return objectURL
+}
+
+// PutObject stores a private object; memory storage serves every object, so it is only private
+// in that its URL is not handed out
+func (ms *MemoryStorage) PutObject(ctx context.Context, objectKey string, data []byte, contentType string) error {
+	ms.put(objectKey, data, contentType)
+	return nil
+}
+
+// GetObject returns the content of an object, or ErrObjectNotFound
+func (ms *MemoryStorage) GetObject(ctx context.Context, objectKey string) ([]byte, error) {
+	object, exists := GetMemoryObject(objectKey)
+	if !exists {
+		return nil, ErrObjectNotFound
+	}
+	return object.Data, nil
+}
+
+// DeleteObjectsBefore removes the objects under prefix last modified before cutoff
+func (ms *MemoryStorage) DeleteObjectsBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error) {
+	memoryObjectsMu.Lock()
+	defer memoryObjectsMu.Unlock()
+
+	deleted := 0
+	for objectKey, object := range memoryObjects {
+		if strings.HasPrefix(objectKey, prefix) && object.ModifiedAt.Before(cutoff) {
+			delete(memoryObjects, objectKey)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// GetMemoryObject returns an object held in memory storage
+func GetMemoryObject(objectKey string) (StoredObject, bool) {
+	memoryObjectsMu.RLock()
+	defer memoryObjectsMu.RUnlock()
+
+	object, exists := memoryObjects[objectKey]
+	return object, exists
+}